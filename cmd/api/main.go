@@ -15,7 +15,12 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"time"
+	// The competitions are in IANA time zones, which must resolve even without tzdata on the host
+	_ "time/tzdata"
 
 	"github.com/NiskuT/cross-api/internal/config"
 	"github.com/NiskuT/cross-api/internal/repository"
@@ -43,7 +48,63 @@ func main() {
 		Run:     runRestServer,
 	}
 
+	pruneRolesCmd := &cobra.Command{
+		Use:   "prune-roles",
+		Short: "Prune the roles of past competitions",
+		Long:  `This command removes the referee roles of competitions older than ROLES_RETENTION_MONTHS and reports the users close to the roles size limit, it is meant to be run periodically`,
+		Run:   runPruneRoles,
+	}
+
+	archiveCmd := &cobra.Command{
+		Use:   "archive <competition-id>",
+		Short: "Export a competition to an archive file",
+		Long:  `This command writes the competition with its scales, participants, runs and contacts to a versioned archive (JSON lines and a manifest in a gzipped tarball) meant for long-term storage`,
+		Args:  cobra.ExactArgs(1),
+		Run:   runArchive,
+	}
+	archiveCmd.Flags().StringP("output", "o", "", "path of the archive, defaults to competition-<id>.tar.gz")
+
+	unarchiveCmd := &cobra.Command{
+		Use:   "unarchive <file>",
+		Short: "Recreate a competition from an archive file",
+		Long:  `This command migrates the database schema then recreates the archived competition as a new competition and rebuilds its ranking`,
+		Args:  cobra.ExactArgs(1),
+		Run:   runUnarchive,
+	}
+	unarchiveCmd.Flags().String("name", "", "name of the restored competition, defaults to the archived name")
+	unarchiveCmd.Flags().String("admin", "", "email of an existing user given the admin role of the restored competition")
+
+	grantSuperAdminCmd := &cobra.Command{
+		Use:   "grant-superadmin <email>",
+		Short: "Give the super admin role to a user",
+		Long:  `This command gives the superadmin role to an existing user, super admins can access every competition and the /admin endpoints`,
+		Args:  cobra.ExactArgs(1),
+		Run:   runGrantSuperAdmin,
+	}
+
+	migrateRolesCmd := &cobra.Command{
+		Use:   "migrate-roles",
+		Short: "Move the legacy comma-separated roles to the user_roles table",
+		Long:  `This command moves the roles of the users.roles column to the user_roles table, keeping a copy in user_roles_backup, then verifies that every legacy role was moved. The migration also runs when the server starts. With --rollback, the roles are written back to the users.roles column before downgrading to a version predating user_roles`,
+		Run:   runMigrateRoles,
+	}
+	migrateRolesCmd.Flags().Bool("verify", false, "only verify the migration, without migrating")
+	migrateRolesCmd.Flags().Bool("rollback", false, "write the roles back to the legacy column and empty the user_roles table")
+
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the configuration and the services the server depends on",
+		Long:  `This command validates the configuration, then checks the database connection and schema, the email server, the JWT keys and the storage, and prints a pass/fail report. It does not modify anything and is meant to be run on the production server before an event`,
+		Run:   runDoctor,
+	}
+
 	app.AddCommand(restCmd)
+	app.AddCommand(pruneRolesCmd)
+	app.AddCommand(migrateRolesCmd)
+	app.AddCommand(archiveCmd)
+	app.AddCommand(grantSuperAdminCmd)
+	app.AddCommand(unarchiveCmd)
+	app.AddCommand(doctorCmd)
 
 	if err := app.Execute(); err != nil {
 		log.Fatal()
@@ -66,7 +127,9 @@ func runRestServer(_ *cobra.Command, _ []string) {
 
 	log.Info().Msg("Initializing database ...")
 	err = repository.InitializeDatabase(db)
-	if err != nil {
+	if errors.Is(err, repository.ErrSchemaTooNew) && cfg.Schema.ReadOnlyOnMismatch {
+		log.Warn().Err(err).Msg("Starting read-only, the database was migrated by a newer version")
+	} else if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize database schema")
 	}
 
@@ -75,37 +138,142 @@ func runRestServer(_ *cobra.Command, _ []string) {
 	competitionRepo := repository.NewSQLCompetitionRepository(db)
 	scaleRepo := repository.NewSQLScaleRepository(db)
 	liverankingRepo := repository.NewSQLLiverankingRepository(db)
+	zoneBoundsRepo := repository.NewSQLZoneBoundsRepository(db)
+	zoneRepo := repository.NewSQLZoneRepository(db)
 	participantRepo := repository.NewSQLParticipantRepository(db)
 	runRepo := repository.NewSQLRunRepository(db)
+	sessionRepo := repository.NewSQLSessionRepository(db)
+	auditLogRepo := repository.NewSQLAuditLogRepository(db)
+	contactRepo := repository.NewSQLCompetitionContactRepository(db)
+	apiKeyRepo := repository.NewSQLAPIKeyRepository(db)
+	authEventRepo := repository.NewSQLAuthEventRepository(db)
+	invitationRepo := repository.NewSQLInvitationRepository(db)
+	refereePinRepo := repository.NewSQLRefereePinRepository(db)
+	refereeAssignmentRepo := repository.NewSQLRefereeAssignmentRepository(db)
+	refereeOnboardingRepo := repository.NewSQLRefereeOnboardingRepository(db)
+	displayDeviceRepo := repository.NewSQLDisplayDeviceRepository(db)
+	log.Info().Msg("Loading JWT keys ...")
+	keySet, err := service.NewKeySet(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load JWT keys")
+	}
+
 	log.Info().Msg("Initializing services ...")
+	passwordPolicy, err := service.NewPasswordPolicy(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load password policy")
+	}
+
+	metrics := service.NewMetrics(invitationRepo)
+	liverankingVersions := service.NewLiverankingVersions()
+	degradedMode := service.NewDegradedMode(liverankingRepo, liverankingVersions, cfg.DegradedMode.LiverankingInterval)
+	emailQueue := service.NewEmailQueue(cfg, metrics)
+
+	tokenDenylist := repository.NewMemoryTokenDenylist()
+	if cfg.Denylist.RedisAddr != "" {
+		log.Info().Msgf("Using the Redis token denylist at %s", cfg.Denylist.RedisAddr)
+		tokenDenylist = repository.NewRedisTokenDenylist(cfg.Denylist.RedisAddr, cfg.Denylist.RedisPassword, cfg.Denylist.RedisDB)
+	}
+
 	userService := service.NewUserService(
 		service.UserConfWithUserRepo(userRepo),
+		service.UserConfWithCompetitionRepo(competitionRepo),
+		service.UserConfWithSessionRepo(sessionRepo),
+		service.UserConfWithAuthEventRepo(authEventRepo),
+		service.UserConfWithInvitationRepo(invitationRepo),
+		service.UserConfWithRefereePinRepo(refereePinRepo),
+		service.UserConfWithRefereeOnboardingRepo(refereeOnboardingRepo),
+		service.UserConfWithRefereeAssignmentRepo(refereeAssignmentRepo),
+		service.UserConfWithZoneRepo(zoneRepo),
+		service.UserConfWithDisplayDeviceRepo(displayDeviceRepo),
+		service.UserConfWithOIDCClient(service.NewOIDCClient(cfg)),
+		service.UserConfWithMetrics(metrics),
+		service.UserConfWithPasswordPolicy(passwordPolicy),
+		service.UserConfWithKeySet(keySet),
+		service.UserConfWithTokenDenylist(tokenDenylist),
+		service.UserConfWithDegradedMode(degradedMode),
 		service.UserConfWithConfig(cfg),
 	)
 
-	competitionService := service.NewCompetitionService(
+	competitionConfs := []service.CompetitionServiceConfiguration{
 		service.CompetitionConfWithCompetitionRepo(competitionRepo),
 		service.CompetitionConfWithScaleRepo(scaleRepo),
 		service.CompetitionConfWithLiverankingRepo(liverankingRepo),
 		service.CompetitionConfWithParticipantRepo(participantRepo),
 		service.CompetitionConfWithRunRepo(runRepo),
+		service.CompetitionConfWithContactRepo(contactRepo),
+		service.CompetitionConfWithZoneBoundsRepo(zoneBoundsRepo),
+		service.CompetitionConfWithZoneRepo(zoneRepo),
+		service.CompetitionConfWithExportTemplateRepo(repository.NewSQLExportTemplateRepository(db)),
+		service.CompetitionConfWithSettingsRepo(repository.NewSQLCompetitionSettingsRepository(db)),
+		service.CompetitionConfWithCategoryRepo(repository.NewSQLCategoryRepository(db)),
+		service.CompetitionConfWithClubRepo(repository.NewSQLClubRepository(db)),
+		service.CompetitionConfWithTeamRepo(repository.NewSQLTeamRepository(db)),
+		service.CompetitionConfWithSeriesRepo(repository.NewSQLSeriesRepository(db)),
+		service.CompetitionConfWithOrganizationRepo(repository.NewSQLOrganizationRepository(db)),
+		service.CompetitionConfWithEmailQueue(emailQueue),
 		service.CompetitionConfWithConfig(cfg),
-	)
+	}
+	// The results are only published to static storage when a bucket is configured
+	if cfg.Publication.Bucket != "" {
+		competitionConfs = append(competitionConfs, service.CompetitionConfWithObjectStorage(repository.NewS3ObjectStorage(cfg.Publication)))
+	}
+	// The licences are only checked against a federation register when one is configured
+	licenceRegistry := repository.NewNoopLicenceRegistry()
+	if cfg.Licence.RegistryURL != "" {
+		log.Info().Msgf("Verifying the licences in the federation register at %s", cfg.Licence.RegistryURL)
+		licenceRegistry = repository.NewHTTPLicenceRegistry(cfg.Licence)
+	}
+	competitionConfs = append(competitionConfs, service.CompetitionConfWithLicenceRegistry(licenceRegistry))
+	competitionService := service.NewCompetitionService(competitionConfs...)
 
 	runService := service.NewRunService(
 		service.RunConfWithRunRepo(runRepo),
+		service.RunConfWithCompetitionRepo(competitionRepo),
 		service.RunConfWithParticipantRepo(participantRepo),
 		service.RunConfWithLiverankingRepo(liverankingRepo),
 		service.RunConfWithScaleRepo(scaleRepo),
+		service.RunConfWithZoneBoundsRepo(zoneBoundsRepo),
+		service.RunConfWithZoneRepo(zoneRepo),
+		service.RunConfWithSettingsRepo(repository.NewSQLCompetitionSettingsRepository(db)),
+		service.RunConfWithRefereeAssignmentRepo(refereeAssignmentRepo),
+		service.RunConfWithPendingRunRepo(repository.NewSQLPendingRunRepository(db)),
+		service.RunConfWithMetrics(metrics),
+		service.RunConfWithDegradedMode(degradedMode),
+		service.RunConfWithLiverankingVersions(liverankingVersions),
 		service.RunConfWithConfig(cfg),
 	)
 
+	apiKeyService := service.NewAPIKeyService(
+		service.APIKeyConfWithAPIKeyRepo(apiKeyRepo),
+	)
+
+	auditService := service.NewAuditService(
+		service.AuditConfWithAuditLogRepo(auditLogRepo),
+	)
+
+	statsService := service.NewStatsService(
+		service.StatsConfWithStatsRepo(repository.NewSQLStatsRepository(db)),
+		service.StatsConfWithStandingsCacheTTL(cfg.Standings.CacheTTL),
+	)
+
+	// Refuse writes once a newer version migrates the database during a rolling upgrade
+	schemaGuard := service.NewSchemaGuard(repository.NewSQLSchemaRepository(db), repository.SchemaVersion, cfg.Schema.CheckInterval)
+
 	log.Info().Msg("Creating server ...")
 	server, err := server.NewServer(
 		server.ServerConfWithConfig(cfg),
 		server.ServerConfWithUserService(userService),
 		server.ServerConfWithCompetitionService(competitionService),
 		server.ServerConfWithRunService(runService),
+		server.ServerConfWithAPIKeyService(apiKeyService),
+		server.ServerConfWithAuditService(auditService),
+		server.ServerConfWithStatsService(statsService),
+		server.ServerConfWithKeySet(keySet),
+		server.ServerConfWithURLSigner(service.NewURLSigner(cfg)),
+		server.ServerConfWithMetrics(metrics),
+		server.ServerConfWithDegradedMode(degradedMode),
+		server.ServerConfWithSchemaGuard(schemaGuard),
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create server")
@@ -114,3 +282,328 @@ func runRestServer(_ *cobra.Command, _ []string) {
 	log.Info().Msg("Starting server ...")
 	server.Start(cfg)
 }
+
+func runPruneRoles(_ *cobra.Command, _ []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	log.Info().Msg("Loading configuration ...")
+	cfg := config.New()
+
+	log.Info().Msg("Initializing database ...")
+	db, err := repository.NewDatabaseConnection(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database")
+	}
+
+	err = repository.InitializeDatabase(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database schema")
+	}
+
+	userService := service.NewUserService(
+		service.UserConfWithUserRepo(repository.NewSQLUserRepository(db)),
+		service.UserConfWithCompetitionRepo(repository.NewSQLCompetitionRepository(db)),
+		service.UserConfWithConfig(cfg),
+	)
+
+	log.Info().Msgf("Pruning roles of competitions older than %d months ...", cfg.Roles.RetentionMonths)
+	report, err := userService.PruneExpiredRoles(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to prune roles")
+	}
+
+	log.Info().
+		Ints32("expired_competitions", report.GetExpiredCompetitionIDs()).
+		Int32("pruned_users", report.GetPrunedUsers()).
+		Int32("removed_roles", report.GetRemovedRoles()).
+		Msg("Roles pruned")
+
+	for _, user := range report.GetNearLimitUsers() {
+		log.Warn().
+			Int32("user_id", user.GetID()).
+			Str("email", user.GetEmail()).
+			Int("roles_length", len(user.GetRoles())).
+			Msg("User roles make large tokens")
+	}
+}
+
+// newArchiveCompetitionService connects to the database, runs the migrations and builds the service archiving competitions
+func newArchiveCompetitionService(cfg *config.Config) (*service.CompetitionService, *service.UserService) {
+	log.Info().Msg("Initializing database ...")
+	db, err := repository.NewDatabaseConnection(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database")
+	}
+
+	err = repository.InitializeDatabase(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database schema")
+	}
+
+	competitionService := service.NewCompetitionService(
+		service.CompetitionConfWithCompetitionRepo(repository.NewSQLCompetitionRepository(db)),
+		service.CompetitionConfWithScaleRepo(repository.NewSQLScaleRepository(db)),
+		service.CompetitionConfWithLiverankingRepo(repository.NewSQLLiverankingRepository(db)),
+		service.CompetitionConfWithParticipantRepo(repository.NewSQLParticipantRepository(db)),
+		service.CompetitionConfWithRunRepo(repository.NewSQLRunRepository(db)),
+		service.CompetitionConfWithContactRepo(repository.NewSQLCompetitionContactRepository(db)),
+		service.CompetitionConfWithCategoryRepo(repository.NewSQLCategoryRepository(db)),
+		service.CompetitionConfWithZoneRepo(repository.NewSQLZoneRepository(db)),
+		service.CompetitionConfWithConfig(cfg),
+	)
+
+	userService := service.NewUserService(
+		service.UserConfWithUserRepo(repository.NewSQLUserRepository(db)),
+		service.UserConfWithCompetitionRepo(repository.NewSQLCompetitionRepository(db)),
+		service.UserConfWithConfig(cfg),
+	)
+
+	return competitionService, userService
+}
+
+func runArchive(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	var competitionID int32
+	if _, err := fmt.Sscanf(args[0], "%d", &competitionID); err != nil || competitionID <= 0 {
+		log.Fatal().Str("competition_id", args[0]).Msg("Invalid competition ID")
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = fmt.Sprintf("competition-%d.tar.gz", competitionID)
+	}
+
+	log.Info().Msg("Loading configuration ...")
+	cfg := config.New()
+	competitionService, _ := newArchiveCompetitionService(cfg)
+
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create the archive file")
+	}
+
+	log.Info().Int32("competition_id", competitionID).Msg("Archiving competition ...")
+	err = competitionService.ArchiveCompetition(ctx, competitionID, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+		log.Fatal().Err(err).Msg("Failed to archive competition")
+	}
+
+	log.Info().Int32("competition_id", competitionID).Str("file", output).Msg("Competition archived")
+}
+
+func runUnarchive(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	name, _ := cmd.Flags().GetString("name")
+	adminEmail, _ := cmd.Flags().GetString("admin")
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open the archive file")
+	}
+	defer file.Close()
+
+	log.Info().Msg("Loading configuration ...")
+	cfg := config.New()
+	competitionService, userService := newArchiveCompetitionService(cfg)
+
+	log.Info().Str("file", args[0]).Msg("Restoring competition ...")
+	competitionID, err := competitionService.UnarchiveCompetition(ctx, file, name)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to restore competition")
+	}
+
+	if adminEmail != "" {
+		if _, err := userService.GrantAdminRole(ctx, adminEmail, competitionID); err != nil {
+			log.Error().Err(err).Str("email", adminEmail).Msg("Failed to grant the admin role of the restored competition")
+		}
+	}
+
+	log.Info().Int32("competition_id", competitionID).Msg("Competition restored")
+}
+
+func runGrantSuperAdmin(_ *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	log.Info().Msg("Loading configuration ...")
+	cfg := config.New()
+
+	log.Info().Msg("Initializing database ...")
+	db, err := repository.NewDatabaseConnection(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database")
+	}
+
+	err = repository.InitializeDatabase(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database schema")
+	}
+
+	userService := service.NewUserService(
+		service.UserConfWithUserRepo(repository.NewSQLUserRepository(db)),
+		service.UserConfWithAuthEventRepo(repository.NewSQLAuthEventRepository(db)),
+		service.UserConfWithConfig(cfg),
+	)
+
+	if err := userService.GrantSuperAdmin(ctx, args[0]); err != nil {
+		log.Fatal().Err(err).Str("email", args[0]).Msg("Failed to grant the super admin role")
+	}
+
+	log.Info().Str("email", args[0]).Msg("Super admin role granted")
+}
+
+func runMigrateRoles(cmd *cobra.Command, _ []string) {
+	verifyOnly, _ := cmd.Flags().GetBool("verify")
+	rollback, _ := cmd.Flags().GetBool("rollback")
+
+	log.Info().Msg("Loading configuration ...")
+	cfg := config.New()
+
+	log.Info().Msg("Initializing database ...")
+	db, err := repository.NewDatabaseConnection(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize database")
+	}
+
+	if rollback {
+		users, err := repository.RollbackUserRoles(db)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to roll back the roles migration")
+		}
+		log.Info().Int("users", users).Msg("Roles written back to the legacy column, starting this version again migrates them anew")
+		return
+	}
+
+	if !verifyOnly {
+		users, err := repository.MigrateUserRoles(db)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to migrate roles")
+		}
+		log.Info().Int("users", users).Msg("Roles migrated")
+	}
+
+	verification, err := repository.VerifyUserRoles(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to verify the roles migration")
+	}
+
+	for _, userID := range verification.LegacyUsers {
+		log.Warn().Int32("user_id", userID).Msg("User still has roles in the legacy column")
+	}
+	for userID, roles := range verification.MissingRoles {
+		log.Warn().Int32("user_id", userID).Strs("roles", roles).Msg("Legacy roles missing from user_roles")
+	}
+
+	if !verification.IsValid() {
+		log.Fatal().Int("migrated_users", verification.MigratedUsers).Msg("Roles migration verification failed")
+	}
+	log.Info().Int("migrated_users", verification.MigratedUsers).Msg("Roles migration verified")
+}
+
+// doctorCheck is a line of the report of the doctor command
+type doctorCheck struct {
+	name     string
+	problems []error
+	skipped  bool
+}
+
+func runDoctor(_ *cobra.Command, _ []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var checks []doctorCheck
+
+	cfg, err := loadDoctorConfig()
+	if err != nil {
+		checks = append(checks, doctorCheck{name: "config", problems: []error{err}})
+		printDoctorReport(checks)
+		os.Exit(1)
+	}
+	checks = append(checks, doctorCheck{name: "config", problems: cfg.Validate()})
+
+	db, err := repository.NewDatabaseConnection(cfg)
+	checks = append(checks, doctorCheck{name: "database", problems: doctorProblems(err)})
+	if err != nil {
+		checks = append(checks, doctorCheck{name: "database schema", skipped: true})
+	} else {
+		defer db.Close()
+		missing, err := repository.VerifySchema(ctx, db)
+		check := doctorCheck{name: "database schema", problems: doctorProblems(err)}
+		if version, _, err := repository.NewSQLSchemaRepository(db).GetSchemaVersion(ctx); err != nil {
+			check.problems = append(check.problems, err)
+		} else if version > repository.SchemaVersion {
+			check.problems = append(check.problems, fmt.Errorf("%w: database at version %d, service at version %d", repository.ErrSchemaTooNew, version, repository.SchemaVersion))
+		}
+		for _, item := range missing {
+			check.problems = append(check.problems, fmt.Errorf("missing %s, starting the server migrates the schema", item))
+		}
+		checks = append(checks, check)
+	}
+
+	checks = append(checks, doctorCheck{name: "email server", problems: doctorProblems(service.CheckEmailServer(ctx, cfg))})
+
+	keySet, err := service.NewKeySet(cfg)
+	if err == nil {
+		err = keySet.CheckKeys()
+	}
+	checks = append(checks, doctorCheck{name: "jwt keys", problems: doctorProblems(err)})
+
+	checks = append(checks, doctorCheck{name: "storage", problems: doctorProblems(service.CheckStorage(cfg))})
+
+	if !printDoctorReport(checks) {
+		os.Exit(1)
+	}
+}
+
+// loadDoctorConfig loads the configuration, reporting the variables Load panics on as an error
+func loadDoctorConfig() (cfg *config.Config, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to load the configuration: %v", r)
+		}
+	}()
+	return config.New(), nil
+}
+
+// doctorProblems returns the error as the problems of a check, none when it is nil
+func doctorProblems(err error) []error {
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
+
+// printDoctorReport prints the result of every check and returns whether they all passed
+func printDoctorReport(checks []doctorCheck) bool {
+	passed := true
+	for _, check := range checks {
+		switch {
+		case check.skipped:
+			fmt.Printf("SKIP  %s\n", check.name)
+		case len(check.problems) == 0:
+			fmt.Printf("PASS  %s\n", check.name)
+		default:
+			passed = false
+			fmt.Printf("FAIL  %s\n", check.name)
+			for _, problem := range check.problems {
+				fmt.Printf("      - %s\n", problem)
+			}
+		}
+	}
+
+	if passed {
+		fmt.Println("All checks passed")
+	} else {
+		fmt.Println("Some checks failed")
+	}
+	return passed
+}
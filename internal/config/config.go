@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -35,7 +37,24 @@ type Database struct {
 	Uri  string
 }
 type Jwt struct {
-	SecretKey string
+	SecretKey     string
+	SigningMethod string            // HS256 (default), RS256 or ES256
+	Keys          map[string]string // key ID -> path of the PEM encoded key
+	ActiveKeyID   string            // key ID used to sign new tokens
+	// AcceptHMACTokens keeps verifying the tokens without key ID with the secret key under RS256 or ES256,
+	// while the sessions signed before the switch from HS256 expire
+	AcceptHMACTokens bool
+
+	AccessTokenLifetime  time.Duration
+	RefreshTokenLifetime time.Duration // also the lifetime of the sessions, extended on each refresh
+
+	RefereePinTokenLifetime time.Duration // tokens issued for a referee PIN, they cannot be refreshed
+	DisplayTokenLifetime    time.Duration // longest registration of a display device, its tokens are refreshed until then
+}
+
+type CookieConfig struct {
+	Domain   string        // empty for host-only cookies
+	SameSite http.SameSite // http.SameSiteDefaultMode leaves the attribute out
 }
 
 type EmailConfig struct {
@@ -51,6 +70,93 @@ type RateLimitConfig struct {
 	LoginWindow            time.Duration
 	ForgotPasswordAttempts int
 	ForgotPasswordWindow   time.Duration
+	PinLoginAttempts       int // referee PIN logins per window and IP address
+	PinLoginWindow         time.Duration
+	RunAttempts            int // runs a single user can record per window
+	RunWindow              time.Duration
+	RunAnomalyThreshold    int // runs per anomaly window flagged in the audit log
+	RunAnomalyWindow       time.Duration
+}
+
+type PasswordPolicyConfig struct {
+	MinLength        int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	DenyListPath     string // file with one forbidden password per line
+	CheckPwned       bool   // check the Have I Been Pwned range API
+}
+
+type RolesConfig struct {
+	RetentionMonths int // roles of competitions older than this are pruned
+	WarningLength   int // users whose roles exceed this length in the tokens are reported
+}
+
+type OIDCProviderConfig struct {
+	Name         string // used in the login and callback URLs
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+}
+
+type OIDCConfig struct {
+	Providers       []OIDCProviderConfig
+	RedirectBaseURL string // public URL of the API, callbacks are served on /auth/oidc/{provider}/callback
+}
+
+type SignedURLConfig struct {
+	Secret      string        // HMAC key of the signatures, signed URLs are disabled when empty
+	MaxLifetime time.Duration // longest validity a signed URL can be given
+}
+
+type MetricsConfig struct {
+	Token string // bearer token of the scrapers, the metrics endpoint is disabled without it
+}
+
+type TokenDenylistConfig struct {
+	RedisAddr     string // host:port of a Redis server shared by the instances, in memory when empty
+	RedisPassword string
+	RedisDB       int
+}
+
+type LicenceConfig struct {
+	RegistryURL     string        // lookup endpoint of the register of the federation, every licence is accepted when empty
+	RegistryToken   string        // bearer token sent to the register
+	RegistryTimeout time.Duration // how long a lookup waits for the register
+}
+
+type RunsConfig struct {
+	UndoWindow       time.Duration // how long after recording a run its referee can void it without an admin
+	CorrectionWindow time.Duration // how long after recording a run its referee can edit or delete it without an admin
+}
+
+type SchemaConfig struct {
+	ReadOnlyOnMismatch bool          // start read-only instead of refusing to start on a database migrated by a newer version
+	CheckInterval      time.Duration // interval between two readings of the schema version of the database
+}
+
+type StandingsConfig struct {
+	CacheTTL time.Duration // how long the season standings are served before being computed again
+}
+
+type PublicConfig struct {
+	CompetitionCacheTTL time.Duration // how long the public competition information is served before being read again
+}
+
+type PublicationConfig struct {
+	Endpoint      string // S3 compatible object storage, e.g. https://s3.gra.io.cloud.ovh.net
+	Region        string
+	Bucket        string // the results are not published when empty
+	AccessKey     string
+	SecretKey     string
+	Prefix        string // prefix of the keys of the published objects
+	PublicBaseURL string // URL the bucket is served from, defaults to the endpoint followed by the bucket
+	PhotoPrefix   string // prefix of the keys of the participant photos, kept private and served by the API
+}
+
+type DegradedModeConfig struct {
+	LiverankingInterval time.Duration // interval between two batches of liveranking recalculations in degraded mode
 }
 
 type Config struct {
@@ -61,7 +167,21 @@ type Config struct {
 	AllowOrigins []string
 	Email        EmailConfig
 	SecureMode   bool
+	Cookie       CookieConfig
 	RateLimit    RateLimitConfig
+	Roles        RolesConfig
+	Password     PasswordPolicyConfig
+	OIDC         OIDCConfig
+	SignedURL    SignedURLConfig
+	Metrics      MetricsConfig
+	Denylist     TokenDenylistConfig
+	DegradedMode DegradedModeConfig
+	Standings    StandingsConfig
+	Public       PublicConfig
+	Publication  PublicationConfig
+	Runs         RunsConfig
+	Licence      LicenceConfig
+	Schema       SchemaConfig
 }
 
 func New() *Config {
@@ -112,6 +232,18 @@ func (c *Config) Load() {
 
 	c.Jwt.SecretKey = getStringFromEnv("JWT_SECRET_KEY")
 
+	// Asymmetric signing configuration, HMAC with the secret key is used by default
+	c.Jwt.SigningMethod = strings.ToUpper(getStringFromEnvWithDefault("JWT_SIGNING_METHOD", "HS256"))
+	c.Jwt.Keys = getMapFromEnv("JWT_KEYS")
+	c.Jwt.ActiveKeyID = getStringFromEnvWithDefault("JWT_ACTIVE_KEY_ID", "")
+	c.Jwt.AcceptHMACTokens = getBoolFromEnvWithDefault("JWT_ACCEPT_HMAC_TOKENS", false)
+
+	// Token lifetimes, the access token is refreshed transparently with the refresh token when it expires
+	c.Jwt.AccessTokenLifetime = getDurationFromEnvWithDefault("ACCESS_TOKEN_LIFETIME", time.Hour)
+	c.Jwt.RefreshTokenLifetime = getDurationFromEnvWithDefault("REFRESH_TOKEN_LIFETIME", 7*24*time.Hour)
+	c.Jwt.RefereePinTokenLifetime = getDurationFromEnvWithDefault("REFEREE_PIN_TOKEN_LIFETIME", 12*time.Hour)
+	c.Jwt.DisplayTokenLifetime = getDurationFromEnvWithDefault("DISPLAY_TOKEN_LIFETIME", 7*24*time.Hour)
+
 	c.Email.Host = getStringFromEnv("EMAIL_HOST")
 	c.Email.Port = getIntFromEnv("EMAIL_PORT")
 	c.Email.Username = getStringFromEnv("EMAIL_USERNAME")
@@ -123,6 +255,88 @@ func (c *Config) Load() {
 	c.RateLimit.LoginWindow = getDurationFromEnvWithDefault("LOGIN_RATE_LIMIT_WINDOW", 5*time.Minute)
 	c.RateLimit.ForgotPasswordAttempts = getIntFromEnvWithDefault("FORGOT_PASSWORD_RATE_LIMIT_ATTEMPTS", 3)
 	c.RateLimit.ForgotPasswordWindow = getDurationFromEnvWithDefault("FORGOT_PASSWORD_RATE_LIMIT_WINDOW", 1*time.Hour)
+	c.RateLimit.PinLoginAttempts = getIntFromEnvWithDefault("PIN_LOGIN_RATE_LIMIT_ATTEMPTS", 10)
+	c.RateLimit.PinLoginWindow = getDurationFromEnvWithDefault("PIN_LOGIN_RATE_LIMIT_WINDOW", 15*time.Minute)
+	c.RateLimit.RunAttempts = getIntFromEnvWithDefault("RUN_RATE_LIMIT_ATTEMPTS", 120)
+	c.RateLimit.RunWindow = getDurationFromEnvWithDefault("RUN_RATE_LIMIT_WINDOW", 1*time.Minute)
+	c.RateLimit.RunAnomalyThreshold = getIntFromEnvWithDefault("RUN_ANOMALY_THRESHOLD", 20)
+	c.RateLimit.RunAnomalyWindow = getDurationFromEnvWithDefault("RUN_ANOMALY_WINDOW", 1*time.Minute)
+
+	// Roles pruning configuration, the roles are embedded in the tokens stored in cookies of at most 4KB
+	c.Roles.RetentionMonths = getIntFromEnvWithDefault("ROLES_RETENTION_MONTHS", 12)
+	c.Roles.WarningLength = getIntFromEnvWithDefault("ROLES_WARNING_LENGTH", 400)
+
+	// Password policy configuration, only the minimum length is enforced by default
+	c.Password.MinLength = getIntFromEnvWithDefault("PASSWORD_MIN_LENGTH", 8)
+	c.Password.RequireLowercase = getBoolFromEnvWithDefault("PASSWORD_REQUIRE_LOWERCASE", false)
+	c.Password.RequireUppercase = getBoolFromEnvWithDefault("PASSWORD_REQUIRE_UPPERCASE", false)
+	c.Password.RequireDigit = getBoolFromEnvWithDefault("PASSWORD_REQUIRE_DIGIT", false)
+	c.Password.RequireSymbol = getBoolFromEnvWithDefault("PASSWORD_REQUIRE_SYMBOL", false)
+	c.Password.DenyListPath = getStringFromEnvWithDefault("PASSWORD_DENY_LIST", "")
+	c.Password.CheckPwned = getBoolFromEnvWithDefault("PASSWORD_CHECK_PWNED", false)
+
+	// OpenID Connect providers, e.g. OIDC_PROVIDERS=ffck with OIDC_FFCK_ISSUER, OIDC_FFCK_CLIENT_ID and OIDC_FFCK_CLIENT_SECRET
+	c.OIDC.RedirectBaseURL = strings.TrimSuffix(getStringFromEnvWithDefault("OIDC_REDIRECT_BASE_URL", ""), "/")
+	for _, name := range strings.Split(getStringFromEnvWithDefault("OIDC_PROVIDERS", ""), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		c.OIDC.Providers = append(c.OIDC.Providers, OIDCProviderConfig{
+			Name:         name,
+			Issuer:       strings.TrimSuffix(getStringFromEnv(prefix+"ISSUER"), "/"),
+			ClientID:     getStringFromEnv(prefix + "CLIENT_ID"),
+			ClientSecret: getStringFromEnv(prefix + "CLIENT_SECRET"),
+		})
+	}
+
+	// Signed download URLs of the exports, shared with people without an account
+	c.SignedURL.Secret = getStringFromEnvWithDefault("SIGNED_URL_SECRET", "")
+	c.SignedURL.MaxLifetime = getDurationFromEnvWithDefault("SIGNED_URL_MAX_LIFETIME", 7*24*time.Hour)
+
+	// Prometheus metrics, only served to scrapers presenting the token
+	c.Metrics.Token = getStringFromEnvWithDefault("METRICS_TOKEN", "")
+
+	// Denylist of the access tokens revoked by a logout, shared through Redis by several instances
+	c.Denylist.RedisAddr = getStringFromEnvWithDefault("REDIS_ADDR", "")
+	c.Denylist.RedisPassword = getStringFromEnvWithDefault("REDIS_PASSWORD", "")
+	c.Denylist.RedisDB = getIntFromEnvWithDefault("REDIS_DB", 0)
+
+	// Degraded mode, switched at runtime by the super admins when the venue connection is saturated
+	c.DegradedMode.LiverankingInterval = getDurationFromEnvWithDefault("DEGRADED_LIVERANKING_INTERVAL", 30*time.Second)
+
+	// Season standings, served from a cache as they are published on the organizers' sites
+	c.Standings.CacheTTL = getDurationFromEnvWithDefault("STANDINGS_CACHE_TTL", 5*time.Minute)
+
+	// Public competition information, served from a cache as spectators load it without an account
+	c.Public.CompetitionCacheTTL = getDurationFromEnvWithDefault("PUBLIC_COMPETITION_CACHE_TTL", time.Minute)
+
+	// Static results uploaded to an object storage bucket when a competition is closed, so they outlive the API
+	c.Publication.Endpoint = strings.TrimSuffix(getStringFromEnvWithDefault("RESULTS_S3_ENDPOINT", ""), "/")
+	c.Publication.Region = getStringFromEnvWithDefault("RESULTS_S3_REGION", "us-east-1")
+	c.Publication.Bucket = getStringFromEnvWithDefault("RESULTS_S3_BUCKET", "")
+	c.Publication.AccessKey = getStringFromEnvWithDefault("RESULTS_S3_ACCESS_KEY", "")
+	c.Publication.SecretKey = getStringFromEnvWithDefault("RESULTS_S3_SECRET_KEY", "")
+	c.Publication.Prefix = strings.Trim(getStringFromEnvWithDefault("RESULTS_S3_PREFIX", "results"), "/")
+	c.Publication.PublicBaseURL = strings.TrimSuffix(getStringFromEnvWithDefault("RESULTS_PUBLIC_BASE_URL", ""), "/")
+	c.Publication.PhotoPrefix = strings.Trim(getStringFromEnvWithDefault("RESULTS_S3_PHOTO_PREFIX", "participant-photos"), "/")
+
+	// Register of the federation the licence numbers of the participants are checked in, for the competitions verifying them
+	c.Licence.RegistryURL = getStringFromEnvWithDefault("LICENCE_REGISTRY_URL", "")
+	c.Licence.RegistryToken = getStringFromEnvWithDefault("LICENCE_REGISTRY_TOKEN", "")
+	c.Licence.RegistryTimeout = getDurationFromEnvWithDefault("LICENCE_REGISTRY_TIMEOUT", 5*time.Second)
+
+	// Referees can undo a mistyped run for a short while after recording it
+	c.Runs.UndoWindow = getDurationFromEnvWithDefault("RUN_UNDO_WINDOW", 2*time.Minute)
+	c.Runs.CorrectionWindow = getDurationFromEnvWithDefault("RUN_CORRECTION_WINDOW", 5*time.Minute)
+
+	// Schema version guard, keeping the instances of a previous version from writing to a migrated database
+	c.Schema.ReadOnlyOnMismatch = getBoolFromEnvWithDefault("SCHEMA_MISMATCH_READ_ONLY", false)
+	c.Schema.CheckInterval = getDurationFromEnvWithDefault("SCHEMA_CHECK_INTERVAL", 30*time.Second)
+
+	// Frontend the users are sent back to after logging in with an OIDC provider
+	c.ClientURI = getStringFromEnvWithDefault("CLIENT_URI", "")
 
 	// Origins
 	origins := getStringFromEnv("ALLOW_ORIGINS")
@@ -131,9 +345,72 @@ func (c *Config) Load() {
 
 	c.SecureMode = getBoolFromEnv("SECURE_MODE")
 
+	// Cookies of the tokens, e.g. COOKIE_DOMAIN=.yourdomain.com to share them with subdomains
+	c.Cookie.Domain = getStringFromEnvWithDefault("COOKIE_DOMAIN", "")
+	c.Cookie.SameSite = getSameSiteFromEnvWithDefault("COOKIE_SAMESITE", http.SameSiteDefaultMode)
+	if c.Cookie.SameSite == http.SameSiteNoneMode && !c.SecureMode {
+		log.Warn().Msg("COOKIE_SAMESITE=none requires SECURE_MODE, browsers will reject the cookies")
+	}
+
 	log.Info().Msgf("%s environment loaded successfully !", appEnv)
 }
 
+// minSecretKeyLength is the shortest JWT secret key accepted by Validate, HS256 keys should be as long as the hash
+const minSecretKeyLength = 32
+
+// Validate returns the problems of the configuration which would break the service at runtime, none when it is sane
+func (c *Config) Validate() []error {
+	var problems []error
+
+	if c.Database.Uri == "" {
+		problems = append(problems, errors.New("DB_URI is not set"))
+	}
+
+	if c.Jwt.SigningMethod == "HS256" {
+		if len(c.Jwt.SecretKey) < minSecretKeyLength {
+			problems = append(problems, fmt.Errorf("JWT_SECRET_KEY must be at least %d characters long", minSecretKeyLength))
+		}
+	} else if c.Jwt.ActiveKeyID == "" || len(c.Jwt.Keys) == 0 {
+		problems = append(problems, fmt.Errorf("JWT_KEYS and JWT_ACTIVE_KEY_ID are required with JWT_SIGNING_METHOD=%s", c.Jwt.SigningMethod))
+	}
+	if c.Jwt.AccessTokenLifetime <= 0 || c.Jwt.RefreshTokenLifetime <= 0 || c.Jwt.RefereePinTokenLifetime <= 0 || c.Jwt.DisplayTokenLifetime <= 0 {
+		problems = append(problems, errors.New("token lifetimes must be positive"))
+	}
+
+	if c.Email.Host == "" || c.Email.Port == 0 || c.Email.From == "" {
+		problems = append(problems, errors.New("EMAIL_HOST, EMAIL_PORT and EMAIL_FROM are required to send invitations and passwords"))
+	}
+
+	if c.SignedURL.Secret != "" && c.SignedURL.Secret == c.Jwt.SecretKey {
+		problems = append(problems, errors.New("SIGNED_URL_SECRET must differ from JWT_SECRET_KEY"))
+	}
+
+	if len(c.OIDC.Providers) > 0 && c.OIDC.RedirectBaseURL == "" {
+		problems = append(problems, errors.New("OIDC_REDIRECT_BASE_URL is required with OIDC_PROVIDERS"))
+	}
+	for _, provider := range c.OIDC.Providers {
+		if provider.Issuer == "" || provider.ClientID == "" || provider.ClientSecret == "" {
+			problems = append(problems, fmt.Errorf("the issuer, client ID and client secret of the OIDC provider %s are required", provider.Name))
+		}
+	}
+
+	if c.Publication.Bucket != "" && (c.Publication.Endpoint == "" || c.Publication.AccessKey == "" || c.Publication.SecretKey == "") {
+		problems = append(problems, errors.New("RESULTS_S3_ENDPOINT, RESULTS_S3_ACCESS_KEY and RESULTS_S3_SECRET_KEY are required with RESULTS_S3_BUCKET"))
+	}
+	// The participant photos must not be reachable from the published results
+	if c.Publication.Bucket != "" && (c.Publication.PhotoPrefix == "" ||
+		strings.HasPrefix(c.Publication.PhotoPrefix+"/", c.Publication.Prefix+"/") ||
+		strings.HasPrefix(c.Publication.Prefix+"/", c.Publication.PhotoPrefix+"/")) {
+		problems = append(problems, errors.New("RESULTS_S3_PHOTO_PREFIX must be set outside RESULTS_S3_PREFIX"))
+	}
+
+	if c.GetEnv() == string(Production) && !c.SecureMode {
+		problems = append(problems, errors.New("SECURE_MODE must be enabled in production"))
+	}
+
+	return problems
+}
+
 func (c *Config) GetEnv() string {
 	return getStringFromEnv(AppEnv)
 }
@@ -179,6 +456,54 @@ func getDurationFromEnvWithDefault(key string, defaultValue time.Duration) time.
 	return duration
 }
 
+// getSameSiteFromEnvWithDefault parses a SameSite cookie attribute: lax, strict or none
+func getSameSiteFromEnvWithDefault(key string, defaultValue http.SameSite) http.SameSite {
+	valueStr := viper.GetString(key)
+	switch strings.ToLower(valueStr) {
+	case "":
+		return defaultValue
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		log.Warn().Msgf("Invalid value for %s: %s, expected lax, strict or none", key, valueStr)
+		return defaultValue
+	}
+}
+
+func getStringFromEnvWithDefault(key string, defaultValue string) string {
+	valueStr := viper.GetString(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	return valueStr
+}
+
+// getMapFromEnv parses a "key1=value1,key2=value2" environment variable
+func getMapFromEnv(key string) map[string]string {
+	values := make(map[string]string)
+
+	valueStr := viper.GetString(key)
+	if valueStr == "" {
+		return values
+	}
+
+	for _, pair := range strings.Split(valueStr, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			log.Warn().Msgf("Invalid entry in %s: %s", key, pair)
+			continue
+		}
+		values[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return values
+}
+
 func getStringFromEnv(key string) string {
 	myString := viper.GetString(key)
 
@@ -189,6 +514,21 @@ func getStringFromEnv(key string) string {
 	return myString
 }
 
+func getBoolFromEnvWithDefault(key string, defaultValue bool) bool {
+	valueStr := viper.GetString(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		log.Warn().Msgf("Invalid value for %s: %s, using default: %t", key, valueStr, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
 func getBoolFromEnv(key string) bool {
 	myBool := viper.GetBool(key)
 
@@ -0,0 +1,146 @@
+package aggregate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// APIKeyScopeReadLiveranking allows reading the liveranking of the competition
+	APIKeyScopeReadLiveranking = "read-liveranking"
+	// APIKeyScopeWriteRuns allows recording runs for the competition
+	APIKeyScopeWriteRuns = "write-runs"
+)
+
+// APIKeyScopes lists the scopes that can be granted to an API key
+var APIKeyScopes = []string{APIKeyScopeReadLiveranking, APIKeyScopeWriteRuns}
+
+// APIKey is the aggregate root for API keys used by external integrations
+type APIKey struct {
+	apiKey *entity.APIKey
+}
+
+// NewAPIKey creates a new API key aggregate
+func NewAPIKey() *APIKey {
+	return &APIKey{apiKey: &entity.APIKey{}}
+}
+
+// GetID returns the API key ID
+func (a *APIKey) GetID() int32 {
+	return a.apiKey.ID
+}
+
+// GetCompetitionID returns the competition the key is scoped to
+func (a *APIKey) GetCompetitionID() int32 {
+	return a.apiKey.CompetitionID
+}
+
+// GetName returns the name given to the key
+func (a *APIKey) GetName() string {
+	return a.apiKey.Name
+}
+
+// GetPrefix returns the first characters of the key, used to identify it
+func (a *APIKey) GetPrefix() string {
+	return a.apiKey.Prefix
+}
+
+// GetKeyHash returns the SHA-256 hash of the key
+func (a *APIKey) GetKeyHash() string {
+	return a.apiKey.KeyHash
+}
+
+// GetScopes returns the scopes granted to the key
+func (a *APIKey) GetScopes() []string {
+	if a.apiKey.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(a.apiKey.Scopes, ",")
+}
+
+// GetCreatedBy returns the ID of the user who created the key
+func (a *APIKey) GetCreatedBy() int32 {
+	return a.apiKey.CreatedBy
+}
+
+// GetCreatedAt returns the creation time of the key
+func (a *APIKey) GetCreatedAt() time.Time {
+	return a.apiKey.CreatedAt
+}
+
+// GetLastUsedAt returns the last time the key was used, zero if never used
+func (a *APIKey) GetLastUsedAt() time.Time {
+	return a.apiKey.LastUsedAt
+}
+
+// GetRevokedAt returns the revocation time of the key, zero if still active
+func (a *APIKey) GetRevokedAt() time.Time {
+	return a.apiKey.RevokedAt
+}
+
+// IsRevoked returns whether the key has been revoked
+func (a *APIKey) IsRevoked() bool {
+	return !a.apiKey.RevokedAt.IsZero()
+}
+
+// HasScope returns whether the key has been granted the scope
+func (a *APIKey) HasScope(scope string) bool {
+	for _, s := range a.GetScopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// SetID sets the API key ID
+func (a *APIKey) SetID(id int32) {
+	a.apiKey.ID = id
+}
+
+// SetCompetitionID sets the competition the key is scoped to
+func (a *APIKey) SetCompetitionID(competitionID int32) {
+	a.apiKey.CompetitionID = competitionID
+}
+
+// SetName sets the name given to the key
+func (a *APIKey) SetName(name string) {
+	a.apiKey.Name = name
+}
+
+// SetPrefix sets the first characters of the key
+func (a *APIKey) SetPrefix(prefix string) {
+	a.apiKey.Prefix = prefix
+}
+
+// SetKeyHash sets the SHA-256 hash of the key
+func (a *APIKey) SetKeyHash(keyHash string) {
+	a.apiKey.KeyHash = keyHash
+}
+
+// SetScopes sets the scopes granted to the key
+func (a *APIKey) SetScopes(scopes []string) {
+	a.apiKey.Scopes = strings.Join(scopes, ",")
+}
+
+// SetCreatedBy sets the ID of the user who created the key
+func (a *APIKey) SetCreatedBy(createdBy int32) {
+	a.apiKey.CreatedBy = createdBy
+}
+
+// SetCreatedAt sets the creation time of the key
+func (a *APIKey) SetCreatedAt(createdAt time.Time) {
+	a.apiKey.CreatedAt = createdAt
+}
+
+// SetLastUsedAt sets the last time the key was used
+func (a *APIKey) SetLastUsedAt(lastUsedAt time.Time) {
+	a.apiKey.LastUsedAt = lastUsedAt
+}
+
+// SetRevokedAt sets the revocation time of the key
+func (a *APIKey) SetRevokedAt(revokedAt time.Time) {
+	a.apiKey.RevokedAt = revokedAt
+}
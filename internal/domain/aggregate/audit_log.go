@@ -0,0 +1,112 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// AuditActionRunAnomaly flags a user recording runs at an abnormal pace
+	AuditActionRunAnomaly = "run.anomaly"
+	// AuditActionRunConflictResolved records an admin picking the authoritative run among conflicting ones
+	AuditActionRunConflictResolved = "run.conflict_resolved"
+	// AuditActionRunVoided records a referee voiding their own run within the undo window
+	AuditActionRunVoided = "run.voided"
+	// AuditActionRunApproved records an admin approving a run recorded by a referee
+	AuditActionRunApproved = "run.approved"
+	// AuditActionRunDeleted records a referee deleting their own run within the correction window
+	AuditActionRunDeleted = "run.deleted"
+	// AuditActionCompetitionDeleted records a super admin deleting a competition, the entry outlives it
+	AuditActionCompetitionDeleted = "competition.deleted"
+	// AuditActionCompetitionArchived records an admin archiving a competition
+	AuditActionCompetitionArchived = "competition.archived"
+	// AuditActionCompetitionStatusChanged records an admin moving a competition along its lifecycle
+	AuditActionCompetitionStatusChanged = "competition.status_changed"
+	// AuditActionDegradedModeChanged records a super admin switching the degraded mode on or off
+	AuditActionDegradedModeChanged = "system.degraded_mode"
+	// AuditActionParticipantDeleted records an admin deleting a participant with its runs and ranking
+	AuditActionParticipantDeleted = "participant.deleted"
+	// AuditActionParticipantsCleared records an admin clearing every participant of a competition with their runs and ranking
+	AuditActionParticipantsCleared = "participant.cleared"
+)
+
+// AuditLog is the aggregate root for audit log entries
+type AuditLog struct {
+	auditLog *entity.AuditLog
+}
+
+// NewAuditLog creates a new audit log aggregate
+func NewAuditLog() *AuditLog {
+	return &AuditLog{auditLog: &entity.AuditLog{}}
+}
+
+// GetID returns the audit log entry ID
+func (a *AuditLog) GetID() int32 {
+	return a.auditLog.ID
+}
+
+// GetCompetitionID returns the competition concerned by the entry, zero if none
+func (a *AuditLog) GetCompetitionID() int32 {
+	return a.auditLog.CompetitionID
+}
+
+// GetUserID returns the user who performed the action, zero if unknown
+func (a *AuditLog) GetUserID() int32 {
+	return a.auditLog.UserID
+}
+
+// GetAction returns the action recorded
+func (a *AuditLog) GetAction() string {
+	return a.auditLog.Action
+}
+
+// GetDetails returns a human readable description of the action
+func (a *AuditLog) GetDetails() string {
+	return a.auditLog.Details
+}
+
+// GetIP returns the IP address the action came from
+func (a *AuditLog) GetIP() string {
+	return a.auditLog.IP
+}
+
+// GetCreatedAt returns the time of the action
+func (a *AuditLog) GetCreatedAt() time.Time {
+	return a.auditLog.CreatedAt
+}
+
+// SetID sets the audit log entry ID
+func (a *AuditLog) SetID(id int32) {
+	a.auditLog.ID = id
+}
+
+// SetCompetitionID sets the competition concerned by the entry
+func (a *AuditLog) SetCompetitionID(competitionID int32) {
+	a.auditLog.CompetitionID = competitionID
+}
+
+// SetUserID sets the user who performed the action
+func (a *AuditLog) SetUserID(userID int32) {
+	a.auditLog.UserID = userID
+}
+
+// SetAction sets the action recorded
+func (a *AuditLog) SetAction(action string) {
+	a.auditLog.Action = action
+}
+
+// SetDetails sets the description of the action
+func (a *AuditLog) SetDetails(details string) {
+	a.auditLog.Details = details
+}
+
+// SetIP sets the IP address the action came from
+func (a *AuditLog) SetIP(ip string) {
+	a.auditLog.IP = ip
+}
+
+// SetCreatedAt sets the time of the action
+func (a *AuditLog) SetCreatedAt(createdAt time.Time) {
+	a.auditLog.CreatedAt = createdAt
+}
@@ -0,0 +1,126 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// AuthEventLogin is recorded when a user logs in
+	AuthEventLogin = "login"
+	// AuthEventLoginFailed is recorded when a login attempt is rejected
+	AuthEventLoginFailed = "login_failed"
+	// AuthEventPasswordChanged is recorded when a user changes their password
+	AuthEventPasswordChanged = "password_changed"
+	// AuthEventPasswordReset is recorded when a new password is sent after a forgotten password
+	AuthEventPasswordReset = "password_reset"
+	// AuthEventRoleGranted is recorded when a user is given a role
+	AuthEventRoleGranted = "role_granted"
+	// AuthEventImpersonated is recorded when a super admin logs in as the user for support
+	AuthEventImpersonated = "impersonated"
+	// AuthEventLogoutAll is recorded when a user logs out of every device
+	AuthEventLogoutAll = "logout_all"
+	// AuthEventRefereePinLogin is recorded when a referee logs in to a competition with a PIN on a shared tablet
+	AuthEventRefereePinLogin = "referee_pin_login"
+)
+
+// AuthEvent is the aggregate root for the security events of users
+type AuthEvent struct {
+	authEvent *entity.AuthEvent
+}
+
+// NewAuthEvent creates a new auth event aggregate
+func NewAuthEvent() *AuthEvent {
+	return &AuthEvent{authEvent: &entity.AuthEvent{}}
+}
+
+// GetID returns the event ID
+func (a *AuthEvent) GetID() int32 {
+	return a.authEvent.ID
+}
+
+// GetUserID returns the user concerned by the event, zero when the email is unknown
+func (a *AuthEvent) GetUserID() int32 {
+	return a.authEvent.UserID
+}
+
+// GetEmail returns the email the event was recorded for
+func (a *AuthEvent) GetEmail() string {
+	return a.authEvent.Email
+}
+
+// GetType returns the event type
+func (a *AuthEvent) GetType() string {
+	return a.authEvent.Type
+}
+
+// GetCompetitionID returns the competition concerned by the event, zero if none
+func (a *AuthEvent) GetCompetitionID() int32 {
+	return a.authEvent.CompetitionID
+}
+
+// GetDetails returns the event details, e.g. the granted role
+func (a *AuthEvent) GetDetails() string {
+	return a.authEvent.Details
+}
+
+// GetIP returns the IP address of the request
+func (a *AuthEvent) GetIP() string {
+	return a.authEvent.IP
+}
+
+// GetUserAgent returns the user agent of the request
+func (a *AuthEvent) GetUserAgent() string {
+	return a.authEvent.UserAgent
+}
+
+// GetCreatedAt returns when the event happened
+func (a *AuthEvent) GetCreatedAt() time.Time {
+	return a.authEvent.CreatedAt
+}
+
+// SetID sets the event ID
+func (a *AuthEvent) SetID(id int32) {
+	a.authEvent.ID = id
+}
+
+// SetUserID sets the user concerned by the event
+func (a *AuthEvent) SetUserID(userID int32) {
+	a.authEvent.UserID = userID
+}
+
+// SetEmail sets the email the event was recorded for
+func (a *AuthEvent) SetEmail(email string) {
+	a.authEvent.Email = email
+}
+
+// SetType sets the event type
+func (a *AuthEvent) SetType(eventType string) {
+	a.authEvent.Type = eventType
+}
+
+// SetCompetitionID sets the competition concerned by the event
+func (a *AuthEvent) SetCompetitionID(competitionID int32) {
+	a.authEvent.CompetitionID = competitionID
+}
+
+// SetDetails sets the event details
+func (a *AuthEvent) SetDetails(details string) {
+	a.authEvent.Details = details
+}
+
+// SetIP sets the IP address of the request
+func (a *AuthEvent) SetIP(ip string) {
+	a.authEvent.IP = ip
+}
+
+// SetUserAgent sets the user agent of the request
+func (a *AuthEvent) SetUserAgent(userAgent string) {
+	a.authEvent.UserAgent = userAgent
+}
+
+// SetCreatedAt sets when the event happened
+func (a *AuthEvent) SetCreatedAt(createdAt time.Time) {
+	a.authEvent.CreatedAt = createdAt
+}
@@ -0,0 +1,73 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+// Category is the aggregate root for the categories of a competition, a zero age is not limited
+type Category struct {
+	category *entity.Category
+}
+
+// NewCategory creates a new category aggregate
+func NewCategory() *Category {
+	return &Category{category: &entity.Category{}}
+}
+
+// GetID returns the category ID
+func (c *Category) GetID() int32 {
+	return c.category.ID
+}
+
+// GetCompetitionID returns the competition ID
+func (c *Category) GetCompetitionID() int32 {
+	return c.category.CompetitionID
+}
+
+// GetName returns the category name, used by the participants and scales of the category
+func (c *Category) GetName() string {
+	return c.category.Name
+}
+
+// GetMinAge returns the minimum age of the category, zero if not limited
+func (c *Category) GetMinAge() int32 {
+	return c.category.MinAge
+}
+
+// GetMaxAge returns the maximum age of the category, zero if not limited
+func (c *Category) GetMaxAge() int32 {
+	return c.category.MaxAge
+}
+
+// GetDisplayOrder returns the position of the category in the lists, lowest first
+func (c *Category) GetDisplayOrder() int32 {
+	return c.category.DisplayOrder
+}
+
+// SetID sets the category ID
+func (c *Category) SetID(id int32) {
+	c.category.ID = id
+}
+
+// SetCompetitionID sets the competition ID
+func (c *Category) SetCompetitionID(competitionID int32) {
+	c.category.CompetitionID = competitionID
+}
+
+// SetName sets the category name
+func (c *Category) SetName(name string) {
+	c.category.Name = name
+}
+
+// SetMinAge sets the minimum age of the category
+func (c *Category) SetMinAge(minAge int32) {
+	c.category.MinAge = minAge
+}
+
+// SetMaxAge sets the maximum age of the category
+func (c *Category) SetMaxAge(maxAge int32) {
+	c.category.MaxAge = maxAge
+}
+
+// SetDisplayOrder sets the position of the category in the lists
+func (c *Category) SetDisplayOrder(displayOrder int32) {
+	c.category.DisplayOrder = displayOrder
+}
@@ -0,0 +1,37 @@
+package aggregate
+
+// CheckInCount counts the participants of a category of a competition confirmed present at the start desk
+type CheckInCount struct {
+	category   string
+	registered int32
+	checkedIn  int32
+}
+
+// NewCheckInCount creates the check-in count of a category
+func NewCheckInCount(category string, registered, checkedIn int32) *CheckInCount {
+	return &CheckInCount{
+		category:   category,
+		registered: registered,
+		checkedIn:  checkedIn,
+	}
+}
+
+// GetCategory returns the category counted
+func (c *CheckInCount) GetCategory() string {
+	return c.category
+}
+
+// GetRegistered returns the number of participants of the category, waitlisted ones excluded
+func (c *CheckInCount) GetRegistered() int32 {
+	return c.registered
+}
+
+// GetCheckedIn returns the number of participants of the category confirmed present
+func (c *CheckInCount) GetCheckedIn() int32 {
+	return c.checkedIn
+}
+
+// GetMissing returns the number of participants of the category not confirmed present yet
+func (c *CheckInCount) GetMissing() int32 {
+	return c.registered - c.checkedIn
+}
@@ -0,0 +1,101 @@
+package aggregate
+
+const (
+	// ChronoImportMerged is the status of a row whose chrono was merged into an existing run
+	ChronoImportMerged = "merged"
+	// ChronoImportUnchanged is the status of a row whose chrono was already recorded, e.g. when importing a file again
+	ChronoImportUnchanged = "unchanged"
+	// ChronoImportPending is the status of a row without a run to merge into, its run waits for a referee to confirm it
+	ChronoImportPending = "pending"
+	// ChronoImportFailed is the status of a row that could not be imported
+	ChronoImportFailed = "failed"
+)
+
+// ChronoImportRow is the outcome of a single row of a chrono import
+type ChronoImportRow struct {
+	row       int32
+	dossard   int32
+	zone      string
+	chronoMs  int32
+	runNumber int32
+	status    string
+	message   string
+}
+
+// GetRow returns the line number of the row in the file
+func (r *ChronoImportRow) GetRow() int32 {
+	return r.row
+}
+
+// GetDossard returns the dossard of the row, zero when it could not be read
+func (r *ChronoImportRow) GetDossard() int32 {
+	return r.dossard
+}
+
+// GetZone returns the zone of the row
+func (r *ChronoImportRow) GetZone() string {
+	return r.zone
+}
+
+// GetChronoSec returns the chrono of the row in whole seconds
+func (r *ChronoImportRow) GetChronoSec() int32 {
+	return r.chronoMs / 1000
+}
+
+// GetChronoMs returns the chrono of the row in milliseconds
+func (r *ChronoImportRow) GetChronoMs() int32 {
+	return r.chronoMs
+}
+
+// GetRunNumber returns the run the chrono was merged into, zero when it was not
+func (r *ChronoImportRow) GetRunNumber() int32 {
+	return r.runNumber
+}
+
+// GetStatus returns the status of the row
+func (r *ChronoImportRow) GetStatus() string {
+	return r.status
+}
+
+// GetMessage returns why the row failed
+func (r *ChronoImportRow) GetMessage() string {
+	return r.message
+}
+
+// ChronoImportReport is the outcome of a chrono import
+type ChronoImportReport struct {
+	rows   []*ChronoImportRow
+	counts map[string]int32
+}
+
+// NewChronoImportReport creates a new chrono import report aggregate
+func NewChronoImportReport() *ChronoImportReport {
+	return &ChronoImportReport{
+		rows:   []*ChronoImportRow{},
+		counts: make(map[string]int32),
+	}
+}
+
+// GetRows returns the outcome of every row, in file order
+func (r *ChronoImportReport) GetRows() []*ChronoImportRow {
+	return r.rows
+}
+
+// Count returns the number of rows with the status
+func (r *ChronoImportReport) Count(status string) int32 {
+	return r.counts[status]
+}
+
+// AddRow records the outcome of a row
+func (r *ChronoImportReport) AddRow(row, dossard int32, zone string, chronoMs, runNumber int32, status, message string) {
+	r.rows = append(r.rows, &ChronoImportRow{
+		row:       row,
+		dossard:   dossard,
+		zone:      zone,
+		chronoMs:  chronoMs,
+		runNumber: runNumber,
+		status:    status,
+		message:   message,
+	})
+	r.counts[status]++
+}
@@ -0,0 +1,102 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+// Club is the aggregate root for the clubs of a competition
+type Club struct {
+	club *entity.Club
+}
+
+// NewClub creates a new club aggregate
+func NewClub() *Club {
+	return &Club{club: &entity.Club{}}
+}
+
+// GetID returns the club ID
+func (c *Club) GetID() int32 {
+	return c.club.ID
+}
+
+// GetCompetitionID returns the competition ID
+func (c *Club) GetCompetitionID() int32 {
+	return c.club.CompetitionID
+}
+
+// GetName returns the club name, also written on its participants
+func (c *Club) GetName() string {
+	return c.club.Name
+}
+
+// SetID sets the club ID
+func (c *Club) SetID(id int32) {
+	c.club.ID = id
+}
+
+// SetCompetitionID sets the competition ID
+func (c *Club) SetCompetitionID(competitionID int32) {
+	c.club.CompetitionID = competitionID
+}
+
+// SetName sets the club name
+func (c *Club) SetName(name string) {
+	c.club.Name = name
+}
+
+// ClubRanking is the rank of a club of a competition, on the points of its members
+type ClubRanking struct {
+	club        *Club
+	rank        int32
+	members     int32
+	ranked      int32
+	totalPoints int32
+}
+
+// NewClubRanking creates the ranking of a club
+func NewClubRanking(club *Club) *ClubRanking {
+	return &ClubRanking{club: club}
+}
+
+// GetClub returns the club
+func (r *ClubRanking) GetClub() *Club {
+	return r.club
+}
+
+// GetRank returns the rank of the club, clubs with the same points sharing it
+func (r *ClubRanking) GetRank() int32 {
+	return r.rank
+}
+
+// GetMembers returns the number of participants of the club, waitlisted ones excluded
+func (r *ClubRanking) GetMembers() int32 {
+	return r.members
+}
+
+// GetRanked returns the number of members with at least one run
+func (r *ClubRanking) GetRanked() int32 {
+	return r.ranked
+}
+
+// GetTotalPoints returns the sum of the points of the members
+func (r *ClubRanking) GetTotalPoints() int32 {
+	return r.totalPoints
+}
+
+// SetRank sets the rank of the club
+func (r *ClubRanking) SetRank(rank int32) {
+	r.rank = rank
+}
+
+// SetMembers sets the number of participants of the club
+func (r *ClubRanking) SetMembers(members int32) {
+	r.members = members
+}
+
+// SetRanked sets the number of members with at least one run
+func (r *ClubRanking) SetRanked(ranked int32) {
+	r.ranked = ranked
+}
+
+// SetTotalPoints sets the sum of the points of the members
+func (r *ClubRanking) SetTotalPoints(totalPoints int32) {
+	r.totalPoints = totalPoints
+}
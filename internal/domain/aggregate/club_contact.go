@@ -0,0 +1,39 @@
+package aggregate
+
+// ClubContact is a club contact address of a competition, with the clubs of the participants giving it
+type ClubContact struct {
+	email        string
+	clubs        []string
+	participants int32
+}
+
+// NewClubContact creates a new ClubContact
+func NewClubContact(email string) *ClubContact {
+	return &ClubContact{email: email}
+}
+
+// GetEmail returns the address of the contact
+func (c *ClubContact) GetEmail() string {
+	return c.email
+}
+
+// GetClubs returns the distinct clubs the contact is given for, in the order they were added
+func (c *ClubContact) GetClubs() []string {
+	return c.clubs
+}
+
+// GetParticipants returns the number of participants giving the contact
+func (c *ClubContact) GetParticipants() int32 {
+	return c.participants
+}
+
+// AddParticipant counts a participant of the club giving the contact
+func (c *ClubContact) AddParticipant(club string) {
+	c.participants++
+	for _, known := range c.clubs {
+		if known == club {
+			return
+		}
+	}
+	c.clubs = append(c.clubs, club)
+}
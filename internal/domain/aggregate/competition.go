@@ -1,6 +1,60 @@
 package aggregate
 
-import "github.com/NiskuT/cross-api/internal/domain/entity"
+import (
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// ChronoFormatMinutesSeconds displays and enters chronos as mm:ss
+	ChronoFormatMinutesSeconds = "mm:ss"
+	// ChronoFormatSeconds displays and enters chronos as a number of seconds
+	ChronoFormatSeconds = "seconds"
+	// ChronoFormatMilliseconds displays and enters chronos as a number of milliseconds
+	ChronoFormatMilliseconds = "milliseconds"
+
+	// ChronoDirectionUp means the chrono counts the time spent in the zone
+	ChronoDirectionUp = "up"
+	// ChronoDirectionDown means the chrono counts down the time left in the zone
+	ChronoDirectionDown = "down"
+
+	// CompetitionStatusDraft is the status of a competition being prepared
+	CompetitionStatusDraft = "draft"
+	// CompetitionStatusOpen is the status of a competition open to registrations, not scored yet
+	CompetitionStatusOpen = "open"
+	// CompetitionStatusRunning is the status of a competition being scored, the only one accepting new runs
+	CompetitionStatusRunning = "running"
+	// CompetitionStatusClosed is the status of a finished competition, its runs and liveranking are frozen
+	CompetitionStatusClosed = "closed"
+
+	// DefaultCompetitionTimezone is the time zone of the competitions created without one
+	DefaultCompetitionTimezone = "Europe/Paris"
+	// CompetitionDayLayout is the layout of the day of a competition, e.g. in the exports
+	CompetitionDayLayout = "2006-01-02"
+)
+
+// competitionDateLayouts are the layouts of the dates given without offset, read in the time zone of the competition
+var competitionDateLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", CompetitionDayLayout}
+
+// ChronoFormats lists the formats a competition can display chronos with
+var ChronoFormats = []string{ChronoFormatMinutesSeconds, ChronoFormatSeconds, ChronoFormatMilliseconds}
+
+// ChronoDirections lists the directions a competition chrono can count in
+var ChronoDirections = []string{ChronoDirectionUp, ChronoDirectionDown}
+
+// CompetitionStatuses lists the statuses of a competition in the order of its lifecycle
+var CompetitionStatuses = []string{CompetitionStatusDraft, CompetitionStatusOpen, CompetitionStatusRunning, CompetitionStatusClosed}
+
+// competitionStatusTransitions lists the statuses a competition can move to from each status.
+// A competition can go back one step, e.g. to reopen a closed competition for corrections.
+var competitionStatusTransitions = map[string][]string{
+	CompetitionStatusDraft:   {CompetitionStatusOpen},
+	CompetitionStatusOpen:    {CompetitionStatusDraft, CompetitionStatusRunning},
+	CompetitionStatusRunning: {CompetitionStatusOpen, CompetitionStatusClosed},
+	CompetitionStatusClosed:  {CompetitionStatusRunning},
+}
 
 // Competition is the aggregate root for competition domain
 type Competition struct {
@@ -10,7 +64,12 @@ type Competition struct {
 // NewCompetition creates a new competition aggregate
 func NewCompetition() *Competition {
 	return &Competition{
-		competition: &entity.Competition{},
+		competition: &entity.Competition{
+			Timezone:        DefaultCompetitionTimezone,
+			ChronoFormat:    ChronoFormatMinutesSeconds,
+			ChronoDirection: ChronoDirectionUp,
+			Status:          CompetitionStatusDraft,
+		},
 	}
 }
 
@@ -29,11 +88,30 @@ func (c *Competition) GetDescription() string {
 	return c.competition.Description
 }
 
-// GetDate returns the competition date
-func (c *Competition) GetDate() string {
+// GetDate returns when the competition starts
+func (c *Competition) GetDate() time.Time {
 	return c.competition.Date
 }
 
+// GetTimezone returns the IANA time zone the competition takes place in
+func (c *Competition) GetTimezone() string {
+	return c.competition.Timezone
+}
+
+// GetLocalDate returns when the competition starts, in its time zone
+func (c *Competition) GetLocalDate() time.Time {
+	location, err := time.LoadLocation(c.competition.Timezone)
+	if err != nil {
+		return c.competition.Date
+	}
+	return c.competition.Date.In(location)
+}
+
+// GetDay returns the day the competition takes place in its time zone, as YYYY-MM-DD
+func (c *Competition) GetDay() string {
+	return c.GetLocalDate().Format(CompetitionDayLayout)
+}
+
 // GetLocation returns the competition location
 func (c *Competition) GetLocation() string {
 	return c.competition.Location
@@ -64,11 +142,16 @@ func (c *Competition) SetDescription(description string) {
 	c.competition.Description = description
 }
 
-// SetDate sets the competition date
-func (c *Competition) SetDate(date string) {
+// SetDate sets when the competition starts
+func (c *Competition) SetDate(date time.Time) {
 	c.competition.Date = date
 }
 
+// SetTimezone sets the IANA time zone the competition takes place in
+func (c *Competition) SetTimezone(timezone string) {
+	c.competition.Timezone = timezone
+}
+
 // SetLocation sets the competition location
 func (c *Competition) SetLocation(location string) {
 	c.competition.Location = location
@@ -83,3 +166,170 @@ func (c *Competition) SetOrganizer(organizer string) {
 func (c *Competition) SetContact(contact string) {
 	c.competition.Contact = contact
 }
+
+// GetChronoFormat returns how the chronos of the competition are displayed and entered
+func (c *Competition) GetChronoFormat() string {
+	return c.competition.ChronoFormat
+}
+
+// GetChronoDirection returns whether the chrono of the competition counts up or down
+func (c *Competition) GetChronoDirection() string {
+	return c.competition.ChronoDirection
+}
+
+// SetChronoFormat sets how the chronos of the competition are displayed and entered
+func (c *Competition) SetChronoFormat(chronoFormat string) {
+	c.competition.ChronoFormat = chronoFormat
+}
+
+// SetChronoDirection sets whether the chrono of the competition counts up or down
+func (c *Competition) SetChronoDirection(chronoDirection string) {
+	c.competition.ChronoDirection = chronoDirection
+}
+
+// GetStatus returns the status of the competition in its lifecycle
+func (c *Competition) GetStatus() string {
+	return c.competition.Status
+}
+
+// SetStatus sets the status of the competition in its lifecycle
+func (c *Competition) SetStatus(status string) {
+	c.competition.Status = status
+}
+
+// CanTransitionTo returns whether the competition can move from its status to the given one
+func (c *Competition) CanTransitionTo(status string) bool {
+	for _, next := range competitionStatusTransitions[c.competition.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRunning returns whether the competition is being scored and accepts new runs
+func (c *Competition) IsRunning() bool {
+	return c.competition.Status == CompetitionStatusRunning
+}
+
+// IsClosed returns whether the competition is closed, its runs and liveranking can no longer change
+func (c *Competition) IsClosed() bool {
+	return c.competition.Status == CompetitionStatusClosed
+}
+
+// GetArchivedAt returns when the competition was archived, zero if it was not
+func (c *Competition) GetArchivedAt() time.Time {
+	return c.competition.ArchivedAt
+}
+
+// IsArchived returns whether the competition was archived, archived competitions are not listed by default
+func (c *Competition) IsArchived() bool {
+	return !c.competition.ArchivedAt.IsZero()
+}
+
+// SetArchivedAt sets when the competition was archived, zero to restore it
+func (c *Competition) SetArchivedAt(archivedAt time.Time) {
+	c.competition.ArchivedAt = archivedAt
+}
+
+// GetOrganizationID returns the organization the competition belongs to, 0 for none
+func (c *Competition) GetOrganizationID() int32 {
+	return c.competition.OrganizationID
+}
+
+// SetOrganizationID sets the organization the competition belongs to, 0 for none
+func (c *Competition) SetOrganizationID(organizationID int32) {
+	c.competition.OrganizationID = organizationID
+}
+
+// GetMaxParticipants returns the number of participants registered at most, 0 for no limit
+func (c *Competition) GetMaxParticipants() int32 {
+	return c.competition.MaxParticipants
+}
+
+// SetMaxParticipants sets the number of participants registered at most, 0 for no limit
+func (c *Competition) SetMaxParticipants(maxParticipants int32) {
+	c.competition.MaxParticipants = maxParticipants
+}
+
+// GetRegistrationDeadline returns when the registrations close, zero if they never close
+func (c *Competition) GetRegistrationDeadline() time.Time {
+	return c.competition.RegistrationDeadline
+}
+
+// SetRegistrationDeadline sets when the registrations close, zero if they never close
+func (c *Competition) SetRegistrationDeadline(registrationDeadline time.Time) {
+	c.competition.RegistrationDeadline = registrationDeadline
+}
+
+// GetLocalRegistrationDeadline returns when the registrations close in the time zone of the competition
+func (c *Competition) GetLocalRegistrationDeadline() time.Time {
+	location, err := time.LoadLocation(c.competition.Timezone)
+	if err != nil {
+		return c.competition.RegistrationDeadline
+	}
+	return c.competition.RegistrationDeadline.In(location)
+}
+
+// IsRegistrationClosed returns whether the registration deadline has passed at the given time
+func (c *Competition) IsRegistrationClosed(at time.Time) bool {
+	return !c.competition.RegistrationDeadline.IsZero() && at.After(c.competition.RegistrationDeadline)
+}
+
+// HasWaitlist returns whether the entries over the limit are waitlisted instead of refused
+func (c *Competition) HasWaitlist() bool {
+	return c.competition.Waitlist
+}
+
+// SetWaitlist sets whether the entries over the limit are waitlisted instead of refused
+func (c *Competition) SetWaitlist(waitlist bool) {
+	c.competition.Waitlist = waitlist
+}
+
+// ParseCompetitionDate parses an RFC3339 date, or a date without offset such as a YYYY-MM-DD day read in
+// the IANA time zone, the default one when empty. It reports false when the date or the time zone is invalid.
+func ParseCompetitionDate(date, timezone string) (time.Time, bool) {
+	parsed, _, ok := parseCompetitionDate(date, timezone)
+	return parsed, ok
+}
+
+// ParseRegistrationDeadline parses a registration deadline as ParseCompetitionDate does,
+// the registrations closing at the end of a YYYY-MM-DD day
+func ParseRegistrationDeadline(deadline, timezone string) (time.Time, bool) {
+	parsed, isDay, ok := parseCompetitionDate(deadline, timezone)
+	if ok && isDay {
+		parsed = parsed.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return parsed, ok
+}
+
+// parseCompetitionDate parses the date as ParseCompetitionDate does, also reporting whether it is a day without time
+func parseCompetitionDate(date, timezone string) (time.Time, bool, bool) {
+	date = strings.TrimSpace(date)
+	if parsed, err := time.Parse(time.RFC3339, date); err == nil {
+		return parsed, false, true
+	}
+
+	if timezone == "" {
+		timezone = DefaultCompetitionTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	for _, layout := range competitionDateLayouts {
+		if parsed, err := time.ParseInLocation(layout, date, location); err == nil {
+			return parsed, layout == CompetitionDayLayout, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// IsValidTimezone returns whether the time zone is a known IANA time zone
+func IsValidTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
@@ -0,0 +1,83 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+const (
+	// ContactRoleResults is the role of contacts receiving the results of the competition
+	ContactRoleResults = "results"
+	// ContactRoleLogistics is the role of contacts handling the organization on site
+	ContactRoleLogistics = "logistics"
+)
+
+// ContactRoles lists the roles an organizer contact can have
+var ContactRoles = []string{ContactRoleResults, ContactRoleLogistics}
+
+// CompetitionContact is the aggregate root for the organizer contacts of a competition
+type CompetitionContact struct {
+	contact *entity.CompetitionContact
+}
+
+// NewCompetitionContact creates a new competition contact aggregate
+func NewCompetitionContact() *CompetitionContact {
+	return &CompetitionContact{contact: &entity.CompetitionContact{}}
+}
+
+// GetID returns the contact ID
+func (c *CompetitionContact) GetID() int32 {
+	return c.contact.ID
+}
+
+// GetCompetitionID returns the competition ID
+func (c *CompetitionContact) GetCompetitionID() int32 {
+	return c.contact.CompetitionID
+}
+
+// GetName returns the contact name
+func (c *CompetitionContact) GetName() string {
+	return c.contact.Name
+}
+
+// GetEmail returns the contact email
+func (c *CompetitionContact) GetEmail() string {
+	return c.contact.Email
+}
+
+// GetPhone returns the contact phone number
+func (c *CompetitionContact) GetPhone() string {
+	return c.contact.Phone
+}
+
+// GetRole returns the contact role
+func (c *CompetitionContact) GetRole() string {
+	return c.contact.Role
+}
+
+// SetID sets the contact ID
+func (c *CompetitionContact) SetID(id int32) {
+	c.contact.ID = id
+}
+
+// SetCompetitionID sets the competition ID
+func (c *CompetitionContact) SetCompetitionID(competitionID int32) {
+	c.contact.CompetitionID = competitionID
+}
+
+// SetName sets the contact name
+func (c *CompetitionContact) SetName(name string) {
+	c.contact.Name = name
+}
+
+// SetEmail sets the contact email
+func (c *CompetitionContact) SetEmail(email string) {
+	c.contact.Email = email
+}
+
+// SetPhone sets the contact phone number
+func (c *CompetitionContact) SetPhone(phone string) {
+	c.contact.Phone = phone
+}
+
+// SetRole sets the contact role
+func (c *CompetitionContact) SetRole(role string) {
+	c.contact.Role = role
+}
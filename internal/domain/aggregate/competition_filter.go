@@ -0,0 +1,131 @@
+package aggregate
+
+import "time"
+
+const (
+	// CompetitionSortDate orders the competitions by date
+	CompetitionSortDate = "date"
+	// CompetitionSortName orders the competitions by name
+	CompetitionSortName = "name"
+	// CompetitionSortLocation orders the competitions by location
+	CompetitionSortLocation = "location"
+	// CompetitionSortCreated orders the competitions by creation
+	CompetitionSortCreated = "created"
+)
+
+// CompetitionSorts lists the orders the competitions can be listed in
+var CompetitionSorts = []string{CompetitionSortDate, CompetitionSortName, CompetitionSortLocation, CompetitionSortCreated}
+
+// CompetitionFilter selects and orders the competitions to list, empty criteria are not applied
+type CompetitionFilter struct {
+	// competitionIDs restricts the listed competitions when not nil, an empty list matches none
+	competitionIDs []int32
+	archived       bool
+	search         string
+	dateFrom       string
+	dateTo         string
+	sort           string
+	descending     bool
+}
+
+// NewCompetitionFilter creates a filter listing the competitions that are not archived, the latest first
+func NewCompetitionFilter() *CompetitionFilter {
+	return &CompetitionFilter{
+		sort:       CompetitionSortDate,
+		descending: true,
+	}
+}
+
+// GetCompetitionIDs returns the competitions the listing is restricted to, nil if it is not restricted
+func (f *CompetitionFilter) GetCompetitionIDs() []int32 {
+	return f.competitionIDs
+}
+
+// GetArchived returns whether only the archived competitions are listed instead of the others
+func (f *CompetitionFilter) GetArchived() bool {
+	return f.archived
+}
+
+// GetSearch returns the text the name or location of the competitions contains
+func (f *CompetitionFilter) GetSearch() string {
+	return f.search
+}
+
+// GetDateFrom returns the earliest date of the competitions, as RFC3339 or YYYY-MM-DD
+func (f *CompetitionFilter) GetDateFrom() string {
+	return f.dateFrom
+}
+
+// GetDateTo returns the latest date of the competitions, as RFC3339 or YYYY-MM-DD
+func (f *CompetitionFilter) GetDateTo() string {
+	return f.dateTo
+}
+
+// GetDateRange returns the earliest and latest start of the competitions, zero when unbounded. The days given
+// as YYYY-MM-DD are whole days in the default time zone. It reports false when a date is invalid.
+func (f *CompetitionFilter) GetDateRange() (time.Time, time.Time, bool) {
+	var from, to time.Time
+	if f.dateFrom != "" {
+		date, _, ok := parseCompetitionDate(f.dateFrom, DefaultCompetitionTimezone)
+		if !ok {
+			return time.Time{}, time.Time{}, false
+		}
+		from = date
+	}
+	if f.dateTo != "" {
+		date, day, ok := parseCompetitionDate(f.dateTo, DefaultCompetitionTimezone)
+		if !ok {
+			return time.Time{}, time.Time{}, false
+		}
+		to = date
+		if day {
+			to = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	return from, to, true
+}
+
+// GetSort returns the order of the competitions
+func (f *CompetitionFilter) GetSort() string {
+	return f.sort
+}
+
+// GetDescending returns whether the order is reversed
+func (f *CompetitionFilter) GetDescending() bool {
+	return f.descending
+}
+
+// SetCompetitionIDs restricts the listing to the competitions, nil lifts the restriction
+func (f *CompetitionFilter) SetCompetitionIDs(competitionIDs []int32) {
+	f.competitionIDs = competitionIDs
+}
+
+// SetArchived sets whether only the archived competitions are listed instead of the others
+func (f *CompetitionFilter) SetArchived(archived bool) {
+	f.archived = archived
+}
+
+// SetSearch sets the text the name or location of the competitions contains
+func (f *CompetitionFilter) SetSearch(search string) {
+	f.search = search
+}
+
+// SetDateFrom sets the earliest date of the competitions, as RFC3339 or YYYY-MM-DD
+func (f *CompetitionFilter) SetDateFrom(dateFrom string) {
+	f.dateFrom = dateFrom
+}
+
+// SetDateTo sets the latest date of the competitions, as RFC3339 or YYYY-MM-DD
+func (f *CompetitionFilter) SetDateTo(dateTo string) {
+	f.dateTo = dateTo
+}
+
+// SetSort sets the order of the competitions
+func (f *CompetitionFilter) SetSort(sort string) {
+	f.sort = sort
+}
+
+// SetDescending sets whether the order is reversed
+func (f *CompetitionFilter) SetDescending(descending bool) {
+	f.descending = descending
+}
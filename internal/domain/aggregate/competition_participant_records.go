@@ -0,0 +1,35 @@
+package aggregate
+
+// CompetitionParticipantRecords counts the participants of a competition and their records, all removed when the
+// participants are cleared
+type CompetitionParticipantRecords struct {
+	participants int32
+	records      *ParticipantRecords
+}
+
+// NewCompetitionParticipantRecords creates a new CompetitionParticipantRecords
+func NewCompetitionParticipantRecords() *CompetitionParticipantRecords {
+	return &CompetitionParticipantRecords{
+		records: NewParticipantRecords(),
+	}
+}
+
+// GetParticipants returns the number of participants, waitlisted ones included
+func (c *CompetitionParticipantRecords) GetParticipants() int32 {
+	return c.participants
+}
+
+// GetRecords returns the number of runs, liveranking rows and pending runs of the participants
+func (c *CompetitionParticipantRecords) GetRecords() *ParticipantRecords {
+	return c.records
+}
+
+// SetParticipants sets the number of participants
+func (c *CompetitionParticipantRecords) SetParticipants(participants int32) {
+	c.participants = participants
+}
+
+// SetRecords sets the number of runs, liveranking rows and pending runs of the participants
+func (c *CompetitionParticipantRecords) SetRecords(records *ParticipantRecords) {
+	c.records = records
+}
@@ -0,0 +1,131 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+const (
+	// ScoringAllRuns adds up the points, penalties and chronos of every run of the participant
+	ScoringAllRuns = "all_runs"
+	// ScoringBestRun only counts the best run of the participant in each zone
+	ScoringBestRun = "best_run"
+)
+
+// ScoringModes lists the ways the runs of a competition can be scored
+var ScoringModes = []string{ScoringAllRuns, ScoringBestRun}
+
+const (
+	// RunDSQZeroPoints scores the disqualified runs no points, like the runs not finished
+	RunDSQZeroPoints = "zero_points"
+	// RunDSQDisqualifies also disqualifies the participant of a disqualified run
+	RunDSQDisqualifies = "disqualify"
+)
+
+// RunDSQRules lists the ways the disqualified runs of a competition can be scored
+var RunDSQRules = []string{RunDSQZeroPoints, RunDSQDisqualifies}
+
+// CompetitionSettings is the aggregate root for the settings of a competition.
+// A zero number of runs per zone keeps the historical rule, two runs with two zones and a single run otherwise,
+// and a zero number of zones per category does not limit the zones.
+type CompetitionSettings struct {
+	settings *entity.CompetitionSettings
+}
+
+// NewCompetitionSettings creates new competition settings with the default values
+func NewCompetitionSettings() *CompetitionSettings {
+	return &CompetitionSettings{
+		settings: &entity.CompetitionSettings{
+			Scoring:    ScoringAllRuns,
+			RunDSQRule: RunDSQZeroPoints,
+		},
+	}
+}
+
+// GetCompetitionID returns the competition of the settings
+func (s *CompetitionSettings) GetCompetitionID() int32 {
+	return s.settings.CompetitionID
+}
+
+// GetRunsPerZone returns the number of runs expected from each participant in each zone, zero for the historical rule
+func (s *CompetitionSettings) GetRunsPerZone() int32 {
+	return s.settings.RunsPerZone
+}
+
+// GetZonesPerCategory returns the number of zones of each category, zero if not limited
+func (s *CompetitionSettings) GetZonesPerCategory() int32 {
+	return s.settings.ZonesPerCategory
+}
+
+// GetScoring returns how the runs are scored
+func (s *CompetitionSettings) GetScoring() string {
+	return s.settings.Scoring
+}
+
+// RestrictsRefereeZones returns whether the referees can only record runs in the zones they are assigned to
+func (s *CompetitionSettings) RestrictsRefereeZones() bool {
+	return s.settings.RestrictRefereeZones
+}
+
+// VerifiesLicences returns whether the licence numbers of the imported participants are checked in the register of the federation
+func (s *CompetitionSettings) VerifiesLicences() bool {
+	return s.settings.VerifyLicences
+}
+
+// GetRunDSQRule returns how the disqualified runs are scored
+func (s *CompetitionSettings) GetRunDSQRule() string {
+	return s.settings.RunDSQRule
+}
+
+// RequiresRunApproval returns whether the runs recorded by the referees only count once an admin approves them
+func (s *CompetitionSettings) RequiresRunApproval() bool {
+	return s.settings.RequireRunApproval
+}
+
+// SetCompetitionID sets the competition of the settings
+func (s *CompetitionSettings) SetCompetitionID(competitionID int32) {
+	s.settings.CompetitionID = competitionID
+}
+
+// SetRunsPerZone sets the number of runs expected from each participant in each zone
+func (s *CompetitionSettings) SetRunsPerZone(runsPerZone int32) {
+	s.settings.RunsPerZone = runsPerZone
+}
+
+// SetZonesPerCategory sets the number of zones of each category
+func (s *CompetitionSettings) SetZonesPerCategory(zonesPerCategory int32) {
+	s.settings.ZonesPerCategory = zonesPerCategory
+}
+
+// SetScoring sets how the runs are scored
+func (s *CompetitionSettings) SetScoring(scoring string) {
+	s.settings.Scoring = scoring
+}
+
+// SetRestrictRefereeZones sets whether the referees can only record runs in the zones they are assigned to
+func (s *CompetitionSettings) SetRestrictRefereeZones(restrict bool) {
+	s.settings.RestrictRefereeZones = restrict
+}
+
+// SetVerifyLicences sets whether the licence numbers of the imported participants are checked in the register of the federation
+func (s *CompetitionSettings) SetVerifyLicences(verify bool) {
+	s.settings.VerifyLicences = verify
+}
+
+// SetRunDSQRule sets how the disqualified runs are scored
+func (s *CompetitionSettings) SetRunDSQRule(rule string) {
+	s.settings.RunDSQRule = rule
+}
+
+// SetRequireRunApproval sets whether the runs recorded by the referees only count once an admin approves them
+func (s *CompetitionSettings) SetRequireRunApproval(require bool) {
+	s.settings.RequireRunApproval = require
+}
+
+// ExpectedRunsPerZone returns the number of runs expected from each participant in each of the zones of a category
+func (s *CompetitionSettings) ExpectedRunsPerZone(zoneCount int) int {
+	if s.GetRunsPerZone() > 0 {
+		return int(s.GetRunsPerZone())
+	}
+	if zoneCount == 2 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,111 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// DisplayScope restricts the tokens of display devices to reading the rankings of their competition
+const DisplayScope = "display"
+
+// DisplayRole returns the role of the tokens issued to the display devices of the competition
+func DisplayRole(competitionID int32) string {
+	return fmt.Sprintf("display:%d", competitionID)
+}
+
+// DisplayDevice is the aggregate root for the scoreboard displays of a competition
+type DisplayDevice struct {
+	displayDevice *entity.DisplayDevice
+}
+
+// NewDisplayDevice creates a new display device aggregate
+func NewDisplayDevice() *DisplayDevice {
+	return &DisplayDevice{displayDevice: &entity.DisplayDevice{}}
+}
+
+// GetID returns the device ID
+func (d *DisplayDevice) GetID() int32 {
+	return d.displayDevice.ID
+}
+
+// GetCompetitionID returns the competition the device displays
+func (d *DisplayDevice) GetCompetitionID() int32 {
+	return d.displayDevice.CompetitionID
+}
+
+// GetName returns the name the admin gave to the device, e.g. its location
+func (d *DisplayDevice) GetName() string {
+	return d.displayDevice.Name
+}
+
+// GetCreatedBy returns the admin who registered the device
+func (d *DisplayDevice) GetCreatedBy() int32 {
+	return d.displayDevice.CreatedBy
+}
+
+// GetCreatedAt returns the registration time
+func (d *DisplayDevice) GetCreatedAt() time.Time {
+	return d.displayDevice.CreatedAt
+}
+
+// GetLastSeenAt returns the last time the device refreshed its token, zero if it never did
+func (d *DisplayDevice) GetLastSeenAt() time.Time {
+	return d.displayDevice.LastSeenAt
+}
+
+// GetExpiresAt returns the time after which the tokens of the device can no longer be refreshed
+func (d *DisplayDevice) GetExpiresAt() time.Time {
+	return d.displayDevice.ExpiresAt
+}
+
+// GetRevokedAt returns the revocation time, zero if the device is still active
+func (d *DisplayDevice) GetRevokedAt() time.Time {
+	return d.displayDevice.RevokedAt
+}
+
+// IsActive returns whether the tokens of the device can still be refreshed
+func (d *DisplayDevice) IsActive() bool {
+	return d.displayDevice.RevokedAt.IsZero() && time.Now().Before(d.displayDevice.ExpiresAt)
+}
+
+// SetID sets the device ID
+func (d *DisplayDevice) SetID(id int32) {
+	d.displayDevice.ID = id
+}
+
+// SetCompetitionID sets the competition the device displays
+func (d *DisplayDevice) SetCompetitionID(competitionID int32) {
+	d.displayDevice.CompetitionID = competitionID
+}
+
+// SetName sets the name of the device
+func (d *DisplayDevice) SetName(name string) {
+	d.displayDevice.Name = name
+}
+
+// SetCreatedBy sets the admin who registered the device
+func (d *DisplayDevice) SetCreatedBy(createdBy int32) {
+	d.displayDevice.CreatedBy = createdBy
+}
+
+// SetCreatedAt sets the registration time
+func (d *DisplayDevice) SetCreatedAt(createdAt time.Time) {
+	d.displayDevice.CreatedAt = createdAt
+}
+
+// SetLastSeenAt sets the last time the device refreshed its token
+func (d *DisplayDevice) SetLastSeenAt(lastSeenAt time.Time) {
+	d.displayDevice.LastSeenAt = lastSeenAt
+}
+
+// SetExpiresAt sets the time after which the tokens of the device can no longer be refreshed
+func (d *DisplayDevice) SetExpiresAt(expiresAt time.Time) {
+	d.displayDevice.ExpiresAt = expiresAt
+}
+
+// SetRevokedAt sets the revocation time
+func (d *DisplayDevice) SetRevokedAt(revokedAt time.Time) {
+	d.displayDevice.RevokedAt = revokedAt
+}
@@ -0,0 +1,57 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// ExportTemplate is the aggregate root for the XLSX template of the results export of a competition
+type ExportTemplate struct {
+	exportTemplate *entity.ExportTemplate
+}
+
+// NewExportTemplate creates a new export template aggregate
+func NewExportTemplate() *ExportTemplate {
+	return &ExportTemplate{exportTemplate: &entity.ExportTemplate{}}
+}
+
+// GetCompetitionID returns the competition the template is used for
+func (e *ExportTemplate) GetCompetitionID() int32 {
+	return e.exportTemplate.CompetitionID
+}
+
+// GetFilename returns the name of the uploaded file
+func (e *ExportTemplate) GetFilename() string {
+	return e.exportTemplate.Filename
+}
+
+// GetContent returns the XLSX workbook
+func (e *ExportTemplate) GetContent() []byte {
+	return e.exportTemplate.Content
+}
+
+// GetUploadedAt returns when the template was uploaded
+func (e *ExportTemplate) GetUploadedAt() time.Time {
+	return e.exportTemplate.UploadedAt
+}
+
+// SetCompetitionID sets the competition the template is used for
+func (e *ExportTemplate) SetCompetitionID(competitionID int32) {
+	e.exportTemplate.CompetitionID = competitionID
+}
+
+// SetFilename sets the name of the uploaded file
+func (e *ExportTemplate) SetFilename(filename string) {
+	e.exportTemplate.Filename = filename
+}
+
+// SetContent sets the XLSX workbook
+func (e *ExportTemplate) SetContent(content []byte) {
+	e.exportTemplate.Content = content
+}
+
+// SetUploadedAt sets when the template was uploaded
+func (e *ExportTemplate) SetUploadedAt(uploadedAt time.Time) {
+	e.exportTemplate.UploadedAt = uploadedAt
+}
@@ -0,0 +1,132 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// InvitationRoleReferee is the role given by referee invitation links
+	InvitationRoleReferee = "referee"
+	// InvitationRoleAdmin is the role given by co-admin invitation links
+	InvitationRoleAdmin = "admin"
+)
+
+// Invitation is the aggregate root for the invitation links of a competition
+type Invitation struct {
+	invitation *entity.Invitation
+}
+
+// NewInvitation creates a new invitation aggregate
+func NewInvitation() *Invitation {
+	return &Invitation{invitation: &entity.Invitation{}}
+}
+
+// GetID returns the invitation ID, it is the jti claim of the invitation token
+func (i *Invitation) GetID() string {
+	return i.invitation.ID
+}
+
+// GetCompetitionID returns the competition the invitation gives access to
+func (i *Invitation) GetCompetitionID() int32 {
+	return i.invitation.CompetitionID
+}
+
+// GetRole returns the role given to the users accepting the invitation
+func (i *Invitation) GetRole() string {
+	return i.invitation.Role
+}
+
+// GetCreatedBy returns the ID of the user who created the invitation
+func (i *Invitation) GetCreatedBy() int32 {
+	return i.invitation.CreatedBy
+}
+
+// GetCreatedAt returns when the invitation was created
+func (i *Invitation) GetCreatedAt() time.Time {
+	return i.invitation.CreatedAt
+}
+
+// GetExpiresAt returns when the invitation expires
+func (i *Invitation) GetExpiresAt() time.Time {
+	return i.invitation.ExpiresAt
+}
+
+// GetRevokedAt returns when the invitation was revoked, zero if it was not
+func (i *Invitation) GetRevokedAt() time.Time {
+	return i.invitation.RevokedAt
+}
+
+// GetAcceptances returns how many users accepted the invitation
+func (i *Invitation) GetAcceptances() int32 {
+	return i.invitation.Acceptances
+}
+
+// GetMaxAcceptances returns how many users can accept the invitation, zero if unlimited
+func (i *Invitation) GetMaxAcceptances() int32 {
+	return i.invitation.MaxAcceptances
+}
+
+// GetZone returns the zone the invited referees are expected at, empty if none
+func (i *Invitation) GetZone() string {
+	return i.invitation.Zone
+}
+
+// IsPending returns whether the invitation can still be accepted
+func (i *Invitation) IsPending() bool {
+	if i.invitation.MaxAcceptances > 0 && i.invitation.Acceptances >= i.invitation.MaxAcceptances {
+		return false
+	}
+	return i.invitation.RevokedAt.IsZero() && time.Now().Before(i.invitation.ExpiresAt)
+}
+
+// SetID sets the invitation ID
+func (i *Invitation) SetID(id string) {
+	i.invitation.ID = id
+}
+
+// SetCompetitionID sets the competition the invitation gives access to
+func (i *Invitation) SetCompetitionID(competitionID int32) {
+	i.invitation.CompetitionID = competitionID
+}
+
+// SetRole sets the role given to the users accepting the invitation
+func (i *Invitation) SetRole(role string) {
+	i.invitation.Role = role
+}
+
+// SetCreatedBy sets the ID of the user who created the invitation
+func (i *Invitation) SetCreatedBy(createdBy int32) {
+	i.invitation.CreatedBy = createdBy
+}
+
+// SetCreatedAt sets when the invitation was created
+func (i *Invitation) SetCreatedAt(createdAt time.Time) {
+	i.invitation.CreatedAt = createdAt
+}
+
+// SetExpiresAt sets when the invitation expires
+func (i *Invitation) SetExpiresAt(expiresAt time.Time) {
+	i.invitation.ExpiresAt = expiresAt
+}
+
+// SetRevokedAt sets when the invitation was revoked
+func (i *Invitation) SetRevokedAt(revokedAt time.Time) {
+	i.invitation.RevokedAt = revokedAt
+}
+
+// SetAcceptances sets how many users accepted the invitation
+func (i *Invitation) SetAcceptances(acceptances int32) {
+	i.invitation.Acceptances = acceptances
+}
+
+// SetMaxAcceptances sets how many users can accept the invitation, zero if unlimited
+func (i *Invitation) SetMaxAcceptances(maxAcceptances int32) {
+	i.invitation.MaxAcceptances = maxAcceptances
+}
+
+// SetZone sets the zone the invited referees are expected at
+func (i *Invitation) SetZone(zone string) {
+	i.invitation.Zone = zone
+}
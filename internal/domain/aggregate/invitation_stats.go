@@ -0,0 +1,43 @@
+package aggregate
+
+// InvitationStats counts the invitations of a competition and how many of them were accepted
+type InvitationStats struct {
+	competitionID int32
+	invitations   int32
+	accepted      int32
+}
+
+// NewInvitationStats creates a new InvitationStats
+func NewInvitationStats() *InvitationStats {
+	return &InvitationStats{}
+}
+
+// GetCompetitionID returns the competition ID
+func (i *InvitationStats) GetCompetitionID() int32 {
+	return i.competitionID
+}
+
+// GetInvitations returns the number of invitations created
+func (i *InvitationStats) GetInvitations() int32 {
+	return i.invitations
+}
+
+// GetAccepted returns the number of invitations accepted at least once
+func (i *InvitationStats) GetAccepted() int32 {
+	return i.accepted
+}
+
+// SetCompetitionID sets the competition ID
+func (i *InvitationStats) SetCompetitionID(competitionID int32) {
+	i.competitionID = competitionID
+}
+
+// SetInvitations sets the number of invitations created
+func (i *InvitationStats) SetInvitations(invitations int32) {
+	i.invitations = invitations
+}
+
+// SetAccepted sets the number of invitations accepted at least once
+func (i *InvitationStats) SetAccepted(accepted int32) {
+	i.accepted = accepted
+}
@@ -7,7 +7,8 @@ type Liveranking struct {
 	numberOfRuns int32
 	totalPoints  int32
 	penality     int32
-	chronoSec    int32
+	chronoMs     int32
+	rank         int32
 }
 
 func NewLiveranking() *Liveranking {
@@ -44,6 +45,11 @@ func (l *Liveranking) GetClub() string {
 	return l.participant.Club
 }
 
+// GetConsentDataProcessing returns whether the participant consented to the processing of their personal data
+func (l *Liveranking) GetConsentDataProcessing() bool {
+	return l.participant.ConsentDataProcessing
+}
+
 func (l *Liveranking) GetNumberOfRuns() int32 {
 	return l.numberOfRuns
 }
@@ -57,7 +63,30 @@ func (l *Liveranking) GetPenality() int32 {
 }
 
 func (l *Liveranking) GetChronoSec() int32 {
-	return l.chronoSec
+	return l.chronoMs / 1000
+}
+
+// GetChronoMs returns the total chrono of the participant in milliseconds
+func (l *Liveranking) GetChronoMs() int32 {
+	return l.chronoMs
+}
+
+// GetStatus returns the status of the participant, registered when none was set
+func (l *Liveranking) GetStatus() string {
+	if l.participant.Status == "" {
+		return ParticipantStatusRegistered
+	}
+	return l.participant.Status
+}
+
+// GetStatusReason returns why the participant was disqualified or did not finish
+func (l *Liveranking) GetStatusReason() string {
+	return l.participant.StatusReason
+}
+
+// GetRank returns the rank of the participant in the listed ranking, as computed by the rankings view
+func (l *Liveranking) GetRank() int32 {
+	return l.rank
 }
 
 func (l *Liveranking) SetCompetitionID(competitionID int32) {
@@ -91,6 +120,11 @@ func (l *Liveranking) SetClub(club string) {
 	l.participant.Club = club
 }
 
+// SetConsentDataProcessing sets whether the participant consented to the processing of their personal data
+func (l *Liveranking) SetConsentDataProcessing(consent bool) {
+	l.participant.ConsentDataProcessing = consent
+}
+
 func (l *Liveranking) SetNumberOfRuns(numberOfRuns int32) {
 	l.numberOfRuns = numberOfRuns
 }
@@ -104,5 +138,22 @@ func (l *Liveranking) SetPenality(penality int32) {
 }
 
 func (l *Liveranking) SetChronoSec(chronoSec int32) {
-	l.chronoSec = chronoSec
+	l.chronoMs = chronoSec * 1000
+}
+
+// SetChronoMs sets the total chrono of the participant in milliseconds
+func (l *Liveranking) SetChronoMs(chronoMs int32) {
+	l.chronoMs = chronoMs
+}
+
+func (l *Liveranking) SetStatus(status string) {
+	l.participant.Status = status
+}
+
+func (l *Liveranking) SetStatusReason(reason string) {
+	l.participant.StatusReason = reason
+}
+
+func (l *Liveranking) SetRank(rank int32) {
+	l.rank = rank
 }
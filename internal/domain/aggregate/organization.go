@@ -0,0 +1,81 @@
+package aggregate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// organizationAdminRolePrefix prefixes the roles giving admin access to every competition of an organization
+const organizationAdminRolePrefix = "orgadmin:"
+
+// OrganizationAdminRole returns the role giving admin access to every competition of the organization
+func OrganizationAdminRole(organizationID int32) string {
+	return fmt.Sprintf("%s%d", organizationAdminRolePrefix, organizationID)
+}
+
+// ParseOrganizationAdminRole returns the organization of an organization admin role, false for the other roles
+func ParseOrganizationAdminRole(role string) (int32, bool) {
+	if !strings.HasPrefix(role, organizationAdminRolePrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(role, organizationAdminRolePrefix), 10, 32)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// Organization is the aggregate root for an organization
+type Organization struct {
+	organization   *entity.Organization
+	competitionIDs []int32
+}
+
+// NewOrganization creates a new organization aggregate
+func NewOrganization() *Organization {
+	return &Organization{organization: &entity.Organization{}, competitionIDs: []int32{}}
+}
+
+// GetID returns the organization ID
+func (o *Organization) GetID() int32 {
+	return o.organization.ID
+}
+
+// GetName returns the name of the organization
+func (o *Organization) GetName() string {
+	return o.organization.Name
+}
+
+// GetCreatedAt returns when the organization was created
+func (o *Organization) GetCreatedAt() time.Time {
+	return o.organization.CreatedAt
+}
+
+// GetCompetitionIDs returns the competitions of the organization
+func (o *Organization) GetCompetitionIDs() []int32 {
+	return o.competitionIDs
+}
+
+// SetID sets the organization ID
+func (o *Organization) SetID(id int32) {
+	o.organization.ID = id
+}
+
+// SetName sets the name of the organization
+func (o *Organization) SetName(name string) {
+	o.organization.Name = name
+}
+
+// SetCreatedAt sets when the organization was created
+func (o *Organization) SetCreatedAt(createdAt time.Time) {
+	o.organization.CreatedAt = createdAt
+}
+
+// SetCompetitionIDs sets the competitions of the organization
+func (o *Organization) SetCompetitionIDs(competitionIDs []int32) {
+	o.competitionIDs = competitionIDs
+}
@@ -1,6 +1,66 @@
 package aggregate
 
-import "github.com/NiskuT/cross-api/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// ConsentDataProcessing is the consent to the processing of the personal data of a participant
+	ConsentDataProcessing = "data_processing"
+	// ConsentPhotoRights is the consent to photos of a participant and to their public display
+	ConsentPhotoRights = "photo_rights"
+)
+
+// Consents lists the consents a participant can give
+var Consents = []string{ConsentDataProcessing, ConsentPhotoRights}
+
+const (
+	// ParticipantFlagMedical tells the referees the participant has a medical condition to be aware of
+	ParticipantFlagMedical = "medical"
+	// ParticipantFlagMinor tells the referees the participant is a minor
+	ParticipantFlagMinor = "minor"
+	// ParticipantFlagSpecialStart tells the referees the participant starts under special conditions
+	ParticipantFlagSpecialStart = "special_start"
+)
+
+// ParticipantFlags lists the flags a participant can be given
+var ParticipantFlags = []string{ParticipantFlagMedical, ParticipantFlagMinor, ParticipantFlagSpecialStart}
+
+// IsParticipantFlag checks if a flag is one of the participant flags
+func IsParticipantFlag(flag string) bool {
+	for _, known := range ParticipantFlags {
+		if flag == known {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// ParticipantStatusRegistered is the status of a participant taking part in the competition
+	ParticipantStatusRegistered = "registered"
+	// ParticipantStatusDNS is the status of a participant who did not start, left out of the rankings
+	ParticipantStatusDNS = "dns"
+	// ParticipantStatusDNF is the status of a participant who did not finish, ranked after the finishers
+	ParticipantStatusDNF = "dnf"
+	// ParticipantStatusDSQ is the status of a disqualified participant, ranked last with the reason of the disqualification
+	ParticipantStatusDSQ = "dsq"
+)
+
+// ParticipantStatuses lists the statuses a participant can have
+var ParticipantStatuses = []string{ParticipantStatusRegistered, ParticipantStatusDNS, ParticipantStatusDNF, ParticipantStatusDSQ}
+
+// IsParticipantStatus checks if a status is one of the participant statuses
+func IsParticipantStatus(status string) bool {
+	for _, known := range ParticipantStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
 
 type Participant struct {
 	participant *entity.Participant
@@ -38,6 +98,107 @@ func (p *Participant) GetClub() string {
 	return p.participant.Club
 }
 
+func (p *Participant) GetClubID() int32 {
+	return p.participant.ClubID
+}
+
+func (p *Participant) GetClubEmail() string {
+	return p.participant.ClubEmail
+}
+
+func (p *Participant) GetLicence() string {
+	return p.participant.Licence
+}
+
+// GetBirthDate returns the day of birth of the participant, zero when unknown
+func (p *Participant) GetBirthDate() time.Time {
+	return p.participant.BirthDate
+}
+
+// GetBirthDay returns the day of birth of the participant as YYYY-MM-DD, empty when unknown
+func (p *Participant) GetBirthDay() string {
+	if p.participant.BirthDate.IsZero() {
+		return ""
+	}
+	return p.participant.BirthDate.Format(time.DateOnly)
+}
+
+func (p *Participant) GetConsentDataProcessing() bool {
+	return p.participant.ConsentDataProcessing
+}
+
+func (p *Participant) GetConsentPhotoRights() bool {
+	return p.participant.ConsentPhotoRights
+}
+
+// IsWaitlisted returns whether the participant entered once the competition was full and waits for a place
+func (p *Participant) IsWaitlisted() bool {
+	return p.participant.Waitlisted
+}
+
+// GetWaitlistedAt returns when the participant entered the waitlist, zero when unknown
+func (p *Participant) GetWaitlistedAt() time.Time {
+	return p.participant.WaitlistedAt
+}
+
+// IsCheckedIn returns whether the presence of the participant was confirmed at the start desk
+func (p *Participant) IsCheckedIn() bool {
+	return p.participant.CheckedIn
+}
+
+// GetNotes returns the notes written by the organizers for the referees
+func (p *Participant) GetNotes() string {
+	return p.participant.Notes
+}
+
+// GetFlags returns the flags of the participant, never nil
+func (p *Participant) GetFlags() []string {
+	if p.participant.Flags == nil {
+		return []string{}
+	}
+	return p.participant.Flags
+}
+
+// GetStatus returns the status of the participant, registered when none was set
+func (p *Participant) GetStatus() string {
+	if p.participant.Status == "" {
+		return ParticipantStatusRegistered
+	}
+	return p.participant.Status
+}
+
+// GetStatusReason returns why the participant was disqualified or did not finish, empty when not given
+func (p *Participant) GetStatusReason() string {
+	return p.participant.StatusReason
+}
+
+// GetPhotoKey returns the object key of the photo of the participant, empty without one
+func (p *Participant) GetPhotoKey() string {
+	return p.participant.PhotoKey
+}
+
+// HasFlag returns whether the participant was given the flag
+func (p *Participant) HasFlag(flag string) bool {
+	for _, given := range p.participant.Flags {
+		if given == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasConsent returns whether the participant gave the consent, unknown consents are never given
+func (p *Participant) HasConsent(consent string) bool {
+	switch consent {
+	case ConsentDataProcessing:
+		return p.participant.ConsentDataProcessing
+	case ConsentPhotoRights:
+		return p.participant.ConsentPhotoRights
+	default:
+		return false
+	}
+}
+
 func (p *Participant) SetCompetitionID(competitionID int32) {
 	p.participant.CompetitionID = competitionID
 }
@@ -65,3 +226,67 @@ func (p *Participant) SetGender(gender string) {
 func (p *Participant) SetClub(club string) {
 	p.participant.Club = club
 }
+
+func (p *Participant) SetClubID(clubID int32) {
+	p.participant.ClubID = clubID
+}
+
+func (p *Participant) SetClubEmail(clubEmail string) {
+	p.participant.ClubEmail = clubEmail
+}
+
+func (p *Participant) SetLicence(licence string) {
+	p.participant.Licence = licence
+}
+
+// SetBirthDate sets the day of birth of the participant
+func (p *Participant) SetBirthDate(birthDate time.Time) {
+	p.participant.BirthDate = birthDate
+}
+
+func (p *Participant) SetConsentDataProcessing(consent bool) {
+	p.participant.ConsentDataProcessing = consent
+}
+
+func (p *Participant) SetConsentPhotoRights(consent bool) {
+	p.participant.ConsentPhotoRights = consent
+}
+
+func (p *Participant) SetWaitlisted(waitlisted bool) {
+	p.participant.Waitlisted = waitlisted
+}
+
+// SetWaitlistedAt sets when the participant entered the waitlist
+func (p *Participant) SetWaitlistedAt(waitlistedAt time.Time) {
+	p.participant.WaitlistedAt = waitlistedAt
+}
+
+// SetCheckedIn sets whether the presence of the participant was confirmed at the start desk
+func (p *Participant) SetCheckedIn(checkedIn bool) {
+	p.participant.CheckedIn = checkedIn
+}
+
+// SetNotes sets the notes written by the organizers for the referees
+func (p *Participant) SetNotes(notes string) {
+	p.participant.Notes = notes
+}
+
+// SetFlags sets the flags of the participant
+func (p *Participant) SetFlags(flags []string) {
+	p.participant.Flags = flags
+}
+
+// SetStatus sets the status of the participant
+func (p *Participant) SetStatus(status string) {
+	p.participant.Status = status
+}
+
+// SetStatusReason sets why the participant was disqualified or did not finish
+func (p *Participant) SetStatusReason(reason string) {
+	p.participant.StatusReason = reason
+}
+
+// SetPhotoKey sets the object key of the photo of the participant
+func (p *Participant) SetPhotoKey(photoKey string) {
+	p.participant.PhotoKey = photoKey
+}
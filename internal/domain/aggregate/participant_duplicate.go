@@ -0,0 +1,44 @@
+package aggregate
+
+// ParticipantDuplicate represents two participants of a competition that are likely the same person
+// registered twice under different dossards, e.g. when club files imported separately overlap
+type ParticipantDuplicate struct {
+	participants []*Participant
+	nameDistance int32
+	sameClub     bool
+}
+
+// NewParticipantDuplicate creates a new ParticipantDuplicate
+func NewParticipantDuplicate() *ParticipantDuplicate {
+	return &ParticipantDuplicate{}
+}
+
+// GetParticipants returns the participants that are likely the same person, ordered by dossard
+func (d *ParticipantDuplicate) GetParticipants() []*Participant {
+	return d.participants
+}
+
+// GetNameDistance returns how many letters differ between the names of the participants, zero when they are the same
+func (d *ParticipantDuplicate) GetNameDistance() int32 {
+	return d.nameDistance
+}
+
+// IsSameClub returns whether the participants are registered with the same club
+func (d *ParticipantDuplicate) IsSameClub() bool {
+	return d.sameClub
+}
+
+// AddParticipant adds a participant that is likely the same person
+func (d *ParticipantDuplicate) AddParticipant(participant *Participant) {
+	d.participants = append(d.participants, participant)
+}
+
+// SetNameDistance sets how many letters differ between the names of the participants
+func (d *ParticipantDuplicate) SetNameDistance(nameDistance int32) {
+	d.nameDistance = nameDistance
+}
+
+// SetSameClub sets whether the participants are registered with the same club
+func (d *ParticipantDuplicate) SetSameClub(sameClub bool) {
+	d.sameClub = sameClub
+}
@@ -0,0 +1,54 @@
+package aggregate
+
+// ParticipantFilter selects the participants of a competition to list, empty criteria are not applied
+type ParticipantFilter struct {
+	category string
+	gender   string
+	club     string
+	search   string
+}
+
+// NewParticipantFilter creates a filter listing every participant
+func NewParticipantFilter() *ParticipantFilter {
+	return &ParticipantFilter{}
+}
+
+// GetCategory returns the category of the participants
+func (f *ParticipantFilter) GetCategory() string {
+	return f.category
+}
+
+// GetGender returns the gender of the participants
+func (f *ParticipantFilter) GetGender() string {
+	return f.gender
+}
+
+// GetClub returns the club of the participants
+func (f *ParticipantFilter) GetClub() string {
+	return f.club
+}
+
+// GetSearch returns the text the first or last name of the participants contains
+func (f *ParticipantFilter) GetSearch() string {
+	return f.search
+}
+
+// SetCategory sets the category of the participants
+func (f *ParticipantFilter) SetCategory(category string) {
+	f.category = category
+}
+
+// SetGender sets the gender of the participants
+func (f *ParticipantFilter) SetGender(gender string) {
+	f.gender = gender
+}
+
+// SetClub sets the club of the participants
+func (f *ParticipantFilter) SetClub(club string) {
+	f.club = club
+}
+
+// SetSearch sets the text the first or last name of the participants contains
+func (f *ParticipantFilter) SetSearch(search string) {
+	f.search = search
+}
@@ -0,0 +1,25 @@
+package aggregate
+
+import "time"
+
+// ParticipantHistoryEntry is the result of a person in one competition, as the results export ranks it
+type ParticipantHistoryEntry struct {
+	CompetitionID   int32
+	CompetitionName string
+	CompetitionDate time.Time
+	CompetitionDay  string // YYYY-MM-DD, in the time zone of the competition
+	DossardNumber   int32
+	FirstName       string
+	LastName        string
+	Club            string
+	Category        string
+	Gender          string
+	Status          string // registered, dns, dnf or dsq, the participants who did not start have no result
+	Rank            int32  // rank within the category and gender, 0 when not ranked
+	Ranked          int32  // number of participants ranked in the category and gender
+	TotalPoints     int32
+	TotalPenalty    int32
+	TotalTime       int32
+	PointsEarned    int32
+	Complete        bool // whether the participant has the expected runs in every zone
+}
@@ -0,0 +1,138 @@
+package aggregate
+
+// ParticipantImportRow is a row of a participants import file that was not added, with its line number in the file
+type ParticipantImportRow struct {
+	row     int32
+	dossard int32
+	reason  string
+}
+
+// NewParticipantImportRow creates a new ParticipantImportRow
+func NewParticipantImportRow(row, dossard int32, reason string) *ParticipantImportRow {
+	return &ParticipantImportRow{
+		row:     row,
+		dossard: dossard,
+		reason:  reason,
+	}
+}
+
+// GetRow returns the line number of the row in the file, the header being line 1
+func (r *ParticipantImportRow) GetRow() int32 {
+	return r.row
+}
+
+// GetDossard returns the dossard of the row, 0 when it could not be read
+func (r *ParticipantImportRow) GetDossard() int32 {
+	return r.dossard
+}
+
+// GetReason returns why the row was not added
+func (r *ParticipantImportRow) GetReason() string {
+	return r.reason
+}
+
+// ParticipantImportReport reports the outcome of each row of a participants import
+type ParticipantImportReport struct {
+	dryRun     bool
+	inserted   int32
+	waitlisted int32
+	skipped    []*ParticipantImportRow
+	failed     []*ParticipantImportRow
+	flagged    []*ParticipantImportRow
+	duplicates []*ParticipantImportRow
+	held       bool
+}
+
+// NewParticipantImportReport creates the report of an import, nothing being written on a dry run
+func NewParticipantImportReport(dryRun bool) *ParticipantImportReport {
+	return &ParticipantImportReport{
+		dryRun:     dryRun,
+		skipped:    []*ParticipantImportRow{},
+		failed:     []*ParticipantImportRow{},
+		flagged:    []*ParticipantImportRow{},
+		duplicates: []*ParticipantImportRow{},
+	}
+}
+
+// IsDryRun returns whether the rows were only checked, nothing being written
+func (r *ParticipantImportReport) IsDryRun() bool {
+	return r.dryRun
+}
+
+// GetInserted returns the number of participants added, or that would be on a dry run, waitlisted ones included
+func (r *ParticipantImportReport) GetInserted() int32 {
+	return r.inserted
+}
+
+// GetWaitlisted returns the number of participants added to the waitlist
+func (r *ParticipantImportReport) GetWaitlisted() int32 {
+	return r.waitlisted
+}
+
+// GetSkipped returns the rows skipped because their dossard is already taken
+func (r *ParticipantImportReport) GetSkipped() []*ParticipantImportRow {
+	return r.skipped
+}
+
+// GetSkippedDossards returns the dossards of the rows skipped because they are already taken, in the order of the file
+func (r *ParticipantImportReport) GetSkippedDossards() []int32 {
+	dossards := make([]int32, 0, len(r.skipped))
+	for _, row := range r.skipped {
+		dossards = append(dossards, row.GetDossard())
+	}
+	return dossards
+}
+
+// GetFailed returns the invalid rows and the rows refused by the competition, with the reason
+func (r *ParticipantImportReport) GetFailed() []*ParticipantImportRow {
+	return r.failed
+}
+
+// GetFlagged returns the rows added with a category that disagrees with the birth date, with the reason
+func (r *ParticipantImportReport) GetFlagged() []*ParticipantImportRow {
+	return r.flagged
+}
+
+// GetDuplicates returns the warnings of the rows that likely duplicate a participant or another row, with the reason
+func (r *ParticipantImportReport) GetDuplicates() []*ParticipantImportRow {
+	return r.duplicates
+}
+
+// IsHeld returns whether the import was turned into a dry run because likely duplicates were found
+func (r *ParticipantImportReport) IsHeld() bool {
+	return r.held
+}
+
+// AddInserted counts a participant added, on the waitlist or not
+func (r *ParticipantImportReport) AddInserted(waitlisted bool) {
+	r.inserted++
+	if waitlisted {
+		r.waitlisted++
+	}
+}
+
+// AddSkipped records a row skipped because its dossard is already taken
+func (r *ParticipantImportReport) AddSkipped(row *ParticipantImportRow) {
+	r.skipped = append(r.skipped, row)
+}
+
+// AddFailed records a row that could not be added
+func (r *ParticipantImportReport) AddFailed(row *ParticipantImportRow) {
+	r.failed = append(r.failed, row)
+}
+
+// AddFlagged records a row added with a category that disagrees with the birth date
+func (r *ParticipantImportReport) AddFlagged(row *ParticipantImportRow) {
+	r.flagged = append(r.flagged, row)
+}
+
+// SetDuplicates records the warnings of the rows that likely duplicate a participant or another row
+func (r *ParticipantImportReport) SetDuplicates(duplicates []*ParticipantImportRow) {
+	r.duplicates = duplicates
+}
+
+// Hold turns the import into a dry run, nothing being written until the likely duplicates are confirmed
+func (r *ParticipantImportReport) Hold() {
+	r.dryRun = true
+	r.held = true
+}
@@ -0,0 +1,43 @@
+package aggregate
+
+// ParticipantRecords counts the records of a participant that are removed with it
+type ParticipantRecords struct {
+	runs         int32
+	liverankings int32
+	pendingRuns  int32
+}
+
+// NewParticipantRecords creates a new ParticipantRecords
+func NewParticipantRecords() *ParticipantRecords {
+	return &ParticipantRecords{}
+}
+
+// GetRuns returns the number of runs, voided ones included
+func (p *ParticipantRecords) GetRuns() int32 {
+	return p.runs
+}
+
+// GetLiverankings returns the number of liveranking rows
+func (p *ParticipantRecords) GetLiverankings() int32 {
+	return p.liverankings
+}
+
+// GetPendingRuns returns the number of imported chronos waiting for a referee
+func (p *ParticipantRecords) GetPendingRuns() int32 {
+	return p.pendingRuns
+}
+
+// SetRuns sets the number of runs
+func (p *ParticipantRecords) SetRuns(runs int32) {
+	p.runs = runs
+}
+
+// SetLiverankings sets the number of liveranking rows
+func (p *ParticipantRecords) SetLiverankings(liverankings int32) {
+	p.liverankings = liverankings
+}
+
+// SetPendingRuns sets the number of imported chronos waiting for a referee
+func (p *ParticipantRecords) SetPendingRuns(pendingRuns int32) {
+	p.pendingRuns = pendingRuns
+}
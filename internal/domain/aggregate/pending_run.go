@@ -0,0 +1,92 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// PendingRun is the aggregate root for the imported chronos waiting for a referee to confirm their run
+type PendingRun struct {
+	pendingRun *entity.PendingRun
+}
+
+// NewPendingRun creates a new pending run aggregate
+func NewPendingRun() *PendingRun {
+	return &PendingRun{pendingRun: &entity.PendingRun{}}
+}
+
+// GetID returns the pending run ID
+func (p *PendingRun) GetID() int32 {
+	return p.pendingRun.ID
+}
+
+// GetCompetitionID returns the competition of the pending run
+func (p *PendingRun) GetCompetitionID() int32 {
+	return p.pendingRun.CompetitionID
+}
+
+// GetDossard returns the dossard the timing system recorded the chrono for
+func (p *PendingRun) GetDossard() int32 {
+	return p.pendingRun.Dossard
+}
+
+// GetZone returns the zone the timing system recorded the chrono in
+func (p *PendingRun) GetZone() string {
+	return p.pendingRun.Zone
+}
+
+// GetChronoSec returns the imported chrono in whole seconds
+func (p *PendingRun) GetChronoSec() int32 {
+	return p.pendingRun.ChronoMs / 1000
+}
+
+// GetChronoMs returns the imported chrono in milliseconds
+func (p *PendingRun) GetChronoMs() int32 {
+	return p.pendingRun.ChronoMs
+}
+
+// GetImportedBy returns the admin who imported the chrono
+func (p *PendingRun) GetImportedBy() int32 {
+	return p.pendingRun.ImportedBy
+}
+
+// GetImportedAt returns when the chrono was imported
+func (p *PendingRun) GetImportedAt() time.Time {
+	return p.pendingRun.ImportedAt
+}
+
+// SetID sets the pending run ID
+func (p *PendingRun) SetID(id int32) {
+	p.pendingRun.ID = id
+}
+
+// SetCompetitionID sets the competition of the pending run
+func (p *PendingRun) SetCompetitionID(competitionID int32) {
+	p.pendingRun.CompetitionID = competitionID
+}
+
+// SetDossard sets the dossard the timing system recorded the chrono for
+func (p *PendingRun) SetDossard(dossard int32) {
+	p.pendingRun.Dossard = dossard
+}
+
+// SetZone sets the zone the timing system recorded the chrono in
+func (p *PendingRun) SetZone(zone string) {
+	p.pendingRun.Zone = zone
+}
+
+// SetChronoMs sets the imported chrono in milliseconds
+func (p *PendingRun) SetChronoMs(chronoMs int32) {
+	p.pendingRun.ChronoMs = chronoMs
+}
+
+// SetImportedBy sets the admin who imported the chrono
+func (p *PendingRun) SetImportedBy(importedBy int32) {
+	p.pendingRun.ImportedBy = importedBy
+}
+
+// SetImportedAt sets when the chrono was imported
+func (p *PendingRun) SetImportedAt(importedAt time.Time) {
+	p.pendingRun.ImportedAt = importedAt
+}
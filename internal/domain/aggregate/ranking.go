@@ -0,0 +1,65 @@
+package aggregate
+
+const (
+	// RankingPoints is the ranking criterion on the total points
+	RankingPoints = "points"
+	// RankingPenalty is the ranking criterion on the total penalties
+	RankingPenalty = "penalty"
+	// RankingTime is the ranking criterion on the total chrono
+	RankingTime = "time"
+	// RankingDossard is the ranking criterion on the dossard, so that the order is the same everywhere for full ties
+	RankingDossard = "dossard"
+)
+
+// RankingCriterion is a value participants are ranked on
+type RankingCriterion struct {
+	Name       string
+	Descending bool
+}
+
+// RankingOrder lists the criteria ranking participants: more points, then less penalties, then a shorter time,
+// then the lower dossard. The rankings view of the database orders by it and the exports compare with it,
+// so that the live ranking and the results always rank the participants alike.
+var RankingOrder = []RankingCriterion{
+	{Name: RankingPoints, Descending: true},
+	{Name: RankingPenalty},
+	{Name: RankingTime},
+	{Name: RankingDossard},
+}
+
+// RankingScore holds the values a participant, or a single run, is ranked on
+type RankingScore struct {
+	Points  int32
+	Penalty int32
+	Time    int32
+	Dossard int32
+}
+
+// RanksBefore returns whether the score ranks before another one according to RankingOrder
+func (s RankingScore) RanksBefore(other RankingScore) bool {
+	for _, criterion := range RankingOrder {
+		value, otherValue := s.value(criterion.Name), other.value(criterion.Name)
+		if value == otherValue {
+			continue
+		}
+		if criterion.Descending {
+			return value > otherValue
+		}
+		return value < otherValue
+	}
+	return false
+}
+
+func (s RankingScore) value(criterion string) int32 {
+	switch criterion {
+	case RankingPoints:
+		return s.Points
+	case RankingPenalty:
+		return s.Penalty
+	case RankingTime:
+		return s.Time
+	case RankingDossard:
+		return s.Dossard
+	}
+	return 0
+}
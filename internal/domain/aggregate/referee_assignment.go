@@ -0,0 +1,79 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// RefereeAssignment is the aggregate root for the assignment of a referee to a zone of a competition.
+// A referee can be assigned to several zones.
+type RefereeAssignment struct {
+	assignment *entity.RefereeAssignment
+	user       *User
+}
+
+// NewRefereeAssignment creates a new referee assignment aggregate
+func NewRefereeAssignment() *RefereeAssignment {
+	return &RefereeAssignment{assignment: &entity.RefereeAssignment{}}
+}
+
+// GetCompetitionID returns the competition of the assignment
+func (r *RefereeAssignment) GetCompetitionID() int32 {
+	return r.assignment.CompetitionID
+}
+
+// GetUserID returns the assigned referee
+func (r *RefereeAssignment) GetUserID() int32 {
+	return r.assignment.UserID
+}
+
+// GetUser returns the assigned referee when the assignment was listed with the referees, nil otherwise
+func (r *RefereeAssignment) GetUser() *User {
+	return r.user
+}
+
+// GetZone returns the zone the referee is assigned to
+func (r *RefereeAssignment) GetZone() string {
+	return r.assignment.Zone
+}
+
+// GetAssignedBy returns the admin who assigned the referee
+func (r *RefereeAssignment) GetAssignedBy() int32 {
+	return r.assignment.AssignedBy
+}
+
+// GetAssignedAt returns when the referee was assigned
+func (r *RefereeAssignment) GetAssignedAt() time.Time {
+	return r.assignment.AssignedAt
+}
+
+// SetCompetitionID sets the competition of the assignment
+func (r *RefereeAssignment) SetCompetitionID(competitionID int32) {
+	r.assignment.CompetitionID = competitionID
+}
+
+// SetUserID sets the assigned referee
+func (r *RefereeAssignment) SetUserID(userID int32) {
+	r.assignment.UserID = userID
+}
+
+// SetUser sets the assigned referee
+func (r *RefereeAssignment) SetUser(user *User) {
+	r.user = user
+}
+
+// SetZone sets the zone the referee is assigned to
+func (r *RefereeAssignment) SetZone(zone string) {
+	r.assignment.Zone = zone
+}
+
+// SetAssignedBy sets the admin who assigned the referee
+func (r *RefereeAssignment) SetAssignedBy(assignedBy int32) {
+	r.assignment.AssignedBy = assignedBy
+}
+
+// SetAssignedAt sets when the referee was assigned
+func (r *RefereeAssignment) SetAssignedAt(assignedAt time.Time) {
+	r.assignment.AssignedAt = assignedAt
+}
@@ -0,0 +1,106 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// RefereeOnboarding is the aggregate root for the onboarding checklist of a referee of a competition:
+// accepting the invitation, reading the rules, confirming the zone to referee and submitting a test run
+type RefereeOnboarding struct {
+	onboarding *entity.RefereeOnboarding
+	user       *User
+}
+
+// NewRefereeOnboarding creates a new referee onboarding aggregate
+func NewRefereeOnboarding() *RefereeOnboarding {
+	return &RefereeOnboarding{onboarding: &entity.RefereeOnboarding{}}
+}
+
+// GetCompetitionID returns the competition the referee is onboarded to
+func (r *RefereeOnboarding) GetCompetitionID() int32 {
+	return r.onboarding.CompetitionID
+}
+
+// GetUserID returns the referee
+func (r *RefereeOnboarding) GetUserID() int32 {
+	return r.onboarding.UserID
+}
+
+// GetUser returns the referee when the onboarding was listed with the referees, nil otherwise
+func (r *RefereeOnboarding) GetUser() *User {
+	return r.user
+}
+
+// GetInvitationAcceptedAt returns when the referee accepted the invitation, zero if not yet
+func (r *RefereeOnboarding) GetInvitationAcceptedAt() time.Time {
+	return r.onboarding.InvitationAcceptedAt
+}
+
+// GetRulesReadAt returns when the referee read the rules, zero if not yet
+func (r *RefereeOnboarding) GetRulesReadAt() time.Time {
+	return r.onboarding.RulesReadAt
+}
+
+// GetConfirmedZone returns the zone the referee confirmed to referee, empty if none yet
+func (r *RefereeOnboarding) GetConfirmedZone() string {
+	return r.onboarding.ConfirmedZone
+}
+
+// GetZoneConfirmedAt returns when the referee confirmed the zone, zero if not yet
+func (r *RefereeOnboarding) GetZoneConfirmedAt() time.Time {
+	return r.onboarding.ZoneConfirmedAt
+}
+
+// GetTestRunAt returns when the referee last submitted a test run, zero if never
+func (r *RefereeOnboarding) GetTestRunAt() time.Time {
+	return r.onboarding.TestRunAt
+}
+
+// IsReady returns whether the referee completed every step of the checklist
+func (r *RefereeOnboarding) IsReady() bool {
+	return !r.onboarding.InvitationAcceptedAt.IsZero() &&
+		!r.onboarding.RulesReadAt.IsZero() &&
+		!r.onboarding.ZoneConfirmedAt.IsZero() &&
+		!r.onboarding.TestRunAt.IsZero()
+}
+
+// SetCompetitionID sets the competition the referee is onboarded to
+func (r *RefereeOnboarding) SetCompetitionID(competitionID int32) {
+	r.onboarding.CompetitionID = competitionID
+}
+
+// SetUserID sets the referee
+func (r *RefereeOnboarding) SetUserID(userID int32) {
+	r.onboarding.UserID = userID
+}
+
+// SetUser sets the referee the onboarding is listed with
+func (r *RefereeOnboarding) SetUser(user *User) {
+	r.user = user
+}
+
+// SetInvitationAcceptedAt sets when the referee accepted the invitation
+func (r *RefereeOnboarding) SetInvitationAcceptedAt(acceptedAt time.Time) {
+	r.onboarding.InvitationAcceptedAt = acceptedAt
+}
+
+// SetRulesReadAt sets when the referee read the rules, zero when they have to read them again
+func (r *RefereeOnboarding) SetRulesReadAt(readAt time.Time) {
+	r.onboarding.RulesReadAt = readAt
+}
+
+// SetConfirmedZone sets the zone the referee confirmed and when, an empty zone clearing the confirmation
+func (r *RefereeOnboarding) SetConfirmedZone(zone string, confirmedAt time.Time) {
+	r.onboarding.ConfirmedZone = zone
+	r.onboarding.ZoneConfirmedAt = confirmedAt
+	if zone == "" {
+		r.onboarding.ZoneConfirmedAt = time.Time{}
+	}
+}
+
+// SetTestRunAt sets when the referee submitted a test run
+func (r *RefereeOnboarding) SetTestRunAt(testRunAt time.Time) {
+	r.onboarding.TestRunAt = testRunAt
+}
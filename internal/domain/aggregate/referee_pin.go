@@ -0,0 +1,77 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// RefereePinScope restricts the tokens issued for a referee PIN to recording runs
+const RefereePinScope = "create-run"
+
+// RefereePinRole returns the role of the tokens issued for a referee PIN of the competition.
+// It differs from the referee role so that the token cannot be used anywhere else.
+func RefereePinRole(competitionID int32) string {
+	return fmt.Sprintf("pin-referee:%d", competitionID)
+}
+
+// RefereePin is the aggregate root for the PINs referees log in with on shared tablets
+type RefereePin struct {
+	refereePin *entity.RefereePin
+}
+
+// NewRefereePin creates a new referee PIN aggregate
+func NewRefereePin() *RefereePin {
+	return &RefereePin{refereePin: &entity.RefereePin{}}
+}
+
+// GetCompetitionID returns the competition the PIN logs in to
+func (r *RefereePin) GetCompetitionID() int32 {
+	return r.refereePin.CompetitionID
+}
+
+// GetUserID returns the referee the PIN logs in as
+func (r *RefereePin) GetUserID() int32 {
+	return r.refereePin.UserID
+}
+
+// GetPinHash returns the hash of the PIN, the PIN itself is never stored
+func (r *RefereePin) GetPinHash() string {
+	return r.refereePin.PinHash
+}
+
+// GetCreatedBy returns the admin who generated the PIN
+func (r *RefereePin) GetCreatedBy() int32 {
+	return r.refereePin.CreatedBy
+}
+
+// GetCreatedAt returns when the PIN was generated
+func (r *RefereePin) GetCreatedAt() time.Time {
+	return r.refereePin.CreatedAt
+}
+
+// SetCompetitionID sets the competition the PIN logs in to
+func (r *RefereePin) SetCompetitionID(competitionID int32) {
+	r.refereePin.CompetitionID = competitionID
+}
+
+// SetUserID sets the referee the PIN logs in as
+func (r *RefereePin) SetUserID(userID int32) {
+	r.refereePin.UserID = userID
+}
+
+// SetPinHash sets the hash of the PIN
+func (r *RefereePin) SetPinHash(pinHash string) {
+	r.refereePin.PinHash = pinHash
+}
+
+// SetCreatedBy sets the admin who generated the PIN
+func (r *RefereePin) SetCreatedBy(createdBy int32) {
+	r.refereePin.CreatedBy = createdBy
+}
+
+// SetCreatedAt sets when the PIN was generated
+func (r *RefereePin) SetCreatedAt(createdAt time.Time) {
+	r.refereePin.CreatedAt = createdAt
+}
@@ -0,0 +1,53 @@
+package aggregate
+
+// RolesPruneReport is the outcome of a roles pruning run
+type RolesPruneReport struct {
+	expiredCompetitionIDs []int32
+	prunedUsers           int32
+	removedRoles          int32
+	nearLimitUsers        []*User
+}
+
+// NewRolesPruneReport creates a new roles prune report aggregate
+func NewRolesPruneReport() *RolesPruneReport {
+	return &RolesPruneReport{
+		expiredCompetitionIDs: []int32{},
+		nearLimitUsers:        []*User{},
+	}
+}
+
+// GetExpiredCompetitionIDs returns the competitions whose roles were pruned
+func (r *RolesPruneReport) GetExpiredCompetitionIDs() []int32 {
+	return r.expiredCompetitionIDs
+}
+
+// GetPrunedUsers returns the number of users who lost at least one role
+func (r *RolesPruneReport) GetPrunedUsers() int32 {
+	return r.prunedUsers
+}
+
+// GetRemovedRoles returns the total number of roles removed
+func (r *RolesPruneReport) GetRemovedRoles() int32 {
+	return r.removedRoles
+}
+
+// GetNearLimitUsers returns the users whose roles are still close to the storage limit after pruning
+func (r *RolesPruneReport) GetNearLimitUsers() []*User {
+	return r.nearLimitUsers
+}
+
+// AddExpiredCompetitionID records a competition whose roles are pruned
+func (r *RolesPruneReport) AddExpiredCompetitionID(competitionID int32) {
+	r.expiredCompetitionIDs = append(r.expiredCompetitionIDs, competitionID)
+}
+
+// AddPrunedUser records a user who lost removedRoles roles
+func (r *RolesPruneReport) AddPrunedUser(removedRoles int32) {
+	r.prunedUsers++
+	r.removedRoles += removedRoles
+}
+
+// AddNearLimitUser records a user whose roles are close to the storage limit
+func (r *RolesPruneReport) AddNearLimitUser(user *User) {
+	r.nearLimitUsers = append(r.nearLimitUsers, user)
+}
@@ -1,6 +1,32 @@
 package aggregate
 
-import "github.com/NiskuT/cross-api/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+const (
+	// RunStatusOK is the status of a run scored on its doors
+	RunStatusOK = "ok"
+	// RunStatusDNF is the status of a run the participant did not finish, it scores no points
+	RunStatusDNF = "dnf"
+	// RunStatusDSQ is the status of a disqualified run, scored according to the run DSQ rule of the competition
+	RunStatusDSQ = "dsq"
+)
+
+// RunStatuses lists the statuses a run can have
+var RunStatuses = []string{RunStatusOK, RunStatusDNF, RunStatusDSQ}
+
+// IsRunStatus checks if a status is one of the run statuses
+func IsRunStatus(status string) bool {
+	for _, known := range RunStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
 
 // Run is the aggregate root for run domain
 type Run struct {
@@ -33,49 +59,87 @@ func (r *Run) GetZone() string {
 	return r.run.Zone
 }
 
-// GetDoor1 returns the door1 status
-func (r *Run) GetDoor1() bool {
-	return r.run.Door1
+// GetDoors returns whether each door of the zone was passed, in order
+func (r *Run) GetDoors() []bool {
+	return r.run.Doors
 }
 
-// GetDoor2 returns the door2 status
-func (r *Run) GetDoor2() bool {
-	return r.run.Door2
+// GetPenality returns the penality
+func (r *Run) GetPenality() int32 {
+	return r.run.Penality
 }
 
-// GetDoor3 returns the door3 status
-func (r *Run) GetDoor3() bool {
-	return r.run.Door3
+// GetChronoSec returns the chrono in whole seconds
+func (r *Run) GetChronoSec() int32 {
+	return r.run.ChronoMs / 1000
 }
 
-// GetDoor4 returns the door4 status
-func (r *Run) GetDoor4() bool {
-	return r.run.Door4
+// GetChronoMs returns the chrono in milliseconds
+func (r *Run) GetChronoMs() int32 {
+	return r.run.ChronoMs
 }
 
-// GetDoor5 returns the door5 status
-func (r *Run) GetDoor5() bool {
-	return r.run.Door5
+// GetRefereeId returns the referee ID
+func (r *Run) GetRefereeId() int32 {
+	return r.run.RefereeId
 }
 
-// GetDoor6 returns the door6 status
-func (r *Run) GetDoor6() bool {
-	return r.run.Door6
+// GetCreatedAt returns the time the run was recorded
+func (r *Run) GetCreatedAt() time.Time {
+	return r.run.CreatedAt
 }
 
-// GetPenality returns the penality
-func (r *Run) GetPenality() int32 {
-	return r.run.Penality
+// GetVoidedAt returns when the run was voided, zero if it was not
+func (r *Run) GetVoidedAt() time.Time {
+	return r.run.VoidedAt
 }
 
-// GetChronoSec returns the chrono seconds
-func (r *Run) GetChronoSec() int32 {
-	return r.run.ChronoSec
+// GetUpdatedAt returns the last time the run was modified or voided, its creation time if it never was
+func (r *Run) GetUpdatedAt() time.Time {
+	return r.run.UpdatedAt
 }
 
-// GetRefereeId returns the referee ID
-func (r *Run) GetRefereeId() int32 {
-	return r.run.RefereeId
+// IsVoided checks if the run was voided, voided runs do not count in the ranking
+func (r *Run) IsVoided() bool {
+	return !r.run.VoidedAt.IsZero()
+}
+
+// IsPendingApproval checks if the run waits for an admin to approve it, it does not count in the ranking meanwhile
+func (r *Run) IsPendingApproval() bool {
+	return r.run.PendingApproval
+}
+
+// Counts checks if the run counts in the ranking, neither voided nor waiting for its approval
+func (r *Run) Counts() bool {
+	return !r.IsVoided() && !r.IsPendingApproval()
+}
+
+// GetComment returns the comment of the referee on the run, e.g. a gate dispute or a hardware issue
+func (r *Run) GetComment() string {
+	return r.run.Comment
+}
+
+// GetIdempotencyKey returns the key sent by the client recording the run, so that its retries do not record it twice
+func (r *Run) GetIdempotencyKey() string {
+	return r.run.IdempotencyKey
+}
+
+// GetStatus returns the status of the run, ok unless it was not finished or disqualified
+func (r *Run) GetStatus() string {
+	if r.run.Status == "" {
+		return RunStatusOK
+	}
+	return r.run.Status
+}
+
+// IsScored checks if the points of the doors of the run count, the runs not finished or disqualified score none
+func (r *Run) IsScored() bool {
+	return r.GetStatus() == RunStatusOK
+}
+
+// GetReceiptCode returns the receipt code of the run, written on the paper backup sheet by the referee
+func (r *Run) GetReceiptCode() string {
+	return r.run.ReceiptCode
 }
 
 // GetRefereeName returns the referee name (for detailed queries)
@@ -103,49 +167,69 @@ func (r *Run) SetZone(zone string) {
 	r.run.Zone = zone
 }
 
-// SetDoor1 sets the door1 status
-func (r *Run) SetDoor1(door1 bool) {
-	r.run.Door1 = door1
+// SetDoors sets whether each door of the zone was passed, in order
+func (r *Run) SetDoors(doors []bool) {
+	r.run.Doors = doors
 }
 
-// SetDoor2 sets the door2 status
-func (r *Run) SetDoor2(door2 bool) {
-	r.run.Door2 = door2
+// SetPenality sets the penality
+func (r *Run) SetPenality(penality int32) {
+	r.run.Penality = penality
 }
 
-// SetDoor3 sets the door3 status
-func (r *Run) SetDoor3(door3 bool) {
-	r.run.Door3 = door3
+// SetChronoSec sets the chrono in whole seconds
+func (r *Run) SetChronoSec(chronoSec int32) {
+	r.run.ChronoMs = chronoSec * 1000
 }
 
-// SetDoor4 sets the door4 status
-func (r *Run) SetDoor4(door4 bool) {
-	r.run.Door4 = door4
+// SetChronoMs sets the chrono in milliseconds
+func (r *Run) SetChronoMs(chronoMs int32) {
+	r.run.ChronoMs = chronoMs
 }
 
-// SetDoor5 sets the door5 status
-func (r *Run) SetDoor5(door5 bool) {
-	r.run.Door5 = door5
+// SetRefereeId sets the referee ID
+func (r *Run) SetRefereeId(refereeId int32) {
+	r.run.RefereeId = refereeId
 }
 
-// SetDoor6 sets the door6 status
-func (r *Run) SetDoor6(door6 bool) {
-	r.run.Door6 = door6
+// SetCreatedAt sets the time the run was recorded
+func (r *Run) SetCreatedAt(createdAt time.Time) {
+	r.run.CreatedAt = createdAt
 }
 
-// SetPenality sets the penality
-func (r *Run) SetPenality(penality int32) {
-	r.run.Penality = penality
+// SetVoidedAt sets when the run was voided
+func (r *Run) SetVoidedAt(voidedAt time.Time) {
+	r.run.VoidedAt = voidedAt
 }
 
-// SetChronoSec sets the chrono seconds
-func (r *Run) SetChronoSec(chronoSec int32) {
-	r.run.ChronoSec = chronoSec
+// SetUpdatedAt sets the last time the run was modified or voided
+func (r *Run) SetUpdatedAt(updatedAt time.Time) {
+	r.run.UpdatedAt = updatedAt
 }
 
-// SetRefereeId sets the referee ID
-func (r *Run) SetRefereeId(refereeId int32) {
-	r.run.RefereeId = refereeId
+// SetIdempotencyKey sets the key sent by the client recording the run
+func (r *Run) SetIdempotencyKey(key string) {
+	r.run.IdempotencyKey = key
+}
+
+// SetStatus sets the status of the run
+func (r *Run) SetStatus(status string) {
+	r.run.Status = status
+}
+
+// SetPendingApproval sets whether the run waits for an admin to approve it
+func (r *Run) SetPendingApproval(pending bool) {
+	r.run.PendingApproval = pending
+}
+
+// SetComment sets the comment of the referee on the run
+func (r *Run) SetComment(comment string) {
+	r.run.Comment = comment
+}
+
+// SetReceiptCode sets the receipt code of the run
+func (r *Run) SetReceiptCode(receiptCode string) {
+	r.run.ReceiptCode = receiptCode
 }
 
 // SetRefereeName sets the referee name (for detailed queries)
@@ -0,0 +1,43 @@
+package aggregate
+
+// RunConflict represents a participant scored more than once in the same zone, e.g. by two referees
+type RunConflict struct {
+	dossard int32
+	zone    string
+	runs    []*Run
+}
+
+// NewRunConflict creates a new RunConflict
+func NewRunConflict() *RunConflict {
+	return &RunConflict{}
+}
+
+// GetDossard returns the dossard number of the participant
+func (r *RunConflict) GetDossard() int32 {
+	return r.dossard
+}
+
+// GetZone returns the zone scored more than once
+func (r *RunConflict) GetZone() string {
+	return r.zone
+}
+
+// GetRuns returns the conflicting runs, ordered by run number
+func (r *RunConflict) GetRuns() []*Run {
+	return r.runs
+}
+
+// SetDossard sets the dossard number of the participant
+func (r *RunConflict) SetDossard(dossard int32) {
+	r.dossard = dossard
+}
+
+// SetZone sets the zone scored more than once
+func (r *RunConflict) SetZone(zone string) {
+	r.zone = zone
+}
+
+// AddRun adds a conflicting run
+func (r *RunConflict) AddRun(run *Run) {
+	r.runs = append(r.runs, run)
+}
@@ -0,0 +1,79 @@
+package aggregate
+
+import "time"
+
+// RunFilter selects the runs of a competition to list, empty criteria are not applied
+type RunFilter struct {
+	zone      string
+	dossard   int32
+	refereeID int32
+	from      time.Time
+	to        time.Time
+
+	pendingApprovalOnly bool
+}
+
+// NewRunFilter creates a filter listing every run
+func NewRunFilter() *RunFilter {
+	return &RunFilter{}
+}
+
+// GetZone returns the zone of the runs
+func (f *RunFilter) GetZone() string {
+	return f.zone
+}
+
+// GetDossard returns the dossard of the participant of the runs
+func (f *RunFilter) GetDossard() int32 {
+	return f.dossard
+}
+
+// GetRefereeID returns the ID of the referee who recorded the runs
+func (f *RunFilter) GetRefereeID() int32 {
+	return f.refereeID
+}
+
+// GetFrom returns the earliest recording time of the runs
+func (f *RunFilter) GetFrom() time.Time {
+	return f.from
+}
+
+// GetTo returns the latest recording time of the runs
+func (f *RunFilter) GetTo() time.Time {
+	return f.to
+}
+
+// IsPendingApprovalOnly returns whether only the runs waiting for their approval are listed
+func (f *RunFilter) IsPendingApprovalOnly() bool {
+	return f.pendingApprovalOnly
+}
+
+// SetZone sets the zone of the runs
+func (f *RunFilter) SetZone(zone string) {
+	f.zone = zone
+}
+
+// SetDossard sets the dossard of the participant of the runs
+func (f *RunFilter) SetDossard(dossard int32) {
+	f.dossard = dossard
+}
+
+// SetRefereeID sets the ID of the referee who recorded the runs
+func (f *RunFilter) SetRefereeID(refereeID int32) {
+	f.refereeID = refereeID
+}
+
+// SetFrom sets the earliest recording time of the runs
+func (f *RunFilter) SetFrom(from time.Time) {
+	f.from = from
+}
+
+// SetTo sets the latest recording time of the runs
+func (f *RunFilter) SetTo(to time.Time) {
+	f.to = to
+}
+
+// SetPendingApprovalOnly sets whether only the runs waiting for their approval are listed
+func (f *RunFilter) SetPendingApprovalOnly(pendingApprovalOnly bool) {
+	f.pendingApprovalOnly = pendingApprovalOnly
+}
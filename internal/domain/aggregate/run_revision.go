@@ -0,0 +1,78 @@
+package aggregate
+
+import "time"
+
+// RunRevision is a change of the values of a run, with the run before and after it
+type RunRevision struct {
+	id         int32
+	editorID   int32
+	editorName string
+	createdAt  time.Time
+	before     *Run
+	after      *Run
+}
+
+// NewRunRevision creates a new RunRevision
+func NewRunRevision() *RunRevision {
+	return &RunRevision{}
+}
+
+// GetID returns the ID of the revision
+func (r *RunRevision) GetID() int32 {
+	return r.id
+}
+
+// GetEditorID returns the ID of the user who changed the run
+func (r *RunRevision) GetEditorID() int32 {
+	return r.editorID
+}
+
+// GetEditorName returns the name of the user who changed the run
+func (r *RunRevision) GetEditorName() string {
+	return r.editorName
+}
+
+// GetCreatedAt returns when the run was changed
+func (r *RunRevision) GetCreatedAt() time.Time {
+	return r.createdAt
+}
+
+// GetBefore returns the run as it was before the change
+func (r *RunRevision) GetBefore() *Run {
+	return r.before
+}
+
+// GetAfter returns the run as it was after the change
+func (r *RunRevision) GetAfter() *Run {
+	return r.after
+}
+
+// SetID sets the ID of the revision
+func (r *RunRevision) SetID(id int32) {
+	r.id = id
+}
+
+// SetEditorID sets the ID of the user who changed the run
+func (r *RunRevision) SetEditorID(editorID int32) {
+	r.editorID = editorID
+}
+
+// SetEditorName sets the name of the user who changed the run
+func (r *RunRevision) SetEditorName(editorName string) {
+	r.editorName = editorName
+}
+
+// SetCreatedAt sets when the run was changed
+func (r *RunRevision) SetCreatedAt(createdAt time.Time) {
+	r.createdAt = createdAt
+}
+
+// SetBefore sets the run as it was before the change
+func (r *RunRevision) SetBefore(before *Run) {
+	r.before = before
+}
+
+// SetAfter sets the run as it was after the change
+func (r *RunRevision) SetAfter(after *Run) {
+	r.after = after
+}
@@ -2,6 +2,9 @@ package aggregate
 
 import "github.com/NiskuT/cross-api/internal/domain/entity"
 
+// MaxScaleDoors is the largest number of doors a zone can have
+const MaxScaleDoors = 20
+
 type Scale struct {
 	scale *entity.Scale
 }
@@ -24,28 +27,25 @@ func (s *Scale) GetZone() string {
 	return s.scale.Zone
 }
 
-func (s *Scale) GetPointsDoor1() int32 {
-	return s.scale.PointsDoor1
-}
-
-func (s *Scale) GetPointsDoor2() int32 {
-	return s.scale.PointsDoor2
-}
-
-func (s *Scale) GetPointsDoor3() int32 {
-	return s.scale.PointsDoor3
+// GetDoorPoints returns the points earned for each door of the zone, in order
+func (s *Scale) GetDoorPoints() []int32 {
+	return s.scale.DoorPoints
 }
 
-func (s *Scale) GetPointsDoor4() int32 {
-	return s.scale.PointsDoor4
+// GetDoorCount returns the number of doors of the zone
+func (s *Scale) GetDoorCount() int {
+	return len(s.scale.DoorPoints)
 }
 
-func (s *Scale) GetPointsDoor5() int32 {
-	return s.scale.PointsDoor5
-}
-
-func (s *Scale) GetPointsDoor6() int32 {
-	return s.scale.PointsDoor6
+// CalculatePoints returns the points earned by passing the doors, the doors the scale does not have earn nothing
+func (s *Scale) CalculatePoints(doors []bool) int32 {
+	points := int32(0)
+	for i, passed := range doors {
+		if passed && i < len(s.scale.DoorPoints) {
+			points += s.scale.DoorPoints[i]
+		}
+	}
+	return points
 }
 
 func (s *Scale) SetCompetitionID(competitionID int32) {
@@ -60,26 +60,7 @@ func (s *Scale) SetZone(zone string) {
 	s.scale.Zone = zone
 }
 
-func (s *Scale) SetPointsDoor1(points int32) {
-	s.scale.PointsDoor1 = points
-}
-
-func (s *Scale) SetPointsDoor2(points int32) {
-	s.scale.PointsDoor2 = points
-}
-
-func (s *Scale) SetPointsDoor3(points int32) {
-	s.scale.PointsDoor3 = points
-}
-
-func (s *Scale) SetPointsDoor4(points int32) {
-	s.scale.PointsDoor4 = points
-}
-
-func (s *Scale) SetPointsDoor5(points int32) {
-	s.scale.PointsDoor5 = points
-}
-
-func (s *Scale) SetPointsDoor6(points int32) {
-	s.scale.PointsDoor6 = points
+// SetDoorPoints sets the points earned for each door of the zone, in order
+func (s *Scale) SetDoorPoints(points []int32) {
+	s.scale.DoorPoints = points
 }
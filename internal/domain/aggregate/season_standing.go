@@ -0,0 +1,153 @@
+package aggregate
+
+import "time"
+
+// PointsEarned is the total of points a participant earned in a competition
+type PointsEarned struct {
+	competitionID         int32
+	competitionDate       time.Time
+	firstName             string
+	lastName              string
+	club                  string
+	consentDataProcessing bool
+	points                int32
+}
+
+// NewPointsEarned creates a new PointsEarned
+func NewPointsEarned() *PointsEarned {
+	return &PointsEarned{}
+}
+
+// GetCompetitionID returns the competition the points were earned in
+func (p *PointsEarned) GetCompetitionID() int32 {
+	return p.competitionID
+}
+
+// GetCompetitionDate returns when the competition started, in its time zone
+func (p *PointsEarned) GetCompetitionDate() time.Time {
+	return p.competitionDate
+}
+
+// GetFirstName returns the first name of the participant
+func (p *PointsEarned) GetFirstName() string {
+	return p.firstName
+}
+
+// GetLastName returns the last name of the participant
+func (p *PointsEarned) GetLastName() string {
+	return p.lastName
+}
+
+// GetClub returns the club the participant competed for
+func (p *PointsEarned) GetClub() string {
+	return p.club
+}
+
+// GetConsentDataProcessing returns whether the participant agreed to the processing of their data
+func (p *PointsEarned) GetConsentDataProcessing() bool {
+	return p.consentDataProcessing
+}
+
+// GetPoints returns the points earned, penalties excluded
+func (p *PointsEarned) GetPoints() int32 {
+	return p.points
+}
+
+// SetCompetitionID sets the competition the points were earned in
+func (p *PointsEarned) SetCompetitionID(competitionID int32) {
+	p.competitionID = competitionID
+}
+
+// SetCompetitionDate sets when the competition started, in its time zone
+func (p *PointsEarned) SetCompetitionDate(competitionDate time.Time) {
+	p.competitionDate = competitionDate
+}
+
+// SetFirstName sets the first name of the participant
+func (p *PointsEarned) SetFirstName(firstName string) {
+	p.firstName = firstName
+}
+
+// SetLastName sets the last name of the participant
+func (p *PointsEarned) SetLastName(lastName string) {
+	p.lastName = lastName
+}
+
+// SetClub sets the club the participant competed for
+func (p *PointsEarned) SetClub(club string) {
+	p.club = club
+}
+
+// SetConsentDataProcessing sets whether the participant agreed to the processing of their data
+func (p *PointsEarned) SetConsentDataProcessing(consent bool) {
+	p.consentDataProcessing = consent
+}
+
+// SetPoints sets the points earned
+func (p *PointsEarned) SetPoints(points int32) {
+	p.points = points
+}
+
+// SeasonStanding is the rank of a club or an athlete in the standing of a season
+type SeasonStanding struct {
+	rank         int32
+	name         string
+	club         string
+	points       int32
+	competitions int32
+}
+
+// NewSeasonStanding creates a new SeasonStanding
+func NewSeasonStanding() *SeasonStanding {
+	return &SeasonStanding{}
+}
+
+// GetRank returns the rank, tied standings share the same rank
+func (s *SeasonStanding) GetRank() int32 {
+	return s.rank
+}
+
+// GetName returns the name of the club or of the athlete
+func (s *SeasonStanding) GetName() string {
+	return s.name
+}
+
+// GetClub returns the latest club of the athlete, empty in the club standing
+func (s *SeasonStanding) GetClub() string {
+	return s.club
+}
+
+// GetPoints returns the points earned over the season
+func (s *SeasonStanding) GetPoints() int32 {
+	return s.points
+}
+
+// GetCompetitions returns the number of competitions the points were earned in
+func (s *SeasonStanding) GetCompetitions() int32 {
+	return s.competitions
+}
+
+// SetRank sets the rank
+func (s *SeasonStanding) SetRank(rank int32) {
+	s.rank = rank
+}
+
+// SetName sets the name of the club or of the athlete
+func (s *SeasonStanding) SetName(name string) {
+	s.name = name
+}
+
+// SetClub sets the latest club of the athlete
+func (s *SeasonStanding) SetClub(club string) {
+	s.club = club
+}
+
+// SetPoints sets the points earned over the season
+func (s *SeasonStanding) SetPoints(points int32) {
+	s.points = points
+}
+
+// SetCompetitions sets the number of competitions the points were earned in
+func (s *SeasonStanding) SetCompetitions(competitions int32) {
+	s.competitions = competitions
+}
@@ -0,0 +1,158 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// Series is the aggregate root for a series of competitions
+type Series struct {
+	series         *entity.Series
+	competitionIDs []int32
+}
+
+// NewSeries creates a new series aggregate
+func NewSeries() *Series {
+	return &Series{series: &entity.Series{}, competitionIDs: []int32{}}
+}
+
+// GetID returns the series ID
+func (s *Series) GetID() int32 {
+	return s.series.ID
+}
+
+// GetName returns the name of the series
+func (s *Series) GetName() string {
+	return s.series.Name
+}
+
+// GetCreatedBy returns the user who created the series, who manages it
+func (s *Series) GetCreatedBy() int32 {
+	return s.series.CreatedBy
+}
+
+// GetCreatedAt returns when the series was created
+func (s *Series) GetCreatedAt() time.Time {
+	return s.series.CreatedAt
+}
+
+// GetCompetitionIDs returns the competitions of the series
+func (s *Series) GetCompetitionIDs() []int32 {
+	return s.competitionIDs
+}
+
+// HasCompetition returns whether the competition is part of the series
+func (s *Series) HasCompetition(competitionID int32) bool {
+	for _, id := range s.competitionIDs {
+		if id == competitionID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetID sets the series ID
+func (s *Series) SetID(id int32) {
+	s.series.ID = id
+}
+
+// SetName sets the name of the series
+func (s *Series) SetName(name string) {
+	s.series.Name = name
+}
+
+// SetCreatedBy sets the user who created the series
+func (s *Series) SetCreatedBy(userID int32) {
+	s.series.CreatedBy = userID
+}
+
+// SetCreatedAt sets when the series was created
+func (s *Series) SetCreatedAt(createdAt time.Time) {
+	s.series.CreatedAt = createdAt
+}
+
+// SetCompetitionIDs sets the competitions of the series
+func (s *Series) SetCompetitionIDs(competitionIDs []int32) {
+	s.competitionIDs = competitionIDs
+}
+
+// SeriesResult is the points a participant earned in one competition of a series
+type SeriesResult struct {
+	CompetitionID int32
+	Category      string
+	Gender        string
+	Rank          int32 // rank within the category and gender, as in the results export
+	PointsEarned  int32
+}
+
+// SeriesStanding is the rank of a participant in the standing of a series
+type SeriesStanding struct {
+	rank      int32
+	firstName string
+	lastName  string
+	club      string
+	points    int32
+	results   []SeriesResult
+}
+
+// NewSeriesStanding creates a new SeriesStanding
+func NewSeriesStanding() *SeriesStanding {
+	return &SeriesStanding{}
+}
+
+// GetRank returns the rank, tied standings share the same rank
+func (s *SeriesStanding) GetRank() int32 {
+	return s.rank
+}
+
+// GetFirstName returns the first name of the participant
+func (s *SeriesStanding) GetFirstName() string {
+	return s.firstName
+}
+
+// GetLastName returns the last name of the participant
+func (s *SeriesStanding) GetLastName() string {
+	return s.lastName
+}
+
+// GetClub returns the latest club of the participant
+func (s *SeriesStanding) GetClub() string {
+	return s.club
+}
+
+// GetPoints returns the points earned over the series
+func (s *SeriesStanding) GetPoints() int32 {
+	return s.points
+}
+
+// GetResults returns the points earned in each competition of the series, in the order of the series
+func (s *SeriesStanding) GetResults() []SeriesResult {
+	return s.results
+}
+
+// SetRank sets the rank
+func (s *SeriesStanding) SetRank(rank int32) {
+	s.rank = rank
+}
+
+// SetFirstName sets the first name of the participant
+func (s *SeriesStanding) SetFirstName(firstName string) {
+	s.firstName = firstName
+}
+
+// SetLastName sets the last name of the participant
+func (s *SeriesStanding) SetLastName(lastName string) {
+	s.lastName = lastName
+}
+
+// SetClub sets the latest club of the participant
+func (s *SeriesStanding) SetClub(club string) {
+	s.club = club
+}
+
+// AddResult adds the points earned in a competition of the series
+func (s *SeriesStanding) AddResult(result SeriesResult) {
+	s.results = append(s.results, result)
+	s.points += result.PointsEarned
+}
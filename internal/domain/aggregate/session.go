@@ -0,0 +1,102 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// Session is the aggregate root for refresh sessions
+type Session struct {
+	session *entity.Session
+}
+
+// NewSession creates a new session aggregate
+func NewSession() *Session {
+	return &Session{session: &entity.Session{}}
+}
+
+// GetID returns the session ID
+func (s *Session) GetID() string {
+	return s.session.ID
+}
+
+// GetUserID returns the ID of the user owning the session
+func (s *Session) GetUserID() int32 {
+	return s.session.UserID
+}
+
+// GetUserAgent returns the user agent of the device
+func (s *Session) GetUserAgent() string {
+	return s.session.UserAgent
+}
+
+// GetIP returns the last IP address the session was used from
+func (s *Session) GetIP() string {
+	return s.session.IP
+}
+
+// GetCreatedAt returns the login time
+func (s *Session) GetCreatedAt() time.Time {
+	return s.session.CreatedAt
+}
+
+// GetLastUsedAt returns the last time the session was refreshed
+func (s *Session) GetLastUsedAt() time.Time {
+	return s.session.LastUsedAt
+}
+
+// GetExpiresAt returns the expiration time of the current refresh token
+func (s *Session) GetExpiresAt() time.Time {
+	return s.session.ExpiresAt
+}
+
+// GetRevokedAt returns the revocation time, zero if the session is still active
+func (s *Session) GetRevokedAt() time.Time {
+	return s.session.RevokedAt
+}
+
+// IsActive returns whether the session can still be refreshed
+func (s *Session) IsActive() bool {
+	return s.session.RevokedAt.IsZero() && time.Now().Before(s.session.ExpiresAt)
+}
+
+// SetID sets the session ID
+func (s *Session) SetID(id string) {
+	s.session.ID = id
+}
+
+// SetUserID sets the ID of the user owning the session
+func (s *Session) SetUserID(userID int32) {
+	s.session.UserID = userID
+}
+
+// SetUserAgent sets the user agent of the device
+func (s *Session) SetUserAgent(userAgent string) {
+	s.session.UserAgent = userAgent
+}
+
+// SetIP sets the last IP address the session was used from
+func (s *Session) SetIP(ip string) {
+	s.session.IP = ip
+}
+
+// SetCreatedAt sets the login time
+func (s *Session) SetCreatedAt(createdAt time.Time) {
+	s.session.CreatedAt = createdAt
+}
+
+// SetLastUsedAt sets the last time the session was refreshed
+func (s *Session) SetLastUsedAt(lastUsedAt time.Time) {
+	s.session.LastUsedAt = lastUsedAt
+}
+
+// SetExpiresAt sets the expiration time of the current refresh token
+func (s *Session) SetExpiresAt(expiresAt time.Time) {
+	s.session.ExpiresAt = expiresAt
+}
+
+// SetRevokedAt sets the revocation time
+func (s *Session) SetRevokedAt(revokedAt time.Time) {
+	s.session.RevokedAt = revokedAt
+}
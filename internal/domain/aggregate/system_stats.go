@@ -0,0 +1,76 @@
+package aggregate
+
+// SystemStats counts the records of the whole platform, as seen by the super admins
+type SystemStats struct {
+	users          int32
+	competitions   int32
+	participants   int32
+	runs           int32
+	activeSessions int32
+	apiKeys        int32
+}
+
+// NewSystemStats creates a new SystemStats
+func NewSystemStats() *SystemStats {
+	return &SystemStats{}
+}
+
+// GetUsers returns the number of user accounts
+func (s *SystemStats) GetUsers() int32 {
+	return s.users
+}
+
+// GetCompetitions returns the number of competitions
+func (s *SystemStats) GetCompetitions() int32 {
+	return s.competitions
+}
+
+// GetParticipants returns the number of participants of all competitions
+func (s *SystemStats) GetParticipants() int32 {
+	return s.participants
+}
+
+// GetRuns returns the number of runs of all competitions, voided runs excluded
+func (s *SystemStats) GetRuns() int32 {
+	return s.runs
+}
+
+// GetActiveSessions returns the number of sessions neither revoked nor expired
+func (s *SystemStats) GetActiveSessions() int32 {
+	return s.activeSessions
+}
+
+// GetAPIKeys returns the number of API keys not revoked
+func (s *SystemStats) GetAPIKeys() int32 {
+	return s.apiKeys
+}
+
+// SetUsers sets the number of user accounts
+func (s *SystemStats) SetUsers(users int32) {
+	s.users = users
+}
+
+// SetCompetitions sets the number of competitions
+func (s *SystemStats) SetCompetitions(competitions int32) {
+	s.competitions = competitions
+}
+
+// SetParticipants sets the number of participants of all competitions
+func (s *SystemStats) SetParticipants(participants int32) {
+	s.participants = participants
+}
+
+// SetRuns sets the number of runs of all competitions, voided runs excluded
+func (s *SystemStats) SetRuns(runs int32) {
+	s.runs = runs
+}
+
+// SetActiveSessions sets the number of sessions neither revoked nor expired
+func (s *SystemStats) SetActiveSessions(activeSessions int32) {
+	s.activeSessions = activeSessions
+}
+
+// SetAPIKeys sets the number of API keys not revoked
+func (s *SystemStats) SetAPIKeys(apiKeys int32) {
+	s.apiKeys = apiKeys
+}
@@ -0,0 +1,149 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+// Team is the aggregate root for the teams of a competition
+type Team struct {
+	team *entity.Team
+}
+
+// NewTeam creates a new team aggregate
+func NewTeam() *Team {
+	return &Team{team: &entity.Team{}}
+}
+
+// GetID returns the team ID
+func (t *Team) GetID() int32 {
+	return t.team.ID
+}
+
+// GetCompetitionID returns the competition ID
+func (t *Team) GetCompetitionID() int32 {
+	return t.team.CompetitionID
+}
+
+// GetName returns the team name
+func (t *Team) GetName() string {
+	return t.team.Name
+}
+
+// GetDossards returns the dossards of the members of the team
+func (t *Team) GetDossards() []int32 {
+	return t.team.Dossards
+}
+
+// SetID sets the team ID
+func (t *Team) SetID(id int32) {
+	t.team.ID = id
+}
+
+// SetCompetitionID sets the competition ID
+func (t *Team) SetCompetitionID(competitionID int32) {
+	t.team.CompetitionID = competitionID
+}
+
+// SetName sets the team name
+func (t *Team) SetName(name string) {
+	t.team.Name = name
+}
+
+// SetDossards sets the dossards of the members of the team
+func (t *Team) SetDossards(dossards []int32) {
+	t.team.Dossards = dossards
+}
+
+// TeamMemberScore is the score of a member of a team in the liveranking
+type TeamMemberScore struct {
+	participant  *Participant
+	totalPoints  int32
+	numberOfRuns int32
+	counted      bool
+}
+
+// NewTeamMemberScore creates the score of a member of a team
+func NewTeamMemberScore(participant *Participant) *TeamMemberScore {
+	return &TeamMemberScore{participant: participant}
+}
+
+// GetParticipant returns the member
+func (m *TeamMemberScore) GetParticipant() *Participant {
+	return m.participant
+}
+
+// GetTotalPoints returns the points of the member
+func (m *TeamMemberScore) GetTotalPoints() int32 {
+	return m.totalPoints
+}
+
+// GetNumberOfRuns returns the number of runs of the member
+func (m *TeamMemberScore) GetNumberOfRuns() int32 {
+	return m.numberOfRuns
+}
+
+// IsCounted returns whether the points of the member are among the best ones added up for the team
+func (m *TeamMemberScore) IsCounted() bool {
+	return m.counted
+}
+
+// SetTotalPoints sets the points of the member
+func (m *TeamMemberScore) SetTotalPoints(totalPoints int32) {
+	m.totalPoints = totalPoints
+}
+
+// SetNumberOfRuns sets the number of runs of the member
+func (m *TeamMemberScore) SetNumberOfRuns(numberOfRuns int32) {
+	m.numberOfRuns = numberOfRuns
+}
+
+// SetCounted sets whether the points of the member are added up for the team
+func (m *TeamMemberScore) SetCounted(counted bool) {
+	m.counted = counted
+}
+
+// TeamRanking is the rank of a team of a competition, on the best scores of its members
+type TeamRanking struct {
+	team        *Team
+	rank        int32
+	members     []*TeamMemberScore
+	totalPoints int32
+}
+
+// NewTeamRanking creates the ranking of a team
+func NewTeamRanking(team *Team) *TeamRanking {
+	return &TeamRanking{team: team}
+}
+
+// GetTeam returns the team
+func (r *TeamRanking) GetTeam() *Team {
+	return r.team
+}
+
+// GetRank returns the rank of the team, teams with the same points sharing it
+func (r *TeamRanking) GetRank() int32 {
+	return r.rank
+}
+
+// GetMembers returns the scores of the members of the team
+func (r *TeamRanking) GetMembers() []*TeamMemberScore {
+	return r.members
+}
+
+// GetTotalPoints returns the sum of the counted scores of the members
+func (r *TeamRanking) GetTotalPoints() int32 {
+	return r.totalPoints
+}
+
+// SetRank sets the rank of the team
+func (r *TeamRanking) SetRank(rank int32) {
+	r.rank = rank
+}
+
+// AddMember adds the score of a member of the team
+func (r *TeamRanking) AddMember(member *TeamMemberScore) {
+	r.members = append(r.members, member)
+}
+
+// SetTotalPoints sets the sum of the counted scores of the members
+func (r *TeamRanking) SetTotalPoints(totalPoints int32) {
+	r.totalPoints = totalPoints
+}
@@ -76,6 +76,17 @@ func (u *User) SetRoles(roles string) {
 	u.user.Roles = roles
 }
 
+// GetRoleList returns the user roles as a list, without blank entries
+func (u *User) GetRoleList() []string {
+	roles := []string{}
+	for _, role := range strings.Split(u.GetRoles(), ",") {
+		if trimmed := strings.TrimSpace(role); trimmed != "" {
+			roles = append(roles, trimmed)
+		}
+	}
+	return roles
+}
+
 func (u *User) AddRole(newRole string) {
 	// Split existing roles and trim spaces
 	roles := strings.Split(u.GetRoles(), ",")
@@ -100,3 +111,26 @@ func (u *User) AddRole(newRole string) {
 		u.SetRoles(strings.Join(trimmedRoles, ",") + "," + newRole)
 	}
 }
+
+// RemoveRole removes a role from the user and returns whether the user had it
+func (u *User) RemoveRole(roleToRemove string) bool {
+	roles := strings.Split(u.GetRoles(), ",")
+	keptRoles := make([]string, 0, len(roles))
+	removed := false
+	for _, role := range roles {
+		trimmed := strings.TrimSpace(role)
+		if trimmed == "" {
+			continue
+		}
+		if trimmed == roleToRemove {
+			removed = true
+			continue
+		}
+		keptRoles = append(keptRoles, trimmed)
+	}
+
+	if removed {
+		u.SetRoles(strings.Join(keptRoles, ","))
+	}
+	return removed
+}
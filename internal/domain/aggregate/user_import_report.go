@@ -0,0 +1,75 @@
+package aggregate
+
+const (
+	// UserImportCreated is the status of a row whose account was created
+	UserImportCreated = "created"
+	// UserImportRoleAdded is the status of a row whose existing account was given the competition role
+	UserImportRoleAdded = "role_added"
+	// UserImportSkipped is the status of a row whose account already exists
+	UserImportSkipped = "skipped"
+	// UserImportFailed is the status of a row that could not be imported
+	UserImportFailed = "failed"
+)
+
+// UserImportRow is the outcome of a single row of a users import
+type UserImportRow struct {
+	row     int32
+	email   string
+	status  string
+	message string
+}
+
+// GetRow returns the line number of the row in the file
+func (r *UserImportRow) GetRow() int32 {
+	return r.row
+}
+
+// GetEmail returns the email of the row
+func (r *UserImportRow) GetEmail() string {
+	return r.email
+}
+
+// GetStatus returns the status of the row
+func (r *UserImportRow) GetStatus() string {
+	return r.status
+}
+
+// GetMessage returns why the row failed or what went wrong after the account was created
+func (r *UserImportRow) GetMessage() string {
+	return r.message
+}
+
+// UserImportReport is the outcome of a users import
+type UserImportReport struct {
+	rows   []*UserImportRow
+	counts map[string]int32
+}
+
+// NewUserImportReport creates a new users import report aggregate
+func NewUserImportReport() *UserImportReport {
+	return &UserImportReport{
+		rows:   []*UserImportRow{},
+		counts: make(map[string]int32),
+	}
+}
+
+// GetRows returns the outcome of every row, in file order
+func (r *UserImportReport) GetRows() []*UserImportRow {
+	return r.rows
+}
+
+// Count returns the number of rows with the status
+func (r *UserImportReport) Count(status string) int32 {
+	return r.counts[status]
+}
+
+// AddRow records the outcome of a row
+func (r *UserImportReport) AddRow(row int32, email, status, message string) {
+	r.rows = append(r.rows, &UserImportRow{
+		row:     row,
+		email:   email,
+		status:  status,
+		message: message,
+	})
+	r.counts[status]++
+}
@@ -0,0 +1,67 @@
+package aggregate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// ObserverRole returns the role giving read access to the rankings, runs and participants of the
+// competition without any write access, for coaches and federation delegates
+func ObserverRole(competitionID int32) string {
+	return fmt.Sprintf("observer:%d", competitionID)
+}
+
+// UserRole is the aggregate root for a role granted to a user
+type UserRole struct {
+	role *entity.UserRole
+}
+
+// NewUserRole creates a user role aggregate, the competition is deduced from the role
+// suffix (e.g. "referee:12"), roles such as "admin:*", "create:competition" or the organization
+// admin roles are global
+func NewUserRole(userID int32, role string) *UserRole {
+	competitionID := int32(0)
+	_, isOrganizationRole := ParseOrganizationAdminRole(role)
+	if i := strings.LastIndex(role, ":"); i >= 0 && !isOrganizationRole {
+		if id, err := strconv.ParseInt(role[i+1:], 10, 32); err == nil {
+			competitionID = int32(id)
+		}
+	}
+
+	return &UserRole{role: &entity.UserRole{
+		UserID:        userID,
+		Role:          role,
+		CompetitionID: competitionID,
+	}}
+}
+
+// GetUserID returns the ID of the user having the role
+func (r *UserRole) GetUserID() int32 {
+	return r.role.UserID
+}
+
+// GetRole returns the role
+func (r *UserRole) GetRole() string {
+	return r.role.Role
+}
+
+// GetName returns the role without its competition suffix (e.g. "referee")
+func (r *UserRole) GetName() string {
+	if i := strings.Index(r.role.Role, ":"); i >= 0 {
+		return r.role.Role[:i]
+	}
+	return r.role.Role
+}
+
+// GetCompetitionID returns the competition the role applies to, 0 for global roles
+func (r *UserRole) GetCompetitionID() int32 {
+	return r.role.CompetitionID
+}
+
+// IsGlobal returns whether the role is not tied to a competition
+func (r *UserRole) IsGlobal() bool {
+	return r.role.CompetitionID == 0
+}
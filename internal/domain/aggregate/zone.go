@@ -0,0 +1,94 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+// Zone is the aggregate root for the description of a zone of a competition: what it is, where it is and
+// what it looks like. The scales of the zone reference it by its name.
+type Zone struct {
+	zone *entity.Zone
+}
+
+// NewZone creates a new zone aggregate
+func NewZone() *Zone {
+	return &Zone{zone: &entity.Zone{Open: true}}
+}
+
+// GetCompetitionID returns the competition of the zone
+func (z *Zone) GetCompetitionID() int32 {
+	return z.zone.CompetitionID
+}
+
+// GetZone returns the zone name, the key the scales reference it by
+func (z *Zone) GetZone() string {
+	return z.zone.Zone
+}
+
+// GetDescription returns the description of the zone
+func (z *Zone) GetDescription() string {
+	return z.zone.Description
+}
+
+// GetLocation returns where the zone is on the course, e.g. "below the bridge"
+func (z *Zone) GetLocation() string {
+	return z.zone.Location
+}
+
+// GetCoordinates returns the GPS coordinates of the zone, ok is false when they are not known
+func (z *Zone) GetCoordinates() (latitude, longitude float64, ok bool) {
+	if z.zone.Latitude == nil || z.zone.Longitude == nil {
+		return 0, 0, false
+	}
+	return *z.zone.Latitude, *z.zone.Longitude, true
+}
+
+// GetPhotoURL returns the URL of a photo of the zone
+func (z *Zone) GetPhotoURL() string {
+	return z.zone.PhotoURL
+}
+
+// IsOpen reports whether runs can be recorded in the zone, zones are closed once their judging ends
+func (z *Zone) IsOpen() bool {
+	return z.zone.Open
+}
+
+// SetCompetitionID sets the competition of the zone
+func (z *Zone) SetCompetitionID(competitionID int32) {
+	z.zone.CompetitionID = competitionID
+}
+
+// SetZone sets the zone name
+func (z *Zone) SetZone(zone string) {
+	z.zone.Zone = zone
+}
+
+// SetDescription sets the description of the zone
+func (z *Zone) SetDescription(description string) {
+	z.zone.Description = description
+}
+
+// SetLocation sets where the zone is on the course
+func (z *Zone) SetLocation(location string) {
+	z.zone.Location = location
+}
+
+// SetCoordinates sets the GPS coordinates of the zone
+func (z *Zone) SetCoordinates(latitude, longitude float64) {
+	z.zone.Latitude = &latitude
+	z.zone.Longitude = &longitude
+}
+
+// ClearCoordinates forgets the GPS coordinates of the zone
+func (z *Zone) ClearCoordinates() {
+	z.zone.Latitude = nil
+	z.zone.Longitude = nil
+}
+
+// SetPhotoURL sets the URL of a photo of the zone
+func (z *Zone) SetPhotoURL(photoURL string) {
+	z.zone.PhotoURL = photoURL
+}
+
+// SetOpen opens or closes the zone to new runs
+func (z *Zone) SetOpen(open bool) {
+	z.zone.Open = open
+}
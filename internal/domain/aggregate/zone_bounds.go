@@ -0,0 +1,79 @@
+package aggregate
+
+import "github.com/NiskuT/cross-api/internal/domain/entity"
+
+const (
+	// RunWarningChronoAboveMax flags a run whose chrono exceeds the maximum of its zone
+	RunWarningChronoAboveMax = "chrono_above_max"
+	// RunWarningPenalityAboveMax flags a run whose penalty exceeds the maximum of its zone
+	RunWarningPenalityAboveMax = "penality_above_max"
+)
+
+// ZoneBounds is the aggregate root for the plausible values of the runs of a zone, a zero maximum is not checked
+type ZoneBounds struct {
+	zoneBounds *entity.ZoneBounds
+}
+
+// NewZoneBounds creates a new zone bounds aggregate
+func NewZoneBounds() *ZoneBounds {
+	return &ZoneBounds{zoneBounds: &entity.ZoneBounds{}}
+}
+
+// GetCompetitionID returns the competition of the zone
+func (z *ZoneBounds) GetCompetitionID() int32 {
+	return z.zoneBounds.CompetitionID
+}
+
+// GetZone returns the zone name
+func (z *ZoneBounds) GetZone() string {
+	return z.zoneBounds.Zone
+}
+
+// GetMaxChronoSec returns the longest plausible chrono in seconds, zero if not checked
+func (z *ZoneBounds) GetMaxChronoSec() int32 {
+	return z.zoneBounds.MaxChronoSec
+}
+
+// GetMaxPenality returns the highest plausible penalty, zero if not checked
+func (z *ZoneBounds) GetMaxPenality() int32 {
+	return z.zoneBounds.MaxPenality
+}
+
+// SetCompetitionID sets the competition of the zone
+func (z *ZoneBounds) SetCompetitionID(competitionID int32) {
+	z.zoneBounds.CompetitionID = competitionID
+}
+
+// SetZone sets the zone name
+func (z *ZoneBounds) SetZone(zone string) {
+	z.zoneBounds.Zone = zone
+}
+
+// SetMaxChronoSec sets the longest plausible chrono in seconds
+func (z *ZoneBounds) SetMaxChronoSec(maxChronoSec int32) {
+	z.zoneBounds.MaxChronoSec = maxChronoSec
+}
+
+// SetMaxPenality sets the highest plausible penalty
+func (z *ZoneBounds) SetMaxPenality(maxPenality int32) {
+	z.zoneBounds.MaxPenality = maxPenality
+}
+
+// RunWarning is a value of a run outside the bounds of its zone
+type RunWarning struct {
+	Code  string
+	Value int32
+	Max   int32
+}
+
+// CheckRun returns the values of the run outside the bounds
+func (z *ZoneBounds) CheckRun(run *Run) []RunWarning {
+	warnings := []RunWarning{}
+	if z.GetMaxChronoSec() > 0 && run.GetChronoMs() > z.GetMaxChronoSec()*1000 {
+		warnings = append(warnings, RunWarning{Code: RunWarningChronoAboveMax, Value: run.GetChronoSec(), Max: z.GetMaxChronoSec()})
+	}
+	if z.GetMaxPenality() > 0 && run.GetPenality() > z.GetMaxPenality() {
+		warnings = append(warnings, RunWarning{Code: RunWarningPenalityAboveMax, Value: run.GetPenality(), Max: z.GetMaxPenality()})
+	}
+	return warnings
+}
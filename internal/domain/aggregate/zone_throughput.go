@@ -0,0 +1,67 @@
+package aggregate
+
+import "time"
+
+// ZoneThroughput represents the run throughput of a zone over a time window
+type ZoneThroughput struct {
+	zone               string
+	runCount           int32
+	runsPerHour        float64
+	averageIntervalSec float64
+	lastRunAt          time.Time
+}
+
+// NewZoneThroughput creates a new ZoneThroughput
+func NewZoneThroughput() *ZoneThroughput {
+	return &ZoneThroughput{}
+}
+
+// GetZone returns the zone name
+func (z *ZoneThroughput) GetZone() string {
+	return z.zone
+}
+
+// GetRunCount returns the number of runs recorded in the window
+func (z *ZoneThroughput) GetRunCount() int32 {
+	return z.runCount
+}
+
+// GetRunsPerHour returns the number of runs per hour over the window
+func (z *ZoneThroughput) GetRunsPerHour() float64 {
+	return z.runsPerHour
+}
+
+// GetAverageIntervalSec returns the average time between two consecutive runs, in seconds
+func (z *ZoneThroughput) GetAverageIntervalSec() float64 {
+	return z.averageIntervalSec
+}
+
+// GetLastRunAt returns the time of the last run recorded in the window
+func (z *ZoneThroughput) GetLastRunAt() time.Time {
+	return z.lastRunAt
+}
+
+// SetZone sets the zone name
+func (z *ZoneThroughput) SetZone(zone string) {
+	z.zone = zone
+}
+
+// SetRunCount sets the number of runs recorded in the window
+func (z *ZoneThroughput) SetRunCount(runCount int32) {
+	z.runCount = runCount
+}
+
+// SetRunsPerHour sets the number of runs per hour over the window
+func (z *ZoneThroughput) SetRunsPerHour(runsPerHour float64) {
+	z.runsPerHour = runsPerHour
+}
+
+// SetAverageIntervalSec sets the average time between two consecutive runs, in seconds
+func (z *ZoneThroughput) SetAverageIntervalSec(averageIntervalSec float64) {
+	z.averageIntervalSec = averageIntervalSec
+}
+
+// SetLastRunAt sets the time of the last run recorded in the window
+func (z *ZoneThroughput) SetLastRunAt(lastRunAt time.Time) {
+	z.lastRunAt = lastRunAt
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// APIKey represents an API key entity
+type APIKey struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	Prefix        string
+	KeyHash       string
+	Scopes        string
+	CreatedBy     int32
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+	RevokedAt     time.Time
+}
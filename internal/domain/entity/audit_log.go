@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// AuditLog represents an audit log entry entity
+type AuditLog struct {
+	ID            int32
+	CompetitionID int32
+	UserID        int32
+	Action        string
+	Details       string
+	IP            string
+	CreatedAt     time.Time
+}
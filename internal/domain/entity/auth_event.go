@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// AuthEvent represents an authentication or authorization event of a user
+type AuthEvent struct {
+	ID            int32
+	UserID        int32
+	Email         string
+	Type          string
+	CompetitionID int32
+	Details       string
+	IP            string
+	UserAgent     string
+	CreatedAt     time.Time
+}
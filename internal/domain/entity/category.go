@@ -0,0 +1,11 @@
+package entity
+
+// Category represents a category of a competition, such as an age group
+type Category struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	MinAge        int32
+	MaxAge        int32
+	DisplayOrder  int32
+}
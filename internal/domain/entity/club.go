@@ -0,0 +1,8 @@
+package entity
+
+// Club represents a club the participants of a competition belong to
+type Club struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+}
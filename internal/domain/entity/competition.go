@@ -1,12 +1,27 @@
 package entity
 
+import "time"
+
 // Competition represents a competition entity
 type Competition struct {
 	ID          int32
 	Name        string
 	Description string
-	Date        string
+	Date        time.Time // start of the competition
+	Timezone    string    // IANA time zone the competition takes place in
 	Location    string
 	Organizer   string
 	Contact     string
+
+	ChronoFormat    string
+	ChronoDirection string
+
+	Status     string
+	ArchivedAt time.Time // zero unless the competition was archived
+
+	OrganizationID int32 // organization the competition belongs to, 0 for none
+
+	MaxParticipants      int32     // participants registered at most, 0 for no limit
+	RegistrationDeadline time.Time // zero when the registrations never close
+	Waitlist             bool      // the entries over the limit are waitlisted instead of refused
 }
@@ -0,0 +1,11 @@
+package entity
+
+// CompetitionContact represents an organizer contact of a competition
+type CompetitionContact struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	Email         string
+	Phone         string
+	Role          string
+}
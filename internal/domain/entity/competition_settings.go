@@ -0,0 +1,17 @@
+package entity
+
+// CompetitionSettings represents how the runs of a competition are expected and scored
+type CompetitionSettings struct {
+	CompetitionID    int32
+	RunsPerZone      int32
+	ZonesPerCategory int32
+	Scoring          string
+	// RestrictRefereeZones only lets the referees record runs in the zones they are assigned to
+	RestrictRefereeZones bool
+	// VerifyLicences checks the licence numbers of the imported participants in the register of the federation
+	VerifyLicences bool
+	// RunDSQRule is how the disqualified runs are scored
+	RunDSQRule string
+	// RequireRunApproval keeps the runs recorded by the referees out of the liveranking until an admin approves them
+	RequireRunApproval bool
+}
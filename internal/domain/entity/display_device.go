@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// DisplayDevice represents a scoreboard display registered on a competition
+type DisplayDevice struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	CreatedBy     int32
+	CreatedAt     time.Time
+	LastSeenAt    time.Time
+	ExpiresAt     time.Time
+	RevokedAt     time.Time
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// ExportTemplate represents the XLSX workbook a competition fills its results export with
+type ExportTemplate struct {
+	CompetitionID int32
+	Filename      string
+	Content       []byte
+	UploadedAt    time.Time
+}
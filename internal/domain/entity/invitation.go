@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Invitation represents an invitation link to join a competition
+type Invitation struct {
+	ID            string
+	CompetitionID int32
+	Role          string
+	CreatedBy     int32
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	RevokedAt     time.Time
+	Acceptances   int32
+	// MaxAcceptances is the number of users who can accept the invitation, zero if unlimited
+	MaxAcceptances int32
+	// Zone is the zone the invited referees are expected at, empty if none
+	Zone string
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// Organization represents a club or a federation running several competitions
+type Organization struct {
+	ID        int32
+	Name      string
+	CreatedAt time.Time
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type Participant struct {
 	CompetitionID int32
 	DossardNumber int32
@@ -8,4 +10,23 @@ type Participant struct {
 	Category      string
 	Gender        string
 	Club          string
+	ClubID        int32     // club of the competition named Club, 0 without club
+	ClubEmail     string    // address of the club contact, for the emails sent to the clubs
+	Licence       string    // federation licence number, recognises the participant across the competitions of a series
+	BirthDate     time.Time // day of birth, zero when unknown, places the participant in the category of their age
+
+	ConsentDataProcessing bool // agreed to the processing of their personal data
+	ConsentPhotoRights    bool // agreed to be photographed and shown publicly
+
+	Waitlisted   bool      // entered once the competition was full, waiting for a place
+	WaitlistedAt time.Time // when the participant entered the waitlist, zero when not waitlisted or waitlisted before it was recorded
+	CheckedIn    bool      // confirmed present at the start desk
+
+	Notes string   // free text written by the organizers for the referees
+	Flags []string // structured notices shown to the referees, among the participant flags
+
+	Status       string // registered, dns, dnf or dsq, among the participant statuses
+	StatusReason string // why the participant was disqualified or did not finish
+
+	PhotoKey string // object key of the photo the referees confirm the identity of the participant with, empty without one
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PendingRun represents a chrono imported from a timing system which waits for a referee to confirm its run
+type PendingRun struct {
+	ID            int32
+	CompetitionID int32
+	Dossard       int32
+	Zone          string
+	ChronoMs      int32 // imported chrono in milliseconds
+	ImportedBy    int32
+	ImportedAt    time.Time
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// RefereeAssignment represents a zone a referee of a competition is assigned to
+type RefereeAssignment struct {
+	CompetitionID int32
+	UserID        int32
+	Zone          string
+	AssignedBy    int32
+	AssignedAt    time.Time
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// RefereeOnboarding represents the steps a referee of a competition completed to be ready for the event,
+// a zero time meaning the step is not done yet
+type RefereeOnboarding struct {
+	CompetitionID        int32
+	UserID               int32
+	InvitationAcceptedAt time.Time
+	RulesReadAt          time.Time
+	ConfirmedZone        string
+	ZoneConfirmedAt      time.Time
+	TestRunAt            time.Time
+}
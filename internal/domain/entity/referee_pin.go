@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// RefereePin represents the PIN a referee logs in with on the shared tablets of a competition
+type RefereePin struct {
+	CompetitionID int32
+	UserID        int32
+	PinHash       string
+	CreatedBy     int32
+	CreatedAt     time.Time
+}
@@ -1,17 +1,23 @@
 package entity
 
+import "time"
+
 type Run struct {
-	CompetitionID int32
-	Dossard       int32
-	RunNumber     int32
-	Zone          string
-	Door1         bool
-	Door2         bool
-	Door3         bool
-	Door4         bool
-	Door5         bool
-	Door6         bool
-	Penality      int32
-	ChronoSec     int32
-	RefereeId     int32
+	CompetitionID  int32
+	Dossard        int32
+	RunNumber      int32
+	Zone           string
+	Doors          []bool // whether each door of the zone was passed, in order
+	Penality       int32
+	ChronoMs       int32 // chrono of the run in milliseconds
+	RefereeId      int32
+	CreatedAt      time.Time
+	VoidedAt       time.Time // zero unless the run was voided
+	UpdatedAt      time.Time // last time the run was modified or voided, its creation time otherwise
+	Status         string    // ok, dnf or dsq
+	ReceiptCode    string    // empty for the runs recorded before receipts existed
+	IdempotencyKey string    // sent by the client recording the run, empty if it sent none
+	// PendingApproval is set on the runs recorded by the referees of a competition requiring their approval by an admin
+	PendingApproval bool
+	Comment         string // noted by the referee, e.g. a gate dispute or a hardware issue
 }
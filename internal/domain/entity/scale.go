@@ -5,10 +5,5 @@ type Scale struct {
 	CompetitionID int32
 	Category      string
 	Zone          string
-	PointsDoor1   int32
-	PointsDoor2   int32
-	PointsDoor3   int32
-	PointsDoor4   int32
-	PointsDoor5   int32
-	PointsDoor6   int32
+	DoorPoints    []int32 // points earned for each door of the zone, in order
 }
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Series represents a challenge grouping competitions, the points earned in each of them add up in one standing
+type Series struct {
+	ID        int32
+	Name      string
+	CreatedBy int32
+	CreatedAt time.Time
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// SessionClientKey is the context key holding the SessionClient of the request
+const SessionClientKey = "session_client"
+
+// SessionClient describes the device a request comes from
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// Session represents a refresh session entity, one per logged in device
+type Session struct {
+	ID         string
+	UserID     int32
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  time.Time
+}
@@ -0,0 +1,9 @@
+package entity
+
+// Team represents a named group of participants of a competition ranked together
+type Team struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	Dossards      []int32
+}
@@ -1,7 +1,13 @@
 package entity
 
+import "time"
+
 type UserToken struct {
-	Id    int32    `json:"sub"`
-	Email string   `json:"email"`
-	Roles []string `json:"roles"`
+	Id        int32     `json:"sub"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	SessionID string    `json:"sid"`
+	TokenID   string    `json:"jti"`   // empty for tokens issued before the denylist existed
+	IssuedAt  time.Time `json:"iat"`   // zero for tokens issued before the denylist existed
+	Scope     string    `json:"scope"` // restricts the token to a single action, empty for full access
 }
@@ -0,0 +1,8 @@
+package entity
+
+// UserRole represents a role granted to a user, CompetitionID is 0 for global roles
+type UserRole struct {
+	UserID        int32
+	Role          string
+	CompetitionID int32
+}
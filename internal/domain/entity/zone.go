@@ -0,0 +1,13 @@
+package entity
+
+// Zone represents the description of a zone of a competition, the scales of the zone reference it by its name
+type Zone struct {
+	CompetitionID int32
+	Zone          string
+	Description   string
+	Location      string
+	Latitude      *float64
+	Longitude     *float64
+	PhotoURL      string
+	Open          bool // runs can only be recorded in open zones
+}
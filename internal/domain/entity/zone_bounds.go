@@ -0,0 +1,9 @@
+package entity
+
+// ZoneBounds represents the plausible values of the runs recorded in a zone of a competition
+type ZoneBounds struct {
+	CompetitionID int32
+	Zone          string
+	MaxChronoSec  int32
+	MaxPenality   int32
+}
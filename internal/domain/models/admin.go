@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// SystemStatsResponse represents the usage of the whole platform
+type SystemStatsResponse struct {
+	Users          int32 `json:"users"`
+	Competitions   int32 `json:"competitions"`
+	Participants   int32 `json:"participants"`
+	Runs           int32 `json:"runs"`
+	ActiveSessions int32 `json:"active_sessions"`
+	APIKeys        int32 `json:"api_keys"`
+}
+
+// RateLimitEndpointResponse represents the limit of a rate limited endpoint and the requests it rejected since startup
+type RateLimitEndpointResponse struct {
+	Endpoint      string `json:"endpoint"`
+	MaxAttempts   int    `json:"max_attempts"`
+	WindowSeconds int64  `json:"window_seconds"`
+	Rejections    int64  `json:"rejections"`
+}
+
+// RateLimitKeyResponse represents the current usage of an endpoint by an IP address or a user
+type RateLimitKeyResponse struct {
+	Endpoint  string    `json:"endpoint"`
+	Key       string    `json:"key"`
+	Attempts  int       `json:"attempts"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// RateLimitStateResponse represents the state of the rate limiter
+type RateLimitStateResponse struct {
+	Endpoints []RateLimitEndpointResponse `json:"endpoints"`
+	Keys      []RateLimitKeyResponse      `json:"keys"`
+}
+
+// SchemaStatusResponse represents the migration status of the database schema
+type SchemaStatusResponse struct {
+	ExpectedVersion int32      `json:"expected_version"`
+	DatabaseVersion int32      `json:"database_version"`
+	MigratedAt      *time.Time `json:"migrated_at,omitempty"`
+	CheckedAt       time.Time  `json:"checked_at"`
+	ReadOnly        bool       `json:"read_only"`         // The database was migrated by a newer version, this instance refuses writes
+	Missing         []string   `json:"missing,omitempty"` // Tables and columns the migrations of this version create that the database lacks
+}
+
+// DegradedModeInput represents the request to switch the degraded mode on or off
+type DegradedModeInput struct {
+	Enabled bool `json:"enabled"`
+}
+
+// DegradedModeResponse represents the state of the degraded mode
+type DegradedModeResponse struct {
+	Enabled                    bool       `json:"enabled"`
+	EnabledAt                  *time.Time `json:"enabled_at,omitempty"`
+	EnabledBy                  int32      `json:"enabled_by,omitempty"`
+	PendingLiverankings        int        `json:"pending_liverankings"`
+	LiverankingIntervalSeconds int64      `json:"liveranking_interval_seconds"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// APIKeyInput represents the input for creating an API key
+type APIKeyInput struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required,min=1" example:"read-liveranking,write-runs"`
+}
+
+// APIKeyResponse represents an API key, without its secret value
+type APIKeyResponse struct {
+	ID            int32      `json:"id"`
+	CompetitionID int32      `json:"competition_id"`
+	Name          string     `json:"name"`
+	Prefix        string     `json:"prefix"`
+	Scopes        []string   `json:"scopes"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyCreatedResponse represents a newly created API key.
+// The key is only returned once and cannot be retrieved afterwards.
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse represents the API keys of a competition
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
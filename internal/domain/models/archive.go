@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// ArchiveManifest describes the content of a competition archive, it is stored as manifest.json
+type ArchiveManifest struct {
+	Format              string        `json:"format"`
+	Version             int           `json:"version"`
+	CreatedAt           time.Time     `json:"created_at"`
+	SourceCompetitionID int32         `json:"source_competition_id"`
+	Files               []ArchiveFile `json:"files"`
+}
+
+// ArchiveFile describes a JSON lines file of an archive and the fields of its records
+type ArchiveFile struct {
+	Name    string   `json:"name"`
+	Records int      `json:"records"`
+	Fields  []string `json:"fields"`
+}
+
+// ArchiveCompetition is the record of competition.jsonl
+type ArchiveCompetition struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Date            string `json:"date"`
+	Timezone        string `json:"timezone,omitempty"`
+	Location        string `json:"location"`
+	Organizer       string `json:"organizer"`
+	Contact         string `json:"contact"`
+	ChronoFormat    string `json:"chrono_format"`
+	ChronoDirection string `json:"chrono_direction"`
+}
+
+// ArchiveScale is a record of scales.jsonl
+type ArchiveScale struct {
+	Category   string  `json:"category"`
+	Zone       string  `json:"zone"`
+	DoorPoints []int32 `json:"door_points"`
+}
+
+// ArchiveParticipant is a record of participants.jsonl
+type ArchiveParticipant struct {
+	DossardNumber int32  `json:"dossard_number"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Category      string `json:"category"`
+	Gender        string `json:"gender"`
+	Club          string `json:"club"`
+	ClubEmail     string `json:"club_email,omitempty"`
+	Licence       string `json:"licence,omitempty"`
+	BirthDate     string `json:"birth_date,omitempty"`
+
+	ConsentDataProcessing bool `json:"consent_data_processing"`
+	ConsentPhotoRights    bool `json:"consent_photo_rights"`
+
+	Waitlisted bool `json:"waitlisted,omitempty"`
+	CheckedIn  bool `json:"checked_in,omitempty"`
+}
+
+// ArchiveRun is a record of runs.jsonl, referees are kept by ID only
+type ArchiveRun struct {
+	Dossard   int32      `json:"dossard"`
+	RunNumber int32      `json:"run_number"`
+	Zone      string     `json:"zone"`
+	Doors     []bool     `json:"doors"`
+	Penality  int32      `json:"penality"`
+	ChronoSec int32      `json:"chrono_sec"`
+	ChronoMs  int32      `json:"chrono_ms,omitempty"` // replaces chrono_sec, missing from the archives made before it existed
+	RefereeID int32      `json:"referee_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	VoidedAt  *time.Time `json:"voided_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // set when the run was modified or voided after being recorded
+	Status    string     `json:"status,omitempty"`     // dnf or dsq, empty for the runs scored on their doors
+
+	PendingApproval bool   `json:"pending_approval,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+}
+
+// ArchiveContact is a record of contacts.jsonl
+type ArchiveContact struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+	Role  string `json:"role"`
+}
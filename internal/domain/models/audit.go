@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// AuditLogResponse represents an audit log entry
+type AuditLogResponse struct {
+	ID        int32     `json:"id"`
+	UserID    int32     `json:"user_id,omitempty"`
+	Action    string    `json:"action"`
+	Details   string    `json:"details"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditLogListResponse represents a page of the audit log of a competition
+type AuditLogListResponse struct {
+	CompetitionID int32              `json:"competition_id"`
+	Page          int32              `json:"page"`
+	PageSize      int32              `json:"page_size"`
+	Total         int32              `json:"total"`
+	Entries       []AuditLogResponse `json:"entries"`
+}
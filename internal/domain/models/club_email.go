@@ -0,0 +1,15 @@
+package models
+
+// ClubContactResponse represents a club contact an email was queued for
+type ClubContactResponse struct {
+	Email        string   `json:"email"`
+	Clubs        []string `json:"clubs"`
+	Participants int32    `json:"participants"`
+}
+
+// ClubEmailResponse represents the club contacts of a competition an email was queued for
+type ClubEmailResponse struct {
+	CompetitionID int32                 `json:"competition_id"`
+	Queued        int                   `json:"queued"`
+	Recipients    []ClubContactResponse `json:"recipients"`
+}
@@ -1,38 +1,88 @@
 package models
 
+import "time"
+
 type Competition struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description,omitempty"`
-	Date        string `json:"date,omitempty"`
+	Date        string `json:"date" binding:"required" example:"2025-06-14T09:00:00+02:00"` // RFC3339, or YYYY-MM-DD for midnight in the time zone
+	Timezone    string `json:"timezone,omitempty" example:"Europe/Paris"`                   // IANA time zone, Europe/Paris when empty
 	Location    string `json:"location,omitempty"`
 	Organizer   string `json:"organizer,omitempty"`
 	Contact     string `json:"contact,omitempty"`
+
+	OrganizationID int32 `json:"organization_id,omitempty"` // Organization the competition is created in, the caller must administrate it. Moved with PUT /competition/{competitionID}/organization
 }
 
 type CompetitionResponse struct {
 	ID          int32  `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Date        string `json:"date"`
+	Date        string `json:"date"` // RFC3339, in the time zone of the competition
+	Timezone    string `json:"timezone"`
 	Location    string `json:"location"`
 	Organizer   string `json:"organizer"`
 	Contact     string `json:"contact"`
+	Status      string `json:"status"`
+
+	OrganizationID int32      `json:"organization_id,omitempty"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+}
+
+// CompetitionCloneInput optionally overrides the name and date of a cloned competition
+type CompetitionCloneInput struct {
+	Name string `json:"name,omitempty"`
+	Date string `json:"date,omitempty"` // RFC3339, or YYYY-MM-DD read in the time zone of the copied competition
+}
+
+// CompetitionStatusInput moves a competition to a status of its lifecycle: draft, open, running or closed
+type CompetitionStatusInput struct {
+	Status string `json:"status" binding:"required"`
 }
 
+// CompetitionListResponse represents a page of competitions
 type CompetitionListResponse struct {
+	Page         int32                  `json:"page"`
+	PageSize     int32                  `json:"page_size"`
+	Total        int32                  `json:"total"`
 	Competitions []*CompetitionResponse `json:"competitions"`
 }
 
 type CompetitionScaleInput struct {
-	CompetitionID int32  `json:"competition_id" binding:"required"`
-	Category      string `json:"category" binding:"required"`
-	Zone          string `json:"zone" binding:"required"`
-	PointsDoor1   int32  `json:"points_door1" binding:"required"`
-	PointsDoor2   int32  `json:"points_door2" binding:"required"`
-	PointsDoor3   int32  `json:"points_door3" binding:"required"`
-	PointsDoor4   int32  `json:"points_door4" binding:"required"`
-	PointsDoor5   int32  `json:"points_door5" binding:"required"`
-	PointsDoor6   int32  `json:"points_door6" binding:"required"`
+	CompetitionID int32   `json:"competition_id" binding:"required"`
+	Category      string  `json:"category" binding:"required"`
+	Zone          string  `json:"zone" binding:"required"`
+	DoorPoints    []int32 `json:"door_points" binding:"required,min=1,max=20"` // points of each door of the zone, in order
+}
+
+// ParticipantImportRowResponse represents a row of a participants import that was not added
+type ParticipantImportRowResponse struct {
+	Row     int32  `json:"row"`
+	Dossard int32  `json:"dossard,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// ParticipantImportResponse represents the outcome of each row of a participants import
+type ParticipantImportResponse struct {
+	DryRun     bool  `json:"dry_run"`
+	Inserted   int32 `json:"inserted"`
+	Waitlisted int32 `json:"waitlisted"`
+	// SkippedDossards lists the dossards of the skipped rows, not loaded because they are already taken
+	SkippedDossards []int32                        `json:"skipped_dossards"`
+	Skipped         []ParticipantImportRowResponse `json:"skipped"`
+	Failed          []ParticipantImportRowResponse `json:"failed"`
+	// Flagged lists the rows added with a category that disagrees with the birth date of the participant
+	Flagged []ParticipantImportRowResponse `json:"flagged"`
+	// Duplicates lists the rows that likely duplicate a participant or another row, when the duplicates are checked
+	Duplicates []ParticipantImportRowResponse `json:"duplicates"`
+	// Held tells the import was turned into a dry run because of the duplicates
+	Held bool `json:"held"`
+}
+
+// ScaleImportResponse represents the outcome of a scales import
+type ScaleImportResponse struct {
+	Created int32 `json:"created"`
+	Updated int32 `json:"updated"`
 }
 
 // CompetitionZoneDeleteInput represents the input for deleting a zone from a competition
@@ -50,9 +100,64 @@ type RefereeInput struct {
 	Email         string `json:"email" binding:"required,email"`
 }
 
+// AdminInput represents the input for inviting a co-admin to a competition by email
+type AdminInput struct {
+	CompetitionID int32  `json:"competition_id" binding:"required"`
+	Email         string `json:"email" binding:"required,email"`
+}
+
 // RefereeInvitationResponse represents the response for generating a referee invitation link
 type RefereeInvitationResponse struct {
-	Token     string `json:"token"`
+	InvitationID string `json:"invitation_id"`
+	Token        string `json:"token"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+// InvitationResponse represents a pending invitation of a competition
+type InvitationResponse struct {
+	ID             string    `json:"id"`
+	Role           string    `json:"role"`
+	Zone           string    `json:"zone,omitempty"`
+	CreatedBy      int32     `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Acceptances    int32     `json:"acceptances"`
+	MaxAcceptances int32     `json:"max_acceptances,omitempty"`
+}
+
+// InvitationListResponse represents the pending invitations of a competition
+type InvitationListResponse struct {
+	CompetitionID int32                `json:"competition_id"`
+	Invitations   []InvitationResponse `json:"invitations"`
+}
+
+// RefereeInvitationBatchInput represents the input for generating several single-use referee invitation links
+type RefereeInvitationBatchInput struct {
+	Count           int32  `json:"count" binding:"required"`
+	Zone            string `json:"zone,omitempty"`
+	LifetimeMinutes int32  `json:"lifetime_minutes,omitempty"`
+}
+
+// RefereeInvitationBatchResponse represents the single-use referee invitation links generated in one call
+type RefereeInvitationBatchResponse struct {
+	CompetitionID int32                       `json:"competition_id"`
+	Zone          string                      `json:"zone,omitempty"`
+	Invitations   []RefereeInvitationResponse `json:"invitations"`
+}
+
+// InvitationExtendInput represents the input for extending an invitation
+type InvitationExtendInput struct {
+	LifetimeMinutes int32 `json:"lifetime_minutes"`
+}
+
+// SignedURLInput represents the input for signing a download URL, the default lifetime applies when omitted
+type SignedURLInput struct {
+	LifetimeMinutes int32 `json:"lifetime_minutes"`
+}
+
+// SignedURLResponse represents a download URL that can be opened without being authenticated until it expires
+type SignedURLResponse struct {
+	URL       string `json:"url"` // path and query, relative to the API URL
 	ExpiresAt int64  `json:"expires_at"`
 }
 
@@ -72,14 +177,9 @@ type RefereeInvitationAcceptUnauthenticatedInput struct {
 
 // ZoneResponse represents a single zone in a competition
 type ZoneResponse struct {
-	Zone        string `json:"zone"`
-	Category    string `json:"category"`
-	PointsDoor1 int32  `json:"points_door1"`
-	PointsDoor2 int32  `json:"points_door2"`
-	PointsDoor3 int32  `json:"points_door3"`
-	PointsDoor4 int32  `json:"points_door4"`
-	PointsDoor5 int32  `json:"points_door5"`
-	PointsDoor6 int32  `json:"points_door6"`
+	Zone       string  `json:"zone"`
+	Category   string  `json:"category"`
+	DoorPoints []int32 `json:"door_points"`
 }
 
 // ZonesListResponse represents a list of zones in a competition
@@ -88,6 +188,125 @@ type ZonesListResponse struct {
 	Zones         []ZoneResponse `json:"zones"`
 }
 
+// PublicZoneResponse represents a zone of a category as shown to spectators
+type PublicZoneResponse struct {
+	Category string `json:"category"`
+	Zone     string `json:"zone"`
+}
+
+// PublicCompetitionResponse represents the information of a competition published to spectators
+type PublicCompetitionResponse struct {
+	ID         int32                `json:"id"`
+	Name       string               `json:"name"`
+	Date       string               `json:"date"`
+	Timezone   string               `json:"timezone"`
+	Location   string               `json:"location"`
+	Status     string               `json:"status"`
+	Categories []string             `json:"categories"`
+	Zones      []PublicZoneResponse `json:"zones"`
+	ReadAt     time.Time            `json:"read_at"`
+}
+
+// CompetitionSettingsInput represents how the runs of a competition are expected and scored
+type CompetitionSettingsInput struct {
+	RunsPerZone      int32  `json:"runs_per_zone"`
+	ZonesPerCategory int32  `json:"zones_per_category"`
+	Scoring          string `json:"scoring" example:"all_runs"`
+	// RestrictRefereeZones only lets the referees record runs in the zones they are assigned to
+	RestrictRefereeZones bool `json:"restrict_referee_zones"`
+	// VerifyLicences checks the licence numbers of the imported participants in the register of the federation
+	VerifyLicences bool `json:"verify_licences"`
+	// RunDSQRule is how the disqualified runs are scored: zero_points, or disqualify to also disqualify their participant
+	RunDSQRule string `json:"run_dsq_rule" example:"zero_points"`
+	// RequireRunApproval keeps the runs recorded by the referees pending, out of the liveranking, until an admin approves them
+	RequireRunApproval bool `json:"require_run_approval"`
+}
+
+// CompetitionSettingsResponse represents the settings of a competition
+type CompetitionSettingsResponse struct {
+	CompetitionID        int32  `json:"competition_id"`
+	RunsPerZone          int32  `json:"runs_per_zone"`
+	ZonesPerCategory     int32  `json:"zones_per_category"`
+	Scoring              string `json:"scoring"`
+	RestrictRefereeZones bool   `json:"restrict_referee_zones"`
+	VerifyLicences       bool   `json:"verify_licences"`
+	RunDSQRule           string `json:"run_dsq_rule"`
+	RequireRunApproval   bool   `json:"require_run_approval"`
+}
+
+// ZoneBoundsInput represents the plausible values of the runs of a zone, a zero maximum is not checked
+type ZoneBoundsInput struct {
+	Zone         string `json:"zone" binding:"required"`
+	MaxChronoSec int32  `json:"max_chrono_sec"`
+	MaxPenality  int32  `json:"max_penality"`
+}
+
+// ZoneBoundsResponse represents the plausible values of the runs of a zone
+type ZoneBoundsResponse struct {
+	Zone         string `json:"zone"`
+	MaxChronoSec int32  `json:"max_chrono_sec"`
+	MaxPenality  int32  `json:"max_penality"`
+}
+
+// ZoneBoundsListResponse represents the bounds of the zones of a competition
+type ZoneBoundsListResponse struct {
+	CompetitionID int32                `json:"competition_id"`
+	Zones         []ZoneBoundsResponse `json:"zones"`
+}
+
+// ZoneDetailsInput represents the description of a zone, the latitude and longitude are given together or not at all
+type ZoneDetailsInput struct {
+	Description string   `json:"description" binding:"max=2000"`
+	Location    string   `json:"location" binding:"max=255"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	PhotoURL    string   `json:"photo_url" binding:"max=2048"`
+}
+
+// ZoneCreateInput represents the input for describing a new zone of a competition
+type ZoneCreateInput struct {
+	Zone string `json:"zone" binding:"required,max=100"`
+	ZoneDetailsInput
+}
+
+// ZoneDetailsResponse represents the description of a zone, the coordinates are left out when unknown
+type ZoneDetailsResponse struct {
+	Zone        string   `json:"zone"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	Latitude    *float64 `json:"latitude,omitempty"`
+	Longitude   *float64 `json:"longitude,omitempty"`
+	PhotoURL    string   `json:"photo_url"`
+	IsOpen      bool     `json:"is_open"`
+}
+
+// ZoneStateInput represents the input for opening or closing a zone to new runs
+type ZoneStateInput struct {
+	IsOpen *bool `json:"is_open" binding:"required"`
+}
+
+// ZoneDetailsListResponse represents the descriptions of the zones of a competition
+type ZoneDetailsListResponse struct {
+	CompetitionID int32                 `json:"competition_id"`
+	Zones         []ZoneDetailsResponse `json:"zones"`
+}
+
+// ZoneThroughputResponse represents the run throughput of a single zone
+type ZoneThroughputResponse struct {
+	Zone               string     `json:"zone"`
+	RunCount           int32      `json:"run_count"`
+	RunsPerHour        float64    `json:"runs_per_hour"`
+	AverageIntervalSec float64    `json:"average_interval_sec"`
+	LastRunAt          *time.Time `json:"last_run_at,omitempty"`
+}
+
+// ZoneThroughputListResponse represents the run throughput of every zone in a competition
+type ZoneThroughputListResponse struct {
+	CompetitionID int32                    `json:"competition_id"`
+	WindowMinutes int32                    `json:"window_minutes"`
+	Zones         []ZoneThroughputResponse `json:"zones"`
+}
+
 // LiverankingResponse represents a single liveranking entry
 type LiverankingResponse struct {
 	Rank         int32  `json:"rank"`
@@ -101,6 +320,9 @@ type LiverankingResponse struct {
 	TotalPoints  int32  `json:"total_points"`
 	Penality     int32  `json:"penality"`
 	ChronoSec    int32  `json:"chrono_sec"`
+	ChronoMs     int32  `json:"chrono_ms"`
+	Status       string `json:"status"`                  // registered, dnf or dsq, the dnf then dsq participants are ranked last
+	StatusReason string `json:"status_reason,omitempty"` // why the participant was disqualified or did not finish
 }
 
 // LiverankingListResponse represents a list of liveranking entries
@@ -113,3 +335,199 @@ type LiverankingListResponse struct {
 	Total         int32                 `json:"total"`
 	Rankings      []LiverankingResponse `json:"rankings"`
 }
+
+// LiverankingVersionResponse represents the liveranking version of a competition returned by the long poll
+type LiverankingVersionResponse struct {
+	CompetitionID int32 `json:"competition_id"`
+	Version       int64 `json:"version"`
+	Changed       bool  `json:"changed"` // false when the wait timed out with the version unchanged
+}
+
+// CategoryInput represents the input for adding or updating a category of a competition, a zero age is not limited
+type CategoryInput struct {
+	Name         string `json:"name" binding:"required" example:"U15"`
+	MinAge       int32  `json:"min_age"`
+	MaxAge       int32  `json:"max_age"`
+	DisplayOrder int32  `json:"display_order"`
+}
+
+// CategoryResponse represents a category of a competition
+type CategoryResponse struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	MinAge       int32  `json:"min_age"`
+	MaxAge       int32  `json:"max_age"`
+	DisplayOrder int32  `json:"display_order"`
+}
+
+// CategoryListResponse represents the categories of a competition
+type CategoryListResponse struct {
+	CompetitionID int32              `json:"competition_id"`
+	Categories    []CategoryResponse `json:"categories"`
+}
+
+// ClubInput represents the input for adding or renaming a club of a competition
+type ClubInput struct {
+	Name string `json:"name" binding:"required,max=40" example:"AS Golene"`
+}
+
+// ClubResponse represents a club of a competition
+type ClubResponse struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ClubListResponse represents the clubs of a competition
+type ClubListResponse struct {
+	CompetitionID int32          `json:"competition_id"`
+	Clubs         []ClubResponse `json:"clubs"`
+}
+
+// ClubRankingResponse represents the rank of a club on the points of its members
+type ClubRankingResponse struct {
+	Rank        int32  `json:"rank"`
+	ClubID      int32  `json:"club_id"`
+	Club        string `json:"club"`
+	Members     int32  `json:"members"`
+	Ranked      int32  `json:"ranked"` // Members with at least one run
+	TotalPoints int32  `json:"total_points"`
+}
+
+// ClubRankingListResponse represents the club ranking of a competition
+type ClubRankingListResponse struct {
+	CompetitionID int32                 `json:"competition_id"`
+	Rankings      []ClubRankingResponse `json:"rankings"`
+}
+
+// TeamInput represents the input for adding or changing a team of a competition
+type TeamInput struct {
+	Name     string  `json:"name" binding:"required,max=100" example:"Les Golenes"`
+	Dossards []int32 `json:"dossards" binding:"required,min=3,max=4" example:"12,15,23"`
+}
+
+// TeamResponse represents a team of a competition with the dossards of its members
+type TeamResponse struct {
+	ID       int32   `json:"id"`
+	Name     string  `json:"name"`
+	Dossards []int32 `json:"dossards"`
+}
+
+// TeamListResponse represents the teams of a competition
+type TeamListResponse struct {
+	CompetitionID int32          `json:"competition_id"`
+	Teams         []TeamResponse `json:"teams"`
+}
+
+// TeamMemberScoreResponse represents the score of a member of a team
+type TeamMemberScoreResponse struct {
+	DossardNumber int32  `json:"dossard_number"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Category      string `json:"category"`
+	Gender        string `json:"gender"`
+	TotalPoints   int32  `json:"total_points"`
+	NumberOfRuns  int32  `json:"number_of_runs"`
+	Counted       bool   `json:"counted"` // Among the best scores added up for the team
+}
+
+// TeamRankingResponse represents the rank of a team on the best scores of its members
+type TeamRankingResponse struct {
+	Rank        int32                     `json:"rank"`
+	TeamID      int32                     `json:"team_id"`
+	Team        string                    `json:"team"`
+	TotalPoints int32                     `json:"total_points"`
+	Members     []TeamMemberScoreResponse `json:"members"`
+}
+
+// TeamRankingListResponse represents the team ranking of a competition
+type TeamRankingListResponse struct {
+	CompetitionID  int32                 `json:"competition_id"`
+	CountedMembers int32                 `json:"counted_members"`
+	Rankings       []TeamRankingResponse `json:"rankings"`
+}
+
+// CompetitionContactInput represents the input for adding an organizer contact to a competition
+type CompetitionContactInput struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+	Phone string `json:"phone,omitempty"`
+	Role  string `json:"role" binding:"required" example:"results"`
+}
+
+// CompetitionContactResponse represents an organizer contact of a competition
+type CompetitionContactResponse struct {
+	ID    int32  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+	Role  string `json:"role"`
+}
+
+// CompetitionContactListResponse represents the organizer contacts of a competition
+type CompetitionContactListResponse struct {
+	CompetitionID int32                        `json:"competition_id"`
+	Contacts      []CompetitionContactResponse `json:"contacts"`
+}
+
+// TimeDisplayInput represents the input for the chrono display preferences of a competition
+type TimeDisplayInput struct {
+	ChronoFormat    string `json:"chrono_format" binding:"required"`
+	ChronoDirection string `json:"chrono_direction" binding:"required"`
+}
+
+// TimeDisplayResponse represents how the clients display and enter the chronos of a competition
+type TimeDisplayResponse struct {
+	ChronoFormat    string `json:"chrono_format"`
+	ChronoDirection string `json:"chrono_direction"`
+}
+
+// CompetitionBundleResponse represents everything a client needs to run a competition
+type CompetitionBundleResponse struct {
+	Competition CompetitionResponse `json:"competition"`
+	TimeDisplay TimeDisplayResponse `json:"time_display"`
+	Zones       []ZoneResponse      `json:"zones"`
+}
+
+// CompetitionRegistrationInput sets the limits of the registrations of a competition
+type CompetitionRegistrationInput struct {
+	MaxParticipants      int32  `json:"max_participants" binding:"min=0"`                                    // 0 for no limit
+	RegistrationDeadline string `json:"registration_deadline,omitempty" example:"2025-06-10T23:59:59+02:00"` // RFC3339, or YYYY-MM-DD closing at the end of the day in the time zone of the competition, empty for none
+	Waitlist             bool   `json:"waitlist"`                                                            // Waitlist the entries over the limit instead of refusing them
+}
+
+// CompetitionRegistrationResponse represents the limits of the registrations of a competition and the participants registered
+type CompetitionRegistrationResponse struct {
+	CompetitionID        int32  `json:"competition_id"`
+	MaxParticipants      int32  `json:"max_participants"`
+	RegistrationDeadline string `json:"registration_deadline,omitempty"` // RFC3339, in the time zone of the competition
+	Waitlist             bool   `json:"waitlist"`
+	RegistrationOpen     bool   `json:"registration_open"`
+	Registered           int32  `json:"registered"`
+	Waitlisted           int32  `json:"waitlisted"`
+}
+
+// WaitlistEntryResponse represents a participant of the waitlist
+type WaitlistEntryResponse struct {
+	Position      int32  `json:"position"` // 1 for the next participant to be promoted
+	DossardNumber int32  `json:"dossard_number"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Category      string `json:"category"`
+	Gender        string `json:"gender"`
+	Club          string `json:"club"`
+	WaitlistedAt  string `json:"waitlisted_at,omitempty"` // RFC3339, empty for the participants waitlisted before it was recorded
+}
+
+// WaitlistResponse represents the waitlist of a competition, in the order the participants are promoted
+type WaitlistResponse struct {
+	CompetitionID   int32                   `json:"competition_id"`
+	MaxParticipants int32                   `json:"max_participants"`
+	Registered      int32                   `json:"registered"`
+	Entries         []WaitlistEntryResponse `json:"entries"`
+}
+
+// WaitlistPromotionResponse represents the participants promoted from the head of the waitlist with their new dossards
+type WaitlistPromotionResponse struct {
+	CompetitionID int32                 `json:"competition_id"`
+	Promoted      []ParticipantResponse `json:"promoted"`
+}
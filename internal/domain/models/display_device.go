@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// DisplayDeviceInput represents the input for registering a display device
+type DisplayDeviceInput struct {
+	Name          string `json:"name" binding:"required"`
+	LifetimeHours int32  `json:"lifetime_hours,omitempty"` // defaults to the configured display token lifetime
+}
+
+// DisplayDeviceResponse represents a display device of a competition
+type DisplayDeviceResponse struct {
+	ID            int32      `json:"id"`
+	CompetitionID int32      `json:"competition_id"`
+	Name          string     `json:"name"`
+	CreatedBy     int32      `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastSeenAt    *time.Time `json:"last_seen_at,omitempty"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	Active        bool       `json:"active"`
+}
+
+// DisplayDeviceRegistrationResponse represents a registered display device with its display token, only returned once
+type DisplayDeviceRegistrationResponse struct {
+	Device       DisplayDeviceResponse `json:"device"`
+	DisplayToken string                `json:"display_token"`
+}
+
+// DisplayDeviceListResponse represents the display devices of a competition
+type DisplayDeviceListResponse struct {
+	Devices []DisplayDeviceResponse `json:"devices"`
+}
+
+// DisplayLoginInput represents the input for logging a display device in with its display token
+type DisplayLoginInput struct {
+	DisplayToken string `json:"display_token" binding:"required"`
+}
@@ -4,3 +4,34 @@ type ErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
+
+// PasswordPolicyErrorResponse is returned when a password breaks the password policy.
+// Violations are among too_short, missing_lowercase, missing_uppercase, missing_digit,
+// missing_symbol, denied and pwned.
+type PasswordPolicyErrorResponse struct {
+	Code       int      `json:"code"`
+	Message    string   `json:"message"`
+	Violations []string `json:"violations"`
+	MinLength  int      `json:"min_length"`
+}
+
+// AlreadyMemberErrorResponse is returned when a user accepts an invitation to a competition they are already a member of.
+// ErrorCode is always already_member, Role is the role the user already has, referee or admin.
+type AlreadyMemberErrorResponse struct {
+	Code          int    `json:"code"`
+	Message       string `json:"message"`
+	ErrorCode     string `json:"error_code"`
+	CompetitionID int32  `json:"competition_id"`
+	Role          string `json:"role"`
+}
+
+// RunsPerZoneErrorResponse is returned when a participant already has the runs expected in a zone.
+// ErrorCode is always runs_per_zone_exceeded, RunNumbers are the runs of the participant in the zone.
+type RunsPerZoneErrorResponse struct {
+	Code       int     `json:"code"`
+	Message    string  `json:"message"`
+	ErrorCode  string  `json:"error_code"`
+	Zone       string  `json:"zone"`
+	MaxRuns    int32   `json:"max_runs"`
+	RunNumbers []int32 `json:"run_numbers"`
+}
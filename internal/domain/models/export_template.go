@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ExportTemplateResponse describes the XLSX template the results export of a competition fills
+type ExportTemplateResponse struct {
+	CompetitionID int32     `json:"competition_id"`
+	Filename      string    `json:"filename"`
+	Size          int       `json:"size"`
+	UploadedAt    time.Time `json:"uploaded_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// JWK represents a public JSON Web Key used to verify tokens
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKSResponse represents a JSON Web Key Set
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
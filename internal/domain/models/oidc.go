@@ -0,0 +1,6 @@
+package models
+
+// OIDCProvidersResponse lists the OpenID Connect providers users can log in with
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// OrganizationInput represents the input for creating an organization
+type OrganizationInput struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// OrganizationResponse represents an organization and its competitions, the latest first
+type OrganizationResponse struct {
+	ID             int32     `json:"id"`
+	Name           string    `json:"name"`
+	CreatedAt      time.Time `json:"created_at"`
+	CompetitionIDs []int32   `json:"competition_ids"`
+}
+
+// OrganizationListResponse represents the list of the organizations
+type OrganizationListResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+}
+
+// OrganizationAdminInput represents the input for granting the admin role of an organization to a user
+type OrganizationAdminInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// OrganizationAdminResponse represents a user granted the admin role of an organization
+type OrganizationAdminResponse struct {
+	OrganizationID int32  `json:"organization_id"`
+	UserID         int32  `json:"user_id"`
+	Email          string `json:"email"`
+	Role           string `json:"role"`
+}
+
+// CompetitionOrganizationInput represents the organization a competition is moved to
+type CompetitionOrganizationInput struct {
+	OrganizationID int32 `json:"organization_id"` // 0 detaches the competition from its organization
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// PublishedResult represents the result of a participant in the published results.
+// Participants who did not consent to the processing of their data are published without their name and club.
+type PublishedResult struct {
+	Rank         int32  `json:"rank,omitempty"`
+	Dossard      int32  `json:"dossard"`
+	LastName     string `json:"last_name"`
+	FirstName    string `json:"first_name"`
+	Club         string `json:"club,omitempty"`
+	TotalPoints  int32  `json:"total_points"`
+	TotalPenalty int32  `json:"total_penalty"`
+	TotalTime    int32  `json:"total_time"`    // whole seconds
+	TotalTimeMs  int32  `json:"total_time_ms"` // milliseconds, the participants are ranked on it
+	PointsEarned int32  `json:"points_earned,omitempty"`
+	Complete     bool   `json:"complete"`
+	Status       string `json:"status"`                  // registered, dnf or dsq, the participants who did not start are left out
+	StatusReason string `json:"status_reason,omitempty"` // why the participant was disqualified or did not finish
+}
+
+// PublishedResultGroup represents the results of a category and gender in the published results
+type PublishedResultGroup struct {
+	Category string            `json:"category"`
+	Gender   string            `json:"gender"`
+	Zones    []string          `json:"zones"`
+	Results  []PublishedResult `json:"results"`
+}
+
+// PublishedResults represents the final results of a competition uploaded to the static site
+type PublishedResults struct {
+	CompetitionID int32                  `json:"competition_id"`
+	Name          string                 `json:"name"`
+	Date          string                 `json:"date"`
+	Location      string                 `json:"location"`
+	Organizer     string                 `json:"organizer"`
+	PublishedAt   time.Time              `json:"published_at"`
+	Groups        []PublishedResultGroup `json:"groups"`
+}
+
+// ResultsPublicationResponse represents the URLs the results of a competition were published to
+type ResultsPublicationResponse struct {
+	CompetitionID int32     `json:"competition_id"`
+	HTMLURL       string    `json:"html_url"`
+	JSONURL       string    `json:"json_url"`
+	PublishedAt   time.Time `json:"published_at"`
+}
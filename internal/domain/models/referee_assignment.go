@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// RefereeAssignmentInput represents the input for assigning a referee to a zone of a competition
+type RefereeAssignmentInput struct {
+	UserID int32  `json:"user_id" binding:"required"`
+	Zone   string `json:"zone" binding:"required,max=100"`
+}
+
+// RefereeAssignmentResponse represents the assignment of a referee to a zone
+type RefereeAssignmentResponse struct {
+	CompetitionID int32     `json:"competition_id"`
+	UserID        int32     `json:"user_id"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Email         string    `json:"email"`
+	Zone          string    `json:"zone"`
+	AssignedBy    int32     `json:"assigned_by"`
+	AssignedAt    time.Time `json:"assigned_at"`
+}
+
+// RefereeAssignmentListResponse represents the assignments of the referees of a competition by zone
+type RefereeAssignmentListResponse struct {
+	CompetitionID        int32                       `json:"competition_id"`
+	RestrictRefereeZones bool                        `json:"restrict_referee_zones"` // whether the referees can only record runs in their zones
+	Assignments          []RefereeAssignmentResponse `json:"assignments"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// RefereeOnboardingInput represents the steps of the onboarding checklist a referee updates
+type RefereeOnboardingInput struct {
+	RulesRead     bool   `json:"rules_read"`
+	ConfirmedZone string `json:"confirmed_zone" binding:"max=100"` // empty to clear the confirmation
+}
+
+// RefereeTestRunInput represents a test run a referee submits to check their device, it is not recorded
+type RefereeTestRunInput struct {
+	Zone      string `json:"zone" binding:"required"`
+	Doors     []bool `json:"doors" binding:"max=20"` // whether each door was passed, in order
+	Penality  int32  `json:"penality"`
+	ChronoSec int32  `json:"chrono_sec"`
+}
+
+// RefereeOnboardingResponse represents the onboarding checklist of a referee of a competition
+type RefereeOnboardingResponse struct {
+	CompetitionID        int32      `json:"competition_id"`
+	UserID               int32      `json:"user_id"`
+	FirstName            string     `json:"first_name"`
+	LastName             string     `json:"last_name"`
+	Email                string     `json:"email"`
+	InvitationAccepted   bool       `json:"invitation_accepted"`
+	InvitationAcceptedAt *time.Time `json:"invitation_accepted_at,omitempty"`
+	RulesRead            bool       `json:"rules_read"`
+	RulesReadAt          *time.Time `json:"rules_read_at,omitempty"`
+	ConfirmedZone        string     `json:"confirmed_zone,omitempty"`
+	ZoneConfirmedAt      *time.Time `json:"zone_confirmed_at,omitempty"`
+	TestRunSubmitted     bool       `json:"test_run_submitted"`
+	TestRunAt            *time.Time `json:"test_run_at,omitempty"`
+	Ready                bool       `json:"ready"`
+}
+
+// RefereeOnboardingOverviewResponse represents the readiness of the referees of a competition
+type RefereeOnboardingOverviewResponse struct {
+	CompetitionID      int32                       `json:"competition_id"`
+	Total              int32                       `json:"total"`
+	Ready              int32                       `json:"ready"`
+	PendingInvitations int32                       `json:"pending_invitations"` // referee invitation links not accepted yet
+	Referees           []RefereeOnboardingResponse `json:"referees"`
+}
@@ -3,3 +3,44 @@ package models
 type RoleResponse struct {
 	Roles []string `json:"roles"`
 }
+
+// RolesNearLimitUser represents a user whose roles make large tokens
+type RolesNearLimitUser struct {
+	ID          int32  `json:"id"`
+	Email       string `json:"email"`
+	RolesLength int    `json:"roles_length"`
+}
+
+// RolesPruneResponse represents the outcome of a roles pruning run
+type RolesPruneResponse struct {
+	ExpiredCompetitionIDs []int32              `json:"expired_competition_ids"`
+	PrunedUsers           int32                `json:"pruned_users"`
+	RemovedRoles          int32                `json:"removed_roles"`
+	NearLimitUsers        []RolesNearLimitUser `json:"near_limit_users"`
+}
+
+// CompetitionRolesResponse represents the roles of a user on a competition
+type CompetitionRolesResponse struct {
+	CompetitionID int32    `json:"competition_id"`
+	Roles         []string `json:"roles"`
+}
+
+// UserRolesResponse represents the roles of a user grouped per competition
+type UserRolesResponse struct {
+	UserID       int32                      `json:"user_id"`
+	GlobalRoles  []string                   `json:"global_roles"`
+	Competitions []CompetitionRolesResponse `json:"competitions"`
+}
+
+// ObserverInput represents the input for granting the observer role of a competition to a user
+type ObserverInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ObserverResponse represents a user granted the observer role of a competition
+type ObserverResponse struct {
+	CompetitionID int32  `json:"competition_id"`
+	UserID        int32  `json:"user_id"`
+	Email         string `json:"email"`
+	Role          string `json:"role"`
+}
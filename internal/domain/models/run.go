@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // ParticipantInput represents the input for creating a participant
 type ParticipantInput struct {
 	CompetitionID int32  `json:"competition_id" binding:"required"`
@@ -8,7 +10,14 @@ type ParticipantInput struct {
 	LastName      string `json:"last_name" binding:"required"`
 	Category      string `json:"category" binding:"required"`
 	Gender        string `json:"gender" binding:"required"`
-	Club          string `json:"club"`
+	Club          string `json:"club" binding:"max=40"`
+	ClubID        int32  `json:"club_id"`                              // Club of the competition, replaces the club name when set
+	ClubEmail     string `json:"club_email" binding:"omitempty,email"` // Address of the club contact, receiving the emails sent to the clubs
+	Licence       string `json:"licence" binding:"max=50"`             // Federation licence number, recognising the participant across the competitions of a series
+	BirthDate     string `json:"birth_date" binding:"omitempty,datetime=2006-01-02" example:"2012-04-23"`
+
+	ConsentDataProcessing bool `json:"consent_data_processing"`
+	ConsentPhotoRights    bool `json:"consent_photo_rights"`
 }
 
 // ParticipantResponse represents the response for a participant
@@ -20,11 +29,155 @@ type ParticipantResponse struct {
 	Category      string `json:"category"`
 	Gender        string `json:"gender"`
 	Club          string `json:"club"`
+	ClubID        int32  `json:"club_id,omitempty"`
+	ClubEmail     string `json:"club_email,omitempty"`
+	Licence       string `json:"licence,omitempty"`
+	BirthDate     string `json:"birth_date,omitempty"` // YYYY-MM-DD
+
+	ConsentDataProcessing bool `json:"consent_data_processing"`
+	ConsentPhotoRights    bool `json:"consent_photo_rights"`
+
+	Waitlisted bool `json:"waitlisted"` // Entered once the competition was full, waiting for a place
+	CheckedIn  bool `json:"checked_in"` // Confirmed present at the start desk
+
+	Notes string   `json:"notes,omitempty"` // Written by the organizers for the referees
+	Flags []string `json:"flags"`           // Among medical, minor and special_start
+
+	Status       string `json:"status"`                  // Among registered, dns, dnf and dsq
+	StatusReason string `json:"status_reason,omitempty"` // Why the participant was disqualified or did not finish
+	PhotoURL     string `json:"photo_url,omitempty"`     // Route of the API serving the photo, for the referees to confirm the identity of the participant
+}
+
+// ParticipantStatusInput represents the status of a participant, a disqualification requires a reason
+type ParticipantStatusInput struct {
+	Status string `json:"status" binding:"required,oneof=registered dns dnf dsq" example:"dsq"`
+	Reason string `json:"reason" binding:"max=255" example:"Outside help on the second zone"`
+}
+
+// ParticipantNotesInput represents the notes and flags of a participant shown to the referees
+type ParticipantNotesInput struct {
+	Notes string   `json:"notes" binding:"max=1000" example:"Asthmatic, inhaler in the bag"`
+	Flags []string `json:"flags" example:"medical,minor"`
+}
+
+// CheckInCountResponse represents the check-in of the participants of a category
+type CheckInCountResponse struct {
+	Category   string `json:"category"`
+	Registered int32  `json:"registered"` // Waitlisted participants excluded
+	CheckedIn  int32  `json:"checked_in"`
+	Missing    int32  `json:"missing"`
+}
+
+// CheckInSummaryResponse represents the check-in of the participants of a competition, in total and by category
+type CheckInSummaryResponse struct {
+	CompetitionID int32                  `json:"competition_id"`
+	Registered    int32                  `json:"registered"`
+	CheckedIn     int32                  `json:"checked_in"`
+	Missing       int32                  `json:"missing"`
+	Categories    []CheckInCountResponse `json:"categories"`
+}
+
+// ParticipantRenumberInput represents the input for changing the dossard number of a participant
+type ParticipantRenumberInput struct {
+	DossardNumber int32 `json:"dossard_number" binding:"required"`
+	Swap          bool  `json:"swap"` // Gives the current dossard number to the participant holding the new one
+}
+
+// ParticipantDeletionResponse represents the records deleted with a participant, or that would be on a dry run
+type ParticipantDeletionResponse struct {
+	CompetitionID int32 `json:"competition_id"`
+	DossardNumber int32 `json:"dossard_number"`
+	DryRun        bool  `json:"dry_run"`
+	Runs          int32 `json:"runs"`
+	Liverankings  int32 `json:"liverankings"`
+	PendingRuns   int32 `json:"pending_runs"`
 }
 
-// ParticipantListResponse represents the response for a list of participants
+// ParticipantsClearInput represents the confirmation of the clearing of the participants of a competition
+type ParticipantsClearInput struct {
+	ConfirmationToken string `json:"confirmation_token"`
+}
+
+// ParticipantsClearResponse represents the participants and records cleared, or that would be with the confirmation token
+type ParticipantsClearResponse struct {
+	CompetitionID     int32  `json:"competition_id"`
+	Cleared           bool   `json:"cleared"`
+	Message           string `json:"message,omitempty"`
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+	Participants      int32  `json:"participants"`
+	Runs              int32  `json:"runs"`
+	Liverankings      int32  `json:"liverankings"`
+	PendingRuns       int32  `json:"pending_runs"`
+}
+
+// ParticipantListResponse represents the response for a page of participants
 type ParticipantListResponse struct {
+	Page         int32                  `json:"page"`
+	PageSize     int32                  `json:"page_size"`
+	Total        int32                  `json:"total"`
+	Participants []*ParticipantResponse `json:"participants"`
+}
+
+// ParticipantDuplicateResponse represents participants that are likely the same person registered twice
+type ParticipantDuplicateResponse struct {
 	Participants []*ParticipantResponse `json:"participants"`
+	NameDistance int32                  `json:"name_distance"` // letters differing between the names, 0 when they are the same
+	SameClub     bool                   `json:"same_club"`
+}
+
+// ParticipantDuplicateListResponse represents the likely duplicate participants of a competition
+type ParticipantDuplicateListResponse struct {
+	CompetitionID int32                           `json:"competition_id"`
+	Duplicates    []*ParticipantDuplicateResponse `json:"duplicates"`
+}
+
+// ParticipantCandidateResponse represents a participant found by a name search
+type ParticipantCandidateResponse struct {
+	DossardNumber int32    `json:"dossard_number"`
+	FirstName     string   `json:"first_name"`
+	LastName      string   `json:"last_name"`
+	Category      string   `json:"category"`
+	Gender        string   `json:"gender"`
+	Club          string   `json:"club"`
+	Waitlisted    bool     `json:"waitlisted"`
+	CheckedIn     bool     `json:"checked_in"`
+	Flags         []string `json:"flags"`               // Among medical, minor and special_start
+	PhotoURL      string   `json:"photo_url,omitempty"` // Route of the API serving the photo, for the referees to confirm the identity of the participant
+}
+
+// ParticipantSearchResponse represents the participants of a competition whose names match a search
+type ParticipantSearchResponse struct {
+	CompetitionID int32                           `json:"competition_id"`
+	Query         string                          `json:"query"`
+	Candidates    []*ParticipantCandidateResponse `json:"candidates"`
+}
+
+// ParticipantHistoryEntryResponse represents the result of a person in one competition
+type ParticipantHistoryEntryResponse struct {
+	CompetitionID   int32  `json:"competition_id"`
+	CompetitionName string `json:"competition_name"`
+	CompetitionDate string `json:"competition_date"` // YYYY-MM-DD, in the time zone of the competition
+	DossardNumber   int32  `json:"dossard_number"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Club            string `json:"club,omitempty"`
+	Category        string `json:"category"`
+	Gender          string `json:"gender"`
+	Status          string `json:"status"`         // Among registered, dns, dnf and dsq
+	Rank            int32  `json:"rank,omitempty"` // Within the category and gender, missing when not ranked
+	Ranked          int32  `json:"ranked"`         // Participants ranked in the category and gender
+	TotalPoints     int32  `json:"total_points"`
+	TotalPenalty    int32  `json:"total_penalty"`
+	TotalTime       int32  `json:"total_time"`
+	PointsEarned    int32  `json:"points_earned,omitempty"`
+	Complete        bool   `json:"complete"` // Whether the participant has the expected runs in every zone
+}
+
+// ParticipantHistoryResponse represents the results of a person across the competitions, the latest first
+type ParticipantHistoryResponse struct {
+	Licence string                            `json:"licence,omitempty"`
+	Name    string                            `json:"name,omitempty"`
+	Results []ParticipantHistoryEntryResponse `json:"results"`
 }
 
 // RunInput represents the input for creating a new run
@@ -32,14 +185,32 @@ type RunInput struct {
 	CompetitionID int32  `json:"competition_id" binding:"required"`
 	Dossard       int32  `json:"dossard" binding:"required"`
 	Zone          string `json:"zone" binding:"required"`
-	Door1         bool   `json:"door1"`
-	Door2         bool   `json:"door2"`
-	Door3         bool   `json:"door3"`
-	Door4         bool   `json:"door4"`
-	Door5         bool   `json:"door5"`
-	Door6         bool   `json:"door6"`
+	Doors         []bool `json:"doors" binding:"max=20"` // whether each door was passed, in order
 	Penality      int32  `json:"penality"`
-	ChronoSec     int32  `json:"chrono_sec"`
+	ChronoSec     int32  `json:"chrono_sec"`          // whole seconds, kept for the clients not sending chrono_ms
+	ChronoMs      int32  `json:"chrono_ms"`           // milliseconds, replaces chrono_sec when set, at most 1 hour
+	Status        string `json:"status" example:"ok"` // ok (default), dnf or dsq
+	Confirmed     bool   `json:"confirmed"`           // records the run even if its values are outside the bounds of the zone
+	// IdempotencyKey is a unique key drawn by the client, e.g. a UUID, so that its retries do not record the run twice.
+	// The Idempotency-Key header replaces it.
+	IdempotencyKey string `json:"idempotency_key" binding:"max=64"`
+	// Comment lets the referee note a gate dispute or a hardware issue
+	Comment string `json:"comment" binding:"max=500" example:"Gate 3 disputed by the coach"`
+}
+
+// RunWarningResponse represents a value of a run outside the bounds of its zone,
+// the code is chrono_above_max or penality_above_max
+type RunWarningResponse struct {
+	Code  string `json:"code"`
+	Value int32  `json:"value"`
+	Max   int32  `json:"max"`
+}
+
+// RunConfirmationResponse is returned when a run must be confirmed before being recorded
+type RunConfirmationResponse struct {
+	Code     int                  `json:"code"`
+	Message  string               `json:"message"`
+	Warnings []RunWarningResponse `json:"warnings"`
 }
 
 // RunResponse represents the response for a run
@@ -48,14 +219,15 @@ type RunResponse struct {
 	Dossard       int32  `json:"dossard"`
 	RunNumber     int32  `json:"run_number"`
 	Zone          string `json:"zone"`
-	Door1         bool   `json:"door1"`
-	Door2         bool   `json:"door2"`
-	Door3         bool   `json:"door3"`
-	Door4         bool   `json:"door4"`
-	Door5         bool   `json:"door5"`
-	Door6         bool   `json:"door6"`
+	Doors         []bool `json:"doors"`
 	Penality      int32  `json:"penality"`
 	ChronoSec     int32  `json:"chrono_sec"`
+	ChronoMs      int32  `json:"chrono_ms"`
+	Status        string `json:"status"`                 // ok, dnf or dsq
+	ReceiptCode   string `json:"receipt_code,omitempty"` // returned when the run is recorded, for the paper backup sheet
+	Comment       string `json:"comment,omitempty"`      // noted by the referee, e.g. a gate dispute or a hardware issue
+
+	PendingApproval bool `json:"pending_approval"` // the run only counts in the ranking once an admin approves it
 }
 
 // RunUpdateInput represents the input for updating a run
@@ -64,14 +236,13 @@ type RunUpdateInput struct {
 	Dossard       int32  `json:"dossard" binding:"required"`
 	RunNumber     int32  `json:"run_number" binding:"required"`
 	Zone          string `json:"zone" binding:"required"`
-	Door1         bool   `json:"door1"`
-	Door2         bool   `json:"door2"`
-	Door3         bool   `json:"door3"`
-	Door4         bool   `json:"door4"`
-	Door5         bool   `json:"door5"`
-	Door6         bool   `json:"door6"`
+	Doors         []bool `json:"doors" binding:"max=20"` // whether each door was passed, in order
 	Penality      int32  `json:"penality"`
-	ChronoSec     int32  `json:"chrono_sec"`
+	ChronoSec     int32  `json:"chrono_sec"`          // whole seconds, kept for the clients not sending chrono_ms
+	ChronoMs      int32  `json:"chrono_ms"`           // milliseconds, replaces chrono_sec when set, at most 1 hour
+	Status        string `json:"status" example:"ok"` // ok, dnf or dsq, the run keeps its status when empty
+
+	Comment *string `json:"comment" binding:"omitempty,max=500"` // the run keeps its comment when left out, an empty one removes it
 }
 
 // RunDetailsResponse represents a detailed run response with referee and zone information
@@ -80,19 +251,142 @@ type RunDetailsResponse struct {
 	Dossard       int32  `json:"dossard"`
 	RunNumber     int32  `json:"run_number"`
 	Zone          string `json:"zone"`
-	Door1         bool   `json:"door1"`
-	Door2         bool   `json:"door2"`
-	Door3         bool   `json:"door3"`
-	Door4         bool   `json:"door4"`
-	Door5         bool   `json:"door5"`
-	Door6         bool   `json:"door6"`
+	Doors         []bool `json:"doors"`
 	Penality      int32  `json:"penality"`
 	ChronoSec     int32  `json:"chrono_sec"`
+	ChronoMs      int32  `json:"chrono_ms"`
 	RefereeID     int32  `json:"referee_id"`
 	RefereeName   string `json:"referee_name"`
+	Status        string `json:"status"` // ok, dnf or dsq
+	Voided        bool   `json:"voided"` // voided runs do not count in the ranking
+	ReceiptCode   string `json:"receipt_code,omitempty"`
+	Comment       string `json:"comment,omitempty"` // noted by the referee, e.g. a gate dispute or a hardware issue
+
+	PendingApproval bool `json:"pending_approval"` // the run only counts in the ranking once an admin approves it
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"` // last time the run was modified or voided, its creation time if it never was
 }
 
 // RunListResponse represents the response for a list of runs
 type RunListResponse struct {
 	Runs []*RunDetailsResponse `json:"runs"`
 }
+
+// RunPageResponse represents a page of the runs of a competition
+type RunPageResponse struct {
+	Page     int32                 `json:"page"`
+	PageSize int32                 `json:"page_size"`
+	Total    int32                 `json:"total"`
+	Runs     []*RunDetailsResponse `json:"runs"`
+}
+
+// RunValuesResponse represents the values of a run before or after one of its changes
+type RunValuesResponse struct {
+	Zone      string `json:"zone"`
+	Doors     []bool `json:"doors"`
+	Penality  int32  `json:"penality"`
+	ChronoSec int32  `json:"chrono_sec"`
+	ChronoMs  int32  `json:"chrono_ms"`
+	Status    string `json:"status"`
+}
+
+// RunRevisionResponse represents a change of the values of a run
+type RunRevisionResponse struct {
+	ID         int32             `json:"id"`
+	EditorID   int32             `json:"editor_id"`
+	EditorName string            `json:"editor_name"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Before     RunValuesResponse `json:"before"`
+	After      RunValuesResponse `json:"after"`
+}
+
+// RunRevisionListResponse represents the history of a run
+type RunRevisionListResponse struct {
+	CompetitionID int32                 `json:"competition_id"`
+	Dossard       int32                 `json:"dossard"`
+	RunNumber     int32                 `json:"run_number"`
+	Revisions     []RunRevisionResponse `json:"revisions"`
+}
+
+// RunConflictResponse represents a participant scored more than once in the same zone
+type RunConflictResponse struct {
+	Dossard int32                 `json:"dossard"`
+	Zone    string                `json:"zone"`
+	Runs    []*RunDetailsResponse `json:"runs"`
+}
+
+// RunConflictListResponse represents the run conflicts of a competition
+type RunConflictListResponse struct {
+	CompetitionID int32                  `json:"competition_id"`
+	Conflicts     []*RunConflictResponse `json:"conflicts"`
+}
+
+// RunVoidInput represents the run a referee voids shortly after recording it
+type RunVoidInput struct {
+	CompetitionID int32 `json:"competition_id" binding:"required"`
+	Dossard       int32 `json:"dossard" binding:"required"`
+	RunNumber     int32 `json:"run_number" binding:"required"`
+}
+
+// RunApproveInput represents the run recorded by a referee an admin approves
+type RunApproveInput struct {
+	CompetitionID int32 `json:"competition_id" binding:"required"`
+	Dossard       int32 `json:"dossard" binding:"required"`
+	RunNumber     int32 `json:"run_number" binding:"required"`
+}
+
+// RunConflictResolveInput represents the input for keeping a run among conflicting ones
+type RunConflictResolveInput struct {
+	Dossard   int32 `json:"dossard" binding:"required"`
+	RunNumber int32 `json:"run_number" binding:"required"` // run kept, the other runs of its zone are voided
+}
+
+// RunConflictResolveResponse represents the outcome of a run conflict resolution
+type RunConflictResolveResponse struct {
+	VoidedRuns int32 `json:"voided_runs"`
+}
+
+// ChronoImportRowResponse represents the outcome of a row of a chrono import,
+// the status is merged, unchanged, pending or failed
+type ChronoImportRowResponse struct {
+	Row       int32  `json:"row"`
+	Dossard   int32  `json:"dossard"`
+	Zone      string `json:"zone"`
+	ChronoSec int32  `json:"chrono_sec"`
+	ChronoMs  int32  `json:"chrono_ms"`
+	RunNumber int32  `json:"run_number,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// ChronoImportResponse represents the report of a chrono import
+type ChronoImportResponse struct {
+	Merged    int32                     `json:"merged"`
+	Unchanged int32                     `json:"unchanged"`
+	Pending   int32                     `json:"pending"`
+	Failed    int32                     `json:"failed"`
+	Rows      []ChronoImportRowResponse `json:"rows"`
+}
+
+// PendingRunResponse represents an imported chrono waiting for a referee to confirm its run
+type PendingRunResponse struct {
+	ID         int32     `json:"id"`
+	Dossard    int32     `json:"dossard"`
+	Zone       string    `json:"zone"`
+	ChronoSec  int32     `json:"chrono_sec"`
+	ChronoMs   int32     `json:"chrono_ms"`
+	ImportedBy int32     `json:"imported_by"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// PendingRunListResponse represents the pending runs of a competition
+type PendingRunListResponse struct {
+	PendingRuns []PendingRunResponse `json:"pending_runs"`
+}
+
+// PendingRunConfirmInput represents the doors and penalty a referee confirms a pending run with
+type PendingRunConfirmInput struct {
+	Doors    []bool `json:"doors" binding:"max=20"` // whether each door was passed, in order
+	Penality int32  `json:"penality"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// PublishedSchema describes a payload published by the API with its JSON Schema and an example
+type PublishedSchema struct {
+	Name     string                 `json:"name"`
+	Version  int                    `json:"version"`
+	Endpoint string                 `json:"endpoint"`
+	Schema   map[string]interface{} `json:"schema"`
+}
+
+// PublishedSchemasResponse lists the schemas of the payloads published by the API
+type PublishedSchemasResponse struct {
+	Schemas []PublishedSchema `json:"schemas"`
+}
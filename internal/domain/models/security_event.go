@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// SecurityEventResponse represents a login, password change or role grant of a user
+type SecurityEventResponse struct {
+	ID            int32     `json:"id"`
+	UserID        int32     `json:"user_id,omitempty"`
+	Email         string    `json:"email"`
+	Type          string    `json:"type"`
+	CompetitionID int32     `json:"competition_id,omitempty"`
+	Details       string    `json:"details,omitempty"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"user_agent"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SecurityEventListResponse represents a page of security events
+type SecurityEventListResponse struct {
+	Page     int32                   `json:"page"`
+	PageSize int32                   `json:"page_size"`
+	Total    int32                   `json:"total"`
+	Events   []SecurityEventResponse `json:"events"`
+}
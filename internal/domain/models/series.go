@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// SeriesInput represents the input for creating or renaming a series
+type SeriesInput struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// SeriesCompetitionInput represents the competition added to a series
+type SeriesCompetitionInput struct {
+	CompetitionID int32 `json:"competition_id" binding:"required"`
+}
+
+// SeriesResponse represents a series and its competitions, ordered by date
+type SeriesResponse struct {
+	ID             int32     `json:"id"`
+	Name           string    `json:"name"`
+	CreatedBy      int32     `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	CompetitionIDs []int32   `json:"competition_ids"`
+}
+
+// SeriesListResponse represents the list of the series
+type SeriesListResponse struct {
+	Series []SeriesResponse `json:"series"`
+}
+
+// SeriesResultResponse represents the points a participant earned in one competition of a series
+type SeriesResultResponse struct {
+	CompetitionID int32  `json:"competition_id"`
+	Category      string `json:"category"`
+	Gender        string `json:"gender"`
+	Rank          int32  `json:"rank"` // within the category and gender
+	PointsEarned  int32  `json:"points_earned"`
+}
+
+// SeriesStandingResponse represents the points a participant earned over a series
+type SeriesStandingResponse struct {
+	Rank         int32                  `json:"rank"`
+	FirstName    string                 `json:"first_name"`
+	LastName     string                 `json:"last_name"`
+	Club         string                 `json:"club,omitempty"`
+	Points       int32                  `json:"points"`
+	Competitions int32                  `json:"competitions"`
+	Results      []SeriesResultResponse `json:"results"`
+}
+
+// SeriesStandingListResponse represents the standings of a series
+type SeriesStandingListResponse struct {
+	SeriesID   int32                    `json:"series_id"`
+	Name       string                   `json:"name"`
+	Category   string                   `json:"category,omitempty"`
+	Gender     string                   `json:"gender,omitempty"`
+	ComputedAt time.Time                `json:"computed_at"`
+	Standings  []SeriesStandingResponse `json:"standings"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// SessionResponse represents a logged in device of the user
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// SessionListResponse represents the active sessions of the user
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
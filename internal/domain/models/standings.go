@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// SeasonStandingResponse represents the points a club or an athlete earned over a season
+type SeasonStandingResponse struct {
+	Rank         int32  `json:"rank"`
+	Name         string `json:"name"`
+	Club         string `json:"club,omitempty"`
+	Points       int32  `json:"points"`
+	Competitions int32  `json:"competitions"`
+}
+
+// SeasonStandingListResponse represents the standings of a season
+type SeasonStandingListResponse struct {
+	Season     int                      `json:"season"`
+	ComputedAt time.Time                `json:"computed_at"`
+	Standings  []SeasonStandingResponse `json:"standings"`
+}
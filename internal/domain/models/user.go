@@ -27,3 +27,50 @@ type ChangePasswordInput struct {
 type ForgotPasswordInput struct {
 	Email string `json:"email" binding:"required,email"`
 }
+
+// UserSearchResult represents a user matching a directory search
+type UserSearchResult struct {
+	ID        int32  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// UserSearchResponse represents a page of the user directory search
+type UserSearchResponse struct {
+	Query    string             `json:"query"`
+	Page     int32              `json:"page"`
+	PageSize int32              `json:"page_size"`
+	Total    int32              `json:"total"`
+	Users    []UserSearchResult `json:"users"`
+}
+
+// UserImportRowResponse represents the outcome of a row of a users import
+type UserImportRowResponse struct {
+	Row     int32  `json:"row"`
+	Email   string `json:"email"`
+	Status  string `json:"status"` // created, role_added, skipped or failed
+	Message string `json:"message,omitempty"`
+}
+
+// UserImportResponse represents the report of a users import
+type UserImportResponse struct {
+	Created    int32                   `json:"created"`
+	RolesAdded int32                   `json:"roles_added"`
+	Skipped    int32                   `json:"skipped"`
+	Failed     int32                   `json:"failed"`
+	Rows       []UserImportRowResponse `json:"rows"`
+}
+
+// RefereePinResponse represents the PIN generated for a referee, it is only returned once
+type RefereePinResponse struct {
+	CompetitionID int32  `json:"competition_id"`
+	UserID        int32  `json:"user_id"`
+	Pin           string `json:"pin"`
+}
+
+// RefereePinLoginInput represents the input for logging in with a referee PIN
+type RefereePinLoginInput struct {
+	CompetitionID int32  `json:"competition_id" binding:"required"`
+	Pin           string `json:"pin" binding:"required"`
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, apiKey *aggregate.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*aggregate.APIKey, error)
+	ListAPIKeys(ctx context.Context, competitionID int32) ([]*aggregate.APIKey, error)
+	RevokeAPIKey(ctx context.Context, competitionID, id int32) error
+	TouchAPIKey(ctx context.Context, competitionID, id int32) error // Records the key has just been used
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type AuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, auditLog *aggregate.AuditLog) error
+	ListAuditLogs(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuditLog, int32, error) // Returns the entries of the page and the total count
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type AuthEventRepository interface {
+	CreateAuthEvent(ctx context.Context, authEvent *aggregate.AuthEvent) error
+	ListUserAuthEvents(ctx context.Context, userID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error)               // Returns the events of the page and the total count
+	ListCompetitionAuthEvents(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error) // Includes the events of the users having a role on the competition
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, category *aggregate.Category) error
+	GetCategory(ctx context.Context, competitionID, id int32) (*aggregate.Category, error)
+	UpdateCategory(ctx context.Context, category *aggregate.Category) error
+	ListCategories(ctx context.Context, competitionID int32) ([]*aggregate.Category, error) // Ordered by display order then name
+	DeleteCategory(ctx context.Context, competitionID, id int32) error
+}
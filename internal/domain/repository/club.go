@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type ClubRepository interface {
+	CreateClub(ctx context.Context, club *aggregate.Club) error
+	GetClub(ctx context.Context, competitionID, id int32) (*aggregate.Club, error)
+	UpdateClub(ctx context.Context, club *aggregate.Club) error                    // Renames its participants in the same transaction
+	ListClubs(ctx context.Context, competitionID int32) ([]*aggregate.Club, error) // Ordered by name
+	CountClubMembers(ctx context.Context, competitionID, id int32) (int32, error)  // Waitlisted participants included
+	DeleteClub(ctx context.Context, competitionID, id int32) error
+	ListClubRankings(ctx context.Context, competitionID int32) ([]*aggregate.ClubRanking, error) // Most points first, unranked
+}
@@ -11,5 +11,6 @@ type CompetitionRepository interface {
 	CreateCompetition(ctx context.Context, competition *aggregate.Competition) (int32, error)
 	UpdateCompetition(ctx context.Context, competition *aggregate.Competition) error
 	DeleteCompetition(ctx context.Context, id int32) error
-	ListCompetitions(ctx context.Context) ([]*aggregate.Competition, error)
+	ListCompetitions(ctx context.Context) ([]*aggregate.Competition, error)                                                                           // Lists all competitions, archived ones included
+	SearchCompetitions(ctx context.Context, filter *aggregate.CompetitionFilter, pageNumber, pageSize int32) ([]*aggregate.Competition, int32, error) // Lists a page of the competitions matching the filter and counts all of them
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type CompetitionContactRepository interface {
+	CreateContact(ctx context.Context, contact *aggregate.CompetitionContact) error
+	ListContacts(ctx context.Context, competitionID int32) ([]*aggregate.CompetitionContact, error)
+	DeleteContact(ctx context.Context, competitionID, id int32) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type CompetitionSettingsRepository interface {
+	SetCompetitionSettings(ctx context.Context, settings *aggregate.CompetitionSettings) error               // Replaces the previous settings of the competition
+	GetCompetitionSettings(ctx context.Context, competitionID int32) (*aggregate.CompetitionSettings, error) // Returns nil when the competition has no settings
+}
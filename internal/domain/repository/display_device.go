@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type DisplayDeviceRepository interface {
+	CreateDisplayDevice(ctx context.Context, device *aggregate.DisplayDevice) error // Sets the ID of the device
+	GetDisplayDevice(ctx context.Context, id int32) (*aggregate.DisplayDevice, error)
+	ListDisplayDevices(ctx context.Context, competitionID int32) ([]*aggregate.DisplayDevice, error)
+	TouchDisplayDevice(ctx context.Context, competitionID, id int32) error // Records a refresh of the device token
+	RevokeDisplayDevice(ctx context.Context, competitionID, id int32) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type ExportTemplateRepository interface {
+	SetExportTemplate(ctx context.Context, template *aggregate.ExportTemplate) error               // Replaces the previous template of the competition
+	GetExportTemplate(ctx context.Context, competitionID int32) (*aggregate.ExportTemplate, error) // Returns nil when the competition has no template
+	DeleteExportTemplate(ctx context.Context, competitionID int32) error
+}
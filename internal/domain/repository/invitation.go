@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, invitation *aggregate.Invitation) error
+	GetInvitation(ctx context.Context, id string) (*aggregate.Invitation, error)
+	ListPendingInvitations(ctx context.Context, competitionID int32) ([]*aggregate.Invitation, error)
+	ExtendInvitation(ctx context.Context, competitionID int32, id string, expiresAt time.Time) error // Only pending invitations can be extended
+	RevokeInvitation(ctx context.Context, competitionID int32, id string) error
+	AddInvitationAcceptance(ctx context.Context, competitionID int32, id string) error              // Fails once the invitation was accepted by as many users as it allows
+	ListInvitationStats(ctx context.Context, since time.Time) ([]*aggregate.InvitationStats, error) // Counts per competition the invitations created since the given time
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+)
+
+// LicenceRegistryRepository looks up the licence numbers of the participants in the register of a federation
+type LicenceRegistryRepository interface {
+	CheckLicence(ctx context.Context, licence, lastName, firstName string) (bool, error) // Whether the register knows the licence, held by this participant when the register compares the names
+}
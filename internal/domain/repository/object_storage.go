@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+)
+
+type ObjectStorageRepository interface {
+	PutObject(ctx context.Context, key, contentType string, content []byte) error // Replaces the object if it exists
+	GetObject(ctx context.Context, key string) ([]byte, string, error)            // Content and content type of the object, nil if it does not exist
+	ObjectURL(key string) string                                                  // Public URL the object is served from
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, organization *aggregate.Organization) (int32, error)
+	GetOrganization(ctx context.Context, organizationID int32) (*aggregate.Organization, error) // Includes the competitions of the organization
+	ListOrganizations(ctx context.Context) ([]*aggregate.Organization, error)                   // Includes the competitions of every organization
+	DeleteOrganization(ctx context.Context, organizationID int32) error                         // Detaches its competitions and removes its admin roles
+	SetCompetitionOrganization(ctx context.Context, competitionID, organizationID int32) error  // 0 detaches the competition
+	ListOrganizationCompetitionIDs(ctx context.Context, organizationIDs []int32) ([]int32, error)
+}
@@ -9,7 +9,26 @@ import (
 type ParticipantRepository interface {
 	GetParticipant(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.Participant, error)
 	CreateParticipant(ctx context.Context, participant *aggregate.Participant) error
+	CreateParticipantsBatch(ctx context.Context, participants []*aggregate.Participant) ([]int32, error) // Creates them in one transaction, skipping and returning the dossards already taken
 	UpdateParticipant(ctx context.Context, participant *aggregate.Participant) error
 	DeleteParticipant(ctx context.Context, competitionID int32, dossardNumber int32) error
+	CountParticipantRecords(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.ParticipantRecords, error)      // Records removed with the participant
+	DeleteParticipantWithRecords(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.ParticipantRecords, error) // Removes its records in the same transaction and counts them
+	CountCompetitionParticipantRecords(ctx context.Context, competitionID int32) (*aggregate.CompetitionParticipantRecords, error)     // Participants and records removed when they are cleared
+	ClearParticipants(ctx context.Context, competitionID int32) (*aggregate.CompetitionParticipantRecords, error)                      // Removes every participant with their records in one transaction and counts them
+	RenumberParticipant(ctx context.Context, competitionID int32, dossardNumber int32, newDossardNumber int32) error                   // Moves the runs, liveranking and pending runs to the new dossard
+	SwapParticipantDossards(ctx context.Context, competitionID int32, dossardNumber int32, otherDossardNumber int32) error             // Exchanges the dossards of two participants with their runs, liveranking and pending runs
 	ListParticipantsByCategory(ctx context.Context, competitionID int32, category string) ([]*aggregate.Participant, error)
+	ListParticipants(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error)
+	SearchParticipants(ctx context.Context, competitionID int32, filter *aggregate.ParticipantFilter, pageNumber, pageSize int32) ([]*aggregate.Participant, int32, error) // Lists a page of the participants matching the filter and counts all of them
+	SearchParticipantsByName(ctx context.Context, competitionID int32, query string, limit int32) ([]*aggregate.Participant, error)                                        // Lists the participants whose first or last name starts with every term of the query
+	ListWaitlistedParticipants(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error)                                                                 // In the order they entered the waitlist
+	PromoteWaitlistedParticipant(ctx context.Context, competitionID int32, dossardNumber int32) (int32, error)                                                             // Gives a place and the next free dossard after the registered participants, returned
+	ListParticipantEntries(ctx context.Context, licence string, name string) ([]*aggregate.Participant, error)                                                             // Entries of a person across the competitions, by licence or full name
+	CountRegisteredParticipants(ctx context.Context, competitionID int32) (int32, error)                                                                                   // Waitlisted participants are not counted
+	SetParticipantCheckedIn(ctx context.Context, competitionID int32, dossardNumber int32, checkedIn bool) error                                                           // Confirms or withdraws the presence of the participant at the start desk
+	SetParticipantNotes(ctx context.Context, competitionID int32, dossardNumber int32, notes string, flags []string) error                                                 // Replaces the notes and flags shown to the referees
+	SetParticipantStatus(ctx context.Context, competitionID int32, dossardNumber int32, status string, reason string) error                                                // Sets the registered, dns, dnf or dsq status and its reason
+	SetParticipantPhoto(ctx context.Context, competitionID int32, dossardNumber int32, photoKey string) error                                                              // Sets the object key of the photo the referees confirm the identity with
+	CountCheckIns(ctx context.Context, competitionID int32) ([]*aggregate.CheckInCount, error)                                                                             // Counts the registered and checked in participants of each category, waitlisted ones excluded
 }
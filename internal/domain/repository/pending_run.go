@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type PendingRunRepository interface {
+	CreatePendingRun(ctx context.Context, pendingRun *aggregate.PendingRun) (bool, error) // Sets the ID of the pending run, returns false when the same chrono is already pending
+	GetPendingRun(ctx context.Context, competitionID, id int32) (*aggregate.PendingRun, error)
+	ListPendingRuns(ctx context.Context, competitionID int32) ([]*aggregate.PendingRun, error)
+	DeletePendingRun(ctx context.Context, competitionID, id int32) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type RefereeAssignmentRepository interface {
+	AssignReferee(ctx context.Context, assignment *aggregate.RefereeAssignment) error // Fails with ErrDuplicateRefereeAssignment when the referee is already assigned to the zone
+	UnassignReferee(ctx context.Context, competitionID, userID int32, zone string) error
+	ListRefereeAssignments(ctx context.Context, competitionID int32) ([]*aggregate.RefereeAssignment, error)
+	ListRefereeZones(ctx context.Context, competitionID, userID int32) ([]string, error) // Lists the zones the referee is assigned to
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type RefereeOnboardingRepository interface {
+	GetRefereeOnboarding(ctx context.Context, competitionID, userID int32) (*aggregate.RefereeOnboarding, error) // Returns nil when the referee has not started the onboarding
+	ListRefereeOnboardings(ctx context.Context, competitionID int32) ([]*aggregate.RefereeOnboarding, error)
+	SetRefereeOnboarding(ctx context.Context, onboarding *aggregate.RefereeOnboarding) error // Replaces the previous state of the onboarding
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type RefereePinRepository interface {
+	SetRefereePin(ctx context.Context, pin *aggregate.RefereePin) error // Replaces the previous PIN of the referee
+	GetRefereePinByHash(ctx context.Context, competitionID int32, pinHash string) (*aggregate.RefereePin, error)
+	DeleteRefereePin(ctx context.Context, competitionID, userID int32) error
+}
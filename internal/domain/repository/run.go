@@ -2,16 +2,27 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 )
 
 type RunRepository interface {
-	CreateRun(ctx context.Context, run *aggregate.Run) error
+	CreateRun(ctx context.Context, run *aggregate.Run, maxRunsPerZone int32) ([]int32, error)
 	GetRun(ctx context.Context, competitionID, runNumber, dossard int32) (*aggregate.Run, error)
 	ListRuns(ctx context.Context, competitionID int32) ([]*aggregate.Run, error)
+	SearchRuns(ctx context.Context, competitionID int32, filter *aggregate.RunFilter, pageNumber, pageSize int32) ([]*aggregate.Run, int32, error) // Lists a page of the runs matching the filter, most recent first, with the referee names, and counts all of them
 	ListRunsByDossard(ctx context.Context, competitionID int32, dossard int32) ([]*aggregate.Run, error)
 	ListRunsByDossardWithDetails(ctx context.Context, competitionID int32, dossard int32) ([]*aggregate.Run, error)
-	UpdateRun(ctx context.Context, run *aggregate.Run) error
+	GetRunByReceiptCode(ctx context.Context, receiptCode string) (*aggregate.Run, error)                 // Includes the referee name
+	GetRunByIdempotencyKey(ctx context.Context, competitionID int32, key string) (*aggregate.Run, error) // Includes the receipt code, nil when there is none
+	ListRunsSince(ctx context.Context, competitionID int32, since time.Time) ([]*aggregate.Run, error)
+	UpdateRun(ctx context.Context, run *aggregate.Run, editorID int32) error                                         // Records the change of the values of the run in its revisions
+	ListRunRevisions(ctx context.Context, competitionID, dossard, runNumber int32) ([]*aggregate.RunRevision, error) // Oldest first, with the editor names
 	DeleteRun(ctx context.Context, competitionID, runNumber, dossard int32) error
+	RestoreRun(ctx context.Context, run *aggregate.Run) error                                                    // Inserts a run as it was archived, keeping its number, creation and void times
+	ListConflictingRuns(ctx context.Context, competitionID int32) ([]*aggregate.Run, error)                      // Lists the runs of participants scored more than once in the same zone, voided runs excluded
+	VoidRun(ctx context.Context, competitionID, runNumber, dossard int32) error                                  // Voids a run and recalculates the liveranking of the participant
+	ApproveRun(ctx context.Context, competitionID, runNumber, dossard int32) error                               // Approves a run waiting for its approval and recalculates the liveranking of the participant
+	ResolveRunConflict(ctx context.Context, competitionID, dossard, keptRunNumber, maxRuns int32) (int32, error) // Voids the runs of the participant in the zone of the kept run beyond the maxRuns expected, keeping it, and recalculates the liveranking, returns the number of voided runs
 }
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type SchemaRepository interface {
+	GetSchemaVersion(ctx context.Context) (int32, time.Time, error) // 0 before any version was recorded
+	ListMissingSchema(ctx context.Context) ([]string, error)        // Tables and columns the migrations create that the database lacks
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type SeriesRepository interface {
+	CreateSeries(ctx context.Context, series *aggregate.Series) (int32, error)
+	GetSeries(ctx context.Context, seriesID int32) (*aggregate.Series, error) // Includes the competitions of the series
+	ListSeries(ctx context.Context) ([]*aggregate.Series, error)              // Includes the competitions of every series
+	UpdateSeries(ctx context.Context, series *aggregate.Series) error         // Renames the series
+	DeleteSeries(ctx context.Context, seriesID int32) error
+	AddCompetitionToSeries(ctx context.Context, seriesID, competitionID int32) error
+	RemoveCompetitionFromSeries(ctx context.Context, seriesID, competitionID int32) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *aggregate.Session) error
+	GetSession(ctx context.Context, id string) (*aggregate.Session, error)
+	ListActiveSessions(ctx context.Context, userID int32) ([]*aggregate.Session, error)
+	TouchSession(ctx context.Context, id, ip string, expiresAt time.Time) error // Records a refresh of the session
+	RevokeSession(ctx context.Context, userID int32, id string) error
+	RevokeAllSessions(ctx context.Context, userID int32) (int32, error) // Returns the number of sessions revoked
+}
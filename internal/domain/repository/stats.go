@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type StatsRepository interface {
+	GetSystemStats(ctx context.Context) (*aggregate.SystemStats, error)                  // Counts the records of the whole platform
+	ListPointsEarned(ctx context.Context, season int) ([]*aggregate.PointsEarned, error) // Lists the points of the competitions whose date mentions the season year
+}
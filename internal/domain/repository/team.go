@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type TeamRepository interface {
+	CreateTeam(ctx context.Context, team *aggregate.Team) error // Adds its members in the same transaction
+	GetTeam(ctx context.Context, competitionID, id int32) (*aggregate.Team, error)
+	UpdateTeam(ctx context.Context, team *aggregate.Team) error                    // Replaces its members in the same transaction
+	ListTeams(ctx context.Context, competitionID int32) ([]*aggregate.Team, error) // Ordered by name
+	DeleteTeam(ctx context.Context, competitionID, id int32) error
+	ListTeamRankings(ctx context.Context, competitionID int32) ([]*aggregate.TeamRanking, error) // Teams with the liveranking scores of their members, unranked
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenDenylistRepository holds the revoked access tokens until they expire
+type TokenDenylistRepository interface {
+	DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	DenyUserTokens(ctx context.Context, userID int32, issuedBefore, expiresAt time.Time) error // Denies the tokens of the user issued before issuedBefore, the entry is kept until expiresAt
+	IsDenied(ctx context.Context, userID int32, tokenID string, issuedAt time.Time) (bool, error)
+}
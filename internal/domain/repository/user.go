@@ -12,4 +12,10 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user *aggregate.User) error
 	DeleteUser(ctx context.Context, id int32) error
 	GetUserByEmail(ctx context.Context, email string) (*aggregate.User, error)
+	ListUsers(ctx context.Context) ([]*aggregate.User, error)
+	SearchUsers(ctx context.Context, query string, pageNumber, pageSize int32) ([]*aggregate.User, int32, error) // Matches names and email, returns the page and the total count
+	ListUserRoles(ctx context.Context, userID int32) ([]*aggregate.UserRole, error)
+	ListCompetitionRoles(ctx context.Context, competitionID int32) ([]*aggregate.UserRole, error)
+	GetUserByIdentity(ctx context.Context, provider, subject string) (*aggregate.User, error) // Returns the user linked to the OpenID Connect identity
+	LinkIdentity(ctx context.Context, provider, subject string, userID int32) error
 }
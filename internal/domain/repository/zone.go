@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type ZoneRepository interface {
+	CreateZone(ctx context.Context, zone *aggregate.Zone) error
+	EnsureZone(ctx context.Context, competitionID int32, zone string) error // Creates the zone without description unless it exists
+	GetZone(ctx context.Context, competitionID int32, zone string) (*aggregate.Zone, error)
+	ListZones(ctx context.Context, competitionID int32) ([]*aggregate.Zone, error)
+	UpdateZone(ctx context.Context, zone *aggregate.Zone) error
+	SetZoneOpen(ctx context.Context, competitionID int32, zone string, open bool) error
+	DeleteZone(ctx context.Context, competitionID int32, zone string) error
+}
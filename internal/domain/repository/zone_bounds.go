@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+type ZoneBoundsRepository interface {
+	SetZoneBounds(ctx context.Context, bounds *aggregate.ZoneBounds) error // Replaces the previous bounds of the zone
+	ListZoneBounds(ctx context.Context, competitionID int32) ([]*aggregate.ZoneBounds, error)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// APIKeyService defines the operations for managing the API keys of external integrations
+type APIKeyService interface {
+	// CreateAPIKey creates a key for the competition and returns it with its plain value, which is never stored
+	CreateAPIKey(ctx context.Context, competitionID int32, name string, scopes []string, createdBy int32) (*aggregate.APIKey, string, error)
+
+	// ListAPIKeys lists the keys of a competition, including revoked ones
+	ListAPIKeys(ctx context.Context, competitionID int32) ([]*aggregate.APIKey, error)
+
+	// RevokeAPIKey revokes a key of the competition
+	RevokeAPIKey(ctx context.Context, competitionID, keyID int32) error
+
+	// Authenticate returns the active key matching the plain value
+	Authenticate(ctx context.Context, plainKey string) (*aggregate.APIKey, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// AuditService records sensitive or suspicious actions for later review by the organizers
+type AuditService interface {
+	Record(ctx context.Context, auditLog *aggregate.AuditLog) error
+	ListAuditLogs(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuditLog, int32, error)
+}
@@ -3,23 +3,100 @@ package service
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
 )
 
 type CompetitionService interface {
 	CreateCompetition(ctx context.Context, competition *aggregate.Competition) (int32, error)
+	UpdateCompetition(ctx context.Context, competition *aggregate.Competition) (*aggregate.Competition, error)
 	AddScale(ctx context.Context, competitionID int32, scale *aggregate.Scale) error
-	AddParticipants(ctx context.Context, competitionID int32, file io.Reader, filename string) error
+	AddParticipants(ctx context.Context, competitionID int32, file io.Reader, filename string, dryRun, checkDuplicates bool) (*aggregate.ParticipantImportReport, error) // Writes nothing on a dry run or when likely duplicates are found
 	CreateParticipant(ctx context.Context, participant *aggregate.Participant) error
-	ListCompetitions(ctx context.Context) ([]*aggregate.Competition, error)
+	SetCompetitionRegistration(ctx context.Context, competitionID, maxParticipants int32, deadline time.Time, waitlist bool) (*aggregate.Competition, error)
+	CountRegistrations(ctx context.Context, competitionID int32) (int32, int32, error) // Registered then waitlisted participants
+	PromoteWaitlistedParticipant(ctx context.Context, competitionID, dossardNumber int32) (*aggregate.Participant, error)
+	ListWaitlist(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error)
+	PromoteWaitlist(ctx context.Context, competitionID, count int32) ([]*aggregate.Participant, error)                          // Promotes the head of the waitlist within the places left
+	CheckInParticipant(ctx context.Context, competitionID, dossardNumber int32, checkedIn bool) (*aggregate.Participant, error) // Confirms or withdraws the presence at the start desk
+	GetCheckInSummary(ctx context.Context, competitionID int32) ([]*aggregate.CheckInCount, error)
+	ListCompetitions(ctx context.Context, filter *aggregate.CompetitionFilter, pageNumber, pageSize int32) ([]*aggregate.Competition, int32, error)
+	MarkCompetitionArchived(ctx context.Context, competitionID int32) (*aggregate.Competition, error)
+	SetCompetitionStatus(ctx context.Context, competitionID int32, status string) (*aggregate.Competition, error)
+	CloneCompetition(ctx context.Context, competitionID int32, name, date string) (*aggregate.Competition, error)
+	DeleteCompetition(ctx context.Context, competitionID int32) error
 	GetCompetition(ctx context.Context, competitionID int32) (*aggregate.Competition, error)
+	GetPublicCompetition(ctx context.Context, competitionID int32) (*aggregate.Competition, []aggregate.ZoneInfo, time.Time, error)
 	GetParticipant(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.Participant, error)
-	ListParticipantsByCategory(ctx context.Context, competitionID int32, category string) ([]*aggregate.Participant, error)
+	RenumberParticipant(ctx context.Context, competitionID int32, dossardNumber int32, newDossardNumber int32, swap bool) (*aggregate.Participant, error)
+	DeleteParticipant(ctx context.Context, competitionID int32, dossardNumber int32, dryRun bool) (*aggregate.ParticipantRecords, error)    // Only counts the records on a dry run
+	CountParticipantsToClear(ctx context.Context, competitionID int32) (*aggregate.CompetitionParticipantRecords, string, error)            // Returns the token confirming the clearing
+	ClearParticipants(ctx context.Context, competitionID int32, confirmationToken string) (*aggregate.CompetitionParticipantRecords, error) // Removes every participant with their records
+	ExportParticipants(ctx context.Context, competitionID int32, format string) ([]byte, string, error)                                     // Same columns as the participants import
+	SearchParticipants(ctx context.Context, competitionID int32, filter *aggregate.ParticipantFilter, pageNumber, pageSize int32) ([]*aggregate.Participant, int32, error)
+	ListParticipantDuplicates(ctx context.Context, competitionID int32) ([]*aggregate.ParticipantDuplicate, error)
+	SetParticipantNotes(ctx context.Context, competitionID, dossard int32, notes string, flags []string) (*aggregate.Participant, error)
+	SetParticipantPhoto(ctx context.Context, competitionID, dossard int32, r io.Reader) (*aggregate.Participant, error) // Stores the photo in the object storage
+	GetParticipantPhoto(ctx context.Context, competitionID, dossard int32) ([]byte, string, error)                      // Photo and its content type, only with the photo rights consent
+	SetParticipantStatus(ctx context.Context, competitionID, dossard int32, status, reason string) (*aggregate.Participant, error)
+	GetParticipantHistory(ctx context.Context, licence, name string) ([]*aggregate.ParticipantHistoryEntry, error) // Results of a person across the competitions, the latest first
+	SearchParticipantsByName(ctx context.Context, competitionID int32, query string, limit int32) ([]*aggregate.Participant, error)
 	ListZones(ctx context.Context, competitionID int32) ([]aggregate.ZoneInfo, error)
+	GetCompetitionSettings(ctx context.Context, competitionID int32) (*aggregate.CompetitionSettings, error)
+	UpdateCompetitionSettings(ctx context.Context, settings *aggregate.CompetitionSettings) error
+	SetZoneBounds(ctx context.Context, bounds *aggregate.ZoneBounds) error
+	ListZoneBounds(ctx context.Context, competitionID int32) ([]*aggregate.ZoneBounds, error)
 	GetScale(ctx context.Context, competitionID int32, category string, zone string) (*aggregate.Scale, error)
 	UpdateScale(ctx context.Context, competitionID int32, scale *aggregate.Scale) error
 	DeleteScale(ctx context.Context, competitionID int32, category string, zone string) error
-	GetLiveranking(ctx context.Context, competitionID int32, category, gender string, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error)
-	ExportCompetitionResults(ctx context.Context, competitionID int32) ([]byte, string, error)
+	CreateZone(ctx context.Context, zone *aggregate.Zone) error
+	GetZone(ctx context.Context, competitionID int32, zone string) (*aggregate.Zone, error)
+	ListZoneDetails(ctx context.Context, competitionID int32) ([]*aggregate.Zone, error)
+	UpdateZone(ctx context.Context, zone *aggregate.Zone) error
+	SetZoneOpen(ctx context.Context, competitionID int32, zone string, open bool) (*aggregate.Zone, error) // Runs are rejected in closed zones
+	DeleteZone(ctx context.Context, competitionID int32, zone string) error                                // Fails while scales reference the zone
+	ExportScales(ctx context.Context, competitionID int32) ([]byte, string, error)
+	ImportScales(ctx context.Context, competitionID int32, file io.Reader, filename string) (int32, int32, error) // Returns the number of scales created and updated
+	GetLiveranking(ctx context.Context, competitionID int32, category, gender string, checkedInOnly bool, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error)
+	UpdateTimeDisplay(ctx context.Context, competitionID int32, chronoFormat, chronoDirection string) (*aggregate.Competition, error)
+	PublishResults(ctx context.Context, competitionID int32) (*models.ResultsPublicationResponse, error)
+	EmailClubs(ctx context.Context, competitionID int32, subject, body string, attachment io.Reader, attachmentName string) ([]*aggregate.ClubContact, error)
+	ExportCompetitionResults(ctx context.Context, competitionID int32, consent string) ([]byte, string, error)
+	SetExportTemplate(ctx context.Context, competitionID int32, r io.Reader, filename string) (*aggregate.ExportTemplate, error)
+	GetExportTemplate(ctx context.Context, competitionID int32) (*aggregate.ExportTemplate, error)
+	DeleteExportTemplate(ctx context.Context, competitionID int32) error
+	AddCategory(ctx context.Context, category *aggregate.Category) error
+	ListCategories(ctx context.Context, competitionID int32) ([]*aggregate.Category, error)
+	UpdateCategory(ctx context.Context, category *aggregate.Category) error
+	DeleteCategory(ctx context.Context, competitionID, categoryID int32) error
+	AddClub(ctx context.Context, club *aggregate.Club) error
+	ListClubs(ctx context.Context, competitionID int32) ([]*aggregate.Club, error)
+	UpdateClub(ctx context.Context, club *aggregate.Club) error
+	DeleteClub(ctx context.Context, competitionID, clubID int32) error
+	GetClubRanking(ctx context.Context, competitionID int32) ([]*aggregate.ClubRanking, error)
+	AddTeam(ctx context.Context, team *aggregate.Team) error
+	ListTeams(ctx context.Context, competitionID int32) ([]*aggregate.Team, error)
+	UpdateTeam(ctx context.Context, team *aggregate.Team) error
+	DeleteTeam(ctx context.Context, competitionID, teamID int32) error
+	GetTeamRanking(ctx context.Context, competitionID int32, countedMembers int32) ([]*aggregate.TeamRanking, error)
+	AddContact(ctx context.Context, contact *aggregate.CompetitionContact) error
+	ListContacts(ctx context.Context, competitionID int32) ([]*aggregate.CompetitionContact, error)
+	DeleteContact(ctx context.Context, competitionID, contactID int32) error
+	GetContactEmails(ctx context.Context, competitionID int32, role string) ([]string, error)
+	CreateSeries(ctx context.Context, series *aggregate.Series) (int32, error)
+	GetSeries(ctx context.Context, seriesID int32) (*aggregate.Series, error)
+	ListSeries(ctx context.Context) ([]*aggregate.Series, error)
+	RenameSeries(ctx context.Context, seriesID int32, name string) (*aggregate.Series, error)
+	DeleteSeries(ctx context.Context, seriesID int32) error
+	AddCompetitionToSeries(ctx context.Context, seriesID, competitionID int32) (*aggregate.Series, error)
+	RemoveCompetitionFromSeries(ctx context.Context, seriesID, competitionID int32) (*aggregate.Series, error)
+	GetSeriesStandings(ctx context.Context, seriesID int32, category, gender string) ([]*aggregate.SeriesStanding, time.Time, error) // Sums the points earned in the competitions of the series
+	CreateOrganization(ctx context.Context, organization *aggregate.Organization) (int32, error)
+	GetOrganization(ctx context.Context, organizationID int32) (*aggregate.Organization, error)
+	ListOrganizations(ctx context.Context) ([]*aggregate.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationID int32) error
+	SetCompetitionOrganization(ctx context.Context, competitionID, organizationID int32) (*aggregate.Competition, error) // 0 detaches the competition
+	ListOrganizationCompetitionIDs(ctx context.Context, organizationIDs []int32) ([]int32, error)
 }
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 )
@@ -11,21 +13,81 @@ type RunService interface {
 	// CreateRun creates a new run and updates the liveranking
 	CreateRun(ctx context.Context, run *aggregate.Run) error
 
+	// FindReplayedRun returns the run already recorded with the idempotency key of the run, nil if there is none
+	FindReplayedRun(ctx context.Context, run *aggregate.Run) (*aggregate.Run, error)
+
+	// CheckRunBounds returns the values of the run outside the bounds of its zone
+	CheckRunBounds(ctx context.Context, run *aggregate.Run) ([]aggregate.RunWarning, error)
+
 	// GetRun retrieves a run by its identifiers
 	GetRun(ctx context.Context, competitionID, runNumber, dossard int32) (*aggregate.Run, error)
 
 	// ListRuns lists all runs for a competition
 	ListRuns(ctx context.Context, competitionID int32) ([]*aggregate.Run, error)
 
+	// SearchRuns lists a page of the runs of a competition matching the filter, most recent first, and counts all of them
+	SearchRuns(ctx context.Context, competitionID int32, filter *aggregate.RunFilter, pageNumber, pageSize int32) ([]*aggregate.Run, int32, error)
+
 	// ListRunsByDossard lists all runs for a participant in a competition
 	ListRunsByDossard(ctx context.Context, competitionID int32, dossard int32) ([]*aggregate.Run, error)
 
 	// ListRunsByDossardWithDetails lists all runs for a participant with referee information
 	ListRunsByDossardWithDetails(ctx context.Context, competitionID int32, dossard int32) ([]*aggregate.Run, error)
 
-	// UpdateRun updates an existing run and recalculates liveranking
-	UpdateRun(ctx context.Context, run *aggregate.Run) error
+	// UpdateRun updates an existing run, records the change in its revisions with the editor and recalculates liveranking
+	UpdateRun(ctx context.Context, run *aggregate.Run, editorID int32) error
+
+	// ListRunRevisions lists the changes of the values of a run, oldest first
+	ListRunRevisions(ctx context.Context, competitionID, dossard, runNumber int32) ([]*aggregate.RunRevision, error)
 
 	// DeleteRun deletes a run and recalculates liveranking
 	DeleteRun(ctx context.Context, competitionID, runNumber, dossard int32) error
+
+	// UpdateOwnRun lets the referee who recorded a run edit it within the correction window
+	UpdateOwnRun(ctx context.Context, run *aggregate.Run, refereeID int32) error
+
+	// DeleteOwnRun lets the referee who recorded a run delete it within the correction window
+	DeleteOwnRun(ctx context.Context, competitionID, runNumber, dossard, refereeID int32) error
+
+	// VoidOwnRun lets the referee who recorded a run void it within the undo window
+	VoidOwnRun(ctx context.Context, competitionID, runNumber, dossard, refereeID int32) (*aggregate.Run, error)
+
+	// RequiresRunApproval returns whether the runs of the referees only count once an admin approves them
+	RequiresRunApproval(ctx context.Context, competitionID int32) (bool, error)
+
+	// ApproveRun approves a run recorded by a referee so that it counts in the ranking
+	ApproveRun(ctx context.Context, competitionID, runNumber, dossard int32) (*aggregate.Run, error)
+
+	// ListRunConflicts lists the participants scored more than once in the same zone
+	ListRunConflicts(ctx context.Context, competitionID int32) ([]*aggregate.RunConflict, error)
+
+	// ResolveRunConflict keeps a run and voids the runs of the participant in its zone beyond the expected ones, returns the number of voided runs
+	ResolveRunConflict(ctx context.Context, competitionID, dossard, keptRunNumber int32) (int32, error)
+
+	// ImportChronos merges the chronos of a timing system export into the runs, the chronos without a run become pending runs
+	ImportChronos(ctx context.Context, competitionID int32, file io.Reader, importedBy int32) (*aggregate.ChronoImportReport, error)
+
+	// ListPendingRuns lists the imported chronos waiting for a referee to confirm their run
+	ListPendingRuns(ctx context.Context, competitionID int32) ([]*aggregate.PendingRun, error)
+
+	// ConfirmPendingRun records the run of a pending run with the doors and penalty given by the referee
+	ConfirmPendingRun(ctx context.Context, competitionID, pendingRunID int32, run *aggregate.Run) error
+
+	// DiscardPendingRun removes a pending run without recording its run
+	DiscardPendingRun(ctx context.Context, competitionID, pendingRunID int32) error
+
+	// GetZoneThroughput computes the run throughput of every zone over the given window
+	GetZoneThroughput(ctx context.Context, competitionID int32, window time.Duration) ([]*aggregate.ZoneThroughput, error)
+
+	// GetRunByReceiptCode returns the run with the receipt code, with the name of its referee
+	GetRunByReceiptCode(ctx context.Context, receiptCode string) (*aggregate.Run, error)
+
+	// CheckRefereeZone returns ErrZoneNotAssigned when the competition restricts the referees to their zones and the referee of the run is not assigned to its zone
+	CheckRefereeZone(ctx context.Context, run *aggregate.Run) error
+
+	// LiverankingVersion returns the current liveranking version of the competition, it increases whenever the liveranking changes
+	LiverankingVersion(competitionID int32) int64
+
+	// WaitLiverankingChange blocks until the liveranking version differs from version or the context is done, returns the current version
+	WaitLiverankingChange(ctx context.Context, competitionID int32, version int64) int64
 }
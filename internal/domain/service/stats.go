@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// StatsService reports the usage of the whole platform to the super admins and the season standings
+type StatsService interface {
+	GetSystemStats(ctx context.Context) (*aggregate.SystemStats, error)
+	GetClubStandings(ctx context.Context, season int) ([]*aggregate.SeasonStanding, time.Time, error)
+	GetAthleteStandings(ctx context.Context, season int) ([]*aggregate.SeasonStanding, time.Time, error)
+}
@@ -2,19 +2,66 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/entity"
 )
 
 type UserService interface {
 	Login(ctx context.Context, email, password string) (*aggregate.JwtToken, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*aggregate.JwtToken, error)
+	OIDCProviders() []string
+	OIDCAuthorizationURL(ctx context.Context, provider, state, nonce string) (string, error)
+	LoginWithOIDC(ctx context.Context, provider, code, nonce string) (*aggregate.JwtToken, error)
 	AddUserToCompetition(ctx context.Context, email string, competition *aggregate.Competition) error
 	InviteUser(ctx context.Context, firstName, lastName, email string, competition *aggregate.Competition) error
+	ImportUsers(ctx context.Context, file io.Reader, competition *aggregate.Competition) (*aggregate.UserImportReport, error)
 	SetUserAsAdmin(ctx context.Context, email string, competitionID int32) (*aggregate.JwtToken, error)
 	ChangePassword(ctx context.Context, userID int32, currentPassword, newPassword string) error
 	ForgotPassword(ctx context.Context, email string) error
-	GenerateRefereeInvitationToken(ctx context.Context, competitionID int32) (string, int64, error)
+	GenerateRefereeInvitationToken(ctx context.Context, competitionID int32) (string, *aggregate.Invitation, error)
+	GenerateRefereeInvitationTokens(ctx context.Context, competitionID int32, count int32, zone string, lifetime time.Duration) ([]string, []*aggregate.Invitation, error)
+	BuildRefereeInvitationSheet(competition *aggregate.Competition, tokens []string, invitations []*aggregate.Invitation) ([]byte, string, error)
+	ListInvitations(ctx context.Context, competitionID int32) ([]*aggregate.Invitation, error)
+	RevokeInvitation(ctx context.Context, competitionID int32, invitationID string) error
+	ExtendInvitation(ctx context.Context, competitionID int32, invitationID string, lifetime time.Duration) (string, *aggregate.Invitation, error)
 	AcceptRefereeInvitation(ctx context.Context, token string, userEmail string) (*aggregate.JwtToken, error)
 	AcceptRefereeInvitationUnauthenticated(ctx context.Context, token, firstName, lastName, email, password string) (*aggregate.JwtToken, error)
+	GenerateAdminInvitationToken(ctx context.Context, competitionID int32) (string, *aggregate.Invitation, error)
+	InviteAdmin(ctx context.Context, email string, competition *aggregate.Competition) (*aggregate.Invitation, error)
+	AcceptAdminInvitation(ctx context.Context, token string, userEmail string) (*aggregate.JwtToken, error)
+	AcceptAdminInvitationUnauthenticated(ctx context.Context, token, firstName, lastName, email, password string) (*aggregate.JwtToken, error)
+	PruneExpiredRoles(ctx context.Context) (*aggregate.RolesPruneReport, error)
+	GrantSuperAdmin(ctx context.Context, email string) error
+	Impersonate(ctx context.Context, superAdminEmail string, userID int32) (*aggregate.JwtToken, error)
+	GenerateRefereePin(ctx context.Context, competitionID, userID, createdBy int32) (string, error)
+	RevokeRefereePin(ctx context.Context, competitionID, userID int32) error
+	LoginWithRefereePin(ctx context.Context, competitionID int32, pin string) (*aggregate.JwtToken, error)
+	GetRefereeOnboarding(ctx context.Context, competitionID, userID int32) (*aggregate.RefereeOnboarding, error)
+	UpdateRefereeOnboarding(ctx context.Context, competitionID, userID int32, rulesRead bool, zone string) (*aggregate.RefereeOnboarding, error)
+	SubmitRefereeTestRun(ctx context.Context, competitionID, userID int32, run *aggregate.Run) (*aggregate.RefereeOnboarding, error) // Checks the run without recording it
+	ListRefereeOnboardings(ctx context.Context, competitionID int32) ([]*aggregate.RefereeOnboarding, int32, error)                  // Also returns the number of pending referee invitations
+	AssignRefereeToZone(ctx context.Context, competitionID, userID int32, zone string, assignedBy int32) (*aggregate.RefereeAssignment, error)
+	UnassignRefereeFromZone(ctx context.Context, competitionID, userID int32, zone string) error
+	ListRefereeAssignments(ctx context.Context, competitionID int32) ([]*aggregate.RefereeAssignment, error)
+	RegisterDisplayDevice(ctx context.Context, competitionID int32, name string, lifetime time.Duration, createdBy int32) (*aggregate.DisplayDevice, string, error)
+	ListDisplayDevices(ctx context.Context, competitionID int32) ([]*aggregate.DisplayDevice, error)
+	RevokeDisplayDevice(ctx context.Context, competitionID, deviceID int32) error
+	LoginWithDisplayToken(ctx context.Context, displayToken string) (*aggregate.JwtToken, error)
+	GrantObserverRole(ctx context.Context, email string, competitionID int32) (*aggregate.User, error)
+	RevokeObserverRole(ctx context.Context, competitionID, userID int32) error
+	GrantOrganizationAdminRole(ctx context.Context, email string, organizationID int32) (*aggregate.User, error)
+	RevokeOrganizationAdminRole(ctx context.Context, organizationID, userID int32) error
+	ListSessions(ctx context.Context, userID int32) ([]*aggregate.Session, error)
+	RevokeSession(ctx context.Context, userID int32, sessionID string) error
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	RevokeAccessToken(ctx context.Context, accessToken string) error
+	LogoutAll(ctx context.Context, userID int32) (int32, error)
+	IsAccessTokenRevoked(ctx context.Context, token entity.UserToken) bool
+	SearchUsers(ctx context.Context, query string, pageNumber, pageSize int32) ([]*aggregate.User, int32, error)
+	ListUserRoles(ctx context.Context, userID int32) ([]*aggregate.UserRole, error)
+	ListSecurityEvents(ctx context.Context, userID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error)
+	ListCompetitionSecurityEvents(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error)
 }
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrAPIKeyNotFound is returned when an API key cannot be found
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// SQLAPIKeyRepository is an implementation of the APIKeyRepository interface that uses SQL
+type SQLAPIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewSQLAPIKeyRepository creates a new SQLAPIKeyRepository
+func NewSQLAPIKeyRepository(db *sql.DB) repo.APIKeyRepository {
+	return &SQLAPIKeyRepository{
+		db: db,
+	}
+}
+
+// APIKey is an internal representation of an API key for DB operations
+type APIKey struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	Prefix        string
+	KeyHash       string
+	Scopes        string
+	CreatedBy     int32
+	CreatedAt     time.Time
+	LastUsedAt    sql.NullTime
+	RevokedAt     sql.NullTime
+}
+
+// CreateAPIKey stores a new API key and sets its generated ID
+func (r *SQLAPIKeyRepository) CreateAPIKey(ctx context.Context, apiKey *aggregate.APIKey) error {
+	query := `
+		INSERT INTO api_keys (competition_id, name, key_prefix, key_hash, scopes, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	createdAt := apiKey.GetCreatedAt()
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+		apiKey.SetCreatedAt(createdAt)
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		apiKey.GetCompetitionID(),
+		apiKey.GetName(),
+		apiKey.GetPrefix(),
+		apiKey.GetKeyHash(),
+		strings.Join(apiKey.GetScopes(), ","),
+		apiKey.GetCreatedBy(),
+		createdAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	apiKey.SetID(int32(id))
+
+	return nil
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its plain value
+func (r *SQLAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*aggregate.APIKey, error) {
+	query := `
+		SELECT id, competition_id, name, key_prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = ?
+	`
+
+	var apiKey APIKey
+	row := r.db.QueryRowContext(ctx, query, keyHash)
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.CompetitionID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&apiKey.Scopes,
+		&apiKey.CreatedBy,
+		&apiKey.CreatedAt,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return mapToAPIKeyAggregate(apiKey), nil
+}
+
+// ListAPIKeys lists the API keys of a competition, most recent first
+func (r *SQLAPIKeyRepository) ListAPIKeys(ctx context.Context, competitionID int32) ([]*aggregate.APIKey, error) {
+	query := `
+		SELECT id, competition_id, name, key_prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE competition_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apiKeys []*aggregate.APIKey
+	for rows.Next() {
+		var apiKey APIKey
+		err := rows.Scan(
+			&apiKey.ID,
+			&apiKey.CompetitionID,
+			&apiKey.Name,
+			&apiKey.Prefix,
+			&apiKey.KeyHash,
+			&apiKey.Scopes,
+			&apiKey.CreatedBy,
+			&apiKey.CreatedAt,
+			&apiKey.LastUsedAt,
+			&apiKey.RevokedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, mapToAPIKeyAggregate(apiKey))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey marks an API key of the competition as revoked
+func (r *SQLAPIKeyRepository) RevokeAPIKey(ctx context.Context, competitionID, id int32) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+		WHERE competition_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		// The key may already be revoked, in which case the update changes nothing
+		var exists bool
+		err = r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM api_keys WHERE competition_id = ? AND id = ?)", competitionID, id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrAPIKeyNotFound
+		}
+	}
+
+	return nil
+}
+
+// TouchAPIKey records the key of the competition has just been used
+func (r *SQLAPIKeyRepository) TouchAPIKey(ctx context.Context, competitionID, id int32) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = CURRENT_TIMESTAMP
+		WHERE competition_id = ? AND id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, competitionID, id)
+	return err
+}
+
+// Helper function to map an APIKey struct to an APIKey aggregate
+func mapToAPIKeyAggregate(apiKey APIKey) *aggregate.APIKey {
+	apiKeyAggregate := aggregate.NewAPIKey()
+	apiKeyAggregate.SetID(apiKey.ID)
+	apiKeyAggregate.SetCompetitionID(apiKey.CompetitionID)
+	apiKeyAggregate.SetName(apiKey.Name)
+	apiKeyAggregate.SetPrefix(apiKey.Prefix)
+	apiKeyAggregate.SetKeyHash(apiKey.KeyHash)
+	if apiKey.Scopes != "" {
+		apiKeyAggregate.SetScopes(strings.Split(apiKey.Scopes, ","))
+	}
+	apiKeyAggregate.SetCreatedBy(apiKey.CreatedBy)
+	apiKeyAggregate.SetCreatedAt(apiKey.CreatedAt)
+	if apiKey.LastUsedAt.Valid {
+		apiKeyAggregate.SetLastUsedAt(apiKey.LastUsedAt.Time)
+	}
+	if apiKey.RevokedAt.Valid {
+		apiKeyAggregate.SetRevokedAt(apiKey.RevokedAt.Time)
+	}
+	return apiKeyAggregate
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLAuditLogRepository is an implementation of the AuditLogRepository interface that uses SQL
+type SQLAuditLogRepository struct {
+	db *sql.DB
+}
+
+// NewSQLAuditLogRepository creates a new SQLAuditLogRepository
+func NewSQLAuditLogRepository(db *sql.DB) repo.AuditLogRepository {
+	return &SQLAuditLogRepository{
+		db: db,
+	}
+}
+
+// AuditLog is an internal representation of an audit log entry for DB operations
+type AuditLog struct {
+	ID            int32
+	CompetitionID sql.NullInt32
+	UserID        sql.NullInt32
+	Action        string
+	Details       sql.NullString
+	IP            string
+	CreatedAt     time.Time
+}
+
+// CreateAuditLog stores a new audit log entry and sets its generated ID
+func (r *SQLAuditLogRepository) CreateAuditLog(ctx context.Context, auditLog *aggregate.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (competition_id, user_id, action, details, ip, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	createdAt := auditLog.GetCreatedAt()
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+		auditLog.SetCreatedAt(createdAt)
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		sql.NullInt32{Int32: auditLog.GetCompetitionID(), Valid: auditLog.GetCompetitionID() != 0},
+		sql.NullInt32{Int32: auditLog.GetUserID(), Valid: auditLog.GetUserID() != 0},
+		auditLog.GetAction(),
+		auditLog.GetDetails(),
+		auditLog.GetIP(),
+		createdAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	auditLog.SetID(int32(id))
+
+	return nil
+}
+
+// ListAuditLogs lists the audit log entries of a competition, most recent first
+func (r *SQLAuditLogRepository) ListAuditLogs(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuditLog, int32, error) {
+	if pageSize <= 0 {
+		pageSize = 10 // Default page size
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1 // Default page number
+	}
+
+	// Get total count first
+	countQuery := `
+		SELECT COUNT(*)
+		FROM audit_logs
+		WHERE competition_id = ?
+	`
+	var totalCount int32
+	err := r.db.QueryRowContext(ctx, countQuery, competitionID).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+
+	query := `
+		SELECT id, competition_id, user_id, action, details, ip, created_at
+		FROM audit_logs
+		WHERE competition_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	auditLogs := []*aggregate.AuditLog{}
+	for rows.Next() {
+		var auditLog AuditLog
+		err := rows.Scan(
+			&auditLog.ID,
+			&auditLog.CompetitionID,
+			&auditLog.UserID,
+			&auditLog.Action,
+			&auditLog.Details,
+			&auditLog.IP,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		auditLogs = append(auditLogs, mapToAuditLogAggregate(auditLog))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, totalCount, nil
+}
+
+// Helper function to map an AuditLog struct to an AuditLog aggregate
+func mapToAuditLogAggregate(auditLog AuditLog) *aggregate.AuditLog {
+	auditLogAggregate := aggregate.NewAuditLog()
+	auditLogAggregate.SetID(auditLog.ID)
+	auditLogAggregate.SetCompetitionID(auditLog.CompetitionID.Int32)
+	auditLogAggregate.SetUserID(auditLog.UserID.Int32)
+	auditLogAggregate.SetAction(auditLog.Action)
+	auditLogAggregate.SetDetails(auditLog.Details.String)
+	auditLogAggregate.SetIP(auditLog.IP)
+	auditLogAggregate.SetCreatedAt(auditLog.CreatedAt)
+	return auditLogAggregate
+}
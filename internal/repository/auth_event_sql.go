@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLAuthEventRepository is an implementation of the AuthEventRepository interface that uses SQL
+type SQLAuthEventRepository struct {
+	db *sql.DB
+}
+
+// NewSQLAuthEventRepository creates a new SQLAuthEventRepository
+func NewSQLAuthEventRepository(db *sql.DB) repo.AuthEventRepository {
+	return &SQLAuthEventRepository{
+		db: db,
+	}
+}
+
+// AuthEvent is an internal representation of an auth event for DB operations
+type AuthEvent struct {
+	ID            int32
+	UserID        sql.NullInt32
+	Email         string
+	Type          string
+	CompetitionID sql.NullInt32
+	Details       sql.NullString
+	IP            string
+	UserAgent     string
+	CreatedAt     time.Time
+}
+
+// CreateAuthEvent stores a new auth event and sets its generated ID
+func (r *SQLAuthEventRepository) CreateAuthEvent(ctx context.Context, authEvent *aggregate.AuthEvent) error {
+	query := `
+		INSERT INTO auth_events (user_id, email, type, competition_id, details, ip, user_agent, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	createdAt := authEvent.GetCreatedAt()
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+		authEvent.SetCreatedAt(createdAt)
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		sql.NullInt32{Int32: authEvent.GetUserID(), Valid: authEvent.GetUserID() != 0},
+		authEvent.GetEmail(),
+		authEvent.GetType(),
+		sql.NullInt32{Int32: authEvent.GetCompetitionID(), Valid: authEvent.GetCompetitionID() != 0},
+		authEvent.GetDetails(),
+		authEvent.GetIP(),
+		authEvent.GetUserAgent(),
+		createdAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	authEvent.SetID(int32(id))
+
+	return nil
+}
+
+// ListUserAuthEvents lists the auth events of a user, most recent first
+func (r *SQLAuthEventRepository) ListUserAuthEvents(ctx context.Context, userID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error) {
+	return r.listAuthEvents(ctx, "WHERE user_id = ?", []interface{}{userID}, pageNumber, pageSize)
+}
+
+// ListCompetitionAuthEvents lists the auth events concerning a competition and the events of
+// the users having a role on it, most recent first
+func (r *SQLAuthEventRepository) ListCompetitionAuthEvents(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error) {
+	where := "WHERE competition_id = ? OR user_id IN (SELECT user_id FROM user_roles WHERE competition_id = ?)"
+	return r.listAuthEvents(ctx, where, []interface{}{competitionID, competitionID}, pageNumber, pageSize)
+}
+
+// listAuthEvents lists a page of the auth events matching the where clause
+func (r *SQLAuthEventRepository) listAuthEvents(ctx context.Context, where string, args []interface{}, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error) {
+	if pageSize <= 0 {
+		pageSize = 10 // Default page size
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1 // Default page number
+	}
+
+	// Get total count first
+	var totalCount int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM auth_events "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+
+	query := `
+		SELECT id, user_id, email, type, competition_id, details, ip, user_agent, created_at
+		FROM auth_events
+	` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, pageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	authEvents := []*aggregate.AuthEvent{}
+	for rows.Next() {
+		var authEvent AuthEvent
+		err := rows.Scan(
+			&authEvent.ID,
+			&authEvent.UserID,
+			&authEvent.Email,
+			&authEvent.Type,
+			&authEvent.CompetitionID,
+			&authEvent.Details,
+			&authEvent.IP,
+			&authEvent.UserAgent,
+			&authEvent.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		authEvents = append(authEvents, mapToAuthEventAggregate(authEvent))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return authEvents, totalCount, nil
+}
+
+// Helper function to map an AuthEvent struct to an AuthEvent aggregate
+func mapToAuthEventAggregate(authEvent AuthEvent) *aggregate.AuthEvent {
+	authEventAggregate := aggregate.NewAuthEvent()
+	authEventAggregate.SetID(authEvent.ID)
+	authEventAggregate.SetUserID(authEvent.UserID.Int32)
+	authEventAggregate.SetEmail(authEvent.Email)
+	authEventAggregate.SetType(authEvent.Type)
+	authEventAggregate.SetCompetitionID(authEvent.CompetitionID.Int32)
+	authEventAggregate.SetDetails(authEvent.Details.String)
+	authEventAggregate.SetIP(authEvent.IP)
+	authEventAggregate.SetUserAgent(authEvent.UserAgent)
+	authEventAggregate.SetCreatedAt(authEvent.CreatedAt)
+	return authEventAggregate
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrCategoryNotFound is returned when a category of a competition cannot be found
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrDuplicateCategory is returned when the competition already has a category with this name
+	ErrDuplicateCategory = errors.New("category with this name already exists for the competition")
+)
+
+// SQLCategoryRepository is an implementation of the CategoryRepository interface that uses SQL
+type SQLCategoryRepository struct {
+	db *sql.DB
+}
+
+// NewSQLCategoryRepository creates a new SQLCategoryRepository
+func NewSQLCategoryRepository(db *sql.DB) repo.CategoryRepository {
+	return &SQLCategoryRepository{
+		db: db,
+	}
+}
+
+// Category is an internal representation of a category for DB operations
+type Category struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	MinAge        int32
+	MaxAge        int32
+	DisplayOrder  int32
+}
+
+// CreateCategory creates a new category and sets its generated ID
+func (r *SQLCategoryRepository) CreateCategory(ctx context.Context, category *aggregate.Category) error {
+	query := `
+		INSERT INTO categories (competition_id, name, min_age, max_age, display_order)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		category.GetCompetitionID(),
+		category.GetName(),
+		category.GetMinAge(),
+		category.GetMaxAge(),
+		category.GetDisplayOrder(),
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateCategory
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	category.SetID(int32(id))
+
+	return nil
+}
+
+// GetCategory retrieves a category of a competition
+func (r *SQLCategoryRepository) GetCategory(ctx context.Context, competitionID, id int32) (*aggregate.Category, error) {
+	query := `
+		SELECT id, competition_id, name, min_age, max_age, display_order
+		FROM categories
+		WHERE competition_id = ? AND id = ?
+	`
+
+	var category Category
+	err := r.db.QueryRowContext(ctx, query, competitionID, id).Scan(
+		&category.ID,
+		&category.CompetitionID,
+		&category.Name,
+		&category.MinAge,
+		&category.MaxAge,
+		&category.DisplayOrder,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	return toCategoryAggregate(category), nil
+}
+
+// UpdateCategory updates the name, ages and display order of a category
+func (r *SQLCategoryRepository) UpdateCategory(ctx context.Context, category *aggregate.Category) error {
+	query := `
+		UPDATE categories
+		SET name = ?, min_age = ?, max_age = ?, display_order = ?
+		WHERE competition_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		category.GetName(),
+		category.GetMinAge(),
+		category.GetMaxAge(),
+		category.GetDisplayOrder(),
+		category.GetCompetitionID(),
+		category.GetID(),
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateCategory
+		}
+		return err
+	}
+
+	// Updating a category with its current values affects no rows, its existence is checked separately
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		_, err := r.GetCategory(ctx, category.GetCompetitionID(), category.GetID())
+		return err
+	}
+
+	return nil
+}
+
+// ListCategories lists the categories of a competition by display order then name
+func (r *SQLCategoryRepository) ListCategories(ctx context.Context, competitionID int32) ([]*aggregate.Category, error) {
+	query := `
+		SELECT id, competition_id, name, min_age, max_age, display_order
+		FROM categories
+		WHERE competition_id = ?
+		ORDER BY display_order, name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*aggregate.Category{}
+	for rows.Next() {
+		var category Category
+		err := rows.Scan(
+			&category.ID,
+			&category.CompetitionID,
+			&category.Name,
+			&category.MinAge,
+			&category.MaxAge,
+			&category.DisplayOrder,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, toCategoryAggregate(category))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// DeleteCategory deletes a category of a competition
+func (r *SQLCategoryRepository) DeleteCategory(ctx context.Context, competitionID, id int32) error {
+	query := `
+		DELETE FROM categories
+		WHERE competition_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// toCategoryAggregate converts the internal representation to an aggregate
+func toCategoryAggregate(category Category) *aggregate.Category {
+	categoryAggregate := aggregate.NewCategory()
+	categoryAggregate.SetID(category.ID)
+	categoryAggregate.SetCompetitionID(category.CompetitionID)
+	categoryAggregate.SetName(category.Name)
+	categoryAggregate.SetMinAge(category.MinAge)
+	categoryAggregate.SetMaxAge(category.MaxAge)
+	categoryAggregate.SetDisplayOrder(category.DisplayOrder)
+	return categoryAggregate
+}
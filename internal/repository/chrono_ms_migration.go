@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateChronoMs converts to milliseconds the chronos in seconds of the runs, liverankings, run revisions and
+// pending runs recorded before the milliseconds existed. Only the rows without chrono in milliseconds are changed, so that
+// running it again changes nothing. The chronos in seconds are kept up to date for the clients still reading them.
+func MigrateChronoMs(db *sql.DB) error {
+	if _, err := db.Exec(BackfillRunsChronoMsQuery); err != nil {
+		return fmt.Errorf("failed to convert the chronos of the runs: %w", err)
+	}
+	if _, err := db.Exec(BackfillLiverankingsChronoMsQuery); err != nil {
+		return fmt.Errorf("failed to convert the chronos of the liverankings: %w", err)
+	}
+	if _, err := db.Exec(BackfillRunRevisionsChronoMsQuery); err != nil {
+		return fmt.Errorf("failed to convert the chronos of the run revisions: %w", err)
+	}
+	if _, err := db.Exec(BackfillPendingRunsChronoMsQuery); err != nil {
+		return fmt.Errorf("failed to convert the chronos of the pending runs: %w", err)
+	}
+
+	// The pending runs are unique to the millisecond once converted
+	if err := addIndex(db, AddPendingRunsChronoMsIndexQuery); err != nil {
+		return fmt.Errorf("failed to add the chrono index of the pending runs: %w", err)
+	}
+	if err := dropIndex(db, DropPendingRunsChronoSecIndexQuery); err != nil {
+		return fmt.Errorf("failed to drop the chrono in seconds index of the pending runs: %w", err)
+	}
+
+	return nil
+}
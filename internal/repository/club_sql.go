@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrClubNotFound is returned when a club of a competition cannot be found
+	ErrClubNotFound = errors.New("club not found")
+	// ErrDuplicateClub is returned when the competition already has a club with this name
+	ErrDuplicateClub = errors.New("club with this name already exists for the competition")
+)
+
+// SQLClubRepository is an implementation of the ClubRepository interface that uses SQL
+type SQLClubRepository struct {
+	db *sql.DB
+}
+
+// NewSQLClubRepository creates a new SQLClubRepository
+func NewSQLClubRepository(db *sql.DB) repo.ClubRepository {
+	return &SQLClubRepository{
+		db: db,
+	}
+}
+
+// Club is an internal representation of a club for DB operations
+type Club struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+}
+
+// CreateClub creates a new club and sets its generated ID
+func (r *SQLClubRepository) CreateClub(ctx context.Context, club *aggregate.Club) error {
+	query := `
+		INSERT INTO clubs (competition_id, name)
+		VALUES (?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, club.GetCompetitionID(), club.GetName())
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateClub
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	club.SetID(int32(id))
+
+	return nil
+}
+
+// GetClub retrieves a club of a competition
+func (r *SQLClubRepository) GetClub(ctx context.Context, competitionID, id int32) (*aggregate.Club, error) {
+	query := `
+		SELECT id, competition_id, name
+		FROM clubs
+		WHERE competition_id = ? AND id = ?
+	`
+
+	var club Club
+	err := r.db.QueryRowContext(ctx, query, competitionID, id).Scan(&club.ID, &club.CompetitionID, &club.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrClubNotFound
+		}
+		return nil, err
+	}
+
+	return toClubAggregate(club), nil
+}
+
+// UpdateClub renames a club and its participants in one transaction
+func (r *SQLClubRepository) UpdateClub(ctx context.Context, club *aggregate.Club) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE clubs
+		SET name = ?
+		WHERE competition_id = ? AND id = ?
+	`, club.GetName(), club.GetCompetitionID(), club.GetID())
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateClub
+		}
+		return err
+	}
+
+	// Renaming a club with its current name affects no rows, its existence is checked separately
+	var exists bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM clubs WHERE competition_id = ? AND id = ?)
+	`, club.GetCompetitionID(), club.GetID()).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrClubNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE participants
+		SET club = ?
+		WHERE competition_id = ? AND club_id = ?
+	`, club.GetName(), club.GetCompetitionID(), club.GetID())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListClubs lists the clubs of a competition by name
+func (r *SQLClubRepository) ListClubs(ctx context.Context, competitionID int32) ([]*aggregate.Club, error) {
+	query := `
+		SELECT id, competition_id, name
+		FROM clubs
+		WHERE competition_id = ?
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clubs := []*aggregate.Club{}
+	for rows.Next() {
+		var club Club
+		if err := rows.Scan(&club.ID, &club.CompetitionID, &club.Name); err != nil {
+			return nil, err
+		}
+		clubs = append(clubs, toClubAggregate(club))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return clubs, nil
+}
+
+// CountClubMembers counts the participants of a club, waitlisted ones included
+func (r *SQLClubRepository) CountClubMembers(ctx context.Context, competitionID, id int32) (int32, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM participants
+		WHERE competition_id = ? AND club_id = ?
+	`
+
+	var count int32
+	err := r.db.QueryRowContext(ctx, query, competitionID, id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// DeleteClub deletes a club of a competition
+func (r *SQLClubRepository) DeleteClub(ctx context.Context, competitionID, id int32) error {
+	query := `
+		DELETE FROM clubs
+		WHERE competition_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrClubNotFound
+	}
+
+	return nil
+}
+
+// ListClubRankings sums the points of the members of each club of a competition, the most points first.
+// The waitlisted participants are not members and the clubs without member are left out.
+func (r *SQLClubRepository) ListClubRankings(ctx context.Context, competitionID int32) ([]*aggregate.ClubRanking, error) {
+	query := `
+		SELECT c.id, c.competition_id, c.name,
+			COUNT(p.dossard_number),
+			COUNT(CASE WHEN l.number_of_runs > 0 THEN 1 END),
+			COALESCE(SUM(l.total_points), 0) AS total_points
+		FROM clubs c
+		JOIN participants p ON p.competition_id = c.competition_id AND p.club_id = c.id AND p.waitlisted = false
+		LEFT JOIN liverankings l ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
+		WHERE c.competition_id = ?
+		GROUP BY c.id, c.competition_id, c.name
+		ORDER BY total_points DESC, c.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rankings := []*aggregate.ClubRanking{}
+	for rows.Next() {
+		var club Club
+		var members, ranked, totalPoints int32
+		if err := rows.Scan(&club.ID, &club.CompetitionID, &club.Name, &members, &ranked, &totalPoints); err != nil {
+			return nil, err
+		}
+
+		ranking := aggregate.NewClubRanking(toClubAggregate(club))
+		ranking.SetMembers(members)
+		ranking.SetRanked(ranked)
+		ranking.SetTotalPoints(totalPoints)
+		rankings = append(rankings, ranking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rankings, nil
+}
+
+// attachClub returns the ID of the club of the competition with this name, creating it when the competition does
+// not have it yet, and NULL without name
+func attachClub(ctx context.Context, tx *sql.Tx, competitionID int32, name string) (sql.NullInt32, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return sql.NullInt32{}, nil
+	}
+
+	// LAST_INSERT_ID(id) makes the ID of the existing club the inserted ID
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO clubs (competition_id, name)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)
+	`, competitionID, name)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+
+	return sql.NullInt32{Int32: int32(id), Valid: true}, nil
+}
+
+// toClubAggregate converts the internal representation to an aggregate
+func toClubAggregate(club Club) *aggregate.Club {
+	clubAggregate := aggregate.NewClub()
+	clubAggregate.SetID(club.ID)
+	clubAggregate.SetCompetitionID(club.CompetitionID)
+	clubAggregate.SetName(club.Name)
+	return clubAggregate
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateClubs creates a club for each club name of the participants and points them to it. Only the participants
+// without club are changed, so that running it again changes nothing.
+func MigrateClubs(db *sql.DB) error {
+	if _, err := db.Exec(BackfillClubsQuery); err != nil {
+		return fmt.Errorf("failed to create the clubs of the participants: %w", err)
+	}
+	if _, err := db.Exec(BackfillParticipantsClubIDQuery); err != nil {
+		return fmt.Errorf("failed to attach the participants to their club: %w", err)
+	}
+
+	return nil
+}
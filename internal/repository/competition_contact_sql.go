@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrContactNotFound is returned when a competition contact cannot be found
+	ErrContactNotFound = errors.New("contact not found")
+	// ErrDuplicateContact is returned when the contact already has this role in the competition
+	ErrDuplicateContact = errors.New("contact with this email and role already exists for the competition")
+)
+
+// SQLCompetitionContactRepository is an implementation of the CompetitionContactRepository interface that uses SQL
+type SQLCompetitionContactRepository struct {
+	db *sql.DB
+}
+
+// NewSQLCompetitionContactRepository creates a new SQLCompetitionContactRepository
+func NewSQLCompetitionContactRepository(db *sql.DB) repo.CompetitionContactRepository {
+	return &SQLCompetitionContactRepository{
+		db: db,
+	}
+}
+
+// CompetitionContact is an internal representation of a competition contact for DB operations
+type CompetitionContact struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	Email         string
+	Phone         string
+	Role          string
+}
+
+// CreateContact creates a new contact and sets its generated ID
+func (r *SQLCompetitionContactRepository) CreateContact(ctx context.Context, contact *aggregate.CompetitionContact) error {
+	query := `
+		INSERT INTO competition_contacts (competition_id, name, email, phone, role)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		contact.GetCompetitionID(),
+		contact.GetName(),
+		contact.GetEmail(),
+		contact.GetPhone(),
+		contact.GetRole(),
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateContact
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	contact.SetID(int32(id))
+
+	return nil
+}
+
+// ListContacts lists the contacts of a competition
+func (r *SQLCompetitionContactRepository) ListContacts(ctx context.Context, competitionID int32) ([]*aggregate.CompetitionContact, error) {
+	query := `
+		SELECT id, competition_id, name, email, phone, role
+		FROM competition_contacts
+		WHERE competition_id = ?
+		ORDER BY role, name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []*aggregate.CompetitionContact{}
+	for rows.Next() {
+		var contact CompetitionContact
+		if err := rows.Scan(&contact.ID, &contact.CompetitionID, &contact.Name, &contact.Email, &contact.Phone, &contact.Role); err != nil {
+			return nil, err
+		}
+
+		contactAggregate := aggregate.NewCompetitionContact()
+		contactAggregate.SetID(contact.ID)
+		contactAggregate.SetCompetitionID(contact.CompetitionID)
+		contactAggregate.SetName(contact.Name)
+		contactAggregate.SetEmail(contact.Email)
+		contactAggregate.SetPhone(contact.Phone)
+		contactAggregate.SetRole(contact.Role)
+		contacts = append(contacts, contactAggregate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// DeleteContact deletes a contact of a competition
+func (r *SQLCompetitionContactRepository) DeleteContact(ctx context.Context, competitionID, id int32) error {
+	query := `
+		DELETE FROM competition_contacts
+		WHERE competition_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrContactNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// legacyCompetitionDateLayouts are the formats the free-form competition dates were entered with.
+// The last one is the format the migration rewrites them to, so that a migration interrupted before
+// converting the column can run again.
+var legacyCompetitionDateLayouts = []string{"2006-01-02", "02/01/2006", "02-01-2006", time.RFC3339, "2006-01-02 15:04:05"}
+
+// MigrateCompetitionDates rewrites the free-form competition dates of the VARCHAR date column as UTC date
+// times, read in the time zone of the competition when they have no offset, then turns the column into
+// a DATETIME. Nothing is changed when the column already is a DATETIME or when a date cannot be read,
+// the competitions whose date must be fixed by hand being reported in the error.
+// It returns the number of competitions migrated.
+func MigrateCompetitionDates(db *sql.DB) (int, error) {
+	var dataType string
+	err := db.QueryRow(`
+		SELECT data_type
+		FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = 'competitions' AND column_name = 'date'
+	`).Scan(&dataType)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.Contains(strings.ToLower(dataType), "char") {
+		return 0, nil
+	}
+
+	rows, err := db.Query("SELECT id, date, timezone FROM competitions")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	dates := make(map[int32]time.Time)
+	var unreadable []string
+	for rows.Next() {
+		var id int32
+		var date, timezone string
+		if err := rows.Scan(&id, &date, &timezone); err != nil {
+			return 0, err
+		}
+
+		parsed, ok := parseLegacyCompetitionDate(date, timezone)
+		if !ok {
+			unreadable = append(unreadable, fmt.Sprintf("%d (%q)", id, date))
+			continue
+		}
+		dates[id] = parsed
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(unreadable) > 0 {
+		return 0, fmt.Errorf("the dates of the competitions %s cannot be read, set them as YYYY-MM-DD and start again", strings.Join(unreadable, ", "))
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	for id, date := range dates {
+		if _, err := tx.Exec("UPDATE competitions SET date = ? WHERE id = ?", date.UTC().Format("2006-01-02 15:04:05"), id); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("competition %d: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	if _, err := db.Exec(ConvertCompetitionsDateColumnQuery); err != nil {
+		return 0, fmt.Errorf("failed to convert the date column: %w", err)
+	}
+
+	return len(dates), nil
+}
+
+// parseLegacyCompetitionDate parses a free-form competition date of the previous versions, the rewritten
+// dates being UTC already
+func parseLegacyCompetitionDate(date, timezone string) (time.Time, bool) {
+	date = strings.TrimSpace(date)
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location, _ = time.LoadLocation(aggregate.DefaultCompetitionTimezone)
+	}
+
+	for i, layout := range legacyCompetitionDateLayouts {
+		if i == len(legacyCompetitionDateLayouts)-1 {
+			location = time.UTC
+		}
+		if parsed, err := time.ParseInLocation(layout, date, location); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
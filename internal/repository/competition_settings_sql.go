@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLCompetitionSettingsRepository is an implementation of the CompetitionSettingsRepository interface that uses SQL
+type SQLCompetitionSettingsRepository struct {
+	db *sql.DB
+}
+
+// NewSQLCompetitionSettingsRepository creates a new SQLCompetitionSettingsRepository
+func NewSQLCompetitionSettingsRepository(db *sql.DB) repo.CompetitionSettingsRepository {
+	return &SQLCompetitionSettingsRepository{
+		db: db,
+	}
+}
+
+// CompetitionSettings is an internal representation of the settings of a competition for DB operations
+type CompetitionSettings struct {
+	CompetitionID    int32
+	RunsPerZone      int32
+	ZonesPerCategory int32
+	Scoring          string
+	RestrictZones    bool
+	VerifyLicences   bool
+	RunDSQRule       string
+	RequireApproval  bool
+}
+
+// SetCompetitionSettings stores the settings of a competition, replacing the previous ones
+func (r *SQLCompetitionSettingsRepository) SetCompetitionSettings(ctx context.Context, settings *aggregate.CompetitionSettings) error {
+	query := `
+		INSERT INTO competition_settings (competition_id, runs_per_zone, zones_per_category, scoring, restrict_referee_zones, verify_licences, run_dsq_rule, require_run_approval)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE runs_per_zone = VALUES(runs_per_zone), zones_per_category = VALUES(zones_per_category), scoring = VALUES(scoring),
+			restrict_referee_zones = VALUES(restrict_referee_zones), verify_licences = VALUES(verify_licences), run_dsq_rule = VALUES(run_dsq_rule),
+			require_run_approval = VALUES(require_run_approval)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		settings.GetCompetitionID(),
+		settings.GetRunsPerZone(),
+		settings.GetZonesPerCategory(),
+		settings.GetScoring(),
+		settings.RestrictsRefereeZones(),
+		settings.VerifiesLicences(),
+		settings.GetRunDSQRule(),
+		settings.RequiresRunApproval(),
+	)
+	return err
+}
+
+// GetCompetitionSettings retrieves the settings of a competition, nil when it has none
+func (r *SQLCompetitionSettingsRepository) GetCompetitionSettings(ctx context.Context, competitionID int32) (*aggregate.CompetitionSettings, error) {
+	query := `
+		SELECT competition_id, runs_per_zone, zones_per_category, scoring, restrict_referee_zones, verify_licences, run_dsq_rule, require_run_approval
+		FROM competition_settings
+		WHERE competition_id = ?
+	`
+
+	var settings CompetitionSettings
+	err := r.db.QueryRowContext(ctx, query, competitionID).Scan(
+		&settings.CompetitionID,
+		&settings.RunsPerZone,
+		&settings.ZonesPerCategory,
+		&settings.Scoring,
+		&settings.RestrictZones,
+		&settings.VerifyLicences,
+		&settings.RunDSQRule,
+		&settings.RequireApproval,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	settingsAggregate := aggregate.NewCompetitionSettings()
+	settingsAggregate.SetCompetitionID(settings.CompetitionID)
+	settingsAggregate.SetRunsPerZone(settings.RunsPerZone)
+	settingsAggregate.SetZonesPerCategory(settings.ZonesPerCategory)
+	settingsAggregate.SetScoring(settings.Scoring)
+	settingsAggregate.SetRestrictRefereeZones(settings.RestrictZones)
+	settingsAggregate.SetVerifyLicences(settings.VerifyLicences)
+	settingsAggregate.SetRunDSQRule(settings.RunDSQRule)
+	settingsAggregate.SetRequireRunApproval(settings.RequireApproval)
+
+	return settingsAggregate, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	repo "github.com/NiskuT/cross-api/internal/domain/repository"
@@ -33,16 +36,28 @@ type Competition struct {
 	ID          int32
 	Name        string
 	Description string
-	Date        string
+	Date        time.Time
+	Timezone    string
 	Location    string
 	Organizer   string
 	Contact     string
+
+	ChronoFormat    string
+	ChronoDirection string
+	Status          string
+	ArchivedAt      sql.NullTime
+	OrganizationID  sql.NullInt32
+
+	MaxParticipants      int32
+	RegistrationDeadline sql.NullTime
+	Waitlist             bool
 }
 
 // GetCompetition retrieves a competition by ID
 func (r *SQLCompetitionRepository) GetCompetition(ctx context.Context, id int32) (*aggregate.Competition, error) {
 	query := `
-		SELECT id, name, description, date, location, organizer, contact
+		SELECT id, name, description, date, timezone, location, organizer, contact, chrono_format, chrono_direction, status, archived_at, organization_id,
+			max_participants, registration_deadline, waitlist
 		FROM competitions
 		WHERE id = ?
 	`
@@ -54,9 +69,18 @@ func (r *SQLCompetitionRepository) GetCompetition(ctx context.Context, id int32)
 		&competition.Name,
 		&competition.Description,
 		&competition.Date,
+		&competition.Timezone,
 		&competition.Location,
 		&competition.Organizer,
 		&competition.Contact,
+		&competition.ChronoFormat,
+		&competition.ChronoDirection,
+		&competition.Status,
+		&competition.ArchivedAt,
+		&competition.OrganizationID,
+		&competition.MaxParticipants,
+		&competition.RegistrationDeadline,
+		&competition.Waitlist,
 	)
 
 	if err != nil {
@@ -66,23 +90,15 @@ func (r *SQLCompetitionRepository) GetCompetition(ctx context.Context, id int32)
 		return nil, err
 	}
 
-	competitionAggregate := aggregate.NewCompetition()
-	competitionAggregate.SetID(competition.ID)
-	competitionAggregate.SetName(competition.Name)
-	competitionAggregate.SetDescription(competition.Description)
-	competitionAggregate.SetDate(competition.Date)
-	competitionAggregate.SetLocation(competition.Location)
-	competitionAggregate.SetOrganizer(competition.Organizer)
-	competitionAggregate.SetContact(competition.Contact)
-
-	return competitionAggregate, nil
+	return mapToCompetitionAggregate(&competition), nil
 }
 
 // CreateCompetition creates a new competition
 func (r *SQLCompetitionRepository) CreateCompetition(ctx context.Context, competition *aggregate.Competition) (int32, error) {
 	query := `
-		INSERT INTO competitions (name, description, date, location, organizer, contact)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO competitions (name, description, date, timezone, location, organizer, contact, chrono_format, chrono_direction, status, organization_id,
+			max_participants, registration_deadline, waitlist)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(
@@ -90,10 +106,18 @@ func (r *SQLCompetitionRepository) CreateCompetition(ctx context.Context, compet
 		query,
 		competition.GetName(),
 		competition.GetDescription(),
-		competition.GetDate(),
+		competition.GetDate().UTC(),
+		competition.GetTimezone(),
 		competition.GetLocation(),
 		competition.GetOrganizer(),
 		competition.GetContact(),
+		competition.GetChronoFormat(),
+		competition.GetChronoDirection(),
+		competition.GetStatus(),
+		sql.NullInt32{Int32: competition.GetOrganizationID(), Valid: competition.GetOrganizationID() != 0},
+		competition.GetMaxParticipants(),
+		registrationDeadlineValue(competition),
+		competition.HasWaitlist(),
 	)
 
 	if err != nil {
@@ -116,19 +140,33 @@ func (r *SQLCompetitionRepository) CreateCompetition(ctx context.Context, compet
 func (r *SQLCompetitionRepository) UpdateCompetition(ctx context.Context, competition *aggregate.Competition) error {
 	query := `
 		UPDATE competitions
-		SET name = ?, description = ?, date = ?, location = ?, organizer = ?, contact = ?
+		SET name = ?, description = ?, date = ?, timezone = ?, location = ?, organizer = ?, contact = ?, chrono_format = ?, chrono_direction = ?, status = ?, archived_at = ?,
+			max_participants = ?, registration_deadline = ?, waitlist = ?
 		WHERE id = ?
 	`
 
+	var archivedAt sql.NullTime
+	if competition.IsArchived() {
+		archivedAt = sql.NullTime{Time: competition.GetArchivedAt(), Valid: true}
+	}
+
 	result, err := r.db.ExecContext(
 		ctx,
 		query,
 		competition.GetName(),
 		competition.GetDescription(),
-		competition.GetDate(),
+		competition.GetDate().UTC(),
+		competition.GetTimezone(),
 		competition.GetLocation(),
 		competition.GetOrganizer(),
 		competition.GetContact(),
+		competition.GetChronoFormat(),
+		competition.GetChronoDirection(),
+		competition.GetStatus(),
+		archivedAt,
+		competition.GetMaxParticipants(),
+		registrationDeadlineValue(competition),
+		competition.HasWaitlist(),
 		competition.GetID(),
 	)
 
@@ -172,11 +210,12 @@ func (r *SQLCompetitionRepository) DeleteCompetition(ctx context.Context, id int
 	return nil
 }
 
-// ListCompetitions lists all competitions
+// ListCompetitions lists all competitions, archived ones included
 func (r *SQLCompetitionRepository) ListCompetitions(ctx context.Context) ([]*aggregate.Competition, error) {
 
 	query := `
-		SELECT id, name, description, date, location, organizer, contact
+		SELECT id, name, description, date, timezone, location, organizer, contact, chrono_format, chrono_direction, status, archived_at, organization_id,
+			max_participants, registration_deadline, waitlist
 		FROM competitions
 		ORDER BY date DESC
 	`
@@ -192,20 +231,144 @@ func (r *SQLCompetitionRepository) ListCompetitions(ctx context.Context) ([]*agg
 
 	for rows.Next() {
 		var competition Competition
-		if err := rows.Scan(&competition.ID, &competition.Name, &competition.Description, &competition.Date, &competition.Location, &competition.Organizer, &competition.Contact); err != nil {
+		if err := rows.Scan(&competition.ID, &competition.Name, &competition.Description, &competition.Date, &competition.Timezone, &competition.Location, &competition.Organizer, &competition.Contact, &competition.ChronoFormat, &competition.ChronoDirection, &competition.Status, &competition.ArchivedAt, &competition.OrganizationID, &competition.MaxParticipants, &competition.RegistrationDeadline, &competition.Waitlist); err != nil {
 			return nil, err
 		}
 
-		competitionAggregate := aggregate.NewCompetition()
-		competitionAggregate.SetID(competition.ID)
-		competitionAggregate.SetName(competition.Name)
-		competitionAggregate.SetDescription(competition.Description)
-		competitionAggregate.SetDate(competition.Date)
-		competitionAggregate.SetLocation(competition.Location)
-		competitionAggregate.SetOrganizer(competition.Organizer)
-		competitionAggregate.SetContact(competition.Contact)
-		competitions = append(competitions, competitionAggregate)
+		competitions = append(competitions, mapToCompetitionAggregate(&competition))
 	}
 
 	return competitions, nil
 }
+
+// competitionSortColumns maps the orders of the competitions to their columns
+var competitionSortColumns = map[string]string{
+	aggregate.CompetitionSortDate:     "date",
+	aggregate.CompetitionSortName:     "name",
+	aggregate.CompetitionSortLocation: "location",
+	aggregate.CompetitionSortCreated:  "id",
+}
+
+// SearchCompetitions lists a page of the competitions matching the filter and counts all of them
+func (r *SQLCompetitionRepository) SearchCompetitions(ctx context.Context, filter *aggregate.CompetitionFilter, pageNumber, pageSize int32) ([]*aggregate.Competition, int32, error) {
+	if pageSize <= 0 {
+		pageSize = 10 // Default page size
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1 // Default page number
+	}
+
+	where := " WHERE archived_at IS NULL"
+	if filter.GetArchived() {
+		where = " WHERE archived_at IS NOT NULL"
+	}
+	args := []interface{}{}
+	if competitionIDs := filter.GetCompetitionIDs(); competitionIDs != nil {
+		if len(competitionIDs) == 0 {
+			return []*aggregate.Competition{}, 0, nil
+		}
+		where += " AND id IN (?" + strings.Repeat(", ?", len(competitionIDs)-1) + ")"
+		for _, competitionID := range competitionIDs {
+			args = append(args, competitionID)
+		}
+	}
+	if filter.GetSearch() != "" {
+		// Escape LIKE wildcards so they match literally
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.GetSearch()) + "%"
+		where += " AND (name LIKE ? OR location LIKE ?)"
+		args = append(args, pattern, pattern)
+	}
+	dateFrom, dateTo, ok := filter.GetDateRange()
+	if !ok {
+		return nil, 0, errors.New("invalid competition date range")
+	}
+	if !dateFrom.IsZero() {
+		where += " AND date >= ?"
+		args = append(args, dateFrom.UTC())
+	}
+	if !dateTo.IsZero() {
+		where += " AND date <= ?"
+		args = append(args, dateTo.UTC())
+	}
+
+	var totalCount int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM competitions"+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := competitionSortColumns[filter.GetSort()]
+	if !ok {
+		column = competitionSortColumns[aggregate.CompetitionSortDate]
+	}
+	direction := "ASC"
+	if filter.GetDescending() {
+		direction = "DESC"
+	}
+	orderBy := fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+
+	offset := (pageNumber - 1) * pageSize
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT id, name, description, date, timezone, location, organizer, contact, chrono_format, chrono_direction, status, archived_at, organization_id, max_participants, registration_deadline, waitlist FROM competitions"+where+orderBy+" LIMIT ? OFFSET ?",
+		append(args, pageSize, offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	competitions := []*aggregate.Competition{}
+	for rows.Next() {
+		var competition Competition
+		if err := rows.Scan(&competition.ID, &competition.Name, &competition.Description, &competition.Date, &competition.Timezone, &competition.Location, &competition.Organizer, &competition.Contact, &competition.ChronoFormat, &competition.ChronoDirection, &competition.Status, &competition.ArchivedAt, &competition.OrganizationID, &competition.MaxParticipants, &competition.RegistrationDeadline, &competition.Waitlist); err != nil {
+			return nil, 0, err
+		}
+		competitions = append(competitions, mapToCompetitionAggregate(&competition))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return competitions, totalCount, nil
+}
+
+// Helper function to map a Competition struct to a Competition aggregate
+func mapToCompetitionAggregate(competition *Competition) *aggregate.Competition {
+	competitionAggregate := aggregate.NewCompetition()
+	competitionAggregate.SetID(competition.ID)
+	competitionAggregate.SetName(competition.Name)
+	competitionAggregate.SetDescription(competition.Description)
+	competitionAggregate.SetDate(competition.Date)
+	competitionAggregate.SetTimezone(competition.Timezone)
+	competitionAggregate.SetLocation(competition.Location)
+	competitionAggregate.SetOrganizer(competition.Organizer)
+	competitionAggregate.SetContact(competition.Contact)
+	competitionAggregate.SetChronoFormat(competition.ChronoFormat)
+	competitionAggregate.SetChronoDirection(competition.ChronoDirection)
+	competitionAggregate.SetStatus(competition.Status)
+	if competition.ArchivedAt.Valid {
+		competitionAggregate.SetArchivedAt(competition.ArchivedAt.Time)
+	}
+	if competition.OrganizationID.Valid {
+		competitionAggregate.SetOrganizationID(competition.OrganizationID.Int32)
+	}
+	competitionAggregate.SetMaxParticipants(competition.MaxParticipants)
+	if competition.RegistrationDeadline.Valid {
+		competitionAggregate.SetRegistrationDeadline(competition.RegistrationDeadline.Time)
+	}
+	competitionAggregate.SetWaitlist(competition.Waitlist)
+
+	return competitionAggregate
+}
+
+// registrationDeadlineValue returns the registration deadline of the competition as stored, in UTC and NULL when none
+func registrationDeadlineValue(competition *aggregate.Competition) sql.NullTime {
+	if competition.GetRegistrationDeadline().IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: competition.GetRegistrationDeadline().UTC(), Valid: true}
+}
@@ -1,17 +1,35 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/config"
-	_ "github.com/go-sql-driver/mysql" // MySQL driver
+	"github.com/go-sql-driver/mysql"
 )
 
 // NewDatabaseConnection creates a new database connection
 func NewDatabaseConnection(cfg *config.Config) (*sql.DB, error) {
+	// Timestamps are scanned into time.Time, which requires parseTime in the DSN
+	dsn, err := mysql.ParseDSN(cfg.Database.Uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URI: %w", err)
+	}
+	dsn.ParseTime = true
+
+	// The timestamps are read and written in UTC whatever the time zone of the MySQL server, so that the times
+	// since the creation of the runs are right
+	dsn.Loc = time.UTC
+	if dsn.Params == nil {
+		dsn.Params = map[string]string{}
+	}
+	dsn.Params["time_zone"] = "'+00:00'"
+
 	// Connect to the database using the configuration
-	db, err := sql.Open("mysql", cfg.Database.Uri)
+	db, err := sql.Open("mysql", dsn.FormatDSN())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -28,14 +46,30 @@ func NewDatabaseConnection(cfg *config.Config) (*sql.DB, error) {
 	return db, nil
 }
 
-// InitializeDatabase sets up the database schema
+// InitializeDatabase sets up the database schema and records its version.
+// It returns ErrSchemaTooNew, without modifying the database, when a newer version of the service migrated it.
 func InitializeDatabase(db *sql.DB) error {
+	// A previous version must not write to a schema it does not know
+	databaseVersion, _, err := getSchemaVersion(context.Background(), db)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if databaseVersion > SchemaVersion {
+		return fmt.Errorf("%w: database at version %d, service at version %d", ErrSchemaTooNew, databaseVersion, SchemaVersion)
+	}
+
 	// Create users table
-	_, err := db.Exec(CreateUsersTableQuery)
+	_, err = db.Exec(CreateUsersTableQuery)
 	if err != nil {
 		return fmt.Errorf("failed to create users table: %w", err)
 	}
 
+	// Create organizations table
+	_, err = db.Exec(CreateOrganizationsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create organizations table: %w", err)
+	}
+
 	// Create competitions table
 	_, err = db.Exec(CreateCompetitionsTableQuery)
 	if err != nil {
@@ -66,5 +100,491 @@ func InitializeDatabase(db *sql.DB) error {
 		return fmt.Errorf("failed to create liverankings table: %w", err)
 	}
 
+	// Create audit_logs table
+	_, err = db.Exec(CreateAuditLogsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create audit_logs table: %w", err)
+	}
+
+	// Create sessions table
+	_, err = db.Exec(CreateSessionsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create sessions table: %w", err)
+	}
+
+	// Create competition_contacts table
+	_, err = db.Exec(CreateCompetitionContactsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create competition_contacts table: %w", err)
+	}
+
+	// Create api_keys table
+	_, err = db.Exec(CreateAPIKeysTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create api_keys table: %w", err)
+	}
+
+	// Create invitations table
+	_, err = db.Exec(CreateInvitationsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create invitations table: %w", err)
+	}
+
+	// Create user_roles table
+	_, err = db.Exec(CreateUserRolesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create user_roles table: %w", err)
+	}
+
+	// Create auth_events table
+	_, err = db.Exec(CreateAuthEventsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create auth_events table: %w", err)
+	}
+
+	// Create user_identities table
+	_, err = db.Exec(CreateUserIdentitiesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create user_identities table: %w", err)
+	}
+
+	// Create referee_pins table
+	_, err = db.Exec(CreateRefereePinsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create referee_pins table: %w", err)
+	}
+
+	// Create referee_onboardings table
+	_, err = db.Exec(CreateRefereeOnboardingsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create referee_onboardings table: %w", err)
+	}
+
+	// Create zone_bounds table
+	_, err = db.Exec(CreateZoneBoundsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create zone_bounds table: %w", err)
+	}
+
+	// Create zones table, with the zones the scales already reference
+	_, err = db.Exec(CreateZonesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create zones table: %w", err)
+	}
+	_, err = db.Exec(FillZonesFromScalesQuery)
+	if err != nil {
+		return fmt.Errorf("failed to fill zones table: %w", err)
+	}
+
+	// Create referee_assignments table, after the zones it references
+	_, err = db.Exec(CreateRefereeAssignmentsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create referee_assignments table: %w", err)
+	}
+
+	// Create export_templates table
+	_, err = db.Exec(CreateExportTemplatesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create export_templates table: %w", err)
+	}
+
+	// Create competition_settings table
+	_, err = db.Exec(CreateCompetitionSettingsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create competition_settings table: %w", err)
+	}
+
+	// Create categories table
+	_, err = db.Exec(CreateCategoriesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create categories table: %w", err)
+	}
+
+	// Create clubs table
+	_, err = db.Exec(CreateClubsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create clubs table: %w", err)
+	}
+
+	// Create teams table
+	_, err = db.Exec(CreateTeamsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create teams table: %w", err)
+	}
+
+	// Create team_members table
+	_, err = db.Exec(CreateTeamMembersTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create team_members table: %w", err)
+	}
+
+	// Create display_devices table
+	_, err = db.Exec(CreateDisplayDevicesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create display_devices table: %w", err)
+	}
+
+	// Create pending_runs table
+	_, err = db.Exec(CreatePendingRunsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create pending_runs table: %w", err)
+	}
+
+	// Create run_revisions table
+	_, err = db.Exec(CreateRunRevisionsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create run_revisions table: %w", err)
+	}
+
+	// Create series table
+	_, err = db.Exec(CreateSeriesTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create series table: %w", err)
+	}
+
+	// Create series_competitions table
+	_, err = db.Exec(CreateSeriesCompetitionsTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create series_competitions table: %w", err)
+	}
+
+	// Create user_roles_backup table
+	_, err = db.Exec(CreateUserRolesBackupTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create user_roles_backup table: %w", err)
+	}
+
+	// Move the roles still stored in the legacy users.roles column
+	_, err = MigrateUserRoles(db)
+	if err != nil {
+		return fmt.Errorf("failed to migrate users roles: %w", err)
+	}
+
+	// Add columns introduced after the first release to existing tables
+	err = addColumn(db, AddRunsCreatedAtColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add created_at column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsVoidedAtColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add voided_at column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsUpdatedAtColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add updated_at column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsStatusColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add status column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsReceiptCodeColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add receipt_code column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsIdempotencyKeyColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add idempotency_key column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsPendingApprovalColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add pending_approval column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsCommentColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add comment column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsChronoMsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add chrono_ms column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddLiverankingsChronoMsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add chrono_ms column to liverankings table: %w", err)
+	}
+
+	err = addColumn(db, AddRunRevisionsOldChronoMsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add old_chrono_ms column to run_revisions table: %w", err)
+	}
+
+	err = addColumn(db, AddRunRevisionsNewChronoMsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add new_chrono_ms column to run_revisions table: %w", err)
+	}
+
+	err = addColumn(db, AddPendingRunsChronoMsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add chrono_ms column to pending_runs table: %w", err)
+	}
+
+	err = addColumn(db, AddRunsDoorsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add doors column to runs table: %w", err)
+	}
+
+	err = addColumn(db, AddScalesDoorPointsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add door_points column to scales table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsChronoFormatColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add chrono_format column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsChronoDirectionColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add chrono_direction column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsGenderColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add gender column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsClubColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add club column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsConsentDataProcessingColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add consent_data_processing column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsConsentPhotoRightsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add consent_photo_rights column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsClubEmailColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add club_email column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsLicenceColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add licence column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsWaitlistedColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add waitlisted column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsWaitlistedAtColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add waitlisted_at column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsClubIDColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add club_id column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsCheckedInColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add checked_in column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsBirthDateColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add birth_date column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsNotesColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add notes column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsFlagsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add flags column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsStatusColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add status column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsStatusReasonColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add status_reason column to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddParticipantsPhotoKeyColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add photo_key column to participants table: %w", err)
+	}
+
+	err = addIndex(db, AddParticipantsNameIndexesQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add name indexes to participants table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsArchivedAtColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add archived_at column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsOrganizationIDColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add organization_id column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsMaxParticipantsColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add max_participants column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsRegistrationDeadlineColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add registration_deadline column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsWaitlistColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add waitlist column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsStatusColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add status column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddInvitationsMaxAcceptancesColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add max_acceptances column to invitations table: %w", err)
+	}
+
+	err = addColumn(db, AddInvitationsZoneColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add zone column to invitations table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionsTimezoneColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add timezone column to competitions table: %w", err)
+	}
+
+	err = addColumn(db, AddZonesIsOpenColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add is_open column to zones table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionSettingsRestrictRefereeZonesColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add restrict_referee_zones column to competition_settings table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionSettingsVerifyLicencesColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add verify_licences column to competition_settings table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionSettingsRunDSQRuleColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add run_dsq_rule column to competition_settings table: %w", err)
+	}
+
+	err = addColumn(db, AddCompetitionSettingsRequireRunApprovalColumnQuery)
+	if err != nil {
+		return fmt.Errorf("failed to add require_run_approval column to competition_settings table: %w", err)
+	}
+
+	// Create or replace rankings view, once the participants columns it reads were added
+	_, err = db.Exec(CreateRankingsViewQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create rankings view: %w", err)
+	}
+
+	// Turn the free-form competition dates of the previous versions into date times
+	_, err = MigrateCompetitionDates(db)
+	if err != nil {
+		return fmt.Errorf("failed to migrate competition dates: %w", err)
+	}
+
+	// Move the six door columns of the previous versions to the door lists
+	err = MigrateDoorColumns(db)
+	if err != nil {
+		return fmt.Errorf("failed to migrate door columns: %w", err)
+	}
+
+	// Convert the chronos in seconds of the previous versions to milliseconds
+	err = MigrateChronoMs(db)
+	if err != nil {
+		return fmt.Errorf("failed to migrate chronos to milliseconds: %w", err)
+	}
+
+	// Attach the participants to the clubs they name
+	err = MigrateClubs(db)
+	if err != nil {
+		return fmt.Errorf("failed to migrate clubs: %w", err)
+	}
+
+	// Record the version once every migration succeeded
+	_, err = db.Exec(CreateSchemaVersionTableQuery)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+	_, err = db.Exec(RecordSchemaVersionQuery, SchemaVersion)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
 	return nil
 }
+
+// addColumn runs an ALTER TABLE ... ADD COLUMN query, ignoring the error raised when the column already exists
+func addColumn(db *sql.DB, query string) error {
+	_, err := db.Exec(query)
+	if isDuplicateColumnError(err) {
+		return nil
+	}
+	return err
+}
+
+// addIndex runs a query adding indexes, ignoring the error raised when they already exist
+func addIndex(db *sql.DB, query string) error {
+	_, err := db.Exec(query)
+	if isDuplicateKeyNameError(err) {
+		return nil
+	}
+	return err
+}
+
+// dropIndex runs a query dropping an index, ignoring the error raised when it does not exist
+func dropIndex(db *sql.DB, query string) error {
+	_, err := db.Exec(query)
+	if isMissingKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// isMissingKeyError checks if an error is raised by an index or column that does not exist
+func isMissingKeyError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1091
+}
+
+// isDuplicateKeyNameError checks if an error is raised by an index that already exists
+func isDuplicateKeyNameError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1061
+}
+
+// Helper function to check if an error is a duplicate column error
+func isDuplicateColumnError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1060
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrDisplayDeviceNotFound is returned when a display device cannot be found
+	ErrDisplayDeviceNotFound = errors.New("display device not found")
+)
+
+// SQLDisplayDeviceRepository is an implementation of the DisplayDeviceRepository interface that uses SQL
+type SQLDisplayDeviceRepository struct {
+	db *sql.DB
+}
+
+// NewSQLDisplayDeviceRepository creates a new SQLDisplayDeviceRepository
+func NewSQLDisplayDeviceRepository(db *sql.DB) repo.DisplayDeviceRepository {
+	return &SQLDisplayDeviceRepository{
+		db: db,
+	}
+}
+
+// DisplayDevice is an internal representation of a display device for DB operations
+type DisplayDevice struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+	CreatedBy     int32
+	CreatedAt     time.Time
+	LastSeenAt    sql.NullTime
+	ExpiresAt     time.Time
+	RevokedAt     sql.NullTime
+}
+
+// CreateDisplayDevice registers a display device and sets its ID
+func (r *SQLDisplayDeviceRepository) CreateDisplayDevice(ctx context.Context, device *aggregate.DisplayDevice) error {
+	query := `
+		INSERT INTO display_devices (competition_id, name, created_by, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		device.GetCompetitionID(),
+		device.GetName(),
+		device.GetCreatedBy(),
+		device.GetCreatedAt(),
+		device.GetExpiresAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	device.SetID(int32(id))
+
+	return nil
+}
+
+// GetDisplayDevice retrieves a display device by its ID
+func (r *SQLDisplayDeviceRepository) GetDisplayDevice(ctx context.Context, id int32) (*aggregate.DisplayDevice, error) {
+	query := `
+		SELECT id, competition_id, name, created_by, created_at, last_seen_at, expires_at, revoked_at
+		FROM display_devices
+		WHERE id = ?
+	`
+
+	var device DisplayDevice
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&device.ID,
+		&device.CompetitionID,
+		&device.Name,
+		&device.CreatedBy,
+		&device.CreatedAt,
+		&device.LastSeenAt,
+		&device.ExpiresAt,
+		&device.RevokedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDisplayDeviceNotFound
+		}
+		return nil, err
+	}
+
+	return mapToDisplayDeviceAggregate(device), nil
+}
+
+// ListDisplayDevices lists the display devices of a competition, revoked and expired ones included, newest first
+func (r *SQLDisplayDeviceRepository) ListDisplayDevices(ctx context.Context, competitionID int32) ([]*aggregate.DisplayDevice, error) {
+	query := `
+		SELECT id, competition_id, name, created_by, created_at, last_seen_at, expires_at, revoked_at
+		FROM display_devices
+		WHERE competition_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []*aggregate.DisplayDevice{}
+	for rows.Next() {
+		var device DisplayDevice
+		err := rows.Scan(
+			&device.ID,
+			&device.CompetitionID,
+			&device.Name,
+			&device.CreatedBy,
+			&device.CreatedAt,
+			&device.LastSeenAt,
+			&device.ExpiresAt,
+			&device.RevokedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		devices = append(devices, mapToDisplayDeviceAggregate(device))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// TouchDisplayDevice records a refresh of the token of the device of the competition
+func (r *SQLDisplayDeviceRepository) TouchDisplayDevice(ctx context.Context, competitionID, id int32) error {
+	query := `
+		UPDATE display_devices
+		SET last_seen_at = ?
+		WHERE id = ? AND competition_id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, time.Now(), id, competitionID)
+	return err
+}
+
+// RevokeDisplayDevice revokes a display device of the competition
+func (r *SQLDisplayDeviceRepository) RevokeDisplayDevice(ctx context.Context, competitionID, id int32) error {
+	query := `
+		UPDATE display_devices
+		SET revoked_at = ?
+		WHERE id = ? AND competition_id = ? AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id, competitionID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrDisplayDeviceNotFound
+	}
+
+	return nil
+}
+
+// Helper function to map a DisplayDevice struct to a DisplayDevice aggregate
+func mapToDisplayDeviceAggregate(device DisplayDevice) *aggregate.DisplayDevice {
+	deviceAggregate := aggregate.NewDisplayDevice()
+	deviceAggregate.SetID(device.ID)
+	deviceAggregate.SetCompetitionID(device.CompetitionID)
+	deviceAggregate.SetName(device.Name)
+	deviceAggregate.SetCreatedBy(device.CreatedBy)
+	deviceAggregate.SetCreatedAt(device.CreatedAt)
+	if device.LastSeenAt.Valid {
+		deviceAggregate.SetLastSeenAt(device.LastSeenAt.Time)
+	}
+	deviceAggregate.SetExpiresAt(device.ExpiresAt)
+	if device.RevokedAt.Valid {
+		deviceAggregate.SetRevokedAt(device.RevokedAt.Time)
+	}
+	return deviceAggregate
+}
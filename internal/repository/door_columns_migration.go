@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateDoorColumns copies the points and the doors passed of the six door columns of the previous versions to
+// the door lists of the scales and runs that do not have them yet. The six columns are kept, with a default for
+// the scales, so that the instances of the previous version can still read them during an upgrade.
+// Databases created with the door lists have no such column and are left unchanged.
+func MigrateDoorColumns(db *sql.DB) error {
+	hasLegacyColumn := func(table, column string) (bool, error) {
+		var count int
+		err := db.QueryRow(`
+			SELECT COUNT(*)
+			FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?
+		`, table, column).Scan(&count)
+		return count > 0, err
+	}
+
+	legacyScales, err := hasLegacyColumn("scales", "points_door1")
+	if err != nil {
+		return err
+	}
+	if legacyScales {
+		if _, err := db.Exec(BackfillScalesDoorPointsQuery); err != nil {
+			return fmt.Errorf("failed to copy the points of the doors of the scales: %w", err)
+		}
+		if _, err := db.Exec(RelaxScalesLegacyDoorColumnsQuery); err != nil {
+			return fmt.Errorf("failed to give the door columns of the scales a default: %w", err)
+		}
+	}
+
+	legacyRuns, err := hasLegacyColumn("runs", "door1")
+	if err != nil {
+		return err
+	}
+	if legacyRuns {
+		if _, err := db.Exec(BackfillRunsDoorsQuery); err != nil {
+			return fmt.Errorf("failed to copy the doors of the runs: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLExportTemplateRepository is an implementation of the ExportTemplateRepository interface that uses SQL
+type SQLExportTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewSQLExportTemplateRepository creates a new SQLExportTemplateRepository
+func NewSQLExportTemplateRepository(db *sql.DB) repo.ExportTemplateRepository {
+	return &SQLExportTemplateRepository{
+		db: db,
+	}
+}
+
+// ExportTemplate is an internal representation of an export template for DB operations
+type ExportTemplate struct {
+	CompetitionID int32
+	Filename      string
+	Content       []byte
+	UploadedAt    time.Time
+}
+
+// SetExportTemplate stores the export template of a competition, replacing the previous one
+func (r *SQLExportTemplateRepository) SetExportTemplate(ctx context.Context, template *aggregate.ExportTemplate) error {
+	query := `
+		INSERT INTO export_templates (competition_id, filename, content, uploaded_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON DUPLICATE KEY UPDATE filename = VALUES(filename), content = VALUES(content), uploaded_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		template.GetCompetitionID(),
+		template.GetFilename(),
+		template.GetContent(),
+	)
+	return err
+}
+
+// GetExportTemplate retrieves the export template of a competition, nil when it has none
+func (r *SQLExportTemplateRepository) GetExportTemplate(ctx context.Context, competitionID int32) (*aggregate.ExportTemplate, error) {
+	query := `
+		SELECT competition_id, filename, content, uploaded_at
+		FROM export_templates
+		WHERE competition_id = ?
+	`
+
+	var template ExportTemplate
+	err := r.db.QueryRowContext(ctx, query, competitionID).Scan(
+		&template.CompetitionID,
+		&template.Filename,
+		&template.Content,
+		&template.UploadedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	templateAggregate := aggregate.NewExportTemplate()
+	templateAggregate.SetCompetitionID(template.CompetitionID)
+	templateAggregate.SetFilename(template.Filename)
+	templateAggregate.SetContent(template.Content)
+	templateAggregate.SetUploadedAt(template.UploadedAt)
+
+	return templateAggregate, nil
+}
+
+// DeleteExportTemplate deletes the export template of a competition
+func (r *SQLExportTemplateRepository) DeleteExportTemplate(ctx context.Context, competitionID int32) error {
+	query := `
+		DELETE FROM export_templates
+		WHERE competition_id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, competitionID)
+	return err
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrInvitationNotFound is returned when an invitation cannot be found, or is no longer pending
+	ErrInvitationNotFound = errors.New("invitation not found")
+)
+
+// SQLInvitationRepository is an implementation of the InvitationRepository interface that uses SQL
+type SQLInvitationRepository struct {
+	db *sql.DB
+}
+
+// NewSQLInvitationRepository creates a new SQLInvitationRepository
+func NewSQLInvitationRepository(db *sql.DB) repo.InvitationRepository {
+	return &SQLInvitationRepository{
+		db: db,
+	}
+}
+
+// Invitation is an internal representation of an invitation for DB operations
+type Invitation struct {
+	ID            string
+	CompetitionID int32
+	Role          string
+	CreatedBy     int32
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	RevokedAt     sql.NullTime
+	Acceptances   int32
+	// MaxAcceptances is zero for the invitations that can be accepted by any number of users
+	MaxAcceptances int32
+	Zone           string
+}
+
+// CreateInvitation creates a new invitation
+func (r *SQLInvitationRepository) CreateInvitation(ctx context.Context, invitation *aggregate.Invitation) error {
+	query := `
+		INSERT INTO invitations (id, competition_id, role, created_by, created_at, expires_at, max_acceptances, zone)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		invitation.GetID(),
+		invitation.GetCompetitionID(),
+		invitation.GetRole(),
+		invitation.GetCreatedBy(),
+		invitation.GetCreatedAt(),
+		invitation.GetExpiresAt(),
+		invitation.GetMaxAcceptances(),
+		invitation.GetZone(),
+	)
+
+	return err
+}
+
+// GetInvitation retrieves an invitation by its ID
+func (r *SQLInvitationRepository) GetInvitation(ctx context.Context, id string) (*aggregate.Invitation, error) {
+	query := `
+		SELECT id, competition_id, role, created_by, created_at, expires_at, revoked_at, acceptances, max_acceptances, zone
+		FROM invitations
+		WHERE id = ?
+	`
+
+	var invitation Invitation
+	row := r.db.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.CompetitionID,
+		&invitation.Role,
+		&invitation.CreatedBy,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&invitation.RevokedAt,
+		&invitation.Acceptances,
+		&invitation.MaxAcceptances,
+		&invitation.Zone,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return mapToInvitationAggregate(invitation), nil
+}
+
+// ListPendingInvitations lists the invitations of a competition that are neither revoked, expired nor used up, the most recent first
+func (r *SQLInvitationRepository) ListPendingInvitations(ctx context.Context, competitionID int32) ([]*aggregate.Invitation, error) {
+	query := `
+		SELECT id, competition_id, role, created_by, created_at, expires_at, revoked_at, acceptances, max_acceptances, zone
+		FROM invitations
+		WHERE competition_id = ? AND revoked_at IS NULL AND expires_at > ?
+		AND (max_acceptances = 0 OR acceptances < max_acceptances)
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []*aggregate.Invitation{}
+	for rows.Next() {
+		var invitation Invitation
+		err := rows.Scan(
+			&invitation.ID,
+			&invitation.CompetitionID,
+			&invitation.Role,
+			&invitation.CreatedBy,
+			&invitation.CreatedAt,
+			&invitation.ExpiresAt,
+			&invitation.RevokedAt,
+			&invitation.Acceptances,
+			&invitation.MaxAcceptances,
+			&invitation.Zone,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		invitations = append(invitations, mapToInvitationAggregate(invitation))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// ExtendInvitation sets a new expiry on a pending invitation of the competition
+func (r *SQLInvitationRepository) ExtendInvitation(ctx context.Context, competitionID int32, id string, expiresAt time.Time) error {
+	query := `
+		UPDATE invitations
+		SET expires_at = ?
+		WHERE id = ? AND competition_id = ? AND revoked_at IS NULL AND expires_at > ?
+	`
+
+	return r.execOnPendingInvitation(ctx, query, expiresAt, id, competitionID, time.Now())
+}
+
+// RevokeInvitation revokes a pending invitation of the competition
+func (r *SQLInvitationRepository) RevokeInvitation(ctx context.Context, competitionID int32, id string) error {
+	query := `
+		UPDATE invitations
+		SET revoked_at = ?
+		WHERE id = ? AND competition_id = ? AND revoked_at IS NULL AND expires_at > ?
+	`
+
+	now := time.Now()
+	return r.execOnPendingInvitation(ctx, query, now, id, competitionID, now)
+}
+
+// AddInvitationAcceptance counts a user accepting the invitation, ErrInvitationNotFound is returned
+// when the invitation was already accepted by as many users as it allows
+func (r *SQLInvitationRepository) AddInvitationAcceptance(ctx context.Context, competitionID int32, id string) error {
+	query := `
+		UPDATE invitations
+		SET acceptances = acceptances + 1
+		WHERE id = ? AND competition_id = ? AND (max_acceptances = 0 OR acceptances < max_acceptances)
+	`
+
+	return r.execOnPendingInvitation(ctx, query, id, competitionID)
+}
+
+// ListInvitationStats counts per competition the invitations created since the given time and those accepted at least once
+func (r *SQLInvitationRepository) ListInvitationStats(ctx context.Context, since time.Time) ([]*aggregate.InvitationStats, error) {
+	query := `
+		SELECT competition_id, COUNT(*), COALESCE(SUM(acceptances > 0), 0)
+		FROM invitations
+		WHERE created_at >= ?
+		GROUP BY competition_id
+		ORDER BY competition_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []*aggregate.InvitationStats{}
+	for rows.Next() {
+		var competitionID, invitations, accepted int32
+		if err := rows.Scan(&competitionID, &invitations, &accepted); err != nil {
+			return nil, err
+		}
+
+		competitionStats := aggregate.NewInvitationStats()
+		competitionStats.SetCompetitionID(competitionID)
+		competitionStats.SetInvitations(invitations)
+		competitionStats.SetAccepted(accepted)
+		stats = append(stats, competitionStats)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// execOnPendingInvitation runs an update that must affect exactly one pending invitation
+func (r *SQLInvitationRepository) execOnPendingInvitation(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// Helper function to map an Invitation struct to an Invitation aggregate
+func mapToInvitationAggregate(invitation Invitation) *aggregate.Invitation {
+	invitationAggregate := aggregate.NewInvitation()
+	invitationAggregate.SetID(invitation.ID)
+	invitationAggregate.SetCompetitionID(invitation.CompetitionID)
+	invitationAggregate.SetRole(invitation.Role)
+	invitationAggregate.SetCreatedBy(invitation.CreatedBy)
+	invitationAggregate.SetCreatedAt(invitation.CreatedAt)
+	invitationAggregate.SetExpiresAt(invitation.ExpiresAt)
+	invitationAggregate.SetAcceptances(invitation.Acceptances)
+	invitationAggregate.SetMaxAcceptances(invitation.MaxAcceptances)
+	invitationAggregate.SetZone(invitation.Zone)
+	if invitation.RevokedAt.Valid {
+		invitationAggregate.SetRevokedAt(invitation.RevokedAt.Time)
+	}
+	return invitationAggregate
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// doorsColumn is the doors of a run, stored as a JSON array of booleans. NULL reads as no door.
+type doorsColumn []bool
+
+// Scan implements sql.Scanner
+func (c *doorsColumn) Scan(src interface{}) error {
+	return scanJSONArray(src, (*[]bool)(c))
+}
+
+// Value implements driver.Valuer
+func (c doorsColumn) Value() (driver.Value, error) {
+	return jsonArrayValue([]bool(c))
+}
+
+// doorPointsColumn is the points of the doors of a scale, stored as a JSON array of integers. NULL reads as no door.
+type doorPointsColumn []int32
+
+// Scan implements sql.Scanner
+func (c *doorPointsColumn) Scan(src interface{}) error {
+	return scanJSONArray(src, (*[]int32)(c))
+}
+
+// Value implements driver.Valuer
+func (c doorPointsColumn) Value() (driver.Value, error) {
+	return jsonArrayValue([]int32(c))
+}
+
+// flagsColumn is the flags of a participant, stored as a JSON array of strings. NULL reads as no flag.
+type flagsColumn []string
+
+// Scan implements sql.Scanner
+func (c *flagsColumn) Scan(src interface{}) error {
+	return scanJSONArray(src, (*[]string)(c))
+}
+
+// Value implements driver.Valuer
+func (c flagsColumn) Value() (driver.Value, error) {
+	return jsonArrayValue([]string(c))
+}
+
+// scanJSONArray decodes a JSON array read from the database into dest, NULL leaving it empty
+func scanJSONArray(src interface{}, dest interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("unsupported type %T for a JSON array column", src)
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// jsonArrayValue encodes a list as a JSON array, an empty list as [] rather than null
+func jsonArrayValue[T any](list []T) (driver.Value, error) {
+	if list == nil {
+		list = []T{}
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/config"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// HTTPLicenceRegistry is an implementation of the LicenceRegistryRepository interface querying the register of a
+// federation over HTTP. It sends GET <url>?licence=...&last_name=...&first_name=..., with the token as a bearer token
+// when one is configured, and expects a 200 answering {"valid": true} or {"valid": false}, or a 404 for an unknown licence.
+type HTTPLicenceRegistry struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// licenceCheckResponse is the answer of the register to a lookup
+type licenceCheckResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// NewHTTPLicenceRegistry creates a new HTTPLicenceRegistry for the register of the licence configuration
+func NewHTTPLicenceRegistry(cfg config.LicenceConfig) repo.LicenceRegistryRepository {
+	return &HTTPLicenceRegistry{
+		url:        cfg.RegistryURL,
+		token:      cfg.RegistryToken,
+		httpClient: &http.Client{Timeout: cfg.RegistryTimeout},
+	}
+}
+
+// CheckLicence looks the licence up in the register of the federation
+func (r *HTTPLicenceRegistry) CheckLicence(ctx context.Context, licence, lastName, firstName string) (bool, error) {
+	endpoint, err := url.Parse(r.url)
+	if err != nil {
+		return false, fmt.Errorf("invalid licence registry URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("licence", licence)
+	query.Set("last_name", lastName)
+	query.Set("first_name", firstName)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up licence %s: %w", licence, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var response licenceCheckResponse
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&response); err != nil {
+			return false, fmt.Errorf("failed to read the lookup of licence %s: %w", licence, err)
+		}
+		return response.Valid, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("failed to look up licence %s: licence registry responded %s: %s", licence, resp.Status, strings.TrimSpace(string(body)))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	repo "github.com/NiskuT/cross-api/internal/domain/repository"
@@ -14,6 +15,27 @@ var (
 	ErrLiverankingNotFound = errors.New("liveranking not found")
 )
 
+// rankingColumns maps the ranking criteria to the liverankings columns holding them
+var rankingColumns = map[string]string{
+	aggregate.RankingPoints:  "l.total_points",
+	aggregate.RankingPenalty: "l.penality",
+	aggregate.RankingTime:    "l.chrono_ms",
+	aggregate.RankingDossard: "l.dossard_number",
+}
+
+// rankingOrderClause renders aggregate.RankingOrder as the ORDER BY clause of the rankings view
+func rankingOrderClause() string {
+	clauses := make([]string, 0, len(aggregate.RankingOrder))
+	for _, criterion := range aggregate.RankingOrder {
+		direction := "ASC"
+		if criterion.Descending {
+			direction = "DESC"
+		}
+		clauses = append(clauses, rankingColumns[criterion.Name]+" "+direction)
+	}
+	return strings.Join(clauses, ", ")
+}
+
 // SQLLiverankingRepository is an implementation of the LiverankingRepository interface that uses SQL
 type SQLLiverankingRepository struct {
 	db *sql.DB
@@ -48,7 +70,8 @@ func (r *SQLLiverankingRepository) UpsertLiveranking(ctx context.Context, livera
 			SET number_of_runs = number_of_runs + 1,
 				total_points = total_points + ?,
 				penality = penality + ?,
-				chrono_sec = chrono_sec + ?
+				chrono_ms = chrono_ms + ?,
+				chrono_sec = chrono_ms DIV 1000
 			WHERE competition_id = ? AND dossard_number = ?
 		`
 		_, err = r.db.ExecContext(
@@ -56,7 +79,7 @@ func (r *SQLLiverankingRepository) UpsertLiveranking(ctx context.Context, livera
 			updateQuery,
 			liveranking.GetTotalPoints(),
 			liveranking.GetPenality(),
-			liveranking.GetChronoSec(),
+			liveranking.GetChronoMs(),
 			liveranking.GetCompetitionID(),
 			liveranking.GetDossard(),
 		)
@@ -84,8 +107,8 @@ func (r *SQLLiverankingRepository) UpsertLiveranking(ctx context.Context, livera
 
 	// Insert new liveranking
 	insertQuery := `
-		INSERT INTO liverankings (competition_id, dossard_number, number_of_runs, total_points, penality, chrono_sec)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO liverankings (competition_id, dossard_number, number_of_runs, total_points, penality, chrono_sec, chrono_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err = r.db.ExecContext(
 		ctx,
@@ -96,11 +119,12 @@ func (r *SQLLiverankingRepository) UpsertLiveranking(ctx context.Context, livera
 		liveranking.GetTotalPoints(),
 		liveranking.GetPenality(),
 		liveranking.GetChronoSec(),
+		liveranking.GetChronoMs(),
 	)
 	return err
 }
 
-// ListLiveranking lists liveranking entries sorted by desc total points, asc penality, and desc chrono sec
+// ListLiveranking lists liveranking entries of the competition in the order of the rankings view, with their overall rank
 func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competitionID, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error) {
 	if pageSize <= 0 {
 		pageSize = 10 // Default page size
@@ -113,8 +137,8 @@ func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competit
 	// Get total count first
 	countQuery := `
 		SELECT COUNT(*)
-		FROM liverankings l
-		WHERE l.competition_id = ?
+		FROM rankings
+		WHERE competition_id = ?
 	`
 	var totalCount int32
 	err := r.db.QueryRowContext(ctx, countQuery, competitionID).Scan(&totalCount)
@@ -125,12 +149,11 @@ func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competit
 	offset := (pageNumber - 1) * pageSize
 
 	query := `
-		SELECT l.competition_id, l.dossard_number, p.first_name, p.last_name, p.category, p.gender, p.club,
-		       l.number_of_runs, l.total_points, l.penality, l.chrono_sec
-		FROM liverankings l
-		JOIN participants p ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
-		WHERE l.competition_id = ?
-		ORDER BY l.total_points DESC, l.penality ASC, l.chrono_sec DESC
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club,
+		       consent_data_processing, number_of_runs, total_points, penality, chrono_ms, status, status_reason, overall_rank
+		FROM rankings
+		WHERE competition_id = ?
+		ORDER BY overall_rank
 		LIMIT ? OFFSET ?
 	`
 
@@ -144,8 +167,10 @@ func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competit
 	for rows.Next() {
 		liveranking := aggregate.NewLiveranking()
 
-		var competitionID, dossardNumber, numberOfRuns, totalPoints, penality, chronoSec int32
-		var firstName, lastName, category, gender, club string
+		var competitionID, dossardNumber, numberOfRuns, totalPoints, penality, chronoMs int32
+		var rank int64
+		var firstName, lastName, category, gender, club, status, statusReason string
+		var consentDataProcessing bool
 
 		err := rows.Scan(
 			&competitionID,
@@ -155,10 +180,14 @@ func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competit
 			&category,
 			&gender,
 			&club,
+			&consentDataProcessing,
 			&numberOfRuns,
 			&totalPoints,
 			&penality,
-			&chronoSec,
+			&chronoMs,
+			&status,
+			&statusReason,
+			&rank,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -171,10 +200,14 @@ func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competit
 		liveranking.SetCategory(category)
 		liveranking.SetGender(gender)
 		liveranking.SetClub(club)
+		liveranking.SetConsentDataProcessing(consentDataProcessing)
 		liveranking.SetNumberOfRuns(numberOfRuns)
 		liveranking.SetTotalPoints(totalPoints)
 		liveranking.SetPenality(penality)
-		liveranking.SetChronoSec(chronoSec)
+		liveranking.SetChronoMs(chronoMs)
+		liveranking.SetStatus(status)
+		liveranking.SetStatusReason(statusReason)
+		liveranking.SetRank(int32(rank))
 
 		liverankings = append(liverankings, liveranking)
 	}
@@ -186,8 +219,9 @@ func (r *SQLLiverankingRepository) ListLiveranking(ctx context.Context, competit
 	return liverankings, totalCount, nil
 }
 
-// ListLiverankingByCategoryAndGender lists liveranking entries for a specific category and gender, sorted by desc total points, asc penality, and desc chrono sec
-func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx context.Context, competitionID int32, category, gender string, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error) {
+// ListLiverankingByCategoryAndGender lists liveranking entries for a specific category and gender in the order of the rankings view, with their rank within the category and gender.
+// When checkedInOnly is set, the participants not checked in at the start desk are left out and the others ranked among themselves.
+func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx context.Context, competitionID int32, category, gender string, checkedInOnly bool, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error) {
 	if pageSize <= 0 {
 		pageSize = 10 // Default page size
 	}
@@ -196,15 +230,17 @@ func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx contex
 		pageNumber = 1 // Default page number
 	}
 
+	where := " WHERE competition_id = ? AND category = ? AND gender = ?"
+	rank := "category_rank"
+	if checkedInOnly {
+		// category_rank follows the ranking order, numbering the remaining rows along it ranks them among themselves
+		where += " AND checked_in = true"
+		rank = "ROW_NUMBER() OVER (ORDER BY category_rank)"
+	}
+
 	// Get total count first for the specific category and gender
-	countQuery := `
-		SELECT COUNT(*)
-		FROM liverankings l
-		JOIN participants p ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
-		WHERE l.competition_id = ? AND p.category = ? AND p.gender = ?
-	`
 	var totalCount int32
-	err := r.db.QueryRowContext(ctx, countQuery, competitionID, category, gender).Scan(&totalCount)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM rankings"+where, competitionID, category, gender).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -212,12 +248,10 @@ func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx contex
 	offset := (pageNumber - 1) * pageSize
 
 	query := `
-		SELECT l.competition_id, l.dossard_number, p.first_name, p.last_name, p.category, p.gender, p.club,
-		       l.number_of_runs, l.total_points, l.penality, l.chrono_sec
-		FROM liverankings l
-		JOIN participants p ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
-		WHERE l.competition_id = ? AND p.category = ? AND p.gender = ?
-		ORDER BY l.total_points DESC, l.penality ASC, l.chrono_sec DESC
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club,
+		       consent_data_processing, number_of_runs, total_points, penality, chrono_ms, status, status_reason, ` + rank + `
+		FROM rankings` + where + `
+		ORDER BY category_rank
 		LIMIT ? OFFSET ?
 	`
 
@@ -231,8 +265,10 @@ func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx contex
 	for rows.Next() {
 		liveranking := aggregate.NewLiveranking()
 
-		var competitionID, dossardNumber, numberOfRuns, totalPoints, penality, chronoSec int32
-		var firstName, lastName, category, gender, club string
+		var competitionID, dossardNumber, numberOfRuns, totalPoints, penality, chronoMs int32
+		var rank int64
+		var firstName, lastName, category, gender, club, status, statusReason string
+		var consentDataProcessing bool
 
 		err := rows.Scan(
 			&competitionID,
@@ -242,10 +278,14 @@ func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx contex
 			&category,
 			&gender,
 			&club,
+			&consentDataProcessing,
 			&numberOfRuns,
 			&totalPoints,
 			&penality,
-			&chronoSec,
+			&chronoMs,
+			&status,
+			&statusReason,
+			&rank,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -258,10 +298,14 @@ func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx contex
 		liveranking.SetCategory(category)
 		liveranking.SetGender(gender)
 		liveranking.SetClub(club)
+		liveranking.SetConsentDataProcessing(consentDataProcessing)
 		liveranking.SetNumberOfRuns(numberOfRuns)
 		liveranking.SetTotalPoints(totalPoints)
 		liveranking.SetPenality(penality)
-		liveranking.SetChronoSec(chronoSec)
+		liveranking.SetChronoMs(chronoMs)
+		liveranking.SetStatus(status)
+		liveranking.SetStatusReason(statusReason)
+		liveranking.SetRank(int32(rank))
 
 		liverankings = append(liverankings, liveranking)
 	}
@@ -275,65 +319,66 @@ func (r *SQLLiverankingRepository) ListLiverankingByCategoryAndGender(ctx contex
 
 // RecalculateLiveranking recalculates the liveranking for a specific participant from all their runs
 func (r *SQLLiverankingRepository) RecalculateLiveranking(ctx context.Context, competitionID, dossard int32) error {
+	return recalculateLiveranking(ctx, r.db, competitionID, dossard)
+}
+
+// sqlExecutor is implemented by both *sql.DB and *sql.Tx, so that the liveranking
+// can be recalculated in the transaction changing the runs
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// recalculateLiveranking recalculates the liveranking of a participant from their runs that were neither voided
+// nor are waiting for their approval
+func recalculateLiveranking(ctx context.Context, db sqlExecutor, competitionID, dossard int32) error {
 	// First get all runs for this participant and calculate total points using scales
 	query := `
-		SELECT r.competition_id, r.dossard, r.zone, r.door1, r.door2, r.door3, r.door4, r.door5, r.door6, 
-		       r.penality, r.chrono_sec, p.category,
-		       s.points_door1, s.points_door2, s.points_door3, s.points_door4, s.points_door5, s.points_door6
+		SELECT r.competition_id, r.dossard, r.zone, r.doors,
+		       r.penality, r.chrono_ms, r.status, p.category,
+		       s.door_points
 		FROM runs r
 		JOIN participants p ON r.competition_id = p.competition_id AND r.dossard = p.dossard_number
 		JOIN scales s ON r.competition_id = s.competition_id AND p.category = s.category AND r.zone = s.zone
-		WHERE r.competition_id = ? AND r.dossard = ?
+		WHERE r.competition_id = ? AND r.dossard = ? AND r.voided_at IS NULL AND r.pending_approval = false
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, competitionID, dossard)
+	rows, err := db.QueryContext(ctx, query, competitionID, dossard)
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 
-	var totalRuns, totalPoints, totalPenalty, totalChronoSec int32
+	var totalRuns, totalPoints, totalPenalty, totalChronoMs int32
 
 	for rows.Next() {
-		var competitionID, dossard, penality, chronoSec int32
-		var zone, category string
-		var door1, door2, door3, door4, door5, door6 bool
-		var pointsDoor1, pointsDoor2, pointsDoor3, pointsDoor4, pointsDoor5, pointsDoor6 int32
+		var competitionID, dossard, penality, chronoMs int32
+		var zone, status, category string
+		var doors doorsColumn
+		var doorPoints doorPointsColumn
 
 		err := rows.Scan(
-			&competitionID, &dossard, &zone, &door1, &door2, &door3, &door4, &door5, &door6,
-			&penality, &chronoSec, &category,
-			&pointsDoor1, &pointsDoor2, &pointsDoor3, &pointsDoor4, &pointsDoor5, &pointsDoor6,
+			&competitionID, &dossard, &zone, &doors,
+			&penality, &chronoMs, &status, &category,
+			&doorPoints,
 		)
 		if err != nil {
 			return err
 		}
 
-		// Calculate points for this run
-		runPoints := int32(0)
-		if door1 {
-			runPoints += pointsDoor1
-		}
-		if door2 {
-			runPoints += pointsDoor2
-		}
-		if door3 {
-			runPoints += pointsDoor3
-		}
-		if door4 {
-			runPoints += pointsDoor4
-		}
-		if door5 {
-			runPoints += pointsDoor5
-		}
-		if door6 {
-			runPoints += pointsDoor6
+		// Calculate points for this run, the runs not finished or disqualified score none
+		var runPoints int32
+		if status == aggregate.RunStatusOK {
+			scale := aggregate.NewScale()
+			scale.SetDoorPoints(doorPoints)
+			runPoints = scale.CalculatePoints(doors)
 		}
 
 		totalRuns++
 		totalPoints += runPoints
 		totalPenalty += penality
-		totalChronoSec += chronoSec
+		totalChronoMs += chronoMs
 	}
 
 	if err = rows.Err(); err != nil {
@@ -343,7 +388,7 @@ func (r *SQLLiverankingRepository) RecalculateLiveranking(ctx context.Context, c
 	// If no runs found, delete the liveranking entry if it exists
 	if totalRuns == 0 {
 		deleteQuery := `DELETE FROM liverankings WHERE competition_id = ? AND dossard_number = ?`
-		_, err = r.db.ExecContext(ctx, deleteQuery, competitionID, dossard)
+		_, err = db.ExecContext(ctx, deleteQuery, competitionID, dossard)
 		return err
 	}
 
@@ -355,7 +400,7 @@ func (r *SQLLiverankingRepository) RecalculateLiveranking(ctx context.Context, c
 		)
 	`
 	var exists bool
-	err = r.db.QueryRowContext(ctx, checkQuery, competitionID, dossard).Scan(&exists)
+	err = db.QueryRowContext(ctx, checkQuery, competitionID, dossard).Scan(&exists)
 	if err != nil {
 		return err
 	}
@@ -364,18 +409,18 @@ func (r *SQLLiverankingRepository) RecalculateLiveranking(ctx context.Context, c
 		// Update existing liveranking with recalculated values
 		updateQuery := `
 			UPDATE liverankings
-			SET number_of_runs = ?, total_points = ?, penality = ?, chrono_sec = ?
+			SET number_of_runs = ?, total_points = ?, penality = ?, chrono_sec = ?, chrono_ms = ?
 			WHERE competition_id = ? AND dossard_number = ?
 		`
-		_, err = r.db.ExecContext(ctx, updateQuery, totalRuns, totalPoints, totalPenalty, totalChronoSec, competitionID, dossard)
+		_, err = db.ExecContext(ctx, updateQuery, totalRuns, totalPoints, totalPenalty, totalChronoMs/1000, totalChronoMs, competitionID, dossard)
 		return err
 	}
 
 	// Insert new liveranking if it doesn't exist
 	insertQuery := `
-		INSERT INTO liverankings (competition_id, dossard_number, number_of_runs, total_points, penality, chrono_sec)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO liverankings (competition_id, dossard_number, number_of_runs, total_points, penality, chrono_sec, chrono_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err = r.db.ExecContext(ctx, insertQuery, competitionID, dossard, totalRuns, totalPoints, totalPenalty, totalChronoSec)
+	_, err = db.ExecContext(ctx, insertQuery, competitionID, dossard, totalRuns, totalPoints, totalPenalty, totalChronoMs/1000, totalChronoMs)
 	return err
 }
@@ -10,29 +10,185 @@ CREATE TABLE IF NOT EXISTS participants (
     category VARCHAR(100) NOT NULL,
     gender CHAR(1) NOT NULL DEFAULT 'H' CHECK (gender IN ('H', 'F')),
     club VARCHAR(40) NOT NULL DEFAULT '',
-    PRIMARY KEY (competition_id, dossard_number)
+    club_email VARCHAR(255) NOT NULL DEFAULT '',
+    licence VARCHAR(50) NOT NULL DEFAULT '',
+    consent_data_processing BOOLEAN NOT NULL DEFAULT false,
+    consent_photo_rights BOOLEAN NOT NULL DEFAULT false,
+    waitlisted BOOLEAN NOT NULL DEFAULT false,
+    waitlisted_at TIMESTAMP(6) NULL DEFAULT NULL,
+    club_id INT NULL DEFAULT NULL,
+    checked_in BOOLEAN NOT NULL DEFAULT false,
+    birth_date DATE NULL DEFAULT NULL,
+    notes VARCHAR(1000) NOT NULL DEFAULT '',
+    flags JSON NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'registered' CHECK (status IN ('registered', 'dns', 'dnf', 'dsq')),
+    status_reason VARCHAR(255) NOT NULL DEFAULT '',
+    photo_key VARCHAR(1024) NOT NULL DEFAULT '',
+    PRIMARY KEY (competition_id, dossard_number),
+    KEY (club_id),
+    KEY participants_last_name (competition_id, last_name),
+    KEY participants_first_name (competition_id, first_name)
 );
 `
 
+// AddParticipantsGenderColumnQuery adds the gender to participants tables created before it existed.
+// The participants registered before the upgrade are read as men until they are corrected.
+const AddParticipantsGenderColumnQuery = `
+ALTER TABLE participants ADD COLUMN gender CHAR(1) NOT NULL DEFAULT 'H' CHECK (gender IN ('H', 'F'));
+`
+
+// AddParticipantsClubColumnQuery adds the club name to participants tables created before it existed
+const AddParticipantsClubColumnQuery = `
+ALTER TABLE participants ADD COLUMN club VARCHAR(40) NOT NULL DEFAULT '';
+`
+
+// AddParticipantsConsentDataProcessingColumnQuery adds the data processing consent to participants tables created before it existed.
+// Participants registered before the upgrade have not given it.
+const AddParticipantsConsentDataProcessingColumnQuery = `
+ALTER TABLE participants ADD COLUMN consent_data_processing BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddParticipantsConsentPhotoRightsColumnQuery adds the photo rights consent to participants tables created before it existed
+const AddParticipantsConsentPhotoRightsColumnQuery = `
+ALTER TABLE participants ADD COLUMN consent_photo_rights BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddParticipantsClubEmailColumnQuery adds the club contact address to participants tables created before it existed
+const AddParticipantsClubEmailColumnQuery = `
+ALTER TABLE participants ADD COLUMN club_email VARCHAR(255) NOT NULL DEFAULT '';
+`
+
+// AddParticipantsLicenceColumnQuery adds the licence number to participants tables created before it existed
+const AddParticipantsLicenceColumnQuery = `
+ALTER TABLE participants ADD COLUMN licence VARCHAR(50) NOT NULL DEFAULT '';
+`
+
+// AddParticipantsWaitlistedColumnQuery adds the waitlist flag to participants tables created before it existed
+const AddParticipantsWaitlistedColumnQuery = `
+ALTER TABLE participants ADD COLUMN waitlisted BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddParticipantsWaitlistedAtColumnQuery adds when the participants entered the waitlist to participants tables created
+// before it existed. The participants waitlisted before the upgrade have none and come first on the waitlist.
+const AddParticipantsWaitlistedAtColumnQuery = `
+ALTER TABLE participants ADD COLUMN waitlisted_at TIMESTAMP(6) NULL DEFAULT NULL;
+`
+
+// AddParticipantsClubIDColumnQuery adds the club of the participants to participants tables created before it existed,
+// MigrateClubs fills it in from their club names
+const AddParticipantsClubIDColumnQuery = `
+ALTER TABLE participants ADD COLUMN club_id INT NULL DEFAULT NULL, ADD KEY (club_id);
+`
+
+// AddParticipantsCheckedInColumnQuery adds the start desk check-in to participants tables created before it existed
+const AddParticipantsCheckedInColumnQuery = `
+ALTER TABLE participants ADD COLUMN checked_in BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddParticipantsBirthDateColumnQuery adds the day of birth to participants tables created before it existed
+const AddParticipantsBirthDateColumnQuery = `
+ALTER TABLE participants ADD COLUMN birth_date DATE NULL DEFAULT NULL;
+`
+
+// AddParticipantsNotesColumnQuery adds the notes for the referees to participants tables created before they existed
+const AddParticipantsNotesColumnQuery = `
+ALTER TABLE participants ADD COLUMN notes VARCHAR(1000) NOT NULL DEFAULT '';
+`
+
+// AddParticipantsFlagsColumnQuery adds the flags shown to the referees to participants tables created before they existed
+const AddParticipantsFlagsColumnQuery = `
+ALTER TABLE participants ADD COLUMN flags JSON NULL;
+`
+
+// AddParticipantsStatusColumnQuery adds the status to participants tables created before it existed.
+// The participants registered before the upgrade take part in the competition.
+const AddParticipantsStatusColumnQuery = `
+ALTER TABLE participants ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'registered' CHECK (status IN ('registered', 'dns', 'dnf', 'dsq'));
+`
+
+// AddParticipantsStatusReasonColumnQuery adds the reason of the status to participants tables created before it existed
+const AddParticipantsStatusReasonColumnQuery = `
+ALTER TABLE participants ADD COLUMN status_reason VARCHAR(255) NOT NULL DEFAULT '';
+`
+
+// AddParticipantsPhotoKeyColumnQuery adds the photo of the participants to participants tables created before it existed
+const AddParticipantsPhotoKeyColumnQuery = `
+ALTER TABLE participants ADD COLUMN photo_key VARCHAR(1024) NOT NULL DEFAULT '';
+`
+
+// AddParticipantsNameIndexesQuery indexes the names of the participants of participants tables created before the name search existed
+const AddParticipantsNameIndexesQuery = `
+ALTER TABLE participants ADD INDEX participants_last_name (competition_id, last_name), ADD INDEX participants_first_name (competition_id, first_name);
+`
+
 // DropParticipantsTableQuery drops the participants table
 const DropParticipantsTableQuery = `
 DROP TABLE IF EXISTS participants;
 `
 
+// CreateOrganizationsTableQuery creates the organizations table.
+// An organization such as a club runs several competitions, its admins administrate all of them.
+const CreateOrganizationsTableQuery = `
+CREATE TABLE IF NOT EXISTS organizations (
+    id INT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY (name)
+);
+`
+
 // CreateCompetitionsTableQuery creates the competitions table
 const CreateCompetitionsTableQuery = `
 CREATE TABLE IF NOT EXISTS competitions (
     id INT NOT NULL AUTO_INCREMENT,
     name VARCHAR(255) NOT NULL,
     description TEXT,
-    date VARCHAR(50) NOT NULL,
+    date DATETIME NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Paris',
     location VARCHAR(255) NOT NULL,
     organizer VARCHAR(255) NOT NULL,
     contact VARCHAR(255) NOT NULL,
-    PRIMARY KEY (id)
+    chrono_format VARCHAR(20) NOT NULL DEFAULT 'mm:ss',
+    chrono_direction VARCHAR(10) NOT NULL DEFAULT 'up',
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    archived_at TIMESTAMP NULL DEFAULT NULL,
+    organization_id INT NULL DEFAULT NULL,
+    max_participants INT NOT NULL DEFAULT 0,
+    registration_deadline DATETIME NULL DEFAULT NULL,
+    waitlist BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (id),
+    INDEX (organization_id)
 );
 `
 
+// AddCompetitionsTimezoneColumnQuery adds the time zone to competitions tables created before it existed.
+// Competitions created before the upgrade took place in France.
+const AddCompetitionsTimezoneColumnQuery = `
+ALTER TABLE competitions ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Paris';
+`
+
+// AddCompetitionsMaxParticipantsColumnQuery adds the participants limit to competitions tables created before it existed
+const AddCompetitionsMaxParticipantsColumnQuery = `
+ALTER TABLE competitions ADD COLUMN max_participants INT NOT NULL DEFAULT 0;
+`
+
+// AddCompetitionsRegistrationDeadlineColumnQuery adds the registration deadline to competitions tables created before it existed
+const AddCompetitionsRegistrationDeadlineColumnQuery = `
+ALTER TABLE competitions ADD COLUMN registration_deadline DATETIME NULL DEFAULT NULL;
+`
+
+// AddCompetitionsWaitlistColumnQuery adds the waitlist switch to competitions tables created before it existed
+const AddCompetitionsWaitlistColumnQuery = `
+ALTER TABLE competitions ADD COLUMN waitlist BOOLEAN NOT NULL DEFAULT false;
+`
+
+// ConvertCompetitionsDateColumnQuery turns the free-form competition dates, once rewritten as UTC date times
+// by MigrateCompetitionDates, into a DATETIME column
+const ConvertCompetitionsDateColumnQuery = `
+ALTER TABLE competitions MODIFY COLUMN date DATETIME NOT NULL;
+`
+
 // DropCompetitionsTableQuery drops the competitions table
 const DropCompetitionsTableQuery = `
 DROP TABLE IF EXISTS competitions;
@@ -44,17 +200,36 @@ CREATE TABLE IF NOT EXISTS scales (
     competition_id INT NOT NULL,
     category VARCHAR(100) NOT NULL,
     zone VARCHAR(100) NOT NULL,
-    points_door1 INT NOT NULL,
-    points_door2 INT NOT NULL,
-    points_door3 INT NOT NULL,
-    points_door4 INT NOT NULL,
-    points_door5 INT NOT NULL,
-    points_door6 INT NOT NULL,
+    door_points JSON NULL,
     PRIMARY KEY (competition_id, category, zone),
     FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
 );
 `
 
+// AddScalesDoorPointsColumnQuery adds the points of each door to scales tables created when zones had six doors
+const AddScalesDoorPointsColumnQuery = `
+ALTER TABLE scales ADD COLUMN door_points JSON NULL;
+`
+
+// BackfillScalesDoorPointsQuery copies the points of the six door columns of the previous versions to door_points
+const BackfillScalesDoorPointsQuery = `
+UPDATE scales
+SET door_points = JSON_ARRAY(points_door1, points_door2, points_door3, points_door4, points_door5, points_door6)
+WHERE door_points IS NULL;
+`
+
+// RelaxScalesLegacyDoorColumnsQuery gives the six door columns of the previous versions a default, they are no
+// longer written but kept for the instances of the previous version still reading them during an upgrade
+const RelaxScalesLegacyDoorColumnsQuery = `
+ALTER TABLE scales
+    MODIFY COLUMN points_door1 INT NOT NULL DEFAULT 0,
+    MODIFY COLUMN points_door2 INT NOT NULL DEFAULT 0,
+    MODIFY COLUMN points_door3 INT NOT NULL DEFAULT 0,
+    MODIFY COLUMN points_door4 INT NOT NULL DEFAULT 0,
+    MODIFY COLUMN points_door5 INT NOT NULL DEFAULT 0,
+    MODIFY COLUMN points_door6 INT NOT NULL DEFAULT 0;
+`
+
 // DropScalesTableQuery drops the scales table
 const DropScalesTableQuery = `
 DROP TABLE IF EXISTS scales;
@@ -68,7 +243,7 @@ CREATE TABLE IF NOT EXISTS users (
     first_name VARCHAR(255) NOT NULL,
     last_name VARCHAR(255) NOT NULL,
     password_hash VARCHAR(255) NOT NULL,
-    roles VARCHAR(500) NOT NULL,
+    roles VARCHAR(500) NOT NULL DEFAULT '',
     PRIMARY KEY (id),
     UNIQUE KEY (email)
 );
@@ -86,16 +261,22 @@ CREATE TABLE IF NOT EXISTS runs (
     dossard INT NOT NULL,
     run_number INT NOT NULL,
     zone VARCHAR(100) NOT NULL,
-    door1 BOOLEAN NOT NULL DEFAULT false,
-    door2 BOOLEAN NOT NULL DEFAULT false,
-    door3 BOOLEAN NOT NULL DEFAULT false,
-    door4 BOOLEAN NOT NULL DEFAULT false,
-    door5 BOOLEAN NOT NULL DEFAULT false,
-    door6 BOOLEAN NOT NULL DEFAULT false,
+    doors JSON NULL,
     penality INT NOT NULL DEFAULT 0,
     chrono_sec INT NOT NULL DEFAULT 0,
+    chrono_ms INT NOT NULL DEFAULT 0,
     referee_id INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    voided_at TIMESTAMP NULL DEFAULT NULL,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    receipt_code VARCHAR(16) NULL DEFAULT NULL,
+    status VARCHAR(3) NOT NULL DEFAULT 'ok' CHECK (status IN ('ok', 'dnf', 'dsq')),
+    idempotency_key VARCHAR(64) NULL DEFAULT NULL,
+    pending_approval BOOLEAN NOT NULL DEFAULT false,
+    comment VARCHAR(500) NOT NULL DEFAULT '',
     PRIMARY KEY (competition_id, run_number, dossard),
+    UNIQUE INDEX runs_receipt_code (receipt_code),
+    UNIQUE INDEX runs_idempotency_key (competition_id, idempotency_key),
     FOREIGN KEY (competition_id, dossard) REFERENCES participants(competition_id, dossard_number) ON DELETE CASCADE
 );
 `
@@ -105,6 +286,110 @@ const DropRunsTableQuery = `
 DROP TABLE IF EXISTS runs;
 `
 
+// AddRunsCreatedAtColumnQuery adds the creation timestamp to runs tables created before it existed.
+// Runs recorded before the upgrade get the migration time as creation time.
+const AddRunsCreatedAtColumnQuery = `
+ALTER TABLE runs ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+`
+
+// AddRunsVoidedAtColumnQuery adds the void timestamp to runs tables created before it existed.
+// Voided runs are kept for the record but no longer count in the ranking.
+const AddRunsVoidedAtColumnQuery = `
+ALTER TABLE runs ADD COLUMN voided_at TIMESTAMP NULL DEFAULT NULL;
+`
+
+// AddRunsUpdatedAtColumnQuery adds the modification timestamp to runs tables created before it existed.
+// It stays null until the run is modified or voided, the runs recorded before the upgrade keep it null.
+const AddRunsUpdatedAtColumnQuery = `
+ALTER TABLE runs ADD COLUMN updated_at TIMESTAMP NULL DEFAULT NULL;
+`
+
+// AddRunsStatusColumnQuery adds the status to runs tables created before it existed, the runs recorded before are ok
+const AddRunsStatusColumnQuery = `
+ALTER TABLE runs ADD COLUMN status VARCHAR(3) NOT NULL DEFAULT 'ok' CHECK (status IN ('ok', 'dnf', 'dsq'));
+`
+
+// AddRunsPendingApprovalColumnQuery adds the approval of the runs to runs tables created before it existed,
+// the runs recorded before count
+const AddRunsPendingApprovalColumnQuery = `
+ALTER TABLE runs ADD COLUMN pending_approval BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddRunsCommentColumnQuery adds the comments of the referees to runs tables created before they existed
+const AddRunsCommentColumnQuery = `
+ALTER TABLE runs ADD COLUMN comment VARCHAR(500) NOT NULL DEFAULT '';
+`
+
+// AddRunsChronoMsColumnQuery adds the chrono in milliseconds to runs tables created before it existed,
+// MigrateChronoMs fills it in from the chrono in seconds
+const AddRunsChronoMsColumnQuery = `
+ALTER TABLE runs ADD COLUMN chrono_ms INT NOT NULL DEFAULT 0;
+`
+
+// BackfillRunsChronoMsQuery converts the chronos in seconds of the runs recorded before the milliseconds existed
+const BackfillRunsChronoMsQuery = `
+UPDATE runs SET chrono_ms = chrono_sec * 1000 WHERE chrono_ms = 0 AND chrono_sec <> 0;
+`
+
+// AddRunsDoorsColumnQuery adds the doors passed to runs tables created when zones had six doors
+const AddRunsDoorsColumnQuery = `
+ALTER TABLE runs ADD COLUMN doors JSON NULL;
+`
+
+// BackfillRunsDoorsQuery copies the six door columns of the previous versions to doors
+const BackfillRunsDoorsQuery = `
+UPDATE runs
+SET doors = JSON_ARRAY(
+    IF(door1, CAST('true' AS JSON), CAST('false' AS JSON)),
+    IF(door2, CAST('true' AS JSON), CAST('false' AS JSON)),
+    IF(door3, CAST('true' AS JSON), CAST('false' AS JSON)),
+    IF(door4, CAST('true' AS JSON), CAST('false' AS JSON)),
+    IF(door5, CAST('true' AS JSON), CAST('false' AS JSON)),
+    IF(door6, CAST('true' AS JSON), CAST('false' AS JSON))
+)
+WHERE doors IS NULL;
+`
+
+// AddRunsReceiptCodeColumnQuery adds the receipt codes to runs tables created before they existed,
+// the runs recorded before have no receipt
+const AddRunsReceiptCodeColumnQuery = `
+ALTER TABLE runs ADD COLUMN receipt_code VARCHAR(16) NULL DEFAULT NULL, ADD UNIQUE INDEX runs_receipt_code (receipt_code);
+`
+
+// AddRunsIdempotencyKeyColumnQuery adds the idempotency keys sent by the clients recording runs to runs tables
+// created before they existed, the runs recorded before have none
+const AddRunsIdempotencyKeyColumnQuery = `
+ALTER TABLE runs ADD COLUMN idempotency_key VARCHAR(64) NULL DEFAULT NULL, ADD UNIQUE INDEX runs_idempotency_key (competition_id, idempotency_key);
+`
+
+// AddCompetitionsChronoFormatColumnQuery adds the chrono display format to competitions tables created before it existed
+const AddCompetitionsChronoFormatColumnQuery = `
+ALTER TABLE competitions ADD COLUMN chrono_format VARCHAR(20) NOT NULL DEFAULT 'mm:ss';
+`
+
+// AddCompetitionsChronoDirectionColumnQuery adds the chrono direction to competitions tables created before it existed
+const AddCompetitionsChronoDirectionColumnQuery = `
+ALTER TABLE competitions ADD COLUMN chrono_direction VARCHAR(10) NOT NULL DEFAULT 'up';
+`
+
+// AddCompetitionsStatusColumnQuery adds the lifecycle status to competitions tables created before it existed.
+// Existing competitions are considered running so that their referees can keep scoring.
+const AddCompetitionsStatusColumnQuery = `
+ALTER TABLE competitions ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'running';
+`
+
+// AddCompetitionsArchivedAtColumnQuery adds the archive timestamp to competitions tables created before it existed.
+// Archived competitions are kept but no longer listed by default.
+const AddCompetitionsArchivedAtColumnQuery = `
+ALTER TABLE competitions ADD COLUMN archived_at TIMESTAMP NULL DEFAULT NULL;
+`
+
+// AddCompetitionsOrganizationIDColumnQuery adds the organization to competitions tables created before it existed,
+// existing competitions belong to none
+const AddCompetitionsOrganizationIDColumnQuery = `
+ALTER TABLE competitions ADD COLUMN organization_id INT NULL DEFAULT NULL, ADD INDEX (organization_id);
+`
+
 // CreateLiverankingsTableQuery creates the liverankings table
 const CreateLiverankingsTableQuery = `
 CREATE TABLE IF NOT EXISTS liverankings (
@@ -114,16 +399,574 @@ CREATE TABLE IF NOT EXISTS liverankings (
     total_points INT NOT NULL DEFAULT 0,
     penality INT NOT NULL DEFAULT 0,
     chrono_sec INT NOT NULL DEFAULT 0,
+    chrono_ms INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (competition_id, dossard_number),
+    FOREIGN KEY (competition_id, dossard_number) REFERENCES participants(competition_id, dossard_number) ON DELETE CASCADE
+);
+`
+
+// AddLiverankingsChronoMsColumnQuery adds the total chrono in milliseconds to liverankings tables created before
+// it existed, MigrateChronoMs fills it in from the total chrono in seconds
+const AddLiverankingsChronoMsColumnQuery = `
+ALTER TABLE liverankings ADD COLUMN chrono_ms INT NOT NULL DEFAULT 0;
+`
+
+// BackfillLiverankingsChronoMsQuery converts the total chronos in seconds of the liverankings computed before the
+// milliseconds existed
+const BackfillLiverankingsChronoMsQuery = `
+UPDATE liverankings SET chrono_ms = chrono_sec * 1000 WHERE chrono_ms = 0 AND chrono_sec <> 0;
+`
+
+// CreateAuditLogsTableQuery creates the audit_logs table.
+// Entries are kept when the competition or the user is deleted, hence the missing foreign keys.
+const CreateAuditLogsTableQuery = `
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NULL DEFAULT NULL,
+    user_id INT NULL DEFAULT NULL,
+    action VARCHAR(100) NOT NULL,
+    details TEXT,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX (competition_id, created_at)
+);
+`
+
+// CreateSessionsTableQuery creates the sessions table.
+// Every refresh token carries the ID of its session, so revoking a session prevents it from being refreshed.
+const CreateSessionsTableQuery = `
+CREATE TABLE IF NOT EXISTS sessions (
+    id CHAR(36) NOT NULL,
+    user_id INT NOT NULL,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    INDEX (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// CreateCompetitionContactsTableQuery creates the competition_contacts table.
+// A competition can have several organizer contacts, each with a role.
+const CreateCompetitionContactsTableQuery = `
+CREATE TABLE IF NOT EXISTS competition_contacts (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    role VARCHAR(20) NOT NULL CHECK (role IN ('results', 'logistics')),
+    PRIMARY KEY (id),
+    UNIQUE KEY (competition_id, email, role),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateAPIKeysTableQuery creates the api_keys table.
+// Only the SHA-256 hash of a key is stored, the plain key is shown once at creation.
+const CreateAPIKeysTableQuery = `
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    created_by INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY (key_hash),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateUserRolesTableQuery creates the user_roles table.
+// It replaces the comma-separated users.roles column, competition_id is NULL for global roles
+// such as "admin:*" and has no foreign key so that roles of deleted competitions can be pruned later.
+const CreateUserRolesTableQuery = `
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INT NOT NULL,
+    role VARCHAR(255) NOT NULL,
+    competition_id INT NULL DEFAULT NULL,
+    PRIMARY KEY (user_id, role),
+    INDEX (competition_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// CreateUserRolesBackupTableQuery creates the user_roles_backup table.
+// It keeps the comma-separated roles of each user as they were when moved to user_roles,
+// to verify the migration.
+const CreateUserRolesBackupTableQuery = `
+CREATE TABLE IF NOT EXISTS user_roles_backup (
+    user_id INT NOT NULL,
+    roles VARCHAR(500) NOT NULL,
+    migrated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// CreateAuthEventsTableQuery creates the auth_events table.
+// Failed logins of unknown emails have no user, and entries are kept when the user is deleted, hence the missing foreign key.
+const CreateAuthEventsTableQuery = `
+CREATE TABLE IF NOT EXISTS auth_events (
+    id INT NOT NULL AUTO_INCREMENT,
+    user_id INT NULL DEFAULT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    type VARCHAR(50) NOT NULL,
+    competition_id INT NULL DEFAULT NULL,
+    details TEXT,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX (user_id, created_at),
+    INDEX (competition_id, created_at)
+);
+`
+
+// CreateInvitationsTableQuery creates the invitations table.
+// The ID is the jti claim of the invitation token, a token is only accepted while its invitation is pending.
+const CreateInvitationsTableQuery = `
+CREATE TABLE IF NOT EXISTS invitations (
+    id CHAR(36) NOT NULL,
+    competition_id INT NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'referee',
+    created_by INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    acceptances INT NOT NULL DEFAULT 0,
+    max_acceptances INT NOT NULL DEFAULT 0,
+    zone VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    INDEX (competition_id, expires_at),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// AddInvitationsMaxAcceptancesColumnQuery adds the acceptance limit to invitations tables created before it existed.
+// Existing invitations can be accepted by any number of users.
+const AddInvitationsMaxAcceptancesColumnQuery = `
+ALTER TABLE invitations ADD COLUMN max_acceptances INT NOT NULL DEFAULT 0;
+`
+
+// AddInvitationsZoneColumnQuery adds the zone of the invited referees to invitations tables created before it existed
+const AddInvitationsZoneColumnQuery = `
+ALTER TABLE invitations ADD COLUMN zone VARCHAR(255) NOT NULL DEFAULT '';
+`
+
+// CreateUserIdentitiesTableQuery creates the user_identities table.
+// It links the subject of an OpenID Connect provider to the user it logs in as.
+const CreateUserIdentitiesTableQuery = `
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, subject),
+    INDEX (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// CreateRefereePinsTableQuery creates the referee_pins table.
+// A referee has at most one PIN per competition, only the SHA-256 hash of the PIN is stored and is unique
+// within the competition so that a PIN identifies its referee.
+const CreateRefereePinsTableQuery = `
+CREATE TABLE IF NOT EXISTS referee_pins (
+    competition_id INT NOT NULL,
+    user_id INT NOT NULL,
+    pin_hash CHAR(64) NOT NULL,
+    created_by INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (competition_id, user_id),
+    UNIQUE KEY (competition_id, pin_hash),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// CreateRefereeOnboardingsTableQuery creates the referee_onboardings table.
+// The steps of the onboarding checklist a referee completed for a competition, NULL when not done yet.
+const CreateRefereeOnboardingsTableQuery = `
+CREATE TABLE IF NOT EXISTS referee_onboardings (
+    competition_id INT NOT NULL,
+    user_id INT NOT NULL,
+    invitation_accepted_at TIMESTAMP NULL DEFAULT NULL,
+    rules_read_at TIMESTAMP NULL DEFAULT NULL,
+    confirmed_zone VARCHAR(100) NOT NULL DEFAULT '',
+    zone_confirmed_at TIMESTAMP NULL DEFAULT NULL,
+    test_run_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (competition_id, user_id),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// CreateZoneBoundsTableQuery creates the zone_bounds table.
+// The plausible values of the runs of a zone, a zero maximum is not checked.
+const CreateZoneBoundsTableQuery = `
+CREATE TABLE IF NOT EXISTS zone_bounds (
+    competition_id INT NOT NULL,
+    zone VARCHAR(100) NOT NULL,
+    max_chrono_sec INT NOT NULL DEFAULT 0,
+    max_penality INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (competition_id, zone),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateZonesTableQuery creates the zones table.
+// The description of the zones of a competition, the scales reference a zone by its name.
+const CreateZonesTableQuery = `
+CREATE TABLE IF NOT EXISTS zones (
+    competition_id INT NOT NULL,
+    zone VARCHAR(100) NOT NULL,
+    description VARCHAR(2000) NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    latitude DECIMAL(9,6) NULL,
+    longitude DECIMAL(9,6) NULL,
+    photo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    is_open BOOLEAN NOT NULL DEFAULT true,
+    PRIMARY KEY (competition_id, zone),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// FillZonesFromScalesQuery creates the zones the scales reference and the zones table lacks,
+// e.g. those of the competitions created before the table existed
+const FillZonesFromScalesQuery = `
+INSERT IGNORE INTO zones (competition_id, zone)
+SELECT DISTINCT competition_id, zone FROM scales;
+`
+
+// CreateRefereeAssignmentsTableQuery creates the referee_assignments table.
+// The zones the referees of a competition are assigned to, a referee can be assigned to several zones.
+const CreateRefereeAssignmentsTableQuery = `
+CREATE TABLE IF NOT EXISTS referee_assignments (
+    competition_id INT NOT NULL,
+    user_id INT NOT NULL,
+    zone VARCHAR(100) NOT NULL,
+    assigned_by INT NOT NULL DEFAULT 0,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (competition_id, user_id, zone),
+    INDEX (competition_id, zone),
+    FOREIGN KEY (competition_id, zone) REFERENCES zones(competition_id, zone) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+// AddZonesIsOpenColumnQuery adds the open state to zones tables created before it existed, existing zones are open
+const AddZonesIsOpenColumnQuery = `
+ALTER TABLE zones ADD COLUMN is_open BOOLEAN NOT NULL DEFAULT true;
+`
+
+// CreateExportTemplatesTableQuery creates the export_templates table.
+// The XLSX workbook a competition fills its results export with, instead of the default layout.
+const CreateExportTemplatesTableQuery = `
+CREATE TABLE IF NOT EXISTS export_templates (
+    competition_id INT NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content MEDIUMBLOB NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (competition_id),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateCompetitionSettingsTableQuery creates the competition_settings table.
+// How the runs of a competition are expected and scored, competitions without a row keep the default settings.
+const CreateCompetitionSettingsTableQuery = `
+CREATE TABLE IF NOT EXISTS competition_settings (
+    competition_id INT NOT NULL,
+    runs_per_zone INT NOT NULL DEFAULT 0,
+    zones_per_category INT NOT NULL DEFAULT 0,
+    scoring VARCHAR(20) NOT NULL DEFAULT 'all_runs',
+    restrict_referee_zones BOOLEAN NOT NULL DEFAULT false,
+    verify_licences BOOLEAN NOT NULL DEFAULT false,
+    run_dsq_rule VARCHAR(20) NOT NULL DEFAULT 'zero_points',
+    require_run_approval BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (competition_id),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// AddCompetitionSettingsRestrictRefereeZonesColumnQuery adds the restriction of the referees to their zones to
+// competition_settings tables created before it existed, existing competitions keep accepting runs from any referee
+const AddCompetitionSettingsRestrictRefereeZonesColumnQuery = `
+ALTER TABLE competition_settings ADD COLUMN restrict_referee_zones BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddCompetitionSettingsVerifyLicencesColumnQuery adds the verification of the licence numbers to competition_settings
+// tables created before it existed, existing competitions import participants without it
+const AddCompetitionSettingsVerifyLicencesColumnQuery = `
+ALTER TABLE competition_settings ADD COLUMN verify_licences BOOLEAN NOT NULL DEFAULT false;
+`
+
+// AddCompetitionSettingsRunDSQRuleColumnQuery adds the rule of the disqualified runs to competition_settings tables
+// created before it existed, the disqualified runs of existing competitions score no points
+const AddCompetitionSettingsRunDSQRuleColumnQuery = `
+ALTER TABLE competition_settings ADD COLUMN run_dsq_rule VARCHAR(20) NOT NULL DEFAULT 'zero_points';
+`
+
+// AddCompetitionSettingsRequireRunApprovalColumnQuery adds the approval of the runs to competition_settings tables
+// created before it existed, the runs of existing competitions count as soon as they are recorded
+const AddCompetitionSettingsRequireRunApprovalColumnQuery = `
+ALTER TABLE competition_settings ADD COLUMN require_run_approval BOOLEAN NOT NULL DEFAULT false;
+`
+
+// CreateCategoriesTableQuery creates the categories table.
+// Competitions without categories accept any category in their participants and scales.
+const CreateCategoriesTableQuery = `
+CREATE TABLE IF NOT EXISTS categories (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    min_age INT NOT NULL DEFAULT 0,
+    max_age INT NOT NULL DEFAULT 0,
+    display_order INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id),
+    UNIQUE KEY (competition_id, name),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateClubsTableQuery creates the clubs table.
+// The participants name their club and point to it, a club is created for every name the participants give.
+const CreateClubsTableQuery = `
+CREATE TABLE IF NOT EXISTS clubs (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    name VARCHAR(40) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY (competition_id, name),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// BackfillClubsQuery creates the clubs named by the participants that do not have one yet
+const BackfillClubsQuery = `
+INSERT IGNORE INTO clubs (competition_id, name)
+SELECT DISTINCT competition_id, club FROM participants WHERE club <> '' AND club_id IS NULL;
+`
+
+// BackfillParticipantsClubIDQuery points the participants without club to the club they name
+const BackfillParticipantsClubIDQuery = `
+UPDATE participants p
+JOIN clubs c ON c.competition_id = p.competition_id AND c.name = p.club
+SET p.club_id = c.id
+WHERE p.club_id IS NULL AND p.club <> '';
+`
+
+// CreateTeamsTableQuery creates the teams table.
+// A team groups a few participants of a competition, ranked on the best scores of its members.
+const CreateTeamsTableQuery = `
+CREATE TABLE IF NOT EXISTS teams (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY (competition_id, name),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateTeamMembersTableQuery creates the team_members table.
+// A participant belongs to one team at most and leaves it when removed from the competition.
+const CreateTeamMembersTableQuery = `
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id INT NOT NULL,
+    competition_id INT NOT NULL,
+    dossard_number INT NOT NULL,
     PRIMARY KEY (competition_id, dossard_number),
+    KEY (team_id),
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
     FOREIGN KEY (competition_id, dossard_number) REFERENCES participants(competition_id, dossard_number) ON DELETE CASCADE
 );
 `
 
+// CreateDisplayDevicesTableQuery creates the display_devices table.
+// The display tokens carry the ID of their device, so revoking a device prevents its tokens from being refreshed.
+const CreateDisplayDevicesTableQuery = `
+CREATE TABLE IF NOT EXISTS display_devices (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_by INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NULL DEFAULT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    INDEX (competition_id),
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreatePendingRunsTableQuery creates the pending_runs table.
+// Chronos imported from timing systems without a run to merge into wait there for a referee to confirm the run,
+// they do not count in the rankings until then.
+const CreatePendingRunsTableQuery = `
+CREATE TABLE IF NOT EXISTS pending_runs (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    dossard INT NOT NULL,
+    zone VARCHAR(100) NOT NULL,
+    chrono_sec INT NOT NULL DEFAULT 0,
+    chrono_ms INT NOT NULL DEFAULT 0,
+    imported_by INT NOT NULL,
+    imported_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY pending_runs_chrono_ms (competition_id, dossard, zone, chrono_ms),
+    FOREIGN KEY (competition_id, dossard) REFERENCES participants(competition_id, dossard_number) ON DELETE CASCADE
+);
+`
+
+// AddPendingRunsChronoMsColumnQuery adds the imported chrono in milliseconds to pending_runs tables created before
+// it existed, MigrateChronoMs fills it in from the chrono in seconds
+const AddPendingRunsChronoMsColumnQuery = `
+ALTER TABLE pending_runs ADD COLUMN chrono_ms INT NOT NULL DEFAULT 0;
+`
+
+// BackfillPendingRunsChronoMsQuery converts the chronos in seconds of the pending runs imported before the
+// milliseconds existed
+const BackfillPendingRunsChronoMsQuery = `
+UPDATE pending_runs SET chrono_ms = chrono_sec * 1000 WHERE chrono_ms = 0 AND chrono_sec <> 0;
+`
+
+// AddPendingRunsChronoMsIndexQuery keeps the same chrono from being pending twice for a participant in a zone,
+// to the millisecond, in pending_runs tables created before the milliseconds existed
+const AddPendingRunsChronoMsIndexQuery = `
+ALTER TABLE pending_runs ADD UNIQUE INDEX pending_runs_chrono_ms (competition_id, dossard, zone, chrono_ms);
+`
+
+// DropPendingRunsChronoSecIndexQuery drops the unique key on the chrono in seconds of the pending_runs tables
+// created before the milliseconds existed, which would refuse two chronos within the same second
+const DropPendingRunsChronoSecIndexQuery = `
+ALTER TABLE pending_runs DROP INDEX competition_id;
+`
+
+// CreateRunRevisionsTableQuery creates the run_revisions table.
+// Every change of the values of a run is kept there with the values before and after it and its editor,
+// the revisions follow their run when the participant changes dossard and are removed with it.
+const CreateRunRevisionsTableQuery = `
+CREATE TABLE IF NOT EXISTS run_revisions (
+    id INT NOT NULL AUTO_INCREMENT,
+    competition_id INT NOT NULL,
+    dossard INT NOT NULL,
+    run_number INT NOT NULL,
+    editor_id INT NOT NULL DEFAULT 0,
+    old_zone VARCHAR(100) NOT NULL,
+    old_doors JSON NULL,
+    old_penality INT NOT NULL DEFAULT 0,
+    old_chrono_sec INT NOT NULL DEFAULT 0,
+    old_chrono_ms INT NOT NULL DEFAULT 0,
+    old_status VARCHAR(3) NOT NULL DEFAULT 'ok',
+    new_zone VARCHAR(100) NOT NULL,
+    new_doors JSON NULL,
+    new_penality INT NOT NULL DEFAULT 0,
+    new_chrono_sec INT NOT NULL DEFAULT 0,
+    new_chrono_ms INT NOT NULL DEFAULT 0,
+    new_status VARCHAR(3) NOT NULL DEFAULT 'ok',
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    INDEX run_revisions_run (competition_id, run_number, dossard),
+    FOREIGN KEY (competition_id, run_number, dossard) REFERENCES runs(competition_id, run_number, dossard) ON DELETE CASCADE ON UPDATE CASCADE
+);
+`
+
+// AddRunRevisionsOldChronoMsColumnQuery adds the previous chrono in milliseconds to run_revisions tables created
+// before it existed
+const AddRunRevisionsOldChronoMsColumnQuery = `
+ALTER TABLE run_revisions ADD COLUMN old_chrono_ms INT NOT NULL DEFAULT 0;
+`
+
+// AddRunRevisionsNewChronoMsColumnQuery adds the new chrono in milliseconds to run_revisions tables created
+// before it existed
+const AddRunRevisionsNewChronoMsColumnQuery = `
+ALTER TABLE run_revisions ADD COLUMN new_chrono_ms INT NOT NULL DEFAULT 0;
+`
+
+// BackfillRunRevisionsChronoMsQuery converts the chronos in seconds of the revisions recorded before the
+// milliseconds existed
+const BackfillRunRevisionsChronoMsQuery = `
+UPDATE run_revisions
+SET old_chrono_ms = IF(old_chrono_ms = 0, old_chrono_sec * 1000, old_chrono_ms),
+    new_chrono_ms = IF(new_chrono_ms = 0, new_chrono_sec * 1000, new_chrono_ms)
+WHERE (old_chrono_ms = 0 AND old_chrono_sec <> 0) OR (new_chrono_ms = 0 AND new_chrono_sec <> 0);
+`
+
+// CreateSeriesTableQuery creates the series table.
+// A series groups the competitions of a challenge whose earned points add up in one standing.
+const CreateSeriesTableQuery = `
+CREATE TABLE IF NOT EXISTS series (
+    id INT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_by INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+`
+
+// CreateSeriesCompetitionsTableQuery creates the series_competitions table, the competitions of each series
+const CreateSeriesCompetitionsTableQuery = `
+CREATE TABLE IF NOT EXISTS series_competitions (
+    series_id INT NOT NULL,
+    competition_id INT NOT NULL,
+    PRIMARY KEY (series_id, competition_id),
+    FOREIGN KEY (series_id) REFERENCES series(id) ON DELETE CASCADE,
+    FOREIGN KEY (competition_id) REFERENCES competitions(id) ON DELETE CASCADE
+);
+`
+
+// CreateRankingsViewQuery creates the rankings view, ranking the liverankings of each competition overall and within
+// each category and gender. It is replaced on every start, so that its order always follows aggregate.RankingOrder.
+// The participants who did not start are left out, those who did not finish then the disqualified ones are ranked last.
+var CreateRankingsViewQuery = `
+CREATE OR REPLACE VIEW rankings AS
+SELECT l.competition_id, l.dossard_number, p.first_name, p.last_name, p.category, p.gender, p.club, p.checked_in,
+       p.consent_data_processing, l.number_of_runs, l.total_points, l.penality, l.chrono_sec, l.chrono_ms, p.status, p.status_reason,
+       ROW_NUMBER() OVER (PARTITION BY l.competition_id ORDER BY FIELD(p.status, 'dnf', 'dsq'), ` + rankingOrderClause() + `) AS overall_rank,
+       ROW_NUMBER() OVER (PARTITION BY l.competition_id, p.category, p.gender ORDER BY FIELD(p.status, 'dnf', 'dsq'), ` + rankingOrderClause() + `) AS category_rank
+FROM liverankings l
+JOIN participants p ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
+WHERE p.status <> 'dns';
+`
+
 // DropLiverankingsTableQuery drops the liverankings table
 const DropLiverankingsTableQuery = `
 DROP TABLE IF EXISTS liverankings;
 `
 
+// CreateSchemaVersionTableQuery creates the schema_version table, holding in a single row the version of the schema
+// the database was last migrated to
+const CreateSchemaVersionTableQuery = `
+CREATE TABLE IF NOT EXISTS schema_version (
+    id TINYINT NOT NULL DEFAULT 1,
+    version INT NOT NULL,
+    migrated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+`
+
+// RecordSchemaVersionQuery records the version of the schema the database was migrated to.
+// The version is never lowered, so that a previous version of the service cannot hide a newer schema.
+const RecordSchemaVersionQuery = `
+INSERT INTO schema_version (id, version) VALUES (1, ?)
+ON DUPLICATE KEY UPDATE
+    migrated_at = IF(VALUES(version) > version, CURRENT_TIMESTAMP, migrated_at),
+    version = GREATEST(version, VALUES(version));
+`
+
 // SetupDatabase creates necessary tables for the application
 func SetupDatabase(db interface{}) error {
 	// The actual implementation depends on the database/sql package or ORM being used
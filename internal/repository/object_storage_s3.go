@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/config"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// S3ObjectStorage is an implementation of the ObjectStorageRepository interface for S3 compatible
+// object storages such as OVHcloud Object Storage. It signs its requests with AWS Signature Version 4
+// and addresses the bucket in the path, which every S3 compatible storage supports.
+type S3ObjectStorage struct {
+	endpoint      string
+	region        string
+	bucket        string
+	accessKey     string
+	secretKey     string
+	publicBaseURL string
+	httpClient    *http.Client
+}
+
+// NewS3ObjectStorage creates a new S3ObjectStorage for the bucket of the publication configuration
+func NewS3ObjectStorage(cfg config.PublicationConfig) repo.ObjectStorageRepository {
+	publicBaseURL := cfg.PublicBaseURL
+	if publicBaseURL == "" {
+		publicBaseURL = cfg.Endpoint + "/" + cfg.Bucket
+	}
+
+	return &S3ObjectStorage{
+		endpoint:      cfg.Endpoint,
+		region:        cfg.Region,
+		bucket:        cfg.Bucket,
+		accessKey:     cfg.AccessKey,
+		secretKey:     cfg.SecretKey,
+		publicBaseURL: publicBaseURL,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// PutObject uploads the object, replacing it if it exists
+func (s *S3ObjectStorage) PutObject(ctx context.Context, key, contentType string, content []byte) error {
+	endpoint, err := url.Parse(s.endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid object storage endpoint: %w", err)
+	}
+
+	path := "/" + s3URIEncode(s.bucket, false) + "/" + s3URIEncode(key, true)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.Scheme+"://"+endpoint.Host+path, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(content))
+
+	payloadHash := sha256.Sum256(content)
+	headers := map[string]string{
+		"content-type":         contentType,
+		"host":                 endpoint.Host,
+		"x-amz-content-sha256": hex.EncodeToString(payloadHash[:]),
+		"x-amz-date":           time.Now().UTC().Format("20060102T150405Z"),
+	}
+	for name, value := range headers {
+		if name != "host" {
+			req.Header.Set(name, value)
+		}
+	}
+	req.Header.Set("Authorization", s.authorization(http.MethodPut, path, headers))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: object storage responded %s: %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// GetObject downloads the object with its content type, or returns a nil content when it does not exist
+func (s *S3ObjectStorage) GetObject(ctx context.Context, key string) ([]byte, string, error) {
+	endpoint, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid object storage endpoint: %w", err)
+	}
+
+	path := "/" + s3URIEncode(s.bucket, false) + "/" + s3URIEncode(key, true)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.Scheme+"://"+endpoint.Host+path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	payloadHash := sha256.Sum256(nil)
+	headers := map[string]string{
+		"host":                 endpoint.Host,
+		"x-amz-content-sha256": hex.EncodeToString(payloadHash[:]),
+		"x-amz-date":           time.Now().UTC().Format("20060102T150405Z"),
+	}
+	for name, value := range headers {
+		if name != "host" {
+			req.Header.Set(name, value)
+		}
+	}
+	req.Header.Set("Authorization", s.authorization(http.MethodGet, path, headers))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, "", fmt.Errorf("failed to download %s: object storage responded %s: %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return content, resp.Header.Get("Content-Type"), nil
+}
+
+// ObjectURL returns the public URL the object is served from
+func (s *S3ObjectStorage) ObjectURL(key string) string {
+	return s.publicBaseURL + "/" + s3URIEncode(key, true)
+}
+
+// authorization returns the AWS Signature Version 4 authorization header of a request without query string
+func (s *S3ObjectStorage) authorization(method, path string, headers map[string]string) string {
+	amzDate := headers["x-amz-date"]
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", amzDate[:8], s.region)
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		path,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		headers["x-amz-content-sha256"],
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), amzDate[:8])
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKey, scope, signedHeaders, signature)
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3URIEncode percent-encodes every byte except the unreserved characters, and the slashes when keepSlash is set,
+// as required by the canonical request of the signature
+func s3URIEncode(value string, keepSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			encoded.WriteByte(c)
+		case c == '/' && keepSlash:
+			encoded.WriteByte(c)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+	return encoded.String()
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrOrganizationNotFound is returned when the organization does not exist
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrDuplicateOrganization is returned when another organization has the same name
+	ErrDuplicateOrganization = errors.New("an organization with this name already exists")
+)
+
+// SQLOrganizationRepository is an implementation of the OrganizationRepository interface that uses SQL
+type SQLOrganizationRepository struct {
+	db *sql.DB
+}
+
+// NewSQLOrganizationRepository creates a new SQLOrganizationRepository
+func NewSQLOrganizationRepository(db *sql.DB) repo.OrganizationRepository {
+	return &SQLOrganizationRepository{
+		db: db,
+	}
+}
+
+// Organization is an internal representation of an organization for DB operations
+type Organization struct {
+	ID        int32
+	Name      string
+	CreatedAt time.Time
+}
+
+// CreateOrganization creates a new organization and returns its ID
+func (r *SQLOrganizationRepository) CreateOrganization(ctx context.Context, organization *aggregate.Organization) (int32, error) {
+	query := `
+		INSERT INTO organizations (name)
+		VALUES (?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, organization.GetName())
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return 0, ErrDuplicateOrganization
+		}
+		return 0, err
+	}
+
+	// Get the auto-incremented ID
+	if id, err := result.LastInsertId(); err == nil {
+		organization.SetID(int32(id))
+	}
+
+	return organization.GetID(), nil
+}
+
+// GetOrganization retrieves an organization with its competitions
+func (r *SQLOrganizationRepository) GetOrganization(ctx context.Context, organizationID int32) (*aggregate.Organization, error) {
+	query := `
+		SELECT id, name, created_at
+		FROM organizations
+		WHERE id = ?
+	`
+
+	var organization Organization
+	err := r.db.QueryRowContext(ctx, query, organizationID).Scan(&organization.ID, &organization.Name, &organization.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	competitionIDs, err := r.ListOrganizationCompetitionIDs(ctx, []int32{organizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	organizationAggregate := mapToOrganizationAggregate(organization)
+	organizationAggregate.SetCompetitionIDs(competitionIDs)
+	return organizationAggregate, nil
+}
+
+// ListOrganizations retrieves every organization with its competitions, by name
+func (r *SQLOrganizationRepository) ListOrganizations(ctx context.Context) ([]*aggregate.Organization, error) {
+	query := `
+		SELECT id, name, created_at
+		FROM organizations
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []*aggregate.Organization{}
+	byID := make(map[int32]*aggregate.Organization)
+	for rows.Next() {
+		var organization Organization
+		if err := rows.Scan(&organization.ID, &organization.Name, &organization.CreatedAt); err != nil {
+			return nil, err
+		}
+		organizationAggregate := mapToOrganizationAggregate(organization)
+		organizations = append(organizations, organizationAggregate)
+		byID[organization.ID] = organizationAggregate
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	competitionRows, err := r.db.QueryContext(ctx, `
+		SELECT organization_id, id
+		FROM competitions
+		WHERE organization_id IS NOT NULL
+		ORDER BY date DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer competitionRows.Close()
+
+	for competitionRows.Next() {
+		var organizationID, competitionID int32
+		if err := competitionRows.Scan(&organizationID, &competitionID); err != nil {
+			return nil, err
+		}
+		if organization, ok := byID[organizationID]; ok {
+			organization.SetCompetitionIDs(append(organization.GetCompetitionIDs(), competitionID))
+		}
+	}
+
+	if err := competitionRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+// DeleteOrganization deletes an organization in one transaction: its competitions no longer belong to
+// any organization and the roles of its admins are removed
+func (r *SQLOrganizationRepository) DeleteOrganization(ctx context.Context, organizationID int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM organizations WHERE id = ?", organizationID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrOrganizationNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE competitions SET organization_id = NULL WHERE organization_id = ?", organizationID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM user_roles WHERE role = ?", aggregate.OrganizationAdminRole(organizationID))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetCompetitionOrganization sets the organization a competition belongs to, 0 detaching it from its organization
+func (r *SQLOrganizationRepository) SetCompetitionOrganization(ctx context.Context, competitionID, organizationID int32) error {
+	query := `
+		UPDATE competitions
+		SET organization_id = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, sql.NullInt32{Int32: organizationID, Valid: organizationID != 0}, competitionID)
+	return err
+}
+
+// ListOrganizationCompetitionIDs lists the competitions of the organizations, the latest first
+func (r *SQLOrganizationRepository) ListOrganizationCompetitionIDs(ctx context.Context, organizationIDs []int32) ([]int32, error) {
+	competitionIDs := []int32{}
+	if len(organizationIDs) == 0 {
+		return competitionIDs, nil
+	}
+
+	placeholders := make([]string, len(organizationIDs))
+	args := make([]interface{}, len(organizationIDs))
+	for i, id := range organizationIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := "SELECT id FROM competitions WHERE organization_id IN (" + strings.Join(placeholders, ",") + ") ORDER BY date DESC"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var competitionID int32
+		if err := rows.Scan(&competitionID); err != nil {
+			return nil, err
+		}
+		competitionIDs = append(competitionIDs, competitionID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return competitionIDs, nil
+}
+
+// mapToOrganizationAggregate maps an organization to its aggregate, without its competitions
+func mapToOrganizationAggregate(organization Organization) *aggregate.Organization {
+	organizationAggregate := aggregate.NewOrganization()
+	organizationAggregate.SetID(organization.ID)
+	organizationAggregate.SetName(organization.Name)
+	organizationAggregate.SetCreatedAt(organization.CreatedAt)
+	return organizationAggregate
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	repo "github.com/NiskuT/cross-api/internal/domain/repository"
@@ -39,12 +40,28 @@ type Participant struct {
 	Category      string
 	Gender        string
 	Club          string
+	ClubEmail     string
+	Licence       string
+
+	ConsentDataProcessing bool
+	ConsentPhotoRights    bool
+	Waitlisted            bool
+	WaitlistedAt          sql.NullTime
+	ClubID                sql.NullInt32
+	CheckedIn             bool
+	BirthDate             sql.NullTime
+	Notes                 string
+	Flags                 flagsColumn
+	Status                string
+	StatusReason          string
+	PhotoKey              string
 }
 
 // GetParticipant retrieves a participant by competition ID and dossard number
 func (r *SQLParticipantRepository) GetParticipant(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.Participant, error) {
 	query := `
-		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
 		FROM participants
 		WHERE competition_id = ? AND dossard_number = ?
 	`
@@ -59,6 +76,20 @@ func (r *SQLParticipantRepository) GetParticipant(ctx context.Context, competiti
 		&participant.Category,
 		&participant.Gender,
 		&participant.Club,
+		&participant.ClubEmail,
+		&participant.Licence,
+		&participant.ConsentDataProcessing,
+		&participant.ConsentPhotoRights,
+		&participant.Waitlisted,
+		&participant.WaitlistedAt,
+		&participant.ClubID,
+		&participant.CheckedIn,
+		&participant.BirthDate,
+		&participant.Notes,
+		&participant.Flags,
+		&participant.Status,
+		&participant.StatusReason,
+		&participant.PhotoKey,
 	)
 
 	if err != nil {
@@ -76,18 +107,45 @@ func (r *SQLParticipantRepository) GetParticipant(ctx context.Context, competiti
 	participantAggregate.SetCategory(participant.Category)
 	participantAggregate.SetGender(participant.Gender)
 	participantAggregate.SetClub(participant.Club)
+	participantAggregate.SetClubEmail(participant.ClubEmail)
+	participantAggregate.SetLicence(participant.Licence)
+	participantAggregate.SetConsentDataProcessing(participant.ConsentDataProcessing)
+	participantAggregate.SetConsentPhotoRights(participant.ConsentPhotoRights)
+	participantAggregate.SetWaitlisted(participant.Waitlisted)
+	participantAggregate.SetWaitlistedAt(participant.WaitlistedAt.Time)
+	participantAggregate.SetClubID(participant.ClubID.Int32)
+	participantAggregate.SetCheckedIn(participant.CheckedIn)
+	participantAggregate.SetBirthDate(participant.BirthDate.Time)
+	participantAggregate.SetNotes(participant.Notes)
+	participantAggregate.SetFlags(participant.Flags)
+	participantAggregate.SetStatus(participant.Status)
+	participantAggregate.SetStatusReason(participant.StatusReason)
+	participantAggregate.SetPhotoKey(participant.PhotoKey)
 
 	return participantAggregate, nil
 }
 
-// CreateParticipant creates a new participant
+// CreateParticipant creates a new participant, attached to the club it names, which is created when the competition
+// does not have it yet
 func (r *SQLParticipantRepository) CreateParticipant(ctx context.Context, participant *aggregate.Participant) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	clubID, err := attachClub(ctx, tx, participant.GetCompetitionID(), participant.GetClub())
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO participants (competition_id, dossard_number, first_name, last_name, category, gender, club)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO participants (competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, IF(?, CURRENT_TIMESTAMP(6), NULL), ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		participant.GetCompetitionID(),
@@ -97,6 +155,15 @@ func (r *SQLParticipantRepository) CreateParticipant(ctx context.Context, partic
 		participant.GetCategory(),
 		participant.GetGender(),
 		participant.GetClub(),
+		participant.GetClubEmail(),
+		participant.GetLicence(),
+		participant.GetConsentDataProcessing(),
+		participant.GetConsentPhotoRights(),
+		participant.IsWaitlisted(),
+		participant.IsWaitlisted(),
+		clubID,
+		participant.IsCheckedIn(),
+		toNullTime(participant.GetBirthDate()),
 	)
 
 	if err != nil {
@@ -107,18 +174,145 @@ func (r *SQLParticipantRepository) CreateParticipant(ctx context.Context, partic
 		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	participant.SetClubID(clubID.Int32)
 	return nil
 }
 
-// UpdateParticipant updates an existing participant
+// CreateParticipantsBatch creates participants of one competition with a multi-row INSERT in one transaction, each
+// attached to the club it names like CreateParticipant. The participants whose dossard is already taken are not
+// created and their dossards are returned, the dossards are locked until the end of the transaction so that they
+// cannot be taken in between.
+func (r *SQLParticipantRepository) CreateParticipantsBatch(ctx context.Context, participants []*aggregate.Participant) ([]int32, error) {
+	taken := []int32{}
+	if len(participants) == 0 {
+		return taken, nil
+	}
+	competitionID := participants[0].GetCompetitionID()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	args := []interface{}{competitionID}
+	for _, participant := range participants {
+		args = append(args, participant.GetDossardNumber())
+	}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT dossard_number
+		FROM participants
+		WHERE competition_id = ? AND dossard_number IN (?`+strings.Repeat(", ?", len(participants)-1)+`)
+		FOR UPDATE
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	takenDossards := make(map[int32]bool)
+	for rows.Next() {
+		var dossardNumber int32
+		if err := rows.Scan(&dossardNumber); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		takenDossards[dossardNumber] = true
+		taken = append(taken, dossardNumber)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	clubIDs := make(map[string]sql.NullInt32)
+	created := make([]*aggregate.Participant, 0, len(participants))
+	values := make([]string, 0, len(participants))
+	args = make([]interface{}, 0, len(participants)*16)
+	for _, participant := range participants {
+		if takenDossards[participant.GetDossardNumber()] {
+			continue
+		}
+
+		clubID, ok := clubIDs[participant.GetClub()]
+		if !ok {
+			clubID, err = attachClub(ctx, tx, competitionID, participant.GetClub())
+			if err != nil {
+				return nil, err
+			}
+			clubIDs[participant.GetClub()] = clubID
+		}
+		participant.SetClubID(clubID.Int32)
+
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, IF(?, CURRENT_TIMESTAMP(6), NULL), ?, ?, ?)")
+		args = append(args,
+			competitionID,
+			participant.GetDossardNumber(),
+			participant.GetFirstName(),
+			participant.GetLastName(),
+			participant.GetCategory(),
+			participant.GetGender(),
+			participant.GetClub(),
+			participant.GetClubEmail(),
+			participant.GetLicence(),
+			participant.GetConsentDataProcessing(),
+			participant.GetConsentPhotoRights(),
+			participant.IsWaitlisted(),
+			participant.IsWaitlisted(),
+			clubID,
+			participant.IsCheckedIn(),
+			toNullTime(participant.GetBirthDate()),
+		)
+		created = append(created, participant)
+	}
+
+	if len(created) > 0 {
+		query := `
+			INSERT INTO participants (competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+				consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date)
+			VALUES ` + strings.Join(values, ", ")
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			// The same dossard given twice in the batch
+			if isDuplicateKeyError(err) {
+				return nil, ErrDuplicateParticipant
+			}
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return taken, nil
+}
+
+// UpdateParticipant updates an existing participant, attached to the club it names like CreateParticipant
 func (r *SQLParticipantRepository) UpdateParticipant(ctx context.Context, participant *aggregate.Participant) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	clubID, err := attachClub(ctx, tx, participant.GetCompetitionID(), participant.GetClub())
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE participants
-		SET first_name = ?, last_name = ?, category = ?, gender = ?, club = ?
+		SET first_name = ?, last_name = ?, category = ?, gender = ?, club = ?, club_email = ?, licence = ?,
+			consent_data_processing = ?, consent_photo_rights = ?, waitlisted = ?,
+			waitlisted_at = IF(?, COALESCE(waitlisted_at, CURRENT_TIMESTAMP(6)), NULL), club_id = ?, checked_in = ?, birth_date = ?
 		WHERE competition_id = ? AND dossard_number = ?
 	`
 
-	result, err := r.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		participant.GetFirstName(),
@@ -126,6 +320,15 @@ func (r *SQLParticipantRepository) UpdateParticipant(ctx context.Context, partic
 		participant.GetCategory(),
 		participant.GetGender(),
 		participant.GetClub(),
+		participant.GetClubEmail(),
+		participant.GetLicence(),
+		participant.GetConsentDataProcessing(),
+		participant.GetConsentPhotoRights(),
+		participant.IsWaitlisted(),
+		participant.IsWaitlisted(),
+		clubID,
+		participant.IsCheckedIn(),
+		toNullTime(participant.GetBirthDate()),
 		participant.GetCompetitionID(),
 		participant.GetDossardNumber(),
 	)
@@ -143,6 +346,11 @@ func (r *SQLParticipantRepository) UpdateParticipant(ctx context.Context, partic
 		return ErrParticipantNotFound
 	}
 
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	participant.SetClubID(clubID.Int32)
 	return nil
 }
 
@@ -170,16 +378,654 @@ func (r *SQLParticipantRepository) DeleteParticipant(ctx context.Context, compet
 	return nil
 }
 
+// DeleteParticipantWithRecords deletes a participant with its runs, liveranking rows and pending runs in one
+// transaction and returns how many of them were deleted. The foreign keys would cascade the deletion, the records
+// are deleted first to count them.
+func (r *SQLParticipantRepository) DeleteParticipantWithRecords(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.ParticipantRecords, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	records := aggregate.NewParticipantRecords()
+	for _, deletion := range []struct {
+		query string
+		set   func(int32)
+	}{
+		{`DELETE FROM runs WHERE competition_id = ? AND dossard = ?`, records.SetRuns},
+		{`DELETE FROM liverankings WHERE competition_id = ? AND dossard_number = ?`, records.SetLiverankings},
+		{`DELETE FROM pending_runs WHERE competition_id = ? AND dossard = ?`, records.SetPendingRuns},
+	} {
+		result, err := tx.ExecContext(ctx, deletion.query, competitionID, dossardNumber)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		deletion.set(int32(rowsAffected))
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM participants
+		WHERE competition_id = ? AND dossard_number = ?
+	`, competitionID, dossardNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, ErrParticipantNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// CountParticipantRecords counts the runs, liveranking rows and pending runs of a participant
+func (r *SQLParticipantRepository) CountParticipantRecords(ctx context.Context, competitionID int32, dossardNumber int32) (*aggregate.ParticipantRecords, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM runs WHERE competition_id = p.competition_id AND dossard = p.dossard_number),
+			(SELECT COUNT(*) FROM liverankings WHERE competition_id = p.competition_id AND dossard_number = p.dossard_number),
+			(SELECT COUNT(*) FROM pending_runs WHERE competition_id = p.competition_id AND dossard = p.dossard_number)
+		FROM participants p
+		WHERE p.competition_id = ? AND p.dossard_number = ?
+	`
+
+	var runs, liverankings, pendingRuns int32
+	err := r.db.QueryRowContext(ctx, query, competitionID, dossardNumber).Scan(&runs, &liverankings, &pendingRuns)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, err
+	}
+
+	records := aggregate.NewParticipantRecords()
+	records.SetRuns(runs)
+	records.SetLiverankings(liverankings)
+	records.SetPendingRuns(pendingRuns)
+	return records, nil
+}
+
+// ClearParticipants deletes every participant of a competition with their runs, liveranking rows and pending runs
+// in one transaction and counts them
+func (r *SQLParticipantRepository) ClearParticipants(ctx context.Context, competitionID int32) (*aggregate.CompetitionParticipantRecords, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cleared := aggregate.NewCompetitionParticipantRecords()
+	records := cleared.GetRecords()
+	for _, deletion := range []struct {
+		query string
+		set   func(int32)
+	}{
+		{`DELETE FROM runs WHERE competition_id = ?`, records.SetRuns},
+		{`DELETE FROM liverankings WHERE competition_id = ?`, records.SetLiverankings},
+		{`DELETE FROM pending_runs WHERE competition_id = ?`, records.SetPendingRuns},
+		{`DELETE FROM participants WHERE competition_id = ?`, cleared.SetParticipants},
+	} {
+		result, err := tx.ExecContext(ctx, deletion.query, competitionID)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		deletion.set(int32(rowsAffected))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return cleared, nil
+}
+
+// CountCompetitionParticipantRecords counts the participants of a competition and their runs, liveranking rows and pending runs
+func (r *SQLParticipantRepository) CountCompetitionParticipantRecords(ctx context.Context, competitionID int32) (*aggregate.CompetitionParticipantRecords, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM participants WHERE competition_id = ?),
+			(SELECT COUNT(*) FROM runs WHERE competition_id = ?),
+			(SELECT COUNT(*) FROM liverankings WHERE competition_id = ?),
+			(SELECT COUNT(*) FROM pending_runs WHERE competition_id = ?)
+	`
+
+	var participants, runs, liverankings, pendingRuns int32
+	err := r.db.QueryRowContext(ctx, query, competitionID, competitionID, competitionID, competitionID).Scan(&participants, &runs, &liverankings, &pendingRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	counted := aggregate.NewCompetitionParticipantRecords()
+	counted.SetParticipants(participants)
+	counted.GetRecords().SetRuns(runs)
+	counted.GetRecords().SetLiverankings(liverankings)
+	counted.GetRecords().SetPendingRuns(pendingRuns)
+	return counted, nil
+}
+
+// RenumberParticipant changes the dossard number of a participant in one transaction.
+// The dossard is part of the runs and liverankings keys and their foreign keys do not cascade
+// updates, so the participant is copied to the new dossard, its rows are moved, then the old row is removed.
+func (r *SQLParticipantRepository) RenumberParticipant(ctx context.Context, competitionID int32, dossardNumber int32, newDossardNumber int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := moveParticipant(ctx, tx, competitionID, dossardNumber, newDossardNumber); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SwapParticipantDossards exchanges the dossard numbers of two participants with their runs, liverankings and
+// pending runs in one transaction, for the bibs handed to each other's participant.
+// The first participant waits on the negative of its dossard, which no participant uses, while the second one takes its place.
+func (r *SQLParticipantRepository) SwapParticipantDossards(ctx context.Context, competitionID int32, dossardNumber int32, otherDossardNumber int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := moveParticipant(ctx, tx, competitionID, dossardNumber, -dossardNumber); err != nil {
+		return err
+	}
+
+	if err := moveParticipant(ctx, tx, competitionID, otherDossardNumber, dossardNumber); err != nil {
+		return err
+	}
+
+	if err := moveParticipant(ctx, tx, competitionID, -dossardNumber, otherDossardNumber); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// moveParticipant copies a participant to a new dossard within the transaction, moves its runs, liveranking,
+// pending runs and team membership to it, then removes the old row.
+// It fails with ErrDuplicateParticipant when the new dossard is taken.
+func moveParticipant(ctx context.Context, tx *sql.Tx, competitionID int32, dossardNumber int32, newDossardNumber int32) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO participants (competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key)
+		SELECT competition_id, ?, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
+		FROM participants
+		WHERE competition_id = ? AND dossard_number = ?
+	`, newDossardNumber, competitionID, dossardNumber)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateParticipant
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrParticipantNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE runs
+		SET dossard = ?
+		WHERE competition_id = ? AND dossard = ?
+	`, newDossardNumber, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE liverankings
+		SET dossard_number = ?
+		WHERE competition_id = ? AND dossard_number = ?
+	`, newDossardNumber, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	// The pending runs and team memberships would otherwise be removed with the old row by their cascading foreign key
+	_, err = tx.ExecContext(ctx, `
+		UPDATE pending_runs
+		SET dossard = ?
+		WHERE competition_id = ? AND dossard = ?
+	`, newDossardNumber, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE team_members
+		SET dossard_number = ?
+		WHERE competition_id = ? AND dossard_number = ?
+	`, newDossardNumber, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM participants
+		WHERE competition_id = ? AND dossard_number = ?
+	`, competitionID, dossardNumber)
+	return err
+}
+
 // ListParticipantsByCategory retrieves all participants for a competition by category
 func (r *SQLParticipantRepository) ListParticipantsByCategory(ctx context.Context, competitionID int32, category string) ([]*aggregate.Participant, error) {
 	query := `
-		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
 		FROM participants
 		WHERE competition_id = ? AND category = ?
 		ORDER BY dossard_number
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, competitionID, category)
+	return r.queryParticipants(ctx, query, competitionID, category)
+}
+
+// SearchParticipants lists a page of the participants of a competition matching the filter, ordered by dossard, and counts all of them
+func (r *SQLParticipantRepository) SearchParticipants(ctx context.Context, competitionID int32, filter *aggregate.ParticipantFilter, pageNumber, pageSize int32) ([]*aggregate.Participant, int32, error) {
+	if pageSize <= 0 {
+		pageSize = 10 // Default page size
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1 // Default page number
+	}
+
+	where := " WHERE competition_id = ?"
+	args := []interface{}{competitionID}
+	if filter.GetCategory() != "" {
+		where += " AND category = ?"
+		args = append(args, filter.GetCategory())
+	}
+	if filter.GetGender() != "" {
+		where += " AND gender = ?"
+		args = append(args, filter.GetGender())
+	}
+	if filter.GetClub() != "" {
+		where += " AND club = ?"
+		args = append(args, filter.GetClub())
+	}
+	if filter.GetSearch() != "" {
+		// Escape LIKE wildcards so they match literally
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.GetSearch()) + "%"
+		where += " AND (first_name LIKE ? OR last_name LIKE ? OR CONCAT(first_name, ' ', last_name) LIKE ? OR CONCAT(last_name, ' ', first_name) LIKE ?)"
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+
+	var totalCount int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM participants"+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+
+	query := `
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
+		FROM participants` + where + `
+		ORDER BY dossard_number
+		LIMIT ? OFFSET ?
+	`
+
+	participants, err := r.queryParticipants(ctx, query, append(args, pageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return participants, totalCount, nil
+}
+
+// SearchParticipantsByName lists the participants whose first or last name starts with every term of the query,
+// the exact last names first. The prefixes are matched on the name indexes of the participants.
+func (r *SQLParticipantRepository) SearchParticipantsByName(ctx context.Context, competitionID int32, query string, limit int32) ([]*aggregate.Participant, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return []*aggregate.Participant{}, nil
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	// Escape LIKE wildcards so they match literally
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+	where := " WHERE competition_id = ?"
+	args := []interface{}{competitionID}
+	exactArgs := make([]interface{}, len(terms))
+	for i, term := range terms {
+		pattern := escaper.Replace(term) + "%"
+		where += " AND (last_name LIKE ? OR first_name LIKE ?)"
+		args = append(args, pattern, pattern)
+		exactArgs[i] = term
+	}
+	args = append(args, exactArgs...)
+
+	sqlQuery := `
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
+		FROM participants` + where + `
+		ORDER BY last_name IN (?` + strings.Repeat(", ?", len(terms)-1) + `) DESC, last_name, first_name, dossard_number
+		LIMIT ?
+	`
+
+	return r.queryParticipants(ctx, sqlQuery, append(args, limit)...)
+}
+
+// CountRegisteredParticipants counts the participants of a competition who are not waitlisted
+func (r *SQLParticipantRepository) CountRegisteredParticipants(ctx context.Context, competitionID int32) (int32, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM participants
+		WHERE competition_id = ? AND waitlisted = false
+	`
+
+	var count int32
+	err := r.db.QueryRowContext(ctx, query, competitionID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SetParticipantCheckedIn confirms or withdraws the presence of a participant at the start desk
+func (r *SQLParticipantRepository) SetParticipantCheckedIn(ctx context.Context, competitionID int32, dossardNumber int32, checkedIn bool) error {
+	query := `
+		UPDATE participants
+		SET checked_in = ?
+		WHERE competition_id = ? AND dossard_number = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, checkedIn, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	// Setting the flag to its current value affects no row, so the participant is looked up to tell it from a missing one
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.GetParticipant(ctx, competitionID, dossardNumber)
+		return err
+	}
+
+	return nil
+}
+
+// SetParticipantNotes replaces the notes and the flags of a participant shown to the referees
+func (r *SQLParticipantRepository) SetParticipantNotes(ctx context.Context, competitionID int32, dossardNumber int32, notes string, flags []string) error {
+	query := `
+		UPDATE participants
+		SET notes = ?, flags = ?
+		WHERE competition_id = ? AND dossard_number = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, notes, flagsColumn(flags), competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	// Setting the notes to their current value affects no row, so the participant is looked up to tell it from a missing one
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.GetParticipant(ctx, competitionID, dossardNumber)
+		return err
+	}
+
+	return nil
+}
+
+// SetParticipantStatus sets the status of a participant and the reason of it
+func (r *SQLParticipantRepository) SetParticipantStatus(ctx context.Context, competitionID int32, dossardNumber int32, status string, reason string) error {
+	query := `
+		UPDATE participants
+		SET status = ?, status_reason = ?
+		WHERE competition_id = ? AND dossard_number = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, reason, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	// Setting the status to its current value affects no row, so the participant is looked up to tell it from a missing one
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.GetParticipant(ctx, competitionID, dossardNumber)
+		return err
+	}
+
+	return nil
+}
+
+// SetParticipantPhoto sets the object key of the photo of a participant
+func (r *SQLParticipantRepository) SetParticipantPhoto(ctx context.Context, competitionID int32, dossardNumber int32, photoKey string) error {
+	query := `
+		UPDATE participants
+		SET photo_key = ?
+		WHERE competition_id = ? AND dossard_number = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, photoKey, competitionID, dossardNumber)
+	if err != nil {
+		return err
+	}
+
+	// Setting the photo to its current key affects no row, so the participant is looked up to tell it from a missing one
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.GetParticipant(ctx, competitionID, dossardNumber)
+		return err
+	}
+
+	return nil
+}
+
+// CountCheckIns counts the registered and the checked in participants of each category of a competition, by category.
+// The waitlisted participants do not start and are not counted.
+func (r *SQLParticipantRepository) CountCheckIns(ctx context.Context, competitionID int32) ([]*aggregate.CheckInCount, error) {
+	query := `
+		SELECT category, COUNT(*), COALESCE(SUM(checked_in), 0)
+		FROM participants
+		WHERE competition_id = ? AND waitlisted = false
+		GROUP BY category
+		ORDER BY category
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*aggregate.CheckInCount
+	for rows.Next() {
+		var category string
+		var registered, checkedIn int32
+		if err := rows.Scan(&category, &registered, &checkedIn); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, aggregate.NewCheckInCount(category, registered, checkedIn))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// ListParticipants retrieves all participants of a competition
+func (r *SQLParticipantRepository) ListParticipants(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error) {
+	query := `
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
+		FROM participants
+		WHERE competition_id = ?
+		ORDER BY dossard_number
+	`
+
+	return r.queryParticipants(ctx, query, competitionID)
+}
+
+// ListWaitlistedParticipants lists the waitlisted participants of a competition in the order they entered the waitlist,
+// those waitlisted before it was recorded first
+func (r *SQLParticipantRepository) ListWaitlistedParticipants(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error) {
+	query := `
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
+		FROM participants
+		WHERE competition_id = ? AND waitlisted = true
+		ORDER BY waitlisted_at, dossard_number
+	`
+
+	return r.queryParticipants(ctx, query, competitionID)
+}
+
+// PromoteWaitlistedParticipant gives a place to a waitlisted participant in one transaction and returns its dossard.
+// The participant takes the first free dossard after the highest one of the registered participants, its runs,
+// liveranking and pending runs moved along like RenumberParticipant.
+func (r *SQLParticipantRepository) PromoteWaitlistedParticipant(ctx context.Context, competitionID int32, dossardNumber int32) (int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Locking the competition keeps two promotions from giving out the same dossard
+	var locked int32
+	err = tx.QueryRowContext(ctx, "SELECT id FROM competitions WHERE id = ? FOR UPDATE", competitionID).Scan(&locked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrCompetitionNotFound
+		}
+		return 0, err
+	}
+
+	var newDossardNumber int32
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(dossard_number), 0) + 1
+		FROM participants
+		WHERE competition_id = ? AND waitlisted = false
+	`, competitionID).Scan(&newDossardNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	// The dossards after the registered participants may be held by other waitlisted participants
+	for newDossardNumber != dossardNumber {
+		var taken bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS(
+				SELECT 1 FROM participants
+				WHERE competition_id = ? AND dossard_number = ?
+			)
+		`, competitionID, newDossardNumber).Scan(&taken)
+		if err != nil {
+			return 0, err
+		}
+		if !taken {
+			break
+		}
+		newDossardNumber++
+	}
+
+	if newDossardNumber != dossardNumber {
+		if err := moveParticipant(ctx, tx, competitionID, dossardNumber, newDossardNumber); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE participants
+		SET waitlisted = false, waitlisted_at = NULL
+		WHERE competition_id = ? AND dossard_number = ?
+	`, competitionID, newDossardNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		return 0, ErrParticipantNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return newDossardNumber, nil
+}
+
+// ListParticipantEntries lists the entries of a person across the competitions, recognised by their licence number
+// or by their full name in either order. Either can be empty, the waitlisted entries are left out.
+func (r *SQLParticipantRepository) ListParticipantEntries(ctx context.Context, licence string, name string) ([]*aggregate.Participant, error) {
+	query := `
+		SELECT competition_id, dossard_number, first_name, last_name, category, gender, club, club_email, licence,
+			consent_data_processing, consent_photo_rights, waitlisted, waitlisted_at, club_id, checked_in, birth_date, notes, flags, status, status_reason, photo_key
+		FROM participants
+		WHERE waitlisted = false AND (
+			(? <> '' AND UPPER(TRIM(licence)) = UPPER(?))
+			OR (? <> '' AND (CONCAT(TRIM(first_name), ' ', TRIM(last_name)) = ? OR CONCAT(TRIM(last_name), ' ', TRIM(first_name)) = ?))
+		)
+		ORDER BY competition_id, dossard_number
+	`
+
+	return r.queryParticipants(ctx, query, licence, licence, name, name, name)
+}
+
+// queryParticipants runs a query selecting participants and maps the rows to aggregates
+func (r *SQLParticipantRepository) queryParticipants(ctx context.Context, query string, args ...interface{}) ([]*aggregate.Participant, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -196,6 +1042,20 @@ func (r *SQLParticipantRepository) ListParticipantsByCategory(ctx context.Contex
 			&participant.Category,
 			&participant.Gender,
 			&participant.Club,
+			&participant.ClubEmail,
+			&participant.Licence,
+			&participant.ConsentDataProcessing,
+			&participant.ConsentPhotoRights,
+			&participant.Waitlisted,
+			&participant.WaitlistedAt,
+			&participant.ClubID,
+			&participant.CheckedIn,
+			&participant.BirthDate,
+			&participant.Notes,
+			&participant.Flags,
+			&participant.Status,
+			&participant.StatusReason,
+			&participant.PhotoKey,
 		)
 
 		if err != nil {
@@ -210,6 +1070,20 @@ func (r *SQLParticipantRepository) ListParticipantsByCategory(ctx context.Contex
 		participantAggregate.SetCategory(participant.Category)
 		participantAggregate.SetGender(participant.Gender)
 		participantAggregate.SetClub(participant.Club)
+		participantAggregate.SetClubEmail(participant.ClubEmail)
+		participantAggregate.SetLicence(participant.Licence)
+		participantAggregate.SetConsentDataProcessing(participant.ConsentDataProcessing)
+		participantAggregate.SetConsentPhotoRights(participant.ConsentPhotoRights)
+		participantAggregate.SetWaitlisted(participant.Waitlisted)
+		participantAggregate.SetWaitlistedAt(participant.WaitlistedAt.Time)
+		participantAggregate.SetClubID(participant.ClubID.Int32)
+		participantAggregate.SetCheckedIn(participant.CheckedIn)
+		participantAggregate.SetBirthDate(participant.BirthDate.Time)
+		participantAggregate.SetNotes(participant.Notes)
+		participantAggregate.SetFlags(participant.Flags)
+		participantAggregate.SetStatus(participant.Status)
+		participantAggregate.SetStatusReason(participant.StatusReason)
+		participantAggregate.SetPhotoKey(participant.PhotoKey)
 
 		participants = append(participants, participantAggregate)
 	}
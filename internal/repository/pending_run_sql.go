@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrPendingRunNotFound is returned when a pending run cannot be found
+	ErrPendingRunNotFound = errors.New("pending run not found")
+)
+
+// PendingRun is an internal representation of a pending run for DB operations
+type PendingRun struct {
+	ID            int32
+	CompetitionID int32
+	Dossard       int32
+	Zone          string
+	ChronoMs      int32
+	ImportedBy    int32
+	ImportedAt    time.Time
+}
+
+// SQLPendingRunRepository is an implementation of the PendingRunRepository interface that uses SQL
+type SQLPendingRunRepository struct {
+	db *sql.DB
+}
+
+// NewSQLPendingRunRepository creates a new SQLPendingRunRepository
+func NewSQLPendingRunRepository(db *sql.DB) repo.PendingRunRepository {
+	return &SQLPendingRunRepository{
+		db: db,
+	}
+}
+
+// CreatePendingRun stores an imported chrono waiting for its run to be confirmed and sets its ID.
+// It returns false without error when the same chrono is already pending for the participant in the zone,
+// so that importing a file again does not duplicate its pending runs.
+func (r *SQLPendingRunRepository) CreatePendingRun(ctx context.Context, pendingRun *aggregate.PendingRun) (bool, error) {
+	query := `
+		INSERT INTO pending_runs (competition_id, dossard, zone, chrono_sec, chrono_ms, imported_by, imported_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		pendingRun.GetCompetitionID(),
+		pendingRun.GetDossard(),
+		pendingRun.GetZone(),
+		pendingRun.GetChronoSec(),
+		pendingRun.GetChronoMs(),
+		pendingRun.GetImportedBy(),
+		pendingRun.GetImportedAt(),
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+	pendingRun.SetID(int32(id))
+
+	return true, nil
+}
+
+// GetPendingRun retrieves a pending run of the competition by its ID
+func (r *SQLPendingRunRepository) GetPendingRun(ctx context.Context, competitionID, id int32) (*aggregate.PendingRun, error) {
+	query := `
+		SELECT id, competition_id, dossard, zone, chrono_ms, imported_by, imported_at
+		FROM pending_runs
+		WHERE competition_id = ? AND id = ?
+	`
+
+	var pendingRun PendingRun
+	err := r.db.QueryRowContext(ctx, query, competitionID, id).Scan(
+		&pendingRun.ID,
+		&pendingRun.CompetitionID,
+		&pendingRun.Dossard,
+		&pendingRun.Zone,
+		&pendingRun.ChronoMs,
+		&pendingRun.ImportedBy,
+		&pendingRun.ImportedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPendingRunNotFound
+		}
+		return nil, err
+	}
+
+	return mapToPendingRunAggregate(pendingRun), nil
+}
+
+// ListPendingRuns lists the pending runs of the competition by zone and dossard
+func (r *SQLPendingRunRepository) ListPendingRuns(ctx context.Context, competitionID int32) ([]*aggregate.PendingRun, error) {
+	query := `
+		SELECT id, competition_id, dossard, zone, chrono_ms, imported_by, imported_at
+		FROM pending_runs
+		WHERE competition_id = ?
+		ORDER BY zone, dossard, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pendingRuns := []*aggregate.PendingRun{}
+	for rows.Next() {
+		var pendingRun PendingRun
+		err := rows.Scan(
+			&pendingRun.ID,
+			&pendingRun.CompetitionID,
+			&pendingRun.Dossard,
+			&pendingRun.Zone,
+			&pendingRun.ChronoMs,
+			&pendingRun.ImportedBy,
+			&pendingRun.ImportedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pendingRuns = append(pendingRuns, mapToPendingRunAggregate(pendingRun))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pendingRuns, nil
+}
+
+// DeletePendingRun deletes a pending run of the competition, once its run is confirmed or when it is discarded
+func (r *SQLPendingRunRepository) DeletePendingRun(ctx context.Context, competitionID, id int32) error {
+	query := `DELETE FROM pending_runs WHERE competition_id = ? AND id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrPendingRunNotFound
+	}
+
+	return nil
+}
+
+// Helper function to map a PendingRun struct to a PendingRun aggregate
+func mapToPendingRunAggregate(pendingRun PendingRun) *aggregate.PendingRun {
+	pendingRunAggregate := aggregate.NewPendingRun()
+	pendingRunAggregate.SetID(pendingRun.ID)
+	pendingRunAggregate.SetCompetitionID(pendingRun.CompetitionID)
+	pendingRunAggregate.SetDossard(pendingRun.Dossard)
+	pendingRunAggregate.SetZone(pendingRun.Zone)
+	pendingRunAggregate.SetChronoMs(pendingRun.ChronoMs)
+	pendingRunAggregate.SetImportedBy(pendingRun.ImportedBy)
+	pendingRunAggregate.SetImportedAt(pendingRun.ImportedAt)
+	return pendingRunAggregate
+}
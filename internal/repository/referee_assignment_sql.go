@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrRefereeAssignmentNotFound is returned when the referee is not assigned to the zone
+	ErrRefereeAssignmentNotFound = errors.New("the referee is not assigned to this zone")
+	// ErrDuplicateRefereeAssignment is returned when the referee is already assigned to the zone
+	ErrDuplicateRefereeAssignment = errors.New("the referee is already assigned to this zone")
+)
+
+// SQLRefereeAssignmentRepository is an implementation of the RefereeAssignmentRepository interface that uses SQL
+type SQLRefereeAssignmentRepository struct {
+	db *sql.DB
+}
+
+// NewSQLRefereeAssignmentRepository creates a new SQLRefereeAssignmentRepository
+func NewSQLRefereeAssignmentRepository(db *sql.DB) repo.RefereeAssignmentRepository {
+	return &SQLRefereeAssignmentRepository{
+		db: db,
+	}
+}
+
+// RefereeAssignment is an internal representation of the assignment of a referee for DB operations
+type RefereeAssignment struct {
+	CompetitionID int32
+	UserID        int32
+	Zone          string
+	AssignedBy    int32
+	AssignedAt    time.Time
+}
+
+// AssignReferee assigns a referee to a zone of the competition
+func (r *SQLRefereeAssignmentRepository) AssignReferee(ctx context.Context, assignment *aggregate.RefereeAssignment) error {
+	query := `
+		INSERT INTO referee_assignments (competition_id, user_id, zone, assigned_by, assigned_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		assignment.GetCompetitionID(),
+		assignment.GetUserID(),
+		assignment.GetZone(),
+		assignment.GetAssignedBy(),
+		assignment.GetAssignedAt(),
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateRefereeAssignment
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UnassignReferee removes the assignment of a referee to a zone of the competition
+func (r *SQLRefereeAssignmentRepository) UnassignReferee(ctx context.Context, competitionID, userID int32, zone string) error {
+	query := `
+		DELETE FROM referee_assignments
+		WHERE competition_id = ? AND user_id = ? AND zone = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, userID, zone)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRefereeAssignmentNotFound
+	}
+
+	return nil
+}
+
+// ListRefereeAssignments lists the assignments of the referees of the competition by zone
+func (r *SQLRefereeAssignmentRepository) ListRefereeAssignments(ctx context.Context, competitionID int32) ([]*aggregate.RefereeAssignment, error) {
+	query := `
+		SELECT competition_id, user_id, zone, assigned_by, assigned_at
+		FROM referee_assignments
+		WHERE competition_id = ?
+		ORDER BY zone, assigned_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []*aggregate.RefereeAssignment{}
+	for rows.Next() {
+		var assignment RefereeAssignment
+		err := rows.Scan(
+			&assignment.CompetitionID,
+			&assignment.UserID,
+			&assignment.Zone,
+			&assignment.AssignedBy,
+			&assignment.AssignedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, mapToRefereeAssignmentAggregate(assignment))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// ListRefereeZones lists the zones a referee of the competition is assigned to
+func (r *SQLRefereeAssignmentRepository) ListRefereeZones(ctx context.Context, competitionID, userID int32) ([]string, error) {
+	query := `
+		SELECT zone
+		FROM referee_assignments
+		WHERE competition_id = ? AND user_id = ?
+		ORDER BY zone
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []string{}
+	for rows.Next() {
+		var zone string
+		if err := rows.Scan(&zone); err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// Helper function to map a RefereeAssignment struct to a RefereeAssignment aggregate
+func mapToRefereeAssignmentAggregate(assignment RefereeAssignment) *aggregate.RefereeAssignment {
+	assignmentAggregate := aggregate.NewRefereeAssignment()
+	assignmentAggregate.SetCompetitionID(assignment.CompetitionID)
+	assignmentAggregate.SetUserID(assignment.UserID)
+	assignmentAggregate.SetZone(assignment.Zone)
+	assignmentAggregate.SetAssignedBy(assignment.AssignedBy)
+	assignmentAggregate.SetAssignedAt(assignment.AssignedAt)
+	return assignmentAggregate
+}
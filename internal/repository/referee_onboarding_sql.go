@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLRefereeOnboardingRepository is an implementation of the RefereeOnboardingRepository interface that uses SQL
+type SQLRefereeOnboardingRepository struct {
+	db *sql.DB
+}
+
+// NewSQLRefereeOnboardingRepository creates a new SQLRefereeOnboardingRepository
+func NewSQLRefereeOnboardingRepository(db *sql.DB) repo.RefereeOnboardingRepository {
+	return &SQLRefereeOnboardingRepository{
+		db: db,
+	}
+}
+
+// RefereeOnboarding is an internal representation of the onboarding of a referee for DB operations
+type RefereeOnboarding struct {
+	CompetitionID        int32
+	UserID               int32
+	InvitationAcceptedAt sql.NullTime
+	RulesReadAt          sql.NullTime
+	ConfirmedZone        string
+	ZoneConfirmedAt      sql.NullTime
+	TestRunAt            sql.NullTime
+}
+
+// GetRefereeOnboarding retrieves the onboarding of a referee of the competition, nil when they have not started it
+func (r *SQLRefereeOnboardingRepository) GetRefereeOnboarding(ctx context.Context, competitionID, userID int32) (*aggregate.RefereeOnboarding, error) {
+	query := `
+		SELECT competition_id, user_id, invitation_accepted_at, rules_read_at, confirmed_zone, zone_confirmed_at, test_run_at
+		FROM referee_onboardings
+		WHERE competition_id = ? AND user_id = ?
+	`
+
+	var onboarding RefereeOnboarding
+	err := r.db.QueryRowContext(ctx, query, competitionID, userID).Scan(
+		&onboarding.CompetitionID,
+		&onboarding.UserID,
+		&onboarding.InvitationAcceptedAt,
+		&onboarding.RulesReadAt,
+		&onboarding.ConfirmedZone,
+		&onboarding.ZoneConfirmedAt,
+		&onboarding.TestRunAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mapToRefereeOnboardingAggregate(onboarding), nil
+}
+
+// ListRefereeOnboardings lists the onboardings the referees of the competition started
+func (r *SQLRefereeOnboardingRepository) ListRefereeOnboardings(ctx context.Context, competitionID int32) ([]*aggregate.RefereeOnboarding, error) {
+	query := `
+		SELECT competition_id, user_id, invitation_accepted_at, rules_read_at, confirmed_zone, zone_confirmed_at, test_run_at
+		FROM referee_onboardings
+		WHERE competition_id = ?
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	onboardings := []*aggregate.RefereeOnboarding{}
+	for rows.Next() {
+		var onboarding RefereeOnboarding
+		err := rows.Scan(
+			&onboarding.CompetitionID,
+			&onboarding.UserID,
+			&onboarding.InvitationAcceptedAt,
+			&onboarding.RulesReadAt,
+			&onboarding.ConfirmedZone,
+			&onboarding.ZoneConfirmedAt,
+			&onboarding.TestRunAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		onboardings = append(onboardings, mapToRefereeOnboardingAggregate(onboarding))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return onboardings, nil
+}
+
+// SetRefereeOnboarding stores the onboarding of a referee, replacing the previous state
+func (r *SQLRefereeOnboardingRepository) SetRefereeOnboarding(ctx context.Context, onboarding *aggregate.RefereeOnboarding) error {
+	query := `
+		INSERT INTO referee_onboardings (competition_id, user_id, invitation_accepted_at, rules_read_at, confirmed_zone, zone_confirmed_at, test_run_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			invitation_accepted_at = VALUES(invitation_accepted_at),
+			rules_read_at = VALUES(rules_read_at),
+			confirmed_zone = VALUES(confirmed_zone),
+			zone_confirmed_at = VALUES(zone_confirmed_at),
+			test_run_at = VALUES(test_run_at)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		onboarding.GetCompetitionID(),
+		onboarding.GetUserID(),
+		toNullTime(onboarding.GetInvitationAcceptedAt()),
+		toNullTime(onboarding.GetRulesReadAt()),
+		onboarding.GetConfirmedZone(),
+		toNullTime(onboarding.GetZoneConfirmedAt()),
+		toNullTime(onboarding.GetTestRunAt()),
+	)
+	return err
+}
+
+// toNullTime stores a zero time as NULL
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// Helper function to map a RefereeOnboarding struct to a RefereeOnboarding aggregate
+func mapToRefereeOnboardingAggregate(onboarding RefereeOnboarding) *aggregate.RefereeOnboarding {
+	onboardingAggregate := aggregate.NewRefereeOnboarding()
+	onboardingAggregate.SetCompetitionID(onboarding.CompetitionID)
+	onboardingAggregate.SetUserID(onboarding.UserID)
+	onboardingAggregate.SetInvitationAcceptedAt(onboarding.InvitationAcceptedAt.Time)
+	onboardingAggregate.SetRulesReadAt(onboarding.RulesReadAt.Time)
+	onboardingAggregate.SetConfirmedZone(onboarding.ConfirmedZone, onboarding.ZoneConfirmedAt.Time)
+	onboardingAggregate.SetTestRunAt(onboarding.TestRunAt.Time)
+	return onboardingAggregate
+}
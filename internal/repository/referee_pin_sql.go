@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrRefereePinNotFound is returned when a referee PIN cannot be found
+	ErrRefereePinNotFound = errors.New("referee PIN not found")
+	// ErrDuplicateRefereePin is returned when another referee of the competition already has the PIN
+	ErrDuplicateRefereePin = errors.New("referee PIN already used in the competition")
+)
+
+// SQLRefereePinRepository is an implementation of the RefereePinRepository interface that uses SQL
+type SQLRefereePinRepository struct {
+	db *sql.DB
+}
+
+// NewSQLRefereePinRepository creates a new SQLRefereePinRepository
+func NewSQLRefereePinRepository(db *sql.DB) repo.RefereePinRepository {
+	return &SQLRefereePinRepository{
+		db: db,
+	}
+}
+
+// RefereePin is an internal representation of a referee PIN for DB operations
+type RefereePin struct {
+	CompetitionID int32
+	UserID        int32
+	PinHash       string
+	CreatedBy     int32
+	CreatedAt     time.Time
+}
+
+// SetRefereePin stores the PIN of a referee, replacing the previous one
+func (r *SQLRefereePinRepository) SetRefereePin(ctx context.Context, pin *aggregate.RefereePin) error {
+	query := `
+		INSERT INTO referee_pins (competition_id, user_id, pin_hash, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE pin_hash = VALUES(pin_hash), created_by = VALUES(created_by), created_at = VALUES(created_at)
+	`
+
+	createdAt := pin.GetCreatedAt()
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+		pin.SetCreatedAt(createdAt)
+	}
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		pin.GetCompetitionID(),
+		pin.GetUserID(),
+		pin.GetPinHash(),
+		pin.GetCreatedBy(),
+		createdAt,
+	)
+	if err != nil {
+		// The unique key on the PIN hash is the only one left to violate after the upsert
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateRefereePin
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetRefereePinByHash retrieves the PIN of a competition by its hash
+func (r *SQLRefereePinRepository) GetRefereePinByHash(ctx context.Context, competitionID int32, pinHash string) (*aggregate.RefereePin, error) {
+	query := `
+		SELECT competition_id, user_id, pin_hash, created_by, created_at
+		FROM referee_pins
+		WHERE competition_id = ? AND pin_hash = ?
+	`
+
+	var pin RefereePin
+	row := r.db.QueryRowContext(ctx, query, competitionID, pinHash)
+	err := row.Scan(
+		&pin.CompetitionID,
+		&pin.UserID,
+		&pin.PinHash,
+		&pin.CreatedBy,
+		&pin.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRefereePinNotFound
+		}
+		return nil, err
+	}
+
+	pinAggregate := aggregate.NewRefereePin()
+	pinAggregate.SetCompetitionID(pin.CompetitionID)
+	pinAggregate.SetUserID(pin.UserID)
+	pinAggregate.SetPinHash(pin.PinHash)
+	pinAggregate.SetCreatedBy(pin.CreatedBy)
+	pinAggregate.SetCreatedAt(pin.CreatedAt)
+
+	return pinAggregate, nil
+}
+
+// DeleteRefereePin deletes the PIN of a referee
+func (r *SQLRefereePinRepository) DeleteRefereePin(ctx context.Context, competitionID, userID int32) error {
+	query := `
+		DELETE FROM referee_pins
+		WHERE competition_id = ? AND user_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRefereePinNotFound
+	}
+
+	return nil
+}
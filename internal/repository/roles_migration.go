@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// legacyRolesMaxLength is the size of the legacy users.roles column
+const legacyRolesMaxLength = 500
+
+// RolesVerification is the outcome of the verification of the roles migration
+type RolesVerification struct {
+	MigratedUsers int                // users whose legacy roles were moved to user_roles
+	LegacyUsers   []int32            // users still having roles in the legacy column
+	MissingRoles  map[int32][]string // legacy roles of migrated users absent from user_roles
+}
+
+// IsValid returns whether every legacy role was found in user_roles
+func (v *RolesVerification) IsValid() bool {
+	return len(v.LegacyUsers) == 0 && len(v.MissingRoles) == 0
+}
+
+// MigrateUserRoles copies the comma-separated users.roles column into the user_roles table, keeps a copy
+// in user_roles_backup and empties the column. Users already migrated have an empty column, so running
+// it again does nothing. It returns the number of users migrated.
+func MigrateUserRoles(db *sql.DB) (int, error) {
+	// The tables are created here too so the migration can run before the server is upgraded
+	if _, err := db.Exec(CreateUserRolesTableQuery); err != nil {
+		return 0, fmt.Errorf("failed to create user_roles table: %w", err)
+	}
+	if _, err := db.Exec(CreateUserRolesBackupTableQuery); err != nil {
+		return 0, fmt.Errorf("failed to create user_roles_backup table: %w", err)
+	}
+
+	legacyRoles, err := listLegacyRoles(db)
+	if err != nil {
+		return 0, err
+	}
+
+	backupQuery := `
+		INSERT INTO user_roles_backup (user_id, roles)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE roles = VALUES(roles), migrated_at = CURRENT_TIMESTAMP
+	`
+
+	for id, roles := range legacyRoles {
+		user := aggregate.NewUser()
+		user.SetID(id)
+		user.SetRoles(roles)
+
+		tx, err := db.Begin()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(backupQuery, id, roles); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("user %d: %w", id, err)
+		}
+		if err := insertUserRoles(context.Background(), tx, user); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("user %d: %w", id, err)
+		}
+		if _, err := tx.Exec("UPDATE users SET roles = '' WHERE id = ?", id); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("user %d: %w", id, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(legacyRoles), nil
+}
+
+// VerifyUserRoles checks that no user has roles left in the legacy column and that the roles saved
+// in user_roles_backup are all in user_roles. Roles removed since the migration, e.g. by pruning,
+// are reported as missing.
+func VerifyUserRoles(db *sql.DB) (*RolesVerification, error) {
+	verification := &RolesVerification{
+		LegacyUsers:  []int32{},
+		MissingRoles: make(map[int32][]string),
+	}
+
+	legacyRoles, err := listLegacyRoles(db)
+	if err != nil {
+		return nil, err
+	}
+	for id := range legacyRoles {
+		verification.LegacyUsers = append(verification.LegacyUsers, id)
+	}
+	sort.Slice(verification.LegacyUsers, func(i, j int) bool {
+		return verification.LegacyUsers[i] < verification.LegacyUsers[j]
+	})
+
+	backups := make(map[int32]string)
+	rows, err := db.Query("SELECT user_id, roles FROM user_roles_backup")
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int32
+		var roles string
+		if err := rows.Scan(&id, &roles); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		backups[id] = roles
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	currentRoles, err := listUserRolesByUser(db)
+	if err != nil {
+		return nil, err
+	}
+
+	verification.MigratedUsers = len(backups)
+	for id, roles := range backups {
+		user := aggregate.NewUser()
+		user.SetRoles(roles)
+
+		current := make(map[string]bool)
+		for _, role := range currentRoles[id] {
+			current[role] = true
+		}
+		for _, role := range user.GetRoleList() {
+			if !current[role] {
+				verification.MissingRoles[id] = append(verification.MissingRoles[id], role)
+			}
+		}
+	}
+
+	return verification, nil
+}
+
+// RollbackUserRoles writes the current roles of every user back to the legacy users.roles column and
+// empties user_roles and user_roles_backup, so that a version of the API predating user_roles can be
+// deployed again. Roles granted since the migration are kept. It returns the number of users rolled back.
+func RollbackUserRoles(db *sql.DB) (int, error) {
+	currentRoles, err := listUserRolesByUser(db)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for id, roles := range currentRoles {
+		legacyRoles := strings.Join(roles, ",")
+		if len(legacyRoles) > legacyRolesMaxLength {
+			return 0, fmt.Errorf("user %d: roles exceed the %d characters of the legacy column, prune them first", id, legacyRolesMaxLength)
+		}
+		if _, err := tx.Exec("UPDATE users SET roles = ? WHERE id = ?", legacyRoles, id); err != nil {
+			return 0, fmt.Errorf("user %d: %w", id, err)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM user_roles"); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("DELETE FROM user_roles_backup"); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(currentRoles), nil
+}
+
+// listLegacyRoles returns the users still having roles in the legacy users.roles column
+func listLegacyRoles(db *sql.DB) (map[int32]string, error) {
+	rows, err := db.Query("SELECT id, roles FROM users WHERE roles <> ''")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	legacyRoles := make(map[int32]string)
+	for rows.Next() {
+		var id int32
+		var roles string
+		if err := rows.Scan(&id, &roles); err != nil {
+			return nil, err
+		}
+		legacyRoles[id] = roles
+	}
+
+	return legacyRoles, rows.Err()
+}
+
+// listUserRolesByUser returns the roles of the user_roles table per user
+func listUserRolesByUser(db *sql.DB) (map[int32][]string, error) {
+	rows, err := db.Query("SELECT user_id, role FROM user_roles ORDER BY user_id, role")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make(map[int32][]string)
+	for rows.Next() {
+		var id int32
+		var role string
+		if err := rows.Scan(&id, &role); err != nil {
+			return nil, err
+		}
+		roles[id] = append(roles[id], role)
+	}
+
+	return roles, rows.Err()
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// sameRunValues checks if the stored values of a run are the ones of its aggregate
+func sameRunValues(stored *Run, run *aggregate.Run) bool {
+	return stored.Zone == run.GetZone() &&
+		slices.Equal([]bool(stored.Doors), run.GetDoors()) &&
+		stored.Penality == run.GetPenality() &&
+		stored.ChronoMs == run.GetChronoMs() &&
+		stored.Status == run.GetStatus()
+}
+
+// insertRunRevision records within the transaction the change of the values of a run by its editor
+func insertRunRevision(ctx context.Context, tx *sql.Tx, previous *Run, run *aggregate.Run, editorID int32) error {
+	query := `
+		INSERT INTO run_revisions (competition_id, dossard, run_number, editor_id,
+			old_zone, old_doors, old_penality, old_chrono_sec, old_chrono_ms, old_status,
+			new_zone, new_doors, new_penality, new_chrono_sec, new_chrono_ms, new_status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		run.GetCompetitionID(),
+		run.GetDossard(),
+		run.GetRunNumber(),
+		editorID,
+		previous.Zone,
+		previous.Doors,
+		previous.Penality,
+		previous.ChronoMs/1000,
+		previous.ChronoMs,
+		previous.Status,
+		run.GetZone(),
+		doorsColumn(run.GetDoors()),
+		run.GetPenality(),
+		run.GetChronoSec(),
+		run.GetChronoMs(),
+		run.GetStatus(),
+	)
+	return err
+}
+
+// ListRunRevisions lists the changes of the values of a run, oldest first, with the names of their editors
+func (r *SQLRunRepository) ListRunRevisions(ctx context.Context, competitionID, dossard, runNumber int32) ([]*aggregate.RunRevision, error) {
+	query := `
+		SELECT
+			rr.id, rr.editor_id, COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as editor_name, rr.created_at,
+			rr.old_zone, rr.old_doors, rr.old_penality, rr.old_chrono_ms, rr.old_status,
+			rr.new_zone, rr.new_doors, rr.new_penality, rr.new_chrono_ms, rr.new_status
+		FROM run_revisions rr
+		LEFT JOIN users u ON rr.editor_id = u.id
+		WHERE rr.competition_id = ? AND rr.dossard = ? AND rr.run_number = ?
+		ORDER BY rr.created_at, rr.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID, dossard, runNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*aggregate.RunRevision{}
+	for rows.Next() {
+		var id, editorID int32
+		var editorName string
+		var createdAt time.Time
+		before := Run{CompetitionID: competitionID, Dossard: dossard, RunNumber: runNumber}
+		after := Run{CompetitionID: competitionID, Dossard: dossard, RunNumber: runNumber}
+
+		err := rows.Scan(
+			&id,
+			&editorID,
+			&editorName,
+			&createdAt,
+			&before.Zone,
+			&before.Doors,
+			&before.Penality,
+			&before.ChronoMs,
+			&before.Status,
+			&after.Zone,
+			&after.Doors,
+			&after.Penality,
+			&after.ChronoMs,
+			&after.Status,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		revision := aggregate.NewRunRevision()
+		revision.SetID(id)
+		revision.SetEditorID(editorID)
+		revision.SetEditorName(editorName)
+		revision.SetCreatedAt(createdAt)
+		revision.SetBefore(mapToRunAggregate(&before))
+		revision.SetAfter(mapToRunAggregate(&after))
+
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	repo "github.com/NiskuT/cross-api/internal/domain/repository"
@@ -16,6 +18,12 @@ var (
 	ErrDuplicateRun = errors.New("run with this combination of competition ID, run number, and dossard already exists")
 	// ErrParticipantNotFoundForRun is returned when trying to create a run for a non-existent participant
 	ErrParticipantNotFoundForRun = errors.New("participant not found for this run")
+	// ErrDuplicateReceiptCode is returned when the receipt code of a new run is already the one of another run
+	ErrDuplicateReceiptCode = errors.New("receipt code already used by another run")
+	// ErrDuplicateIdempotencyKey is returned when a run with the same idempotency key was already recorded in the competition
+	ErrDuplicateIdempotencyKey = errors.New("a run with this idempotency key was already recorded")
+	// ErrNoRunConflict is returned when resolving a conflict on a run whose zone has no run beyond the expected ones
+	ErrNoRunConflict = errors.New("no run of the participant beyond the runs expected in this zone")
 )
 
 // SQLRunRepository is an implementation of the RunRepository interface that uses SQL
@@ -32,25 +40,27 @@ func NewSQLRunRepository(db *sql.DB) repo.RunRepository {
 
 // Run is an internal representation of a run for DB operations
 type Run struct {
-	CompetitionID int32
-	Dossard       int32
-	RunNumber     int32
-	Zone          string
-	Door1         bool
-	Door2         bool
-	Door3         bool
-	Door4         bool
-	Door5         bool
-	Door6         bool
-	Penality      int32
-	ChronoSec     int32
-	RefereeId     int32
+	CompetitionID   int32
+	Dossard         int32
+	RunNumber       int32
+	Zone            string
+	Doors           doorsColumn
+	Penality        int32
+	ChronoMs        int32
+	RefereeId       int32
+	CreatedAt       time.Time
+	VoidedAt        sql.NullTime
+	UpdatedAt       sql.NullTime // null until the run is modified or voided
+	Status          string
+	PendingApproval bool
+	Comment         string
+	ReceiptCode     sql.NullString
 }
 
 // GetRun retrieves a run by its primary key (competition ID, run number, dossard)
 func (r *SQLRunRepository) GetRun(ctx context.Context, competitionID, runNumber, dossard int32) (*aggregate.Run, error) {
 	query := `
-		SELECT competition_id, dossard, run_number, zone, door1, door2, door3, door4, door5, door6, penality, chrono_sec, referee_id
+		SELECT competition_id, dossard, run_number, zone, doors, penality, chrono_ms, referee_id, created_at, voided_at, updated_at, status, pending_approval, comment
 		FROM runs
 		WHERE competition_id = ? AND run_number = ? AND dossard = ?
 	`
@@ -62,15 +72,16 @@ func (r *SQLRunRepository) GetRun(ctx context.Context, competitionID, runNumber,
 		&run.Dossard,
 		&run.RunNumber,
 		&run.Zone,
-		&run.Door1,
-		&run.Door2,
-		&run.Door3,
-		&run.Door4,
-		&run.Door5,
-		&run.Door6,
+		&run.Doors,
 		&run.Penality,
-		&run.ChronoSec,
+		&run.ChronoMs,
 		&run.RefereeId,
+		&run.CreatedAt,
+		&run.VoidedAt,
+		&run.UpdatedAt,
+		&run.Status,
+		&run.PendingApproval,
+		&run.Comment,
 	)
 
 	if err != nil {
@@ -86,7 +97,7 @@ func (r *SQLRunRepository) GetRun(ctx context.Context, competitionID, runNumber,
 // ListRuns lists all runs for a competition
 func (r *SQLRunRepository) ListRuns(ctx context.Context, competitionID int32) ([]*aggregate.Run, error) {
 	query := `
-		SELECT competition_id, dossard, run_number, zone, door1, door2, door3, door4, door5, door6, penality, chrono_sec, referee_id
+		SELECT competition_id, dossard, run_number, zone, doors, penality, chrono_ms, referee_id, created_at, voided_at, updated_at, status, pending_approval, comment
 		FROM runs
 		WHERE competition_id = ?
 		ORDER BY dossard, run_number
@@ -106,15 +117,16 @@ func (r *SQLRunRepository) ListRuns(ctx context.Context, competitionID int32) ([
 			&run.Dossard,
 			&run.RunNumber,
 			&run.Zone,
-			&run.Door1,
-			&run.Door2,
-			&run.Door3,
-			&run.Door4,
-			&run.Door5,
-			&run.Door6,
+			&run.Doors,
 			&run.Penality,
-			&run.ChronoSec,
+			&run.ChronoMs,
 			&run.RefereeId,
+			&run.CreatedAt,
+			&run.VoidedAt,
+			&run.UpdatedAt,
+			&run.Status,
+			&run.PendingApproval,
+			&run.Comment,
 		)
 
 		if err != nil {
@@ -131,10 +143,112 @@ func (r *SQLRunRepository) ListRuns(ctx context.Context, competitionID int32) ([
 	return runs, nil
 }
 
+// SearchRuns lists a page of the runs of a competition matching the filter, with the names of their referees,
+// most recent first, and counts all of them
+func (r *SQLRunRepository) SearchRuns(ctx context.Context, competitionID int32, filter *aggregate.RunFilter, pageNumber, pageSize int32) ([]*aggregate.Run, int32, error) {
+	if pageSize <= 0 {
+		pageSize = 10 // Default page size
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1 // Default page number
+	}
+
+	where := " WHERE r.competition_id = ?"
+	args := []interface{}{competitionID}
+	if filter.GetZone() != "" {
+		where += " AND r.zone = ?"
+		args = append(args, filter.GetZone())
+	}
+	if filter.GetDossard() > 0 {
+		where += " AND r.dossard = ?"
+		args = append(args, filter.GetDossard())
+	}
+	if filter.GetRefereeID() > 0 {
+		where += " AND r.referee_id = ?"
+		args = append(args, filter.GetRefereeID())
+	}
+	if !filter.GetFrom().IsZero() {
+		where += " AND r.created_at >= ?"
+		args = append(args, filter.GetFrom())
+	}
+	if !filter.GetTo().IsZero() {
+		where += " AND r.created_at <= ?"
+		args = append(args, filter.GetTo())
+	}
+	if filter.IsPendingApprovalOnly() {
+		where += " AND r.pending_approval = true AND r.voided_at IS NULL"
+	}
+
+	var totalCount int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM runs r"+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+
+	query := `
+		SELECT
+			r.competition_id, r.dossard, r.run_number, r.zone, r.doors,
+			r.penality, r.chrono_ms, r.referee_id, r.created_at, r.voided_at, r.updated_at, r.status, r.pending_approval, r.comment, r.receipt_code,
+			COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as referee_name
+		FROM runs r
+		LEFT JOIN users u ON r.referee_id = u.id` + where + `
+		ORDER BY r.created_at DESC, r.dossard, r.run_number
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, pageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var runs []*aggregate.Run
+	for rows.Next() {
+		var run Run
+		var refereeName string
+
+		err := rows.Scan(
+			&run.CompetitionID,
+			&run.Dossard,
+			&run.RunNumber,
+			&run.Zone,
+			&run.Doors,
+			&run.Penality,
+			&run.ChronoMs,
+			&run.RefereeId,
+			&run.CreatedAt,
+			&run.VoidedAt,
+			&run.UpdatedAt,
+			&run.Status,
+			&run.PendingApproval,
+			&run.Comment,
+			&run.ReceiptCode,
+			&refereeName,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		runAggregate := mapToRunAggregate(&run)
+		runAggregate.SetRefereeName(refereeName)
+
+		runs = append(runs, runAggregate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return runs, totalCount, nil
+}
+
 // ListRunsByDossard lists all runs for a specific participant in a competition
 func (r *SQLRunRepository) ListRunsByDossard(ctx context.Context, competitionID int32, dossard int32) ([]*aggregate.Run, error) {
 	query := `
-		SELECT competition_id, dossard, run_number, zone, door1, door2, door3, door4, door5, door6, penality, chrono_sec, referee_id
+		SELECT competition_id, dossard, run_number, zone, doors, penality, chrono_ms, referee_id, created_at, voided_at, updated_at, status, pending_approval, comment
 		FROM runs
 		WHERE competition_id = ? AND dossard = ?
 		ORDER BY run_number
@@ -154,15 +268,151 @@ func (r *SQLRunRepository) ListRunsByDossard(ctx context.Context, competitionID
 			&run.Dossard,
 			&run.RunNumber,
 			&run.Zone,
-			&run.Door1,
-			&run.Door2,
-			&run.Door3,
-			&run.Door4,
-			&run.Door5,
-			&run.Door6,
+			&run.Doors,
+			&run.Penality,
+			&run.ChronoMs,
+			&run.RefereeId,
+			&run.CreatedAt,
+			&run.VoidedAt,
+			&run.UpdatedAt,
+			&run.Status,
+			&run.PendingApproval,
+			&run.Comment,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, mapToRunAggregate(&run))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// GetRunByReceiptCode retrieves the run with the receipt code, with the name of its referee
+func (r *SQLRunRepository) GetRunByReceiptCode(ctx context.Context, receiptCode string) (*aggregate.Run, error) {
+	query := `
+		SELECT
+			r.competition_id, r.dossard, r.run_number, r.zone, r.doors,
+			r.penality, r.chrono_ms, r.referee_id, r.created_at, r.voided_at, r.updated_at, r.status, r.pending_approval, r.comment, r.receipt_code,
+			COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as referee_name
+		FROM runs r
+		LEFT JOIN users u ON r.referee_id = u.id
+		WHERE r.receipt_code = ?
+	`
+
+	var run Run
+	var refereeName string
+	err := r.db.QueryRowContext(ctx, query, receiptCode).Scan(
+		&run.CompetitionID,
+		&run.Dossard,
+		&run.RunNumber,
+		&run.Zone,
+		&run.Doors,
+		&run.Penality,
+		&run.ChronoMs,
+		&run.RefereeId,
+		&run.CreatedAt,
+		&run.VoidedAt,
+		&run.UpdatedAt,
+		&run.Status,
+		&run.PendingApproval,
+		&run.Comment,
+		&run.ReceiptCode,
+		&refereeName,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRunNotFound
+		}
+		return nil, err
+	}
+
+	runAggregate := mapToRunAggregate(&run)
+	runAggregate.SetRefereeName(refereeName)
+	return runAggregate, nil
+}
+
+// GetRunByIdempotencyKey retrieves the run of a competition recorded with the idempotency key, with its receipt code,
+// nil when there is none
+func (r *SQLRunRepository) GetRunByIdempotencyKey(ctx context.Context, competitionID int32, key string) (*aggregate.Run, error) {
+	query := `
+		SELECT competition_id, dossard, run_number, zone, doors, penality, chrono_ms, referee_id, created_at, voided_at, updated_at, status, pending_approval, comment,
+			receipt_code, idempotency_key
+		FROM runs
+		WHERE competition_id = ? AND idempotency_key = ?
+	`
+
+	var run Run
+	var idempotencyKey sql.NullString
+	err := r.db.QueryRowContext(ctx, query, competitionID, key).Scan(
+		&run.CompetitionID,
+		&run.Dossard,
+		&run.RunNumber,
+		&run.Zone,
+		&run.Doors,
+		&run.Penality,
+		&run.ChronoMs,
+		&run.RefereeId,
+		&run.CreatedAt,
+		&run.VoidedAt,
+		&run.UpdatedAt,
+		&run.Status,
+		&run.PendingApproval,
+		&run.Comment,
+		&run.ReceiptCode,
+		&idempotencyKey,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	runAggregate := mapToRunAggregate(&run)
+	runAggregate.SetIdempotencyKey(idempotencyKey.String)
+	return runAggregate, nil
+}
+
+// ListRunsSince lists the runs of a competition recorded since the given time, ordered by creation time
+func (r *SQLRunRepository) ListRunsSince(ctx context.Context, competitionID int32, since time.Time) ([]*aggregate.Run, error) {
+	query := `
+		SELECT competition_id, dossard, run_number, zone, doors, penality, chrono_ms, referee_id, created_at, voided_at, updated_at, status, pending_approval, comment
+		FROM runs
+		WHERE competition_id = ? AND created_at >= ?
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*aggregate.Run
+	for rows.Next() {
+		var run Run
+		err := rows.Scan(
+			&run.CompetitionID,
+			&run.Dossard,
+			&run.RunNumber,
+			&run.Zone,
+			&run.Doors,
 			&run.Penality,
-			&run.ChronoSec,
+			&run.ChronoMs,
 			&run.RefereeId,
+			&run.CreatedAt,
+			&run.VoidedAt,
+			&run.UpdatedAt,
+			&run.Status,
+			&run.PendingApproval,
+			&run.Comment,
 		)
 
 		if err != nil {
@@ -183,9 +433,8 @@ func (r *SQLRunRepository) ListRunsByDossard(ctx context.Context, competitionID
 func (r *SQLRunRepository) ListRunsByDossardWithDetails(ctx context.Context, competitionID, dossard int32) ([]*aggregate.Run, error) {
 	query := `
 		SELECT 
-			r.competition_id, r.dossard, r.run_number, r.zone,
-			r.door1, r.door2, r.door3, r.door4, r.door5, r.door6,
-			r.penality, r.chrono_sec, r.referee_id,
+			r.competition_id, r.dossard, r.run_number, r.zone, r.doors,
+			r.penality, r.chrono_ms, r.referee_id, r.created_at, r.voided_at, r.updated_at, r.status, r.pending_approval, r.comment, r.receipt_code,
 			COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as referee_name
 		FROM runs r
 		LEFT JOIN users u ON r.referee_id = u.id
@@ -209,15 +458,17 @@ func (r *SQLRunRepository) ListRunsByDossardWithDetails(ctx context.Context, com
 			&run.Dossard,
 			&run.RunNumber,
 			&run.Zone,
-			&run.Door1,
-			&run.Door2,
-			&run.Door3,
-			&run.Door4,
-			&run.Door5,
-			&run.Door6,
+			&run.Doors,
 			&run.Penality,
-			&run.ChronoSec,
+			&run.ChronoMs,
 			&run.RefereeId,
+			&run.CreatedAt,
+			&run.VoidedAt,
+			&run.UpdatedAt,
+			&run.Status,
+			&run.PendingApproval,
+			&run.Comment,
+			&run.ReceiptCode,
 			&refereeName,
 		)
 		if err != nil {
@@ -238,66 +489,154 @@ func (r *SQLRunRepository) ListRunsByDossardWithDetails(ctx context.Context, com
 	return runs, nil
 }
 
-// CreateRun creates a new run with auto-incrementing run number per participant
-func (r *SQLRunRepository) CreateRun(ctx context.Context, run *aggregate.Run) error {
-	// First, verify that the participant exists
+// CreateRun creates a new run with auto-incrementing run number per participant. The runs of the participant are
+// locked meanwhile, so that concurrent runs cannot exceed maxRunsPerZone: when the participant already has that many
+// runs in the zone, voided ones excluded, their run numbers are returned without recording the run.
+func (r *SQLRunRepository) CreateRun(ctx context.Context, run *aggregate.Run, maxRunsPerZone int32) ([]int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// First, verify that the participant exists, locking it until the run is recorded
 	checkQuery := `
 		SELECT 1 FROM participants
 		WHERE competition_id = ? AND dossard_number = ?
+		FOR UPDATE
 	`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, checkQuery, run.GetCompetitionID(), run.GetDossard()).Scan(&exists)
+	err = tx.QueryRowContext(ctx, checkQuery, run.GetCompetitionID(), run.GetDossard()).Scan(&exists)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return ErrParticipantNotFoundForRun
+			return nil, ErrParticipantNotFoundForRun
 		}
-		return err
+		return nil, err
 	}
 
-	// Auto-increment the run number for this participant if not explicitly set
-	if run.GetRunNumber() <= 0 {
-		// Find the max run number for this participant
-		maxQuery := `
-			SELECT COALESCE(MAX(run_number), 0)
-			FROM runs
-			WHERE competition_id = ? AND dossard = ?
-		`
-		var maxRunNumber int32
-		err := r.db.QueryRowContext(ctx, maxQuery, run.GetCompetitionID(), run.GetDossard()).Scan(&maxRunNumber)
-		if err != nil && err != sql.ErrNoRows {
-			return err
+	// The runs of the participant give the next run number and the runs already recorded in the zone
+	runsQuery := `
+		SELECT run_number, zone, voided_at IS NOT NULL
+		FROM runs
+		WHERE competition_id = ? AND dossard = ?
+		ORDER BY run_number
+		FOR UPDATE
+	`
+	rows, err := tx.QueryContext(ctx, runsQuery, run.GetCompetitionID(), run.GetDossard())
+	if err != nil {
+		return nil, err
+	}
+	var maxRunNumber int32
+	zoneRunNumbers := []int32{}
+	for rows.Next() {
+		var runNumber int32
+		var zone string
+		var voided bool
+		if err := rows.Scan(&runNumber, &zone, &voided); err != nil {
+			rows.Close()
+			return nil, err
 		}
+		maxRunNumber = max(maxRunNumber, runNumber)
+		if zone == run.GetZone() && !voided {
+			zoneRunNumbers = append(zoneRunNumbers, runNumber)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if int32(len(zoneRunNumbers)) >= maxRunsPerZone {
+		return zoneRunNumbers, nil
+	}
 
-		// Set the next run number
+	// Auto-increment the run number for this participant if not explicitly set
+	if run.GetRunNumber() <= 0 {
 		run.SetRunNumber(maxRunNumber + 1)
 	}
 
 	// Now insert the run with the calculated run number
 	query := `
-		INSERT INTO runs (competition_id, dossard, run_number, zone, door1, door2, door3, door4, door5, door6, penality, chrono_sec, referee_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO runs (competition_id, dossard, run_number, zone, doors, penality, chrono_sec, chrono_ms, referee_id, receipt_code, status, idempotency_key, pending_approval, comment)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err = r.db.ExecContext(
+	receiptCode := sql.NullString{String: run.GetReceiptCode(), Valid: run.GetReceiptCode() != ""}
+	idempotencyKey := sql.NullString{String: run.GetIdempotencyKey(), Valid: run.GetIdempotencyKey() != ""}
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		run.GetCompetitionID(),
 		run.GetDossard(),
 		run.GetRunNumber(),
 		run.GetZone(),
-		run.GetDoor1(),
-		run.GetDoor2(),
-		run.GetDoor3(),
-		run.GetDoor4(),
-		run.GetDoor5(),
-		run.GetDoor6(),
+		doorsColumn(run.GetDoors()),
 		run.GetPenality(),
 		run.GetChronoSec(),
+		run.GetChronoMs(),
 		run.GetRefereeId(),
+		receiptCode,
+		run.GetStatus(),
+		idempotencyKey,
+		run.IsPendingApproval(),
+		run.GetComment(),
+	)
+
+	if err != nil {
+		// Check for duplicate key error, on the receipt code or on the run itself
+		if isDuplicateKeyError(err) && strings.Contains(err.Error(), "runs_receipt_code") {
+			return nil, ErrDuplicateReceiptCode
+		}
+		if isDuplicateKeyError(err) && strings.Contains(err.Error(), "runs_idempotency_key") {
+			return nil, ErrDuplicateIdempotencyKey
+		}
+		if isDuplicateKeyError(err) {
+			return nil, ErrDuplicateRun
+		}
+		return nil, err
+	}
+
+	return nil, tx.Commit()
+}
+
+// RestoreRun inserts a run as it was archived, keeping its number, creation and void times
+func (r *SQLRunRepository) RestoreRun(ctx context.Context, run *aggregate.Run) error {
+	query := `
+		INSERT INTO runs (competition_id, dossard, run_number, zone, doors, penality, chrono_sec, chrono_ms, referee_id, created_at, voided_at, updated_at, status, pending_approval, comment)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var voidedAt sql.NullTime
+	if run.IsVoided() {
+		voidedAt = sql.NullTime{Time: run.GetVoidedAt(), Valid: true}
+	}
+
+	var updatedAt sql.NullTime
+	if run.GetUpdatedAt().After(run.GetCreatedAt()) {
+		updatedAt = sql.NullTime{Time: run.GetUpdatedAt(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		run.GetCompetitionID(),
+		run.GetDossard(),
+		run.GetRunNumber(),
+		run.GetZone(),
+		doorsColumn(run.GetDoors()),
+		run.GetPenality(),
+		run.GetChronoSec(),
+		run.GetChronoMs(),
+		run.GetRefereeId(),
+		run.GetCreatedAt(),
+		voidedAt,
+		updatedAt,
+		run.GetStatus(),
+		run.IsPendingApproval(),
+		run.GetComment(),
 	)
 
 	if err != nil {
-		// Check for duplicate key error
 		if isDuplicateKeyError(err) {
 			return ErrDuplicateRun
 		}
@@ -307,32 +646,77 @@ func (r *SQLRunRepository) CreateRun(ctx context.Context, run *aggregate.Run) er
 	return nil
 }
 
-// UpdateRun updates an existing run
-func (r *SQLRunRepository) UpdateRun(ctx context.Context, run *aggregate.Run) error {
+// UpdateRun updates an existing run and records the change of its values with its editor in the run revisions,
+// in one transaction
+func (r *SQLRunRepository) UpdateRun(ctx context.Context, run *aggregate.Run, editorID int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous Run
+	err = tx.QueryRowContext(ctx, `
+		SELECT zone, doors, penality, chrono_ms, status
+		FROM runs
+		WHERE competition_id = ? AND run_number = ? AND dossard = ?
+		FOR UPDATE
+	`, run.GetCompetitionID(), run.GetRunNumber(), run.GetDossard()).Scan(
+		&previous.Zone,
+		&previous.Doors,
+		&previous.Penality,
+		&previous.ChronoMs,
+		&previous.Status,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrRunNotFound
+		}
+		return err
+	}
+
 	query := `
 		UPDATE runs
-		SET zone = ?, door1 = ?, door2 = ?, door3 = ?, door4 = ?, door5 = ?, door6 = ?, penality = ?, chrono_sec = ?, referee_id = ?
+		SET zone = ?, doors = ?, penality = ?, chrono_sec = ?, chrono_ms = ?, referee_id = ?, status = ?, comment = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE competition_id = ? AND run_number = ? AND dossard = ?
 	`
 
-	result, err := r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		run.GetZone(),
-		run.GetDoor1(),
-		run.GetDoor2(),
-		run.GetDoor3(),
-		run.GetDoor4(),
-		run.GetDoor5(),
-		run.GetDoor6(),
+		doorsColumn(run.GetDoors()),
 		run.GetPenality(),
 		run.GetChronoSec(),
+		run.GetChronoMs(),
 		run.GetRefereeId(),
+		run.GetStatus(),
+		run.GetComment(),
 		run.GetCompetitionID(),
 		run.GetRunNumber(),
 		run.GetDossard(),
 	)
+	if err != nil {
+		return err
+	}
+
+	if !sameRunValues(&previous, run) {
+		if err := insertRunRevision(ctx, tx, &previous, run, editorID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
 
+// DeleteRun deletes a run by its primary key
+func (r *SQLRunRepository) DeleteRun(ctx context.Context, competitionID, runNumber, dossard int32) error {
+	query := `
+		DELETE FROM runs
+		WHERE competition_id = ? AND run_number = ? AND dossard = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, runNumber, dossard)
 	if err != nil {
 		return err
 	}
@@ -349,14 +733,172 @@ func (r *SQLRunRepository) UpdateRun(ctx context.Context, run *aggregate.Run) er
 	return nil
 }
 
-// DeleteRun deletes a run by its primary key
-func (r *SQLRunRepository) DeleteRun(ctx context.Context, competitionID, runNumber, dossard int32) error {
+// ListConflictingRuns lists the runs of the participants scored more than once in the same zone,
+// e.g. by two referees, ordered by dossard, zone and run number. Voided runs are ignored. The zones expecting
+// several runs are listed too, it is up to the caller to compare them with the runs expected.
+func (r *SQLRunRepository) ListConflictingRuns(ctx context.Context, competitionID int32) ([]*aggregate.Run, error) {
 	query := `
-		DELETE FROM runs
-		WHERE competition_id = ? AND run_number = ? AND dossard = ?
+		SELECT r.competition_id, r.dossard, r.run_number, r.zone, r.doors,
+		       r.penality, r.chrono_ms, r.referee_id, r.created_at, r.voided_at, r.updated_at, r.status, r.pending_approval, r.comment
+		FROM runs r
+		JOIN (
+			SELECT dossard, zone
+			FROM runs
+			WHERE competition_id = ? AND voided_at IS NULL
+			GROUP BY dossard, zone
+			HAVING COUNT(*) > 1
+		) c ON r.dossard = c.dossard AND r.zone = c.zone
+		WHERE r.competition_id = ? AND r.voided_at IS NULL
+		ORDER BY r.dossard, r.zone, r.run_number
 	`
 
-	result, err := r.db.ExecContext(ctx, query, competitionID, runNumber, dossard)
+	rows, err := r.db.QueryContext(ctx, query, competitionID, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []*aggregate.Run{}
+	for rows.Next() {
+		var run Run
+		err := rows.Scan(
+			&run.CompetitionID,
+			&run.Dossard,
+			&run.RunNumber,
+			&run.Zone,
+			&run.Doors,
+			&run.Penality,
+			&run.ChronoMs,
+			&run.RefereeId,
+			&run.CreatedAt,
+			&run.VoidedAt,
+			&run.UpdatedAt,
+			&run.Status,
+			&run.PendingApproval,
+			&run.Comment,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, mapToRunAggregate(&run))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// ResolveRunConflict keeps a run as the authoritative one and voids the runs of the participant in the same zone
+// beyond the maxRuns expected there, the earliest ones being kept with it. The liveranking is recalculated in the
+// same transaction so the ranking never counts the extra runs.
+func (r *SQLRunRepository) ResolveRunConflict(ctx context.Context, competitionID, dossard, keptRunNumber, maxRuns int32) (int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var zone string
+	zoneQuery := `
+		SELECT zone
+		FROM runs
+		WHERE competition_id = ? AND dossard = ? AND run_number = ? AND voided_at IS NULL
+		FOR UPDATE
+	`
+	err = tx.QueryRowContext(ctx, zoneQuery, competitionID, dossard, keptRunNumber).Scan(&zone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrRunNotFound
+		}
+		return 0, err
+	}
+
+	runsQuery := `
+		SELECT run_number
+		FROM runs
+		WHERE competition_id = ? AND dossard = ? AND zone = ? AND voided_at IS NULL
+		ORDER BY run_number
+		FOR UPDATE
+	`
+	rows, err := tx.QueryContext(ctx, runsQuery, competitionID, dossard, zone)
+	if err != nil {
+		return 0, err
+	}
+	var runNumbers []int32
+	for rows.Next() {
+		var runNumber int32
+		if err := rows.Scan(&runNumber); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		runNumbers = append(runNumbers, runNumber)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// The kept run and the earliest other runs fill the runs expected in the zone, the runs beyond are voided
+	kept := int32(1)
+	var voidedRuns []interface{}
+	for _, runNumber := range runNumbers {
+		if runNumber == keptRunNumber {
+			continue
+		}
+		if kept < maxRuns {
+			kept++
+			continue
+		}
+		voidedRuns = append(voidedRuns, runNumber)
+	}
+
+	if len(voidedRuns) == 0 {
+		return 0, ErrNoRunConflict
+	}
+
+	voidQuery := `
+		UPDATE runs
+		SET voided_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE competition_id = ? AND dossard = ? AND voided_at IS NULL AND run_number IN (?` + strings.Repeat(", ?", len(voidedRuns)-1) + `)
+	`
+	result, err := tx.ExecContext(ctx, voidQuery, append([]interface{}{competitionID, dossard}, voidedRuns...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	voided, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := recalculateLiveranking(ctx, tx, competitionID, dossard); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int32(voided), nil
+}
+
+// VoidRun voids a run so that it no longer counts in the ranking, the liveranking is recalculated in the same transaction
+func (r *SQLRunRepository) VoidRun(ctx context.Context, competitionID, runNumber, dossard int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE runs
+		SET voided_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE competition_id = ? AND run_number = ? AND dossard = ? AND voided_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, competitionID, runNumber, dossard)
 	if err != nil {
 		return err
 	}
@@ -370,7 +912,46 @@ func (r *SQLRunRepository) DeleteRun(ctx context.Context, competitionID, runNumb
 		return ErrRunNotFound
 	}
 
-	return nil
+	if err := recalculateLiveranking(ctx, tx, competitionID, dossard); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ApproveRun approves a run waiting for its approval so that it counts in the ranking, the liveranking is
+// recalculated in the same transaction
+func (r *SQLRunRepository) ApproveRun(ctx context.Context, competitionID, runNumber, dossard int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE runs
+		SET pending_approval = false, updated_at = CURRENT_TIMESTAMP
+		WHERE competition_id = ? AND run_number = ? AND dossard = ? AND pending_approval = true AND voided_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, competitionID, runNumber, dossard)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRunNotFound
+	}
+
+	if err := recalculateLiveranking(ctx, tx, competitionID, dossard); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // Helper function to map a Run struct to a Run aggregate
@@ -380,14 +961,21 @@ func mapToRunAggregate(run *Run) *aggregate.Run {
 	runAggregate.SetDossard(run.Dossard)
 	runAggregate.SetRunNumber(run.RunNumber)
 	runAggregate.SetZone(run.Zone)
-	runAggregate.SetDoor1(run.Door1)
-	runAggregate.SetDoor2(run.Door2)
-	runAggregate.SetDoor3(run.Door3)
-	runAggregate.SetDoor4(run.Door4)
-	runAggregate.SetDoor5(run.Door5)
-	runAggregate.SetDoor6(run.Door6)
+	runAggregate.SetDoors(run.Doors)
 	runAggregate.SetPenality(run.Penality)
-	runAggregate.SetChronoSec(run.ChronoSec)
+	runAggregate.SetChronoMs(run.ChronoMs)
 	runAggregate.SetRefereeId(run.RefereeId)
+	runAggregate.SetCreatedAt(run.CreatedAt)
+	if run.VoidedAt.Valid {
+		runAggregate.SetVoidedAt(run.VoidedAt.Time)
+	}
+	runAggregate.SetUpdatedAt(run.CreatedAt)
+	if run.UpdatedAt.Valid {
+		runAggregate.SetUpdatedAt(run.UpdatedAt.Time)
+	}
+	runAggregate.SetReceiptCode(run.ReceiptCode.String)
+	runAggregate.SetStatus(run.Status)
+	runAggregate.SetPendingApproval(run.PendingApproval)
+	runAggregate.SetComment(run.Comment)
 	return runAggregate
 }
@@ -33,18 +33,13 @@ type Scale struct {
 	CompetitionID int32
 	Category      string
 	Zone          string
-	PointsDoor1   int32
-	PointsDoor2   int32
-	PointsDoor3   int32
-	PointsDoor4   int32
-	PointsDoor5   int32
-	PointsDoor6   int32
+	DoorPoints    doorPointsColumn
 }
 
 // GetScale retrieves a scale by its primary key (competition ID, category, zone)
 func (r *SQLScaleRepository) GetScale(ctx context.Context, competitionID int32, category string, zone string) (*aggregate.Scale, error) {
 	query := `
-		SELECT competition_id, category, zone, points_door1, points_door2, points_door3, points_door4, points_door5, points_door6
+		SELECT competition_id, category, zone, door_points
 		FROM scales
 		WHERE competition_id = ? AND category = ? AND zone = ?
 	`
@@ -55,12 +50,7 @@ func (r *SQLScaleRepository) GetScale(ctx context.Context, competitionID int32,
 		&scale.CompetitionID,
 		&scale.Category,
 		&scale.Zone,
-		&scale.PointsDoor1,
-		&scale.PointsDoor2,
-		&scale.PointsDoor3,
-		&scale.PointsDoor4,
-		&scale.PointsDoor5,
-		&scale.PointsDoor6,
+		&scale.DoorPoints,
 	)
 
 	if err != nil {
@@ -74,12 +64,7 @@ func (r *SQLScaleRepository) GetScale(ctx context.Context, competitionID int32,
 	scaleAggregate.SetCompetitionID(scale.CompetitionID)
 	scaleAggregate.SetCategory(scale.Category)
 	scaleAggregate.SetZone(scale.Zone)
-	scaleAggregate.SetPointsDoor1(scale.PointsDoor1)
-	scaleAggregate.SetPointsDoor2(scale.PointsDoor2)
-	scaleAggregate.SetPointsDoor3(scale.PointsDoor3)
-	scaleAggregate.SetPointsDoor4(scale.PointsDoor4)
-	scaleAggregate.SetPointsDoor5(scale.PointsDoor5)
-	scaleAggregate.SetPointsDoor6(scale.PointsDoor6)
+	scaleAggregate.SetDoorPoints(scale.DoorPoints)
 
 	return scaleAggregate, nil
 }
@@ -87,8 +72,8 @@ func (r *SQLScaleRepository) GetScale(ctx context.Context, competitionID int32,
 // CreateScale creates a new scale
 func (r *SQLScaleRepository) CreateScale(ctx context.Context, scale *aggregate.Scale) error {
 	query := `
-		INSERT INTO scales (competition_id, category, zone, points_door1, points_door2, points_door3, points_door4, points_door5, points_door6)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO scales (competition_id, category, zone, door_points)
+		VALUES (?, ?, ?, ?)
 	`
 
 	_, err := r.db.ExecContext(
@@ -97,12 +82,7 @@ func (r *SQLScaleRepository) CreateScale(ctx context.Context, scale *aggregate.S
 		scale.GetCompetitionID(),
 		scale.GetCategory(),
 		scale.GetZone(),
-		scale.GetPointsDoor1(),
-		scale.GetPointsDoor2(),
-		scale.GetPointsDoor3(),
-		scale.GetPointsDoor4(),
-		scale.GetPointsDoor5(),
-		scale.GetPointsDoor6(),
+		doorPointsColumn(scale.GetDoorPoints()),
 	)
 
 	if err != nil {
@@ -120,19 +100,14 @@ func (r *SQLScaleRepository) CreateScale(ctx context.Context, scale *aggregate.S
 func (r *SQLScaleRepository) UpdateScale(ctx context.Context, scale *aggregate.Scale) error {
 	query := `
 		UPDATE scales
-		SET points_door1 = ?, points_door2 = ?, points_door3 = ?, points_door4 = ?, points_door5 = ?, points_door6 = ?
+		SET door_points = ?
 		WHERE competition_id = ? AND category = ? AND zone = ?
 	`
 
 	result, err := r.db.ExecContext(
 		ctx,
 		query,
-		scale.GetPointsDoor1(),
-		scale.GetPointsDoor2(),
-		scale.GetPointsDoor3(),
-		scale.GetPointsDoor4(),
-		scale.GetPointsDoor5(),
-		scale.GetPointsDoor6(),
+		doorPointsColumn(scale.GetDoorPoints()),
 		scale.GetCompetitionID(),
 		scale.GetCategory(),
 		scale.GetZone(),
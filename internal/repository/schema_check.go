@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+)
+
+var (
+	createTablePattern = regexp.MustCompile(`CREATE (?:TABLE IF NOT EXISTS|OR REPLACE VIEW) (\w+)`)
+	addColumnPattern   = regexp.MustCompile(`ALTER TABLE (\w+) ADD COLUMN (\w+)`)
+)
+
+// schemaQueries are the queries of InitializeDatabase defining the tables, views and columns the service expects
+var schemaQueries = []string{
+	CreateUsersTableQuery,
+	CreateOrganizationsTableQuery,
+	CreateCompetitionsTableQuery,
+	CreateParticipantsTableQuery,
+	CreateScalesTableQuery,
+	CreateRunsTableQuery,
+	CreateLiverankingsTableQuery,
+	CreateAuditLogsTableQuery,
+	CreateSessionsTableQuery,
+	CreateCompetitionContactsTableQuery,
+	CreateAPIKeysTableQuery,
+	CreateInvitationsTableQuery,
+	CreateUserRolesTableQuery,
+	CreateAuthEventsTableQuery,
+	CreateUserIdentitiesTableQuery,
+	CreateRefereePinsTableQuery,
+	CreateRefereeOnboardingsTableQuery,
+	CreateZoneBoundsTableQuery,
+	CreateZonesTableQuery,
+	CreateRefereeAssignmentsTableQuery,
+	CreateExportTemplatesTableQuery,
+	CreateCompetitionSettingsTableQuery,
+	CreateCategoriesTableQuery,
+	CreateClubsTableQuery,
+	CreateTeamsTableQuery,
+	CreateTeamMembersTableQuery,
+	CreateDisplayDevicesTableQuery,
+	CreatePendingRunsTableQuery,
+	CreateRunRevisionsTableQuery,
+	CreateSeriesTableQuery,
+	CreateSeriesCompetitionsTableQuery,
+	CreateRankingsViewQuery,
+	CreateUserRolesBackupTableQuery,
+	CreateSchemaVersionTableQuery,
+	AddRunsCreatedAtColumnQuery,
+	AddRunsVoidedAtColumnQuery,
+	AddRunsUpdatedAtColumnQuery,
+	AddRunsStatusColumnQuery,
+	AddRunsReceiptCodeColumnQuery,
+	AddRunsIdempotencyKeyColumnQuery,
+	AddRunsPendingApprovalColumnQuery,
+	AddRunsCommentColumnQuery,
+	AddRunsChronoMsColumnQuery,
+	AddLiverankingsChronoMsColumnQuery,
+	AddRunRevisionsOldChronoMsColumnQuery,
+	AddRunRevisionsNewChronoMsColumnQuery,
+	AddPendingRunsChronoMsColumnQuery,
+	AddRunsDoorsColumnQuery,
+	AddScalesDoorPointsColumnQuery,
+	AddCompetitionsChronoFormatColumnQuery,
+	AddCompetitionsChronoDirectionColumnQuery,
+	AddParticipantsGenderColumnQuery,
+	AddParticipantsClubColumnQuery,
+	AddParticipantsConsentDataProcessingColumnQuery,
+	AddParticipantsConsentPhotoRightsColumnQuery,
+	AddParticipantsClubEmailColumnQuery,
+	AddParticipantsLicenceColumnQuery,
+	AddParticipantsWaitlistedColumnQuery,
+	AddParticipantsWaitlistedAtColumnQuery,
+	AddParticipantsClubIDColumnQuery,
+	AddParticipantsCheckedInColumnQuery,
+	AddParticipantsBirthDateColumnQuery,
+	AddParticipantsNotesColumnQuery,
+	AddParticipantsFlagsColumnQuery,
+	AddParticipantsStatusColumnQuery,
+	AddParticipantsStatusReasonColumnQuery,
+	AddParticipantsPhotoKeyColumnQuery,
+	AddCompetitionsArchivedAtColumnQuery,
+	AddCompetitionsOrganizationIDColumnQuery,
+	AddCompetitionsMaxParticipantsColumnQuery,
+	AddCompetitionsRegistrationDeadlineColumnQuery,
+	AddCompetitionsWaitlistColumnQuery,
+	AddCompetitionsStatusColumnQuery,
+	AddCompetitionsTimezoneColumnQuery,
+	AddInvitationsMaxAcceptancesColumnQuery,
+	AddInvitationsZoneColumnQuery,
+	AddZonesIsOpenColumnQuery,
+	AddCompetitionSettingsRestrictRefereeZonesColumnQuery,
+	AddCompetitionSettingsVerifyLicencesColumnQuery,
+	AddCompetitionSettingsRunDSQRuleColumnQuery,
+	AddCompetitionSettingsRequireRunApprovalColumnQuery,
+}
+
+// VerifySchema lists the tables and columns created by the migrations that are missing from the database,
+// none when the schema is up to date. Unlike InitializeDatabase, it does not modify the database.
+func VerifySchema(ctx context.Context, db *sql.DB) ([]string, error) {
+	tables, err := listSchemaColumns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, query := range schemaQueries {
+		if match := createTablePattern.FindStringSubmatch(query); match != nil {
+			if _, ok := tables[match[1]]; !ok {
+				missing = append(missing, fmt.Sprintf("table %s", match[1]))
+			}
+			continue
+		}
+		if match := addColumnPattern.FindStringSubmatch(query); match != nil {
+			columns, ok := tables[match[1]]
+			if ok && !columns[match[2]] {
+				missing = append(missing, fmt.Sprintf("column %s.%s", match[1], match[2]))
+			}
+		}
+	}
+
+	return missing, nil
+}
+
+// listSchemaColumns returns the columns of every table of the current database, by table
+func listSchemaColumns(ctx context.Context, db *sql.DB) (map[string]map[string]bool, error) {
+	query := `
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = DATABASE()
+	`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string]map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		if tables[table] == nil {
+			tables[table] = make(map[string]bool)
+		}
+		tables[table][column] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+	"github.com/go-sql-driver/mysql"
+)
+
+// SchemaVersion is the version of the schema InitializeDatabase migrates the database to.
+// Raise it with the migrations the previous versions of the service cannot safely write through.
+// Version 2 stores the doors of scales and runs as lists and no longer writes the six door columns.
+// Version 3 points the participants to their club, which the previous versions would leave stale.
+// Version 4 reads the chronos in milliseconds, the previous versions only write them in seconds.
+const SchemaVersion int32 = 4
+
+var (
+	// ErrSchemaTooNew is returned when the database was migrated by a newer version of the service
+	ErrSchemaTooNew = errors.New("database schema is newer than the schema of this version")
+)
+
+// SQLSchemaRepository is an implementation of the SchemaRepository interface that uses SQL
+type SQLSchemaRepository struct {
+	db *sql.DB
+}
+
+// NewSQLSchemaRepository creates a new SQLSchemaRepository
+func NewSQLSchemaRepository(db *sql.DB) repo.SchemaRepository {
+	return &SQLSchemaRepository{
+		db: db,
+	}
+}
+
+// GetSchemaVersion returns the version the database was last migrated to and when, 0 before any version was recorded
+func (r *SQLSchemaRepository) GetSchemaVersion(ctx context.Context) (int32, time.Time, error) {
+	return getSchemaVersion(ctx, r.db)
+}
+
+// ListMissingSchema lists the tables and columns created by the migrations that are missing from the database
+func (r *SQLSchemaRepository) ListMissingSchema(ctx context.Context) ([]string, error) {
+	return VerifySchema(ctx, r.db)
+}
+
+// getSchemaVersion reads the recorded schema version, 0 when the schema_version table does not exist yet
+func getSchemaVersion(ctx context.Context, db *sql.DB) (int32, time.Time, error) {
+	query := `SELECT version, migrated_at FROM schema_version WHERE id = 1`
+
+	var version int32
+	var migratedAt time.Time
+	err := db.QueryRowContext(ctx, query).Scan(&version, &migratedAt)
+	if errors.Is(err, sql.ErrNoRows) || isMissingTableError(err) {
+		return 0, time.Time{}, nil
+	}
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return version, migratedAt, nil
+}
+
+// isMissingTableError checks if an error is raised by a table that does not exist
+func isMissingTableError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1146
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrSeriesNotFound is returned when the series does not exist
+	ErrSeriesNotFound = errors.New("series not found")
+	// ErrSeriesCompetitionNotFound is returned when the competition is not part of the series
+	ErrSeriesCompetitionNotFound = errors.New("the competition is not part of this series")
+	// ErrDuplicateSeriesCompetition is returned when the competition is already part of the series
+	ErrDuplicateSeriesCompetition = errors.New("the competition is already part of this series")
+)
+
+// SQLSeriesRepository is an implementation of the SeriesRepository interface that uses SQL
+type SQLSeriesRepository struct {
+	db *sql.DB
+}
+
+// NewSQLSeriesRepository creates a new SQLSeriesRepository
+func NewSQLSeriesRepository(db *sql.DB) repo.SeriesRepository {
+	return &SQLSeriesRepository{
+		db: db,
+	}
+}
+
+// Series is an internal representation of a series for DB operations
+type Series struct {
+	ID        int32
+	Name      string
+	CreatedBy int32
+	CreatedAt time.Time
+}
+
+// CreateSeries creates a new series and returns its ID
+func (r *SQLSeriesRepository) CreateSeries(ctx context.Context, series *aggregate.Series) (int32, error) {
+	query := `
+		INSERT INTO series (name, created_by)
+		VALUES (?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, series.GetName(), series.GetCreatedBy())
+	if err != nil {
+		return 0, err
+	}
+
+	// Get the auto-incremented ID
+	if id, err := result.LastInsertId(); err == nil {
+		series.SetID(int32(id))
+	}
+
+	return series.GetID(), nil
+}
+
+// GetSeries retrieves a series with its competitions, ordered by date
+func (r *SQLSeriesRepository) GetSeries(ctx context.Context, seriesID int32) (*aggregate.Series, error) {
+	query := `
+		SELECT id, name, created_by, created_at
+		FROM series
+		WHERE id = ?
+	`
+
+	var series Series
+	err := r.db.QueryRowContext(ctx, query, seriesID).Scan(&series.ID, &series.Name, &series.CreatedBy, &series.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	competitionIDs, err := r.listSeriesCompetitions(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesAggregate := mapToSeriesAggregate(series)
+	if ids, ok := competitionIDs[seriesID]; ok {
+		seriesAggregate.SetCompetitionIDs(ids)
+	}
+	return seriesAggregate, nil
+}
+
+// ListSeries retrieves every series with its competitions, the latest created first
+func (r *SQLSeriesRepository) ListSeries(ctx context.Context) ([]*aggregate.Series, error) {
+	query := `
+		SELECT id, name, created_by, created_at
+		FROM series
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seriesList := []*aggregate.Series{}
+	for rows.Next() {
+		var series Series
+		if err := rows.Scan(&series.ID, &series.Name, &series.CreatedBy, &series.CreatedAt); err != nil {
+			return nil, err
+		}
+		seriesList = append(seriesList, mapToSeriesAggregate(series))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	competitionIDs, err := r.listSeriesCompetitions(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, series := range seriesList {
+		if ids, ok := competitionIDs[series.GetID()]; ok {
+			series.SetCompetitionIDs(ids)
+		}
+	}
+
+	return seriesList, nil
+}
+
+// UpdateSeries renames a series
+func (r *SQLSeriesRepository) UpdateSeries(ctx context.Context, series *aggregate.Series) error {
+	query := `
+		UPDATE series
+		SET name = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, series.GetName(), series.GetID())
+	return err
+}
+
+// DeleteSeries deletes a series, its competitions are left untouched
+func (r *SQLSeriesRepository) DeleteSeries(ctx context.Context, seriesID int32) error {
+	query := `
+		DELETE FROM series
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, seriesID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSeriesNotFound
+	}
+
+	return nil
+}
+
+// AddCompetitionToSeries adds a competition to a series
+func (r *SQLSeriesRepository) AddCompetitionToSeries(ctx context.Context, seriesID, competitionID int32) error {
+	query := `
+		INSERT INTO series_competitions (series_id, competition_id)
+		VALUES (?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, seriesID, competitionID)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateSeriesCompetition
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RemoveCompetitionFromSeries removes a competition from a series
+func (r *SQLSeriesRepository) RemoveCompetitionFromSeries(ctx context.Context, seriesID, competitionID int32) error {
+	query := `
+		DELETE FROM series_competitions
+		WHERE series_id = ? AND competition_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, seriesID, competitionID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSeriesCompetitionNotFound
+	}
+
+	return nil
+}
+
+// listSeriesCompetitions lists the competitions of a series by series ID, ordered by date.
+// A zero seriesID lists the competitions of every series.
+func (r *SQLSeriesRepository) listSeriesCompetitions(ctx context.Context, seriesID int32) (map[int32][]int32, error) {
+	query := `
+		SELECT sc.series_id, sc.competition_id
+		FROM series_competitions sc
+		JOIN competitions c ON sc.competition_id = c.id
+		WHERE ? = 0 OR sc.series_id = ?
+		ORDER BY c.date, c.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, seriesID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	competitionIDs := make(map[int32][]int32)
+	for rows.Next() {
+		var id, competitionID int32
+		if err := rows.Scan(&id, &competitionID); err != nil {
+			return nil, err
+		}
+		competitionIDs[id] = append(competitionIDs[id], competitionID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return competitionIDs, nil
+}
+
+// mapToSeriesAggregate maps a series to its aggregate, without its competitions
+func mapToSeriesAggregate(series Series) *aggregate.Series {
+	seriesAggregate := aggregate.NewSeries()
+	seriesAggregate.SetID(series.ID)
+	seriesAggregate.SetName(series.Name)
+	seriesAggregate.SetCreatedBy(series.CreatedBy)
+	seriesAggregate.SetCreatedAt(series.CreatedAt)
+	return seriesAggregate
+}
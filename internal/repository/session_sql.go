@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrSessionNotFound is returned when a session cannot be found
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// SQLSessionRepository is an implementation of the SessionRepository interface that uses SQL
+type SQLSessionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLSessionRepository creates a new SQLSessionRepository
+func NewSQLSessionRepository(db *sql.DB) repo.SessionRepository {
+	return &SQLSessionRepository{
+		db: db,
+	}
+}
+
+// Session is an internal representation of a session for DB operations
+type Session struct {
+	ID         string
+	UserID     int32
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  sql.NullTime
+}
+
+// CreateSession creates a new session
+func (r *SQLSessionRepository) CreateSession(ctx context.Context, session *aggregate.Session) error {
+	query := `
+		INSERT INTO sessions (id, user_id, user_agent, ip, created_at, last_used_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		session.GetID(),
+		session.GetUserID(),
+		session.GetUserAgent(),
+		session.GetIP(),
+		session.GetCreatedAt(),
+		session.GetLastUsedAt(),
+		session.GetExpiresAt(),
+	)
+
+	return err
+}
+
+// GetSession retrieves a session by its ID
+func (r *SQLSessionRepository) GetSession(ctx context.Context, id string) (*aggregate.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = ?
+	`
+
+	var session Session
+	row := r.db.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return mapToSessionAggregate(session), nil
+}
+
+// ListActiveSessions lists the sessions of a user that are neither revoked nor expired, most recently used first
+func (r *SQLSessionRepository) ListActiveSessions(ctx context.Context, userID int32) ([]*aggregate.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id = ? AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY last_used_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*aggregate.Session{}
+	for rows.Next() {
+		var session Session
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.UserAgent,
+			&session.IP,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, mapToSessionAggregate(session))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// TouchSession records a refresh of the session from the given IP address
+func (r *SQLSessionRepository) TouchSession(ctx context.Context, id, ip string, expiresAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET last_used_at = ?, ip = ?, expires_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, time.Now(), ip, expiresAt, id)
+	return err
+}
+
+// RevokeSession revokes a session of the user
+func (r *SQLSessionRepository) RevokeSession(ctx context.Context, userID int32, id string) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = ?
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllSessions revokes every active session of the user and returns how many were revoked
+func (r *SQLSessionRepository) RevokeAllSessions(ctx context.Context, userID int32) (int32, error) {
+	query := `
+		UPDATE sessions
+		SET revoked_at = ?
+		WHERE user_id = ? AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), userID)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(rowsAffected), nil
+}
+
+// Helper function to map a Session struct to a Session aggregate
+func mapToSessionAggregate(session Session) *aggregate.Session {
+	sessionAggregate := aggregate.NewSession()
+	sessionAggregate.SetID(session.ID)
+	sessionAggregate.SetUserID(session.UserID)
+	sessionAggregate.SetUserAgent(session.UserAgent)
+	sessionAggregate.SetIP(session.IP)
+	sessionAggregate.SetCreatedAt(session.CreatedAt)
+	sessionAggregate.SetLastUsedAt(session.LastUsedAt)
+	sessionAggregate.SetExpiresAt(session.ExpiresAt)
+	if session.RevokedAt.Valid {
+		sessionAggregate.SetRevokedAt(session.RevokedAt.Time)
+	}
+	return sessionAggregate
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLStatsRepository is an implementation of the StatsRepository interface that uses SQL
+type SQLStatsRepository struct {
+	db *sql.DB
+}
+
+// NewSQLStatsRepository creates a new SQLStatsRepository
+func NewSQLStatsRepository(db *sql.DB) repo.StatsRepository {
+	return &SQLStatsRepository{
+		db: db,
+	}
+}
+
+// GetSystemStats counts the users, competitions, participants, runs, active sessions and API keys
+func (r *SQLStatsRepository) GetSystemStats(ctx context.Context) (*aggregate.SystemStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM competitions),
+			(SELECT COUNT(*) FROM participants),
+			(SELECT COUNT(*) FROM runs WHERE voided_at IS NULL),
+			(SELECT COUNT(*) FROM sessions WHERE revoked_at IS NULL AND expires_at > ?),
+			(SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL)
+	`
+
+	var users, competitions, participants, runs, activeSessions, apiKeys int32
+	err := r.db.QueryRowContext(ctx, query, time.Now()).Scan(&users, &competitions, &participants, &runs, &activeSessions, &apiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := aggregate.NewSystemStats()
+	stats.SetUsers(users)
+	stats.SetCompetitions(competitions)
+	stats.SetParticipants(participants)
+	stats.SetRuns(runs)
+	stats.SetActiveSessions(activeSessions)
+	stats.SetAPIKeys(apiKeys)
+
+	return stats, nil
+}
+
+// ListPointsEarned lists the points earned by the participants of the competitions starting around the
+// season year, archived competitions included. The caller checks the year of the date in the time zone of the competition.
+func (r *SQLStatsRepository) ListPointsEarned(ctx context.Context, season int) ([]*aggregate.PointsEarned, error) {
+	query := `
+		SELECT c.id, c.date, c.timezone, p.first_name, p.last_name, p.club, p.consent_data_processing, l.total_points
+		FROM liverankings l
+		JOIN participants p ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
+		JOIN competitions c ON l.competition_id = c.id
+		WHERE c.date >= ? AND c.date < ?
+		ORDER BY c.date, c.id
+	`
+
+	// A day of margin covers the time zones ahead of or behind UTC
+	from := time.Date(season, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	to := time.Date(season+1, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pointsEarned := []*aggregate.PointsEarned{}
+	for rows.Next() {
+		var competitionID, points int32
+		var date time.Time
+		var timezone, firstName, lastName, club string
+		var consent bool
+		if err := rows.Scan(&competitionID, &date, &timezone, &firstName, &lastName, &club, &consent, &points); err != nil {
+			return nil, err
+		}
+
+		earned := aggregate.NewPointsEarned()
+		earned.SetCompetitionID(competitionID)
+		if location, err := time.LoadLocation(timezone); err == nil {
+			date = date.In(location)
+		}
+		earned.SetCompetitionDate(date)
+		earned.SetFirstName(firstName)
+		earned.SetLastName(lastName)
+		earned.SetClub(club)
+		earned.SetConsentDataProcessing(consent)
+		earned.SetPoints(points)
+		pointsEarned = append(pointsEarned, earned)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pointsEarned, nil
+}
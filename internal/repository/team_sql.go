@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrTeamNotFound is returned when a team of a competition cannot be found
+	ErrTeamNotFound = errors.New("team not found")
+	// ErrDuplicateTeam is returned when the competition already has a team with this name
+	ErrDuplicateTeam = errors.New("team with this name already exists for the competition")
+	// ErrParticipantInOtherTeam is returned when a member of a team already belongs to another team
+	ErrParticipantInOtherTeam = errors.New("participant already belongs to another team")
+)
+
+// SQLTeamRepository is an implementation of the TeamRepository interface that uses SQL
+type SQLTeamRepository struct {
+	db *sql.DB
+}
+
+// NewSQLTeamRepository creates a new SQLTeamRepository
+func NewSQLTeamRepository(db *sql.DB) repo.TeamRepository {
+	return &SQLTeamRepository{
+		db: db,
+	}
+}
+
+// Team is an internal representation of a team for DB operations
+type Team struct {
+	ID            int32
+	CompetitionID int32
+	Name          string
+}
+
+// CreateTeam creates a new team with its members in one transaction and sets its generated ID
+func (r *SQLTeamRepository) CreateTeam(ctx context.Context, team *aggregate.Team) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO teams (competition_id, name)
+		VALUES (?, ?)
+	`, team.GetCompetitionID(), team.GetName())
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateTeam
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	err = insertTeamMembers(ctx, tx, team.GetCompetitionID(), int32(id), team.GetDossards())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	team.SetID(int32(id))
+
+	return nil
+}
+
+// GetTeam retrieves a team of a competition with its members
+func (r *SQLTeamRepository) GetTeam(ctx context.Context, competitionID, id int32) (*aggregate.Team, error) {
+	query := `
+		SELECT id, competition_id, name
+		FROM teams
+		WHERE competition_id = ? AND id = ?
+	`
+
+	var team Team
+	err := r.db.QueryRowContext(ctx, query, competitionID, id).Scan(&team.ID, &team.CompetitionID, &team.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT dossard_number
+		FROM team_members
+		WHERE team_id = ?
+		ORDER BY dossard_number
+	`, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dossards := []int32{}
+	for rows.Next() {
+		var dossardNumber int32
+		if err := rows.Scan(&dossardNumber); err != nil {
+			return nil, err
+		}
+		dossards = append(dossards, dossardNumber)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	teamAggregate := toTeamAggregate(team)
+	teamAggregate.SetDossards(dossards)
+
+	return teamAggregate, nil
+}
+
+// UpdateTeam renames a team and replaces its members in one transaction
+func (r *SQLTeamRepository) UpdateTeam(ctx context.Context, team *aggregate.Team) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE teams
+		SET name = ?
+		WHERE competition_id = ? AND id = ?
+	`, team.GetName(), team.GetCompetitionID(), team.GetID())
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateTeam
+		}
+		return err
+	}
+
+	// Renaming a team with its current name affects no rows, its existence is checked separately
+	var exists bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM teams WHERE competition_id = ? AND id = ?)
+	`, team.GetCompetitionID(), team.GetID()).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrTeamNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM team_members
+		WHERE team_id = ?
+	`, team.GetID())
+	if err != nil {
+		return err
+	}
+
+	err = insertTeamMembers(ctx, tx, team.GetCompetitionID(), team.GetID(), team.GetDossards())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListTeams lists the teams of a competition by name with their members
+func (r *SQLTeamRepository) ListTeams(ctx context.Context, competitionID int32) ([]*aggregate.Team, error) {
+	query := `
+		SELECT id, competition_id, name
+		FROM teams
+		WHERE competition_id = ?
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []*aggregate.Team{}
+	for rows.Next() {
+		var team Team
+		if err := rows.Scan(&team.ID, &team.CompetitionID, &team.Name); err != nil {
+			return nil, err
+		}
+		teams = append(teams, toTeamAggregate(team))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	members, err := r.listTeamMembers(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		team.SetDossards(members[team.GetID()])
+	}
+
+	return teams, nil
+}
+
+// DeleteTeam deletes a team of a competition, its members are left in the competition
+func (r *SQLTeamRepository) DeleteTeam(ctx context.Context, competitionID, id int32) error {
+	query := `
+		DELETE FROM teams
+		WHERE competition_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// ListTeamRankings lists the teams of a competition with the liveranking scores of their members,
+// the members without run scoring no point
+func (r *SQLTeamRepository) ListTeamRankings(ctx context.Context, competitionID int32) ([]*aggregate.TeamRanking, error) {
+	query := `
+		SELECT t.id, t.competition_id, t.name,
+			p.dossard_number, p.first_name, p.last_name, p.category, p.gender, p.consent_data_processing,
+			COALESCE(l.total_points, 0), COALESCE(l.number_of_runs, 0)
+		FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		JOIN participants p ON p.competition_id = m.competition_id AND p.dossard_number = m.dossard_number
+		LEFT JOIN liverankings l ON l.competition_id = p.competition_id AND l.dossard_number = p.dossard_number
+		WHERE t.competition_id = ?
+		ORDER BY t.name, p.dossard_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rankings := []*aggregate.TeamRanking{}
+	var ranking *aggregate.TeamRanking
+	for rows.Next() {
+		var team Team
+		var dossardNumber, totalPoints, numberOfRuns int32
+		var firstName, lastName, category, gender string
+		var consentDataProcessing bool
+		err := rows.Scan(&team.ID, &team.CompetitionID, &team.Name,
+			&dossardNumber, &firstName, &lastName, &category, &gender, &consentDataProcessing, &totalPoints, &numberOfRuns)
+		if err != nil {
+			return nil, err
+		}
+
+		if ranking == nil || ranking.GetTeam().GetID() != team.ID {
+			ranking = aggregate.NewTeamRanking(toTeamAggregate(team))
+			rankings = append(rankings, ranking)
+		}
+		ranking.GetTeam().SetDossards(append(ranking.GetTeam().GetDossards(), dossardNumber))
+
+		participant := aggregate.NewParticipant()
+		participant.SetCompetitionID(team.CompetitionID)
+		participant.SetDossardNumber(dossardNumber)
+		participant.SetFirstName(firstName)
+		participant.SetLastName(lastName)
+		participant.SetCategory(category)
+		participant.SetGender(gender)
+		participant.SetConsentDataProcessing(consentDataProcessing)
+
+		member := aggregate.NewTeamMemberScore(participant)
+		member.SetTotalPoints(totalPoints)
+		member.SetNumberOfRuns(numberOfRuns)
+		ranking.AddMember(member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rankings, nil
+}
+
+// listTeamMembers returns the dossards of the members of the teams of a competition, by team ID
+func (r *SQLTeamRepository) listTeamMembers(ctx context.Context, competitionID int32) (map[int32][]int32, error) {
+	query := `
+		SELECT team_id, dossard_number
+		FROM team_members
+		WHERE competition_id = ?
+		ORDER BY dossard_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make(map[int32][]int32)
+	for rows.Next() {
+		var teamID, dossardNumber int32
+		if err := rows.Scan(&teamID, &dossardNumber); err != nil {
+			return nil, err
+		}
+		members[teamID] = append(members[teamID], dossardNumber)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// insertTeamMembers adds the members of a team, a participant belonging to one team at most
+func insertTeamMembers(ctx context.Context, tx *sql.Tx, competitionID, teamID int32, dossards []int32) error {
+	for _, dossard := range dossards {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO team_members (team_id, competition_id, dossard_number)
+			VALUES (?, ?, ?)
+		`, teamID, competitionID, dossard)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				return ErrParticipantInOtherTeam
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toTeamAggregate converts the internal representation to an aggregate
+func toTeamAggregate(team Team) *aggregate.Team {
+	teamAggregate := aggregate.NewTeam()
+	teamAggregate.SetID(team.ID)
+	teamAggregate.SetCompetitionID(team.CompetitionID)
+	teamAggregate.SetName(team.Name)
+	return teamAggregate
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// userDenial denies the tokens of a user issued before a given time
+type userDenial struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+// MemoryTokenDenylist is an implementation of the TokenDenylistRepository interface kept in memory,
+// it only covers the tokens checked by the instance of the API it runs in
+type MemoryTokenDenylist struct {
+	mutex  sync.RWMutex
+	tokens map[string]time.Time
+	users  map[int32]userDenial
+}
+
+// NewMemoryTokenDenylist creates a new MemoryTokenDenylist
+func NewMemoryTokenDenylist() repo.TokenDenylistRepository {
+	return &MemoryTokenDenylist{
+		tokens: make(map[string]time.Time),
+		users:  make(map[int32]userDenial),
+	}
+}
+
+// DenyToken denies a token until it expires
+func (d *MemoryTokenDenylist) DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.removeExpired()
+	d.tokens[tokenID] = expiresAt
+	return nil
+}
+
+// DenyUserTokens denies the tokens of the user issued before issuedBefore
+func (d *MemoryTokenDenylist) DenyUserTokens(ctx context.Context, userID int32, issuedBefore, expiresAt time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.removeExpired()
+	d.users[userID] = userDenial{issuedBefore: issuedBefore, expiresAt: expiresAt}
+	return nil
+}
+
+// IsDenied returns whether the token was denied, by its ID or with all the tokens of its user
+func (d *MemoryTokenDenylist) IsDenied(ctx context.Context, userID int32, tokenID string, issuedAt time.Time) (bool, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	now := time.Now()
+	if expiresAt, exists := d.tokens[tokenID]; exists && tokenID != "" && now.Before(expiresAt) {
+		return true, nil
+	}
+	if denial, exists := d.users[userID]; exists && now.Before(denial.expiresAt) && issuedAt.Before(denial.issuedBefore) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// removeExpired drops the entries past their expiry, the caller must hold the lock
+func (d *MemoryTokenDenylist) removeExpired() {
+	now := time.Now()
+	for tokenID, expiresAt := range d.tokens {
+		if now.After(expiresAt) {
+			delete(d.tokens, tokenID)
+		}
+	}
+	for userID, denial := range d.users {
+		if now.After(denial.expiresAt) {
+			delete(d.users, userID)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+const (
+	// redisTimeout bounds each command sent to Redis when the context has no deadline
+	redisTimeout = 2 * time.Second
+	// redisKeyPrefix namespaces the keys of the denylist
+	redisKeyPrefix = "orkys:denylist:"
+)
+
+// errRedisNil is returned for the nil reply of a missing key
+var errRedisNil = errors.New("redis: nil")
+
+// RedisTokenDenylist is an implementation of the TokenDenylistRepository interface that uses Redis,
+// so that every instance of the API shares the denylist. Entries expire with the tokens they deny.
+// It speaks the RESP protocol over a single connection, which is enough for the few commands it sends.
+type RedisTokenDenylist struct {
+	addr     string
+	password string
+	db       int
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisTokenDenylist creates a new RedisTokenDenylist, the connection is opened on first use
+func NewRedisTokenDenylist(addr, password string, db int) repo.TokenDenylistRepository {
+	return &RedisTokenDenylist{
+		addr:     addr,
+		password: password,
+		db:       db,
+	}
+}
+
+// DenyToken denies a token until it expires
+func (d *RedisTokenDenylist) DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	_, err := d.do(ctx, "SET", redisKeyPrefix+"token:"+tokenID, "1", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// DenyUserTokens denies the tokens of the user issued before issuedBefore
+func (d *RedisTokenDenylist) DenyUserTokens(ctx context.Context, userID int32, issuedBefore, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := fmt.Sprintf("%suser:%d", redisKeyPrefix, userID)
+	_, err := d.do(ctx, "SET", key, strconv.FormatInt(issuedBefore.Unix(), 10), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// IsDenied returns whether the token was denied, by its ID or with all the tokens of its user
+func (d *RedisTokenDenylist) IsDenied(ctx context.Context, userID int32, tokenID string, issuedAt time.Time) (bool, error) {
+	if tokenID != "" {
+		_, err := d.do(ctx, "GET", redisKeyPrefix+"token:"+tokenID)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, errRedisNil) {
+			return false, err
+		}
+	}
+
+	reply, err := d.do(ctx, "GET", fmt.Sprintf("%suser:%d", redisKeyPrefix, userID))
+	if errors.Is(err, errRedisNil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	issuedBefore, err := strconv.ParseInt(reply, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid denylist entry of user %d: %w", userID, err)
+	}
+
+	return issuedAt.Unix() < issuedBefore, nil
+}
+
+// do sends a command and returns its reply, the connection is dropped on any network error
+func (d *RedisTokenDenylist) do(ctx context.Context, args ...string) (string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.conn == nil {
+		if err := d.connect(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	reply, err := d.send(ctx, args...)
+	if err != nil && !errors.Is(err, errRedisNil) && !isRedisErrorReply(err) {
+		d.conn.Close()
+		d.conn = nil
+	}
+	return reply, err
+}
+
+// connect opens the connection, authenticates and selects the database, the caller must hold the lock
+func (d *RedisTokenDenylist) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: redisTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", d.addr)
+	if err != nil {
+		return err
+	}
+	d.conn = conn
+	d.reader = bufio.NewReader(conn)
+
+	if d.password != "" {
+		if _, err := d.send(ctx, "AUTH", d.password); err != nil {
+			d.conn.Close()
+			d.conn = nil
+			return err
+		}
+	}
+	if d.db != 0 {
+		if _, err := d.send(ctx, "SELECT", strconv.Itoa(d.db)); err != nil {
+			d.conn.Close()
+			d.conn = nil
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send writes a command as a RESP array of bulk strings and reads its reply
+func (d *RedisTokenDenylist) send(ctx context.Context, args ...string) (string, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	if err := d.conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	command := fmt.Sprintf("*%d\r\n", len(args))
+	for _, arg := range args {
+		command += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := d.conn.Write([]byte(command)); err != nil {
+		return "", err
+	}
+
+	return d.readReply()
+}
+
+// redisErrorReply is an error returned by the Redis server, the connection stays usable
+type redisErrorReply struct {
+	message string
+}
+
+func (e *redisErrorReply) Error() string {
+	return "redis: " + e.message
+}
+
+func isRedisErrorReply(err error) bool {
+	var replyErr *redisErrorReply
+	return errors.As(err, &replyErr)
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func (d *RedisTokenDenylist) readReply() (string, error) {
+	line, err := d.readLine()
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return "", errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", &redisErrorReply{message: line[1:]}
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("redis: invalid bulk length %q", line[1:])
+		}
+		if length < 0 {
+			return "", errRedisNil
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(d.reader, data); err != nil {
+			return "", err
+		}
+		return string(data[:length]), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+// readLine reads a line terminated by CRLF, without the terminator
+func (d *RedisTokenDenylist) readLine() (string, error) {
+	line, err := d.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed reply %q", line)
+	}
+	return line[:len(line)-2], nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	repo "github.com/NiskuT/cross-api/internal/domain/repository"
@@ -14,6 +15,8 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	// ErrDuplicateUser is returned when a user with the same ID or email already exists
 	ErrDuplicateUser = errors.New("user with this ID or email already exists")
+	// ErrDuplicateIdentity is returned when the OpenID Connect identity is already linked to a user
+	ErrDuplicateIdentity = errors.New("identity already linked to a user")
 )
 
 // SQLUserRepository is an implementation of the UserRepository interface that uses SQL
@@ -35,13 +38,19 @@ type User struct {
 	FirstName    string
 	LastName     string
 	PasswordHash string
-	Roles        string
+}
+
+// UserRole is an internal representation of a user role for DB operations
+type UserRole struct {
+	UserID        int32
+	Role          string
+	CompetitionID sql.NullInt32
 }
 
 // GetUser retrieves a user by ID
 func (r *SQLUserRepository) GetUser(ctx context.Context, id int32) (*aggregate.User, error) {
 	query := `
-		SELECT id, email, first_name, last_name, password_hash, roles
+		SELECT id, email, first_name, last_name, password_hash
 		FROM users
 		WHERE id = ?
 	`
@@ -54,7 +63,6 @@ func (r *SQLUserRepository) GetUser(ctx context.Context, id int32) (*aggregate.U
 		&user.FirstName,
 		&user.LastName,
 		&user.PasswordHash,
-		&user.Roles,
 	)
 
 	if err != nil {
@@ -70,26 +78,34 @@ func (r *SQLUserRepository) GetUser(ctx context.Context, id int32) (*aggregate.U
 	userAggregate.SetFirstName(user.FirstName)
 	userAggregate.SetLastName(user.LastName)
 	userAggregate.SetPasswordHash(user.PasswordHash)
-	userAggregate.SetRoles(user.Roles)
+
+	if err := r.loadRoles(ctx, []*aggregate.User{userAggregate}); err != nil {
+		return nil, err
+	}
 
 	return userAggregate, nil
 }
 
-// CreateUser creates a new user
+// CreateUser creates a new user with its roles
 func (r *SQLUserRepository) CreateUser(ctx context.Context, user *aggregate.User) error {
 	query := `
-		INSERT INTO users (email, first_name, last_name, password_hash, roles)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO users (email, first_name, last_name, password_hash)
+		VALUES (?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		user.GetEmail(),
 		user.GetFirstName(),
 		user.GetLastName(),
 		user.GetPasswordHash(),
-		user.GetRoles(),
 	)
 
 	if err != nil {
@@ -101,29 +117,40 @@ func (r *SQLUserRepository) CreateUser(ctx context.Context, user *aggregate.User
 	}
 
 	// Get the auto-incremented ID
-	if id, err := result.LastInsertId(); err == nil {
-		user.SetID(int32(id))
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
 	}
+	user.SetID(int32(id))
 
-	return nil
+	if err := insertUserRoles(ctx, tx, user); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// UpdateUser updates an existing user
+// UpdateUser updates an existing user and replaces its roles
 func (r *SQLUserRepository) UpdateUser(ctx context.Context, user *aggregate.User) error {
 	query := `
 		UPDATE users
-		SET email = ?, first_name = ?, last_name = ?, password_hash = ?, roles = ?
+		SET email = ?, first_name = ?, last_name = ?, password_hash = ?
 		WHERE id = ?
 	`
 
-	_, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		user.GetEmail(),
 		user.GetFirstName(),
 		user.GetLastName(),
 		user.GetPasswordHash(),
-		user.GetRoles(),
 		user.GetID(),
 	)
 
@@ -135,7 +162,16 @@ func (r *SQLUserRepository) UpdateUser(ctx context.Context, user *aggregate.User
 		return err
 	}
 
-	return nil
+	_, err = tx.ExecContext(ctx, "DELETE FROM user_roles WHERE user_id = ?", user.GetID())
+	if err != nil {
+		return err
+	}
+
+	if err := insertUserRoles(ctx, tx, user); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // DeleteUser deletes a user by ID
@@ -165,7 +201,7 @@ func (r *SQLUserRepository) DeleteUser(ctx context.Context, id int32) error {
 // GetUserByEmail retrieves a user by email
 func (r *SQLUserRepository) GetUserByEmail(ctx context.Context, email string) (*aggregate.User, error) {
 	query := `
-		SELECT id, email, first_name, last_name, password_hash, roles
+		SELECT id, email, first_name, last_name, password_hash
 		FROM users
 		WHERE email = ?
 	`
@@ -178,7 +214,6 @@ func (r *SQLUserRepository) GetUserByEmail(ctx context.Context, email string) (*
 		&user.FirstName,
 		&user.LastName,
 		&user.PasswordHash,
-		&user.Roles,
 	)
 
 	if err != nil {
@@ -194,7 +229,263 @@ func (r *SQLUserRepository) GetUserByEmail(ctx context.Context, email string) (*
 	userAggregate.SetFirstName(user.FirstName)
 	userAggregate.SetLastName(user.LastName)
 	userAggregate.SetPasswordHash(user.PasswordHash)
-	userAggregate.SetRoles(user.Roles)
+
+	if err := r.loadRoles(ctx, []*aggregate.User{userAggregate}); err != nil {
+		return nil, err
+	}
 
 	return userAggregate, nil
 }
+
+// ListUsers lists all users
+func (r *SQLUserRepository) ListUsers(ctx context.Context) ([]*aggregate.User, error) {
+	query := `
+		SELECT id, email, first_name, last_name, password_hash
+		FROM users
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*aggregate.User{}
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.PasswordHash); err != nil {
+			return nil, err
+		}
+
+		userAggregate := aggregate.NewUser()
+		userAggregate.SetID(user.ID)
+		userAggregate.SetEmail(user.Email)
+		userAggregate.SetFirstName(user.FirstName)
+		userAggregate.SetLastName(user.LastName)
+		userAggregate.SetPasswordHash(user.PasswordHash)
+		users = append(users, userAggregate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadRoles(ctx, users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// SearchUsers lists the users whose first name, last name, full name or email contains the query
+func (r *SQLUserRepository) SearchUsers(ctx context.Context, query string, pageNumber, pageSize int32) ([]*aggregate.User, int32, error) {
+	if pageSize <= 0 {
+		pageSize = 10 // Default page size
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1 // Default page number
+	}
+
+	// Escape LIKE wildcards so they match literally
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+
+	where := `
+		WHERE first_name LIKE ? OR last_name LIKE ? OR email LIKE ?
+		   OR CONCAT(first_name, ' ', last_name) LIKE ? OR CONCAT(last_name, ' ', first_name) LIKE ?
+	`
+	args := []interface{}{pattern, pattern, pattern, pattern, pattern}
+
+	var totalCount int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users"+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT id, email, first_name, last_name, password_hash FROM users"+where+"ORDER BY last_name, first_name, id LIMIT ? OFFSET ?",
+		append(args, pageSize, offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []*aggregate.User{}
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.PasswordHash); err != nil {
+			return nil, 0, err
+		}
+
+		userAggregate := aggregate.NewUser()
+		userAggregate.SetID(user.ID)
+		userAggregate.SetEmail(user.Email)
+		userAggregate.SetFirstName(user.FirstName)
+		userAggregate.SetLastName(user.LastName)
+		userAggregate.SetPasswordHash(user.PasswordHash)
+		users = append(users, userAggregate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.loadRoles(ctx, users); err != nil {
+		return nil, 0, err
+	}
+
+	return users, totalCount, nil
+}
+
+// ListUserRoles lists the roles of a user
+func (r *SQLUserRepository) ListUserRoles(ctx context.Context, userID int32) ([]*aggregate.UserRole, error) {
+	query := `
+		SELECT user_id, role, competition_id
+		FROM user_roles
+		WHERE user_id = ?
+		ORDER BY competition_id, role
+	`
+
+	return r.queryUserRoles(ctx, query, userID)
+}
+
+// ListCompetitionRoles lists the roles granted on a competition
+func (r *SQLUserRepository) ListCompetitionRoles(ctx context.Context, competitionID int32) ([]*aggregate.UserRole, error) {
+	query := `
+		SELECT user_id, role, competition_id
+		FROM user_roles
+		WHERE competition_id = ?
+		ORDER BY role, user_id
+	`
+
+	return r.queryUserRoles(ctx, query, competitionID)
+}
+
+// GetUserByIdentity gets the user linked to the subject of an OpenID Connect provider
+func (r *SQLUserRepository) GetUserByIdentity(ctx context.Context, provider, subject string) (*aggregate.User, error) {
+	query := `
+		SELECT user_id
+		FROM user_identities
+		WHERE provider = ? AND subject = ?
+	`
+
+	var userID int32
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+// LinkIdentity links the subject of an OpenID Connect provider to a user
+func (r *SQLUserRepository) LinkIdentity(ctx context.Context, provider, subject string, userID int32) error {
+	query := `
+		INSERT INTO user_identities (provider, subject, user_id)
+		VALUES (?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, provider, subject, userID)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateIdentity
+		}
+		return err
+	}
+
+	return nil
+}
+
+// queryUserRoles runs a query selecting user_id, role and competition_id from user_roles
+func (r *SQLUserRepository) queryUserRoles(ctx context.Context, query string, args ...interface{}) ([]*aggregate.UserRole, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []*aggregate.UserRole{}
+	for rows.Next() {
+		var role UserRole
+		if err := rows.Scan(&role.UserID, &role.Role, &role.CompetitionID); err != nil {
+			return nil, err
+		}
+		roles = append(roles, aggregate.NewUserRole(role.UserID, role.Role))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// loadRoles sets the roles of the users from the user_roles table
+func (r *SQLUserRepository) loadRoles(ctx context.Context, users []*aggregate.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	usersByID := make(map[int32]*aggregate.User, len(users))
+	placeholders := make([]string, 0, len(users))
+	args := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		usersByID[user.GetID()] = user
+		placeholders = append(placeholders, "?")
+		args = append(args, user.GetID())
+	}
+
+	query := "SELECT user_id, role FROM user_roles WHERE user_id IN (" + strings.Join(placeholders, ",") + ") ORDER BY user_id, role"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	roles := make(map[int32][]string, len(users))
+	for rows.Next() {
+		var userID int32
+		var role string
+		if err := rows.Scan(&userID, &role); err != nil {
+			return err
+		}
+		roles[userID] = append(roles[userID], role)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for id, user := range usersByID {
+		user.SetRoles(strings.Join(roles[id], ","))
+	}
+
+	return nil
+}
+
+// insertUserRoles inserts the roles of the user into the user_roles table, existing roles are kept
+func insertUserRoles(ctx context.Context, tx *sql.Tx, user *aggregate.User) error {
+	query := `
+		INSERT IGNORE INTO user_roles (user_id, role, competition_id)
+		VALUES (?, ?, ?)
+	`
+
+	for _, role := range user.GetRoleList() {
+		userRole := aggregate.NewUserRole(user.GetID(), role)
+		competitionID := sql.NullInt32{Int32: userRole.GetCompetitionID(), Valid: !userRole.IsGlobal()}
+
+		if _, err := tx.ExecContext(ctx, query, user.GetID(), role, competitionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
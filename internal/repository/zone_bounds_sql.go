@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// SQLZoneBoundsRepository is an implementation of the ZoneBoundsRepository interface that uses SQL
+type SQLZoneBoundsRepository struct {
+	db *sql.DB
+}
+
+// NewSQLZoneBoundsRepository creates a new SQLZoneBoundsRepository
+func NewSQLZoneBoundsRepository(db *sql.DB) repo.ZoneBoundsRepository {
+	return &SQLZoneBoundsRepository{
+		db: db,
+	}
+}
+
+// ZoneBounds is an internal representation of the bounds of a zone for DB operations
+type ZoneBounds struct {
+	CompetitionID int32
+	Zone          string
+	MaxChronoSec  int32
+	MaxPenality   int32
+}
+
+// SetZoneBounds stores the bounds of a zone, replacing the previous ones
+func (r *SQLZoneBoundsRepository) SetZoneBounds(ctx context.Context, bounds *aggregate.ZoneBounds) error {
+	query := `
+		INSERT INTO zone_bounds (competition_id, zone, max_chrono_sec, max_penality)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE max_chrono_sec = VALUES(max_chrono_sec), max_penality = VALUES(max_penality)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		bounds.GetCompetitionID(),
+		bounds.GetZone(),
+		bounds.GetMaxChronoSec(),
+		bounds.GetMaxPenality(),
+	)
+	return err
+}
+
+// ListZoneBounds lists the bounds of the zones of a competition, zones without bounds are left out
+func (r *SQLZoneBoundsRepository) ListZoneBounds(ctx context.Context, competitionID int32) ([]*aggregate.ZoneBounds, error) {
+	query := `
+		SELECT competition_id, zone, max_chrono_sec, max_penality
+		FROM zone_bounds
+		WHERE competition_id = ?
+		ORDER BY zone
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	boundsList := []*aggregate.ZoneBounds{}
+	for rows.Next() {
+		var bounds ZoneBounds
+		err := rows.Scan(
+			&bounds.CompetitionID,
+			&bounds.Zone,
+			&bounds.MaxChronoSec,
+			&bounds.MaxPenality,
+		)
+		if err != nil {
+			return nil, err
+		}
+		boundsList = append(boundsList, toZoneBoundsAggregate(bounds))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return boundsList, nil
+}
+
+// toZoneBoundsAggregate converts the internal representation to an aggregate
+func toZoneBoundsAggregate(bounds ZoneBounds) *aggregate.ZoneBounds {
+	boundsAggregate := aggregate.NewZoneBounds()
+	boundsAggregate.SetCompetitionID(bounds.CompetitionID)
+	boundsAggregate.SetZone(bounds.Zone)
+	boundsAggregate.SetMaxChronoSec(bounds.MaxChronoSec)
+	boundsAggregate.SetMaxPenality(bounds.MaxPenality)
+	return boundsAggregate
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	repo "github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+var (
+	// ErrZoneNotFound is returned when a zone cannot be found
+	ErrZoneNotFound = errors.New("zone not found")
+	// ErrDuplicateZone is returned when the competition already has a zone with this name
+	ErrDuplicateZone = errors.New("zone already exists for the competition")
+)
+
+// SQLZoneRepository is an implementation of the ZoneRepository interface that uses SQL
+type SQLZoneRepository struct {
+	db *sql.DB
+}
+
+// NewSQLZoneRepository creates a new SQLZoneRepository
+func NewSQLZoneRepository(db *sql.DB) repo.ZoneRepository {
+	return &SQLZoneRepository{
+		db: db,
+	}
+}
+
+// Zone is an internal representation of a zone for DB operations
+type Zone struct {
+	CompetitionID int32
+	Zone          string
+	Description   string
+	Location      string
+	Latitude      sql.NullFloat64
+	Longitude     sql.NullFloat64
+	PhotoURL      string
+	Open          bool
+}
+
+// CreateZone creates a new zone
+func (r *SQLZoneRepository) CreateZone(ctx context.Context, zone *aggregate.Zone) error {
+	query := `
+		INSERT INTO zones (competition_id, zone, description, location, latitude, longitude, photo_url, is_open)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	latitude, longitude := toNullCoordinates(zone)
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		zone.GetCompetitionID(),
+		zone.GetZone(),
+		zone.GetDescription(),
+		zone.GetLocation(),
+		latitude,
+		longitude,
+		zone.GetPhotoURL(),
+		zone.IsOpen(),
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrDuplicateZone
+		}
+		return err
+	}
+
+	return nil
+}
+
+// EnsureZone creates a zone without description, nothing is done when the competition already has the zone
+func (r *SQLZoneRepository) EnsureZone(ctx context.Context, competitionID int32, zone string) error {
+	query := `
+		INSERT IGNORE INTO zones (competition_id, zone)
+		VALUES (?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, competitionID, zone)
+	return err
+}
+
+// GetZone retrieves a zone of the competition by its name
+func (r *SQLZoneRepository) GetZone(ctx context.Context, competitionID int32, zoneName string) (*aggregate.Zone, error) {
+	query := `
+		SELECT competition_id, zone, description, location, latitude, longitude, photo_url, is_open
+		FROM zones
+		WHERE competition_id = ? AND zone = ?
+	`
+
+	var zone Zone
+	err := r.db.QueryRowContext(ctx, query, competitionID, zoneName).Scan(
+		&zone.CompetitionID,
+		&zone.Zone,
+		&zone.Description,
+		&zone.Location,
+		&zone.Latitude,
+		&zone.Longitude,
+		&zone.PhotoURL,
+		&zone.Open,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrZoneNotFound
+		}
+		return nil, err
+	}
+
+	return mapToZoneAggregate(zone), nil
+}
+
+// ListZones lists the zones of a competition by name
+func (r *SQLZoneRepository) ListZones(ctx context.Context, competitionID int32) ([]*aggregate.Zone, error) {
+	query := `
+		SELECT competition_id, zone, description, location, latitude, longitude, photo_url, is_open
+		FROM zones
+		WHERE competition_id = ?
+		ORDER BY zone
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []*aggregate.Zone{}
+	for rows.Next() {
+		var zone Zone
+		err := rows.Scan(
+			&zone.CompetitionID,
+			&zone.Zone,
+			&zone.Description,
+			&zone.Location,
+			&zone.Latitude,
+			&zone.Longitude,
+			&zone.PhotoURL,
+			&zone.Open,
+		)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, mapToZoneAggregate(zone))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// UpdateZone updates the description of a zone, not whether it is open. Its name cannot change as the scales reference it by name
+func (r *SQLZoneRepository) UpdateZone(ctx context.Context, zone *aggregate.Zone) error {
+	// The zone is selected first so that updating it with unchanged values is not mistaken for a missing zone,
+	// the updated zone keeps its open state
+	current, err := r.GetZone(ctx, zone.GetCompetitionID(), zone.GetZone())
+	if err != nil {
+		return err
+	}
+	zone.SetOpen(current.IsOpen())
+
+	query := `
+		UPDATE zones
+		SET description = ?, location = ?, latitude = ?, longitude = ?, photo_url = ?
+		WHERE competition_id = ? AND zone = ?
+	`
+
+	latitude, longitude := toNullCoordinates(zone)
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		zone.GetDescription(),
+		zone.GetLocation(),
+		latitude,
+		longitude,
+		zone.GetPhotoURL(),
+		zone.GetCompetitionID(),
+		zone.GetZone(),
+	)
+	return err
+}
+
+// SetZoneOpen opens or closes a zone of the competition to new runs
+func (r *SQLZoneRepository) SetZoneOpen(ctx context.Context, competitionID int32, zone string, open bool) error {
+	// The zone is selected first so that setting its current state is not mistaken for a missing zone
+	if _, err := r.GetZone(ctx, competitionID, zone); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE zones
+		SET is_open = ?
+		WHERE competition_id = ? AND zone = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, open, competitionID, zone)
+	return err
+}
+
+// DeleteZone deletes a zone of the competition
+func (r *SQLZoneRepository) DeleteZone(ctx context.Context, competitionID int32, zone string) error {
+	query := `
+		DELETE FROM zones
+		WHERE competition_id = ? AND zone = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, competitionID, zone)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrZoneNotFound
+	}
+
+	return nil
+}
+
+// toNullCoordinates returns the coordinates of the zone as nullable columns
+func toNullCoordinates(zone *aggregate.Zone) (sql.NullFloat64, sql.NullFloat64) {
+	latitude, longitude, ok := zone.GetCoordinates()
+	return sql.NullFloat64{Float64: latitude, Valid: ok}, sql.NullFloat64{Float64: longitude, Valid: ok}
+}
+
+// Helper function to map a Zone struct to a Zone aggregate
+func mapToZoneAggregate(zone Zone) *aggregate.Zone {
+	zoneAggregate := aggregate.NewZone()
+	zoneAggregate.SetCompetitionID(zone.CompetitionID)
+	zoneAggregate.SetZone(zone.Zone)
+	zoneAggregate.SetDescription(zone.Description)
+	zoneAggregate.SetLocation(zone.Location)
+	if zone.Latitude.Valid && zone.Longitude.Valid {
+		zoneAggregate.SetCoordinates(zone.Latitude.Float64, zone.Longitude.Float64)
+	}
+	zoneAggregate.SetPhotoURL(zone.PhotoURL)
+	zoneAggregate.SetOpen(zone.Open)
+	return zoneAggregate
+}
@@ -0,0 +1,396 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// pruneRoles godoc
+// @Summary      Prune roles of past competitions
+// @Description  Removes the referee roles of competitions older than the configured retention (ROLES_RETENTION_MONTHS) and lists the users whose roles still exceed ROLES_WARNING_LENGTH characters in the tokens
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.RolesPruneResponse  "Returns the pruning report"
+// @Failure      401     {object}  models.ErrorResponse       "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse       "Forbidden (super admin access required)"
+// @Failure      500     {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /admin/roles/prune [post]
+func (s *Server) pruneRoles(c *gin.Context) {
+	report, err := s.userService.PruneExpiredRoles(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRolesPruneResponse(report))
+}
+
+// listAllUsers godoc
+// @Summary      List all users
+// @Description  Lists every user account, optionally filtered by name or email, for support purposes
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie     header    string  true   "Authentication cookie"
+// @Param        q          query     string  false  "Text contained in the name or email"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 10, at most 100)"
+// @Success      200        {object}  models.UserSearchResponse  "Returns a page of users"
+// @Failure      401        {object}  models.ErrorResponse       "Unauthorized (invalid credentials)"
+// @Failure      403        {object}  models.ErrorResponse       "Forbidden (super admin access required)"
+// @Failure      500        {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /admin/users [get]
+func (s *Server) listAllUsers(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+
+	page, pageSize := getPagination(c)
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	users, total, err := s.userService.SearchUsers(c, query, page, pageSize)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserSearchResponse(query, page, pageSize, total, users))
+}
+
+// impersonateUser godoc
+// @Summary      Impersonate a user
+// @Description  Logs the super admin in as the user, e.g. to see what an organizer sees. The tokens of the user replace those of the super admin in the cookies, the session is listed in the sessions of the user and the impersonation in their security events. Super admins cannot be impersonated.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Param        userID  path      int     true  "User ID"
+// @Success      200     {object}  models.RoleResponse   "Returns the roles of the user and their tokens in cookies"
+// @Failure      400     {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse  "Forbidden (super admin access required, or the user is a super admin)"
+// @Failure      404     {object}  models.ErrorResponse  "User not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /admin/users/{userID}/impersonate [post]
+func (s *Server) impersonateUser(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	superAdmin, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	token, err := s.userService.Impersonate(c, superAdmin.Email, int32(userID))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, service.ErrCannotImpersonateSuperAdmin):
+			RespondError(c, http.StatusForbidden, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	log.Info().Str("super_admin", superAdmin.Email).Int("user_id", userID).Msg("User impersonated")
+
+	middlewares.SetTokenCookies(c, token)
+
+	// Add headers when tokens are set
+	c.Header("x-token-refreshed", "true")
+	if roles := token.GetRoles(); len(roles) > 0 {
+		rolesJSON, err := json.Marshal(roles)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("x-user-roles", string(rolesJSON))
+	}
+
+	c.JSON(http.StatusOK, models.RoleResponse{
+		Roles: token.GetRoles(),
+	})
+}
+
+// deleteCompetition godoc
+// @Summary      Delete a competition
+// @Description  Deletes any competition with its participants, runs, rankings, scales, contacts, invitations and API keys. The audit log of the competition is kept.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      204            "Competition deleted"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (super admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /admin/competition/{competitionID} [delete]
+func (s *Server) deleteCompetition(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competitionID"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = s.competitionService.DeleteCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(int32(competitionID))
+	if user, err := middlewares.GetUser(c); err == nil {
+		auditLog.SetUserID(user.Id)
+	}
+	auditLog.SetAction(aggregate.AuditActionCompetitionDeleted)
+	auditLog.SetDetails(competition.GetName())
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int("competition_id", competitionID).Msg("Failed to record competition deletion")
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getSystemStats godoc
+// @Summary      Get system stats
+// @Description  Counts the users, competitions, participants, runs, active sessions and API keys of the whole platform
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.SystemStatsResponse  "Returns the stats"
+// @Failure      401     {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse        "Forbidden (super admin access required)"
+// @Failure      500     {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /admin/stats [get]
+func (s *Server) getSystemStats(c *gin.Context) {
+	stats, err := s.statsService.GetSystemStats(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SystemStatsResponse{
+		Users:          stats.GetUsers(),
+		Competitions:   stats.GetCompetitions(),
+		Participants:   stats.GetParticipants(),
+		Runs:           stats.GetRuns(),
+		ActiveSessions: stats.GetActiveSessions(),
+		APIKeys:        stats.GetAPIKeys(),
+	})
+}
+
+// getRateLimits godoc
+// @Summary      Inspect the rate limiter
+// @Description  Lists the limit of every rate limited endpoint with the requests it rejected since startup, and the IP addresses and users
+// @Description  currently tracked with their remaining attempts and reset time, e.g. to debug clients rejected behind a shared venue NAT.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie    header    string  true   "Authentication cookie"
+// @Param        endpoint  query     string  false  "Only list the keys of this endpoint"
+// @Param        key       query     string  false  "Only list the keys containing this text, e.g. an IP address or user-42"
+// @Success      200       {object}  models.RateLimitStateResponse  "Returns the state of the rate limiter"
+// @Failure      401       {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403       {object}  models.ErrorResponse           "Forbidden (super admin access required)"
+// @Router       /admin/rate-limits [get]
+func (s *Server) getRateLimits(c *gin.Context) {
+	endpointFilter := c.Query("endpoint")
+	keyFilter := c.Query("key")
+
+	response := models.RateLimitStateResponse{
+		Endpoints: []models.RateLimitEndpointResponse{},
+		Keys:      []models.RateLimitKeyResponse{},
+	}
+	for _, endpoint := range s.rateLimiter.Endpoints() {
+		response.Endpoints = append(response.Endpoints, models.RateLimitEndpointResponse{
+			Endpoint:      endpoint.Endpoint,
+			MaxAttempts:   endpoint.MaxAttempts,
+			WindowSeconds: int64(endpoint.Window.Seconds()),
+			Rejections:    endpoint.Rejections,
+		})
+	}
+	for _, key := range s.rateLimiter.Keys() {
+		if endpointFilter != "" && key.Endpoint != endpointFilter {
+			continue
+		}
+		if keyFilter != "" && !strings.Contains(key.Key, keyFilter) {
+			continue
+		}
+		response.Keys = append(response.Keys, models.RateLimitKeyResponse{
+			Endpoint:  key.Endpoint,
+			Key:       key.Key,
+			Attempts:  key.Attempts,
+			Remaining: key.Remaining,
+			ResetAt:   key.ResetAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toRolesPruneResponse builds the response of a roles pruning run
+func toRolesPruneResponse(report *aggregate.RolesPruneReport) models.RolesPruneResponse {
+	response := models.RolesPruneResponse{
+		ExpiredCompetitionIDs: report.GetExpiredCompetitionIDs(),
+		PrunedUsers:           report.GetPrunedUsers(),
+		RemovedRoles:          report.GetRemovedRoles(),
+		NearLimitUsers:        make([]models.RolesNearLimitUser, 0, len(report.GetNearLimitUsers())),
+	}
+	for _, user := range report.GetNearLimitUsers() {
+		response.NearLimitUsers = append(response.NearLimitUsers, models.RolesNearLimitUser{
+			ID:          user.GetID(),
+			Email:       user.GetEmail(),
+			RolesLength: len(user.GetRoles()),
+		})
+	}
+	return response
+}
+
+// getDegradedMode godoc
+// @Summary      Get the degraded mode
+// @Description  Returns whether the degraded mode is enabled and the number of liverankings waiting for the next batch recalculation
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.DegradedModeResponse  "Returns the state of the degraded mode"
+// @Failure      401     {object}  models.ErrorResponse         "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse         "Forbidden (super admin access required)"
+// @Router       /admin/degraded-mode [get]
+func (s *Server) getDegradedMode(c *gin.Context) {
+	c.JSON(http.StatusOK, s.toDegradedModeResponse())
+}
+
+// setDegradedMode godoc
+// @Summary      Switch the degraded mode
+// @Description  Switches the degraded mode on or off when the venue connection is saturated. In degraded mode the notification emails are skipped
+// @Description  and the liverankings are recalculated in batches every DEGRADED_LIVERANKING_INTERVAL instead of on every run.
+// @Description  Switching it off recalculates the deferred liverankings right away.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string                    true  "Authentication cookie"
+// @Param        input   body      models.DegradedModeInput  true  "Whether the degraded mode is enabled"
+// @Success      200     {object}  models.DegradedModeResponse  "Returns the state of the degraded mode"
+// @Failure      400     {object}  models.ErrorResponse         "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse         "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse         "Forbidden (super admin access required)"
+// @Failure      500     {object}  models.ErrorResponse         "Internal Server Error (the liverankings stay pending for the next batch)"
+// @Router       /admin/degraded-mode [put]
+func (s *Server) setDegradedMode(c *gin.Context) {
+	var input models.DegradedModeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	if input.Enabled {
+		s.degradedMode.Enable(user.Id)
+	} else if err := s.degradedMode.Disable(c); err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetUserID(user.Id)
+	auditLog.SetAction(aggregate.AuditActionDegradedModeChanged)
+	auditLog.SetDetails(strconv.FormatBool(input.Enabled))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Bool("enabled", input.Enabled).Msg("Failed to record degraded mode change")
+	}
+
+	c.JSON(http.StatusOK, s.toDegradedModeResponse())
+}
+
+// toDegradedModeResponse builds the response describing the state of the degraded mode
+func (s *Server) toDegradedModeResponse() models.DegradedModeResponse {
+	enabled, enabledAt, enabledBy, pending := s.degradedMode.Status()
+
+	response := models.DegradedModeResponse{
+		Enabled:                    enabled,
+		EnabledBy:                  enabledBy,
+		PendingLiverankings:        pending,
+		LiverankingIntervalSeconds: int64(s.degradedMode.Interval().Seconds()),
+	}
+	if enabled {
+		response.EnabledAt = &enabledAt
+	}
+	return response
+}
+
+// getSchemaStatus godoc
+// @Summary      Get the migration status of the schema
+// @Description  Returns the schema version this instance expects, the version the database was migrated to and the tables and columns it lacks.
+// @Description  An instance started before the database was migrated by a newer version is read-only and refuses the requests modifying data.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.SchemaStatusResponse  "Returns the migration status of the schema"
+// @Failure      401     {object}  models.ErrorResponse         "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse         "Forbidden (super admin access required)"
+// @Failure      500     {object}  models.ErrorResponse         "Internal Server Error"
+// @Router       /admin/schema [get]
+func (s *Server) getSchemaStatus(c *gin.Context) {
+	status, err := s.schemaGuard.Status(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.SchemaStatusResponse{
+		ExpectedVersion: status.ExpectedVersion,
+		DatabaseVersion: status.DatabaseVersion,
+		CheckedAt:       status.CheckedAt,
+		ReadOnly:        status.ReadOnly,
+		Missing:         status.Missing,
+	}
+	if !status.MigratedAt.IsZero() {
+		response.MigratedAt = &status.MigratedAt
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// inviteAdmin godoc
+// @Summary      Invite a co-admin to a competition
+// @Description  Emails an invitation link granting admin access to the competition, valid for 72 hours
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string             true  "Authentication cookie"
+// @Param        admin   body      models.AdminInput  true  "Competition and email of the invited admin"
+// @Success      200     {object}  gin.H                 "Invitation sent"
+// @Failure      400     {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      500     {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/admin [post]
+func (s *Server) inviteAdmin(c *gin.Context) {
+	var adminInput models.AdminInput
+	if err := c.ShouldBindJSON(&adminInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err := checkHasAdminAccessToCompetition(c, adminInput.CompetitionID)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, adminInput.CompetitionID)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	invitation, err := s.userService.InviteAdmin(c, adminInput.Email, competition)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Admin invitation sent",
+		"invitation_id": invitation.GetID(),
+	})
+}
+
+// generateAdminInvitationLink godoc
+// @Summary      Generate co-admin invitation token
+// @Description  Generates an invitation token granting admin access to the competition
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie        header    string  true   "Authentication cookie"
+// @Param        competitionID path      int     true   "Competition ID"
+// @Success      200           {object}  models.RefereeInvitationResponse  "Returns invitation token"
+// @Failure      400           {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403           {object}  models.ErrorResponse              "Forbidden (admin access required)"
+// @Failure      500           {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/admin/invitation [get]
+func (s *Server) generateAdminInvitationLink(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	token, invitation, err := s.userService.GenerateAdminInvitationToken(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RefereeInvitationResponse{
+		InvitationID: invitation.GetID(),
+		Token:        token,
+		ExpiresAt:    invitation.GetExpiresAt().Unix(),
+	})
+}
+
+// acceptAdminInvitation godoc
+// @Summary      Accept co-admin invitation
+// @Description  Accepts a co-admin invitation and makes the user admin of the competition
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie     header    string                               true  "Authentication cookie"
+// @Param        invitation body      models.RefereeInvitationAcceptInput  true  "Invitation token"
+// @Success      200        {object}  gin.H                                "Successfully accepted invitation"
+// @Failure      400        {object}  models.ErrorResponse                 "Bad Request"
+// @Failure      401        {object}  models.ErrorResponse                 "Unauthorized (invalid credentials)"
+// @Failure      409        {object}  models.AlreadyMemberErrorResponse    "Already an admin of the competition"
+// @Failure      500        {object}  models.ErrorResponse                 "Internal Server Error"
+// @Router       /admin/invitation/accept [post]
+func (s *Server) acceptAdminInvitation(c *gin.Context) {
+	var invitationInput models.RefereeInvitationAcceptInput
+	if err := c.ShouldBindJSON(&invitationInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Get current user from context
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	tokens, err := s.userService.AcceptAdminInvitation(c, invitationInput.Token, user.Email)
+	if err != nil {
+		if respondAlreadyMemberError(c, err) {
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid or expired invitation token"))
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	// Set new tokens in cookies
+	middlewares.SetTokenCookies(c, tokens)
+
+	// Add headers when tokens are set
+	c.Header("x-token-refreshed", "true")
+	if roles := tokens.GetRoles(); len(roles) > 0 {
+		rolesJSON, err := json.Marshal(roles)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("x-user-roles", string(rolesJSON))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Admin invitation accepted successfully"})
+}
+
+// acceptAdminInvitationUnauthenticated godoc
+// @Summary      Accept co-admin invitation (unauthenticated)
+// @Description  Accepts a co-admin invitation for unauthenticated users, creating account if needed
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        invitation body      models.RefereeInvitationAcceptUnauthenticatedInput   true  "Invitation data with user details"
+// @Success      200        {object}  gin.H                                                "Successfully accepted invitation and logged in"
+// @Failure      400        {object}  models.PasswordPolicyErrorResponse                   "Bad Request, or password breaking the password policy"
+// @Failure      401        {object}  models.ErrorResponse                                 "Invalid credentials"
+// @Failure      409        {object}  models.AlreadyMemberErrorResponse                    "Already an admin of the competition"
+// @Failure      500        {object}  models.ErrorResponse                                 "Internal Server Error"
+// @Router       /admin/invitation/accept-unauthenticated [post]
+func (s *Server) acceptAdminInvitationUnauthenticated(c *gin.Context) {
+	var invitationInput models.RefereeInvitationAcceptUnauthenticatedInput
+	if err := c.ShouldBindJSON(&invitationInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tokens, err := s.userService.AcceptAdminInvitationUnauthenticated(
+		c,
+		invitationInput.Token,
+		invitationInput.FirstName,
+		invitationInput.LastName,
+		invitationInput.Email,
+		invitationInput.Password,
+	)
+	if err != nil {
+		if respondPasswordPolicyError(c, err) || respondAlreadyMemberError(c, err) {
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid or expired invitation token"))
+		} else if err == service.ErrInvalidCredentials {
+			RespondError(c, http.StatusUnauthorized, errors.New("invalid email or password"))
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	// Set tokens in cookies
+	middlewares.SetTokenCookies(c, tokens)
+
+	// Add headers when tokens are set
+	c.Header("x-token-refreshed", "true")
+	if roles := tokens.GetRoles(); len(roles) > 0 {
+		rolesJSON, err := json.Marshal(roles)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("x-user-roles", string(rolesJSON))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Admin invitation accepted successfully"})
+}
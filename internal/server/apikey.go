@@ -0,0 +1,182 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// createAPIKey godoc
+// @Summary      Create an API key
+// @Description  Creates an API key for an external integration (timing system, display board) of the competition. Available scopes are read-liveranking and write-runs. The key is only returned in this response and must be sent in the X-Api-Key header.
+// @Tags         apikey
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string              true  "Authentication cookie"
+// @Param        competitionID  path      int                 true  "Competition ID"
+// @Param        apikey         body      models.APIKeyInput  true  "API key data"
+// @Success      201            {object}  models.APIKeyCreatedResponse  "Returns the created API key"
+// @Failure      400            {object}  models.ErrorResponse          "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse          "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse          "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse          "Internal Server Error"
+// @Router       /competition/{competitionID}/apikeys [post]
+func (s *Server) createAPIKey(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.APIKeyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	apiKey, plainKey, err := s.apiKeyService.CreateAPIKey(c, int32(competitionID), input.Name, input.Scopes, user.Id)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKeyScope) || errors.Is(err, service.ErrAPIKeyScopesRequired) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIKeyCreatedResponse{
+		APIKeyResponse: toAPIKeyResponse(apiKey),
+		Key:            plainKey,
+	})
+}
+
+// listAPIKeys godoc
+// @Summary      List API keys
+// @Description  Lists the API keys of the competition, including revoked ones. Secret values are never returned.
+// @Tags         apikey
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.APIKeyListResponse  "Returns the API keys"
+// @Failure      400            {object}  models.ErrorResponse       "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse       "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse       "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /competition/{competitionID}/apikeys [get]
+func (s *Server) listAPIKeys(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	apiKeys, err := s.apiKeyService.ListAPIKeys(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.APIKeyListResponse{
+		APIKeys: make([]models.APIKeyResponse, 0, len(apiKeys)),
+	}
+	for _, apiKey := range apiKeys {
+		response.APIKeys = append(response.APIKeys, toAPIKeyResponse(apiKey))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// revokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Revokes an API key of the competition, requests using it are rejected immediately
+// @Tags         apikey
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        keyID          path      int     true  "API key ID"
+// @Success      200            {object}  gin.H                 "Returns success message"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "API key not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/apikeys/{keyID} [delete]
+func (s *Server) revokeAPIKey(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	keyID, err := strconv.ParseInt(c.Param("keyID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid API key ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.apiKeyService.RevokeAPIKey(c, int32(competitionID), int32(keyID))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
+
+func toAPIKeyResponse(apiKey *aggregate.APIKey) models.APIKeyResponse {
+	response := models.APIKeyResponse{
+		ID:            apiKey.GetID(),
+		CompetitionID: apiKey.GetCompetitionID(),
+		Name:          apiKey.GetName(),
+		Prefix:        apiKey.GetPrefix(),
+		Scopes:        apiKey.GetScopes(),
+		CreatedAt:     apiKey.GetCreatedAt(),
+	}
+	if lastUsedAt := apiKey.GetLastUsedAt(); !lastUsedAt.IsZero() {
+		response.LastUsedAt = &lastUsedAt
+	}
+	if revokedAt := apiKey.GetRevokedAt(); !revokedAt.IsZero() {
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// listAuditLogs godoc
+// @Summary      List the audit log of a competition
+// @Description  Lists the audit log entries of the competition, most recent first, e.g. users flagged for recording runs at an abnormal pace
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        page           query     int     false  "Page number (default: 1)"
+// @Param        page_size      query     int     false  "Page size (default: 10)"
+// @Success      200            {object}  models.AuditLogListResponse  "Returns the audit log entries"
+// @Failure      400            {object}  models.ErrorResponse         "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse         "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse         "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse         "Internal Server Error"
+// @Router       /competition/{competitionID}/audit-logs [get]
+func (s *Server) listAuditLogs(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	page, pageSize := getPagination(c)
+
+	auditLogs, total, err := s.auditService.ListAuditLogs(c, int32(competitionID), page, pageSize)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.AuditLogListResponse{
+		CompetitionID: int32(competitionID),
+		Page:          page,
+		PageSize:      pageSize,
+		Total:         total,
+		Entries:       make([]models.AuditLogResponse, 0, len(auditLogs)),
+	}
+	for _, auditLog := range auditLogs {
+		response.Entries = append(response.Entries, models.AuditLogResponse{
+			ID:        auditLog.GetID(),
+			UserID:    auditLog.GetUserID(),
+			Action:    auditLog.GetAction(),
+			Details:   auditLog.GetDetails(),
+			IP:        auditLog.GetIP(),
+			CreatedAt: auditLog.GetCreatedAt(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// addCategory godoc
+// @Summary      Add a category
+// @Description  Adds a category to the competition with its age limits and display order, a zero age is not limited.
+// @Description  Once a competition has categories, its participants and zones must be in one of them.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                true  "Authentication cookie"
+// @Param        competitionID  path      int                   true  "Competition ID"
+// @Param        category       body      models.CategoryInput  true  "Category data"
+// @Success      201            {object}  models.CategoryResponse  "Returns the created category"
+// @Failure      400            {object}  models.ErrorResponse     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse     "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse     "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse     "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse     "Category already exists"
+// @Failure      500            {object}  models.ErrorResponse     "Internal Server Error"
+// @Router       /competition/{competitionID}/categories [post]
+func (s *Server) addCategory(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	category := toCategoryAggregate(int32(competitionID), input)
+
+	err = s.competitionService.AddCategory(c, category)
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCategoryResponse(category))
+}
+
+// listCategories godoc
+// @Summary      List categories
+// @Description  Lists the categories of the competition by display order
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.CategoryListResponse  "Returns the categories"
+// @Failure      400            {object}  models.ErrorResponse         "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse         "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse         "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse         "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse         "Internal Server Error"
+// @Router       /competition/{competitionID}/categories [get]
+func (s *Server) listCategories(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	categories, err := s.competitionService.ListCategories(c, int32(competitionID))
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	response := models.CategoryListResponse{
+		CompetitionID: int32(competitionID),
+		Categories:    make([]models.CategoryResponse, 0, len(categories)),
+	}
+	for _, category := range categories {
+		response.Categories = append(response.Categories, toCategoryResponse(category))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// updateCategory godoc
+// @Summary      Update a category
+// @Description  Updates the name, age limits and display order of a category, it cannot be renamed while participants or zones use it
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                true  "Authentication cookie"
+// @Param        competitionID  path      int                   true  "Competition ID"
+// @Param        categoryID     path      int                   true  "Category ID"
+// @Param        category       body      models.CategoryInput  true  "Category data"
+// @Success      200            {object}  models.CategoryResponse  "Returns the updated category"
+// @Failure      400            {object}  models.ErrorResponse     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse     "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse     "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse     "Category not found"
+// @Failure      409            {object}  models.ErrorResponse     "Category already exists or is in use"
+// @Failure      500            {object}  models.ErrorResponse     "Internal Server Error"
+// @Router       /competition/{competitionID}/categories/{categoryID} [put]
+func (s *Server) updateCategory(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	categoryID, err := strconv.ParseInt(c.Param("categoryID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid category ID"))
+		return
+	}
+
+	var input models.CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	category := toCategoryAggregate(int32(competitionID), input)
+	category.SetID(int32(categoryID))
+
+	err = s.competitionService.UpdateCategory(c, category)
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCategoryResponse(category))
+}
+
+// deleteCategory godoc
+// @Summary      Delete a category
+// @Description  Removes a category from the competition, it cannot be removed while participants or zones use it
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        categoryID     path      int     true  "Category ID"
+// @Success      204            "Category removed"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Category not found"
+// @Failure      409            {object}  models.ErrorResponse  "Category is in use"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/categories/{categoryID} [delete]
+func (s *Server) deleteCategory(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	categoryID, err := strconv.ParseInt(c.Param("categoryID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid category ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.competitionService.DeleteCategory(c, int32(competitionID), int32(categoryID))
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondCategoryError responds with the status matching an error of the category endpoints
+func respondCategoryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptyCategoryName), errors.Is(err, service.ErrInvalidCategoryAges):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrCompetitionNotFound), errors.Is(err, repository.ErrCategoryNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrDuplicateCategory), errors.Is(err, service.ErrCategoryInUse):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// toCategoryAggregate builds the category of the competition described by the input
+func toCategoryAggregate(competitionID int32, input models.CategoryInput) *aggregate.Category {
+	category := aggregate.NewCategory()
+	category.SetCompetitionID(competitionID)
+	category.SetName(input.Name)
+	category.SetMinAge(input.MinAge)
+	category.SetMaxAge(input.MaxAge)
+	category.SetDisplayOrder(input.DisplayOrder)
+	return category
+}
+
+// toCategoryResponse builds the response describing a category
+func toCategoryResponse(category *aggregate.Category) models.CategoryResponse {
+	return models.CategoryResponse{
+		ID:           category.GetID(),
+		Name:         category.GetName(),
+		MinAge:       category.GetMinAge(),
+		MaxAge:       category.GetMaxAge(),
+		DisplayOrder: category.GetDisplayOrder(),
+	}
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// checkInParticipant godoc
+// @Summary      Check in a participant
+// @Description  Confirms at the start desk that the participant is present. Checking in a participant already checked in changes nothing.
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        dossard        path      int     true  "Dossard Number"
+// @Success      200            {object}  models.ParticipantResponse  "Returns the checked in participant"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse        "Competition or participant not found"
+// @Failure      409            {object}  models.ErrorResponse        "The participant is waitlisted"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/checkin [post]
+func (s *Server) checkInParticipant(c *gin.Context) {
+	s.setParticipantCheckIn(c, true)
+}
+
+// cancelParticipantCheckIn godoc
+// @Summary      Cancel the check-in of a participant
+// @Description  Withdraws the presence of a participant checked in by mistake
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        dossard        path      int     true  "Dossard Number"
+// @Success      200            {object}  models.ParticipantResponse  "Returns the participant"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse        "Competition or participant not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/checkin [delete]
+func (s *Server) cancelParticipantCheckIn(c *gin.Context) {
+	s.setParticipantCheckIn(c, false)
+}
+
+// setParticipantCheckIn checks in the participant of the request or cancels its check-in, for the admins and referees of the competition
+func (s *Server) setParticipantCheckIn(c *gin.Context, checkedIn bool) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	if err := checkHasAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	participant, err := s.competitionService.CheckInParticipant(c, int32(competitionID), int32(dossard), checkedIn)
+	if err != nil {
+		respondCheckInError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ParticipantResponse{
+		CompetitionID: participant.GetCompetitionID(),
+		DossardNumber: participant.GetDossardNumber(),
+		FirstName:     participant.GetFirstName(),
+		LastName:      participant.GetLastName(),
+		Category:      participant.GetCategory(),
+		Gender:        participant.GetGender(),
+		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	})
+}
+
+// getCheckInSummary godoc
+// @Summary      Get the check-in summary
+// @Description  Counts the participants checked in at the start desk and the ones still missing, in total and by category.
+// @Description  Waitlisted participants do not start and are not counted.
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.CheckInSummaryResponse  "Returns the check-in summary"
+// @Failure      400            {object}  models.ErrorResponse           "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse           "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse           "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse           "Internal Server Error"
+// @Router       /competition/{competitionID}/checkin [get]
+func (s *Server) getCheckInSummary(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	if err := checkHasReadAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	counts, err := s.competitionService.GetCheckInSummary(c, int32(competitionID))
+	if err != nil {
+		respondCheckInError(c, err)
+		return
+	}
+
+	response := models.CheckInSummaryResponse{
+		CompetitionID: int32(competitionID),
+		Categories:    make([]models.CheckInCountResponse, 0, len(counts)),
+	}
+	for _, count := range counts {
+		response.Registered += count.GetRegistered()
+		response.CheckedIn += count.GetCheckedIn()
+		response.Missing += count.GetMissing()
+		response.Categories = append(response.Categories, models.CheckInCountResponse{
+			Category:   count.GetCategory(),
+			Registered: count.GetRegistered(),
+			CheckedIn:  count.GetCheckedIn(),
+			Missing:    count.GetMissing(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondCheckInError maps the errors of the check-in to their status
+func respondCheckInError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrCompetitionNotFound):
+		RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+	case errors.Is(err, repository.ErrParticipantNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrParticipantWaitlistedCheckIn):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
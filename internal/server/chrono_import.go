@@ -0,0 +1,294 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	serviceErr "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// importChronos godoc
+// @Summary      Import chronos from a timing system
+// @Description  Imports a CSV export of a timing system with the dossard, zone and time of each chrono. The columns are found by their header
+// @Description  (dossard or bib, zone or split, time or chrono), or are in that order without header. Comma, semicolon and tab separated files are accepted,
+// @Description  times are seconds or [hh:]mm:ss and fractions of second are kept to the millisecond. The chronos of a participant in a zone are merged in file order
+// @Description  into its runs there, and the chronos left without a run become pending runs for a referee to confirm. Importing a file again changes nothing.
+// @Tags         run
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        file           formData  file    true  "CSV export of the timing system"
+// @Success      200            {object}  models.ChronoImportResponse  "Returns the outcome of every row"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request (unreadable file or missing columns)"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse  "The competition is closed"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/runs/chrono-import [post]
+func (s *Server) importChronos(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	admin, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("file is required"))
+		return
+	}
+	defer file.Close()
+
+	report, err := s.runService.ImportChronos(c, int32(competitionID), file, admin.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		case errors.Is(err, serviceErr.ErrEmptyChronoImport),
+			errors.Is(err, serviceErr.ErrTooManyChronoImportRows),
+			errors.Is(err, serviceErr.ErrChronoImportColumns):
+			RespondError(c, http.StatusBadRequest, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response := models.ChronoImportResponse{
+		Merged:    report.Count(aggregate.ChronoImportMerged),
+		Unchanged: report.Count(aggregate.ChronoImportUnchanged),
+		Pending:   report.Count(aggregate.ChronoImportPending),
+		Failed:    report.Count(aggregate.ChronoImportFailed),
+		Rows:      make([]models.ChronoImportRowResponse, 0, len(report.GetRows())),
+	}
+	for _, row := range report.GetRows() {
+		response.Rows = append(response.Rows, models.ChronoImportRowResponse{
+			Row:       row.GetRow(),
+			Dossard:   row.GetDossard(),
+			Zone:      row.GetZone(),
+			ChronoSec: row.GetChronoSec(),
+			ChronoMs:  row.GetChronoMs(),
+			RunNumber: row.GetRunNumber(),
+			Status:    row.GetStatus(),
+			Message:   row.GetMessage(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// listPendingRuns godoc
+// @Summary      List pending runs
+// @Description  Lists the chronos imported from a timing system without a run, for the referees to confirm their runs. They do not count in the rankings until then.
+// @Tags         run
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        zone           query     string  false  "Zone filter (optional)"
+// @Success      200            {object}  models.PendingRunListResponse  "Returns the pending runs"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin or referee access required)"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/runs/pending [get]
+func (s *Server) listPendingRuns(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	pendingRuns, err := s.runService.ListPendingRuns(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	zone := c.Query("zone")
+	response := models.PendingRunListResponse{
+		PendingRuns: make([]models.PendingRunResponse, 0, len(pendingRuns)),
+	}
+	for _, pendingRun := range pendingRuns {
+		if zone != "" && pendingRun.GetZone() != zone {
+			continue
+		}
+		response.PendingRuns = append(response.PendingRuns, models.PendingRunResponse{
+			ID:         pendingRun.GetID(),
+			Dossard:    pendingRun.GetDossard(),
+			Zone:       pendingRun.GetZone(),
+			ChronoSec:  pendingRun.GetChronoSec(),
+			ChronoMs:   pendingRun.GetChronoMs(),
+			ImportedBy: pendingRun.GetImportedBy(),
+			ImportedAt: pendingRun.GetImportedAt(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// confirmPendingRun godoc
+// @Summary      Confirm a pending run
+// @Description  Records the run of a pending run with the doors and penalty observed by the referee, and the dossard, zone and chrono imported from the timing system
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                         true  "Authentication cookie"
+// @Param        competitionID  path      int                            true  "Competition ID"
+// @Param        pendingRunID   path      int                            true  "Pending run ID"
+// @Param        run            body      models.PendingRunConfirmInput  true  "Doors and penalty of the run"
+// @Success      201            {object}  models.RunResponse    "Returns the recorded run"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin or referee access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Pending run not found"
+// @Failure      409            {object}  models.ErrorResponse  "The competition is not running, the zone is closed or the runs expected in the zone are already recorded (models.RunsPerZoneErrorResponse)"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/runs/pending/{pendingRunID}/confirm [post]
+func (s *Server) confirmPendingRun(c *gin.Context) {
+	competitionID, pendingRunID, ok := parsePendingRunParams(c)
+	if !ok {
+		return
+	}
+
+	var input models.PendingRunConfirmInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err := checkHasAccessToCompetition(c, competitionID)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	run := aggregate.NewRun()
+	run.SetDoors(input.Doors)
+	run.SetPenality(input.Penality)
+	run.SetRefereeId(user.Id)
+
+	// The runs confirmed by a referee can require the approval of an admin
+	if checkHasAdminAccessToCompetition(c, competitionID) != nil {
+		requiresApproval, err := s.runService.RequiresRunApproval(c, competitionID)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		run.SetPendingApproval(requiresApproval)
+	}
+
+	err = s.runService.ConfirmPendingRun(c, competitionID, pendingRunID, run)
+	if err != nil {
+		if respondRunsPerZoneError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, serviceErr.ErrTooManyDoors):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrPendingRunNotFound),
+			errors.Is(err, repository.ErrParticipantNotFound),
+			errors.Is(err, serviceErr.ErrUnknownZone),
+			errors.Is(err, serviceErr.ErrScaleNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, serviceErr.ErrCompetitionNotRunning),
+			errors.Is(err, serviceErr.ErrZoneClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRunResponse(run))
+}
+
+// discardPendingRun godoc
+// @Summary      Discard a pending run
+// @Description  Removes a pending run whose chrono does not match any run, e.g. a false start, without recording it
+// @Tags         run
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        pendingRunID   path      int     true  "Pending run ID"
+// @Success      204            "Pending run discarded"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Pending run not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/runs/pending/{pendingRunID} [delete]
+func (s *Server) discardPendingRun(c *gin.Context) {
+	competitionID, pendingRunID, ok := parsePendingRunParams(c)
+	if !ok {
+		return
+	}
+
+	err := checkHasAdminAccessToCompetition(c, competitionID)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.runService.DiscardPendingRun(c, competitionID, pendingRunID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPendingRunNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parsePendingRunParams parses the competition and pending run IDs of the path, responding with an error when they are invalid
+func parsePendingRunParams(c *gin.Context) (int32, int32, bool) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return 0, 0, false
+	}
+
+	pendingRunID, err := strconv.ParseInt(c.Param("pendingRunID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid pending run ID"))
+		return 0, 0, false
+	}
+
+	return int32(competitionID), int32(pendingRunID), true
+}
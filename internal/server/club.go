@@ -0,0 +1,281 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// addClub godoc
+// @Summary      Add a club
+// @Description  Adds a club to the competition. The clubs named by the participants are also added when the participants are.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string            true  "Authentication cookie"
+// @Param        competitionID  path      int               true  "Competition ID"
+// @Param        club           body      models.ClubInput  true  "Club data"
+// @Success      201            {object}  models.ClubResponse   "Returns the created club"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse  "Club already exists"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/clubs [post]
+func (s *Server) addClub(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.ClubInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	club := aggregate.NewClub()
+	club.SetCompetitionID(int32(competitionID))
+	club.SetName(input.Name)
+
+	err = s.competitionService.AddClub(c, club)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toClubResponse(club))
+}
+
+// listClubs godoc
+// @Summary      List clubs
+// @Description  Lists the clubs of the competition by name
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.ClubListResponse  "Returns the clubs"
+// @Failure      400            {object}  models.ErrorResponse     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse     "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse     "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse     "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse     "Internal Server Error"
+// @Router       /competition/{competitionID}/clubs [get]
+func (s *Server) listClubs(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	clubs, err := s.competitionService.ListClubs(c, int32(competitionID))
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	response := models.ClubListResponse{
+		CompetitionID: int32(competitionID),
+		Clubs:         make([]models.ClubResponse, 0, len(clubs)),
+	}
+	for _, club := range clubs {
+		response.Clubs = append(response.Clubs, toClubResponse(club))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// updateClub godoc
+// @Summary      Rename a club
+// @Description  Renames a club of the competition, its participants are renamed with it
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string            true  "Authentication cookie"
+// @Param        competitionID  path      int               true  "Competition ID"
+// @Param        clubID         path      int               true  "Club ID"
+// @Param        club           body      models.ClubInput  true  "Club data"
+// @Success      200            {object}  models.ClubResponse   "Returns the renamed club"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Club not found"
+// @Failure      409            {object}  models.ErrorResponse  "Club already exists"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/clubs/{clubID} [put]
+func (s *Server) updateClub(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	clubID, err := strconv.ParseInt(c.Param("clubID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid club ID"))
+		return
+	}
+
+	var input models.ClubInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	club := aggregate.NewClub()
+	club.SetID(int32(clubID))
+	club.SetCompetitionID(int32(competitionID))
+	club.SetName(input.Name)
+
+	err = s.competitionService.UpdateClub(c, club)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toClubResponse(club))
+}
+
+// deleteClub godoc
+// @Summary      Delete a club
+// @Description  Removes a club from the competition, it cannot be removed while participants belong to it
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        clubID         path      int     true  "Club ID"
+// @Success      204            "Club removed"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Club not found"
+// @Failure      409            {object}  models.ErrorResponse  "Club has participants"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/clubs/{clubID} [delete]
+func (s *Server) deleteClub(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	clubID, err := strconv.ParseInt(c.Param("clubID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid club ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.competitionService.DeleteClub(c, int32(competitionID), int32(clubID))
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getClubRanking godoc
+// @Summary      Get the club ranking
+// @Description  Ranks the clubs of the competition on the sum of the points of their members, waitlisted participants excluded.
+// @Description  Clubs with the same points share their rank, clubs without member are not ranked.
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.ClubRankingListResponse  "Returns the club ranking"
+// @Failure      400            {object}  models.ErrorResponse            "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse            "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse            "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse            "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse            "Internal Server Error"
+// @Router       /competition/{competitionID}/clubranking [get]
+func (s *Server) getClubRanking(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	rankings, err := s.competitionService.GetClubRanking(c, int32(competitionID))
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	response := models.ClubRankingListResponse{
+		CompetitionID: int32(competitionID),
+		Rankings:      make([]models.ClubRankingResponse, 0, len(rankings)),
+	}
+	for _, ranking := range rankings {
+		response.Rankings = append(response.Rankings, models.ClubRankingResponse{
+			Rank:        ranking.GetRank(),
+			ClubID:      ranking.GetClub().GetID(),
+			Club:        ranking.GetClub().GetName(),
+			Members:     ranking.GetMembers(),
+			Ranked:      ranking.GetRanked(),
+			TotalPoints: ranking.GetTotalPoints(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondClubError responds with the status matching an error of the club endpoints
+func respondClubError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptyClubName):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrCompetitionNotFound), errors.Is(err, repository.ErrClubNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrDuplicateClub), errors.Is(err, service.ErrClubInUse):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// toClubResponse builds the response describing a club
+func toClubResponse(club *aggregate.Club) models.ClubResponse {
+	return models.ClubResponse{
+		ID:   club.GetID(),
+		Name: club.GetName(),
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// emailClubs godoc
+// @Summary      Email the clubs
+// @Description  Queues an email to every distinct club email of the participants of the competition, e.g. with the start list attached.
+// @Description  The {{club}}, {{competition}}, {{date}}, {{location}} and {{participants}} placeholders of the subject and the HTML body are replaced for each club contact.
+// @Tags         competition
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        subject        formData  string  true   "Subject of the email"
+// @Param        body           formData  string  true   "HTML body of the email"
+// @Param        attachment     formData  file    false  "File attached to the email, at most 10 MB"
+// @Success      202            {object}  models.ClubEmailResponse  "Emails queued"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      413            {object}  models.ErrorResponse  "Attachment too large"
+// @Failure      422            {object}  models.ErrorResponse  "No participant has a club email"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Failure      503            {object}  models.ErrorResponse  "Email not configured or too many emails waiting"
+// @Router       /competition/{competitionID}/club-emails [post]
+func (s *Server) emailClubs(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	// The attachment is optional
+	var attachment io.Reader
+	var attachmentName string
+	file, fileHeader, err := c.Request.FormFile("attachment")
+	if err == nil {
+		defer file.Close()
+		attachment = file
+		attachmentName = fileHeader.Filename
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	contacts, err := s.competitionService.EmailClubs(c, int32(competitionID), c.PostForm("subject"), c.PostForm("body"), attachment, attachmentName)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceImpl.ErrEmptyClubEmail):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		case errors.Is(err, serviceImpl.ErrClubEmailAttachmentTooLarge):
+			RespondError(c, http.StatusRequestEntityTooLarge, err)
+		case errors.Is(err, serviceImpl.ErrNoClubContacts):
+			RespondError(c, http.StatusUnprocessableEntity, err)
+		case errors.Is(err, serviceImpl.ErrMissingEmailConfig), errors.Is(err, serviceImpl.ErrEmailQueueFull):
+			RespondError(c, http.StatusServiceUnavailable, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response := models.ClubEmailResponse{
+		CompetitionID: int32(competitionID),
+		Queued:        len(contacts),
+		Recipients:    make([]models.ClubContactResponse, 0, len(contacts)),
+	}
+	for _, contact := range contacts {
+		response.Recipients = append(response.Recipients, models.ClubContactResponse{
+			Email:        contact.GetEmail(),
+			Clubs:        contact.GetClubs(),
+			Participants: contact.GetParticipants(),
+		})
+	}
+
+	c.JSON(http.StatusAccepted, response)
+}
@@ -3,9 +3,14 @@ package server
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
 	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -32,7 +37,7 @@ func getPagination(c *gin.Context) (int32, int32) {
 func checkHasAccessToCompetition(c *gin.Context, competitionID int32) error {
 	hasRole := middlewares.HasRole(c, fmt.Sprintf("admin:%d", competitionID)) ||
 		middlewares.HasRole(c, fmt.Sprintf("referee:%d", competitionID)) ||
-		middlewares.HasRole(c, "admin:*")
+		middlewares.IsSuperAdmin(c)
 	if !hasRole {
 		return ErrForbidden
 	}
@@ -40,14 +45,183 @@ func checkHasAccessToCompetition(c *gin.Context, competitionID int32) error {
 	return nil
 }
 
+// checkHasReadAccessToCompetition checks if user can read the rankings, runs and participants of the competition.
+// Observers are only given this read access, the checks above exclude them from every write.
+func checkHasReadAccessToCompetition(c *gin.Context, competitionID int32) error {
+	if checkHasAccessToCompetition(c, competitionID) == nil ||
+		middlewares.HasRole(c, aggregate.ObserverRole(competitionID)) {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// checkHasAdminOrObserverAccessToCompetition checks if user is admin or observer of the competition or super admin,
+// for the reads referees are not given
+func checkHasAdminOrObserverAccessToCompetition(c *gin.Context, competitionID int32) error {
+	if checkHasAdminAccessToCompetition(c, competitionID) == nil ||
+		middlewares.HasRole(c, aggregate.ObserverRole(competitionID)) {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
 // checkHasAdminAccessToCompetition checks if user is admin of the competition or super admin
 // This is stricter than checkHasAccessToCompetition as it excludes regular referees
 func checkHasAdminAccessToCompetition(c *gin.Context, competitionID int32) error {
 	hasRole := middlewares.HasRole(c, fmt.Sprintf("admin:%d", competitionID)) ||
-		middlewares.HasRole(c, "admin:*")
+		middlewares.IsSuperAdmin(c)
+	if !hasRole {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// checkIsAnyCompetitionAdmin checks if user administrates at least one competition or is super admin
+func checkIsAnyCompetitionAdmin(c *gin.Context) error {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		return ErrUnauthorized
+	}
+
+	if middlewares.IsSuperAdmin(c) {
+		return nil
+	}
+
+	for _, role := range user.Roles {
+		if strings.HasPrefix(role, "admin:") {
+			return nil
+		}
+	}
+
+	return ErrForbidden
+}
+
+// checkIsOrganizationAdmin checks if user is admin of the organization or super admin
+func checkIsOrganizationAdmin(c *gin.Context, organizationID int32) error {
+	hasRole := middlewares.HasRole(c, aggregate.OrganizationAdminRole(organizationID)) ||
+		middlewares.IsSuperAdmin(c)
 	if !hasRole {
 		return ErrForbidden
 	}
 
 	return nil
 }
+
+// memberCompetitionIDs returns the competitions the user administrates, referees or observes
+func memberCompetitionIDs(c *gin.Context) ([]int32, error) {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	competitionIDs := []int32{}
+	seen := make(map[int32]bool)
+	for _, role := range user.Roles {
+		userRole := aggregate.NewUserRole(user.Id, role)
+		if userRole.IsGlobal() || seen[userRole.GetCompetitionID()] {
+			continue
+		}
+		switch userRole.GetName() {
+		case "admin", "referee", "observer":
+			seen[userRole.GetCompetitionID()] = true
+			competitionIDs = append(competitionIDs, userRole.GetCompetitionID())
+		}
+	}
+
+	return competitionIDs, nil
+}
+
+// checkHasAPIKeyScope checks if the request is authenticated with an API key of the competition having the scope
+func checkHasAPIKeyScope(c *gin.Context, scope string, competitionID int32) error {
+	if !middlewares.HasRole(c, middlewares.APIKeyRole(scope, competitionID)) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// checkHasRefereePinAccess checks if the request is authenticated with a token issued for a referee PIN of the competition
+func checkHasRefereePinAccess(c *gin.Context, competitionID int32) error {
+	if !middlewares.HasRole(c, aggregate.RefereePinRole(competitionID)) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// checkCanCorrectRuns checks if user can correct the runs of the competition and whether they are its admin.
+// The referees, also those logged in with a referee PIN, can only correct their own runs for a while after recording them.
+func checkCanCorrectRuns(c *gin.Context, competitionID int32) (bool, error) {
+	if checkHasAdminAccessToCompetition(c, competitionID) == nil {
+		return true, nil
+	}
+	if checkHasAccessToCompetition(c, competitionID) == nil || checkHasRefereePinAccess(c, competitionID) == nil {
+		return false, nil
+	}
+
+	return false, ErrForbidden
+}
+
+// checkHasDisplayAccess checks if the request is authenticated with a token of a display device of the competition
+func checkHasDisplayAccess(c *gin.Context, competitionID int32) error {
+	if !middlewares.HasRole(c, aggregate.DisplayRole(competitionID)) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// respondAlreadyMemberError responds with a 409 the UI can recognise when err is an already member error
+func respondAlreadyMemberError(c *gin.Context, err error) bool {
+	var memberErr *service.AlreadyMemberError
+	if !errors.As(err, &memberErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, models.AlreadyMemberErrorResponse{
+		Code:          http.StatusConflict,
+		Message:       memberErr.Error(),
+		ErrorCode:     "already_member",
+		CompetitionID: memberErr.CompetitionID,
+		Role:          memberErr.Role,
+	})
+	return true
+}
+
+// respondRunsPerZoneError responds with a 409 listing the runs of the participant in the zone when err is a runs
+// per zone exceeded error
+func respondRunsPerZoneError(c *gin.Context, err error) bool {
+	var runsErr *service.RunsPerZoneExceededError
+	if !errors.As(err, &runsErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, models.RunsPerZoneErrorResponse{
+		Code:       http.StatusConflict,
+		Message:    runsErr.Error(),
+		ErrorCode:  "runs_per_zone_exceeded",
+		Zone:       runsErr.Zone,
+		MaxRuns:    runsErr.MaxRuns,
+		RunNumbers: runsErr.RunNumbers,
+	})
+	return true
+}
+
+// respondPasswordPolicyError responds with the violated rules when err is a password policy error
+func respondPasswordPolicyError(c *gin.Context, err error) bool {
+	var policyErr *service.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, models.PasswordPolicyErrorResponse{
+		Code:       http.StatusBadRequest,
+		Message:    policyErr.Error(),
+		Violations: policyErr.Violations,
+		MinLength:  policyErr.MinLength,
+	})
+	return true
+}
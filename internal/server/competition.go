@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	"github.com/NiskuT/cross-api/internal/domain/models"
@@ -14,11 +15,14 @@ import (
 	"github.com/NiskuT/cross-api/internal/server/middlewares"
 	"github.com/NiskuT/cross-api/internal/service"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 )
 
 // createCompetition godoc
 // @Summary      Create a competition
 // @Description  Creates a new competition and returns a JWT token.
+// @Description  The admins of an organization create the competitions of their organization with its `organization_id`.
+// @Description  The date is RFC3339, or YYYY-MM-DD for midnight, in the IANA time zone of the competition (Europe/Paris by default).
 // @Tags         competition
 // @Accept       json
 // @Produce      json
@@ -27,6 +31,8 @@ import (
 // @Success      200           {object}  models.CompetitionResponse     			 "Returns competition data"
 // @Failure      400           {object}  models.ErrorResponse          "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse          "Unauthorized (invalid credentials)"
+// @Failure      403           {object}  models.ErrorResponse          "Forbidden (admin access to the organization required)"
+// @Failure      404           {object}  models.ErrorResponse          "Organization not found"
 // @Failure      500           {object}  models.ErrorResponse          "Internal Server Error"
 // @Router       /competition [post]
 func (s *Server) createCompetition(c *gin.Context) {
@@ -36,21 +42,33 @@ func (s *Server) createCompetition(c *gin.Context) {
 		return
 	}
 
-	if !middlewares.HasRole(c, "create:competition") {
+	// Organization admins create the competitions of their organization without the create:competition role
+	if competition.OrganizationID != 0 {
+		if err := checkIsOrganizationAdmin(c, competition.OrganizationID); err != nil {
+			RespondError(c, http.StatusForbidden, err)
+			return
+		}
+	} else if !middlewares.HasRole(c, "create:competition") {
 		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
 		return
 	}
 
-	competitionAggregate := aggregate.NewCompetition()
-	competitionAggregate.SetName(competition.Name)
-	competitionAggregate.SetDescription(competition.Description)
-	competitionAggregate.SetDate(competition.Date)
-	competitionAggregate.SetLocation(competition.Location)
-	competitionAggregate.SetOrganizer(competition.Organizer)
-	competitionAggregate.SetContact(competition.Contact)
+	competitionAggregate, err := competitionFromInput(competition)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
 
 	competitionID, err := s.competitionService.CreateCompetition(c, competitionAggregate)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCompetitionDate) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
 		RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
@@ -67,8 +85,7 @@ func (s *Server) createCompetition(c *gin.Context) {
 		return
 	}
 
-	c.SetCookie(middlewares.AccessToken, newToken.GetAccessToken(), 0, "/", "", middlewares.SecureMode, true)
-	c.SetCookie(middlewares.RefreshToken, newToken.GetRefreshToken(), 0, "/", "", middlewares.SecureMode, true)
+	middlewares.SetTokenCookies(c, newToken)
 
 	// Add headers when tokens are set
 	c.Header("x-token-refreshed", "true")
@@ -85,35 +102,184 @@ func (s *Server) createCompetition(c *gin.Context) {
 		ID:          competitionID,
 		Name:        competition.Name,
 		Description: competition.Description,
-		Date:        competition.Date,
+		Date:        competitionAggregate.GetLocalDate().Format(time.RFC3339),
+		Timezone:    competitionAggregate.GetTimezone(),
 		Location:    competition.Location,
 		Organizer:   competition.Organizer,
 		Contact:     competition.Contact,
+		Status:      competitionAggregate.GetStatus(),
+
+		OrganizationID: competitionAggregate.GetOrganizationID(),
 	}
 
 	c.JSON(http.StatusOK, res)
 }
 
+// competitionFromInput builds the competition of the input, its date being read in its time zone,
+// the default one when none is given
+func competitionFromInput(input models.Competition) (*aggregate.Competition, error) {
+	timezone := input.Timezone
+	if timezone == "" {
+		timezone = aggregate.DefaultCompetitionTimezone
+	}
+	date, ok := aggregate.ParseCompetitionDate(input.Date, timezone)
+	if !ok || !aggregate.IsValidTimezone(timezone) {
+		return nil, service.ErrInvalidCompetitionDate
+	}
+
+	competition := aggregate.NewCompetition()
+	competition.SetName(input.Name)
+	competition.SetDescription(input.Description)
+	competition.SetDate(date)
+	competition.SetTimezone(timezone)
+	competition.SetLocation(input.Location)
+	competition.SetOrganizer(input.Organizer)
+	competition.SetContact(input.Contact)
+	competition.SetOrganizationID(input.OrganizationID)
+
+	return competition, nil
+}
+
+// updateCompetition godoc
+// @Summary      Update a competition
+// @Description  Updates the name, description, date, time zone, location, organizer and contact of a competition
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string              true  "Authentication cookie"
+// @Param        competitionID  path      int                 true  "Competition ID"
+// @Param        competition    body      models.Competition  true  "Competition data"
+// @Success      200            {object}  models.CompetitionResponse  "Returns the updated competition"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse        "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID} [put]
+func (s *Server) updateCompetition(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.Competition
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competitionAggregate, err := competitionFromInput(input)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+	competitionAggregate.SetID(int32(competitionID))
+
+	competition, err := s.competitionService.UpdateCompetition(c, competitionAggregate)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyCompetitionName), errors.Is(err, service.ErrInvalidCompetitionDate):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CompetitionResponse{
+		ID:          competition.GetID(),
+		Name:        competition.GetName(),
+		Description: competition.GetDescription(),
+		Date:        competition.GetLocalDate().Format(time.RFC3339),
+		Timezone:    competition.GetTimezone(),
+		Location:    competition.GetLocation(),
+		Organizer:   competition.GetOrganizer(),
+		Contact:     competition.GetContact(),
+		Status:      competition.GetStatus(),
+
+		OrganizationID: competition.GetOrganizationID(),
+	})
+}
+
 // listCompetitions godoc
 // @Summary      List competitions
-// @Description  Lists all competitions
+// @Description  Lists a page of the competitions the user administrates, referees or observes, archived competitions are only listed with archived=true.
+// @Description  Super admins list every competition with all=true.
 // @Tags         competition
 // @Accept       json
 // @Produce      json
 // @Param        Cookie  header string    true  "Authentication cookie"
+// @Param        archived   query  bool    false "List the archived competitions instead (default: false)"
+// @Param        all        query  bool    false "List every competition, super admins only (default: false)"
+// @Param        search     query  string  false "Text the name or location contains"
+// @Param        date_from  query  string  false "Earliest date of the competitions, as RFC3339 or YYYY-MM-DD"
+// @Param        date_to    query  string  false "Latest date of the competitions, as RFC3339 or YYYY-MM-DD, the whole day included"
+// @Param        sort       query  string  false "Order of the competitions: date, name, location or created (default: date)"
+// @Param        order      query  string  false "Direction of the order: asc or desc (default: desc)"
+// @Param        page       query  int     false "Page number (default: 1)"
+// @Param        page_size  query  int     false "Page size (default: 10)"
 // @Success      200           {object}  models.CompetitionListResponse     			 "Returns competition data"
 // @Failure      400           {object}  models.ErrorResponse          "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse          "Unauthorized (invalid credentials)"
+// @Failure      403           {object}  models.ErrorResponse          "Forbidden (all=true requires super admin)"
 // @Failure      500           {object}  models.ErrorResponse          "Internal Server Error"
 // @Router       /competition [get]
 func (s *Server) listCompetitions(c *gin.Context) {
-	competitions, err := s.competitionService.ListCompetitions(c)
+	filter := aggregate.NewCompetitionFilter()
+	if c.Query("all") == "true" {
+		if !middlewares.IsSuperAdmin(c) {
+			RespondError(c, http.StatusForbidden, ErrForbidden)
+			return
+		}
+	} else {
+		competitionIDs, err := memberCompetitionIDs(c)
+		if err != nil {
+			RespondError(c, http.StatusUnauthorized, err)
+			return
+		}
+		filter.SetCompetitionIDs(competitionIDs)
+	}
+	filter.SetArchived(c.Query("archived") == "true")
+	filter.SetSearch(strings.TrimSpace(c.Query("search")))
+	filter.SetDateFrom(c.Query("date_from"))
+	filter.SetDateTo(c.Query("date_to"))
+	if sort := c.Query("sort"); sort != "" {
+		filter.SetSort(sort)
+	}
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		filter.SetDescending(false)
+	case "desc":
+		filter.SetDescending(true)
+	default:
+		RespondError(c, http.StatusBadRequest, errors.New("order must be 'asc' or 'desc'"))
+		return
+	}
+	page, pageSize := getPagination(c)
+
+	competitions, total, err := s.competitionService.ListCompetitions(c, filter, page, pageSize)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCompetitionSort) || errors.Is(err, service.ErrInvalidCompetitionDateRange) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
 		RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	res := models.CompetitionListResponse{
+		Page:         page,
+		PageSize:     pageSize,
+		Total:        total,
 		Competitions: make([]*models.CompetitionResponse, len(competitions)),
 	}
 	for i, competition := range competitions {
@@ -121,15 +287,264 @@ func (s *Server) listCompetitions(c *gin.Context) {
 			ID:          competition.GetID(),
 			Name:        competition.GetName(),
 			Description: competition.GetDescription(),
-			Date:        competition.GetDate(),
+			Date:        competition.GetLocalDate().Format(time.RFC3339),
+			Timezone:    competition.GetTimezone(),
 			Location:    competition.GetLocation(),
 			Organizer:   competition.GetOrganizer(),
 			Contact:     competition.GetContact(),
+			Status:      competition.GetStatus(),
+
+			OrganizationID: competition.GetOrganizationID(),
+		}
+		if competition.IsArchived() {
+			archivedAt := competition.GetArchivedAt()
+			res.Competitions[i].ArchivedAt = &archivedAt
 		}
 	}
 	c.JSON(http.StatusOK, res)
 }
 
+// archiveCompetition godoc
+// @Summary      Archive or delete a competition
+// @Description  Archives a finished competition so that it is no longer listed by default, its records are kept and it stays listed with archived=true.
+// @Description  With permanent=true the competition is deleted with its participants, runs, rankings, scales, contacts, invitations and API keys instead.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        permanent      query     bool    false  "Delete the competition instead of archiving it (default: false)"
+// @Success      204            "Competition archived or deleted"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID} [delete]
+func (s *Server) archiveCompetition(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	action := aggregate.AuditActionCompetitionArchived
+	if c.Query("permanent") == "true" {
+		action = aggregate.AuditActionCompetitionDeleted
+		err = s.competitionService.DeleteCompetition(c, int32(competitionID))
+	} else {
+		_, err = s.competitionService.MarkCompetitionArchived(c, int32(competitionID))
+	}
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(int32(competitionID))
+	if user, err := middlewares.GetUser(c); err == nil {
+		auditLog.SetUserID(user.Id)
+	}
+	auditLog.SetAction(action)
+	auditLog.SetDetails(competition.GetName())
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int64("competition_id", competitionID).Msg("Failed to record competition archiving")
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// setCompetitionStatus godoc
+// @Summary      Change the status of a competition
+// @Description  Moves a competition along its lifecycle: draft, open, running then closed. Runs can only be recorded while the competition is running,
+// @Description  and its runs and liveranking are frozen once it is closed. A competition can go back one step, e.g. to reopen it for corrections.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                         true  "Authentication cookie"
+// @Param        competitionID  path      int                            true  "Competition ID"
+// @Param        status         body      models.CompetitionStatusInput  true  "New status"
+// @Success      200            {object}  models.CompetitionResponse     "Returns the updated competition"
+// @Failure      400            {object}  models.ErrorResponse           "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse           "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse           "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse           "Transition not allowed from the current status"
+// @Failure      500            {object}  models.ErrorResponse           "Internal Server Error"
+// @Router       /competition/{competitionID}/status [post]
+func (s *Server) setCompetitionStatus(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.CompetitionStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.SetCompetitionStatus(c, int32(competitionID), input.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCompetitionStatus):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, service.ErrInvalidStatusTransition):
+			RespondError(c, http.StatusConflict, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(int32(competitionID))
+	if user, err := middlewares.GetUser(c); err == nil {
+		auditLog.SetUserID(user.Id)
+	}
+	auditLog.SetAction(aggregate.AuditActionCompetitionStatusChanged)
+	auditLog.SetDetails(competition.GetStatus())
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int64("competition_id", competitionID).Msg("Failed to record competition status change")
+	}
+
+	c.JSON(http.StatusOK, models.CompetitionResponse{
+		ID:          competition.GetID(),
+		Name:        competition.GetName(),
+		Description: competition.GetDescription(),
+		Date:        competition.GetLocalDate().Format(time.RFC3339),
+		Timezone:    competition.GetTimezone(),
+		Location:    competition.GetLocation(),
+		Organizer:   competition.GetOrganizer(),
+		Contact:     competition.GetContact(),
+		Status:      competition.GetStatus(),
+
+		OrganizationID: competition.GetOrganizationID(),
+	})
+}
+
+// cloneCompetition godoc
+// @Summary      Clone a competition
+// @Description  Creates a new competition with the details, chrono preferences, categories, scales, zone details, zone bounds, settings and export template of an existing one, to reuse the zones and categories of a past edition.
+// @Description  Participants, runs and rankings are not copied. The new competition starts as a draft and the caller is made its admin.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                        true   "Authentication cookie"
+// @Param        competitionID  path      int                           true   "Competition to clone"
+// @Param        clone          body      models.CompetitionCloneInput  false  "Name and date of the new competition, by default the name is suffixed with (copy) and the date is kept"
+// @Success      201            {object}  models.CompetitionResponse    "Returns the new competition"
+// @Failure      400            {object}  models.ErrorResponse          "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse          "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse          "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse          "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse          "Internal Server Error"
+// @Router       /competition/{competitionID}/clone [post]
+func (s *Server) cloneCompetition(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// The body is optional
+	var input models.CompetitionCloneInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	competition, err := s.competitionService.CloneCompetition(c, int32(competitionID), input.Name, input.Date)
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		if errors.Is(err, service.ErrInvalidCompetitionDate) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	newToken, err := s.userService.SetUserAsAdmin(c, user.Email, competition.GetID())
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	middlewares.SetTokenCookies(c, newToken)
+
+	// Add headers when tokens are set
+	c.Header("x-token-refreshed", "true")
+	if roles := newToken.GetRoles(); len(roles) > 0 {
+		rolesJSON, err := json.Marshal(roles)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("x-user-roles", string(rolesJSON))
+	}
+
+	c.JSON(http.StatusCreated, models.CompetitionResponse{
+		ID:          competition.GetID(),
+		Name:        competition.GetName(),
+		Description: competition.GetDescription(),
+		Date:        competition.GetLocalDate().Format(time.RFC3339),
+		Timezone:    competition.GetTimezone(),
+		Location:    competition.GetLocation(),
+		Organizer:   competition.GetOrganizer(),
+		Contact:     competition.GetContact(),
+		Status:      competition.GetStatus(),
+
+		OrganizationID: competition.GetOrganizationID(),
+	})
+}
+
 // addZoneToCompetition godoc
 // @Summary      Add a zone to a competition
 // @Description  Adds a zone to a competition
@@ -141,6 +556,7 @@ func (s *Server) listCompetitions(c *gin.Context) {
 // @Success      200           {object}  gin.H       			 						 "Returns competition data"
 // @Failure      400           {object}  models.ErrorResponse          "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse          "Unauthorized (invalid credentials)"
+// @Failure      409           {object}  models.ErrorResponse          "The category already has the zones of the competition settings"
 // @Failure      500           {object}  models.ErrorResponse          "Internal Server Error"
 // @Router       /competition/zone [post]
 func (s *Server) addZoneToCompetition(c *gin.Context) {
@@ -165,16 +581,17 @@ func (s *Server) addZoneToCompetition(c *gin.Context) {
 	scale.SetCompetitionID(competitionScaleInput.CompetitionID)
 	scale.SetCategory(competitionScaleInput.Category)
 	scale.SetZone(competitionScaleInput.Zone)
-	scale.SetPointsDoor1(competitionScaleInput.PointsDoor1)
-	scale.SetPointsDoor2(competitionScaleInput.PointsDoor2)
-	scale.SetPointsDoor3(competitionScaleInput.PointsDoor3)
-	scale.SetPointsDoor4(competitionScaleInput.PointsDoor4)
-	scale.SetPointsDoor5(competitionScaleInput.PointsDoor5)
-	scale.SetPointsDoor6(competitionScaleInput.PointsDoor6)
+	scale.SetDoorPoints(competitionScaleInput.DoorPoints)
 
 	err = s.competitionService.AddScale(c, competitionScaleInput.CompetitionID, scale)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err)
+		if errors.Is(err, service.ErrTooManyZones) {
+			RespondError(c, http.StatusConflict, err)
+		} else if errors.Is(err, service.ErrUnknownCategory) {
+			RespondError(c, http.StatusBadRequest, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
@@ -183,16 +600,27 @@ func (s *Server) addZoneToCompetition(c *gin.Context) {
 
 // addParticipantsToCompetition godoc
 // @Summary      Add participants to a competition
-// @Description  Adds multiple participants to a competition from a CSV or Excel file
+// @Description  Adds multiple participants to a competition from a CSV or Excel file and reports the outcome of each row: the invalid rows fail with the reason
+// @Description  and the next rows are still added, the rows whose dossard is already taken are skipped. Over the maximum number of participants, the rows are waitlisted,
+// @Description  or fail when the competition has no waitlist. The rows without category are placed in the category whose age bracket holds the age of the participant
+// @Description  in the year of the competition, the rows whose category disagrees with it are added and flagged. With dryRun=true the rows are checked the same way but nothing is added.
+// @Description  With check_duplicates=true the rows are first compared with the participants and with each other, and the rows that likely duplicate one of them,
+// @Description  the same name under another dossard or the same dossard under another name, are returned as warnings. The import is then held: nothing is added.
+// @Description  The file is read one row at a time and the participants are created by batches, so that very large files are imported with bounded memory.
 // @Tags         competition
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        Cookie  header string    true  "Authentication cookie"
 // @Param        competitionID  formData  int     true  "Competition ID"
-// @Param        file           formData  file    true  "CSV or Excel file with participants data (format: dossard number, category, last name, first name, gender)"
-// @Success      200           {object}  gin.H                        "Successfully added participants"
-// @Failure      400           {object}  models.ErrorResponse         "Bad Request"
+// @Param        file           formData  file    true  "CSV or Excel file with participants data (format: dossard number, category, last name, first name, gender, club, data processing consent, photo rights consent, club email, licence number, birth date as YYYY-MM-DD or DD/MM/YYYY)"
+// @Param        dryRun         query     bool    false "Only check the rows, nothing is added"
+// @Param        check_duplicates  query  bool    false "Report the likely duplicates and add nothing when there are any"
+// @Success      200           {object}  models.ParticipantImportResponse "Returns the outcome of each row"
+// @Failure      400           {object}  models.ErrorResponse         "Bad Request (unreadable file)"
 // @Failure      401           {object}  models.ErrorResponse         "Unauthorized (invalid credentials)"
+// @Failure      403           {object}  models.ErrorResponse         "Forbidden (admin access required)"
+// @Failure      404           {object}  models.ErrorResponse         "Competition not found"
+// @Failure      409           {object}  models.ErrorResponse         "Registrations closed"
 // @Failure      500           {object}  models.ErrorResponse         "Internal Server Error"
 // @Router       /competition/participants [post]
 func (s *Server) addParticipantsToCompetition(c *gin.Context) {
@@ -209,6 +637,25 @@ func (s *Server) addParticipantsToCompetition(c *gin.Context) {
 	}
 	competitionID := int32(competitionIDInt)
 
+	// Support both 'dryRun' and 'dry_run' like the other dry runs
+	dryRun := false
+	if value := c.DefaultQuery("dryRun", c.Query("dry_run")); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid dryRun, expected true or false"))
+			return
+		}
+	}
+
+	checkDuplicates := false
+	if value := c.Query("check_duplicates"); value != "" {
+		checkDuplicates, err = strconv.ParseBool(value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid check_duplicates, expected true or false"))
+			return
+		}
+	}
+
 	err = checkHasAdminAccessToCompetition(c, competitionID)
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
@@ -225,13 +672,61 @@ func (s *Server) addParticipantsToCompetition(c *gin.Context) {
 	// Get filename from the file header
 	filename := fileHeader.Filename
 
-	err = s.competitionService.AddParticipants(c, competitionID, file, filename)
+	report, err := s.competitionService.AddParticipants(c, competitionID, file, filename, dryRun, checkDuplicates)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err)
+		if errors.Is(err, service.ErrInvalidFileFormat) {
+			RespondError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		} else if errors.Is(err, service.ErrRegistrationClosed) {
+			RespondError(c, http.StatusConflict, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Participants added to competition"})
+	response := models.ParticipantImportResponse{
+		DryRun:          report.IsDryRun(),
+		Inserted:        report.GetInserted(),
+		Waitlisted:      report.GetWaitlisted(),
+		SkippedDossards: report.GetSkippedDossards(),
+		Skipped:         make([]models.ParticipantImportRowResponse, 0, len(report.GetSkipped())),
+		Failed:          make([]models.ParticipantImportRowResponse, 0, len(report.GetFailed())),
+		Flagged:         make([]models.ParticipantImportRowResponse, 0, len(report.GetFlagged())),
+		Duplicates:      make([]models.ParticipantImportRowResponse, 0, len(report.GetDuplicates())),
+		Held:            report.IsHeld(),
+	}
+	for _, row := range report.GetSkipped() {
+		response.Skipped = append(response.Skipped, models.ParticipantImportRowResponse{
+			Row:     row.GetRow(),
+			Dossard: row.GetDossard(),
+			Reason:  row.GetReason(),
+		})
+	}
+	for _, row := range report.GetFailed() {
+		response.Failed = append(response.Failed, models.ParticipantImportRowResponse{
+			Row:     row.GetRow(),
+			Dossard: row.GetDossard(),
+			Reason:  row.GetReason(),
+		})
+	}
+	for _, row := range report.GetFlagged() {
+		response.Flagged = append(response.Flagged, models.ParticipantImportRowResponse{
+			Row:     row.GetRow(),
+			Dossard: row.GetDossard(),
+			Reason:  row.GetReason(),
+		})
+	}
+	for _, row := range report.GetDuplicates() {
+		response.Duplicates = append(response.Duplicates, models.ParticipantImportRowResponse{
+			Row:     row.GetRow(),
+			Dossard: row.GetDossard(),
+			Reason:  row.GetReason(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // addRefereeToCompetition godoc
@@ -325,15 +820,16 @@ func (s *Server) generateRefereeInvitationLink(c *gin.Context) {
 	}
 
 	// Generate invitation token
-	token, expiresAt, err := s.userService.GenerateRefereeInvitationToken(c, int32(competitionID))
+	token, invitation, err := s.userService.GenerateRefereeInvitationToken(c, int32(competitionID))
 	if err != nil {
 		RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	response := models.RefereeInvitationResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
+		InvitationID: invitation.GetID(),
+		Token:        token,
+		ExpiresAt:    invitation.GetExpiresAt().Unix(),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -350,6 +846,7 @@ func (s *Server) generateRefereeInvitationLink(c *gin.Context) {
 // @Success      200        {object}  gin.H                                 "Successfully accepted invitation"
 // @Failure      400        {object}  models.ErrorResponse                  "Bad Request"
 // @Failure      401        {object}  models.ErrorResponse                  "Unauthorized (invalid credentials)"
+// @Failure      409        {object}  models.AlreadyMemberErrorResponse     "Already a referee or admin of the competition"
 // @Failure      500        {object}  models.ErrorResponse                  "Internal Server Error"
 // @Router       /referee/invitation/accept [post]
 func (s *Server) acceptRefereeInvitation(c *gin.Context) {
@@ -369,6 +866,9 @@ func (s *Server) acceptRefereeInvitation(c *gin.Context) {
 	// Accept the invitation
 	tokens, err := s.userService.AcceptRefereeInvitation(c, invitationInput.Token, user.Email)
 	if err != nil {
+		if respondAlreadyMemberError(c, err) {
+			return
+		}
 		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
 			RespondError(c, http.StatusBadRequest, errors.New("invalid or expired invitation token"))
 		} else {
@@ -378,8 +878,7 @@ func (s *Server) acceptRefereeInvitation(c *gin.Context) {
 	}
 
 	// Set new tokens in cookies
-	c.SetCookie(middlewares.AccessToken, tokens.GetAccessToken(), 0, "/", "", middlewares.SecureMode, true)
-	c.SetCookie(middlewares.RefreshToken, tokens.GetRefreshToken(), 0, "/", "", middlewares.SecureMode, true)
+	middlewares.SetTokenCookies(c, tokens)
 
 	// Add headers when tokens are set
 	c.Header("x-token-refreshed", "true")
@@ -403,8 +902,9 @@ func (s *Server) acceptRefereeInvitation(c *gin.Context) {
 // @Produce      json
 // @Param        invitation body      models.RefereeInvitationAcceptUnauthenticatedInput   true  "Invitation data with user details"
 // @Success      200        {object}  gin.H                                                "Successfully accepted invitation and logged in"
-// @Failure      400        {object}  models.ErrorResponse                                 "Bad Request"
+// @Failure      400        {object}  models.PasswordPolicyErrorResponse                   "Bad Request, or password breaking the password policy"
 // @Failure      401        {object}  models.ErrorResponse                                 "Invalid credentials"
+// @Failure      409        {object}  models.AlreadyMemberErrorResponse                    "Already a referee or admin of the competition"
 // @Failure      500        {object}  models.ErrorResponse                                 "Internal Server Error"
 // @Router       /referee/invitation/accept-unauthenticated [post]
 func (s *Server) acceptRefereeInvitationUnauthenticated(c *gin.Context) {
@@ -424,6 +924,9 @@ func (s *Server) acceptRefereeInvitationUnauthenticated(c *gin.Context) {
 		invitationInput.Password,
 	)
 	if err != nil {
+		if respondPasswordPolicyError(c, err) || respondAlreadyMemberError(c, err) {
+			return
+		}
 		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
 			RespondError(c, http.StatusBadRequest, errors.New("invalid or expired invitation token"))
 		} else if err == service.ErrInvalidCredentials {
@@ -435,8 +938,7 @@ func (s *Server) acceptRefereeInvitationUnauthenticated(c *gin.Context) {
 	}
 
 	// Set tokens in cookies
-	c.SetCookie(middlewares.AccessToken, tokens.GetAccessToken(), 0, "/", "", middlewares.SecureMode, true)
-	c.SetCookie(middlewares.RefreshToken, tokens.GetRefreshToken(), 0, "/", "", middlewares.SecureMode, true)
+	middlewares.SetTokenCookies(c, tokens)
 
 	// Add headers when tokens are set
 	c.Header("x-token-refreshed", "true")
@@ -475,8 +977,11 @@ func (s *Server) listZones(c *gin.Context) {
 		return
 	}
 
-	// Check if user has access to the competition
-	err = checkHasAccessToCompetition(c, int32(competitionID))
+	// Check if user has read access to the competition, display devices show the zones along the rankings
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasDisplayAccess(c, int32(competitionID))
+	}
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
@@ -493,29 +998,99 @@ func (s *Server) listZones(c *gin.Context) {
 		return
 	}
 
+	zoneResponses, err := s.toZoneResponses(c, int32(competitionID), zones)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	// Build response
 	response := models.ZonesListResponse{
 		CompetitionID: int32(competitionID),
-		Zones:         make([]models.ZoneResponse, 0, len(zones)),
+		Zones:         zoneResponses,
 	}
 
+	c.JSON(http.StatusOK, response)
+}
+
+// toZoneResponses builds the zones of a competition with the points of their scale
+func (s *Server) toZoneResponses(c *gin.Context, competitionID int32, zones []aggregate.ZoneInfo) ([]models.ZoneResponse, error) {
+	responses := make([]models.ZoneResponse, 0, len(zones))
 	for _, zone := range zones {
-		scale, err := s.competitionService.GetScale(c, int32(competitionID), zone.GetCategory(), zone.GetZone())
+		scale, err := s.competitionService.GetScale(c, competitionID, zone.GetCategory(), zone.GetZone())
 		if err != nil {
-			RespondError(c, http.StatusInternalServerError, err)
-			return
+			return nil, err
 		}
-		response.Zones = append(response.Zones, models.ZoneResponse{
-			Zone:        zone.GetZone(),
-			Category:    zone.GetCategory(),
-			PointsDoor1: scale.GetPointsDoor1(),
-			PointsDoor2: scale.GetPointsDoor2(),
-			PointsDoor3: scale.GetPointsDoor3(),
-			PointsDoor4: scale.GetPointsDoor4(),
-			PointsDoor5: scale.GetPointsDoor5(),
-			PointsDoor6: scale.GetPointsDoor6(),
+		responses = append(responses, models.ZoneResponse{
+			Zone:       zone.GetZone(),
+			Category:   zone.GetCategory(),
+			DoorPoints: scale.GetDoorPoints(),
 		})
 	}
+	return responses, nil
+}
+
+// getZoneThroughput godoc
+// @Summary      Get zone throughput
+// @Description  Returns, for every zone, the runs recorded over the last window, the runs per hour and the average interval between two runs, to spot slow zones during the event
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header string    true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        window         query     int     false "Window in minutes (default: 60)"
+// @Success      200           {object}  models.ZoneThroughputListResponse  "Returns zone throughput"
+// @Failure      400           {object}  models.ErrorResponse               "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse               "Unauthorized (invalid credentials)"
+// @Failure      403           {object}  models.ErrorResponse               "Forbidden (admin access required)"
+// @Failure      500           {object}  models.ErrorResponse               "Internal Server Error"
+// @Router       /competition/{competitionID}/zones/throughput [get]
+func (s *Server) getZoneThroughput(c *gin.Context) {
+	competitionIDStr := c.Param("competitionID")
+
+	competitionID, err := strconv.ParseInt(competitionIDStr, 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	window, err := strconv.Atoi(c.DefaultQuery("window", "60"))
+	if err != nil || window < 1 {
+		RespondError(c, http.StatusBadRequest, errors.New("window must be a positive number of minutes"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	throughputs, err := s.runService.GetZoneThroughput(c, int32(competitionID), time.Duration(window)*time.Minute)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.ZoneThroughputListResponse{
+		CompetitionID: int32(competitionID),
+		WindowMinutes: int32(window),
+		Zones:         make([]models.ZoneThroughputResponse, 0, len(throughputs)),
+	}
+
+	for _, throughput := range throughputs {
+		zoneResponse := models.ZoneThroughputResponse{
+			Zone:               throughput.GetZone(),
+			RunCount:           throughput.GetRunCount(),
+			RunsPerHour:        throughput.GetRunsPerHour(),
+			AverageIntervalSec: throughput.GetAverageIntervalSec(),
+		}
+		if lastRunAt := throughput.GetLastRunAt(); !lastRunAt.IsZero() {
+			zoneResponse.LastRunAt = &lastRunAt
+		}
+		response.Zones = append(response.Zones, zoneResponse)
+	}
 
 	c.JSON(http.StatusOK, response)
 }
@@ -556,12 +1131,7 @@ func (s *Server) updateZoneInCompetition(c *gin.Context) {
 	scale.SetCompetitionID(competitionScaleInput.CompetitionID)
 	scale.SetCategory(competitionScaleInput.Category)
 	scale.SetZone(competitionScaleInput.Zone)
-	scale.SetPointsDoor1(competitionScaleInput.PointsDoor1)
-	scale.SetPointsDoor2(competitionScaleInput.PointsDoor2)
-	scale.SetPointsDoor3(competitionScaleInput.PointsDoor3)
-	scale.SetPointsDoor4(competitionScaleInput.PointsDoor4)
-	scale.SetPointsDoor5(competitionScaleInput.PointsDoor5)
-	scale.SetPointsDoor6(competitionScaleInput.PointsDoor6)
+	scale.SetDoorPoints(competitionScaleInput.DoorPoints)
 
 	err = s.competitionService.UpdateScale(c, competitionScaleInput.CompetitionID, scale)
 	if err != nil {
@@ -633,7 +1203,8 @@ func (s *Server) deleteZoneFromCompetition(c *gin.Context) {
 
 // getLiveranking godoc
 // @Summary      Get live ranking
-// @Description  Retrieves live ranking for a competition with optional category and gender filtering
+// @Description  Retrieves live ranking for a competition with optional category and gender filtering. Scoreboard displays only get the names
+// @Description  of the participants who consented to the processing of their data, the others are shown as Anonyme.
 // @Tags         competition
 // @Accept       json
 // @Produce      json
@@ -641,6 +1212,7 @@ func (s *Server) deleteZoneFromCompetition(c *gin.Context) {
 // @Param        competitionID  path      int     true  "Competition ID"
 // @Param        category       query     string  false "Category filter (optional)"
 // @Param        gender         query     string  false "Gender filter (optional, H or F)"
+// @Param        checked_in     query     bool    false "Only rank the participants checked in at the start desk (default: false)"
 // @Param        page           query     int     false "Page number (default: 1)"
 // @Param        page_size      query     int     false "Page size (default: 10)"
 // @Success      200           {object}  models.LiverankingListResponse     "Returns live ranking data"
@@ -648,6 +1220,7 @@ func (s *Server) deleteZoneFromCompetition(c *gin.Context) {
 // @Failure      401           {object}  models.ErrorResponse               "Unauthorized (invalid credentials)"
 // @Failure      404           {object}  models.ErrorResponse               "Competition not found"
 // @Failure      500           {object}  models.ErrorResponse               "Internal Server Error"
+// @Param        X-Api-Key      header    string  false "API key with the read-liveranking scope, replaces the cookie"
 // @Router       /competition/{competitionID}/liveranking [get]
 func (s *Server) getLiveranking(c *gin.Context) {
 	competitionIDStr := c.Param("competitionID")
@@ -658,8 +1231,18 @@ func (s *Server) getLiveranking(c *gin.Context) {
 		return
 	}
 
-	// Check if user has access to the competition
-	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	// Check if user administrates or observes the competition, or uses an API key allowed to read the liveranking
+	// or a display device of the competition
+	err = checkHasAdminOrObserverAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasAPIKeyScope(c, aggregate.APIKeyScopeReadLiveranking, int32(competitionID))
+	}
+	// Scoreboard displays are public, they only show who consented to the processing of their data
+	anonymize := false
+	if err != nil {
+		err = checkHasDisplayAccess(c, int32(competitionID))
+		anonymize = err == nil
+	}
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
@@ -676,8 +1259,18 @@ func (s *Server) getLiveranking(c *gin.Context) {
 		return
 	}
 
+	// Leave out the no-shows when asked
+	checkedInOnly := false
+	if value := c.Query("checked_in"); value != "" {
+		checkedInOnly, err = strconv.ParseBool(value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid checked_in, expected true or false"))
+			return
+		}
+	}
+
 	// Get live ranking from service
-	rankings, total, err := s.competitionService.GetLiveranking(c, int32(competitionID), category, gender, page, pageSize)
+	rankings, total, err := s.competitionService.GetLiveranking(c, int32(competitionID), category, gender, checkedInOnly, page, pageSize)
 	if err != nil {
 		if errors.Is(err, repository.ErrCompetitionNotFound) {
 			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
@@ -698,12 +1291,9 @@ func (s *Server) getLiveranking(c *gin.Context) {
 		Rankings:      make([]models.LiverankingResponse, 0, len(rankings)),
 	}
 
-	// Calculate rank based on position (considering pagination)
-	baseRank := (page-1)*pageSize + 1
-
-	for i, ranking := range rankings {
-		response.Rankings = append(response.Rankings, models.LiverankingResponse{
-			Rank:         baseRank + int32(i),
+	for _, ranking := range rankings {
+		rankingResponse := models.LiverankingResponse{
+			Rank:         ranking.GetRank(),
 			Dossard:      ranking.GetDossard(),
 			FirstName:    ranking.GetFirstName(),
 			LastName:     ranking.GetLastName(),
@@ -714,7 +1304,16 @@ func (s *Server) getLiveranking(c *gin.Context) {
 			TotalPoints:  ranking.GetTotalPoints(),
 			Penality:     ranking.GetPenality(),
 			ChronoSec:    ranking.GetChronoSec(),
-		})
+			ChronoMs:     ranking.GetChronoMs(),
+			Status:       ranking.GetStatus(),
+			StatusReason: ranking.GetStatusReason(),
+		}
+		if anonymize && !ranking.GetConsentDataProcessing() {
+			rankingResponse.FirstName = ""
+			rankingResponse.LastName = service.AnonymousParticipantName
+			rankingResponse.Club = ""
+		}
+		response.Rankings = append(response.Rankings, rankingResponse)
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -722,7 +1321,7 @@ func (s *Server) getLiveranking(c *gin.Context) {
 
 // createParticipant godoc
 // @Summary      Create a participant
-// @Description  Creates a single participant for a competition
+// @Description  Creates a single participant for a competition, waitlisted when the competition is full and has a waitlist
 // @Tags         participant
 // @Accept       json
 // @Produce      json
@@ -731,8 +1330,9 @@ func (s *Server) getLiveranking(c *gin.Context) {
 // @Success      201           {object}  models.ParticipantResponse     "Returns created participant data"
 // @Failure      400           {object}  models.ErrorResponse           "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
-// @Failure      409           {object}  models.ErrorResponse           "Participant already exists"
+// @Failure      409           {object}  models.ErrorResponse           "Participant already exists, registrations closed or competition full"
 // @Failure      500           {object}  models.ErrorResponse           "Internal Server Error"
+// @Failure      502           {object}  models.ErrorResponse           "The federation register could not verify the licence"
 // @Router       /participant [post]
 func (s *Server) createParticipant(c *gin.Context) {
 	var participantInput models.ParticipantInput
@@ -757,6 +1357,16 @@ func (s *Server) createParticipant(c *gin.Context) {
 	participant.SetCategory(participantInput.Category)
 	participant.SetGender(participantInput.Gender)
 	participant.SetClub(participantInput.Club)
+	participant.SetClubID(participantInput.ClubID)
+	participant.SetClubEmail(participantInput.ClubEmail)
+	participant.SetLicence(participantInput.Licence)
+	if participantInput.BirthDate != "" {
+		// The format was checked when binding
+		birthDate, _ := time.Parse(time.DateOnly, participantInput.BirthDate)
+		participant.SetBirthDate(birthDate)
+	}
+	participant.SetConsentDataProcessing(participantInput.ConsentDataProcessing)
+	participant.SetConsentPhotoRights(participantInput.ConsentPhotoRights)
 
 	// Create participant through service
 	err = s.competitionService.CreateParticipant(c, participant)
@@ -766,6 +1376,18 @@ func (s *Server) createParticipant(c *gin.Context) {
 			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
 			return
 		}
+		if errors.Is(err, service.ErrUnknownCategory) || errors.Is(err, repository.ErrClubNotFound) || errors.Is(err, service.ErrUnknownLicence) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, service.ErrLicenceRegistryUnavailable) {
+			RespondError(c, http.StatusBadGateway, err)
+			return
+		}
+		if errors.Is(err, service.ErrRegistrationClosed) || errors.Is(err, service.ErrCompetitionFull) {
+			RespondError(c, http.StatusConflict, err)
+			return
+		}
 		// Check if it's a duplicate error from the participant repository
 		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "duplicate") {
 			RespondError(c, http.StatusConflict, errors.New("participant with this dossard number already exists"))
@@ -784,51 +1406,73 @@ func (s *Server) createParticipant(c *gin.Context) {
 		Category:      participant.GetCategory(),
 		Gender:        participant.GetGender(),
 		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
 	}
 
 	c.JSON(http.StatusCreated, response)
 }
 
-// listParticipantsByCategory godoc
-// @Summary      List participants by category
-// @Description  Lists all participants for a competition filtered by category
+// listParticipants godoc
+// @Summary      List participants
+// @Description  Lists a page of the participants of a competition ordered by dossard, optionally filtered by category, gender and club
+// @Description  and searched by first or last name
 // @Tags         participant
 // @Accept       json
 // @Produce      json
-// @Param        Cookie  header string    true  "Authentication cookie"
-// @Param        competitionID  path      int     true  "Competition ID"
-// @Param        category       query     string  true  "Category filter"
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        category       query     string  false  "Category of the participants"
+// @Param        gender         query     string  false  "Gender of the participants"
+// @Param        club           query     string  false  "Club of the participants"
+// @Param        search         query     string  false  "Text the first or last name contains"
+// @Param        page           query     int     false  "Page number (default: 1)"
+// @Param        page_size      query     int     false  "Page size (default: 10)"
 // @Success      200           {object}  models.ParticipantListResponse "Returns list of participants"
 // @Failure      400           {object}  models.ErrorResponse           "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403           {object}  models.ErrorResponse           "Forbidden"
 // @Failure      404           {object}  models.ErrorResponse           "Competition not found"
 // @Failure      500           {object}  models.ErrorResponse           "Internal Server Error"
 // @Router       /competition/{competitionID}/participants [get]
-func (s *Server) listParticipantsByCategory(c *gin.Context) {
-	competitionIDStr := c.Param("competitionID")
-	category := c.Query("category")
-
-	// Validate inputs
-	competitionID, err := strconv.ParseInt(competitionIDStr, 10, 32)
+func (s *Server) listParticipants(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
 	if err != nil {
 		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
 		return
 	}
 
-	if category == "" {
-		RespondError(c, http.StatusBadRequest, errors.New("category parameter is required"))
-		return
-	}
-
-	// Check if user has access to the competition
-	err = checkHasAccessToCompetition(c, int32(competitionID))
+	// Check if user has read access to the competition
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
 	}
 
-	// Get participants through service
-	participants, err := s.competitionService.ListParticipantsByCategory(c, int32(competitionID), category)
+	filter := aggregate.NewParticipantFilter()
+	filter.SetCategory(c.Query("category"))
+	filter.SetGender(c.Query("gender"))
+	filter.SetClub(c.Query("club"))
+	filter.SetSearch(strings.TrimSpace(c.Query("search")))
+
+	page, pageSize := getPagination(c)
+
+	participants, total, err := s.competitionService.SearchParticipants(c, int32(competitionID), filter, page, pageSize)
 	if err != nil {
 		if errors.Is(err, repository.ErrCompetitionNotFound) {
 			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
@@ -840,6 +1484,9 @@ func (s *Server) listParticipantsByCategory(c *gin.Context) {
 
 	// Build response
 	response := models.ParticipantListResponse{
+		Page:         page,
+		PageSize:     pageSize,
+		Total:        total,
 		Participants: make([]*models.ParticipantResponse, len(participants)),
 	}
 
@@ -852,12 +1499,193 @@ func (s *Server) listParticipantsByCategory(c *gin.Context) {
 			Category:      participant.GetCategory(),
 			Gender:        participant.GetGender(),
 			Club:          participant.GetClub(),
+			ClubID:        participant.GetClubID(),
+			ClubEmail:     participant.GetClubEmail(),
+			Licence:       participant.GetLicence(),
+			BirthDate:     participant.GetBirthDay(),
+
+			ConsentDataProcessing: participant.GetConsentDataProcessing(),
+			ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+			Waitlisted: participant.IsWaitlisted(),
+			CheckedIn:  participant.IsCheckedIn(),
+
+			Notes: participant.GetNotes(),
+			Flags: participant.GetFlags(),
+
+			Status:       participant.GetStatus(),
+			StatusReason: participant.GetStatusReason(),
+			PhotoURL:     participantPhotoURL(participant),
 		}
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// listParticipantDuplicates godoc
+// @Summary      List likely duplicate participants
+// @Description  Lists the pairs of participants of the same gender whose names are the same or differ by a typo, first and last names possibly swapped,
+// @Description  as happens when club files imported separately overlap. The most likely duplicates come first.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.ParticipantDuplicateListResponse  "Returns the likely duplicates"
+// @Failure      400            {object}  models.ErrorResponse                     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                     "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                     "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse                     "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                     "Internal Server Error"
+// @Router       /competition/{competitionID}/participants/duplicates [get]
+func (s *Server) listParticipantDuplicates(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	duplicates, err := s.competitionService.ListParticipantDuplicates(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.ParticipantDuplicateListResponse{
+		CompetitionID: int32(competitionID),
+		Duplicates:    make([]*models.ParticipantDuplicateResponse, 0, len(duplicates)),
+	}
+	for _, duplicate := range duplicates {
+		duplicateResponse := &models.ParticipantDuplicateResponse{
+			Participants: make([]*models.ParticipantResponse, 0, len(duplicate.GetParticipants())),
+			NameDistance: duplicate.GetNameDistance(),
+			SameClub:     duplicate.IsSameClub(),
+		}
+		for _, participant := range duplicate.GetParticipants() {
+			duplicateResponse.Participants = append(duplicateResponse.Participants, &models.ParticipantResponse{
+				CompetitionID: participant.GetCompetitionID(),
+				DossardNumber: participant.GetDossardNumber(),
+				FirstName:     participant.GetFirstName(),
+				LastName:      participant.GetLastName(),
+				Category:      participant.GetCategory(),
+				Gender:        participant.GetGender(),
+				Club:          participant.GetClub(),
+				ClubID:        participant.GetClubID(),
+				ClubEmail:     participant.GetClubEmail(),
+				Licence:       participant.GetLicence(),
+				BirthDate:     participant.GetBirthDay(),
+
+				ConsentDataProcessing: participant.GetConsentDataProcessing(),
+				ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+				Waitlisted: participant.IsWaitlisted(),
+				CheckedIn:  participant.IsCheckedIn(),
+
+				Notes: participant.GetNotes(),
+				Flags: participant.GetFlags(),
+
+				Status:       participant.GetStatus(),
+				StatusReason: participant.GetStatusReason(),
+				PhotoURL:     participantPhotoURL(participant),
+			})
+		}
+		response.Duplicates = append(response.Duplicates, duplicateResponse)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// searchParticipantsByName godoc
+// @Summary      Search participants by name
+// @Description  Lists the participants whose first or last name starts with every word of the query, the exact last names first,
+// @Description  so that a referee knowing only the name of a rider finds the dossard
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        q              query     string  true   "Beginning of the first or last name, several words matching both"
+// @Param        limit          query     int     false  "Maximum number of candidates (default: 10, max: 50)"
+// @Success      200            {object}  models.ParticipantSearchResponse  "Returns the dossard candidates"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse              "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/participants/search [get]
+func (s *Server) searchParticipantsByName(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var limit int64
+	if value := c.Query("limit"); value != "" {
+		limit, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || limit <= 0 {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid limit, expected a positive number"))
+			return
+		}
+	}
+
+	// Check if user has read access to the competition or is logged in with a referee PIN
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasRefereePinAccess(c, int32(competitionID))
+	}
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	participants, err := s.competitionService.SearchParticipantsByName(c, int32(competitionID), query, int32(limit))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyParticipantSearch):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response := models.ParticipantSearchResponse{
+		CompetitionID: int32(competitionID),
+		Query:         query,
+		Candidates:    make([]*models.ParticipantCandidateResponse, 0, len(participants)),
+	}
+	for _, participant := range participants {
+		response.Candidates = append(response.Candidates, &models.ParticipantCandidateResponse{
+			DossardNumber: participant.GetDossardNumber(),
+			FirstName:     participant.GetFirstName(),
+			LastName:      participant.GetLastName(),
+			Category:      participant.GetCategory(),
+			Gender:        participant.GetGender(),
+			Club:          participant.GetClub(),
+			Waitlisted:    participant.IsWaitlisted(),
+			CheckedIn:     participant.IsCheckedIn(),
+			Flags:         participant.GetFlags(),
+			PhotoURL:      participantPhotoURL(participant),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // exportCompetitionResults godoc
 // @Summary      Export competition results to Excel
 // @Description  Exports all competition results to an Excel file with sheets per category-gender combination
@@ -866,6 +1694,7 @@ func (s *Server) listParticipantsByCategory(c *gin.Context) {
 // @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param        Cookie        header    string  true   "Authentication cookie"
 // @Param        competitionID path      int     true   "Competition ID"
+// @Param        consent       query     string  false  "Only export the participants who gave this consent (data_processing or photo_rights)"
 // @Success      200           {file}    file    "Excel file with competition results"
 // @Failure      400           {object}  models.ErrorResponse "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse "Unauthorized"
@@ -889,9 +1718,18 @@ func (s *Server) exportCompetitionResults(c *gin.Context) {
 		return
 	}
 
+	s.sendCompetitionResults(c, int32(competitionID))
+}
+
+// sendCompetitionResults responds with the Excel file of the competition results
+func (s *Server) sendCompetitionResults(c *gin.Context, competitionID int32) {
 	// Export results through service
-	excelData, filename, err := s.competitionService.ExportCompetitionResults(c, int32(competitionID))
+	excelData, filename, err := s.competitionService.ExportCompetitionResults(c, competitionID, c.Query("consent"))
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidConsent) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
 		if errors.Is(err, repository.ErrCompetitionNotFound) {
 			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
 			return
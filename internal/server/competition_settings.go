@@ -0,0 +1,135 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getCompetitionSettings godoc
+// @Summary      Get the settings of a competition
+// @Description  Returns the runs expected from each participant in each zone, the zones of each category and how the runs are scored.
+// @Description  Competitions whose settings were never set return the default ones.
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.CompetitionSettingsResponse  "Returns the settings of the competition"
+// @Failure      400            {object}  models.ErrorResponse                "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse                "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                "Internal Server Error"
+// @Router       /competition/{competitionID}/settings [get]
+func (s *Server) getCompetitionSettings(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	settings, err := s.competitionService.GetCompetitionSettings(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCompetitionSettingsResponse(settings))
+}
+
+// updateCompetitionSettings godoc
+// @Summary      Update the settings of a competition
+// @Description  Sets the runs expected from each participant in each zone (0 to 10, 0 keeps two runs with two zones and a single run otherwise),
+// @Description  the zones of each category (0 to 20, 0 for no limit) and the scoring: all_runs adds up every run, best_run only counts the best run of each zone.
+// @Description  The disqualified runs score no points with the zero_points run DSQ rule (default), and also disqualify their participant with disqualify.
+// @Description  The results export follows these settings, and adding a zone to a category which already has its zones is refused.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                           true  "Authentication cookie"
+// @Param        competitionID  path      int                              true  "Competition ID"
+// @Param        settings       body      models.CompetitionSettingsInput  true  "Settings of the competition"
+// @Success      200            {object}  models.CompetitionSettingsResponse  "Returns the settings of the competition"
+// @Failure      400            {object}  models.ErrorResponse                "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse                "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                "Internal Server Error"
+// @Router       /competition/{competitionID}/settings [put]
+func (s *Server) updateCompetitionSettings(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.CompetitionSettingsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	settings := aggregate.NewCompetitionSettings()
+	settings.SetCompetitionID(int32(competitionID))
+	settings.SetRunsPerZone(input.RunsPerZone)
+	settings.SetZonesPerCategory(input.ZonesPerCategory)
+	settings.SetScoring(input.Scoring)
+	settings.SetRestrictRefereeZones(input.RestrictRefereeZones)
+	settings.SetVerifyLicences(input.VerifyLicences)
+	settings.SetRunDSQRule(input.RunDSQRule)
+	settings.SetRequireRunApproval(input.RequireRunApproval)
+
+	err = s.competitionService.UpdateCompetitionSettings(c, settings)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRunsPerZone),
+			errors.Is(err, service.ErrInvalidZonesPerCategory),
+			errors.Is(err, service.ErrInvalidScoring),
+			errors.Is(err, service.ErrInvalidRunDSQRule):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toCompetitionSettingsResponse(settings))
+}
+
+// toCompetitionSettingsResponse builds the response describing the settings of a competition
+func toCompetitionSettingsResponse(settings *aggregate.CompetitionSettings) models.CompetitionSettingsResponse {
+	return models.CompetitionSettingsResponse{
+		CompetitionID:        settings.GetCompetitionID(),
+		RunsPerZone:          settings.GetRunsPerZone(),
+		ZonesPerCategory:     settings.GetZonesPerCategory(),
+		Scoring:              settings.GetScoring(),
+		RestrictRefereeZones: settings.RestrictsRefereeZones(),
+		VerifyLicences:       settings.VerifiesLicences(),
+		RunDSQRule:           settings.GetRunDSQRule(),
+		RequireRunApproval:   settings.RequiresRunApproval(),
+	}
+}
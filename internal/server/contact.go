@@ -0,0 +1,178 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// addCompetitionContact godoc
+// @Summary      Add an organizer contact
+// @Description  Adds an organizer contact to the competition. The role (results or logistics) selects which notifications the contact receives.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                          true  "Authentication cookie"
+// @Param        competitionID  path      int                             true  "Competition ID"
+// @Param        contact        body      models.CompetitionContactInput  true  "Contact data"
+// @Success      201            {object}  models.CompetitionContactResponse  "Returns the created contact"
+// @Failure      400            {object}  models.ErrorResponse               "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse               "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse               "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse               "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse               "Contact already has this role"
+// @Failure      500            {object}  models.ErrorResponse               "Internal Server Error"
+// @Router       /competition/{competitionID}/contacts [post]
+func (s *Server) addCompetitionContact(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.CompetitionContactInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	contact := aggregate.NewCompetitionContact()
+	contact.SetCompetitionID(int32(competitionID))
+	contact.SetName(input.Name)
+	contact.SetEmail(input.Email)
+	contact.SetPhone(input.Phone)
+	contact.SetRole(input.Role)
+
+	err = s.competitionService.AddContact(c, contact)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidContactRole) {
+			RespondError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repository.ErrDuplicateContact) {
+			RespondError(c, http.StatusConflict, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCompetitionContactResponse(contact))
+}
+
+// listCompetitionContacts godoc
+// @Summary      List organizer contacts
+// @Description  Lists the organizer contacts of the competition with their role
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.CompetitionContactListResponse  "Returns the contacts"
+// @Failure      400            {object}  models.ErrorResponse                   "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                   "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                   "Forbidden"
+// @Failure      500            {object}  models.ErrorResponse                   "Internal Server Error"
+// @Router       /competition/{competitionID}/contacts [get]
+func (s *Server) listCompetitionContacts(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has access to the competition
+	err = checkHasAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	contacts, err := s.competitionService.ListContacts(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.CompetitionContactListResponse{
+		CompetitionID: int32(competitionID),
+		Contacts:      make([]models.CompetitionContactResponse, 0, len(contacts)),
+	}
+	for _, contact := range contacts {
+		response.Contacts = append(response.Contacts, toCompetitionContactResponse(contact))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// deleteCompetitionContact godoc
+// @Summary      Delete an organizer contact
+// @Description  Removes an organizer contact from the competition
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        contactID      path      int     true  "Contact ID"
+// @Success      200            {object}  gin.H                 "Returns success message"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Contact not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/contacts/{contactID} [delete]
+func (s *Server) deleteCompetitionContact(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	contactID, err := strconv.ParseInt(c.Param("contactID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid contact ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.competitionService.DeleteContact(c, int32(competitionID), int32(contactID))
+	if err != nil {
+		if errors.Is(err, repository.ErrContactNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Contact removed from competition"})
+}
+
+func toCompetitionContactResponse(contact *aggregate.CompetitionContact) models.CompetitionContactResponse {
+	return models.CompetitionContactResponse{
+		ID:    contact.GetID(),
+		Name:  contact.GetName(),
+		Email: contact.GetEmail(),
+		Phone: contact.GetPhone(),
+		Role:  contact.GetRole(),
+	}
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// registerDisplayDevice godoc
+// @Summary      Register a display device
+// @Description  Registers a scoreboard display of the competition and returns its display token, which is only returned once.
+// @Description  The device logs in with it on /login/display and can then only read the live ranking and the zones of the competition.
+// @Description  Its access tokens are refreshed automatically until the device expires or is revoked.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                     true  "Authentication cookie"
+// @Param        competitionID  path      int                        true  "Competition ID"
+// @Param        device         body      models.DisplayDeviceInput  true  "Name of the device and lifetime in hours (default: DISPLAY_TOKEN_LIFETIME)"
+// @Success      201            {object}  models.DisplayDeviceRegistrationResponse  "Returns the device and its display token"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/display-devices [post]
+func (s *Server) registerDisplayDevice(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.DisplayDeviceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	admin, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	lifetime := time.Duration(input.LifetimeHours) * time.Hour
+	device, displayToken, err := s.userService.RegisterDisplayDevice(c.Request.Context(), int32(competitionID), input.Name, lifetime, admin.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceImpl.ErrInvalidDisplayDeviceName), errors.Is(err, serviceImpl.ErrInvalidDisplayLifetime):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.DisplayDeviceRegistrationResponse{
+		Device:       toDisplayDeviceResponse(device),
+		DisplayToken: displayToken,
+	})
+}
+
+// listDisplayDevices godoc
+// @Summary      List the display devices
+// @Description  Lists the display devices of the competition with the last time they refreshed their token, revoked and expired ones included
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.DisplayDeviceListResponse  "Returns the display devices"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/display-devices [get]
+func (s *Server) listDisplayDevices(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	devices, err := s.userService.ListDisplayDevices(c.Request.Context(), int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.DisplayDeviceListResponse{
+		Devices: make([]models.DisplayDeviceResponse, 0, len(devices)),
+	}
+	for _, device := range devices {
+		response.Devices = append(response.Devices, toDisplayDeviceResponse(device))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// revokeDisplayDevice godoc
+// @Summary      Revoke a display device
+// @Description  Revokes a display device so that its token is no longer refreshed, the device stays logged in until its current access token expires
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        deviceID       path      int     true  "Display device ID"
+// @Success      204            "Display device revoked"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Display device not found or already revoked"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/display-devices/{deviceID} [delete]
+func (s *Server) revokeDisplayDevice(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	deviceID, err := strconv.ParseInt(c.Param("deviceID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid display device ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.userService.RevokeDisplayDevice(c.Request.Context(), int32(competitionID), int32(deviceID))
+	if err != nil {
+		if errors.Is(err, repository.ErrDisplayDeviceNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// loginWithDisplayToken godoc
+// @Summary      Log a display device in
+// @Description  Exchanges the display token of a device for a token restricted to reading the live ranking and the zones of its competition.
+// @Description  The display token is kept as the refresh token, so the access token is refreshed transparently until the device expires or is revoked.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      models.DisplayLoginInput  true  "Display token of the device"
+// @Success      200    {object}  models.RoleResponse       "Logged in, returns the roles of the token"
+// @Failure      400    {object}  models.ErrorResponse      "Bad Request"
+// @Failure      401    {object}  models.ErrorResponse      "Invalid display token, or device revoked or expired"
+// @Failure      500    {object}  models.ErrorResponse      "Internal Server Error"
+// @Router       /login/display [post]
+func (s *Server) loginWithDisplayToken(c *gin.Context) {
+	var loginInput models.DisplayLoginInput
+	if err := c.ShouldBindJSON(&loginInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tokens, err := s.userService.LoginWithDisplayToken(c, loginInput.DisplayToken)
+	if err != nil {
+		if errors.Is(err, serviceImpl.ErrInvalidDisplayToken) {
+			RespondError(c, http.StatusUnauthorized, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	middlewares.SetTokenCookies(c, tokens)
+	c.Header("x-token-refreshed", "true")
+
+	c.JSON(http.StatusOK, models.RoleResponse{
+		Roles: tokens.GetRoles(),
+	})
+}
+
+// toDisplayDeviceResponse converts a display device to its response
+func toDisplayDeviceResponse(device *aggregate.DisplayDevice) models.DisplayDeviceResponse {
+	response := models.DisplayDeviceResponse{
+		ID:            device.GetID(),
+		CompetitionID: device.GetCompetitionID(),
+		Name:          device.GetName(),
+		CreatedBy:     device.GetCreatedBy(),
+		CreatedAt:     device.GetCreatedAt(),
+		ExpiresAt:     device.GetExpiresAt(),
+		Active:        device.IsActive(),
+	}
+	if lastSeenAt := device.GetLastSeenAt(); !lastSeenAt.IsZero() {
+		response.LastSeenAt = &lastSeenAt
+	}
+	if revokedAt := device.GetRevokedAt(); !revokedAt.IsZero() {
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	service "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// setExportTemplate godoc
+// @Summary      Upload the results export template
+// @Description  Stores an XLSX workbook the results export fills instead of the default layout, keeping its logo, colors and fixed columns.
+// @Description  Its first sheet is copied for every category and gender. Text cells can hold {{competition}}, {{date}}, {{location}}, {{organizer}},
+// @Description  {{category}}, {{gender}} and {{runN_zone}}. The row holding cells such as {{rank}}, {{dossard}}, {{last_name}}, {{first_name}}, {{club}},
+// @Description  {{runN_points}}, {{runN_penalty}}, {{runN_time}}, {{total_points}}, {{total_penalty}}, {{total_time}} and {{points_earned}} is repeated for every participant.
+// @Tags         competition
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        file           formData  file    true  "XLSX template, at most 5 MB"
+// @Success      200            {object}  models.ExportTemplateResponse  "Returns the stored template"
+// @Failure      400            {object}  models.ErrorResponse  "Invalid template"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      413            {object}  models.ErrorResponse  "Template too large"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/export-template [put]
+func (s *Server) setExportTemplate(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	file, fileHeader, err := c.Request.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("file is required"))
+		return
+	}
+	defer file.Close()
+
+	template, err := s.competitionService.SetExportTemplate(c, int32(competitionID), file, fileHeader.Filename)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidExportTemplate):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, service.ErrExportTemplateTooLarge):
+			RespondError(c, http.StatusRequestEntityTooLarge, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ExportTemplateResponse{
+		CompetitionID: template.GetCompetitionID(),
+		Filename:      template.GetFilename(),
+		Size:          len(template.GetContent()),
+		UploadedAt:    template.GetUploadedAt(),
+	})
+}
+
+// getExportTemplate godoc
+// @Summary      Download the results export template
+// @Description  Downloads the XLSX template the results export of the competition fills
+// @Tags         competition
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {file}    file    "XLSX template"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition or template not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/export-template [get]
+func (s *Server) getExportTemplate(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	template, err := s.competitionService.GetExportTemplate(c, int32(competitionID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrExportTemplateNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", template.GetFilename()))
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", template.GetContent())
+}
+
+// deleteExportTemplate godoc
+// @Summary      Delete the results export template
+// @Description  Deletes the export template of the competition, its results are exported with the default layout again
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      204            "Template deleted"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition or template not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/export-template [delete]
+func (s *Server) deleteExportTemplate(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.competitionService.DeleteExportTemplate(c, int32(competitionID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrExportTemplateNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
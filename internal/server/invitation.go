@@ -0,0 +1,287 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// listInvitations godoc
+// @Summary      List pending invitations
+// @Description  Lists the invitation links of the competition that are neither revoked nor expired, with their expiry and how many users accepted them
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.InvitationListResponse  "Returns the pending invitations"
+// @Failure      400            {object}  models.ErrorResponse           "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse           "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse           "Internal Server Error"
+// @Router       /competition/{competitionID}/invitations [get]
+func (s *Server) listInvitations(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	invitations, err := s.userService.ListInvitations(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.InvitationListResponse{
+		CompetitionID: int32(competitionID),
+		Invitations:   make([]models.InvitationResponse, 0, len(invitations)),
+	}
+	for _, invitation := range invitations {
+		response.Invitations = append(response.Invitations, models.InvitationResponse{
+			ID:             invitation.GetID(),
+			Role:           invitation.GetRole(),
+			Zone:           invitation.GetZone(),
+			CreatedBy:      invitation.GetCreatedBy(),
+			CreatedAt:      invitation.GetCreatedAt(),
+			ExpiresAt:      invitation.GetExpiresAt(),
+			Acceptances:    invitation.GetAcceptances(),
+			MaxAcceptances: invitation.GetMaxAcceptances(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// revokeInvitation godoc
+// @Summary      Revoke an invitation
+// @Description  Revokes a pending invitation, its link can no longer be accepted. Users who already accepted it keep their role.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        invitationID   path      string  true  "Invitation ID"
+// @Success      200            {object}  gin.H                 "Invitation revoked"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Invitation not found or no longer pending"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/invitations/{invitationID} [delete]
+func (s *Server) revokeInvitation(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.userService.RevokeInvitation(c, int32(competitionID), c.Param("invitationID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked"})
+}
+
+// extendInvitation godoc
+// @Summary      Extend an invitation
+// @Description  Makes a pending invitation valid for the given lifetime from now (default: 15 minutes, at most 7 days) and returns a new token for it
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                        true   "Authentication cookie"
+// @Param        competitionID  path      int                           true   "Competition ID"
+// @Param        invitationID   path      string                        true   "Invitation ID"
+// @Param        invitation     body      models.InvitationExtendInput  false  "New lifetime"
+// @Success      200            {object}  models.RefereeInvitationResponse  "Returns the new invitation token"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse              "Invitation not found or no longer pending"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/invitations/{invitationID}/extend [post]
+func (s *Server) extendInvitation(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// The body is optional, the default lifetime applies without it
+	var extendInput models.InvitationExtendInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&extendInput); err != nil {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	lifetime := time.Duration(extendInput.LifetimeMinutes) * time.Minute
+	token, invitation, err := s.userService.ExtendInvitation(c, int32(competitionID), c.Param("invitationID"), lifetime)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidInvitationLifetime):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrInvitationNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RefereeInvitationResponse{
+		InvitationID: invitation.GetID(),
+		Token:        token,
+		ExpiresAt:    invitation.GetExpiresAt().Unix(),
+	})
+}
+
+// generateRefereeInvitationLinks godoc
+// @Summary      Generate single-use referee invitation links
+// @Description  Generates count distinct referee invitation links that can each be accepted once, for distributing to the referee team on the morning of the event.
+// @Description  The links can be labelled with the zone the referees are expected at and are valid for lifetime_minutes (default: 72 hours, at most 7 days).
+// @Description  With format=xlsx a printable sheet listing the links is returned instead of the tokens.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        Cookie         header    string                              true   "Authentication cookie"
+// @Param        competitionID  path      int                                 true   "Competition ID"
+// @Param        format         query     string                              false  "Set to xlsx for a printable sheet"
+// @Param        invitations    body      models.RefereeInvitationBatchInput  true   "Number of links, zone and lifetime"
+// @Success      201            {object}  models.RefereeInvitationBatchResponse  "Returns the invitation tokens"
+// @Failure      400            {object}  models.ErrorResponse                   "Bad Request (invalid count, lifetime or zone)"
+// @Failure      401            {object}  models.ErrorResponse                   "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                   "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse                   "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                   "Internal Server Error"
+// @Router       /competition/{competitionID}/referee/invitations [post]
+func (s *Server) generateRefereeInvitationLinks(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.RefereeInvitationBatchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "xlsx" {
+		RespondError(c, http.StatusBadRequest, errors.New("format must be 'json' or 'xlsx'"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The zone must be one of the competition, in any category
+	if input.Zone != "" {
+		zones, err := s.competitionService.ListZones(c, int32(competitionID))
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		found := false
+		for _, zone := range zones {
+			if zone.GetZone() == input.Zone {
+				found = true
+				break
+			}
+		}
+		if !found {
+			RespondError(c, http.StatusBadRequest, errors.New("zone not found in the competition"))
+			return
+		}
+	}
+
+	lifetime := time.Duration(input.LifetimeMinutes) * time.Minute
+	tokens, invitations, err := s.userService.GenerateRefereeInvitationTokens(c, int32(competitionID), input.Count, input.Zone, lifetime)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInvitationCount) || errors.Is(err, service.ErrInvalidInvitationLifetime) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format == "xlsx" {
+		sheet, filename, err := s.userService.BuildRefereeInvitationSheet(competition, tokens, invitations)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		c.Data(http.StatusCreated, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", sheet)
+		return
+	}
+
+	response := models.RefereeInvitationBatchResponse{
+		CompetitionID: int32(competitionID),
+		Zone:          input.Zone,
+		Invitations:   make([]models.RefereeInvitationResponse, 0, len(invitations)),
+	}
+	for i, invitation := range invitations {
+		response.Invitations = append(response.Invitations, models.RefereeInvitationResponse{
+			InvitationID: invitation.GetID(),
+			Token:        tokens[i],
+			ExpiresAt:    invitation.GetExpiresAt().Unix(),
+		})
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
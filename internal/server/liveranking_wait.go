@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultLiverankingWaitSeconds is how long a long poll waits for the liveranking to change by default
+	defaultLiverankingWaitSeconds = 30
+	// maxLiverankingWaitSeconds bounds how long a long poll waits, below the timeouts of the usual proxies
+	maxLiverankingWaitSeconds = 55
+)
+
+// waitLiveranking godoc
+// @Summary      Wait for a liveranking change
+// @Description  Long poll fallback for the clients that can use neither WebSocket nor SSE: blocks until the liveranking version of the
+// @Description  competition differs from `version` or `timeout` seconds elapsed, then returns the current version. The client then reloads
+// @Description  the liveranking when it changed and waits again with the new version. Without `version`, the current version is returned at once.
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        version        query     int     false  "Liveranking version the client has"
+// @Param        timeout        query     int     false  "Seconds to wait for a change (default: 30, at most 55)"
+// @Param        X-Api-Key      header    string  false  "API key with the read-liveranking scope, replaces the cookie"
+// @Success      200            {object}  models.LiverankingVersionResponse  "Returns the liveranking version"
+// @Failure      400            {object}  models.ErrorResponse               "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse               "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse               "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse               "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse               "Internal Server Error"
+// @Router       /competition/{competitionID}/liveranking/wait [get]
+func (s *Server) waitLiveranking(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	timeout, err := strconv.Atoi(c.DefaultQuery("timeout", strconv.Itoa(defaultLiverankingWaitSeconds)))
+	if err != nil || timeout < 1 || timeout > maxLiverankingWaitSeconds {
+		RespondError(c, http.StatusBadRequest, errors.New("timeout must be a number of seconds between 1 and 55"))
+		return
+	}
+
+	// Same access as the liveranking itself
+	err = checkHasAdminOrObserverAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasAPIKeyScope(c, aggregate.APIKeyScopeReadLiveranking, int32(competitionID))
+	}
+	if err != nil {
+		err = checkHasDisplayAccess(c, int32(competitionID))
+	}
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	_, err = s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.LiverankingVersionResponse{CompetitionID: int32(competitionID)}
+
+	versionStr := c.Query("version")
+	if versionStr == "" {
+		response.Version = s.runService.LiverankingVersion(int32(competitionID))
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	version, err := strconv.ParseInt(versionStr, 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid version"))
+		return
+	}
+
+	// The wait also ends when the client disconnects
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	response.Version = s.runService.WaitLiverankingChange(ctx, int32(competitionID), version)
+	response.Changed = response.Version != version
+
+	c.JSON(http.StatusOK, response)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/NiskuT/cross-api/internal/domain/models"
 	"github.com/NiskuT/cross-api/internal/server/middlewares"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 )
 
 // ErrMissingAuthorizationHeader indicates an Authorization header was not provided.
@@ -51,8 +52,7 @@ func (s *Server) login(c *gin.Context) {
 	clientIP := s.rateLimiter.GetClientIP(c)
 	s.rateLimiter.ResetAttempts("login", clientIP)
 
-	c.SetCookie(middlewares.AccessToken, user.GetAccessToken(), 0, "/", "", middlewares.SecureMode, true)
-	c.SetCookie(middlewares.RefreshToken, user.GetRefreshToken(), 0, "/", "", middlewares.SecureMode, true)
+	middlewares.SetTokenCookies(c, user)
 
 	// Add headers when tokens are set
 	c.Header("x-token-refreshed", "true")
@@ -72,24 +72,74 @@ func (s *Server) login(c *gin.Context) {
 
 // logout godoc
 // @Summary      Log out a user
-// @Description  Clears authentication cookies to log out the user
+// @Description  Revokes the session and clears authentication cookies to log out the user
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Success      200           {object}  gin.H                         "Successfully logged out"
 // @Router       /logout [post]
 func (s *Server) logout(c *gin.Context) {
-	// Clear the access token cookie
-	c.SetCookie(middlewares.AccessToken, "", -1, "/", "", middlewares.SecureMode, true)
+	// Deny the access token so it cannot be replayed until it expires
+	if accessToken, err := c.Cookie(middlewares.AccessToken); err == nil && accessToken != "" {
+		if err := s.userService.RevokeAccessToken(c, accessToken); err != nil {
+			log.Warn().Err(err).Msg("Failed to deny access token on logout")
+		}
+	}
+
+	// Revoke the session so the refresh token cannot be reused
+	if refreshToken, err := c.Cookie(middlewares.RefreshToken); err == nil && refreshToken != "" {
+		if err := s.userService.RevokeRefreshToken(c, refreshToken); err != nil {
+			log.Warn().Err(err).Msg("Failed to revoke session on logout")
+		}
+	}
 
-	// Clear the refresh token cookie
-	c.SetCookie(middlewares.RefreshToken, "", -1, "/", "", middlewares.SecureMode, true)
+	// Clear the access and refresh token cookies
+	middlewares.ClearTokenCookies(c)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Successfully logged out",
 	})
 }
 
+// logoutAll godoc
+// @Summary      Log out from every device
+// @Description  Revokes every session of the user and invalidates all their outstanding access tokens, not just the current cookies
+// @Tags         auth
+// @Produce      json
+// @Param        Cookie        header    string                  true  "Authentication cookie"
+// @Success      200           {object}  gin.H                   "Successfully logged out from every device"
+// @Failure      401           {object}  models.ErrorResponse    "Unauthorized"
+// @Failure      500           {object}  models.ErrorResponse    "Internal Server Error"
+// @Router       /auth/logout-all [post]
+func (s *Server) logoutAll(c *gin.Context) {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	revoked, err := s.userService.LogoutAll(c.Request.Context(), user.Id)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The tokens issued within the second of the logout are not denied with the others, deny the current one itself
+	if accessToken, err := c.Cookie(middlewares.AccessToken); err == nil && accessToken != "" {
+		if err := s.userService.RevokeAccessToken(c, accessToken); err != nil {
+			log.Warn().Err(err).Msg("Failed to deny access token on logout")
+		}
+	}
+
+	// Clear the access and refresh token cookies
+	middlewares.ClearTokenCookies(c)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Successfully logged out from every device",
+		"revoked_sessions": revoked,
+	})
+}
+
 // changePassword godoc
 // @Summary      Change user password
 // @Description  Allows authenticated users to change their password by providing current and new password
@@ -99,7 +149,7 @@ func (s *Server) logout(c *gin.Context) {
 // @Param        Cookie              header    string                         true  "Authentication cookie"
 // @Param        changePasswordRequest body      models.ChangePasswordInput     true  "Password change data"
 // @Success      200                 {object}  gin.H                          "Password changed successfully"
-// @Failure      400                 {object}  models.ErrorResponse           "Bad Request"
+// @Failure      400                 {object}  models.PasswordPolicyErrorResponse  "Bad Request, or new password breaking the password policy"
 // @Failure      401                 {object}  models.ErrorResponse           "Unauthorized (invalid current password)"
 // @Failure      500                 {object}  models.ErrorResponse           "Internal Server Error"
 // @Router       /auth/password [put]
@@ -126,6 +176,9 @@ func (s *Server) changePassword(c *gin.Context) {
 	)
 
 	if err != nil {
+		if respondPasswordPolicyError(c, err) {
+			return
+		}
 		if err.Error() == "invalid email or password" {
 			RespondError(c, http.StatusUnauthorized, errors.New("current password is incorrect"))
 		} else {
@@ -169,3 +222,14 @@ func (s *Server) forgotPassword(c *gin.Context) {
 		"message": "If the email address exists in our system, a new password has been sent to it",
 	})
 }
+
+// getJWKS godoc
+// @Summary      Get the JSON Web Key Set
+// @Description  Returns the public keys used to verify the tokens issued by the API. Empty when tokens are signed with a shared secret.
+// @Tags         auth
+// @Produce      json
+// @Success      200           {object}  models.JWKSResponse           "JSON Web Key Set"
+// @Router       /.well-known/jwks.json [get]
+func (s *Server) getJWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, s.keySet.JWKS())
+}
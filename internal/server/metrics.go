@@ -0,0 +1,45 @@
+package server
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getMetrics godoc
+// @Summary      Business metrics
+// @Description  Runs recorded per competition, email failures, and invitations per competition with their acceptance, in the Prometheus text format.
+// @Description  Requires the METRICS_TOKEN as bearer token, the endpoint is disabled when it is not configured.
+// @Tags         monitoring
+// @Produce      plain
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {string}  string  "Metrics in the Prometheus text format"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      404            {object}  models.ErrorResponse  "Metrics are disabled"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /metrics [get]
+func (s *Server) getMetrics(c *gin.Context) {
+	if s.conf.Metrics.Token == "" || s.metrics == nil {
+		RespondError(c, http.StatusNotFound, errors.New("metrics are disabled"))
+		return
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.conf.Metrics.Token)) != 1 {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	// Render before writing so that a failing query does not produce a partial scrape
+	var buffer bytes.Buffer
+	if err := s.metrics.Write(c, &buffer); err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", buffer.Bytes())
+}
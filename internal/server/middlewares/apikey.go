@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+	"github.com/NiskuT/cross-api/internal/domain/service"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-Api-Key"
+
+// APIKeyRole returns the role granted to requests authenticated with a key having the scope on the competition
+func APIKeyRole(scope string, competitionID int32) string {
+	return fmt.Sprintf("apikey:%s:%d", scope, competitionID)
+}
+
+// APIKeyAuthentication authenticates requests carrying an API key.
+// The key scopes are exposed as roles of the request user, and requests without
+// key are left to the cookie based Authentication middleware.
+func APIKeyAuthentication(apiKeyService service.APIKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		plainKey := c.GetHeader(APIKeyHeader)
+		if plainKey == "" {
+			c.Next()
+			return
+		}
+
+		apiKey, err := apiKeyService.Authenticate(c.Request.Context(), plainKey)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+			return
+		}
+
+		roles := make([]string, 0, len(apiKey.GetScopes()))
+		for _, scope := range apiKey.GetScopes() {
+			roles = append(roles, APIKeyRole(scope, apiKey.GetCompetitionID()))
+		}
+
+		c.Set("user", entity.UserToken{Roles: roles})
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/gin-gonic/gin"
+)
+
+// Cookie attributes, set from the configuration when the server starts
+var (
+	CookieDomain   = ""
+	CookieSameSite = http.SameSiteDefaultMode
+)
+
+// SetCookie sets an HTTP only cookie with the configured domain, SameSite and secure attributes
+func SetCookie(c *gin.Context, name, value string, maxAge int, path string) {
+	c.SetSameSite(CookieSameSite)
+	c.SetCookie(name, value, maxAge, path, CookieDomain, SecureMode, true)
+}
+
+// SetTokenCookies stores the access and refresh tokens in session cookies.
+// The refresh token cookie is cleared for tokens that cannot be refreshed, so that the
+// cookie of a previous login on the device is not used to refresh them.
+func SetTokenCookies(c *gin.Context, tokens *aggregate.JwtToken) {
+	SetCookie(c, AccessToken, tokens.GetAccessToken(), 0, "/")
+	if tokens.GetRefreshToken() == "" {
+		SetCookie(c, RefreshToken, "", -1, "/")
+		return
+	}
+	SetCookie(c, RefreshToken, tokens.GetRefreshToken(), 0, "/")
+}
+
+// ClearTokenCookies removes the access and refresh token cookies
+func ClearTokenCookies(c *gin.Context) {
+	SetCookie(c, AccessToken, "", -1, "/")
+	SetCookie(c, RefreshToken, "", -1, "/")
+}
@@ -3,9 +3,11 @@ package middlewares
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	"github.com/NiskuT/cross-api/internal/domain/entity"
 	"github.com/NiskuT/cross-api/internal/domain/service"
 	"github.com/gin-gonic/gin"
@@ -34,13 +36,8 @@ func extractAccessToken(c *gin.Context) (string, error) {
 }
 
 // parseAndValidateToken parses the JWT token and validates it
-func parseAndValidateToken(tokenStr, secretKey string) (*jwt.Token, error) {
-	return jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secretKey), nil
-	})
+func parseAndValidateToken(tokenStr string, keyFunc jwt.Keyfunc) (*jwt.Token, error) {
+	return jwt.Parse(tokenStr, keyFunc)
 }
 
 // handleExpiredToken processes refresh token logic when the access token has expired
@@ -50,13 +47,12 @@ func handleExpiredToken(c *gin.Context, userService service.UserService) (bool,
 		return false, errors.New("refresh token missing")
 	}
 
-	tokens, err := userService.RefreshToken(c.Request.Context(), refreshToken)
+	tokens, err := userService.RefreshToken(c, refreshToken)
 	if err != nil {
 		return false, errors.New("invalid refresh token")
 	}
 
-	c.SetCookie(AccessToken, tokens.GetAccessToken(), 0, "/", "", SecureMode, true)
-	c.SetCookie(RefreshToken, tokens.GetRefreshToken(), 0, "/", "", SecureMode, true)
+	SetTokenCookies(c, tokens)
 
 	// Add headers when tokens are refreshed
 	c.Header("x-token-refreshed", "true")
@@ -97,6 +93,24 @@ func extractUserFromClaims(claims jwt.MapClaims) (entity.UserToken, error) {
 		customClaims.Email = email
 	}
 
+	// Extract session ID, tokens issued before sessions existed have none
+	if sessionID, ok := claims["sid"].(string); ok {
+		customClaims.SessionID = sessionID
+	}
+
+	// Extract token ID and issue time, checked against the denylist
+	if tokenID, ok := claims["jti"].(string); ok {
+		customClaims.TokenID = tokenID
+	}
+	if issuedAt, ok := claims["iat"].(float64); ok {
+		customClaims.IssuedAt = time.Unix(int64(issuedAt), 0)
+	}
+
+	// Extract scope, only restricted tokens have one
+	if scope, ok := claims["scope"].(string); ok {
+		customClaims.Scope = scope
+	}
+
 	// Extract roles
 	if rolesVal, ok := claims["roles"]; ok {
 		switch roles := rolesVal.(type) {
@@ -108,14 +122,36 @@ func extractUserFromClaims(claims jwt.MapClaims) (entity.UserToken, error) {
 			}
 		case []string:
 			customClaims.Roles = roles
+		case string:
+			customClaims.Roles = strings.Split(roles, ",")
 		}
 	}
+	customClaims.Roles = normalizeRoles(customClaims.Roles)
 
 	return customClaims, nil
 }
 
-func Authentication(secretKey string, userService service.UserService) gin.HandlerFunc {
+// normalizeRoles keeps the tokens issued while roles were stored as a comma-separated string valid:
+// they were split without trimming, which left spaces around the roles and an empty role for users
+// without any
+func normalizeRoles(roles []string) []string {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if trimmed := strings.TrimSpace(role); trimmed != "" {
+			normalized = append(normalized, trimmed)
+		}
+	}
+	return normalized
+}
+
+func Authentication(keyFunc jwt.Keyfunc, userService service.UserService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Requests already authenticated with an API key do not carry cookies
+		if _, exists := c.Get("user"); exists {
+			c.Next()
+			return
+		}
+
 		var tokenStr string
 		var err error
 		var refreshed bool
@@ -129,7 +165,7 @@ func Authentication(secretKey string, userService service.UserService) gin.Handl
 		}
 
 		// Step 2: Parse and validate token
-		token, err := parseAndValidateToken(tokenStr, secretKey)
+		token, err := parseAndValidateToken(tokenStr, keyFunc)
 		if err != nil {
 			// Check specifically for token expiration
 			if !refreshed {
@@ -171,12 +207,53 @@ func Authentication(secretKey string, userService service.UserService) gin.Handl
 			return
 		}
 
-		// Step 6: Attach user to context
+		// Step 6: Reject tokens revoked by a logout before they expire
+		if userService.IsAccessTokenRevoked(c, customClaims) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
+			return
+		}
+
+		// Step 7: Keep restricted tokens to the routes of their scope
+		if customClaims.Scope != "" && !isRouteInScope(customClaims.Scope, c.Request.Method, c.FullPath()) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Token restricted to another action"})
+			return
+		}
+
+		// Step 8: Attach user to context
 		c.Set("user", customClaims)
 		c.Next()
 	}
 }
 
+// scopedRoutes lists the routes a restricted token can reach, by scope
+var scopedRoutes = map[string][]string{
+	aggregate.RefereePinScope: {
+		"POST /run",
+		"PUT /run",
+		"DELETE /run",
+		"POST /run/void",
+		"GET /competition/:competitionID/participants/search",
+		"GET /competition/:competitionID/participant/:dossard",
+		"GET /competition/:competitionID/participant/:dossard/photo",
+	},
+	aggregate.DisplayScope: {
+		"GET /competition/:competitionID/liveranking",
+		"GET /competition/:competitionID/liveranking/wait",
+		"GET /competition/:competitionID/teamranking",
+		"GET /competition/:competitionID/zones",
+	},
+}
+
+// isRouteInScope returns whether the route can be reached with a token restricted to the scope
+func isRouteInScope(scope, method, path string) bool {
+	for _, route := range scopedRoutes[scope] {
+		if route == method+" "+path {
+			return true
+		}
+	}
+	return false
+}
+
 func GetUser(c *gin.Context) (*entity.UserToken, error) {
 	val, exists := c.Get("user")
 	if !exists {
@@ -204,3 +281,14 @@ func HasRole(c *gin.Context, role string) bool {
 	}
 	return false
 }
+
+// SessionClient records the device making the request, it is stored with the sessions opened by the request
+func SessionClient() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(entity.SessionClientKey, entity.SessionClient{
+			UserAgent: c.Request.UserAgent(),
+			IP:        c.ClientIP(),
+		})
+		c.Next()
+	}
+}
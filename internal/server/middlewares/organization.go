@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/service"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationRoles gives the admins of an organization the admin role of every competition of the organization
+// for the request, so that the competition access checks apply to them and the competitions added to the
+// organization are reachable without a new token
+func OrganizationRoles(competitionService service.CompetitionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := GetUser(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		var organizationIDs []int32
+		for _, role := range user.Roles {
+			if organizationID, ok := aggregate.ParseOrganizationAdminRole(role); ok {
+				organizationIDs = append(organizationIDs, organizationID)
+			}
+		}
+		if len(organizationIDs) == 0 {
+			c.Next()
+			return
+		}
+
+		competitionIDs, err := competitionService.ListOrganizationCompetitionIDs(c, organizationIDs)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to load the competitions of the organizations"})
+			return
+		}
+
+		roles := append([]string{}, user.Roles...)
+		for _, competitionID := range competitionIDs {
+			role := fmt.Sprintf("admin:%d", competitionID)
+			if !HasRole(c, role) {
+				roles = append(roles, role)
+			}
+		}
+		user.Roles = roles
+
+		c.Set("user", *user)
+		c.Next()
+	}
+}
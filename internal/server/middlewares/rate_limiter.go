@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,12 +18,30 @@ type EndpointLimit struct {
 	Window      time.Duration
 }
 
+// EndpointState is the limit of an endpoint and the number of requests it rejected since startup
+type EndpointState struct {
+	Endpoint    string
+	MaxAttempts int
+	Window      time.Duration
+	Rejections  int64
+}
+
+// KeyState is the current usage of an endpoint by a key, an IP address or a user
+type KeyState struct {
+	Endpoint  string
+	Key       string
+	Attempts  int       // attempts within the window
+	Remaining int       // attempts left before being rejected
+	ResetAt   time.Time // when the oldest attempt leaves the window and frees an attempt
+}
+
 // RateLimiter handles rate limiting for different endpoints
 type RateLimiter struct {
-	limits   map[string]*EndpointLimit // endpoint -> limit config
-	attempts map[string][]time.Time    // IP -> attempt timestamps
-	mutex    sync.RWMutex
-	stopChan chan struct{}
+	limits     map[string]*EndpointLimit // endpoint -> limit config
+	attempts   map[string][]time.Time    // endpoint:key -> attempt timestamps
+	rejections map[string]int64          // endpoint -> rejected requests
+	mutex      sync.RWMutex
+	stopChan   chan struct{}
 }
 
 // NewRateLimiter creates a new rate limiter with default configurations
@@ -37,8 +57,9 @@ func NewRateLimiter() *RateLimiter {
 				Window:      1 * time.Hour,
 			},
 		},
-		attempts: make(map[string][]time.Time),
-		stopChan: make(chan struct{}),
+		attempts:   make(map[string][]time.Time),
+		rejections: make(map[string]int64),
+		stopChan:   make(chan struct{}),
 	}
 
 	// Start cleanup goroutine
@@ -75,15 +96,17 @@ func (rl *RateLimiter) getClientIP(c *gin.Context) string {
 	return clientIP
 }
 
-// isAllowed checks if a request from the given IP is allowed for the endpoint
-func (rl *RateLimiter) isAllowed(endpoint, clientIP string) bool {
+// isAllowed checks if a request from the given IP is allowed for the endpoint.
+// A rejected request is counted, and the limit and the seconds before the client can retry are
+// returned with the decision so that they are read under the same lock.
+func (rl *RateLimiter) isAllowed(endpoint, clientIP string) (bool, int, int) {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
 	limit, exists := rl.limits[endpoint]
 	if !exists {
 		// No limit configured for this endpoint, allow by default
-		return true
+		return true, 0, 0
 	}
 
 	now := time.Now()
@@ -107,38 +130,24 @@ func (rl *RateLimiter) isAllowed(endpoint, clientIP string) bool {
 	if len(validAttempts) >= limit.MaxAttempts {
 		// Update the attempts list (without adding new attempt)
 		rl.attempts[key] = validAttempts
-		return false
+		rl.rejections[endpoint]++
+		return false, limit.MaxAttempts, getRetryAfter(validAttempts, limit.Window, now)
 	}
 
 	// Add current attempt and allow
 	validAttempts = append(validAttempts, now)
 	rl.attempts[key] = validAttempts
 
-	return true
+	return true, limit.MaxAttempts, 0
 }
 
 // getRetryAfter calculates when the client can retry (in seconds)
-func (rl *RateLimiter) getRetryAfter(endpoint, clientIP string) int {
-	rl.mutex.RLock()
-	defer rl.mutex.RUnlock()
-
-	limit, exists := rl.limits[endpoint]
-	if !exists {
-		return 0
-	}
-
-	key := endpoint + ":" + clientIP
-	attempts, exists := rl.attempts[key]
-	if !exists || len(attempts) == 0 {
-		return 0
-	}
-
+func getRetryAfter(attempts []time.Time, window time.Duration, now time.Time) int {
 	// Find the oldest attempt within the window
-	now := time.Now()
 	var oldestAttempt time.Time
 
 	for _, attempt := range attempts {
-		if now.Sub(attempt) < limit.Window {
+		if now.Sub(attempt) < window {
 			if oldestAttempt.IsZero() || attempt.Before(oldestAttempt) {
 				oldestAttempt = attempt
 			}
@@ -150,7 +159,7 @@ func (rl *RateLimiter) getRetryAfter(endpoint, clientIP string) int {
 	}
 
 	// Calculate when the oldest attempt will expire
-	expiry := oldestAttempt.Add(limit.Window)
+	expiry := oldestAttempt.Add(window)
 	retryAfter := int(expiry.Sub(now).Seconds())
 
 	if retryAfter < 0 {
@@ -163,26 +172,62 @@ func (rl *RateLimiter) getRetryAfter(endpoint, clientIP string) int {
 // Limit returns a middleware function that enforces rate limiting for the specified endpoint
 func (rl *RateLimiter) Limit(endpoint string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		clientIP := rl.getClientIP(c)
+		rl.enforce(c, endpoint, rl.getClientIP(c))
+	}
+}
 
-		if !rl.isAllowed(endpoint, clientIP) {
-			retryAfter := rl.getRetryAfter(endpoint, clientIP)
+// LimitByUser returns a middleware function that enforces rate limiting per authenticated user,
+// so referees sharing the venue network do not consume each other's quota.
+// It must be registered after the Authentication middleware.
+func (rl *RateLimiter) LimitByUser(endpoint string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rl.enforce(c, endpoint, rl.GetUserKey(c))
+	}
+}
 
-			c.Header("X-RateLimit-Limit", "5")
-			c.Header("X-RateLimit-Remaining", "0")
-			c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+// enforce aborts the request with a 429 response when the key exceeded the limit of the endpoint
+func (rl *RateLimiter) enforce(c *gin.Context, endpoint, key string) {
+	if allowed, maxAttempts, retryAfter := rl.isAllowed(endpoint, key); !allowed {
+		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxAttempts))
+		c.Header("X-RateLimit-Remaining", "0")
+		c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":               "Too many requests",
+			"message":             "Rate limit exceeded. Please try again later.",
+			"retry_after_seconds": retryAfter,
+		})
+		c.Abort()
+		return
+	}
 
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":               "Too many requests",
-				"message":             "Rate limit exceeded. Please try again later.",
-				"retry_after_seconds": retryAfter,
-			})
-			c.Abort()
-			return
-		}
+	c.Next()
+}
 
-		c.Next()
+// Record adds an attempt for the key without enforcing any limit and returns the number of
+// attempts within the window of the endpoint, it is used to detect abnormal activity
+func (rl *RateLimiter) Record(endpoint, key string) int {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	limit, exists := rl.limits[endpoint]
+	if !exists {
+		return 0
 	}
+
+	now := time.Now()
+	key = endpoint + ":" + key
+
+	var validAttempts []time.Time
+	for _, attempt := range rl.attempts[key] {
+		if now.Sub(attempt) < limit.Window {
+			validAttempts = append(validAttempts, attempt)
+		}
+	}
+	validAttempts = append(validAttempts, now)
+	rl.attempts[key] = validAttempts
+
+	return len(validAttempts)
 }
 
 // cleanup periodically removes old attempts to prevent memory leaks
@@ -197,13 +242,10 @@ func (rl *RateLimiter) cleanup() {
 
 			now := time.Now()
 			for key, attempts := range rl.attempts {
-				// Extract endpoint from key (format: "endpoint:ip")
+				// Extract endpoint from key (format: "endpoint:ip"), IPv6 addresses contain colons too
 				var maxWindow time.Duration
-				for endpoint, limit := range rl.limits {
-					if len(key) > len(endpoint) && key[:len(endpoint)] == endpoint {
-						maxWindow = limit.Window
-						break
-					}
+				if limit, exists := rl.limits[strings.SplitN(key, ":", 2)[0]]; exists {
+					maxWindow = limit.Window
 				}
 
 				// Remove attempts older than the maximum window + buffer
@@ -250,3 +292,87 @@ func (rl *RateLimiter) ResetAttempts(endpoint, clientIP string) {
 func (rl *RateLimiter) GetClientIP(c *gin.Context) string {
 	return rl.getClientIP(c)
 }
+
+// GetUserKey returns the rate limiting key of the authenticated user, requests without user
+// (e.g. authenticated with an API key) are identified by their IP address
+func (rl *RateLimiter) GetUserKey(c *gin.Context) string {
+	if user, err := GetUser(c); err == nil && user.Id != 0 {
+		return fmt.Sprintf("user-%d", user.Id)
+	}
+	return rl.getClientIP(c)
+}
+
+// Endpoints returns the limit of every rate limited endpoint and the requests it rejected, sorted by endpoint
+func (rl *RateLimiter) Endpoints() []EndpointState {
+	rl.mutex.RLock()
+	defer rl.mutex.RUnlock()
+
+	endpoints := make([]EndpointState, 0, len(rl.limits))
+	for endpoint, limit := range rl.limits {
+		endpoints = append(endpoints, EndpointState{
+			Endpoint:    endpoint,
+			MaxAttempts: limit.MaxAttempts,
+			Window:      limit.Window,
+			Rejections:  rl.rejections[endpoint],
+		})
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Endpoint < endpoints[j].Endpoint
+	})
+
+	return endpoints
+}
+
+// Keys returns the keys with attempts within the window of an endpoint, sorted by endpoint then key.
+// Keys whose attempts all expired are left out even before the cleanup removes them.
+func (rl *RateLimiter) Keys() []KeyState {
+	rl.mutex.RLock()
+	defer rl.mutex.RUnlock()
+
+	now := time.Now()
+	keys := []KeyState{}
+	for key, attempts := range rl.attempts {
+		parts := strings.SplitN(key, ":", 2)
+		limit, exists := rl.limits[parts[0]]
+		if !exists || len(parts) != 2 {
+			continue
+		}
+
+		var oldestAttempt time.Time
+		validAttempts := 0
+		for _, attempt := range attempts {
+			if now.Sub(attempt) < limit.Window {
+				validAttempts++
+				if oldestAttempt.IsZero() || attempt.Before(oldestAttempt) {
+					oldestAttempt = attempt
+				}
+			}
+		}
+		if validAttempts == 0 {
+			continue
+		}
+
+		remaining := limit.MaxAttempts - validAttempts
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		keys = append(keys, KeyState{
+			Endpoint:  parts[0],
+			Key:       parts[1],
+			Attempts:  validAttempts,
+			Remaining: remaining,
+			ResetAt:   oldestAttempt.Add(limit.Window),
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Endpoint != keys[j].Endpoint {
+			return keys[i].Endpoint < keys[j].Endpoint
+		}
+		return keys[i].Key < keys[j].Key
+	})
+
+	return keys
+}
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadOnlySwitch tells whether the instance must refuse the requests modifying data
+type ReadOnlySwitch interface {
+	IsReadOnly(ctx context.Context) bool
+}
+
+// ReadOnly aborts the requests modifying data while the switch is on, the requests reading data are served
+func ReadOnly(readOnly ReadOnlySwitch) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		if readOnly.IsReadOnly(c) {
+			c.Header("Retry-After", "30")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "the database was migrated by a newer version of the API, this instance is read-only"})
+			return
+		}
+		c.Next()
+	}
+}
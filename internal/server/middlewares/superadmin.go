@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// SuperAdminRole gives access to every competition and to the administration endpoints
+	SuperAdminRole = "superadmin"
+	// legacySuperAdminRole was given to super admins before SuperAdminRole existed, it is still honoured
+	legacySuperAdminRole = "admin:*"
+)
+
+// IsSuperAdmin returns whether the authenticated user is a super admin
+func IsSuperAdmin(c *gin.Context) bool {
+	return HasRole(c, SuperAdminRole) || HasRole(c, legacySuperAdminRole)
+}
+
+// RequireSuperAdmin aborts the requests of users who are not super admins
+func RequireSuperAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsSuperAdmin(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "super admin access required"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// grantObserver godoc
+// @Summary      Grant the observer role
+// @Description  Gives an existing user read access to the rankings, runs and participants of the competition without any write access, for coaches and federation delegates.
+// @Description  The role is added to the tokens of the user the next time they are refreshed.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                true  "Authentication cookie"
+// @Param        competitionID  path      int                   true  "Competition ID"
+// @Param        observer       body      models.ObserverInput  true  "Email of the user"
+// @Success      201            {object}  models.ObserverResponse  "Observer role granted"
+// @Failure      400            {object}  models.ErrorResponse     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse     "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse     "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse     "User not found"
+// @Failure      500            {object}  models.ErrorResponse     "Internal Server Error"
+// @Router       /competition/{competitionID}/observers [post]
+func (s *Server) grantObserver(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.ObserverInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := s.userService.GrantObserverRole(c, input.Email, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.ObserverResponse{
+		CompetitionID: int32(competitionID),
+		UserID:        user.GetID(),
+		Email:         user.GetEmail(),
+		Role:          aggregate.ObserverRole(int32(competitionID)),
+	})
+}
+
+// revokeObserver godoc
+// @Summary      Revoke the observer role
+// @Description  Removes the observer role of the competition from a user, the tokens already issued keep it until they are refreshed
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        userID         path      int     true  "User ID of the observer"
+// @Success      204            "Observer role revoked"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "The user is not an observer of the competition"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/observers/{userID} [delete]
+func (s *Server) revokeObserver(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid user ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.userService.RevokeObserverRole(c, int32(competitionID), int32(userID))
+	if err != nil {
+		if errors.Is(err, serviceImpl.ErrNotCompetitionObserver) || errors.Is(err, repository.ErrUserNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
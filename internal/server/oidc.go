@@ -0,0 +1,152 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// oidcStateCookie holds the state and nonce of the login in progress
+	oidcStateCookie = "oidc_state"
+	// oidcStateLifetime is the time in seconds the user has to log in with the provider
+	oidcStateLifetime = 10 * 60
+)
+
+var (
+	// ErrInvalidOIDCState is returned when the callback does not match the login started by the browser
+	ErrInvalidOIDCState = errors.New("invalid or expired OpenID Connect login state")
+)
+
+// listOIDCProviders godoc
+// @Summary      List the OpenID Connect providers
+// @Description  Lists the external identity providers users can log in with
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  models.OIDCProvidersResponse  "Returns the provider names"
+// @Router       /auth/oidc/providers [get]
+func (s *Server) listOIDCProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, models.OIDCProvidersResponse{
+		Providers: s.userService.OIDCProviders(),
+	})
+}
+
+// oidcLogin godoc
+// @Summary      Log in with an OpenID Connect provider
+// @Description  Redirects the browser to the login page of the provider, which redirects back to the callback endpoint
+// @Tags         auth
+// @Param        provider  path      string  true  "Provider name"
+// @Success      302       {string}  string  "Redirect to the provider"
+// @Failure      404       {object}  models.ErrorResponse  "Unknown provider"
+// @Failure      500       {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /auth/oidc/{provider}/login [get]
+func (s *Server) oidcLogin(c *gin.Context) {
+	provider := c.Param("provider")
+	state := uuid.NewString()
+	nonce := uuid.NewString()
+
+	authorizationURL, err := s.userService.OIDCAuthorizationURL(c, provider, state, nonce)
+	if err != nil {
+		if errors.Is(err, serviceImpl.ErrUnknownOIDCProvider) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	setOIDCStateCookie(c, state+":"+nonce, oidcStateLifetime)
+	c.Redirect(http.StatusFound, authorizationURL)
+}
+
+// setOIDCStateCookie sets the state cookie of a login, it is always lax because the provider
+// redirects back with a cross-site navigation for which a strict cookie would not be sent
+func setOIDCStateCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, value, maxAge, "/auth/oidc", middlewares.CookieDomain, middlewares.SecureMode, true)
+}
+
+// oidcCallback godoc
+// @Summary      Complete an OpenID Connect login
+// @Description  Exchanges the authorization code of the provider and sets the authentication cookies.
+// @Description  Users logging in for the first time get an account without any role.
+// @Description  Redirects to the client when CLIENT_URI is configured, otherwise returns the roles.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Param        code      query     string  true  "Authorization code"
+// @Param        state     query     string  true  "State of the login"
+// @Success      200       {object}  models.RoleResponse   "Returns the roles and tokens in cookies"
+// @Success      302       {string}  string                "Redirect to the client"
+// @Failure      400       {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401       {object}  models.ErrorResponse  "Unauthorized (login refused)"
+// @Failure      404       {object}  models.ErrorResponse  "Unknown provider"
+// @Failure      500       {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /auth/oidc/{provider}/callback [get]
+func (s *Server) oidcCallback(c *gin.Context) {
+	if providerError := c.Query("error"); providerError != "" {
+		RespondError(c, http.StatusUnauthorized, fmt.Errorf("login refused by the provider: %s", providerError))
+		return
+	}
+
+	stateCookie, err := c.Cookie(oidcStateCookie)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrInvalidOIDCState)
+		return
+	}
+	setOIDCStateCookie(c, "", -1)
+
+	state, nonce, found := strings.Cut(stateCookie, ":")
+	if !found || state == "" || c.Query("state") != state {
+		RespondError(c, http.StatusBadRequest, ErrInvalidOIDCState)
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		RespondError(c, http.StatusBadRequest, errors.New("missing authorization code"))
+		return
+	}
+
+	user, err := s.userService.LoginWithOIDC(c, c.Param("provider"), code, nonce)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceImpl.ErrUnknownOIDCProvider):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, serviceImpl.ErrInvalidOIDCToken), errors.Is(err, serviceImpl.ErrOIDCEmailNotVerified):
+			RespondError(c, http.StatusUnauthorized, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	middlewares.SetTokenCookies(c, user)
+
+	if s.conf.ClientURI != "" {
+		c.Redirect(http.StatusFound, s.conf.ClientURI)
+		return
+	}
+
+	c.Header("x-token-refreshed", "true")
+	if roles := user.GetRoles(); len(roles) > 0 {
+		rolesJSON, err := json.Marshal(roles)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("x-user-roles", string(rolesJSON))
+	}
+
+	c.JSON(http.StatusOK, models.RoleResponse{
+		Roles: user.GetRoles(),
+	})
+}
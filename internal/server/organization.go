@@ -0,0 +1,340 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// createOrganization godoc
+// @Summary      Create an organization
+// @Description  Creates an organization whose admins administrate all its competitions
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        Cookie        header    string                    true  "Authentication cookie"
+// @Param        organization  body      models.OrganizationInput  true  "Organization name"
+// @Success      201           {object}  models.OrganizationResponse  "Returns the organization"
+// @Failure      400           {object}  models.ErrorResponse         "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse         "Unauthorized"
+// @Failure      403           {object}  models.ErrorResponse         "Forbidden (super admin only)"
+// @Failure      409           {object}  models.ErrorResponse         "An organization already has this name"
+// @Failure      500           {object}  models.ErrorResponse         "Internal Server Error"
+// @Router       /admin/organizations [post]
+func (s *Server) createOrganization(c *gin.Context) {
+	var input models.OrganizationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	organization := aggregate.NewOrganization()
+	organization.SetName(input.Name)
+
+	_, err := s.competitionService.CreateOrganization(c, organization)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toOrganizationResponse(organization))
+}
+
+// deleteOrganization godoc
+// @Summary      Delete an organization
+// @Description  Deletes an organization and the roles of its admins, its competitions are kept without organization
+// @Tags         organization
+// @Param        Cookie          header  string  true  "Authentication cookie"
+// @Param        organizationID  path    int     true  "Organization ID"
+// @Success      204  "Organization deleted"
+// @Failure      400  {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401  {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403  {object}  models.ErrorResponse  "Forbidden (super admin only)"
+// @Failure      404  {object}  models.ErrorResponse  "Organization not found"
+// @Failure      500  {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /admin/organizations/{organizationID} [delete]
+func (s *Server) deleteOrganization(c *gin.Context) {
+	organizationID, err := strconv.ParseInt(c.Param("organizationID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid organization ID"))
+		return
+	}
+
+	if err := s.competitionService.DeleteOrganization(c, int32(organizationID)); err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// listOrganizations godoc
+// @Summary      List the organizations
+// @Description  Lists the organizations the user administrates, super admins list every organization
+// @Tags         organization
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.OrganizationListResponse  "Returns the organizations"
+// @Failure      401     {object}  models.ErrorResponse             "Unauthorized"
+// @Failure      500     {object}  models.ErrorResponse             "Internal Server Error"
+// @Router       /organizations [get]
+func (s *Server) listOrganizations(c *gin.Context) {
+	organizations, err := s.competitionService.ListOrganizations(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.OrganizationListResponse{
+		Organizations: make([]models.OrganizationResponse, 0, len(organizations)),
+	}
+	for _, organization := range organizations {
+		if checkIsOrganizationAdmin(c, organization.GetID()) != nil {
+			continue
+		}
+		response.Organizations = append(response.Organizations, toOrganizationResponse(organization))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getOrganization godoc
+// @Summary      Get an organization
+// @Description  Returns an organization with its competitions, the latest first
+// @Tags         organization
+// @Produce      json
+// @Param        Cookie          header    string  true  "Authentication cookie"
+// @Param        organizationID  path      int     true  "Organization ID"
+// @Success      200             {object}  models.OrganizationResponse  "Returns the organization"
+// @Failure      400             {object}  models.ErrorResponse         "Bad Request"
+// @Failure      401             {object}  models.ErrorResponse         "Unauthorized"
+// @Failure      403             {object}  models.ErrorResponse         "Forbidden (admin access to the organization required)"
+// @Failure      404             {object}  models.ErrorResponse         "Organization not found"
+// @Failure      500             {object}  models.ErrorResponse         "Internal Server Error"
+// @Router       /organizations/{organizationID} [get]
+func (s *Server) getOrganization(c *gin.Context) {
+	organizationID, ok := parseAdministratedOrganization(c)
+	if !ok {
+		return
+	}
+
+	organization, err := s.competitionService.GetOrganization(c, organizationID)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOrganizationResponse(organization))
+}
+
+// addOrganizationAdmin godoc
+// @Summary      Add an organization admin
+// @Description  Grants an existing user admin access to every competition of the organization, including the ones added later
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        Cookie          header    string                         true  "Authentication cookie"
+// @Param        organizationID  path      int                            true  "Organization ID"
+// @Param        admin           body      models.OrganizationAdminInput  true  "Email of the user"
+// @Success      201             {object}  models.OrganizationAdminResponse  "Returns the granted role"
+// @Failure      400             {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401             {object}  models.ErrorResponse              "Unauthorized"
+// @Failure      403             {object}  models.ErrorResponse              "Forbidden (admin access to the organization required)"
+// @Failure      404             {object}  models.ErrorResponse              "Organization or user not found"
+// @Failure      500             {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /organizations/{organizationID}/admins [post]
+func (s *Server) addOrganizationAdmin(c *gin.Context) {
+	organizationID, ok := parseAdministratedOrganization(c)
+	if !ok {
+		return
+	}
+
+	var input models.OrganizationAdminInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := s.competitionService.GetOrganization(c, organizationID); err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	user, err := s.userService.GrantOrganizationAdminRole(c, input.Email, organizationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.OrganizationAdminResponse{
+		OrganizationID: organizationID,
+		UserID:         user.GetID(),
+		Email:          user.GetEmail(),
+		Role:           aggregate.OrganizationAdminRole(organizationID),
+	})
+}
+
+// removeOrganizationAdmin godoc
+// @Summary      Remove an organization admin
+// @Description  Revokes the admin role of a user on the organization, tokens already issued keep it until they are refreshed
+// @Tags         organization
+// @Param        Cookie          header  string  true  "Authentication cookie"
+// @Param        organizationID  path    int     true  "Organization ID"
+// @Param        userID          path    int     true  "User ID"
+// @Success      204  "Role revoked"
+// @Failure      400  {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401  {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403  {object}  models.ErrorResponse  "Forbidden (admin access to the organization required)"
+// @Failure      404  {object}  models.ErrorResponse  "User not found or not an admin of the organization"
+// @Failure      500  {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /organizations/{organizationID}/admins/{userID} [delete]
+func (s *Server) removeOrganizationAdmin(c *gin.Context) {
+	organizationID, ok := parseAdministratedOrganization(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid user ID"))
+		return
+	}
+
+	err = s.userService.RevokeOrganizationAdminRole(c, organizationID, int32(userID))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) || errors.Is(err, service.ErrNotOrganizationAdmin) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// setCompetitionOrganization godoc
+// @Summary      Move a competition to an organization
+// @Description  Moves a competition to an organization, or detaches it with organization_id 0.
+// @Description  The user must administrate the competition and both the organization it leaves and the one it joins.
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                               true  "Authentication cookie"
+// @Param        competitionID  path      int                                  true  "Competition ID"
+// @Param        organization   body      models.CompetitionOrganizationInput  true  "Organization of the competition"
+// @Success      200            {object}  models.CompetitionResponse  "Returns the competition"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden (admin access to the competition and the organizations required)"
+// @Failure      404            {object}  models.ErrorResponse        "Competition or organization not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/organization [put]
+func (s *Server) setCompetitionOrganization(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.CompetitionOrganizationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	for _, organizationID := range []int32{competition.GetOrganizationID(), input.OrganizationID} {
+		if organizationID == 0 {
+			continue
+		}
+		if err := checkIsOrganizationAdmin(c, organizationID); err != nil {
+			RespondError(c, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	competition, err = s.competitionService.SetCompetitionOrganization(c, int32(competitionID), input.OrganizationID)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CompetitionResponse{
+		ID:          competition.GetID(),
+		Name:        competition.GetName(),
+		Description: competition.GetDescription(),
+		Date:        competition.GetLocalDate().Format(time.RFC3339),
+		Timezone:    competition.GetTimezone(),
+		Location:    competition.GetLocation(),
+		Organizer:   competition.GetOrganizer(),
+		Contact:     competition.GetContact(),
+		Status:      competition.GetStatus(),
+
+		OrganizationID: competition.GetOrganizationID(),
+	})
+}
+
+// parseAdministratedOrganization parses the organization ID and checks the user administrates the organization,
+// the response is written when it fails
+func parseAdministratedOrganization(c *gin.Context) (int32, bool) {
+	organizationID, err := strconv.ParseInt(c.Param("organizationID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid organization ID"))
+		return 0, false
+	}
+
+	if err := checkIsOrganizationAdmin(c, int32(organizationID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return 0, false
+	}
+
+	return int32(organizationID), true
+}
+
+// respondOrganizationError maps the errors of the organizations to their status
+func respondOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptyOrganizationName):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrOrganizationNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrCompetitionNotFound):
+		RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+	case errors.Is(err, repository.ErrDuplicateOrganization):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// toOrganizationResponse converts an organization to its response
+func toOrganizationResponse(organization *aggregate.Organization) models.OrganizationResponse {
+	return models.OrganizationResponse{
+		ID:             organization.GetID(),
+		Name:           organization.GetName(),
+		CreatedAt:      organization.GetCreatedAt(),
+		CompetitionIDs: organization.GetCompetitionIDs(),
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// exportParticipants godoc
+// @Summary      Export the participants of a competition to CSV or Excel
+// @Description  Exports the start list ordered by dossard with the columns of the participants import: dossard, category, last name, first name, gender, club,
+// @Description  data processing and photo rights consents (oui/non), club email and licence number. The file can be edited and imported back.
+// @Description  The CSV file is comma separated, format=xlsx returns an Excel file instead.
+// @Tags         participant
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        format         query     string  false  "File format: csv or xlsx (default: csv)"
+// @Success      200            {file}    file    "CSV or Excel file with the participants"
+// @Failure      400            {object}  models.ErrorResponse "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse "Internal Server Error"
+// @Router       /competition/{competitionID}/participants/export [get]
+func (s *Server) exportParticipants(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	format := c.DefaultQuery("format", service.ParticipantExportCSV)
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	data, filename, err := s.competitionService.ExportParticipants(c, int32(competitionID), format)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidParticipantExportFormat):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == service.ParticipantExportExcel {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Header("Content-Length", fmt.Sprintf("%d", len(data)))
+	c.Data(http.StatusOK, contentType, data)
+}
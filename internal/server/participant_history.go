@@ -0,0 +1,75 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getParticipantHistory godoc
+// @Summary      Results of a person across the competitions
+// @Description  Lists the results of a person in every competition, the latest first, with their rank, points and category, for the organizers
+// @Description  to seed their start orders on past performances. The person is recognised by their licence number or their full name in either order.
+// @Description  Only the competitions where they agreed to the processing of their data are listed, draft competitions are left out.
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie   header    string  true   "Authentication cookie"
+// @Param        licence  query     string  false  "Licence number"
+// @Param        name     query     string  false  "First and last name"
+// @Success      200      {object}  models.ParticipantHistoryResponse  "Returns the results of the person"
+// @Failure      400      {object}  models.ErrorResponse               "Bad Request (neither licence nor name)"
+// @Failure      401      {object}  models.ErrorResponse               "Unauthorized (invalid credentials)"
+// @Failure      403      {object}  models.ErrorResponse               "Forbidden (competition admin access required)"
+// @Failure      500      {object}  models.ErrorResponse               "Internal Server Error"
+// @Router       /participants/history [get]
+func (s *Server) getParticipantHistory(c *gin.Context) {
+	if err := checkIsAnyCompetitionAdmin(c); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	licence := c.Query("licence")
+	name := c.Query("name")
+
+	history, err := s.competitionService.GetParticipantHistory(c, licence, name)
+	if err != nil {
+		if errors.Is(err, service.ErrEmptyParticipantHistoryQuery) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.ParticipantHistoryResponse{
+		Licence: licence,
+		Name:    name,
+		Results: make([]models.ParticipantHistoryEntryResponse, 0, len(history)),
+	}
+	for _, entry := range history {
+		response.Results = append(response.Results, models.ParticipantHistoryEntryResponse{
+			CompetitionID:   entry.CompetitionID,
+			CompetitionName: entry.CompetitionName,
+			CompetitionDate: entry.CompetitionDay,
+			DossardNumber:   entry.DossardNumber,
+			FirstName:       entry.FirstName,
+			LastName:        entry.LastName,
+			Club:            entry.Club,
+			Category:        entry.Category,
+			Gender:          entry.Gender,
+			Status:          entry.Status,
+			Rank:            entry.Rank,
+			Ranked:          entry.Ranked,
+			TotalPoints:     entry.TotalPoints,
+			TotalPenalty:    entry.TotalPenalty,
+			TotalTime:       entry.TotalTime,
+			PointsEarned:    entry.PointsEarned,
+			Complete:        entry.Complete,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// setParticipantNotes godoc
+// @Summary      Set the notes and flags of a participant
+// @Description  Replaces the free-text notes and the flags (medical, minor, special_start) of a participant, shown to the referees
+// @Description  when they fetch the participant before scoring a run
+// @Tags         participant
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                        true  "Authentication cookie"
+// @Param        competitionID  path      int                           true  "Competition ID"
+// @Param        dossard        path      int                           true  "Dossard Number"
+// @Param        notes          body      models.ParticipantNotesInput  true  "Notes and flags"
+// @Success      200            {object}  models.ParticipantResponse    "Returns the participant"
+// @Failure      400            {object}  models.ErrorResponse          "Bad Request (unknown flag)"
+// @Failure      401            {object}  models.ErrorResponse          "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse          "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse          "Participant not found"
+// @Failure      500            {object}  models.ErrorResponse          "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/notes [put]
+func (s *Server) setParticipantNotes(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	var input models.ParticipantNotesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	participant, err := s.competitionService.SetParticipantNotes(c, int32(competitionID), int32(dossard), input.Notes, input.Flags)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownParticipantFlag):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrParticipantNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ParticipantResponse{
+		CompetitionID: participant.GetCompetitionID(),
+		DossardNumber: participant.GetDossardNumber(),
+		FirstName:     participant.GetFirstName(),
+		LastName:      participant.GetLastName(),
+		Category:      participant.GetCategory(),
+		Gender:        participant.GetGender(),
+		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	})
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// uploadParticipantPhoto godoc
+// @Summary      Upload the photo of a participant
+// @Description  Stores a JPEG, PNG or WebP photo of at most 5 MB of a participant in the object storage, outside the published results, and returns
+// @Description  the participant with the URL of the API the photo is served from, for the referees to confirm the identity of the participant before
+// @Description  scoring a run. The participant must have consented to photo rights. A new photo replaces the previous one. Only the admins upload photos.
+// @Tags         participant
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        dossard        path      int     true  "Dossard Number"
+// @Param        file           formData  file    true  "Photo of the participant"
+// @Success      200            {object}  models.ParticipantResponse  "Returns the participant with its photo URL"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request (missing file or not an image)"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse        "Participant not found"
+// @Failure      409            {object}  models.ErrorResponse        "The participant did not consent to photo rights"
+// @Failure      413            {object}  models.ErrorResponse        "Photo too large"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Failure      503            {object}  models.ErrorResponse        "No object storage configured"
+// @Router       /competition/{competitionID}/participant/{dossard}/photo [post]
+func (s *Server) uploadParticipantPhoto(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	// The photos of the participants, often minors, are only uploaded by the organizers who collected the consents
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("file is required"))
+		return
+	}
+	defer file.Close()
+
+	participant, err := s.competitionService.SetParticipantPhoto(c, int32(competitionID), int32(dossard), file)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidParticipantPhoto):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, service.ErrParticipantPhotoTooLarge):
+			RespondError(c, http.StatusRequestEntityTooLarge, err)
+		case errors.Is(err, service.ErrPhotoRightsNotGiven):
+			RespondError(c, http.StatusConflict, err)
+		case errors.Is(err, service.ErrParticipantPhotosDisabled):
+			RespondError(c, http.StatusServiceUnavailable, err)
+		case errors.Is(err, repository.ErrParticipantNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ParticipantResponse{
+		CompetitionID: participant.GetCompetitionID(),
+		DossardNumber: participant.GetDossardNumber(),
+		FirstName:     participant.GetFirstName(),
+		LastName:      participant.GetLastName(),
+		Category:      participant.GetCategory(),
+		Gender:        participant.GetGender(),
+		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	})
+}
+
+// getParticipantPhoto godoc
+// @Summary      Get the photo of a participant
+// @Description  Serves the photo of a participant from the object storage, for the referees to confirm the identity of the participant before scoring a run.
+// @Description  Referees logged in with a PIN can fetch it. The photo of a participant who withdrew their consent to photo rights is no longer served.
+// @Tags         participant
+// @Produce      image/jpeg,image/png,image/webp
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        dossard        path      int     true  "Dossard Number"
+// @Success      200            {file}    file                        "The photo of the participant"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse        "Participant or photo not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Failure      503            {object}  models.ErrorResponse        "No object storage configured"
+// @Router       /competition/{competitionID}/participant/{dossard}/photo [get]
+func (s *Server) getParticipantPhoto(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	// Same access as the participant, referees logged in with a PIN included
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasRefereePinAccess(c, int32(competitionID))
+	}
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	content, contentType, err := s.competitionService.GetParticipantPhoto(c, int32(competitionID), int32(dossard))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrParticipantPhotoNotFound), errors.Is(err, repository.ErrParticipantNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, service.ErrParticipantPhotosDisabled):
+			RespondError(c, http.StatusServiceUnavailable, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// participantPhotoURL returns the route of the API the photo of the participant is served from, empty without a photo
+// or once the participant withdrew their consent to photo rights
+func participantPhotoURL(participant *aggregate.Participant) string {
+	if participant.GetPhotoKey() == "" || !participant.HasConsent(aggregate.ConsentPhotoRights) {
+		return ""
+	}
+	return fmt.Sprintf("/competition/%d/participant/%d/photo", participant.GetCompetitionID(), participant.GetDossardNumber())
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// setParticipantStatus godoc
+// @Summary      Set the status of a participant
+// @Description  Sets the status of a participant among registered, dns (did not start), dnf (did not finish) and dsq (disqualified).
+// @Description  The dns participants are left out of the liveranking and the exports, the dnf then the dsq ones are ranked last with the reason.
+// @Description  A disqualification requires a reason, going back to registered clears it.
+// @Tags         participant
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                         true  "Authentication cookie"
+// @Param        competitionID  path      int                            true  "Competition ID"
+// @Param        dossard        path      int                            true  "Dossard Number"
+// @Param        status         body      models.ParticipantStatusInput  true  "Status and reason"
+// @Success      200            {object}  models.ParticipantResponse     "Returns the participant"
+// @Failure      400            {object}  models.ErrorResponse           "Bad Request (unknown status or disqualification without reason)"
+// @Failure      401            {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse           "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse           "Participant not found"
+// @Failure      500            {object}  models.ErrorResponse           "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/status [patch]
+func (s *Server) setParticipantStatus(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	var input models.ParticipantStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := checkHasAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	participant, err := s.competitionService.SetParticipantStatus(c, int32(competitionID), int32(dossard), input.Status, input.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownParticipantStatus), errors.Is(err, service.ErrMissingDisqualificationReason):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrParticipantNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ParticipantResponse{
+		CompetitionID: participant.GetCompetitionID(),
+		DossardNumber: participant.GetDossardNumber(),
+		FirstName:     participant.GetFirstName(),
+		LastName:      participant.GetLastName(),
+		Category:      participant.GetCategory(),
+		Gender:        participant.GetGender(),
+		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	})
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getPublicCompetition godoc
+// @Summary      Public information of a competition
+// @Description  Returns the name, date, location, categories and zones of a competition for spectators, without authentication.
+// @Description  The information is cached by the server for PUBLIC_COMPETITION_CACHE_TTL, draft competitions are not published.
+// @Tags         public
+// @Produce      json
+// @Param        competitionID  path      int  true  "Competition ID"
+// @Success      200            {object}  models.PublicCompetitionResponse  "Returns the competition information"
+// @Failure      400            {object}  models.ErrorResponse  "Invalid competition ID"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal server error"
+// @Router       /public/competition/{competitionID} [get]
+func (s *Server) getPublicCompetition(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	competition, zones, readAt, err := s.competitionService.GetPublicCompetition(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) || errors.Is(err, serviceImpl.ErrCompetitionNotPublished) {
+			RespondError(c, http.StatusNotFound, repository.ErrCompetitionNotFound)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response := models.PublicCompetitionResponse{
+		ID:         competition.GetID(),
+		Name:       competition.GetName(),
+		Date:       competition.GetLocalDate().Format(time.RFC3339),
+		Timezone:   competition.GetTimezone(),
+		Location:   competition.GetLocation(),
+		Status:     competition.GetStatus(),
+		Categories: make([]string, 0),
+		Zones:      make([]models.PublicZoneResponse, 0, len(zones)),
+		ReadAt:     readAt,
+	}
+	seenCategories := make(map[string]bool)
+	for _, zone := range zones {
+		if !seenCategories[zone.GetCategory()] {
+			seenCategories[zone.GetCategory()] = true
+			response.Categories = append(response.Categories, zone.GetCategory())
+		}
+		response.Zones = append(response.Zones, models.PublicZoneResponse{
+			Category: zone.GetCategory(),
+			Zone:     zone.GetZone(),
+		})
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.conf.Public.CompetitionCacheTTL.Seconds())))
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// assignRefereeToZone godoc
+// @Summary      Assign a referee to a zone
+// @Description  Assigns a referee of the competition to one of its zones, a referee can be assigned to several zones.
+// @Description  When the settings of the competition restrict the referees to their zones, they can only record runs in the zones they are assigned to.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                         true  "Authentication cookie"
+// @Param        competitionID  path      int                            true  "Competition ID"
+// @Param        assignment     body      models.RefereeAssignmentInput  true  "Referee and zone"
+// @Success      201            {object}  models.RefereeAssignmentResponse  "Returns the assignment"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request (the user is not a referee of the competition or the zone is unknown)"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse              "User not found"
+// @Failure      409            {object}  models.ErrorResponse              "The referee is already assigned to the zone"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/assignments [post]
+func (s *Server) assignRefereeToZone(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.RefereeAssignmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	admin, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	assignment, err := s.userService.AssignRefereeToZone(c.Request.Context(), int32(competitionID), input.UserID, input.Zone, admin.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotCompetitionReferee),
+			errors.Is(err, service.ErrUnknownZone):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrUserNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, repository.ErrDuplicateRefereeAssignment):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRefereeAssignmentResponse(assignment))
+}
+
+// listRefereeAssignments godoc
+// @Summary      List the referee assignments
+// @Description  Lists the zones the referees of the competition are assigned to, by zone, and whether the referees are restricted to their zones
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.RefereeAssignmentListResponse  "Returns the assignments"
+// @Failure      400            {object}  models.ErrorResponse                  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse                  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse                  "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                  "Internal Server Error"
+// @Router       /competition/{competitionID}/assignments [get]
+func (s *Server) listRefereeAssignments(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	assignments, err := s.userService.ListRefereeAssignments(c.Request.Context(), int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	settings, err := s.competitionService.GetCompetitionSettings(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.RefereeAssignmentListResponse{
+		CompetitionID:        int32(competitionID),
+		RestrictRefereeZones: settings.RestrictsRefereeZones(),
+		Assignments:          make([]models.RefereeAssignmentResponse, 0, len(assignments)),
+	}
+	for _, assignment := range assignments {
+		response.Assignments = append(response.Assignments, toRefereeAssignmentResponse(assignment))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// unassignRefereeFromZone godoc
+// @Summary      Unassign a referee from a zone
+// @Description  Removes the assignment of a referee to a zone of the competition
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        userID         path      int     true  "User ID of the referee"
+// @Param        zone           path      string  true  "Zone name"
+// @Success      204            "Assignment removed"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "The referee is not assigned to the zone"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/assignments/{userID}/{zone} [delete]
+func (s *Server) unassignRefereeFromZone(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid user ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.userService.UnassignRefereeFromZone(c.Request.Context(), int32(competitionID), int32(userID), c.Param("zone"))
+	if err != nil {
+		if errors.Is(err, repository.ErrRefereeAssignmentNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// toRefereeAssignmentResponse maps the assignment of a referee to its response
+func toRefereeAssignmentResponse(assignment *aggregate.RefereeAssignment) models.RefereeAssignmentResponse {
+	response := models.RefereeAssignmentResponse{
+		CompetitionID: assignment.GetCompetitionID(),
+		UserID:        assignment.GetUserID(),
+		Zone:          assignment.GetZone(),
+		AssignedBy:    assignment.GetAssignedBy(),
+		AssignedAt:    assignment.GetAssignedAt(),
+	}
+	if user := assignment.GetUser(); user != nil {
+		response.FirstName = user.GetFirstName()
+		response.LastName = user.GetLastName()
+		response.Email = user.GetEmail()
+	}
+	return response
+}
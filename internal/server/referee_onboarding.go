@@ -0,0 +1,243 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getRefereeOnboarding godoc
+// @Summary      Get my referee onboarding checklist
+// @Description  Returns the onboarding checklist of the logged in referee: accepted invitation, read rules, confirmed zone and submitted test run.
+// @Description  Opening the checklist completes the invitation step.
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.RefereeOnboardingResponse  "Returns the onboarding checklist"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (the user is not a referee of the competition)"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/referee/onboarding [get]
+func (s *Server) getRefereeOnboarding(c *gin.Context) {
+	competitionID, userID, ok := parseRefereeOnboardingParams(c)
+	if !ok {
+		return
+	}
+
+	onboarding, err := s.userService.GetRefereeOnboarding(c.Request.Context(), competitionID, userID)
+	if err != nil {
+		respondRefereeOnboardingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRefereeOnboardingResponse(onboarding))
+}
+
+// updateRefereeOnboarding godoc
+// @Summary      Update my referee onboarding checklist
+// @Description  Records whether the logged in referee read the rules and the zone they confirm to referee, an empty zone clearing the confirmation
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                         true  "Authentication cookie"
+// @Param        competitionID  path      int                            true  "Competition ID"
+// @Param        onboarding     body      models.RefereeOnboardingInput  true  "Onboarding steps"
+// @Success      200            {object}  models.RefereeOnboardingResponse  "Returns the onboarding checklist"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request (unknown zone)"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (the user is not a referee of the competition)"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/referee/onboarding [put]
+func (s *Server) updateRefereeOnboarding(c *gin.Context) {
+	competitionID, userID, ok := parseRefereeOnboardingParams(c)
+	if !ok {
+		return
+	}
+
+	var input models.RefereeOnboardingInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	onboarding, err := s.userService.UpdateRefereeOnboarding(c.Request.Context(), competitionID, userID, input.RulesRead, input.ConfirmedZone)
+	if err != nil {
+		respondRefereeOnboardingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRefereeOnboardingResponse(onboarding))
+}
+
+// submitRefereeTestRun godoc
+// @Summary      Submit a referee test run
+// @Description  Checks a run the logged in referee submits from the device they will score with and completes the test run step of their onboarding.
+// @Description  The run is not recorded and can be submitted before the competition starts.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                      true  "Authentication cookie"
+// @Param        competitionID  path      int                         true  "Competition ID"
+// @Param        run            body      models.RefereeTestRunInput  true  "Test run"
+// @Success      200            {object}  models.RefereeOnboardingResponse  "Returns the onboarding checklist"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request (invalid run data or unknown zone)"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (the user is not a referee of the competition)"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/referee/onboarding/test-run [post]
+func (s *Server) submitRefereeTestRun(c *gin.Context) {
+	competitionID, userID, ok := parseRefereeOnboardingParams(c)
+	if !ok {
+		return
+	}
+
+	var input models.RefereeTestRunInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	run := aggregate.NewRun()
+	run.SetCompetitionID(competitionID)
+	run.SetZone(input.Zone)
+	run.SetDoors(input.Doors)
+	run.SetPenality(input.Penality)
+	run.SetChronoSec(input.ChronoSec)
+	run.SetRefereeId(userID)
+
+	onboarding, err := s.userService.SubmitRefereeTestRun(c.Request.Context(), competitionID, userID, run)
+	if err != nil {
+		respondRefereeOnboardingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRefereeOnboardingResponse(onboarding))
+}
+
+// listRefereeOnboardings godoc
+// @Summary      Referee readiness overview
+// @Description  Lists the onboarding checklist of every referee of the competition by name, including the referees who did not open it yet,
+// @Description  with the number of referees ready and of referee invitation links still pending
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.RefereeOnboardingOverviewResponse  "Returns the readiness of the referees"
+// @Failure      400            {object}  models.ErrorResponse                      "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                      "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse                      "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse                      "Internal Server Error"
+// @Router       /competition/{competitionID}/referees/onboarding [get]
+func (s *Server) listRefereeOnboardings(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	onboardings, pendingInvitations, err := s.userService.ListRefereeOnboardings(c.Request.Context(), int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.RefereeOnboardingOverviewResponse{
+		CompetitionID:      int32(competitionID),
+		Total:              int32(len(onboardings)),
+		PendingInvitations: pendingInvitations,
+		Referees:           make([]models.RefereeOnboardingResponse, 0, len(onboardings)),
+	}
+	for _, onboarding := range onboardings {
+		if onboarding.IsReady() {
+			response.Ready++
+		}
+		response.Referees = append(response.Referees, toRefereeOnboardingResponse(onboarding))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseRefereeOnboardingParams parses the competition ID and returns it with the ID of the logged in user,
+// the response is written when it fails
+func parseRefereeOnboardingParams(c *gin.Context) (int32, int32, bool) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return 0, 0, false
+	}
+
+	err = checkHasAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return 0, 0, false
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return 0, 0, false
+	}
+
+	return int32(competitionID), user.Id, true
+}
+
+// respondRefereeOnboardingError maps the errors of the onboarding of a referee to their status
+func respondRefereeOnboardingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotCompetitionReferee):
+		RespondError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrUnknownZone),
+		errors.Is(err, service.ErrInvalidRunData):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrUserNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// toRefereeOnboardingResponse maps the onboarding of a referee to its response
+func toRefereeOnboardingResponse(onboarding *aggregate.RefereeOnboarding) models.RefereeOnboardingResponse {
+	response := models.RefereeOnboardingResponse{
+		CompetitionID: onboarding.GetCompetitionID(),
+		UserID:        onboarding.GetUserID(),
+		ConfirmedZone: onboarding.GetConfirmedZone(),
+		Ready:         onboarding.IsReady(),
+	}
+	if user := onboarding.GetUser(); user != nil {
+		response.FirstName = user.GetFirstName()
+		response.LastName = user.GetLastName()
+		response.Email = user.GetEmail()
+	}
+	if acceptedAt := onboarding.GetInvitationAcceptedAt(); !acceptedAt.IsZero() {
+		response.InvitationAccepted = true
+		response.InvitationAcceptedAt = &acceptedAt
+	}
+	if readAt := onboarding.GetRulesReadAt(); !readAt.IsZero() {
+		response.RulesRead = true
+		response.RulesReadAt = &readAt
+	}
+	if confirmedAt := onboarding.GetZoneConfirmedAt(); !confirmedAt.IsZero() {
+		response.ZoneConfirmedAt = &confirmedAt
+	}
+	if testRunAt := onboarding.GetTestRunAt(); !testRunAt.IsZero() {
+		response.TestRunSubmitted = true
+		response.TestRunAt = &testRunAt
+	}
+	return response
+}
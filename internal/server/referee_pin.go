@@ -0,0 +1,166 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// generateRefereePin godoc
+// @Summary      Generate a referee PIN
+// @Description  Generates the numeric PIN a referee of the competition logs in with on the shared tablets of the zones.
+// @Description  The PIN is only returned once and replaces the previous PIN of the referee.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie        header    string  true  "Authentication cookie"
+// @Param        competitionID path      int     true  "Competition ID"
+// @Param        userID        path      int     true  "User ID of the referee"
+// @Success      201           {object}  models.RefereePinResponse  "Returns the PIN"
+// @Failure      400           {object}  models.ErrorResponse       "Bad Request (the user is not a referee of the competition)"
+// @Failure      401           {object}  models.ErrorResponse       "Unauthorized"
+// @Failure      403           {object}  models.ErrorResponse       "Forbidden (admin access required)"
+// @Failure      404           {object}  models.ErrorResponse       "User not found"
+// @Failure      500           {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /competition/{competitionID}/referee/{userID}/pin [post]
+func (s *Server) generateRefereePin(c *gin.Context) {
+	competitionID, userID, ok := parseRefereePinParams(c)
+	if !ok {
+		return
+	}
+
+	err := checkHasAdminAccessToCompetition(c, competitionID)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	admin, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	pin, err := s.userService.GenerateRefereePin(c.Request.Context(), competitionID, userID, admin.Id)
+	if err != nil {
+		if errors.Is(err, serviceImpl.ErrNotCompetitionReferee) {
+			RespondError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repository.ErrUserNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.RefereePinResponse{
+		CompetitionID: competitionID,
+		UserID:        userID,
+		Pin:           pin,
+	})
+}
+
+// revokeRefereePin godoc
+// @Summary      Revoke a referee PIN
+// @Description  Removes the PIN of a referee, the tablets already logged in with it stay logged in until their token expires
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie        header    string  true  "Authentication cookie"
+// @Param        competitionID path      int     true  "Competition ID"
+// @Param        userID        path      int     true  "User ID of the referee"
+// @Success      204           "PIN revoked"
+// @Failure      400           {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403           {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404           {object}  models.ErrorResponse  "The referee has no PIN"
+// @Failure      500           {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/referee/{userID}/pin [delete]
+func (s *Server) revokeRefereePin(c *gin.Context) {
+	competitionID, userID, ok := parseRefereePinParams(c)
+	if !ok {
+		return
+	}
+
+	err := checkHasAdminAccessToCompetition(c, competitionID)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.userService.RevokeRefereePin(c.Request.Context(), competitionID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefereePinNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// loginWithRefereePin godoc
+// @Summary      Log in with a referee PIN
+// @Description  Exchanges a competition ID and a referee PIN for a token restricted to recording runs of the competition, for shared tablets at the zones.
+// @Description  The token cannot be refreshed, the PIN is entered again once it expires.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      models.RefereePinLoginInput  true  "Competition ID and PIN"
+// @Success      200    {object}  models.RoleResponse          "Logged in, returns the roles of the token"
+// @Failure      400    {object}  models.ErrorResponse         "Bad Request"
+// @Failure      401    {object}  models.ErrorResponse         "Invalid PIN"
+// @Failure      429    {object}  gin.H                        "Too many attempts"
+// @Failure      500    {object}  models.ErrorResponse         "Internal Server Error"
+// @Router       /login/pin [post]
+func (s *Server) loginWithRefereePin(c *gin.Context) {
+	var loginInput models.RefereePinLoginInput
+	if err := c.ShouldBindJSON(&loginInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tokens, err := s.userService.LoginWithRefereePin(c, loginInput.CompetitionID, loginInput.Pin)
+	if err != nil {
+		if errors.Is(err, serviceImpl.ErrInvalidRefereePin) {
+			RespondError(c, http.StatusUnauthorized, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	// Reset rate limit on successful login
+	s.rateLimiter.ResetAttempts("pin-login", s.rateLimiter.GetClientIP(c))
+
+	middlewares.SetTokenCookies(c, tokens)
+	c.Header("x-token-refreshed", "true")
+
+	c.JSON(http.StatusOK, models.RoleResponse{
+		Roles: tokens.GetRoles(),
+	})
+}
+
+// parseRefereePinParams parses the competition and user IDs of the path, responding with an error when they are invalid
+func parseRefereePinParams(c *gin.Context) (int32, int32, bool) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return 0, 0, false
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid user ID"))
+		return 0, 0, false
+	}
+
+	return int32(competitionID), int32(userID), true
+}
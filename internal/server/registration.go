@@ -0,0 +1,370 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getCompetitionRegistration godoc
+// @Summary      Get the registration limits
+// @Description  Returns the maximum number of participants, the registration deadline, whether the entries over the limit are waitlisted,
+// @Description  and the number of registered and waitlisted participants
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.CompetitionRegistrationResponse  "Returns the registration limits"
+// @Failure      400            {object}  models.ErrorResponse                    "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                    "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                    "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse                    "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                    "Internal Server Error"
+// @Router       /competition/{competitionID}/registration [get]
+func (s *Server) getCompetitionRegistration(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	s.respondCompetitionRegistration(c, competition)
+}
+
+// setCompetitionRegistration godoc
+// @Summary      Set the registration limits
+// @Description  Sets the maximum number of participants, 0 for no limit, and the registration deadline, after which participants cannot be added.
+// @Description  Over the limit, the entries are waitlisted when the waitlist is enabled and refused otherwise. The participants already registered are kept.
+// @Tags         participant
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                               true  "Authentication cookie"
+// @Param        competitionID  path      int                                  true  "Competition ID"
+// @Param        registration   body      models.CompetitionRegistrationInput  true  "Registration limits"
+// @Success      200            {object}  models.CompetitionRegistrationResponse  "Returns the registration limits"
+// @Failure      400            {object}  models.ErrorResponse                    "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                    "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                    "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse                    "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse                    "Internal Server Error"
+// @Router       /competition/{competitionID}/registration [put]
+func (s *Server) setCompetitionRegistration(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.CompetitionRegistrationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	var deadline time.Time
+	if input.RegistrationDeadline != "" {
+		var ok bool
+		deadline, ok = aggregate.ParseRegistrationDeadline(input.RegistrationDeadline, competition.GetTimezone())
+		if !ok {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid registration deadline: expected an RFC3339 date or a YYYY-MM-DD day"))
+			return
+		}
+	}
+
+	competition, err = s.competitionService.SetCompetitionRegistration(c, int32(competitionID), input.MaxParticipants, deadline, input.Waitlist)
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	s.respondCompetitionRegistration(c, competition)
+}
+
+// promoteWaitlistedParticipant godoc
+// @Summary      Promote a waitlisted participant
+// @Description  Gives a place to a participant of the waitlist, within the maximum number of participants.
+// @Description  The participant is given the first free dossard after the registered participants, with its runs moved along.
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        dossard        path      int     true  "Dossard Number"
+// @Success      200            {object}  models.ParticipantResponse  "Returns the promoted participant"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse        "Competition or participant not found"
+// @Failure      409            {object}  models.ErrorResponse        "The participant is not waitlisted or the competition is full"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/promote [put]
+func (s *Server) promoteWaitlistedParticipant(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	participant, err := s.competitionService.PromoteWaitlistedParticipant(c, int32(competitionID), int32(dossard))
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ParticipantResponse{
+		CompetitionID: participant.GetCompetitionID(),
+		DossardNumber: participant.GetDossardNumber(),
+		FirstName:     participant.GetFirstName(),
+		LastName:      participant.GetLastName(),
+		Category:      participant.GetCategory(),
+		Gender:        participant.GetGender(),
+		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	})
+}
+
+// getWaitlist godoc
+// @Summary      Get the waitlist
+// @Description  Lists the waitlisted participants of a competition in the order they entered the waitlist, which is the order they are promoted in
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.WaitlistResponse  "Returns the waitlist"
+// @Failure      400            {object}  models.ErrorResponse     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse     "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse     "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse     "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse     "Internal Server Error"
+// @Router       /competition/{competitionID}/waitlist [get]
+func (s *Server) getWaitlist(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	waitlist, err := s.competitionService.ListWaitlist(c, int32(competitionID))
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	registered, _, err := s.competitionService.CountRegistrations(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.WaitlistResponse{
+		CompetitionID:   int32(competitionID),
+		MaxParticipants: competition.GetMaxParticipants(),
+		Registered:      registered,
+		Entries:         make([]models.WaitlistEntryResponse, 0, len(waitlist)),
+	}
+	for i, participant := range waitlist {
+		entry := models.WaitlistEntryResponse{
+			Position:      int32(i + 1),
+			DossardNumber: participant.GetDossardNumber(),
+			FirstName:     participant.GetFirstName(),
+			LastName:      participant.GetLastName(),
+			Category:      participant.GetCategory(),
+			Gender:        participant.GetGender(),
+			Club:          participant.GetClub(),
+		}
+		if !participant.GetWaitlistedAt().IsZero() {
+			entry.WaitlistedAt = participant.GetWaitlistedAt().UTC().Format(time.RFC3339)
+		}
+		response.Entries = append(response.Entries, entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// promoteWaitlist godoc
+// @Summary      Promote the head of the waitlist
+// @Description  Gives a place to up to count participants (1 by default) at the head of the waitlist, within the maximum number of participants.
+// @Description  Each promoted participant is given the first free dossard after the registered participants, with its runs moved along.
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        count          query     int     false  "Number of participants to promote, 1 by default"
+// @Success      200            {object}  models.WaitlistPromotionResponse  "Returns the promoted participants"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse              "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse              "The competition is full"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/waitlist/promote [post]
+func (s *Server) promoteWaitlist(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	count := int64(1)
+	if value := c.Query("count"); value != "" {
+		count, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || count <= 0 {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid count, expected a positive number"))
+			return
+		}
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	promoted, err := s.competitionService.PromoteWaitlist(c, int32(competitionID), int32(count))
+	if err != nil {
+		respondRegistrationError(c, err)
+		return
+	}
+
+	response := models.WaitlistPromotionResponse{
+		CompetitionID: int32(competitionID),
+		Promoted:      make([]models.ParticipantResponse, 0, len(promoted)),
+	}
+	for _, participant := range promoted {
+		response.Promoted = append(response.Promoted, models.ParticipantResponse{
+			CompetitionID: participant.GetCompetitionID(),
+			DossardNumber: participant.GetDossardNumber(),
+			FirstName:     participant.GetFirstName(),
+			LastName:      participant.GetLastName(),
+			Category:      participant.GetCategory(),
+			Gender:        participant.GetGender(),
+			Club:          participant.GetClub(),
+			ClubID:        participant.GetClubID(),
+			ClubEmail:     participant.GetClubEmail(),
+			Licence:       participant.GetLicence(),
+			BirthDate:     participant.GetBirthDay(),
+
+			ConsentDataProcessing: participant.GetConsentDataProcessing(),
+			ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+			Waitlisted: participant.IsWaitlisted(),
+			CheckedIn:  participant.IsCheckedIn(),
+
+			Notes: participant.GetNotes(),
+			Flags: participant.GetFlags(),
+
+			Status:       participant.GetStatus(),
+			StatusReason: participant.GetStatusReason(),
+			PhotoURL:     participantPhotoURL(participant),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondCompetitionRegistration responds with the registration limits of the competition and its participants count
+func (s *Server) respondCompetitionRegistration(c *gin.Context, competition *aggregate.Competition) {
+	registered, waitlisted, err := s.competitionService.CountRegistrations(c, competition.GetID())
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.CompetitionRegistrationResponse{
+		CompetitionID:    competition.GetID(),
+		MaxParticipants:  competition.GetMaxParticipants(),
+		Waitlist:         competition.HasWaitlist(),
+		RegistrationOpen: !competition.IsRegistrationClosed(time.Now()),
+		Registered:       registered,
+		Waitlisted:       waitlisted,
+	}
+	if !competition.GetRegistrationDeadline().IsZero() {
+		response.RegistrationDeadline = competition.GetLocalRegistrationDeadline().Format(time.RFC3339)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondRegistrationError maps the errors of the registrations to their status
+func respondRegistrationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidMaxParticipants),
+		errors.Is(err, service.ErrInvalidPromotionCount):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrCompetitionNotFound):
+		RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+	case errors.Is(err, repository.ErrParticipantNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrRegistrationClosed),
+		errors.Is(err, service.ErrCompetitionFull),
+		errors.Is(err, service.ErrParticipantNotWaitlisted):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/repository"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// publishResults godoc
+// @Summary      Publish the results
+// @Description  Renders the results of the competition to a static HTML page and a JSON document and uploads them to the results bucket,
+// @Description  where they stay available once the API is down. Closing the competition publishes them as well.
+// @Description  Participants who did not consent to the processing of their data are published anonymously.
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.ResultsPublicationResponse  "Returns the URLs of the published results"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Failure      503            {object}  models.ErrorResponse  "No results bucket configured"
+// @Router       /competition/{competitionID}/results/publish [post]
+func (s *Server) publishResults(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	publication, err := s.competitionService.PublishResults(c, int32(competitionID))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, serviceImpl.ErrPublicationDisabled):
+			RespondError(c, http.StatusServiceUnavailable, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, publication)
+}
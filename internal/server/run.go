@@ -2,8 +2,12 @@ package server
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
 	"github.com/NiskuT/cross-api/internal/domain/models"
@@ -11,11 +15,13 @@ import (
 	"github.com/NiskuT/cross-api/internal/server/middlewares"
 	serviceErr "github.com/NiskuT/cross-api/internal/service"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 )
 
 // getParticipant godoc
 // @Summary      Get participant information
-// @Description  Retrieves a participant's information based on dossard number and competition ID
+// @Description  Retrieves a participant's information based on dossard number and competition ID, with the notes and flags the organizers
+// @Description  wrote for the referees. Referees logged in with a PIN can fetch it before scoring a run.
 // @Tags         participant
 // @Accept       json
 // @Produce      json
@@ -44,8 +50,11 @@ func (s *Server) getParticipant(c *gin.Context) {
 		return
 	}
 
-	// Check if user has access to the competition
-	err = checkHasAccessToCompetition(c, int32(competitionID))
+	// Check if user has read access to the competition or is logged in with a referee PIN
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasRefereePinAccess(c, int32(competitionID))
+	}
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
@@ -67,23 +76,344 @@ func (s *Server) getParticipant(c *gin.Context) {
 		Category:      participant.GetCategory(),
 		Gender:        participant.GetGender(),
 		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// renumberParticipant godoc
+// @Summary      Change the dossard number of a participant
+// @Description  Changes the dossard number of a participant and moves its runs, liveranking, pending runs and team membership to the new number
+// @Description  in one transaction, the dossard being part of their keys. With swap, the participant holding the new number takes the current one in the same transaction, otherwise a used number is refused.
+// @Tags         participant
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                           true  "Authentication cookie"
+// @Param        competitionID  path      int                              true  "Competition ID"
+// @Param        dossard        path      int                              true  "Current dossard number"
+// @Param        participant    body      models.ParticipantRenumberInput  true  "New dossard number"
+// @Success      200            {object}  models.ParticipantResponse       "Returns the renumbered participant"
+// @Failure      400            {object}  models.ErrorResponse             "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse             "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse             "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse             "Participant not found"
+// @Failure      409            {object}  models.ErrorResponse             "Dossard number already used"
+// @Failure      500            {object}  models.ErrorResponse             "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/renumber [post]
+func (s *Server) renumberParticipant(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	var renumberInput models.ParticipantRenumberInput
+	if err := c.ShouldBindJSON(&renumberInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	participant, err := s.competitionService.RenumberParticipant(c, int32(competitionID), int32(dossard), renumberInput.DossardNumber, renumberInput.Swap)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceErr.ErrInvalidDossardNumber):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrParticipantNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, repository.ErrDuplicateParticipant):
+			RespondError(c, http.StatusConflict, errors.New("participant with this dossard number already exists"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response := models.ParticipantResponse{
+		CompetitionID: participant.GetCompetitionID(),
+		DossardNumber: participant.GetDossardNumber(),
+		FirstName:     participant.GetFirstName(),
+		LastName:      participant.GetLastName(),
+		Category:      participant.GetCategory(),
+		Gender:        participant.GetGender(),
+		Club:          participant.GetClub(),
+		ClubID:        participant.GetClubID(),
+		ClubEmail:     participant.GetClubEmail(),
+		Licence:       participant.GetLicence(),
+		BirthDate:     participant.GetBirthDay(),
+
+		ConsentDataProcessing: participant.GetConsentDataProcessing(),
+		ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+		Waitlisted: participant.IsWaitlisted(),
+		CheckedIn:  participant.IsCheckedIn(),
+
+		Notes: participant.GetNotes(),
+		Flags: participant.GetFlags(),
+
+		Status:       participant.GetStatus(),
+		StatusReason: participant.GetStatusReason(),
+		PhotoURL:     participantPhotoURL(participant),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// deleteParticipant godoc
+// @Summary      Delete a participant
+// @Description  Deletes a participant with its runs, liveranking rows and pending runs in one transaction and returns how many were deleted.
+// @Description  With dry_run=true nothing is deleted and the response counts the records that would be. The participants of a closed competition cannot be deleted.
+// @Tags         participant
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        dossard        path      int     true   "Dossard number"
+// @Param        dry_run        query     bool    false  "Only count the records that would be deleted"
+// @Success      200            {object}  models.ParticipantDeletionResponse  "Returns the records deleted with the participant"
+// @Failure      400            {object}  models.ErrorResponse                "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse                "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse                "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse                "Competition or participant not found"
+// @Failure      409            {object}  models.ErrorResponse                "The competition is closed"
+// @Failure      500            {object}  models.ErrorResponse                "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard} [delete]
+func (s *Server) deleteParticipant(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid dry_run, expected true or false"))
+			return
+		}
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	records, err := s.competitionService.DeleteParticipant(c, int32(competitionID), int32(dossard), dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		case errors.Is(err, repository.ErrParticipantNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if !dryRun {
+		s.recordParticipantDeletion(c, int32(competitionID), int32(dossard), records)
+	}
+
+	c.JSON(http.StatusOK, models.ParticipantDeletionResponse{
+		CompetitionID: int32(competitionID),
+		DossardNumber: int32(dossard),
+		DryRun:        dryRun,
+		Runs:          records.GetRuns(),
+		Liverankings:  records.GetLiverankings(),
+		PendingRuns:   records.GetPendingRuns(),
+	})
+}
+
+// recordParticipantDeletion records the deletion of a participant in the audit log, a failure being only logged
+func (s *Server) recordParticipantDeletion(c *gin.Context, competitionID, dossard int32, records *aggregate.ParticipantRecords) {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		log.Error().Err(err).Int32("competition_id", competitionID).Msg("Failed to record participant deletion")
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(competitionID)
+	auditLog.SetUserID(user.Id)
+	auditLog.SetAction(aggregate.AuditActionParticipantDeleted)
+	auditLog.SetDetails(fmt.Sprintf("dossard %d deleted with %d runs, %d liveranking rows and %d pending runs",
+		dossard, records.GetRuns(), records.GetLiverankings(), records.GetPendingRuns()))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int32("competition_id", competitionID).Msg("Failed to record participant deletion")
+	}
+}
+
+// clearParticipants godoc
+// @Summary      Clear the participants
+// @Description  Deletes every participant of a competition with their runs, liveranking rows and pending runs in one transaction, so that a botched import can be redone.
+// @Description  Without a confirmation token nothing is deleted: the response counts the records that would be and gives the token to send again to clear them.
+// @Description  The token is refused once the counts changed. The participants of a closed competition cannot be cleared.
+// @Tags         participant
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                         true   "Authentication cookie"
+// @Param        competitionID  path      int                            true   "Competition ID"
+// @Param        confirmation   body      models.ParticipantsClearInput  false  "Confirmation token"
+// @Success      200            {object}  models.ParticipantsClearResponse  "Returns the participants and records cleared"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse              "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse              "The competition is closed or the confirmation token is outdated"
+// @Failure      422            {object}  models.ParticipantsClearResponse  "The records that would be cleared, with the confirmation token"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/participants/clear [post]
+func (s *Server) clearParticipants(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// The body is optional
+	var input models.ParticipantsClearInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	var counted *aggregate.CompetitionParticipantRecords
+	var token string
+	if input.ConfirmationToken == "" {
+		counted, token, err = s.competitionService.CountParticipantsToClear(c, int32(competitionID))
+	} else {
+		counted, err = s.competitionService.ClearParticipants(c, int32(competitionID), input.ConfirmationToken)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		case errors.Is(err, serviceErr.ErrCompetitionClosed),
+			errors.Is(err, serviceErr.ErrInvalidClearToken):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	records := counted.GetRecords()
+	response := models.ParticipantsClearResponse{
+		CompetitionID: int32(competitionID),
+		Participants:  counted.GetParticipants(),
+		Runs:          records.GetRuns(),
+		Liverankings:  records.GetLiverankings(),
+		PendingRuns:   records.GetPendingRuns(),
+	}
+
+	// Clearing cannot be undone, it is only done once the admin sends back the token of the counts they saw
+	if token != "" {
+		response.Message = "nothing was deleted, send it again with the confirmation token to clear these participants and records"
+		response.ConfirmationToken = token
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
 	}
 
+	s.recordParticipantsClearing(c, int32(competitionID), counted)
+
+	response.Cleared = true
 	c.JSON(http.StatusOK, response)
 }
 
+// recordParticipantsClearing records the clearing of the participants in the audit log, a failure being only logged
+func (s *Server) recordParticipantsClearing(c *gin.Context, competitionID int32, counted *aggregate.CompetitionParticipantRecords) {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		log.Error().Err(err).Int32("competition_id", competitionID).Msg("Failed to record participants clearing")
+		return
+	}
+
+	records := counted.GetRecords()
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(competitionID)
+	auditLog.SetUserID(user.Id)
+	auditLog.SetAction(aggregate.AuditActionParticipantsCleared)
+	auditLog.SetDetails(fmt.Sprintf("%d participants cleared with %d runs, %d liveranking rows and %d pending runs",
+		counted.GetParticipants(), records.GetRuns(), records.GetLiverankings(), records.GetPendingRuns()))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int32("competition_id", competitionID).Msg("Failed to record participants clearing")
+	}
+}
+
 // createRun godoc
 // @Summary      Create a new run
-// @Description  Creates a new run and updates the liveranking
+// @Description  Creates a new run and updates the liveranking. A run whose chrono or penalty exceeds the bounds of its zone
+// @Description  is rejected with the warnings until it is sent again with confirmed set. The doors are listed in order, the doors
+// @Description  left out count as not passed and a run with more doors than the scale of its zone is rejected.
+// @Description  A run sent again with the idempotency key of a recorded run, e.g. after a double tap or a lost response, is not recorded twice:
+// @Description  the recorded run is returned with the Idempotent-Replayed header.
+// @Description  When the settings of the competition require it, the runs of the referees are pending_approval and only count once approved.
+// @Description  The optional comment lets the referee note a gate dispute or a hardware issue, it is shown with the runs of the participant and in the Excel export.
 // @Tags         run
 // @Accept       json
 // @Produce      json
 // @Param        Cookie  header string    true  "Authentication cookie"
 // @Param        run  body       models.RunInput  true  "Run data"
+// @Param        X-Api-Key  header  string  false  "API key with the write-runs scope, replaces the cookie"
+// @Param        Idempotency-Key  header  string  false  "Unique key of the run drawn by the client, up to 64 characters, replaces idempotency_key"
 // @Success      201  {object}   models.RunResponse     "Returns created run data"
 // @Failure      400  {object}   models.ErrorResponse   "Bad Request"
 // @Failure      401  {object}   models.ErrorResponse   "Unauthorized"
+// @Failure      403  {object}   models.ErrorResponse   "Forbidden (the referee is not assigned to the zone)"
 // @Failure      404  {object}   models.ErrorResponse   "Not Found"
+// @Failure      409  {object}   models.ErrorResponse   "Competition not running, zone closed, idempotency key used for another run, or runs expected in the zone already recorded (models.RunsPerZoneErrorResponse)"
+// @Failure      422  {object}   models.RunConfirmationResponse  "Values outside the bounds of the zone, to be confirmed"
+// @Failure      429  {object}   gin.H                  "Too many runs recorded by the user"
 // @Failure      500  {object}   models.ErrorResponse   "Internal Server Error"
 // @Router       /run [post]
 func (s *Server) createRun(c *gin.Context) {
@@ -93,8 +423,15 @@ func (s *Server) createRun(c *gin.Context) {
 		return
 	}
 
-	// Check if user has appropriate role (admin or referee for the competition)
+	// Check if user has appropriate role (admin or referee for the competition), logged in with a referee PIN,
+	// or uses an API key allowed to write runs
 	err := checkHasAccessToCompetition(c, runInput.CompetitionID)
+	if err != nil {
+		err = checkHasRefereePinAccess(c, runInput.CompetitionID)
+	}
+	if err != nil {
+		err = checkHasAPIKeyScope(c, aggregate.APIKeyScopeWriteRuns, runInput.CompetitionID)
+	}
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
@@ -112,24 +449,97 @@ func (s *Server) createRun(c *gin.Context) {
 	run.SetCompetitionID(runInput.CompetitionID)
 	run.SetDossard(runInput.Dossard)
 	run.SetZone(runInput.Zone)
-	run.SetDoor1(runInput.Door1)
-	run.SetDoor2(runInput.Door2)
-	run.SetDoor3(runInput.Door3)
-	run.SetDoor4(runInput.Door4)
-	run.SetDoor5(runInput.Door5)
-	run.SetDoor6(runInput.Door6)
+	run.SetDoors(runInput.Doors)
 	run.SetPenality(runInput.Penality)
-	run.SetChronoSec(runInput.ChronoSec)
+	run.SetChronoMs(inputChronoMs(runInput.ChronoSec, runInput.ChronoMs))
+	run.SetStatus(strings.ToLower(runInput.Status))
+	run.SetComment(strings.TrimSpace(runInput.Comment))
 
 	run.SetRefereeId(user.Id)
 
+	// The retries of a client sending an idempotency key get the run it already recorded
+	idempotencyKey := runInput.IdempotencyKey
+	if header := c.GetHeader("Idempotency-Key"); header != "" {
+		idempotencyKey = header
+	}
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid idempotency key, expected at most 64 characters"))
+		return
+	}
+	run.SetIdempotencyKey(idempotencyKey)
+	if s.replayRecordedRun(c, run) {
+		return
+	}
+
+	// Referees can be restricted to the zones they are assigned to and their runs can require the approval of an admin,
+	// admins and API keys are not
+	if checkHasAdminAccessToCompetition(c, runInput.CompetitionID) != nil &&
+		checkHasAPIKeyScope(c, aggregate.APIKeyScopeWriteRuns, runInput.CompetitionID) != nil {
+		err = s.runService.CheckRefereeZone(c, run)
+		if err != nil {
+			if errors.Is(err, serviceErr.ErrZoneNotAssigned) {
+				RespondError(c, http.StatusForbidden, err)
+				return
+			}
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		requiresApproval, err := s.runService.RequiresRunApproval(c, runInput.CompetitionID)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		run.SetPendingApproval(requiresApproval)
+	}
+
+	// Values outside the bounds of the zone are usually typos, they are recorded once confirmed
+	if !runInput.Confirmed {
+		warnings, err := s.runService.CheckRunBounds(c, run)
+		if err != nil {
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		if len(warnings) > 0 {
+			response := models.RunConfirmationResponse{
+				Code:     http.StatusUnprocessableEntity,
+				Message:  "run values outside the bounds of the zone, send it again with confirmed set to record it",
+				Warnings: make([]models.RunWarningResponse, 0, len(warnings)),
+			}
+			for _, warning := range warnings {
+				response.Warnings = append(response.Warnings, models.RunWarningResponse{
+					Code:  warning.Code,
+					Value: warning.Value,
+					Max:   warning.Max,
+				})
+			}
+			c.JSON(http.StatusUnprocessableEntity, response)
+			return
+		}
+	}
+
 	// Call service to create run
 	err = s.runService.CreateRun(c, run)
 	if err != nil {
+		// A concurrent retry with the same idempotency key recorded the run meanwhile
+		if errors.Is(err, repository.ErrDuplicateIdempotencyKey) && s.replayRecordedRun(c, run) {
+			return
+		}
+		if respondRunsPerZoneError(c, err) {
+			return
+		}
 		// Determine appropriate error code based on error type
-		if errors.Is(err, serviceErr.ErrInvalidRunData) {
+		if errors.Is(err, serviceErr.ErrInvalidRunData) ||
+			errors.Is(err, serviceErr.ErrUnknownRunStatus) ||
+			errors.Is(err, serviceErr.ErrInvalidChrono) ||
+			errors.Is(err, serviceErr.ErrTooManyDoors) {
 			RespondError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, serviceErr.ErrCompetitionNotRunning) ||
+			errors.Is(err, serviceErr.ErrZoneClosed) {
+			RespondError(c, http.StatusConflict, err)
 		} else if errors.Is(err, repository.ErrParticipantNotFound) ||
+			errors.Is(err, repository.ErrCompetitionNotFound) ||
+			errors.Is(err, serviceErr.ErrUnknownZone) ||
 			errors.Is(err, serviceErr.ErrScaleNotFound) {
 			RespondError(c, http.StatusNotFound, err)
 		} else {
@@ -138,23 +548,195 @@ func (s *Server) createRun(c *gin.Context) {
 		return
 	}
 
-	// Build response
-	response := models.RunResponse{
+	s.flagRunAnomaly(c, user.Id, run.GetCompetitionID())
+
+	c.JSON(http.StatusCreated, toRunResponse(run))
+}
+
+// maxIdempotencyKeyLength is the longest idempotency key a client can send with a run
+const maxIdempotencyKeyLength = 64
+
+// replayRecordedRun responds with the run already recorded with the idempotency key of the run, as when it was recorded,
+// and returns whether it responded
+func (s *Server) replayRecordedRun(c *gin.Context, run *aggregate.Run) bool {
+	recorded, err := s.runService.FindReplayedRun(c, run)
+	if err != nil {
+		if errors.Is(err, serviceErr.ErrIdempotencyKeyReused) {
+			RespondError(c, http.StatusConflict, err)
+			return true
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return true
+	}
+	if recorded == nil {
+		return false
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.JSON(http.StatusCreated, toRunResponse(recorded))
+	return true
+}
+
+// inputChronoMs returns the chrono of a run input in milliseconds, chrono_ms replacing chrono_sec when set
+func inputChronoMs(chronoSec, chronoMs int32) int32 {
+	if chronoMs != 0 {
+		return chronoMs
+	}
+	// The seconds too large to be counted in milliseconds are out of bounds anyway, the service rejects them
+	if chronoSec > math.MaxInt32/1000 || chronoSec < math.MinInt32/1000 {
+		return math.MaxInt32
+	}
+	return chronoSec * 1000
+}
+
+// toRunResponse builds the response describing a recorded run
+func toRunResponse(run *aggregate.Run) models.RunResponse {
+	return models.RunResponse{
 		CompetitionID: run.GetCompetitionID(),
 		Dossard:       run.GetDossard(),
 		RunNumber:     run.GetRunNumber(),
 		Zone:          run.GetZone(),
-		Door1:         run.GetDoor1(),
-		Door2:         run.GetDoor2(),
-		Door3:         run.GetDoor3(),
-		Door4:         run.GetDoor4(),
-		Door5:         run.GetDoor5(),
-		Door6:         run.GetDoor6(),
+		Doors:         run.GetDoors(),
 		Penality:      run.GetPenality(),
 		ChronoSec:     run.GetChronoSec(),
+		ChronoMs:      run.GetChronoMs(),
+		Status:        run.GetStatus(),
+		ReceiptCode:   run.GetReceiptCode(),
+		Comment:       run.GetComment(),
+
+		PendingApproval: run.IsPendingApproval(),
+	}
+}
+
+// flagRunAnomaly records in the audit log a user recording runs faster than a referee can,
+// which usually means a malfunctioning client is spamming scores. The entry is written once,
+// when the threshold is crossed, rather than for every following run.
+func (s *Server) flagRunAnomaly(c *gin.Context, userID int32, competitionID int32) {
+	count := s.rateLimiter.Record("run-anomaly", s.rateLimiter.GetUserKey(c))
+	if count != s.conf.RateLimit.RunAnomalyThreshold {
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(competitionID)
+	auditLog.SetUserID(userID)
+	auditLog.SetAction(aggregate.AuditActionRunAnomaly)
+	auditLog.SetDetails(fmt.Sprintf("%d runs recorded within %s", count, s.conf.RateLimit.RunAnomalyWindow))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int32("user_id", userID).Msg("Failed to record run anomaly")
+	}
+}
+
+// listRuns godoc
+// @Summary      List the runs of a competition
+// @Description  Lists a page of the runs of a competition, most recent first, with their referees and voided runs included,
+// @Description  optionally filtered by zone, dossard, referee and recording time, to audit the scoring during the event
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        zone           query     string  false  "Zone of the runs"
+// @Param        dossard        query     int     false  "Dossard of the participant"
+// @Param        referee_id     query     int     false  "ID of the referee who recorded the runs"
+// @Param        from           query     string  false  "Earliest recording time of the runs, as RFC3339"
+// @Param        to             query     string  false  "Latest recording time of the runs, as RFC3339"
+// @Param        pending_approval  query  bool    false  "Only list the runs waiting for the approval of an admin"
+// @Param        page           query     int     false  "Page number (default: 1)"
+// @Param        page_size      query     int     false  "Page size (default: 10)"
+// @Success      200            {object}  models.RunPageResponse  "Returns a page of runs with details"
+// @Failure      400            {object}  models.ErrorResponse    "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse    "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse    "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse    "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse    "Internal Server Error"
+// @Router       /competition/{competitionID}/runs [get]
+func (s *Server) listRuns(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user administrates or observes the competition
+	err = checkHasAdminOrObserverAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	filter := aggregate.NewRunFilter()
+	filter.SetZone(strings.TrimSpace(c.Query("zone")))
+	if value := c.Query("dossard"); value != "" {
+		dossard, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || dossard <= 0 {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid dossard, expected a positive number"))
+			return
+		}
+		filter.SetDossard(int32(dossard))
+	}
+	if value := c.Query("referee_id"); value != "" {
+		refereeID, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || refereeID <= 0 {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid referee_id, expected a positive number"))
+			return
+		}
+		filter.SetRefereeID(int32(refereeID))
+	}
+	if value := c.Query("from"); value != "" {
+		from, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, serviceErr.ErrInvalidRunTimeRange)
+			return
+		}
+		filter.SetFrom(from)
+	}
+	if value := c.Query("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, serviceErr.ErrInvalidRunTimeRange)
+			return
+		}
+		filter.SetTo(to)
+	}
+	if value := c.Query("pending_approval"); value != "" {
+		pendingApprovalOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid pending_approval, expected true or false"))
+			return
+		}
+		filter.SetPendingApprovalOnly(pendingApprovalOnly)
 	}
 
-	c.JSON(http.StatusCreated, response)
+	page, pageSize := getPagination(c)
+
+	runs, total, err := s.runService.SearchRuns(c, int32(competitionID), filter, page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceErr.ErrInvalidRunTimeRange):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response := models.RunPageResponse{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+		Runs:     make([]*models.RunDetailsResponse, 0, len(runs)),
+	}
+
+	for _, run := range runs {
+		response.Runs = append(response.Runs, toRunDetailsResponse(run))
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // getParticipantRuns godoc
@@ -189,8 +771,8 @@ func (s *Server) getParticipantRuns(c *gin.Context) {
 		return
 	}
 
-	// Check if user has admin access to the competition
-	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	// Check if user administrates or observes the competition
+	err = checkHasAdminOrObserverAccessToCompetition(c, int32(competitionID))
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
@@ -209,40 +791,136 @@ func (s *Server) getParticipantRuns(c *gin.Context) {
 	}
 
 	for _, run := range runs {
-		response.Runs = append(response.Runs, &models.RunDetailsResponse{
-			CompetitionID: run.GetCompetitionID(),
-			Dossard:       run.GetDossard(),
-			RunNumber:     run.GetRunNumber(),
-			Zone:          run.GetZone(),
-			Door1:         run.GetDoor1(),
-			Door2:         run.GetDoor2(),
-			Door3:         run.GetDoor3(),
-			Door4:         run.GetDoor4(),
-			Door5:         run.GetDoor5(),
-			Door6:         run.GetDoor6(),
-			Penality:      run.GetPenality(),
-			ChronoSec:     run.GetChronoSec(),
-			RefereeID:     run.GetRefereeId(),
-			RefereeName:   run.GetRefereeName(),
+		response.Runs = append(response.Runs, toRunDetailsResponse(run))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getRunHistory godoc
+// @Summary      Get the history of a run
+// @Description  Lists the changes of the values of a run, oldest first, with the values before and after each change, its editor and time
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        dossard        path      int     true  "Participant dossard number"
+// @Param        runNumber      path      int     true  "Run number"
+// @Success      200            {object}  models.RunRevisionListResponse  "Returns the changes of the run"
+// @Failure      400            {object}  models.ErrorResponse            "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse            "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse            "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse            "Run not found"
+// @Failure      500            {object}  models.ErrorResponse            "Internal Server Error"
+// @Router       /competition/{competitionID}/participant/{dossard}/runs/{runNumber}/history [get]
+func (s *Server) getRunHistory(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	dossard, err := strconv.ParseInt(c.Param("dossard"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid dossard number"))
+		return
+	}
+
+	runNumber, err := strconv.ParseInt(c.Param("runNumber"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid run number"))
+		return
+	}
+
+	// Check if user administrates or observes the competition
+	err = checkHasAdminOrObserverAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	revisions, err := s.runService.ListRunRevisions(c, int32(competitionID), int32(dossard), int32(runNumber))
+	if err != nil {
+		if errors.Is(err, repository.ErrRunNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("run not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.RunRevisionListResponse{
+		CompetitionID: int32(competitionID),
+		Dossard:       int32(dossard),
+		RunNumber:     int32(runNumber),
+		Revisions:     make([]models.RunRevisionResponse, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		response.Revisions = append(response.Revisions, models.RunRevisionResponse{
+			ID:         revision.GetID(),
+			EditorID:   revision.GetEditorID(),
+			EditorName: revision.GetEditorName(),
+			CreatedAt:  revision.GetCreatedAt(),
+			Before:     toRunValuesResponse(revision.GetBefore()),
+			After:      toRunValuesResponse(revision.GetAfter()),
 		})
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// toRunValuesResponse builds the response describing the values of a run in one of its revisions
+func toRunValuesResponse(run *aggregate.Run) models.RunValuesResponse {
+	return models.RunValuesResponse{
+		Zone:      run.GetZone(),
+		Doors:     run.GetDoors(),
+		Penality:  run.GetPenality(),
+		ChronoSec: run.GetChronoSec(),
+		ChronoMs:  run.GetChronoMs(),
+		Status:    run.GetStatus(),
+	}
+}
+
+func toRunDetailsResponse(run *aggregate.Run) *models.RunDetailsResponse {
+	return &models.RunDetailsResponse{
+		CompetitionID: run.GetCompetitionID(),
+		Dossard:       run.GetDossard(),
+		RunNumber:     run.GetRunNumber(),
+		Zone:          run.GetZone(),
+		Doors:         run.GetDoors(),
+		Penality:      run.GetPenality(),
+		ChronoSec:     run.GetChronoSec(),
+		ChronoMs:      run.GetChronoMs(),
+		RefereeID:     run.GetRefereeId(),
+		RefereeName:   run.GetRefereeName(),
+		Status:        run.GetStatus(),
+		Voided:        run.IsVoided(),
+		ReceiptCode:   run.GetReceiptCode(),
+		Comment:       run.GetComment(),
+
+		PendingApproval: run.IsPendingApproval(),
+
+		CreatedAt: run.GetCreatedAt(),
+		UpdatedAt: run.GetUpdatedAt(),
+	}
+}
+
 // updateRun godoc
 // @Summary      Update a run
-// @Description  Updates an existing run and recalculates liveranking (admin only)
+// @Description  Updates an existing run and recalculates liveranking, the change is kept in the history of the run.
+// @Description  Admins can update any run, referees only the runs they recorded, within the correction window (RUN_CORRECTION_WINDOW, 5 minutes by default).
 // @Tags         run
 // @Accept       json
 // @Produce      json
 // @Param        Cookie  header    string               true  "Authentication cookie"
 // @Param        run     body      models.RunUpdateInput true  "Run update data"
 // @Success      200     {object}  models.RunResponse   "Returns updated run data"
-// @Failure      400     {object}  models.ErrorResponse "Bad Request"
+// @Failure      400     {object}  models.ErrorResponse "Bad Request, zone not scored for the category of the participant or more doors than its scale"
 // @Failure      401     {object}  models.ErrorResponse "Unauthorized"
-// @Failure      403     {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      403     {object}  models.ErrorResponse "Forbidden (run recorded by another referee, or outside the zones of the referee)"
 // @Failure      404     {object}  models.ErrorResponse "Run not found"
+// @Failure      409     {object}  models.ErrorResponse "Run voided, correction window expired or competition closed"
 // @Failure      500     {object}  models.ErrorResponse "Internal Server Error"
 // @Router       /run [put]
 func (s *Server) updateRun(c *gin.Context) {
@@ -252,8 +930,8 @@ func (s *Server) updateRun(c *gin.Context) {
 		return
 	}
 
-	// Check if user has admin access to the competition
-	err := checkHasAdminAccessToCompetition(c, runInput.CompetitionID)
+	// Admins update any run, referees their own runs within the correction window
+	isAdmin, err := checkCanCorrectRuns(c, runInput.CompetitionID)
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
@@ -272,44 +950,207 @@ func (s *Server) updateRun(c *gin.Context) {
 
 	// Update the run with new values
 	existingRun.SetZone(runInput.Zone)
-	existingRun.SetDoor1(runInput.Door1)
-	existingRun.SetDoor2(runInput.Door2)
-	existingRun.SetDoor3(runInput.Door3)
-	existingRun.SetDoor4(runInput.Door4)
-	existingRun.SetDoor5(runInput.Door5)
-	existingRun.SetDoor6(runInput.Door6)
+	existingRun.SetDoors(runInput.Doors)
 	existingRun.SetPenality(runInput.Penality)
-	existingRun.SetChronoSec(runInput.ChronoSec)
+	existingRun.SetChronoMs(inputChronoMs(runInput.ChronoSec, runInput.ChronoMs))
+	if runInput.Status != "" {
+		existingRun.SetStatus(strings.ToLower(runInput.Status))
+	}
+	if runInput.Comment != nil {
+		existingRun.SetComment(strings.TrimSpace(*runInput.Comment))
+	}
 
-	// Update the run
-	err = s.runService.UpdateRun(c, existingRun)
+	user, err := middlewares.GetUser(c)
 	if err != nil {
 		RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	// Update the run, its previous values are kept in its revisions
+	if isAdmin {
+		err = s.runService.UpdateRun(c, existingRun, user.Id)
+	} else {
+		err = s.runService.UpdateOwnRun(c, existingRun, user.Id)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceErr.ErrUnknownZone),
+			errors.Is(err, serviceErr.ErrScaleNotFound),
+			errors.Is(err, serviceErr.ErrUnknownRunStatus),
+			errors.Is(err, serviceErr.ErrInvalidChrono),
+			errors.Is(err, serviceErr.ErrTooManyDoors):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, serviceErr.ErrNotRunReferee),
+			errors.Is(err, serviceErr.ErrZoneNotAssigned):
+			RespondError(c, http.StatusForbidden, err)
+		case errors.Is(err, serviceErr.ErrRunAlreadyVoided),
+			errors.Is(err, serviceErr.ErrCorrectionWindowExpired),
+			errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
 	// Build response
 	response := models.RunResponse{
 		CompetitionID: existingRun.GetCompetitionID(),
 		Dossard:       existingRun.GetDossard(),
 		RunNumber:     existingRun.GetRunNumber(),
 		Zone:          existingRun.GetZone(),
-		Door1:         existingRun.GetDoor1(),
-		Door2:         existingRun.GetDoor2(),
-		Door3:         existingRun.GetDoor3(),
-		Door4:         existingRun.GetDoor4(),
-		Door5:         existingRun.GetDoor5(),
-		Door6:         existingRun.GetDoor6(),
+		Doors:         existingRun.GetDoors(),
 		Penality:      existingRun.GetPenality(),
 		ChronoSec:     existingRun.GetChronoSec(),
+		ChronoMs:      existingRun.GetChronoMs(),
+		Status:        existingRun.GetStatus(),
+		Comment:       existingRun.GetComment(),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// voidRun godoc
+// @Summary      Void a run
+// @Description  Lets the referee who recorded a run void it within the undo window (RUN_UNDO_WINDOW, 2 minutes by default), e.g. after a typo.
+// @Description  The run is kept but no longer counts in the ranking, and the liveranking of the participant is recalculated.
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string               true  "Authentication cookie"
+// @Param        run     body      models.RunVoidInput  true  "Run to void"
+// @Success      200     {object}  models.RunDetailsResponse  "Returns the voided run"
+// @Failure      400     {object}  models.ErrorResponse "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse "Unauthorized"
+// @Failure      403     {object}  models.ErrorResponse "Forbidden (run recorded by another referee)"
+// @Failure      404     {object}  models.ErrorResponse "Run not found"
+// @Failure      409     {object}  models.ErrorResponse "Run already voided, undo window expired or competition closed"
+// @Failure      500     {object}  models.ErrorResponse "Internal Server Error"
+// @Router       /run/void [post]
+func (s *Server) voidRun(c *gin.Context) {
+	var input models.RunVoidInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Check if user is a referee or admin of the competition, or logged in with a referee PIN
+	err := checkHasAccessToCompetition(c, input.CompetitionID)
+	if err != nil {
+		err = checkHasRefereePinAccess(c, input.CompetitionID)
+	}
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	run, err := s.runService.VoidOwnRun(c, input.CompetitionID, input.RunNumber, input.Dossard, user.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRunNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("run not found"))
+		case errors.Is(err, serviceErr.ErrNotRunReferee):
+			RespondError(c, http.StatusForbidden, err)
+		case errors.Is(err, serviceErr.ErrRunAlreadyVoided),
+			errors.Is(err, serviceErr.ErrUndoWindowExpired),
+			errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(input.CompetitionID)
+	auditLog.SetUserID(user.Id)
+	auditLog.SetAction(aggregate.AuditActionRunVoided)
+	auditLog.SetDetails(fmt.Sprintf("dossard %d: run %d voided by its referee", input.Dossard, input.RunNumber))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int32("competition_id", input.CompetitionID).Msg("Failed to record run void")
+	}
+
+	c.JSON(http.StatusOK, toRunDetailsResponse(run))
+}
+
+// approveRun godoc
+// @Summary      Approve a run
+// @Description  Approves a run recorded by a referee of a competition whose settings require the approval of the runs, e.g. by the head judge.
+// @Description  The run then counts in the ranking, the liveranking of the participant is recalculated and the approval recorded in the audit log (admin only).
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string                  true  "Authentication cookie"
+// @Param        run     body      models.RunApproveInput  true  "Run to approve"
+// @Success      200     {object}  models.RunDetailsResponse  "Returns the approved run"
+// @Failure      400     {object}  models.ErrorResponse "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse "Unauthorized"
+// @Failure      403     {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      404     {object}  models.ErrorResponse "Run not found"
+// @Failure      409     {object}  models.ErrorResponse "Run voided or not waiting for its approval, or competition closed"
+// @Failure      500     {object}  models.ErrorResponse "Internal Server Error"
+// @Router       /run/approve [post]
+func (s *Server) approveRun(c *gin.Context) {
+	var input models.RunApproveInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Check if user is admin of the competition
+	err := checkHasAdminAccessToCompetition(c, input.CompetitionID)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	run, err := s.runService.ApproveRun(c, input.CompetitionID, input.RunNumber, input.Dossard)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRunNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("run not found"))
+		case errors.Is(err, serviceErr.ErrRunAlreadyVoided),
+			errors.Is(err, serviceErr.ErrRunNotPendingApproval),
+			errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(input.CompetitionID)
+	auditLog.SetUserID(user.Id)
+	auditLog.SetAction(aggregate.AuditActionRunApproved)
+	auditLog.SetDetails(fmt.Sprintf("dossard %d: run %d approved", input.Dossard, input.RunNumber))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int32("competition_id", input.CompetitionID).Msg("Failed to record run approval")
+	}
+
+	c.JSON(http.StatusOK, toRunDetailsResponse(run))
+}
+
 // deleteRun godoc
 // @Summary      Delete a run
-// @Description  Deletes an existing run and recalculates liveranking (admin only)
+// @Description  Deletes an existing run and recalculates liveranking. Admins can delete any run, referees only the runs they recorded,
+// @Description  within the correction window (RUN_CORRECTION_WINDOW, 5 minutes by default), the deletion being recorded in the audit log.
 // @Tags         run
 // @Accept       json
 // @Produce      json
@@ -320,8 +1161,9 @@ func (s *Server) updateRun(c *gin.Context) {
 // @Success      200           {object}  gin.H   "Run deleted successfully"
 // @Failure      400           {object}  models.ErrorResponse "Bad Request"
 // @Failure      401           {object}  models.ErrorResponse "Unauthorized"
-// @Failure      403           {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      403           {object}  models.ErrorResponse "Forbidden (run recorded by another referee)"
 // @Failure      404           {object}  models.ErrorResponse "Run not found"
+// @Failure      409           {object}  models.ErrorResponse "Run voided, correction window expired or competition closed"
 // @Failure      500           {object}  models.ErrorResponse "Internal Server Error"
 // @Router       /run [delete]
 func (s *Server) deleteRun(c *gin.Context) {
@@ -352,24 +1194,55 @@ func (s *Server) deleteRun(c *gin.Context) {
 		return
 	}
 
-	// Check if user has admin access to the competition
-	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	// Admins delete any run, referees their own runs within the correction window
+	isAdmin, err := checkCanCorrectRuns(c, int32(competitionID))
 	if err != nil {
 		RespondError(c, http.StatusForbidden, err)
 		return
 	}
 
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	// Delete the run
-	err = s.runService.DeleteRun(c, int32(competitionID), int32(runNumber), int32(dossard))
+	if isAdmin {
+		err = s.runService.DeleteRun(c, int32(competitionID), int32(runNumber), int32(dossard))
+	} else {
+		err = s.runService.DeleteOwnRun(c, int32(competitionID), int32(runNumber), int32(dossard), user.Id)
+	}
 	if err != nil {
-		if errors.Is(err, repository.ErrRunNotFound) {
+		switch {
+		case errors.Is(err, repository.ErrRunNotFound):
 			RespondError(c, http.StatusNotFound, errors.New("run not found"))
-			return
+		case errors.Is(err, serviceErr.ErrNotRunReferee):
+			RespondError(c, http.StatusForbidden, err)
+		case errors.Is(err, serviceErr.ErrRunAlreadyVoided),
+			errors.Is(err, serviceErr.ErrCorrectionWindowExpired),
+			errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
 		}
-		RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	// The runs deleted by their referee leave no revision, the audit log keeps track of them
+	if !isAdmin {
+		auditLog := aggregate.NewAuditLog()
+		auditLog.SetCompetitionID(int32(competitionID))
+		auditLog.SetUserID(user.Id)
+		auditLog.SetAction(aggregate.AuditActionRunDeleted)
+		auditLog.SetDetails(fmt.Sprintf("dossard %d: run %d deleted by its referee", dossard, runNumber))
+		auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+		if err := s.auditService.Record(c, auditLog); err != nil {
+			log.Error().Err(err).Int64("competition_id", competitionID).Msg("Failed to record run deletion")
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Run deleted successfully",
 	})
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	serviceErr "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// listRunConflicts godoc
+// @Summary      List run conflicts
+// @Description  Lists the participants scored more often in a zone than the runs expected for their category, e.g. by two referees, with their runs (admin only)
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie        header    string  true   "Authentication cookie"
+// @Param        competitionID path      int     true   "Competition ID"
+// @Success      200           {object}  models.RunConflictListResponse  "Returns the conflicts"
+// @Failure      400           {object}  models.ErrorResponse            "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse            "Unauthorized"
+// @Failure      403           {object}  models.ErrorResponse            "Forbidden (admin access required)"
+// @Failure      500           {object}  models.ErrorResponse            "Internal Server Error"
+// @Router       /competition/{competitionID}/runs/conflicts [get]
+func (s *Server) listRunConflicts(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	conflicts, err := s.runService.ListRunConflicts(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.RunConflictListResponse{
+		CompetitionID: int32(competitionID),
+		Conflicts:     make([]*models.RunConflictResponse, 0, len(conflicts)),
+	}
+	for _, conflict := range conflicts {
+		conflictResponse := &models.RunConflictResponse{
+			Dossard: conflict.GetDossard(),
+			Zone:    conflict.GetZone(),
+			Runs:    make([]*models.RunDetailsResponse, 0, len(conflict.GetRuns())),
+		}
+		for _, run := range conflict.GetRuns() {
+			conflictResponse.Runs = append(conflictResponse.Runs, toRunDetailsResponse(run))
+		}
+		response.Conflicts = append(response.Conflicts, conflictResponse)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// resolveRunConflict godoc
+// @Summary      Resolve a run conflict
+// @Description  Keeps a run as the authoritative score of the participant in its zone. The runs of the zone beyond the ones expected,
+// @Description  the earliest being kept with it, are voided: they are kept for the record but no longer count in the ranking (admin only)
+// @Tags         run
+// @Accept       json
+// @Produce      json
+// @Param        Cookie        header    string                          true   "Authentication cookie"
+// @Param        competitionID path      int                             true   "Competition ID"
+// @Param        resolution    body      models.RunConflictResolveInput  true   "Participant and run kept"
+// @Success      200           {object}  models.RunConflictResolveResponse  "Returns the number of voided runs"
+// @Failure      400           {object}  models.ErrorResponse               "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse               "Unauthorized"
+// @Failure      403           {object}  models.ErrorResponse               "Forbidden (admin access required)"
+// @Failure      404           {object}  models.ErrorResponse               "Run not found"
+// @Failure      409           {object}  models.ErrorResponse               "No run of the participant beyond the runs expected in this zone, or competition closed"
+// @Failure      500           {object}  models.ErrorResponse               "Internal Server Error"
+// @Router       /competition/{competitionID}/runs/conflicts/resolve [post]
+func (s *Server) resolveRunConflict(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var resolveInput models.RunConflictResolveInput
+	if err := c.ShouldBindJSON(&resolveInput); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	voided, err := s.runService.ResolveRunConflict(c, int32(competitionID), resolveInput.Dossard, resolveInput.RunNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRunNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		case errors.Is(err, repository.ErrNoRunConflict), errors.Is(err, serviceErr.ErrCompetitionClosed):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	auditLog := aggregate.NewAuditLog()
+	auditLog.SetCompetitionID(int32(competitionID))
+	if user, err := middlewares.GetUser(c); err == nil {
+		auditLog.SetUserID(user.Id)
+	}
+	auditLog.SetAction(aggregate.AuditActionRunConflictResolved)
+	auditLog.SetDetails(fmt.Sprintf("dossard %d: run %d kept, %d run(s) voided", resolveInput.Dossard, resolveInput.RunNumber, voided))
+	auditLog.SetIP(s.rateLimiter.GetClientIP(c))
+
+	if err := s.auditService.Record(c, auditLog); err != nil {
+		log.Error().Err(err).Int32("competition_id", int32(competitionID)).Msg("Failed to record run conflict resolution")
+	}
+
+	c.JSON(http.StatusOK, models.RunConflictResolveResponse{
+		VoidedRuns: voided,
+	})
+}
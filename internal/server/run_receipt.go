@@ -0,0 +1,48 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/repository"
+	serviceErr "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getRunByReceipt godoc
+// @Summary      Find a run by its receipt code
+// @Description  Returns the run with the receipt code given to its referee when it was recorded, e.g. read from the paper backup sheet during a dispute.
+// @Description  The code is case insensitive and can be split with dashes or spaces.
+// @Tags         run
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Param        code    path      string  true  "Receipt code"
+// @Success      200     {object}  models.RunDetailsResponse  "Returns the run"
+// @Failure      400     {object}  models.ErrorResponse       "Bad Request (invalid receipt code)"
+// @Failure      401     {object}  models.ErrorResponse       "Unauthorized"
+// @Failure      403     {object}  models.ErrorResponse       "Forbidden (admin access to the competition of the run required)"
+// @Failure      404     {object}  models.ErrorResponse       "Run not found"
+// @Failure      500     {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /run/receipt/{code} [get]
+func (s *Server) getRunByReceipt(c *gin.Context) {
+	run, err := s.runService.GetRunByReceiptCode(c, c.Param("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceErr.ErrInvalidReceiptCode):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrRunNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, run.GetCompetitionID())
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRunDetailsResponse(run))
+}
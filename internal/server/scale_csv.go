@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	serviceErr "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// exportScales godoc
+// @Summary      Export the scales of a competition to CSV
+// @Description  Exports the door points of every zone of every category as a semicolon separated CSV file,
+// @Description  with the category, zone and points_door1 to points_doorN columns, N being the doors of the zone with the most doors.
+// @Description  The cells of the doors a zone lacks are empty. The file can be edited in a spreadsheet and imported back.
+// @Tags         competition
+// @Produce      text/csv
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {file}    file    "CSV file with the scales"
+// @Failure      400            {object}  models.ErrorResponse "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse "Internal Server Error"
+// @Router       /competition/{competitionID}/scales/export [get]
+func (s *Server) exportScales(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	csvData, filename, err := s.competitionService.ExportScales(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Header("Content-Length", fmt.Sprintf("%d", len(csvData)))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", csvData)
+}
+
+// importScales godoc
+// @Summary      Import the scales of a competition from CSV or Excel
+// @Description  Imports door points from a CSV or Excel file with the columns of the export, found by their header. Files are read as Excel when named .xlsx or .xls
+// @Description  and as CSV otherwise, comma, semicolon and tab separated CSV files being accepted.
+// @Description  A zone has as many doors as the points filled in from points_door1.
+// @Description  The zones the category already has are updated and the others are created. The whole file is checked first: when a row is invalid nothing is imported.
+// @Tags         competition
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        file           formData  file    true  "CSV or Excel file with the scales"
+// @Success      200            {object}  models.ScaleImportResponse  "Returns the number of scales created and updated"
+// @Failure      400            {object}  models.ErrorResponse "Bad Request (unreadable file, missing columns or invalid row)"
+// @Failure      401            {object}  models.ErrorResponse "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse "A category would exceed the zones of the competition settings"
+// @Failure      500            {object}  models.ErrorResponse "Internal Server Error"
+// @Router       /competition/{competitionID}/scales/import [post]
+func (s *Server) importScales(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	file, fileHeader, err := c.Request.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("file is required"))
+		return
+	}
+	defer file.Close()
+
+	created, updated, err := s.competitionService.ImportScales(c, int32(competitionID), file, fileHeader.Filename)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		case errors.Is(err, serviceErr.ErrTooManyZones):
+			RespondError(c, http.StatusConflict, err)
+		case errors.Is(err, serviceErr.ErrEmptyScaleImport),
+			errors.Is(err, serviceErr.ErrTooManyScaleImportRows),
+			errors.Is(err, serviceErr.ErrScaleImportColumns),
+			errors.Is(err, serviceErr.ErrInvalidScaleImport):
+			RespondError(c, http.StatusBadRequest, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ScaleImportResponse{
+		Created: created,
+		Updated: updated,
+	})
+}
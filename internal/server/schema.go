@@ -0,0 +1,143 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// jsonSchemaDialect is the JSON Schema version of the published schemas
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// liverankingSchemaVersion must be increased whenever models.LiverankingListResponse changes
+	liverankingSchemaVersion = 3
+)
+
+// getPublicSchemas godoc
+// @Summary      Schemas of the published payloads
+// @Description  Lists the JSON Schemas, with an example, of the payloads published to integrators, e.g. the liveranking read with an API key.
+// @Description  The schemas are derived from the payloads the server emits, their version increases with every change.
+// @Tags         public
+// @Produce      json
+// @Success      200  {object}  models.PublishedSchemasResponse  "Returns the published schemas"
+// @Router       /public/schema [get]
+func (s *Server) getPublicSchemas(c *gin.Context) {
+	c.JSON(http.StatusOK, models.PublishedSchemasResponse{
+		Schemas: []models.PublishedSchema{
+			publishedSchema(
+				"liveranking",
+				liverankingSchemaVersion,
+				"GET /competition/{competitionID}/liveranking",
+				models.LiverankingListResponse{
+					CompetitionID: 12,
+					Category:      "U15",
+					Gender:        "F",
+					Page:          1,
+					PageSize:      10,
+					Total:         1,
+					Rankings: []models.LiverankingResponse{{
+						Rank:         1,
+						Dossard:      104,
+						FirstName:    "Jeanne",
+						LastName:     "Martin",
+						Category:     "U15",
+						Gender:       "F",
+						Club:         "Golene Evasion",
+						NumberOfRuns: 3,
+						TotalPoints:  240,
+						Penality:     10,
+						ChronoSec:    185,
+						ChronoMs:     185420,
+						Status:       "registered",
+					}},
+				},
+			),
+		},
+	})
+}
+
+// publishedSchema builds the JSON Schema of the payload from its type, with the payload as example
+func publishedSchema(name string, version int, endpoint string, example interface{}) models.PublishedSchema {
+	schema := jsonSchemaOf(reflect.TypeOf(example))
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = name
+	schema["examples"] = []interface{}{example}
+
+	return models.PublishedSchema{
+		Name:     name,
+		Version:  version,
+		Endpoint: endpoint,
+		Schema:   schema,
+	}
+}
+
+// jsonSchemaOf returns the JSON Schema of the values of the type once encoded with encoding/json.
+// Fields without omitempty are required, unexported fields and fields tagged "-" are skipped.
+func jsonSchemaOf(t reflect.Type) map[string]interface{} {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := jsonSchemaOf(t.Elem())
+		schema["type"] = []interface{}{schema["type"], "null"}
+		return schema
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchemaOf(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := field.Name
+			omitEmpty := false
+			if tag := field.Tag.Get("json"); tag != "" {
+				parts := strings.Split(tag, ",")
+				if parts[0] == "-" {
+					continue
+				}
+				if parts[0] != "" {
+					name = parts[0]
+				}
+				for _, option := range parts[1:] {
+					if option == "omitempty" {
+						omitEmpty = true
+					}
+				}
+			}
+
+			properties[name] = jsonSchemaOf(field.Type)
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+// listMySecurityEvents godoc
+// @Summary      List my security events
+// @Description  Lists the logins, failed logins, password changes and role grants of the authenticated user, most recent first
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        Cookie     header    string  true   "Authentication cookie"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 10)"
+// @Success      200        {object}  models.SecurityEventListResponse  "Returns the security events"
+// @Failure      401        {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      500        {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /me/security-events [get]
+func (s *Server) listMySecurityEvents(c *gin.Context) {
+	user, err := middlewares.GetUser(c)
+	if err != nil || user.Id == 0 {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	page, pageSize := getPagination(c)
+
+	events, total, err := s.userService.ListSecurityEvents(c, user.Id, page, pageSize)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSecurityEventListResponse(events, page, pageSize, total))
+}
+
+// listCompetitionSecurityEvents godoc
+// @Summary      List the security events of a competition
+// @Description  Lists the role grants on the competition and the logins, failed logins and password changes of its admins and referees, most recent first
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        competitionID  path      int     true   "Competition ID"
+// @Param        page           query     int     false  "Page number (default: 1)"
+// @Param        page_size      query     int     false  "Page size (default: 10)"
+// @Success      200            {object}  models.SecurityEventListResponse  "Returns the security events"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden (admin access required)"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/security-events [get]
+func (s *Server) listCompetitionSecurityEvents(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	page, pageSize := getPagination(c)
+
+	events, total, err := s.userService.ListCompetitionSecurityEvents(c, int32(competitionID), page, pageSize)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSecurityEventListResponse(events, page, pageSize, total))
+}
+
+// toSecurityEventListResponse builds a page of security events
+func toSecurityEventListResponse(events []*aggregate.AuthEvent, page, pageSize, total int32) models.SecurityEventListResponse {
+	response := models.SecurityEventListResponse{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+		Events:   make([]models.SecurityEventResponse, 0, len(events)),
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, models.SecurityEventResponse{
+			ID:            event.GetID(),
+			UserID:        event.GetUserID(),
+			Email:         event.GetEmail(),
+			Type:          event.GetType(),
+			CompetitionID: event.GetCompetitionID(),
+			Details:       event.GetDetails(),
+			IP:            event.GetIP(),
+			UserAgent:     event.GetUserAgent(),
+			CreatedAt:     event.GetCreatedAt(),
+		})
+	}
+	return response
+}
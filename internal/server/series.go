@@ -0,0 +1,417 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// createSeries godoc
+// @Summary      Create a series
+// @Description  Creates a series grouping competitions whose earned points add up in one standing, the creator manages it
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string              true  "Authentication cookie"
+// @Param        series  body      models.SeriesInput  true  "Series name"
+// @Success      201     {object}  models.SeriesResponse  "Returns the series"
+// @Failure      400     {object}  models.ErrorResponse   "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse   "Unauthorized"
+// @Failure      403     {object}  models.ErrorResponse   "Forbidden (competition admin access required)"
+// @Failure      500     {object}  models.ErrorResponse   "Internal Server Error"
+// @Router       /series [post]
+func (s *Server) createSeries(c *gin.Context) {
+	var input models.SeriesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := checkIsAnyCompetitionAdmin(c); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	series := aggregate.NewSeries()
+	series.SetName(input.Name)
+	series.SetCreatedBy(user.Id)
+
+	_, err = s.competitionService.CreateSeries(c, series)
+	if err != nil {
+		if errors.Is(err, service.ErrEmptySeriesName) {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSeriesResponse(series))
+}
+
+// listSeries godoc
+// @Summary      List the series
+// @Description  Lists every series with its competitions, the latest created first
+// @Tags         series
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.SeriesListResponse  "Returns the series"
+// @Failure      401     {object}  models.ErrorResponse       "Unauthorized"
+// @Failure      500     {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /series [get]
+func (s *Server) listSeries(c *gin.Context) {
+	seriesList, err := s.competitionService.ListSeries(c)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.SeriesListResponse{
+		Series: make([]models.SeriesResponse, 0, len(seriesList)),
+	}
+	for _, series := range seriesList {
+		response.Series = append(response.Series, toSeriesResponse(series))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getSeries godoc
+// @Summary      Get a series
+// @Description  Returns a series with its competitions, ordered by date
+// @Tags         series
+// @Produce      json
+// @Param        Cookie    header    string  true  "Authentication cookie"
+// @Param        seriesID  path      int     true  "Series ID"
+// @Success      200       {object}  models.SeriesResponse  "Returns the series"
+// @Failure      400       {object}  models.ErrorResponse   "Bad Request"
+// @Failure      401       {object}  models.ErrorResponse   "Unauthorized"
+// @Failure      404       {object}  models.ErrorResponse   "Series not found"
+// @Failure      500       {object}  models.ErrorResponse   "Internal Server Error"
+// @Router       /series/{seriesID} [get]
+func (s *Server) getSeries(c *gin.Context) {
+	seriesID, err := strconv.ParseInt(c.Param("seriesID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid series ID"))
+		return
+	}
+
+	series, err := s.competitionService.GetSeries(c, int32(seriesID))
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(series))
+}
+
+// renameSeries godoc
+// @Summary      Rename a series
+// @Description  Renames a series
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        Cookie    header    string              true  "Authentication cookie"
+// @Param        seriesID  path      int                 true  "Series ID"
+// @Param        series    body      models.SeriesInput  true  "Series name"
+// @Success      200       {object}  models.SeriesResponse  "Returns the series"
+// @Failure      400       {object}  models.ErrorResponse   "Bad Request"
+// @Failure      401       {object}  models.ErrorResponse   "Unauthorized"
+// @Failure      403       {object}  models.ErrorResponse   "Forbidden (only the creator of the series or a super admin)"
+// @Failure      404       {object}  models.ErrorResponse   "Series not found"
+// @Failure      500       {object}  models.ErrorResponse   "Internal Server Error"
+// @Router       /series/{seriesID} [put]
+func (s *Server) renameSeries(c *gin.Context) {
+	seriesID, ok := s.parseManagedSeries(c)
+	if !ok {
+		return
+	}
+
+	var input models.SeriesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	series, err := s.competitionService.RenameSeries(c, seriesID, input.Name)
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(series))
+}
+
+// deleteSeries godoc
+// @Summary      Delete a series
+// @Description  Deletes a series, its competitions are left untouched
+// @Tags         series
+// @Produce      json
+// @Param        Cookie    header    string  true  "Authentication cookie"
+// @Param        seriesID  path      int     true  "Series ID"
+// @Success      204       "Series deleted"
+// @Failure      400       {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401       {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403       {object}  models.ErrorResponse  "Forbidden (only the creator of the series or a super admin)"
+// @Failure      404       {object}  models.ErrorResponse  "Series not found"
+// @Failure      500       {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /series/{seriesID} [delete]
+func (s *Server) deleteSeries(c *gin.Context) {
+	seriesID, ok := s.parseManagedSeries(c)
+	if !ok {
+		return
+	}
+
+	if err := s.competitionService.DeleteSeries(c, seriesID); err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// addCompetitionToSeries godoc
+// @Summary      Add a competition to a series
+// @Description  Adds a competition to a series, its earned points then count in the standings of the series.
+// @Description  The caller must manage the series and administrate the competition.
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        Cookie       header    string                         true  "Authentication cookie"
+// @Param        seriesID     path      int                            true  "Series ID"
+// @Param        competition  body      models.SeriesCompetitionInput  true  "Competition to add"
+// @Success      200          {object}  models.SeriesResponse  "Returns the series"
+// @Failure      400          {object}  models.ErrorResponse   "Bad Request"
+// @Failure      401          {object}  models.ErrorResponse   "Unauthorized"
+// @Failure      403          {object}  models.ErrorResponse   "Forbidden"
+// @Failure      404          {object}  models.ErrorResponse   "Series or competition not found"
+// @Failure      409          {object}  models.ErrorResponse   "The competition is already part of the series"
+// @Failure      500          {object}  models.ErrorResponse   "Internal Server Error"
+// @Router       /series/{seriesID}/competitions [post]
+func (s *Server) addCompetitionToSeries(c *gin.Context) {
+	seriesID, ok := s.parseManagedSeries(c)
+	if !ok {
+		return
+	}
+
+	var input models.SeriesCompetitionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := checkHasAdminAccessToCompetition(c, input.CompetitionID); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	series, err := s.competitionService.AddCompetitionToSeries(c, seriesID, input.CompetitionID)
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(series))
+}
+
+// removeCompetitionFromSeries godoc
+// @Summary      Remove a competition from a series
+// @Description  Removes a competition from a series, by the manager of the series or an admin of the competition
+// @Tags         series
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        seriesID       path      int     true  "Series ID"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.SeriesResponse  "Returns the series"
+// @Failure      400            {object}  models.ErrorResponse   "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse   "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse   "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse   "Series not found or the competition is not part of it"
+// @Failure      500            {object}  models.ErrorResponse   "Internal Server Error"
+// @Router       /series/{seriesID}/competitions/{competitionID} [delete]
+func (s *Server) removeCompetitionFromSeries(c *gin.Context) {
+	seriesID, err := strconv.ParseInt(c.Param("seriesID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid series ID"))
+		return
+	}
+
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	series, err := s.competitionService.GetSeries(c, int32(seriesID))
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	// The admins of a competition can take it out of a series they do not manage
+	if checkCanManageSeries(c, series) != nil {
+		if err := checkHasAdminAccessToCompetition(c, int32(competitionID)); err != nil {
+			RespondError(c, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	series, err = s.competitionService.RemoveCompetitionFromSeries(c, int32(seriesID), int32(competitionID))
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(series))
+}
+
+// getSeriesStandings godoc
+// @Summary      Standings of a series
+// @Description  Ranks the participants by the points they earned over the competitions of the series, as the results export awards them
+// @Description  in each category and gender. Participants are matched across competitions by licence number, or by name and club without one.
+// @Description  Only the competitions where the participant agreed to the processing of their data are counted, tied participants share the same rank.
+// @Tags         public
+// @Produce      json
+// @Param        seriesID  path      int     true   "Series ID"
+// @Param        category  query     string  false  "Only count the points earned in this category"
+// @Param        gender    query     string  false  "Only count the points earned in this gender (H/F)"
+// @Success      200       {object}  models.SeriesStandingListResponse  "Returns the standings"
+// @Failure      400       {object}  models.ErrorResponse               "Bad Request"
+// @Failure      404       {object}  models.ErrorResponse               "Series not found"
+// @Failure      500       {object}  models.ErrorResponse               "Internal Server Error"
+// @Router       /public/series/{seriesID}/standings [get]
+func (s *Server) getSeriesStandings(c *gin.Context) {
+	seriesID, err := strconv.ParseInt(c.Param("seriesID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid series ID"))
+		return
+	}
+
+	series, err := s.competitionService.GetSeries(c, int32(seriesID))
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	category, gender := c.Query("category"), c.Query("gender")
+	standings, computedAt, err := s.competitionService.GetSeriesStandings(c, int32(seriesID), category, gender)
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	response := models.SeriesStandingListResponse{
+		SeriesID:   series.GetID(),
+		Name:       series.GetName(),
+		Category:   category,
+		Gender:     gender,
+		ComputedAt: computedAt,
+		Standings:  make([]models.SeriesStandingResponse, 0, len(standings)),
+	}
+	for _, standing := range standings {
+		entry := models.SeriesStandingResponse{
+			Rank:         standing.GetRank(),
+			FirstName:    standing.GetFirstName(),
+			LastName:     standing.GetLastName(),
+			Club:         standing.GetClub(),
+			Points:       standing.GetPoints(),
+			Competitions: int32(len(standing.GetResults())),
+			Results:      make([]models.SeriesResultResponse, 0, len(standing.GetResults())),
+		}
+		for _, result := range standing.GetResults() {
+			entry.Results = append(entry.Results, models.SeriesResultResponse{
+				CompetitionID: result.CompetitionID,
+				Category:      result.Category,
+				Gender:        result.Gender,
+				Rank:          result.Rank,
+				PointsEarned:  result.PointsEarned,
+			})
+		}
+		response.Standings = append(response.Standings, entry)
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(standingsMaxAge.Seconds())))
+	c.JSON(http.StatusOK, response)
+}
+
+// parseManagedSeries parses the series ID and checks the user manages the series,
+// the response is written when it fails
+func (s *Server) parseManagedSeries(c *gin.Context) (int32, bool) {
+	seriesID, err := strconv.ParseInt(c.Param("seriesID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid series ID"))
+		return 0, false
+	}
+
+	series, err := s.competitionService.GetSeries(c, int32(seriesID))
+	if err != nil {
+		respondSeriesError(c, err)
+		return 0, false
+	}
+
+	if err := checkCanManageSeries(c, series); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return 0, false
+	}
+
+	return int32(seriesID), true
+}
+
+// checkCanManageSeries checks if user created the series or is super admin
+func checkCanManageSeries(c *gin.Context, series *aggregate.Series) error {
+	if middlewares.IsSuperAdmin(c) {
+		return nil
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	if user.Id != series.GetCreatedBy() {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// respondSeriesError maps the errors of the series to their status
+func respondSeriesError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptySeriesName):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrSeriesNotFound),
+		errors.Is(err, repository.ErrSeriesCompetitionNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrCompetitionNotFound):
+		RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+	case errors.Is(err, repository.ErrDuplicateSeriesCompetition):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// toSeriesResponse maps a series to its response
+func toSeriesResponse(series *aggregate.Series) models.SeriesResponse {
+	return models.SeriesResponse{
+		ID:             series.GetID(),
+		Name:           series.GetName(),
+		CreatedBy:      series.GetCreatedBy(),
+		CreatedAt:      series.GetCreatedAt(),
+		CompetitionIDs: series.GetCompetitionIDs(),
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/NiskuT/cross-api/internal/domain/models"
 	"github.com/NiskuT/cross-api/internal/domain/service"
 	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -23,6 +24,14 @@ type Server struct {
 	userService        service.UserService
 	competitionService service.CompetitionService
 	runService         service.RunService
+	apiKeyService      service.APIKeyService
+	auditService       service.AuditService
+	statsService       service.StatsService
+	keySet             *serviceImpl.KeySet
+	urlSigner          *serviceImpl.URLSigner
+	metrics            *serviceImpl.Metrics
+	degradedMode       *serviceImpl.DegradedMode
+	schemaGuard        *serviceImpl.SchemaGuard
 	rateLimiter        *middlewares.RateLimiter
 }
 
@@ -66,6 +75,62 @@ func ServerConfWithRunService(runService service.RunService) ServerConfiguration
 	}
 }
 
+func ServerConfWithAPIKeyService(apiKeyService service.APIKeyService) ServerConfiguration {
+	return func(s *Server) error {
+		s.apiKeyService = apiKeyService
+		return nil
+	}
+}
+
+func ServerConfWithAuditService(auditService service.AuditService) ServerConfiguration {
+	return func(s *Server) error {
+		s.auditService = auditService
+		return nil
+	}
+}
+
+func ServerConfWithStatsService(statsService service.StatsService) ServerConfiguration {
+	return func(s *Server) error {
+		s.statsService = statsService
+		return nil
+	}
+}
+
+func ServerConfWithKeySet(keySet *serviceImpl.KeySet) ServerConfiguration {
+	return func(s *Server) error {
+		s.keySet = keySet
+		return nil
+	}
+}
+
+func ServerConfWithURLSigner(urlSigner *serviceImpl.URLSigner) ServerConfiguration {
+	return func(s *Server) error {
+		s.urlSigner = urlSigner
+		return nil
+	}
+}
+
+func ServerConfWithMetrics(metrics *serviceImpl.Metrics) ServerConfiguration {
+	return func(s *Server) error {
+		s.metrics = metrics
+		return nil
+	}
+}
+
+func ServerConfWithDegradedMode(degradedMode *serviceImpl.DegradedMode) ServerConfiguration {
+	return func(s *Server) error {
+		s.degradedMode = degradedMode
+		return nil
+	}
+}
+
+func ServerConfWithSchemaGuard(schemaGuard *serviceImpl.SchemaGuard) ServerConfiguration {
+	return func(s *Server) error {
+		s.schemaGuard = schemaGuard
+		return nil
+	}
+}
+
 func (s *Server) Start(cfg *config.Config) {
 	router := s.getRouter(cfg)
 	err := router.Run(fmt.Sprintf(":%d", cfg.Service.Port))
@@ -98,52 +163,224 @@ func (s *Server) getRouter(cfg *config.Config) *gin.Engine {
 	// Configure rate limiter with values from config
 	s.rateLimiter.SetLimit("login", cfg.RateLimit.LoginAttempts, cfg.RateLimit.LoginWindow)
 	s.rateLimiter.SetLimit("forgot-password", cfg.RateLimit.ForgotPasswordAttempts, cfg.RateLimit.ForgotPasswordWindow)
+	s.rateLimiter.SetLimit("pin-login", cfg.RateLimit.PinLoginAttempts, cfg.RateLimit.PinLoginWindow)
+	s.rateLimiter.SetLimit("create-run", cfg.RateLimit.RunAttempts, cfg.RateLimit.RunWindow)
+	s.rateLimiter.SetLimit("run-anomaly", cfg.RateLimit.RunAnomalyThreshold, cfg.RateLimit.RunAnomalyWindow)
 
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     cfg.AllowOrigins,
 		AllowMethods:     []string{"POST", "GET", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length", "x-token-refreshed", "x-user-roles", "Content-Disposition", "Content-Type", "Content-Length"},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", middlewares.APIKeyHeader, "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "x-token-refreshed", "x-user-roles", "Content-Disposition", "Content-Type", "Content-Length", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
 	middlewares.SecureMode = cfg.SecureMode
+	middlewares.CookieDomain = cfg.Cookie.Domain
+	middlewares.CookieSameSite = cfg.Cookie.SameSite
 
-	router.MaxMultipartMemory = 5 << 30
+	router.Use(middlewares.SessionClient())
+	router.Use(middlewares.ReadOnly(s.schemaGuard))
+
+	// Uploads over 32 MB are kept in temporary files rather than in memory, the imports read them as a stream
+	router.MaxMultipartMemory = 32 << 20
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Apply rate limiting to authentication endpoints
 	router.PUT("/login", s.rateLimiter.Limit("login"), s.login)
+	router.POST("/login/pin", s.rateLimiter.Limit("pin-login"), s.loginWithRefereePin)
+	router.POST("/login/display", s.loginWithDisplayToken)
 	router.POST("/logout", s.logout)
 	router.POST("/auth/forgot-password", s.rateLimiter.Limit("forgot-password"), s.forgotPassword)
 
-	// Unauthenticated referee invitation acceptance
+	// Login with the OpenID Connect providers
+	router.GET("/auth/oidc/providers", s.listOIDCProviders)
+	router.GET("/auth/oidc/:provider/login", s.oidcLogin)
+	router.GET("/auth/oidc/:provider/callback", s.oidcCallback)
+
+	// Business metrics for the monitoring, authenticated with their own token
+	router.GET("/metrics", s.getMetrics)
+
+	// JSON Schemas of the payloads published to integrators
+	router.GET("/public/schema", s.getPublicSchemas)
+
+	// Season standings published on the organizers' sites
+	router.GET("/public/standings/:season/clubs", s.getClubStandings)
+	router.GET("/public/standings/:season/athletes", s.getAthleteStandings)
+	router.GET("/public/series/:seriesID/standings", s.getSeriesStandings)
+
+	// Competition information shown to spectators
+	router.GET("/public/competition/:competitionID", s.getPublicCompetition)
+
+	// Public keys used to verify the tokens
+	router.GET("/.well-known/jwks.json", s.getJWKS)
+
+	// Unauthenticated invitation acceptance
 	router.POST("/referee/invitation/accept-unauthenticated", s.acceptRefereeInvitationUnauthenticated)
+	router.POST("/admin/invitation/accept-unauthenticated", s.acceptAdminInvitationUnauthenticated)
+
+	// Downloads shared with signed URLs
+	router.GET("/shared/competition/:competitionID/results/export", s.requireSignedURL, s.exportSharedCompetitionResults)
 
-	router.Use(middlewares.Authentication(cfg.Jwt.SecretKey, s.userService))
+	router.Use(middlewares.APIKeyAuthentication(s.apiKeyService))
+	router.Use(middlewares.Authentication(s.keySet.KeyFunc, s.userService))
+	router.Use(middlewares.OrganizationRoles(s.competitionService))
 
 	router.PUT("/auth/password", s.changePassword)
+	router.POST("/auth/logout-all", s.logoutAll)
+	router.GET("/users/search", s.searchUsers)
+	router.POST("/users/import", s.importUsers)
+	router.GET("/users/:userID/roles", s.getUserRoles)
+	router.GET("/me/roles", s.getMyRoles)
+	router.GET("/me/security-events", s.listMySecurityEvents)
+	router.GET("/me/sessions", s.listSessions)
+	router.DELETE("/me/sessions/:sessionID", s.revokeSession)
 	router.POST("/competition", s.createCompetition)
 	router.GET("/competition", s.listCompetitions)
+	router.PUT("/competition/:competitionID", s.updateCompetition)
+	router.DELETE("/competition/:competitionID", s.archiveCompetition)
+	router.POST("/competition/:competitionID/status", s.setCompetitionStatus)
+	router.POST("/competition/:competitionID/clone", s.cloneCompetition)
 	router.POST("/competition/zone", s.addZoneToCompetition)
 	router.PUT("/competition/zone", s.updateZoneInCompetition)
 	router.DELETE("/competition/zone", s.deleteZoneFromCompetition)
 	router.POST("/competition/participants", s.addParticipantsToCompetition)
 	router.POST("/competition/referee", s.addRefereeToCompetition)
+	router.POST("/competition/admin", s.inviteAdmin)
 	router.GET("/competition/:competitionID/referee/invitation", s.generateRefereeInvitationLink)
+	router.POST("/competition/:competitionID/referee/invitations", s.generateRefereeInvitationLinks)
+	router.POST("/competition/:competitionID/referee/:userID/pin", s.generateRefereePin)
+	router.DELETE("/competition/:competitionID/referee/:userID/pin", s.revokeRefereePin)
+	router.GET("/competition/:competitionID/referee/onboarding", s.getRefereeOnboarding)
+	router.PUT("/competition/:competitionID/referee/onboarding", s.updateRefereeOnboarding)
+	router.POST("/competition/:competitionID/referee/onboarding/test-run", s.submitRefereeTestRun)
+	router.GET("/competition/:competitionID/referees/onboarding", s.listRefereeOnboardings)
+	router.POST("/competition/:competitionID/assignments", s.assignRefereeToZone)
+	router.GET("/competition/:competitionID/assignments", s.listRefereeAssignments)
+	router.DELETE("/competition/:competitionID/assignments/:userID/:zone", s.unassignRefereeFromZone)
+	router.POST("/competition/:competitionID/display-devices", s.registerDisplayDevice)
+	router.GET("/competition/:competitionID/display-devices", s.listDisplayDevices)
+	router.DELETE("/competition/:competitionID/display-devices/:deviceID", s.revokeDisplayDevice)
+	router.POST("/competition/:competitionID/observers", s.grantObserver)
+	router.DELETE("/competition/:competitionID/observers/:userID", s.revokeObserver)
+	router.GET("/competition/:competitionID/admin/invitation", s.generateAdminInvitationLink)
+	router.GET("/competition/:competitionID/invitations", s.listInvitations)
+	router.DELETE("/competition/:competitionID/invitations/:invitationID", s.revokeInvitation)
+	router.POST("/competition/:competitionID/invitations/:invitationID/extend", s.extendInvitation)
 	router.POST("/referee/invitation/accept", s.acceptRefereeInvitation)
+	router.POST("/admin/invitation/accept", s.acceptAdminInvitation)
+	router.GET("/participants/history", s.getParticipantHistory)
 	router.GET("/competition/:competitionID/participant/:dossard", s.getParticipant)
-	router.GET("/competition/:competitionID/participants", s.listParticipantsByCategory)
+	router.DELETE("/competition/:competitionID/participant/:dossard", s.deleteParticipant)
+	router.POST("/competition/:competitionID/participants/clear", s.clearParticipants)
+	router.GET("/competition/:competitionID/participants/export", s.exportParticipants)
+	router.POST("/competition/:competitionID/participant/:dossard/renumber", s.renumberParticipant)
+	router.PUT("/competition/:competitionID/participant/:dossard/promote", s.promoteWaitlistedParticipant)
+	router.GET("/competition/:competitionID/waitlist", s.getWaitlist)
+	router.POST("/competition/:competitionID/waitlist/promote", s.promoteWaitlist)
+	router.POST("/competition/:competitionID/participant/:dossard/checkin", s.checkInParticipant)
+	router.DELETE("/competition/:competitionID/participant/:dossard/checkin", s.cancelParticipantCheckIn)
+	router.PUT("/competition/:competitionID/participant/:dossard/notes", s.setParticipantNotes)
+	router.PATCH("/competition/:competitionID/participant/:dossard/status", s.setParticipantStatus)
+	router.POST("/competition/:competitionID/participant/:dossard/photo", s.uploadParticipantPhoto)
+	router.GET("/competition/:competitionID/participant/:dossard/photo", s.getParticipantPhoto)
+	router.GET("/competition/:competitionID/checkin", s.getCheckInSummary)
+	router.GET("/competition/:competitionID/registration", s.getCompetitionRegistration)
+	router.PUT("/competition/:competitionID/registration", s.setCompetitionRegistration)
+	router.GET("/competition/:competitionID/participants", s.listParticipants)
+	router.GET("/competition/:competitionID/participants/duplicates", s.listParticipantDuplicates)
+	router.GET("/competition/:competitionID/participants/search", s.searchParticipantsByName)
 	router.GET("/competition/:competitionID/participant/:dossard/runs", s.getParticipantRuns)
+	router.GET("/competition/:competitionID/participant/:dossard/runs/:runNumber/history", s.getRunHistory)
+	router.GET("/competition/:competitionID/runs", s.listRuns)
+	router.GET("/competition/:competitionID/runs/conflicts", s.listRunConflicts)
+	router.POST("/competition/:competitionID/runs/conflicts/resolve", s.resolveRunConflict)
+	router.POST("/competition/:competitionID/runs/chrono-import", s.importChronos)
+	router.GET("/competition/:competitionID/runs/pending", s.listPendingRuns)
+	router.POST("/competition/:competitionID/runs/pending/:pendingRunID/confirm", s.confirmPendingRun)
+	router.DELETE("/competition/:competitionID/runs/pending/:pendingRunID", s.discardPendingRun)
+	router.GET("/competition/:competitionID/bundle", s.getCompetitionBundle)
+	router.PUT("/competition/:competitionID/time-display", s.updateTimeDisplay)
 	router.GET("/competition/:competitionID/zones", s.listZones)
+	router.GET("/competition/:competitionID/zones/throughput", s.getZoneThroughput)
+	router.GET("/competition/:competitionID/scales/export", s.exportScales)
+	router.POST("/competition/:competitionID/scales/import", s.importScales)
+	router.GET("/competition/:competitionID/settings", s.getCompetitionSettings)
+	router.PUT("/competition/:competitionID/settings", s.updateCompetitionSettings)
+	router.GET("/competition/:competitionID/zones/bounds", s.listZoneBounds)
+	router.PUT("/competition/:competitionID/zones/bounds", s.setZoneBounds)
+	router.POST("/competition/:competitionID/zone-details", s.createZone)
+	router.GET("/competition/:competitionID/zone-details", s.listZoneDetails)
+	router.GET("/competition/:competitionID/zone-details/:zone", s.getZoneDetails)
+	router.PUT("/competition/:competitionID/zone-details/:zone", s.updateZoneDetails)
+	router.DELETE("/competition/:competitionID/zone-details/:zone", s.deleteZoneDetails)
+	router.PATCH("/competition/:competitionID/zone/:zone/state", s.setZoneState)
 	router.GET("/competition/:competitionID/liveranking", s.getLiveranking)
+	router.GET("/competition/:competitionID/liveranking/wait", s.waitLiveranking)
 	router.GET("/competition/:competitionID/results/export", s.exportCompetitionResults)
+	router.POST("/competition/:competitionID/results/export/signed-url", s.signCompetitionResultsExport)
+	router.POST("/competition/:competitionID/results/publish", s.publishResults)
+	router.POST("/competition/:competitionID/club-emails", s.emailClubs)
+	router.PUT("/competition/:competitionID/export-template", s.setExportTemplate)
+	router.GET("/competition/:competitionID/export-template", s.getExportTemplate)
+	router.DELETE("/competition/:competitionID/export-template", s.deleteExportTemplate)
+	router.POST("/competition/:competitionID/categories", s.addCategory)
+	router.GET("/competition/:competitionID/categories", s.listCategories)
+	router.PUT("/competition/:competitionID/categories/:categoryID", s.updateCategory)
+	router.DELETE("/competition/:competitionID/categories/:categoryID", s.deleteCategory)
+	router.POST("/competition/:competitionID/clubs", s.addClub)
+	router.GET("/competition/:competitionID/clubs", s.listClubs)
+	router.PUT("/competition/:competitionID/clubs/:clubID", s.updateClub)
+	router.DELETE("/competition/:competitionID/clubs/:clubID", s.deleteClub)
+	router.GET("/competition/:competitionID/clubranking", s.getClubRanking)
+	router.POST("/competition/:competitionID/teams", s.addTeam)
+	router.GET("/competition/:competitionID/teams", s.listTeams)
+	router.PUT("/competition/:competitionID/teams/:teamID", s.updateTeam)
+	router.DELETE("/competition/:competitionID/teams/:teamID", s.deleteTeam)
+	router.GET("/competition/:competitionID/teamranking", s.getTeamRanking)
+	router.POST("/competition/:competitionID/contacts", s.addCompetitionContact)
+	router.GET("/competition/:competitionID/contacts", s.listCompetitionContacts)
+	router.DELETE("/competition/:competitionID/contacts/:contactID", s.deleteCompetitionContact)
+	router.GET("/competition/:competitionID/audit-logs", s.listAuditLogs)
+	router.GET("/competition/:competitionID/security-events", s.listCompetitionSecurityEvents)
+	router.POST("/competition/:competitionID/apikeys", s.createAPIKey)
+	router.GET("/competition/:competitionID/apikeys", s.listAPIKeys)
+	router.DELETE("/competition/:competitionID/apikeys/:keyID", s.revokeAPIKey)
 	router.POST("/participant", s.createParticipant)
-	router.POST("/run", s.createRun)
+	router.POST("/run", s.rateLimiter.LimitByUser("create-run"), s.createRun)
 	router.PUT("/run", s.updateRun)
 	router.DELETE("/run", s.deleteRun)
+	router.POST("/run/void", s.voidRun)
+	router.POST("/run/approve", s.approveRun)
+	router.GET("/run/receipt/:code", s.getRunByReceipt)
+	router.POST("/series", s.createSeries)
+	router.GET("/series", s.listSeries)
+	router.GET("/series/:seriesID", s.getSeries)
+	router.PUT("/series/:seriesID", s.renameSeries)
+	router.DELETE("/series/:seriesID", s.deleteSeries)
+	router.POST("/series/:seriesID/competitions", s.addCompetitionToSeries)
+	router.DELETE("/series/:seriesID/competitions/:competitionID", s.removeCompetitionFromSeries)
+	router.GET("/organizations", s.listOrganizations)
+	router.GET("/organizations/:organizationID", s.getOrganization)
+	router.POST("/organizations/:organizationID/admins", s.addOrganizationAdmin)
+	router.DELETE("/organizations/:organizationID/admins/:userID", s.removeOrganizationAdmin)
+	router.PUT("/competition/:competitionID/organization", s.setCompetitionOrganization)
+
+	// Super admin endpoints
+	admin := router.Group("/admin", middlewares.RequireSuperAdmin())
+	admin.POST("/roles/prune", s.pruneRoles)
+	admin.GET("/users", s.listAllUsers)
+	admin.POST("/users/:userID/impersonate", s.impersonateUser)
+	admin.DELETE("/competition/:competitionID", s.deleteCompetition)
+	admin.GET("/stats", s.getSystemStats)
+	admin.GET("/rate-limits", s.getRateLimits)
+	admin.GET("/degraded-mode", s.getDegradedMode)
+	admin.PUT("/degraded-mode", s.setDegradedMode)
+	admin.GET("/schema", s.getSchemaStatus)
+	admin.POST("/organizations", s.createOrganization)
+	admin.DELETE("/organizations/:organizationID", s.deleteOrganization)
 	return router
 }
 
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+// listSessions godoc
+// @Summary      List my sessions
+// @Description  Lists the active sessions of the authenticated user with the device, IP address and last use of each one
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.SessionListResponse  "Returns the active sessions"
+// @Failure      401     {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      500     {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /me/sessions [get]
+func (s *Server) listSessions(c *gin.Context) {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	sessions, err := s.userService.ListSessions(c, user.Id)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.SessionListResponse{
+		Sessions: make([]models.SessionResponse, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, models.SessionResponse{
+			ID:         session.GetID(),
+			UserAgent:  session.GetUserAgent(),
+			IP:         session.GetIP(),
+			CreatedAt:  session.GetCreatedAt(),
+			LastUsedAt: session.GetLastUsedAt(),
+			ExpiresAt:  session.GetExpiresAt(),
+			Current:    session.GetID() == user.SessionID,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// revokeSession godoc
+// @Summary      Revoke one of my sessions
+// @Description  Revokes a session of the authenticated user, for instance on a lost device. The device can no longer refresh its tokens and is logged out when its access token expires.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        Cookie     header    string  true  "Authentication cookie"
+// @Param        sessionID  path      string  true  "Session ID"
+// @Success      200        {object}  gin.H                 "Returns success message"
+// @Failure      401        {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      404        {object}  models.ErrorResponse  "Session not found"
+// @Failure      500        {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /me/sessions/{sessionID} [delete]
+func (s *Server) revokeSession(c *gin.Context) {
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	err = s.userService.RevokeSession(c, user.Id, c.Param("sessionID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	serviceImpl "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSignedURLLifetime is the validity of signed URLs when none is requested
+const defaultSignedURLLifetime = 24 * time.Hour
+
+// signCompetitionResultsExport godoc
+// @Summary      Share the results export
+// @Description  Signs a URL of the results export that can be downloaded without being authenticated until it expires,
+// @Description  e.g. by federation staff. The URL is relative to the API URL.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie        header    string                 true   "Authentication cookie"
+// @Param        competitionID path      int                    true   "Competition ID"
+// @Param        input         body      models.SignedURLInput  false  "Lifetime in minutes (default: 1440)"
+// @Success      200           {object}  models.SignedURLResponse "Returns the signed URL"
+// @Failure      400           {object}  models.ErrorResponse "Bad Request"
+// @Failure      401           {object}  models.ErrorResponse "Unauthorized"
+// @Failure      403           {object}  models.ErrorResponse "Forbidden (admin access required)"
+// @Failure      500           {object}  models.ErrorResponse "Internal Server Error"
+// @Failure      503           {object}  models.ErrorResponse "No SIGNED_URL_SECRET configured"
+// @Router       /competition/{competitionID}/results/export/signed-url [post]
+func (s *Server) signCompetitionResultsExport(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	// The body is optional, the default lifetime applies without it
+	var signInput models.SignedURLInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&signInput); err != nil {
+			RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// Check if user has admin access to the competition
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	lifetime := time.Duration(signInput.LifetimeMinutes) * time.Minute
+	if signInput.LifetimeMinutes == 0 {
+		lifetime = defaultSignedURLLifetime
+		if lifetime > s.urlSigner.MaxLifetime() {
+			lifetime = s.urlSigner.MaxLifetime()
+		}
+	}
+
+	path := fmt.Sprintf("/shared/competition/%d/results/export", competitionID)
+	signedURL, expiresAt, err := s.urlSigner.Sign(path, lifetime)
+	if err != nil {
+		if errors.Is(err, serviceImpl.ErrInvalidSignedURLLifetime) {
+			RespondError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, serviceImpl.ErrSignedURLsDisabled) {
+			RespondError(c, http.StatusServiceUnavailable, err)
+		} else {
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SignedURLResponse{
+		URL:       signedURL,
+		ExpiresAt: expiresAt.Unix(),
+	})
+}
+
+// exportSharedCompetitionResults godoc
+// @Summary      Download a shared results export
+// @Description  Exports the competition results to an Excel file, authenticated by the signature of the URL
+// @Tags         competition
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        competitionID path      int     true   "Competition ID"
+// @Param        expires       query     int     true   "Expiry of the URL (Unix time)"
+// @Param        signature     query     string  true   "Signature of the URL"
+// @Param        consent       query     string  false  "Only export the participants who gave this consent (data_processing or photo_rights)"
+// @Success      200           {file}    file    "Excel file with competition results"
+// @Failure      400           {object}  models.ErrorResponse "Bad Request"
+// @Failure      403           {object}  models.ErrorResponse "Forbidden (invalid or expired signature)"
+// @Failure      404           {object}  models.ErrorResponse "Competition not found"
+// @Failure      500           {object}  models.ErrorResponse "Internal Server Error"
+// @Router       /shared/competition/{competitionID}/results/export [get]
+func (s *Server) exportSharedCompetitionResults(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	s.sendCompetitionResults(c, int32(competitionID))
+}
+
+// requireSignedURL rejects the requests whose path is not signed or whose signature expired
+func (s *Server) requireSignedURL(c *gin.Context) {
+	if err := s.urlSigner.Verify(c.Request.URL.Path, c.Query("expires"), c.Query("signature")); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
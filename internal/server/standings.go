@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	service "github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// standingsMaxAge is how long the sites publishing the standings may cache them
+const standingsMaxAge = 5 * time.Minute
+
+// getClubStandings godoc
+// @Summary      Club standings of a season
+// @Description  Ranks the clubs by the points their athletes earned over the competitions of the season, tied clubs share the same rank
+// @Tags         public
+// @Produce      json
+// @Param        season  path      int  true  "Season year"
+// @Success      200     {object}  models.SeasonStandingListResponse  "Returns the club standings"
+// @Failure      400     {object}  models.ErrorResponse  "Invalid season"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router       /public/standings/{season}/clubs [get]
+func (s *Server) getClubStandings(c *gin.Context) {
+	s.respondSeasonStandings(c, s.statsService.GetClubStandings)
+}
+
+// getAthleteStandings godoc
+// @Summary      Athlete standings of a season
+// @Description  Ranks the athletes by the points they earned over the competitions of the season, tied athletes share the same rank.
+// @Description  Only the competitions where the athlete agreed to the processing of their data are counted.
+// @Tags         public
+// @Produce      json
+// @Param        season  path      int  true  "Season year"
+// @Success      200     {object}  models.SeasonStandingListResponse  "Returns the athlete standings"
+// @Failure      400     {object}  models.ErrorResponse  "Invalid season"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router       /public/standings/{season}/athletes [get]
+func (s *Server) getAthleteStandings(c *gin.Context) {
+	s.respondSeasonStandings(c, s.statsService.GetAthleteStandings)
+}
+
+// respondSeasonStandings responds with the standings of the season of the path returned by getStandings
+func (s *Server) respondSeasonStandings(
+	c *gin.Context,
+	getStandings func(ctx context.Context, season int) ([]*aggregate.SeasonStanding, time.Time, error),
+) {
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid season"))
+		return
+	}
+
+	standings, computedAt, err := getStandings(c, season)
+	if errors.Is(err, service.ErrInvalidSeason) {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.SeasonStandingListResponse{
+		Season:     season,
+		ComputedAt: computedAt,
+		Standings:  make([]models.SeasonStandingResponse, 0, len(standings)),
+	}
+	for _, standing := range standings {
+		response.Standings = append(response.Standings, models.SeasonStandingResponse{
+			Rank:         standing.GetRank(),
+			Name:         standing.GetName(),
+			Club:         standing.GetClub(),
+			Points:       standing.GetPoints(),
+			Competitions: standing.GetCompetitions(),
+		})
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(standingsMaxAge.Seconds())))
+	c.JSON(http.StatusOK, response)
+}
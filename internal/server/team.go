@@ -0,0 +1,334 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// addTeam godoc
+// @Summary      Add a team
+// @Description  Groups 3 or 4 registered participants of the competition into a named team, a participant belonging to one team at most
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string            true  "Authentication cookie"
+// @Param        competitionID  path      int               true  "Competition ID"
+// @Param        team           body      models.TeamInput  true  "Team data"
+// @Success      201            {object}  models.TeamResponse   "Returns the created team"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Competition or participant not found"
+// @Failure      409            {object}  models.ErrorResponse  "Team already exists or participant in another team"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/teams [post]
+func (s *Server) addTeam(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.TeamInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	team := aggregate.NewTeam()
+	team.SetCompetitionID(int32(competitionID))
+	team.SetName(input.Name)
+	team.SetDossards(input.Dossards)
+
+	err = s.competitionService.AddTeam(c, team)
+	if err != nil {
+		respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTeamResponse(team))
+}
+
+// listTeams godoc
+// @Summary      List teams
+// @Description  Lists the teams of the competition by name with the dossards of their members
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.TeamListResponse  "Returns the teams"
+// @Failure      400            {object}  models.ErrorResponse     "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse     "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse     "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse     "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse     "Internal Server Error"
+// @Router       /competition/{competitionID}/teams [get]
+func (s *Server) listTeams(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	teams, err := s.competitionService.ListTeams(c, int32(competitionID))
+	if err != nil {
+		respondTeamError(c, err)
+		return
+	}
+
+	response := models.TeamListResponse{
+		CompetitionID: int32(competitionID),
+		Teams:         make([]models.TeamResponse, 0, len(teams)),
+	}
+	for _, team := range teams {
+		response.Teams = append(response.Teams, toTeamResponse(team))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// updateTeam godoc
+// @Summary      Change a team
+// @Description  Renames a team of the competition and replaces its members
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string            true  "Authentication cookie"
+// @Param        competitionID  path      int               true  "Competition ID"
+// @Param        teamID         path      int               true  "Team ID"
+// @Param        team           body      models.TeamInput  true  "Team data"
+// @Success      200            {object}  models.TeamResponse   "Returns the changed team"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Team or participant not found"
+// @Failure      409            {object}  models.ErrorResponse  "Team already exists or participant in another team"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/teams/{teamID} [put]
+func (s *Server) updateTeam(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid team ID"))
+		return
+	}
+
+	var input models.TeamInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	team := aggregate.NewTeam()
+	team.SetID(int32(teamID))
+	team.SetCompetitionID(int32(competitionID))
+	team.SetName(input.Name)
+	team.SetDossards(input.Dossards)
+
+	err = s.competitionService.UpdateTeam(c, team)
+	if err != nil {
+		respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTeamResponse(team))
+}
+
+// deleteTeam godoc
+// @Summary      Delete a team
+// @Description  Removes a team from the competition, its members stay registered
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        teamID         path      int     true  "Team ID"
+// @Success      204            "Team removed"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Team not found"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/teams/{teamID} [delete]
+func (s *Server) deleteTeam(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid team ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.competitionService.DeleteTeam(c, int32(competitionID), int32(teamID))
+	if err != nil {
+		respondTeamError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getTeamRanking godoc
+// @Summary      Get the team ranking
+// @Description  Ranks the teams of the competition on the sum of the best liveranking scores of their members, members without run
+// @Description  scoring nothing. Teams with the same points share their rank. Scoreboard displays only get the names of the members who
+// @Description  consented to the processing of their data, the others are shown as Anonyme.
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie           header    string  false  "Authentication cookie"
+// @Param        competitionID    path      int     true   "Competition ID"
+// @Param        counted_members  query     int     false  "Number of best member scores added up (default: 3, max: 4)"
+// @Param        X-Api-Key        header    string  false  "API key with the read-liveranking scope, replaces the cookie"
+// @Success      200              {object}  models.TeamRankingListResponse  "Returns the team ranking"
+// @Failure      400              {object}  models.ErrorResponse            "Bad Request"
+// @Failure      401              {object}  models.ErrorResponse            "Unauthorized (invalid credentials)"
+// @Failure      403              {object}  models.ErrorResponse            "Forbidden"
+// @Failure      404              {object}  models.ErrorResponse            "Competition not found"
+// @Failure      500              {object}  models.ErrorResponse            "Internal Server Error"
+// @Router       /competition/{competitionID}/teamranking [get]
+func (s *Server) getTeamRanking(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	countedMembers := int64(service.DefaultTeamCountedMembers)
+	if value := c.Query("counted_members"); value != "" {
+		countedMembers, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || countedMembers <= 0 {
+			RespondError(c, http.StatusBadRequest, service.ErrInvalidTeamCountedMembers)
+			return
+		}
+	}
+
+	// Same access as the liveranking it is shown alongside
+	err = checkHasAdminOrObserverAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		err = checkHasAPIKeyScope(c, aggregate.APIKeyScopeReadLiveranking, int32(competitionID))
+	}
+	// Scoreboard displays are public, they only show who consented to the processing of their data
+	anonymize := false
+	if err != nil {
+		err = checkHasDisplayAccess(c, int32(competitionID))
+		anonymize = err == nil
+	}
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	rankings, err := s.competitionService.GetTeamRanking(c, int32(competitionID), int32(countedMembers))
+	if err != nil {
+		respondTeamError(c, err)
+		return
+	}
+
+	response := models.TeamRankingListResponse{
+		CompetitionID:  int32(competitionID),
+		CountedMembers: int32(countedMembers),
+		Rankings:       make([]models.TeamRankingResponse, 0, len(rankings)),
+	}
+	for _, ranking := range rankings {
+		rankingResponse := models.TeamRankingResponse{
+			Rank:        ranking.GetRank(),
+			TeamID:      ranking.GetTeam().GetID(),
+			Team:        ranking.GetTeam().GetName(),
+			TotalPoints: ranking.GetTotalPoints(),
+			Members:     make([]models.TeamMemberScoreResponse, 0, len(ranking.GetMembers())),
+		}
+		for _, member := range ranking.GetMembers() {
+			participant := member.GetParticipant()
+			memberResponse := models.TeamMemberScoreResponse{
+				DossardNumber: participant.GetDossardNumber(),
+				FirstName:     participant.GetFirstName(),
+				LastName:      participant.GetLastName(),
+				Category:      participant.GetCategory(),
+				Gender:        participant.GetGender(),
+				TotalPoints:   member.GetTotalPoints(),
+				NumberOfRuns:  member.GetNumberOfRuns(),
+				Counted:       member.IsCounted(),
+			}
+			if anonymize && !participant.HasConsent(aggregate.ConsentDataProcessing) {
+				memberResponse.FirstName = ""
+				memberResponse.LastName = service.AnonymousParticipantName
+			}
+			rankingResponse.Members = append(rankingResponse.Members, memberResponse)
+		}
+		response.Rankings = append(response.Rankings, rankingResponse)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondTeamError responds with the status matching an error of the team endpoints
+func respondTeamError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptyTeamName),
+		errors.Is(err, service.ErrInvalidTeamSize),
+		errors.Is(err, service.ErrDuplicateTeamMember),
+		errors.Is(err, service.ErrWaitlistedTeamMember),
+		errors.Is(err, service.ErrInvalidTeamCountedMembers):
+		RespondError(c, http.StatusBadRequest, err)
+	case errors.Is(err, repository.ErrCompetitionNotFound),
+		errors.Is(err, repository.ErrTeamNotFound),
+		errors.Is(err, repository.ErrParticipantNotFound):
+		RespondError(c, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrDuplicateTeam), errors.Is(err, repository.ErrParticipantInOtherTeam):
+		RespondError(c, http.StatusConflict, err)
+	default:
+		RespondError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// toTeamResponse builds the response describing a team
+func toTeamResponse(team *aggregate.Team) models.TeamResponse {
+	dossards := team.GetDossards()
+	if dossards == nil {
+		dossards = []int32{}
+	}
+	return models.TeamResponse{
+		ID:       team.GetID(),
+		Name:     team.GetName(),
+		Dossards: dossards,
+	}
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getCompetitionBundle godoc
+// @Summary      Get competition bundle
+// @Description  Returns the competition, its chrono display preferences and its zones in one call, for clients loading a competition
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.CompetitionBundleResponse  "Returns the competition bundle"
+// @Failure      400            {object}  models.ErrorResponse              "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse              "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse              "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse              "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse              "Internal Server Error"
+// @Router       /competition/{competitionID}/bundle [get]
+func (s *Server) getCompetitionBundle(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.GetCompetition(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	zones, err := s.competitionService.ListZones(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	zoneResponses, err := s.toZoneResponses(c, int32(competitionID), zones)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CompetitionBundleResponse{
+		Competition: models.CompetitionResponse{
+			ID:          competition.GetID(),
+			Name:        competition.GetName(),
+			Description: competition.GetDescription(),
+			Date:        competition.GetLocalDate().Format(time.RFC3339),
+			Timezone:    competition.GetTimezone(),
+			Location:    competition.GetLocation(),
+			Organizer:   competition.GetOrganizer(),
+			Contact:     competition.GetContact(),
+			Status:      competition.GetStatus(),
+
+			OrganizationID: competition.GetOrganizationID(),
+		},
+		TimeDisplay: toTimeDisplayResponse(competition),
+		Zones:       zoneResponses,
+	})
+}
+
+// updateTimeDisplay godoc
+// @Summary      Update chrono display preferences
+// @Description  Sets how the chronos of the competition are displayed and entered (mm:ss, seconds or milliseconds) and whether the chrono counts up or down
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                   true  "Authentication cookie"
+// @Param        competitionID  path      int                      true  "Competition ID"
+// @Param        preferences    body      models.TimeDisplayInput  true  "Chrono display preferences"
+// @Success      200            {object}  models.TimeDisplayResponse  "Returns the updated preferences"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse        "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/time-display [put]
+func (s *Server) updateTimeDisplay(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.TimeDisplayInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	competition, err := s.competitionService.UpdateTimeDisplay(c, int32(competitionID), input.ChronoFormat, input.ChronoDirection)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidChronoFormat), errors.Is(err, service.ErrInvalidChronoDirection):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toTimeDisplayResponse(competition))
+}
+
+// toTimeDisplayResponse builds the chrono display preferences of a competition
+func toTimeDisplayResponse(competition *aggregate.Competition) models.TimeDisplayResponse {
+	return models.TimeDisplayResponse{
+		ChronoFormat:    competition.GetChronoFormat(),
+		ChronoDirection: competition.GetChronoDirection(),
+	}
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/server/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+// minUserSearchLength avoids listing the whole user table with one or two letters
+const minUserSearchLength = 3
+
+// searchUsers godoc
+// @Summary      Search users
+// @Description  Searches users by first name, last name or email, e.g. to add an existing user as referee. Only competition admins can search, and only identity fields are returned.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        Cookie     header    string  true   "Authentication cookie"
+// @Param        q          query     string  true   "Text contained in the name or email (at least 3 characters)"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 10)"
+// @Success      200        {object}  models.UserSearchResponse  "Returns matching users"
+// @Failure      400        {object}  models.ErrorResponse       "Bad Request"
+// @Failure      401        {object}  models.ErrorResponse       "Unauthorized (invalid credentials)"
+// @Failure      403        {object}  models.ErrorResponse       "Forbidden (admin access required)"
+// @Failure      500        {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /users/search [get]
+func (s *Server) searchUsers(c *gin.Context) {
+	err := checkIsAnyCompetitionAdmin(c)
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if len([]rune(query)) < minUserSearchLength {
+		RespondError(c, http.StatusBadRequest, errors.New("query must contain at least 3 characters"))
+		return
+	}
+
+	page, pageSize := getPagination(c)
+	if pageSize > 50 {
+		pageSize = 50
+	}
+
+	users, total, err := s.userService.SearchUsers(c, query, page, pageSize)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserSearchResponse(query, page, pageSize, total, users))
+}
+
+// toUserSearchResponse builds a page of users, only their identity fields are returned
+func toUserSearchResponse(query string, page, pageSize, total int32, users []*aggregate.User) models.UserSearchResponse {
+	response := models.UserSearchResponse{
+		Query:    query,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+		Users:    make([]models.UserSearchResult, 0, len(users)),
+	}
+	for _, user := range users {
+		response.Users = append(response.Users, models.UserSearchResult{
+			ID:        user.GetID(),
+			FirstName: user.GetFirstName(),
+			LastName:  user.GetLastName(),
+			Email:     user.GetEmail(),
+		})
+	}
+	return response
+}
+
+// importUsers godoc
+// @Summary      Import users
+// @Description  Creates accounts in bulk from a CSV file of first name, last name and email, with an optional header row, and emails them their credentials.
+// @Description  With a competition ID, the accounts are given its referee role and existing accounts are added to it, otherwise existing accounts are skipped.
+// @Description  Every row is processed, the report gives the outcome of each of them.
+// @Tags         user
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Cookie         header    string  true   "Authentication cookie"
+// @Param        file           formData  file    true   "CSV file with first name, last name, email columns"
+// @Param        competitionID  formData  int     false  "Competition the accounts referee"
+// @Success      200            {object}  models.UserImportResponse  "Returns the outcome of every row"
+// @Failure      400            {object}  models.ErrorResponse       "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse       "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse       "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse       "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /users/import [post]
+func (s *Server) importUsers(c *gin.Context) {
+	var competition *aggregate.Competition
+	if competitionIDStr := c.PostForm("competitionID"); competitionIDStr != "" {
+		competitionID, err := strconv.ParseInt(competitionIDStr, 10, 32)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID format"))
+			return
+		}
+
+		err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+		if err != nil {
+			RespondError(c, http.StatusForbidden, err)
+			return
+		}
+
+		competition, err = s.competitionService.GetCompetition(c, int32(competitionID))
+		if err != nil {
+			if errors.Is(err, repository.ErrCompetitionNotFound) {
+				RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+				return
+			}
+			RespondError(c, http.StatusInternalServerError, err)
+			return
+		}
+	} else if err := checkIsAnyCompetitionAdmin(c); err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("file is required"))
+		return
+	}
+	defer file.Close()
+
+	report, err := s.userService.ImportUsers(c, file, competition)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	response := models.UserImportResponse{
+		Created:    report.Count(aggregate.UserImportCreated),
+		RolesAdded: report.Count(aggregate.UserImportRoleAdded),
+		Skipped:    report.Count(aggregate.UserImportSkipped),
+		Failed:     report.Count(aggregate.UserImportFailed),
+		Rows:       make([]models.UserImportRowResponse, 0, len(report.GetRows())),
+	}
+	for _, row := range report.GetRows() {
+		response.Rows = append(response.Rows, models.UserImportRowResponse{
+			Row:     row.GetRow(),
+			Email:   row.GetEmail(),
+			Status:  row.GetStatus(),
+			Message: row.GetMessage(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getMyRoles godoc
+// @Summary      List my roles
+// @Description  Lists the roles of the authenticated user grouped per competition
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Success      200     {object}  models.UserRolesResponse  "Returns the roles of the user"
+// @Failure      401     {object}  models.ErrorResponse      "Unauthorized (invalid credentials)"
+// @Failure      500     {object}  models.ErrorResponse      "Internal Server Error"
+// @Router       /me/roles [get]
+func (s *Server) getMyRoles(c *gin.Context) {
+	user, err := middlewares.GetUser(c)
+	if err != nil || user.Id == 0 {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	roles, err := s.userService.ListUserRoles(c, user.Id)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserRolesResponse(user.Id, roles))
+}
+
+// getUserRoles godoc
+// @Summary      List the roles of a user
+// @Description  Lists the roles of a user grouped per competition. Super admins see every role, competition admins only see the roles on the competitions they administer.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        Cookie  header    string  true  "Authentication cookie"
+// @Param        userID  path      int     true  "User ID"
+// @Success      200     {object}  models.UserRolesResponse  "Returns the roles of the user"
+// @Failure      400     {object}  models.ErrorResponse      "Bad Request"
+// @Failure      401     {object}  models.ErrorResponse      "Unauthorized (invalid credentials)"
+// @Failure      403     {object}  models.ErrorResponse      "Forbidden (admin access required)"
+// @Failure      404     {object}  models.ErrorResponse      "User not found"
+// @Failure      500     {object}  models.ErrorResponse      "Internal Server Error"
+// @Router       /users/{userID}/roles [get]
+func (s *Server) getUserRoles(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := middlewares.GetUser(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	seeAll := user.Id == int32(userID) || middlewares.IsSuperAdmin(c)
+	if !seeAll {
+		if err := checkIsAnyCompetitionAdmin(c); err != nil {
+			RespondError(c, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	roles, err := s.userService.ListUserRoles(c, int32(userID))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !seeAll {
+		visibleRoles := make([]*aggregate.UserRole, 0, len(roles))
+		for _, role := range roles {
+			if !role.IsGlobal() && middlewares.HasRole(c, fmt.Sprintf("admin:%d", role.GetCompetitionID())) {
+				visibleRoles = append(visibleRoles, role)
+			}
+		}
+		roles = visibleRoles
+	}
+
+	c.JSON(http.StatusOK, toUserRolesResponse(int32(userID), roles))
+}
+
+// toUserRolesResponse groups the roles of a user per competition
+func toUserRolesResponse(userID int32, roles []*aggregate.UserRole) models.UserRolesResponse {
+	response := models.UserRolesResponse{
+		UserID:       userID,
+		GlobalRoles:  []string{},
+		Competitions: []models.CompetitionRolesResponse{},
+	}
+
+	competitionIndex := make(map[int32]int)
+	for _, role := range roles {
+		if role.IsGlobal() {
+			response.GlobalRoles = append(response.GlobalRoles, role.GetRole())
+			continue
+		}
+
+		i, exists := competitionIndex[role.GetCompetitionID()]
+		if !exists {
+			i = len(response.Competitions)
+			competitionIndex[role.GetCompetitionID()] = i
+			response.Competitions = append(response.Competitions, models.CompetitionRolesResponse{
+				CompetitionID: role.GetCompetitionID(),
+				Roles:         []string{},
+			})
+		}
+		response.Competitions[i].Roles = append(response.Competitions[i].Roles, role.GetName())
+	}
+
+	return response
+}
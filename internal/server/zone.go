@@ -0,0 +1,355 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// createZone godoc
+// @Summary      Describe a zone
+// @Description  Describes a zone of a competition with a description, where it is on the course, its GPS coordinates and a photo.
+// @Description  A zone can be described before its scales are added, the scales reference it by its name.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                  true  "Authentication cookie"
+// @Param        competitionID  path      int                     true  "Competition ID"
+// @Param        zone           body      models.ZoneCreateInput  true  "Description of the zone"
+// @Success      201            {object}  models.ZoneDetailsResponse  "Returns the description of the zone"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request (invalid coordinates or photo URL)"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse        "Competition not found"
+// @Failure      409            {object}  models.ErrorResponse        "The zone is already described"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/zone-details [post]
+func (s *Server) createZone(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.ZoneCreateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	zone, err := zoneFromInput(int32(competitionID), input.Zone, input.ZoneDetailsInput)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = s.competitionService.CreateZone(c, zone)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyZoneName),
+			errors.Is(err, service.ErrInvalidZoneCoordinates),
+			errors.Is(err, service.ErrInvalidZonePhotoURL):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		case errors.Is(err, repository.ErrDuplicateZone):
+			RespondError(c, http.StatusConflict, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toZoneDetailsResponse(zone))
+}
+
+// listZoneDetails godoc
+// @Summary      List the descriptions of the zones
+// @Description  Lists the descriptions of the zones of a competition by name, including the zones without scales yet
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.ZoneDetailsListResponse  "Returns the descriptions of the zones"
+// @Failure      400            {object}  models.ErrorResponse            "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse            "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse            "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse            "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse            "Internal Server Error"
+// @Router       /competition/{competitionID}/zone-details [get]
+func (s *Server) listZoneDetails(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	zones, err := s.competitionService.ListZoneDetails(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.ZoneDetailsListResponse{
+		CompetitionID: int32(competitionID),
+		Zones:         make([]models.ZoneDetailsResponse, 0, len(zones)),
+	}
+	for _, zone := range zones {
+		response.Zones = append(response.Zones, toZoneDetailsResponse(zone))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getZoneDetails godoc
+// @Summary      Get the description of a zone
+// @Description  Returns the description of a zone of a competition
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        zone           path      string  true  "Zone name"
+// @Success      200            {object}  models.ZoneDetailsResponse  "Returns the description of the zone"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse        "Zone not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/zone-details/{zone} [get]
+func (s *Server) getZoneDetails(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	zone, err := s.competitionService.GetZone(c, int32(competitionID), c.Param("zone"))
+	if err != nil {
+		if errors.Is(err, repository.ErrZoneNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toZoneDetailsResponse(zone))
+}
+
+// updateZoneDetails godoc
+// @Summary      Update the description of a zone
+// @Description  Replaces the description, location, GPS coordinates and photo of a zone. Its name cannot change as the scales reference it by name.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                   true  "Authentication cookie"
+// @Param        competitionID  path      int                      true  "Competition ID"
+// @Param        zone           path      string                   true  "Zone name"
+// @Param        details        body      models.ZoneDetailsInput  true  "Description of the zone"
+// @Success      200            {object}  models.ZoneDetailsResponse  "Returns the description of the zone"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request (invalid coordinates or photo URL)"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse        "Zone not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/zone-details/{zone} [put]
+func (s *Server) updateZoneDetails(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.ZoneDetailsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	zone, err := zoneFromInput(int32(competitionID), c.Param("zone"), input)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = s.competitionService.UpdateZone(c, zone)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyZoneName),
+			errors.Is(err, service.ErrInvalidZoneCoordinates),
+			errors.Is(err, service.ErrInvalidZonePhotoURL):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrZoneNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toZoneDetailsResponse(zone))
+}
+
+// setZoneState godoc
+// @Summary      Open or close a zone
+// @Description  Opens or closes a zone of a competition to new runs. Once the judging of a zone ends, closing it rejects the late or erroneous runs submitted to it.
+// @Description  Runs already recorded in the zone can still be corrected by the admins.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                 true  "Authentication cookie"
+// @Param        competitionID  path      int                    true  "Competition ID"
+// @Param        zone           path      string                 true  "Zone name"
+// @Param        state          body      models.ZoneStateInput  true  "Whether the zone is open"
+// @Success      200            {object}  models.ZoneDetailsResponse  "Returns the zone"
+// @Failure      400            {object}  models.ErrorResponse        "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse        "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse        "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse        "Zone not found"
+// @Failure      500            {object}  models.ErrorResponse        "Internal Server Error"
+// @Router       /competition/{competitionID}/zone/{zone}/state [patch]
+func (s *Server) setZoneState(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.ZoneStateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	zone, err := s.competitionService.SetZoneOpen(c, int32(competitionID), c.Param("zone"), *input.IsOpen)
+	if err != nil {
+		if errors.Is(err, repository.ErrZoneNotFound) {
+			RespondError(c, http.StatusNotFound, err)
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toZoneDetailsResponse(zone))
+}
+
+// deleteZoneDetails godoc
+// @Summary      Delete the description of a zone
+// @Description  Deletes the description of a zone, which must not be used by any scale of the competition anymore
+// @Tags         competition
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Param        zone           path      string  true  "Zone name"
+// @Success      204            "Zone deleted"
+// @Failure      400            {object}  models.ErrorResponse  "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse  "Unauthorized"
+// @Failure      403            {object}  models.ErrorResponse  "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse  "Zone not found"
+// @Failure      409            {object}  models.ErrorResponse  "Scales still reference the zone"
+// @Failure      500            {object}  models.ErrorResponse  "Internal Server Error"
+// @Router       /competition/{competitionID}/zone-details/{zone} [delete]
+func (s *Server) deleteZoneDetails(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.competitionService.DeleteZone(c, int32(competitionID), c.Param("zone"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrZoneInUse):
+			RespondError(c, http.StatusConflict, err)
+		case errors.Is(err, repository.ErrZoneNotFound):
+			RespondError(c, http.StatusNotFound, err)
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// zoneFromInput builds the zone described by the input, whose latitude and longitude go together
+func zoneFromInput(competitionID int32, name string, input models.ZoneDetailsInput) (*aggregate.Zone, error) {
+	zone := aggregate.NewZone()
+	zone.SetCompetitionID(competitionID)
+	zone.SetZone(name)
+	zone.SetDescription(input.Description)
+	zone.SetLocation(input.Location)
+	zone.SetPhotoURL(input.PhotoURL)
+
+	if (input.Latitude == nil) != (input.Longitude == nil) {
+		return nil, errors.New("the latitude and longitude must be given together")
+	}
+	if input.Latitude != nil {
+		zone.SetCoordinates(*input.Latitude, *input.Longitude)
+	}
+
+	return zone, nil
+}
+
+// toZoneDetailsResponse builds the response describing a zone
+func toZoneDetailsResponse(zone *aggregate.Zone) models.ZoneDetailsResponse {
+	response := models.ZoneDetailsResponse{
+		Zone:        zone.GetZone(),
+		Description: zone.GetDescription(),
+		Location:    zone.GetLocation(),
+		PhotoURL:    zone.GetPhotoURL(),
+		IsOpen:      zone.IsOpen(),
+	}
+	if latitude, longitude, ok := zone.GetCoordinates(); ok {
+		response.Latitude = &latitude
+		response.Longitude = &longitude
+	}
+	return response
+}
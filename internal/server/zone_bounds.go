@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/repository"
+	"github.com/NiskuT/cross-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// setZoneBounds godoc
+// @Summary      Set the bounds of a zone
+// @Description  Sets the longest plausible chrono and highest plausible penalty of the runs of a zone, zero disables a check.
+// @Description  Runs outside the bounds must be confirmed by the referee before being recorded, catching typos like 600 seconds instead of 60.
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string                  true  "Authentication cookie"
+// @Param        competitionID  path      int                     true  "Competition ID"
+// @Param        bounds         body      models.ZoneBoundsInput  true  "Bounds of the zone"
+// @Success      200            {object}  models.ZoneBoundsResponse  "Returns the bounds of the zone"
+// @Failure      400            {object}  models.ErrorResponse       "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse       "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse       "Forbidden (admin access required)"
+// @Failure      404            {object}  models.ErrorResponse       "Competition or zone not found"
+// @Failure      500            {object}  models.ErrorResponse       "Internal Server Error"
+// @Router       /competition/{competitionID}/zones/bounds [put]
+func (s *Server) setZoneBounds(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	var input models.ZoneBoundsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = checkHasAdminAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	bounds := aggregate.NewZoneBounds()
+	bounds.SetCompetitionID(int32(competitionID))
+	bounds.SetZone(input.Zone)
+	bounds.SetMaxChronoSec(input.MaxChronoSec)
+	bounds.SetMaxPenality(input.MaxPenality)
+
+	err = s.competitionService.SetZoneBounds(c, bounds)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidZoneBounds):
+			RespondError(c, http.StatusBadRequest, err)
+		case errors.Is(err, service.ErrUnknownZone):
+			RespondError(c, http.StatusNotFound, errors.New("zone not found"))
+		case errors.Is(err, repository.ErrCompetitionNotFound):
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+		default:
+			RespondError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toZoneBoundsResponse(bounds))
+}
+
+// listZoneBounds godoc
+// @Summary      List the bounds of the zones
+// @Description  Lists the longest plausible chrono and highest plausible penalty of the zones of a competition, zones without bounds are left out
+// @Tags         competition
+// @Accept       json
+// @Produce      json
+// @Param        Cookie         header    string  true  "Authentication cookie"
+// @Param        competitionID  path      int     true  "Competition ID"
+// @Success      200            {object}  models.ZoneBoundsListResponse  "Returns the bounds of the zones"
+// @Failure      400            {object}  models.ErrorResponse           "Bad Request"
+// @Failure      401            {object}  models.ErrorResponse           "Unauthorized (invalid credentials)"
+// @Failure      403            {object}  models.ErrorResponse           "Forbidden"
+// @Failure      404            {object}  models.ErrorResponse           "Competition not found"
+// @Failure      500            {object}  models.ErrorResponse           "Internal Server Error"
+// @Router       /competition/{competitionID}/zones/bounds [get]
+func (s *Server) listZoneBounds(c *gin.Context) {
+	competitionID, err := strconv.ParseInt(c.Param("competitionID"), 10, 32)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errors.New("invalid competition ID"))
+		return
+	}
+
+	err = checkHasReadAccessToCompetition(c, int32(competitionID))
+	if err != nil {
+		RespondError(c, http.StatusForbidden, err)
+		return
+	}
+
+	boundsList, err := s.competitionService.ListZoneBounds(c, int32(competitionID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCompetitionNotFound) {
+			RespondError(c, http.StatusNotFound, errors.New("competition not found"))
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := models.ZoneBoundsListResponse{
+		CompetitionID: int32(competitionID),
+		Zones:         make([]models.ZoneBoundsResponse, 0, len(boundsList)),
+	}
+	for _, bounds := range boundsList {
+		response.Zones = append(response.Zones, toZoneBoundsResponse(bounds))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toZoneBoundsResponse builds the response describing the bounds of a zone
+func toZoneBoundsResponse(bounds *aggregate.ZoneBounds) models.ZoneBoundsResponse {
+	return models.ZoneBoundsResponse{
+		Zone:         bounds.GetZone(),
+		MaxChronoSec: bounds.GetMaxChronoSec(),
+		MaxPenality:  bounds.GetMaxPenality(),
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/repository"
+	"github.com/NiskuT/cross-api/internal/domain/service"
+)
+
+const (
+	// apiKeyPrefix is prepended to every generated key so they are easy to recognise in configuration files
+	apiKeyPrefix = "ork_"
+	// apiKeyDisplayLength is the number of characters of the key kept in clear to identify it
+	apiKeyDisplayLength = 12
+)
+
+var (
+	// ErrInvalidAPIKey is returned when a key does not match an active API key
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrInvalidAPIKeyScope is returned when an unknown scope is requested
+	ErrInvalidAPIKeyScope = errors.New("invalid API key scope")
+	// ErrAPIKeyScopesRequired is returned when a key is created without scope
+	ErrAPIKeyScopesRequired = errors.New("at least one scope is required")
+)
+
+// APIKeyService implements the APIKeyService interface
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+// APIKeyServiceConfiguration is a function that configures an APIKeyService
+type APIKeyServiceConfiguration func(a *APIKeyService) error
+
+// NewAPIKeyService creates a new APIKeyService
+func NewAPIKeyService(cfgs ...APIKeyServiceConfiguration) service.APIKeyService {
+	impl := new(APIKeyService)
+
+	for _, cfg := range cfgs {
+		if err := cfg(impl); err != nil {
+			panic(err)
+		}
+	}
+
+	return impl
+}
+
+// APIKeyConfWithAPIKeyRepo configures the APIKeyService with an APIKeyRepository
+func APIKeyConfWithAPIKeyRepo(repo repository.APIKeyRepository) APIKeyServiceConfiguration {
+	return func(a *APIKeyService) error {
+		a.apiKeyRepo = repo
+		return nil
+	}
+}
+
+// CreateAPIKey creates a key for the competition and returns it with its plain value, which is never stored
+func (a *APIKeyService) CreateAPIKey(ctx context.Context, competitionID int32, name string, scopes []string, createdBy int32) (*aggregate.APIKey, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", ErrAPIKeyScopesRequired
+	}
+
+	uniqueScopes := make([]string, 0, len(scopes))
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		if !isValidAPIKeyScope(scope) {
+			return nil, "", ErrInvalidAPIKeyScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			uniqueScopes = append(uniqueScopes, scope)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	plainKey := apiKeyPrefix + hex.EncodeToString(secret)
+
+	apiKey := aggregate.NewAPIKey()
+	apiKey.SetCompetitionID(competitionID)
+	apiKey.SetName(name)
+	apiKey.SetPrefix(plainKey[:apiKeyDisplayLength])
+	apiKey.SetKeyHash(hashAPIKey(plainKey))
+	apiKey.SetScopes(uniqueScopes)
+	apiKey.SetCreatedBy(createdBy)
+	apiKey.SetCreatedAt(time.Now())
+
+	if err := a.apiKeyRepo.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, plainKey, nil
+}
+
+// ListAPIKeys lists the keys of a competition, including revoked ones
+func (a *APIKeyService) ListAPIKeys(ctx context.Context, competitionID int32) ([]*aggregate.APIKey, error) {
+	return a.apiKeyRepo.ListAPIKeys(ctx, competitionID)
+}
+
+// RevokeAPIKey revokes a key of the competition
+func (a *APIKeyService) RevokeAPIKey(ctx context.Context, competitionID, keyID int32) error {
+	return a.apiKeyRepo.RevokeAPIKey(ctx, competitionID, keyID)
+}
+
+// Authenticate returns the active key matching the plain value
+func (a *APIKeyService) Authenticate(ctx context.Context, plainKey string) (*aggregate.APIKey, error) {
+	apiKey, err := a.apiKeyRepo.GetAPIKeyByHash(ctx, hashAPIKey(plainKey))
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if apiKey.IsRevoked() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := a.apiKeyRepo.TouchAPIKey(ctx, apiKey.GetCompetitionID(), apiKey.GetID()); err != nil {
+		log.Println("Error recording API key usage:", err)
+	}
+
+	return apiKey, nil
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash of a plain key.
+// Keys are long random values, so a fast hash is enough and allows looking them up directly.
+func hashAPIKey(plainKey string) string {
+	sum := sha256.Sum256([]byte(plainKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func isValidAPIKeyScope(scope string) bool {
+	for _, s := range aggregate.APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
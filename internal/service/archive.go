@@ -0,0 +1,571 @@
+package service
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+)
+
+// The archive is a gzipped tarball holding manifest.json and one JSON lines file per record type.
+// The records are decoupled from the database schema: unarchiving writes them through the
+// repositories once the migrations have run, so an archive can be restored on any later schema.
+const (
+	ArchiveFormat        = "orkys-competition-archive"
+	ArchiveFormatVersion = 2
+
+	archiveManifestFile     = "manifest.json"
+	archiveCompetitionFile  = "competition.jsonl"
+	archiveScalesFile       = "scales.jsonl"
+	archiveParticipantsFile = "participants.jsonl"
+	archiveRunsFile         = "runs.jsonl"
+	archiveContactsFile     = "contacts.jsonl"
+
+	// maxArchiveFileSize bounds each file read from an archive
+	maxArchiveFileSize = 256 << 20
+)
+
+var (
+	// ErrInvalidArchive is returned when the archive is not a readable competition archive
+	ErrInvalidArchive = errors.New("invalid competition archive")
+	// ErrUnsupportedArchiveVersion is returned for archives written by a newer version of the API
+	ErrUnsupportedArchiveVersion = errors.New("unsupported competition archive version")
+)
+
+// archiveEntry is a JSON lines file of an archive being written
+type archiveEntry struct {
+	name    string
+	records int
+	fields  []string
+	data    bytes.Buffer
+}
+
+func newArchiveEntry(name string, record interface{}) *archiveEntry {
+	return &archiveEntry{name: name, fields: archiveFields(record)}
+}
+
+func (e *archiveEntry) add(record interface{}) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	e.data.Write(line)
+	e.data.WriteByte('\n')
+	e.records++
+	return nil
+}
+
+// archiveV1Doors holds the door fields of the scales and runs of the version 1 archives, whose zones had six doors
+type archiveV1Doors struct {
+	PointsDoor1 int32 `json:"points_door1"`
+	PointsDoor2 int32 `json:"points_door2"`
+	PointsDoor3 int32 `json:"points_door3"`
+	PointsDoor4 int32 `json:"points_door4"`
+	PointsDoor5 int32 `json:"points_door5"`
+	PointsDoor6 int32 `json:"points_door6"`
+	Door1       bool  `json:"door1"`
+	Door2       bool  `json:"door2"`
+	Door3       bool  `json:"door3"`
+	Door4       bool  `json:"door4"`
+	Door5       bool  `json:"door5"`
+	Door6       bool  `json:"door6"`
+}
+
+func (d archiveV1Doors) doorPoints() []int32 {
+	return []int32{d.PointsDoor1, d.PointsDoor2, d.PointsDoor3, d.PointsDoor4, d.PointsDoor5, d.PointsDoor6}
+}
+
+func (d archiveV1Doors) doors() []bool {
+	return []bool{d.Door1, d.Door2, d.Door3, d.Door4, d.Door5, d.Door6}
+}
+
+// archiveFields lists the JSON field names of a record, they document the files in the manifest
+func archiveFields(record interface{}) []string {
+	t := reflect.TypeOf(record)
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// ArchiveCompetition writes the whole competition, with its scales, participants, runs and contacts, as an archive
+func (s *CompetitionService) ArchiveCompetition(ctx context.Context, competitionID int32, w io.Writer) error {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	competitionEntry := newArchiveEntry(archiveCompetitionFile, models.ArchiveCompetition{})
+	err = competitionEntry.add(models.ArchiveCompetition{
+		Name:            competition.GetName(),
+		Description:     competition.GetDescription(),
+		Date:            competition.GetLocalDate().Format(time.RFC3339),
+		Timezone:        competition.GetTimezone(),
+		Location:        competition.GetLocation(),
+		Organizer:       competition.GetOrganizer(),
+		Contact:         competition.GetContact(),
+		ChronoFormat:    competition.GetChronoFormat(),
+		ChronoDirection: competition.GetChronoDirection(),
+	})
+	if err != nil {
+		return err
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	scalesEntry := newArchiveEntry(archiveScalesFile, models.ArchiveScale{})
+	for _, zone := range zones {
+		scale, err := s.scaleRepo.GetScale(ctx, competitionID, zone.GetCategory(), zone.GetZone())
+		if err != nil {
+			return err
+		}
+		err = scalesEntry.add(models.ArchiveScale{
+			Category:   scale.GetCategory(),
+			Zone:       scale.GetZone(),
+			DoorPoints: scale.GetDoorPoints(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	participantsEntry := newArchiveEntry(archiveParticipantsFile, models.ArchiveParticipant{})
+	for _, participant := range participants {
+		err = participantsEntry.add(models.ArchiveParticipant{
+			DossardNumber: participant.GetDossardNumber(),
+			FirstName:     participant.GetFirstName(),
+			LastName:      participant.GetLastName(),
+			Category:      participant.GetCategory(),
+			Gender:        participant.GetGender(),
+			Club:          participant.GetClub(),
+			ClubEmail:     participant.GetClubEmail(),
+			Licence:       participant.GetLicence(),
+			BirthDate:     participant.GetBirthDay(),
+
+			ConsentDataProcessing: participant.GetConsentDataProcessing(),
+			ConsentPhotoRights:    participant.GetConsentPhotoRights(),
+
+			Waitlisted: participant.IsWaitlisted(),
+			CheckedIn:  participant.IsCheckedIn(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	runs, err := s.runRepo.ListRuns(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	runsEntry := newArchiveEntry(archiveRunsFile, models.ArchiveRun{})
+	for _, run := range runs {
+		record := models.ArchiveRun{
+			Dossard:   run.GetDossard(),
+			RunNumber: run.GetRunNumber(),
+			Zone:      run.GetZone(),
+			Doors:     run.GetDoors(),
+			Penality:  run.GetPenality(),
+			ChronoSec: run.GetChronoSec(),
+			ChronoMs:  run.GetChronoMs(),
+			RefereeID: run.GetRefereeId(),
+			CreatedAt: run.GetCreatedAt().UTC(),
+		}
+		if run.IsVoided() {
+			voidedAt := run.GetVoidedAt().UTC()
+			record.VoidedAt = &voidedAt
+		}
+		if !run.IsScored() {
+			record.Status = run.GetStatus()
+		}
+		record.PendingApproval = run.IsPendingApproval()
+		record.Comment = run.GetComment()
+		if run.GetUpdatedAt().After(run.GetCreatedAt()) {
+			updatedAt := run.GetUpdatedAt().UTC()
+			record.UpdatedAt = &updatedAt
+		}
+		if err = runsEntry.add(record); err != nil {
+			return err
+		}
+	}
+
+	contacts, err := s.contactRepo.ListContacts(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	contactsEntry := newArchiveEntry(archiveContactsFile, models.ArchiveContact{})
+	for _, contact := range contacts {
+		err = contactsEntry.add(models.ArchiveContact{
+			Name:  contact.GetName(),
+			Email: contact.GetEmail(),
+			Phone: contact.GetPhone(),
+			Role:  contact.GetRole(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	entries := []*archiveEntry{competitionEntry, scalesEntry, participantsEntry, runsEntry, contactsEntry}
+	return writeArchive(w, competitionID, entries)
+}
+
+// writeArchive writes the manifest followed by the entries as a gzipped tarball
+func writeArchive(w io.Writer, competitionID int32, entries []*archiveEntry) error {
+	now := time.Now().UTC()
+
+	manifest := models.ArchiveManifest{
+		Format:              ArchiveFormat,
+		Version:             ArchiveFormatVersion,
+		CreatedAt:           now,
+		SourceCompetitionID: competitionID,
+	}
+	for _, entry := range entries {
+		manifest.Files = append(manifest.Files, models.ArchiveFile{
+			Name:    entry.name,
+			Records: entry.records,
+			Fields:  entry.fields,
+		})
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	writeFile := func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err = writeFile(archiveManifestFile, manifestData); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err = writeFile(entry.name, entry.data.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readArchive reads the files of a gzipped tarball and checks its manifest
+func readArchive(r io.Reader) (*models.ArchiveManifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxArchiveFileSize {
+			return nil, nil, fmt.Errorf("%w: %s is too large", ErrInvalidArchive, header.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		files[header.Name] = data
+	}
+
+	manifestData, ok := files[archiveManifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, archiveManifestFile)
+	}
+	var manifest models.ArchiveManifest
+	if err = json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if manifest.Format != ArchiveFormat {
+		return nil, nil, fmt.Errorf("%w: unknown format %q", ErrInvalidArchive, manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > ArchiveFormatVersion {
+		return nil, nil, fmt.Errorf("%w: version %d, this version of the API reads up to %d", ErrUnsupportedArchiveVersion, manifest.Version, ArchiveFormatVersion)
+	}
+
+	return &manifest, files, nil
+}
+
+// decodeArchiveRecords decodes each line of a JSON lines file with decode, a missing file has no records.
+// Fields unknown to this version are ignored and missing ones keep their zero value.
+func decodeArchiveRecords(files map[string][]byte, name string, decode func(line []byte) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(files[name]))
+	scanner.Buffer(make([]byte, 64*1024), maxArchiveFileSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		if err := decode(scanner.Bytes()); err != nil {
+			return fmt.Errorf("%w: %s line %d: %v", ErrInvalidArchive, name, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+
+	return nil
+}
+
+// UnarchiveCompetition recreates a competition from an archive and returns its new ID.
+// The name overrides the archived one, which helps when the original competition still exists.
+// Nothing is left behind when the restoration fails.
+func (s *CompetitionService) UnarchiveCompetition(ctx context.Context, r io.Reader, name string) (int32, error) {
+	manifest, files, err := readArchive(r)
+	if err != nil {
+		return 0, err
+	}
+
+	var competitions []models.ArchiveCompetition
+	var scales []models.ArchiveScale
+	var participants []models.ArchiveParticipant
+	var runs []models.ArchiveRun
+	var contacts []models.ArchiveContact
+
+	err = decodeArchiveRecords(files, archiveCompetitionFile, func(line []byte) error {
+		var record models.ArchiveCompetition
+		err := json.Unmarshal(line, &record)
+		competitions = append(competitions, record)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(competitions) != 1 {
+		return 0, fmt.Errorf("%w: expected one competition, found %d", ErrInvalidArchive, len(competitions))
+	}
+	err = decodeArchiveRecords(files, archiveScalesFile, func(line []byte) error {
+		var record models.ArchiveScale
+		err := json.Unmarshal(line, &record)
+		if err == nil && manifest.Version < 2 {
+			var legacy archiveV1Doors
+			err = json.Unmarshal(line, &legacy)
+			record.DoorPoints = legacy.doorPoints()
+		}
+		scales = append(scales, record)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = decodeArchiveRecords(files, archiveParticipantsFile, func(line []byte) error {
+		var record models.ArchiveParticipant
+		err := json.Unmarshal(line, &record)
+		participants = append(participants, record)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = decodeArchiveRecords(files, archiveRunsFile, func(line []byte) error {
+		var record models.ArchiveRun
+		err := json.Unmarshal(line, &record)
+		if err == nil && manifest.Version < 2 {
+			var legacy archiveV1Doors
+			err = json.Unmarshal(line, &legacy)
+			record.Doors = legacy.doors()
+		}
+		runs = append(runs, record)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = decodeArchiveRecords(files, archiveContactsFile, func(line []byte) error {
+		var record models.ArchiveContact
+		err := json.Unmarshal(line, &record)
+		contacts = append(contacts, record)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	archived := competitions[0]
+	competition := aggregate.NewCompetition()
+	competition.SetName(archived.Name)
+	if name != "" {
+		competition.SetName(name)
+	}
+	competition.SetDescription(archived.Description)
+	// Archives made before the dates were typed may hold a YYYY-MM-DD day without time zone
+	date, ok := aggregate.ParseCompetitionDate(archived.Date, archived.Timezone)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCompetitionDate, archived.Date)
+	}
+	competition.SetDate(date)
+	if archived.Timezone != "" {
+		competition.SetTimezone(archived.Timezone)
+	}
+	competition.SetLocation(archived.Location)
+	competition.SetOrganizer(archived.Organizer)
+	competition.SetContact(archived.Contact)
+	competition.SetChronoFormat(archived.ChronoFormat)
+	competition.SetChronoDirection(archived.ChronoDirection)
+
+	competitionID, err := s.competitionRepo.CreateCompetition(ctx, competition)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.restoreArchiveRecords(ctx, competitionID, scales, participants, runs, contacts)
+	if err != nil {
+		s.discardRestoredCompetition(ctx, competitionID)
+		return 0, err
+	}
+
+	log.Printf("Restored competition %d from an archive of competition %d (format version %d)", competitionID, manifest.SourceCompetitionID, manifest.Version)
+	return competitionID, nil
+}
+
+// restoreArchiveRecords creates the records of an archive in the new competition and rebuilds its ranking
+func (s *CompetitionService) restoreArchiveRecords(ctx context.Context, competitionID int32,
+	scales []models.ArchiveScale, participants []models.ArchiveParticipant,
+	runs []models.ArchiveRun, contacts []models.ArchiveContact) error {
+	for _, archived := range scales {
+		scale := aggregate.NewScale()
+		scale.SetCompetitionID(competitionID)
+		scale.SetCategory(archived.Category)
+		scale.SetZone(archived.Zone)
+		scale.SetDoorPoints(archived.DoorPoints)
+		if err := s.scaleRepo.CreateScale(ctx, scale); err != nil {
+			return fmt.Errorf("failed to restore scale %s/%s: %w", archived.Category, archived.Zone, err)
+		}
+		if err := s.zoneRepo.EnsureZone(ctx, competitionID, archived.Zone); err != nil {
+			return fmt.Errorf("failed to restore zone %s: %w", archived.Zone, err)
+		}
+	}
+
+	for _, archived := range participants {
+		participant := aggregate.NewParticipant()
+		participant.SetCompetitionID(competitionID)
+		participant.SetDossardNumber(archived.DossardNumber)
+		participant.SetFirstName(archived.FirstName)
+		participant.SetLastName(archived.LastName)
+		participant.SetCategory(archived.Category)
+		participant.SetGender(archived.Gender)
+		participant.SetClub(archived.Club)
+		participant.SetClubEmail(archived.ClubEmail)
+		participant.SetLicence(archived.Licence)
+		if archived.BirthDate != "" {
+			birthDate, err := time.Parse(time.DateOnly, archived.BirthDate)
+			if err != nil {
+				return fmt.Errorf("invalid birth date of participant %d: %w", archived.DossardNumber, err)
+			}
+			participant.SetBirthDate(birthDate)
+		}
+		participant.SetConsentDataProcessing(archived.ConsentDataProcessing)
+		participant.SetConsentPhotoRights(archived.ConsentPhotoRights)
+		participant.SetWaitlisted(archived.Waitlisted)
+		participant.SetCheckedIn(archived.CheckedIn)
+		if err := s.participantRepo.CreateParticipant(ctx, participant); err != nil {
+			return fmt.Errorf("failed to restore participant %d: %w", archived.DossardNumber, err)
+		}
+	}
+
+	dossards := make(map[int32]bool)
+	for _, archived := range runs {
+		run := aggregate.NewRun()
+		run.SetCompetitionID(competitionID)
+		run.SetDossard(archived.Dossard)
+		run.SetRunNumber(archived.RunNumber)
+		run.SetZone(archived.Zone)
+		run.SetDoors(archived.Doors)
+		run.SetPenality(archived.Penality)
+		run.SetChronoSec(archived.ChronoSec)
+		if archived.ChronoMs != 0 {
+			run.SetChronoMs(archived.ChronoMs)
+		}
+		run.SetRefereeId(archived.RefereeID)
+		run.SetCreatedAt(archived.CreatedAt)
+		if archived.VoidedAt != nil {
+			run.SetVoidedAt(*archived.VoidedAt)
+		}
+		if archived.UpdatedAt != nil {
+			run.SetUpdatedAt(*archived.UpdatedAt)
+		}
+		run.SetStatus(archived.Status)
+		run.SetPendingApproval(archived.PendingApproval)
+		run.SetComment(archived.Comment)
+		if err := s.runRepo.RestoreRun(ctx, run); err != nil {
+			return fmt.Errorf("failed to restore run %d of dossard %d: %w", archived.RunNumber, archived.Dossard, err)
+		}
+		dossards[archived.Dossard] = true
+	}
+
+	for dossard := range dossards {
+		if err := s.liverankingRepo.RecalculateLiveranking(ctx, competitionID, dossard); err != nil {
+			return fmt.Errorf("failed to rebuild the ranking of dossard %d: %w", dossard, err)
+		}
+	}
+
+	for _, archived := range contacts {
+		contact := aggregate.NewCompetitionContact()
+		contact.SetCompetitionID(competitionID)
+		contact.SetName(archived.Name)
+		contact.SetEmail(archived.Email)
+		contact.SetPhone(archived.Phone)
+		contact.SetRole(archived.Role)
+		if err := s.contactRepo.CreateContact(ctx, contact); err != nil {
+			return fmt.Errorf("failed to restore contact %s: %w", archived.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// discardRestoredCompetition removes a partially restored competition
+func (s *CompetitionService) discardRestoredCompetition(ctx context.Context, competitionID int32) {
+	if err := s.DeleteCompetition(ctx, competitionID); err != nil {
+		log.Printf("Failed to remove partially restored competition %d: %v", competitionID, err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/repository"
+	"github.com/NiskuT/cross-api/internal/domain/service"
+)
+
+// AuditService implements the AuditService interface
+type AuditService struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// AuditServiceConfiguration is a function that configures an AuditService
+type AuditServiceConfiguration func(a *AuditService) error
+
+// NewAuditService creates a new AuditService
+func NewAuditService(cfgs ...AuditServiceConfiguration) service.AuditService {
+	impl := new(AuditService)
+
+	for _, cfg := range cfgs {
+		if err := cfg(impl); err != nil {
+			panic(err)
+		}
+	}
+
+	return impl
+}
+
+// AuditConfWithAuditLogRepo configures the AuditService with an AuditLogRepository
+func AuditConfWithAuditLogRepo(repo repository.AuditLogRepository) AuditServiceConfiguration {
+	return func(a *AuditService) error {
+		a.auditLogRepo = repo
+		return nil
+	}
+}
+
+// Record stores an audit log entry
+func (a *AuditService) Record(ctx context.Context, auditLog *aggregate.AuditLog) error {
+	return a.auditLogRepo.CreateAuditLog(ctx, auditLog)
+}
+
+// ListAuditLogs lists the audit log entries of a competition, most recent first
+func (a *AuditService) ListAuditLogs(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuditLog, int32, error) {
+	return a.auditLogRepo.ListAuditLogs(ctx, competitionID, pageNumber, pageSize)
+}
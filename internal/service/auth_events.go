@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+)
+
+// recordAuthEvent stores a security event with the device making the request.
+// Failing to record it does not fail the operation, the error is only logged.
+func (s *UserService) recordAuthEvent(ctx context.Context, eventType string, userID int32, email string, competitionID int32, details string) {
+	if s.authEventRepo == nil {
+		return
+	}
+
+	client, _ := ctx.Value(entity.SessionClientKey).(entity.SessionClient)
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	authEvent := aggregate.NewAuthEvent()
+	authEvent.SetType(eventType)
+	authEvent.SetUserID(userID)
+	authEvent.SetEmail(email)
+	authEvent.SetCompetitionID(competitionID)
+	authEvent.SetDetails(details)
+	authEvent.SetIP(client.IP)
+	authEvent.SetUserAgent(userAgent)
+
+	if err := s.authEventRepo.CreateAuthEvent(ctx, authEvent); err != nil {
+		log.Println("Error recording auth event:", err)
+	}
+}
+
+// recordRoleGranted stores the grant of a role on a competition
+func (s *UserService) recordRoleGranted(ctx context.Context, user *aggregate.User, role string) {
+	userRole := aggregate.NewUserRole(user.GetID(), role)
+	s.recordAuthEvent(ctx, aggregate.AuthEventRoleGranted, user.GetID(), user.GetEmail(), userRole.GetCompetitionID(), role)
+}
+
+// ListSecurityEvents lists the security events of the user, most recent first
+func (s *UserService) ListSecurityEvents(ctx context.Context, userID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error) {
+	return s.authEventRepo.ListUserAuthEvents(ctx, userID, pageNumber, pageSize)
+}
+
+// ListCompetitionSecurityEvents lists the security events of a competition and of its members, most recent first
+func (s *UserService) ListCompetitionSecurityEvents(ctx context.Context, competitionID int32, pageNumber, pageSize int32) ([]*aggregate.AuthEvent, int32, error) {
+	return s.authEventRepo.ListCompetitionAuthEvents(ctx, competitionID, pageNumber, pageSize)
+}
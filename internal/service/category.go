@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrEmptyCategoryName is returned when a category is given an empty name
+	ErrEmptyCategoryName = errors.New("category name cannot be empty")
+	// ErrInvalidCategoryAges is returned when the ages of a category are negative or the minimum is above the maximum
+	ErrInvalidCategoryAges = errors.New("invalid category ages: they cannot be negative and the minimum age cannot be above the maximum age")
+	// ErrUnknownCategory is returned when a participant or scale uses a category the competition does not define
+	ErrUnknownCategory = errors.New("category not defined in the competition")
+	// ErrCategoryInUse is returned when deleting or renaming a category still used by participants or scales
+	ErrCategoryInUse = errors.New("category is used by participants or zones")
+)
+
+// AddCategory adds a category to a competition
+func (s *CompetitionService) AddCategory(ctx context.Context, category *aggregate.Category) error {
+	if err := validateCategory(category); err != nil {
+		return err
+	}
+
+	// Check if competition exists
+	_, err := s.competitionRepo.GetCompetition(ctx, category.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+
+	return s.categoryRepo.CreateCategory(ctx, category)
+}
+
+// ListCategories lists the categories of a competition by display order
+func (s *CompetitionService) ListCategories(ctx context.Context, competitionID int32) ([]*aggregate.Category, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.categoryRepo.ListCategories(ctx, competitionID)
+}
+
+// UpdateCategory updates a category, it cannot be renamed while participants or zones use it
+func (s *CompetitionService) UpdateCategory(ctx context.Context, category *aggregate.Category) error {
+	if err := validateCategory(category); err != nil {
+		return err
+	}
+
+	current, err := s.categoryRepo.GetCategory(ctx, category.GetCompetitionID(), category.GetID())
+	if err != nil {
+		return err
+	}
+
+	if current.GetName() != category.GetName() {
+		inUse, err := s.isCategoryInUse(ctx, current)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return ErrCategoryInUse
+		}
+	}
+
+	return s.categoryRepo.UpdateCategory(ctx, category)
+}
+
+// DeleteCategory removes a category from a competition, it cannot be removed while participants or zones use it
+func (s *CompetitionService) DeleteCategory(ctx context.Context, competitionID, categoryID int32) error {
+	category, err := s.categoryRepo.GetCategory(ctx, competitionID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.isCategoryInUse(ctx, category)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrCategoryInUse
+	}
+
+	return s.categoryRepo.DeleteCategory(ctx, competitionID, categoryID)
+}
+
+// isCategoryInUse returns whether participants or scales of the competition use the category
+func (s *CompetitionService) isCategoryInUse(ctx context.Context, category *aggregate.Category) (bool, error) {
+	participants, err := s.participantRepo.ListParticipantsByCategory(ctx, category.GetCompetitionID(), category.GetName())
+	if err != nil {
+		return false, err
+	}
+	if len(participants) > 0 {
+		return true, nil
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, category.GetCompetitionID())
+	if err != nil {
+		return false, err
+	}
+	for _, zone := range zones {
+		if zone.GetCategory() == category.GetName() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// checkCategory returns ErrUnknownCategory when the competition defines categories and not this one,
+// otherwise the category is set to the name the competition defines it with
+func (s *CompetitionService) checkCategory(ctx context.Context, competitionID int32, name string, setCategory func(string)) error {
+	categories, err := s.categoryRepo.ListCategories(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	category, err := resolveCategory(categories, name)
+	if err != nil {
+		return err
+	}
+	setCategory(category)
+	return nil
+}
+
+// resolveCategory returns the name of the category of the competition matching the given one regardless of case,
+// the given name when the competition defines no category
+func resolveCategory(categories []*aggregate.Category, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(categories) == 0 {
+		return name, nil
+	}
+
+	for _, category := range categories {
+		if strings.EqualFold(category.GetName(), name) {
+			return category.GetName(), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownCategory, name)
+}
+
+// validateCategory trims the name of the category and checks its ages
+func validateCategory(category *aggregate.Category) error {
+	category.SetName(strings.TrimSpace(category.GetName()))
+	if category.GetName() == "" {
+		return ErrEmptyCategoryName
+	}
+
+	if category.GetMinAge() < 0 || category.GetMaxAge() < 0 {
+		return ErrInvalidCategoryAges
+	}
+	if category.GetMaxAge() > 0 && category.GetMinAge() > category.GetMaxAge() {
+		return ErrInvalidCategoryAges
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrInvalidBirthDate is returned when a day of birth cannot be read or is in the future
+	ErrInvalidBirthDate = errors.New("invalid birth date: expected YYYY-MM-DD or DD/MM/YYYY")
+	// ErrNoCategoryForAge is returned when no category of the competition holds the age of a participant without category
+	ErrNoCategoryForAge = errors.New("no category of the competition for this age")
+)
+
+// birthDateLayouts lists the layouts a day of birth is read with, the last one being how Excel displays dates by default
+var birthDateLayouts = []string{"2006-01-02", "02/01/2006", "2/1/2006", "01-02-06"}
+
+// parseBirthDate reads a day of birth, an empty value leaving it unknown
+func parseBirthDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range birthDateLayouts {
+		birthDate, err := time.Parse(layout, value)
+		if err == nil {
+			if birthDate.After(time.Now()) {
+				return time.Time{}, ErrInvalidBirthDate
+			}
+			return birthDate, nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidBirthDate
+}
+
+// seasonAge returns the age a participant reaches during the year of the competition, the age the federations
+// place participants in categories with for the whole season
+func seasonAge(birthDate, competitionDate time.Time) int32 {
+	return int32(competitionDate.Year() - birthDate.Year())
+}
+
+// categoryForAge returns the first category, in display order, whose age bracket holds the age.
+// The categories without any age limit are open to all ages and never derived.
+func categoryForAge(categories []*aggregate.Category, age int32) *aggregate.Category {
+	for _, category := range categories {
+		if category.GetMinAge() == 0 && category.GetMaxAge() == 0 {
+			continue
+		}
+		if category.GetMinAge() > 0 && age < category.GetMinAge() {
+			continue
+		}
+		if category.GetMaxAge() > 0 && age > category.GetMaxAge() {
+			continue
+		}
+		return category
+	}
+	return nil
+}
+
+// hasAgeBrackets returns whether a category of the competition limits the age of its participants
+func hasAgeBrackets(categories []*aggregate.Category) bool {
+	for _, category := range categories {
+		if category.GetMinAge() > 0 || category.GetMaxAge() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// assignCategory derives the category of a participant without one from their day of birth, and returns a warning
+// when the category given disagrees with the age of the participant. The category given is kept, the organizers
+// may place a participant out of their age on purpose.
+func assignCategory(competition *aggregate.Competition, categories []*aggregate.Category, participant *aggregate.Participant) (string, error) {
+	if participant.GetBirthDate().IsZero() || !hasAgeBrackets(categories) {
+		if participant.GetCategory() == "" && len(categories) > 0 {
+			return "", fmt.Errorf("invalid category: %w: the category or the birth date is required", ErrUnknownCategory)
+		}
+		return "", nil
+	}
+
+	age := seasonAge(participant.GetBirthDate(), competition.GetLocalDate())
+	computed := categoryForAge(categories, age)
+
+	switch {
+	case participant.GetCategory() == "" && computed == nil:
+		return "", fmt.Errorf("%w: %d", ErrNoCategoryForAge, age)
+	case participant.GetCategory() == "":
+		participant.SetCategory(computed.GetName())
+	case computed == nil:
+		return fmt.Sprintf("category %s given but no category holds the age %d", participant.GetCategory(), age), nil
+	case computed.GetName() != participant.GetCategory():
+		return fmt.Sprintf("category %s given but the age %d is in %s", participant.GetCategory(), age, computed.GetName()), nil
+	}
+	return "", nil
+}
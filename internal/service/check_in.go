@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrParticipantWaitlistedCheckIn is returned when checking in a waitlisted participant, who has no place to start
+	ErrParticipantWaitlistedCheckIn = errors.New("a waitlisted participant cannot be checked in")
+)
+
+// CheckInParticipant confirms or withdraws the presence of a participant at the start desk
+func (s *CompetitionService) CheckInParticipant(ctx context.Context, competitionID, dossardNumber int32, checkedIn bool) (*aggregate.Participant, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.GetParticipant(ctx, competitionID, dossardNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkedIn && participant.IsWaitlisted() {
+		return nil, ErrParticipantWaitlistedCheckIn
+	}
+
+	if err := s.participantRepo.SetParticipantCheckedIn(ctx, competitionID, dossardNumber, checkedIn); err != nil {
+		return nil, err
+	}
+
+	participant.SetCheckedIn(checkedIn)
+	return participant, nil
+}
+
+// GetCheckInSummary counts the registered and the checked in participants of each category of a competition
+func (s *CompetitionService) GetCheckInSummary(ctx context.Context, competitionID int32) ([]*aggregate.CheckInCount, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.CountCheckIns(ctx, competitionID)
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// maxChronoImportRows bounds the chronos of a single import
+const maxChronoImportRows = 5000
+
+var (
+	// ErrEmptyChronoImport is returned when the import file has no chrono row
+	ErrEmptyChronoImport = errors.New("the file contains no chrono, expected rows of dossard, zone, time")
+	// ErrTooManyChronoImportRows is returned when the import file exceeds maxChronoImportRows
+	ErrTooManyChronoImportRows = fmt.Errorf("the file contains more than %d chronos", maxChronoImportRows)
+	// ErrChronoImportColumns is returned when the header of the import file lacks the dossard, zone or time column
+	ErrChronoImportColumns = errors.New("the header of the file must name a dossard (or bib), a zone (or split) and a time (or chrono) column")
+)
+
+// chronoImportColumns lists the header names of the columns used by the common timing systems, once normalized
+var chronoImportColumns = map[string][]string{
+	"dossard": {"dossard", "dos", "bib", "bibnumber", "bibno", "startnumber", "number", "num", "no", "nr"},
+	"zone":    {"zone", "split", "splitname", "checkpoint", "segment", "section", "stage"},
+	"chrono":  {"chrono", "chronosec", "time", "temps", "nettime", "elapsed", "elapsedtime", "duration", "result"},
+}
+
+// ImportChronos imports the chronos of a CSV export of a timing system, keyed by dossard and zone.
+// The columns are found by their header, or are dossard, zone and time in that order without header,
+// and comma, semicolon and tab separated files are accepted. Times are seconds or [hh:]mm:ss, fractions being rounded.
+// The chronos of a participant in a zone are merged in file order into their runs in the zone, the chronos left
+// without a run become pending runs a referee confirms with the doors and penalty. Every row is processed and
+// its outcome reported, a failing row does not stop the import.
+func (s *RunService) ImportChronos(ctx context.Context, competitionID int32, file io.Reader, importedBy int32) (*aggregate.ChronoImportReport, error) {
+	if err := s.checkNotClosed(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	rows, err := readImportRows(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// The header row is optional, it is recognized by its dossard column not being a number.
+	// Line numbers of the report are those of the file.
+	columns := map[string]int{"dossard": 0, "zone": 1, "chrono": 2}
+	firstLine := int32(1)
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		if _, err := strconv.Atoi(strings.TrimSpace(rows[0][0])); err != nil {
+			if columns, err = chronoImportHeader(rows[0]); err != nil {
+				return nil, err
+			}
+			rows = rows[1:]
+			firstLine = 2
+		}
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyChronoImport
+	}
+	if len(rows) > maxChronoImportRows {
+		return nil, ErrTooManyChronoImportRows
+	}
+
+	importer, err := s.newChronoImporter(ctx, competitionID, importedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		importer.importRow(ctx, firstLine+int32(i), row, columns)
+	}
+
+	// The liveranking of the participants whose runs were merged into is recalculated once,
+	// with the next batch in degraded mode
+	for dossard := range importer.merged {
+		if s.degradedMode.DeferLiveranking(competitionID, dossard) {
+			continue
+		}
+		if err := s.liverankingRepo.RecalculateLiveranking(ctx, competitionID, dossard); err != nil {
+			return nil, fmt.Errorf("failed to recalculate liveranking: %w", err)
+		}
+		s.liverankingVersions.Increment(competitionID)
+	}
+
+	return importer.report, nil
+}
+
+// ListPendingRuns lists the imported chronos of the competition waiting for a referee to confirm their run
+func (s *RunService) ListPendingRuns(ctx context.Context, competitionID int32) ([]*aggregate.PendingRun, error) {
+	return s.pendingRunRepo.ListPendingRuns(ctx, competitionID)
+}
+
+// ConfirmPendingRun records the run of a pending run with the doors and penalty the referee gives, and the
+// dossard, zone and chrono imported from the timing system. The pending run is removed once the run is recorded.
+func (s *RunService) ConfirmPendingRun(ctx context.Context, competitionID, pendingRunID int32, run *aggregate.Run) error {
+	pendingRun, err := s.pendingRunRepo.GetPendingRun(ctx, competitionID, pendingRunID)
+	if err != nil {
+		return err
+	}
+
+	run.SetCompetitionID(pendingRun.GetCompetitionID())
+	run.SetDossard(pendingRun.GetDossard())
+	run.SetZone(pendingRun.GetZone())
+	run.SetChronoMs(pendingRun.GetChronoMs())
+
+	if err := s.CreateRun(ctx, run); err != nil {
+		return err
+	}
+
+	return s.pendingRunRepo.DeletePendingRun(ctx, competitionID, pendingRunID)
+}
+
+// DiscardPendingRun removes a pending run whose chrono does not match any run, e.g. a false start
+func (s *RunService) DiscardPendingRun(ctx context.Context, competitionID, pendingRunID int32) error {
+	return s.pendingRunRepo.DeletePendingRun(ctx, competitionID, pendingRunID)
+}
+
+// chronoImporter holds what the rows of a chrono import are checked and merged against
+type chronoImporter struct {
+	s             *RunService
+	competitionID int32
+	importedBy    int32
+	report        *aggregate.ChronoImportReport
+
+	zones        map[string]map[string]string          // category -> lower case zone -> zone
+	participants map[int32]*aggregate.Participant      // dossard -> participant, nil when it does not exist
+	runs         map[int32]map[string][]*aggregate.Run // dossard -> zone -> runs not voided, by run number
+	occurrences  map[string]int                        // rows already imported per dossard and zone
+	merged       map[int32]bool                        // dossards whose runs were merged into
+}
+
+// newChronoImporter loads the zones of the competition for a chrono import
+func (s *RunService) newChronoImporter(ctx context.Context, competitionID, importedBy int32) (*chronoImporter, error) {
+	zoneInfos, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make(map[string]map[string]string)
+	for _, zoneInfo := range zoneInfos {
+		if zones[zoneInfo.GetCategory()] == nil {
+			zones[zoneInfo.GetCategory()] = make(map[string]string)
+		}
+		zones[zoneInfo.GetCategory()][strings.ToLower(zoneInfo.GetZone())] = zoneInfo.GetZone()
+	}
+
+	return &chronoImporter{
+		s:             s,
+		competitionID: competitionID,
+		importedBy:    importedBy,
+		report:        aggregate.NewChronoImportReport(),
+		zones:         zones,
+		participants:  make(map[int32]*aggregate.Participant),
+		runs:          make(map[int32]map[string][]*aggregate.Run),
+		occurrences:   make(map[string]int),
+		merged:        make(map[int32]bool),
+	}, nil
+}
+
+// importRow imports a single row and records its outcome in the report
+func (i *chronoImporter) importRow(ctx context.Context, line int32, row []string, columns map[string]int) {
+	field := func(name string) string {
+		if columns[name] < len(row) {
+			return strings.TrimSpace(row[columns[name]])
+		}
+		return ""
+	}
+
+	zone := field("zone")
+	dossard, err := strconv.ParseInt(field("dossard"), 10, 32)
+	if err != nil || dossard <= 0 {
+		i.report.AddRow(line, 0, zone, 0, 0, aggregate.ChronoImportFailed, "invalid dossard")
+		return
+	}
+	chronoMs, err := parseImportedChrono(field("chrono"))
+	if err != nil {
+		i.report.AddRow(line, int32(dossard), zone, 0, 0, aggregate.ChronoImportFailed, err.Error())
+		return
+	}
+
+	status, runNumber, message := i.importChrono(ctx, int32(dossard), &zone, chronoMs)
+	i.report.AddRow(line, int32(dossard), zone, chronoMs, runNumber, status, message)
+}
+
+// importChrono merges a chrono into the next run of the participant in the zone, or makes it a pending run.
+// The zone is replaced by its name in the competition, the file may differ in case.
+func (i *chronoImporter) importChrono(ctx context.Context, dossard int32, zone *string, chronoMs int32) (string, int32, string) {
+	participant := i.participant(ctx, dossard)
+	if participant == nil {
+		return aggregate.ChronoImportFailed, 0, "unknown dossard"
+	}
+
+	competitionZone, ok := i.zones[participant.GetCategory()][strings.ToLower(*zone)]
+	if !ok {
+		return aggregate.ChronoImportFailed, 0, fmt.Sprintf("no zone %q for the category %s", *zone, participant.GetCategory())
+	}
+	*zone = competitionZone
+
+	runs, err := i.zoneRuns(ctx, dossard)
+	if err != nil {
+		return aggregate.ChronoImportFailed, 0, "failed to read the runs of the participant"
+	}
+
+	// The n-th chrono of the participant in the zone belongs to its n-th run there
+	key := fmt.Sprintf("%d_%s", dossard, competitionZone)
+	occurrence := i.occurrences[key]
+	i.occurrences[key]++
+
+	if occurrence < len(runs[competitionZone]) {
+		run := runs[competitionZone][occurrence]
+		if run.GetChronoMs() == chronoMs {
+			return aggregate.ChronoImportUnchanged, run.GetRunNumber(), ""
+		}
+
+		run.SetChronoMs(chronoMs)
+		if err := i.s.runRepo.UpdateRun(ctx, run, i.importedBy); err != nil {
+			return aggregate.ChronoImportFailed, run.GetRunNumber(), "failed to update the run"
+		}
+		i.merged[dossard] = true
+		return aggregate.ChronoImportMerged, run.GetRunNumber(), ""
+	}
+
+	pendingRun := aggregate.NewPendingRun()
+	pendingRun.SetCompetitionID(i.competitionID)
+	pendingRun.SetDossard(dossard)
+	pendingRun.SetZone(competitionZone)
+	pendingRun.SetChronoMs(chronoMs)
+	pendingRun.SetImportedBy(i.importedBy)
+	pendingRun.SetImportedAt(time.Now())
+
+	created, err := i.s.pendingRunRepo.CreatePendingRun(ctx, pendingRun)
+	if err != nil {
+		return aggregate.ChronoImportFailed, 0, "failed to store the pending run"
+	}
+	if !created {
+		return aggregate.ChronoImportUnchanged, 0, "already pending"
+	}
+	return aggregate.ChronoImportPending, 0, ""
+}
+
+// participant returns the participant with the dossard, nil when it cannot be found
+func (i *chronoImporter) participant(ctx context.Context, dossard int32) *aggregate.Participant {
+	if participant, ok := i.participants[dossard]; ok {
+		return participant
+	}
+
+	participant, err := i.s.participantRepo.GetParticipant(ctx, i.competitionID, dossard)
+	if err != nil {
+		participant = nil
+	}
+
+	i.participants[dossard] = participant
+	return participant
+}
+
+// zoneRuns returns the runs of the participant which are not voided, grouped by zone and ordered by run number
+func (i *chronoImporter) zoneRuns(ctx context.Context, dossard int32) (map[string][]*aggregate.Run, error) {
+	if runs, ok := i.runs[dossard]; ok {
+		return runs, nil
+	}
+
+	runs, err := i.s.runRepo.ListRunsByDossard(ctx, i.competitionID, dossard)
+	if err != nil {
+		return nil, err
+	}
+
+	byZone := make(map[string][]*aggregate.Run)
+	for _, run := range runs {
+		if !run.IsVoided() {
+			byZone[run.GetZone()] = append(byZone[run.GetZone()], run)
+		}
+	}
+
+	i.runs[dossard] = byZone
+	return byZone, nil
+}
+
+// readImportRows reads the rows of a CSV import, guessing the separator from the first line
+func readImportRows(file io.Reader) ([][]string, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	firstLine := content
+	if end := bytes.IndexByte(content, '\n'); end >= 0 {
+		firstLine = content[:end]
+	}
+
+	// Spreadsheets in French locales export with semicolons, as the comma is their decimal separator
+	separator := ','
+	for _, candidate := range []rune{';', '\t'} {
+		if bytes.Count(firstLine, []byte(string(candidate))) > bytes.Count(firstLine, []byte(string(separator))) {
+			separator = candidate
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = separator
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+
+	return rows, nil
+}
+
+// chronoImportHeader returns the index of the dossard, zone and chrono columns named in the header
+func chronoImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for index, name := range header {
+		normalized := normalizeImportColumn(name)
+		for column, aliases := range chronoImportColumns {
+			if _, found := columns[column]; found || !isOneOf(normalized, aliases) {
+				continue
+			}
+			columns[column] = index
+		}
+	}
+
+	if len(columns) != len(chronoImportColumns) {
+		return nil, ErrChronoImportColumns
+	}
+	return columns, nil
+}
+
+// normalizeImportColumn lowercases a column name and keeps its letters and digits only, e.g. "Bib No." gives "bibno"
+func normalizeImportColumn(name string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+// parseImportedChrono parses a time of a timing system, in seconds or [hh:]mm:ss with an optional fraction
+// of second after a dot or a comma, and returns it in milliseconds
+func parseImportedChrono(value string) (int32, error) {
+	invalid := fmt.Errorf("invalid time %q, expected seconds or [hh:]mm:ss", value)
+
+	parts := strings.Split(strings.ReplaceAll(value, ",", "."), ":")
+	if value == "" || len(parts) > 3 {
+		return 0, invalid
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 || (len(parts) > 1 && seconds >= 60) {
+		return 0, invalid
+	}
+
+	multiplier := 60.0
+	for i := len(parts) - 2; i >= 0; i-- {
+		unit, err := strconv.Atoi(parts[i])
+		if err != nil || unit < 0 || (i > 0 && unit >= 60) {
+			return 0, invalid
+		}
+		seconds += float64(unit) * multiplier
+		multiplier *= 60
+	}
+
+	milliseconds := math.Round(seconds * 1000)
+	if milliseconds > math.MaxInt32 {
+		return 0, invalid
+	}
+	return int32(milliseconds), nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// maxClubEmailAttachmentSize bounds the size of the file attached to the emails sent to the clubs
+const maxClubEmailAttachmentSize = 10 << 20
+
+var (
+	// ErrEmptyClubEmail is returned when the email sent to the clubs has no subject or no body
+	ErrEmptyClubEmail = errors.New("email subject and body cannot be empty")
+	// ErrNoClubContacts is returned when no participant of the competition gives a club email
+	ErrNoClubContacts = errors.New("no participant of the competition has a club email")
+	// ErrClubEmailAttachmentTooLarge is returned when the file attached to the emails sent to the clubs is too large
+	ErrClubEmailAttachmentTooLarge = fmt.Errorf("attachment too large: at most %d MB", maxClubEmailAttachmentSize>>20)
+)
+
+func CompetitionConfWithEmailQueue(queue *EmailQueue) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.emailQueue = queue
+		return nil
+	}
+}
+
+// ListClubContacts returns the distinct club emails of the participants of the competition, addresses
+// differing only by case being the same contact
+func (s *CompetitionService) ListClubContacts(ctx context.Context, competitionID int32) ([]*aggregate.ClubContact, error) {
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := []*aggregate.ClubContact{}
+	byEmail := make(map[string]*aggregate.ClubContact)
+	for _, participant := range participants {
+		if participant.GetClubEmail() == "" {
+			continue
+		}
+
+		key := strings.ToLower(participant.GetClubEmail())
+		contact, ok := byEmail[key]
+		if !ok {
+			contact = aggregate.NewClubContact(participant.GetClubEmail())
+			byEmail[key] = contact
+			contacts = append(contacts, contact)
+		}
+		contact.AddParticipant(participant.GetClub())
+	}
+
+	return contacts, nil
+}
+
+// EmailClubs queues an email to every club contact of the competition, with the file attached when
+// one is given. The {{club}}, {{competition}}, {{date}}, {{location}} and {{participants}} placeholders
+// of the subject and the body are replaced for each contact.
+func (s *CompetitionService) EmailClubs(ctx context.Context, competitionID int32, subject, body string, attachment io.Reader, attachmentName string) ([]*aggregate.ClubContact, error) {
+	if strings.TrimSpace(subject) == "" || strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyClubEmail
+	}
+	if s.emailQueue == nil {
+		return nil, ErrMissingEmailConfig
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []EmailAttachment
+	if attachment != nil {
+		content, err := io.ReadAll(io.LimitReader(attachment, maxClubEmailAttachmentSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(content) > maxClubEmailAttachmentSize {
+			return nil, ErrClubEmailAttachmentTooLarge
+		}
+		attachments = append(attachments, EmailAttachment{
+			Filename:    filepath.Base(attachmentName),
+			ContentType: mime.TypeByExtension(filepath.Ext(attachmentName)),
+			Content:     content,
+		})
+	}
+
+	contacts, err := s.ListClubContacts(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(contacts) == 0 {
+		return nil, ErrNoClubContacts
+	}
+
+	emails := make([]*Email, 0, len(contacts))
+	for _, contact := range contacts {
+		emails = append(emails, &Email{
+			To:          contact.GetEmail(),
+			Subject:     clubEmailReplacer(competition, contact, func(value string) string { return value }).Replace(subject),
+			Body:        clubEmailReplacer(competition, contact, html.EscapeString).Replace(body),
+			Attachments: attachments,
+		})
+	}
+
+	if err := s.emailQueue.Enqueue(emails...); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// clubEmailReplacer replaces the placeholders of the emails sent to the clubs with the values of the contact,
+// escaped with escape
+func clubEmailReplacer(competition *aggregate.Competition, contact *aggregate.ClubContact, escape func(string) string) *strings.Replacer {
+	return strings.NewReplacer(
+		"{{club}}", escape(strings.Join(contact.GetClubs(), ", ")),
+		"{{competition}}", escape(competition.GetName()),
+		"{{date}}", escape(competition.GetDay()),
+		"{{location}}", escape(competition.GetLocation()),
+		"{{participants}}", strconv.Itoa(int(contact.GetParticipants())),
+	)
+}
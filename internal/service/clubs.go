@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrEmptyClubName is returned when a club is given an empty name
+	ErrEmptyClubName = errors.New("club name cannot be empty")
+	// ErrClubInUse is returned when deleting a club its participants still belong to
+	ErrClubInUse = errors.New("club has participants")
+)
+
+// AddClub adds a club to a competition
+func (s *CompetitionService) AddClub(ctx context.Context, club *aggregate.Club) error {
+	if err := validateClub(club); err != nil {
+		return err
+	}
+
+	// Check if competition exists
+	_, err := s.competitionRepo.GetCompetition(ctx, club.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+
+	return s.clubRepo.CreateClub(ctx, club)
+}
+
+// ListClubs lists the clubs of a competition by name
+func (s *CompetitionService) ListClubs(ctx context.Context, competitionID int32) ([]*aggregate.Club, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.clubRepo.ListClubs(ctx, competitionID)
+}
+
+// UpdateClub renames a club, its participants being renamed with it
+func (s *CompetitionService) UpdateClub(ctx context.Context, club *aggregate.Club) error {
+	if err := validateClub(club); err != nil {
+		return err
+	}
+
+	return s.clubRepo.UpdateClub(ctx, club)
+}
+
+// DeleteClub removes a club from a competition, it cannot be removed while participants belong to it
+func (s *CompetitionService) DeleteClub(ctx context.Context, competitionID, clubID int32) error {
+	if _, err := s.clubRepo.GetClub(ctx, competitionID, clubID); err != nil {
+		return err
+	}
+
+	members, err := s.clubRepo.CountClubMembers(ctx, competitionID, clubID)
+	if err != nil {
+		return err
+	}
+	if members > 0 {
+		return ErrClubInUse
+	}
+
+	return s.clubRepo.DeleteClub(ctx, competitionID, clubID)
+}
+
+// GetClubRanking ranks the clubs of a competition on the sum of the points of their members, the clubs with the
+// same points sharing their rank. The clubs without member are not ranked.
+func (s *CompetitionService) GetClubRanking(ctx context.Context, competitionID int32) ([]*aggregate.ClubRanking, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	rankings, err := s.clubRepo.ListClubRankings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, ranking := range rankings {
+		if i > 0 && ranking.GetTotalPoints() == rankings[i-1].GetTotalPoints() {
+			ranking.SetRank(rankings[i-1].GetRank())
+			continue
+		}
+		ranking.SetRank(int32(i + 1))
+	}
+
+	return rankings, nil
+}
+
+// validateClub trims the name of the club
+func validateClub(club *aggregate.Club) error {
+	club.SetName(strings.TrimSpace(club.GetName()))
+	if club.GetName() == "" {
+		return ErrEmptyClubName
+	}
+
+	return nil
+}
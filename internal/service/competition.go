@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
-	"encoding/csv"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"net/mail"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/config"
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
@@ -17,20 +23,57 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
+// maxLicenceLength bounds the licence number of a participant, as its column does
+const maxLicenceLength = 50
+
+// participantImportBatchSize is the number of participants of an import created by each multi-row insert
+const participantImportBatchSize = 500
+
 // Define error constants
 var (
 	ErrInvalidFileFormat = errors.New("invalid file format: expected CSV or Excel file with columns for dossard number, category, last name, first name, gender (H/F), and club")
 	ErrParticipantExists = errors.New("participant with this dossard number already exists in the competition")
 	ErrCategoryAndGender = errors.New("category and gender cannot be empty")
+
+	ErrInvalidDossardNumber = errors.New("dossard number must be positive")
+	ErrInvalidConsent       = errors.New("invalid consent: expected data_processing or photo_rights")
+
+	ErrEmptyCompetitionName = errors.New("competition name cannot be empty")
+
+	ErrInvalidCompetitionStatus = errors.New("invalid competition status: expected draft, open, running or closed")
+	ErrInvalidStatusTransition  = errors.New("the competition cannot move from its current status to the requested one")
+
+	ErrInvalidCompetitionSort      = errors.New("invalid competition sort: expected date, name, location or created")
+	ErrInvalidCompetitionDateRange = errors.New("invalid competition date range: expected RFC3339 or YYYY-MM-DD dates, date_from before date_to")
+	ErrInvalidCompetitionDate      = errors.New("invalid competition date: expected an RFC3339 date or a YYYY-MM-DD day, and an IANA time zone such as Europe/Paris")
+
+	ErrInvalidClearToken = errors.New("invalid confirmation token: the participants or their records changed, count them again for a new token")
 )
 
 type CompetitionService struct {
-	competitionRepo repository.CompetitionRepository
-	scaleRepo       repository.ScaleRepository
-	liverankingRepo repository.LiverankingRepository
-	participantRepo repository.ParticipantRepository
-	runRepo         repository.RunRepository
-	cfg             *config.Config
+	competitionRepo    repository.CompetitionRepository
+	scaleRepo          repository.ScaleRepository
+	liverankingRepo    repository.LiverankingRepository
+	participantRepo    repository.ParticipantRepository
+	runRepo            repository.RunRepository
+	contactRepo        repository.CompetitionContactRepository
+	zoneBoundsRepo     repository.ZoneBoundsRepository
+	zoneRepo           repository.ZoneRepository
+	exportTemplateRepo repository.ExportTemplateRepository
+	settingsRepo       repository.CompetitionSettingsRepository
+	categoryRepo       repository.CategoryRepository
+	clubRepo           repository.ClubRepository
+	teamRepo           repository.TeamRepository
+	seriesRepo         repository.SeriesRepository
+	organizationRepo   repository.OrganizationRepository
+	objectStorage      repository.ObjectStorageRepository
+	licenceRegistry    repository.LicenceRegistryRepository
+	emailQueue         *EmailQueue
+	cfg                *config.Config
+
+	publicMutex        sync.Mutex
+	publicCompetitions map[int32]*publicCompetition
+	seriesStandings    map[int32]*seriesStandings
 }
 
 type CompetitionServiceConfiguration func(c *CompetitionService) error
@@ -89,7 +132,103 @@ func CompetitionConfWithRunRepo(repo repository.RunRepository) CompetitionServic
 	}
 }
 
+func CompetitionConfWithZoneBoundsRepo(repo repository.ZoneBoundsRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.zoneBoundsRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithZoneRepo(repo repository.ZoneRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.zoneRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithExportTemplateRepo(repo repository.ExportTemplateRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.exportTemplateRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithSettingsRepo(repo repository.CompetitionSettingsRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.settingsRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithCategoryRepo(repo repository.CategoryRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.categoryRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithClubRepo(repo repository.ClubRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.clubRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithTeamRepo(repo repository.TeamRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.teamRepo = repo
+		return nil
+	}
+}
+
+// CompetitionConfWithObjectStorage configures the bucket the results and the participant photos are stored in, they are
+// not published nor accepted without it
+func CompetitionConfWithObjectStorage(storage repository.ObjectStorageRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.objectStorage = storage
+		return nil
+	}
+}
+
+// CompetitionConfWithLicenceRegistry configures the register of the federation the licences are verified in
+func CompetitionConfWithLicenceRegistry(registry repository.LicenceRegistryRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.licenceRegistry = registry
+		return nil
+	}
+}
+
+func CompetitionConfWithContactRepo(repo repository.CompetitionContactRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.contactRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithSeriesRepo(repo repository.SeriesRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.seriesRepo = repo
+		return nil
+	}
+}
+
+func CompetitionConfWithOrganizationRepo(repo repository.OrganizationRepository) CompetitionServiceConfiguration {
+	return func(c *CompetitionService) error {
+		c.organizationRepo = repo
+		return nil
+	}
+}
+
 func (s *CompetitionService) CreateCompetition(ctx context.Context, competition *aggregate.Competition) (int32, error) {
+	if competition.GetDate().IsZero() || !aggregate.IsValidTimezone(competition.GetTimezone()) {
+		return 0, ErrInvalidCompetitionDate
+	}
+	if competition.GetOrganizationID() != 0 {
+		if _, err := s.organizationRepo.GetOrganization(ctx, competition.GetOrganizationID()); err != nil {
+			return 0, err
+		}
+	}
+
 	id, err := s.competitionRepo.CreateCompetition(ctx, competition)
 	if err != nil {
 		return 0, err
@@ -98,121 +237,389 @@ func (s *CompetitionService) CreateCompetition(ctx context.Context, competition
 	return id, nil
 }
 
-// Helper function to check if error is because participant already exists
-func isParticipantAlreadyExistsError(err error) bool {
-	return err != nil && strings.Contains(strings.ToLower(err.Error()), "duplicate")
+// UpdateCompetition updates the details of a competition, its chrono display preferences are kept
+func (s *CompetitionService) UpdateCompetition(ctx context.Context, competition *aggregate.Competition) (*aggregate.Competition, error) {
+	name := strings.TrimSpace(competition.GetName())
+	if name == "" {
+		return nil, ErrEmptyCompetitionName
+	}
+	if competition.GetDate().IsZero() || !aggregate.IsValidTimezone(competition.GetTimezone()) {
+		return nil, ErrInvalidCompetitionDate
+	}
+
+	existing, err := s.competitionRepo.GetCompetition(ctx, competition.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	existing.SetName(name)
+	existing.SetDescription(competition.GetDescription())
+	existing.SetDate(competition.GetDate())
+	existing.SetTimezone(competition.GetTimezone())
+	existing.SetLocation(competition.GetLocation())
+	existing.SetOrganizer(competition.GetOrganizer())
+	existing.SetContact(competition.GetContact())
+
+	if err := s.competitionRepo.UpdateCompetition(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
 }
 
-// AddParticipants creates multiple participants from a CSV or Excel file for a competition
-func (s *CompetitionService) AddParticipants(ctx context.Context, competitionID int32, file io.Reader, filename string) error {
+// importedRow is a row of a participants import file, with why it could not be read or the warning it is flagged with
+type importedRow struct {
+	line        int32
+	participant *aggregate.Participant
+	err         error
+	warning     string
+}
+
+// AddParticipants creates multiple participants from a CSV or Excel file for a competition and reports the outcome of each row.
+// An invalid row is reported with the reason and the next rows are still added, the rows whose dossard is already taken
+// are skipped. The rows over the maximum number of participants are waitlisted, or fail when the competition has no waitlist.
+// The rows without category are placed in the category of the age of their birth date, the rows whose category disagrees
+// with it are added and flagged. When the competition verifies the licences, the rows whose licence the register of the
+// federation does not know fail. The file is read one row at a time and the valid rows are created by batches, each in
+// one transaction, so that large files are imported with bounded memory. Empty rows are ignored, and a file that cannot
+// be read past a line fails the import with the batches created before it kept. On a dry run the rows are checked the
+// same way but nothing is written.
+// When the duplicates are checked, the rows that likely duplicate a participant or another row are reported before
+// anything is written and the import is held as a dry run when there are any. The rows are then held in memory, to be
+// compared with each other.
+func (s *CompetitionService) AddParticipants(ctx context.Context, competitionID int32, file io.Reader, filename string, dryRun, checkDuplicates bool) (*aggregate.ParticipantImportReport, error) {
 	// Check if competition exists
-	_, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	registered, err := s.openRegistrations(ctx, competition)
+	if err != nil {
+		return nil, err
 	}
 
 	// Determine file type based on extension
-	isCSV := strings.HasSuffix(strings.ToLower(filename), ".csv")
-	isExcel := strings.HasSuffix(strings.ToLower(filename), ".xlsx") || strings.HasSuffix(strings.ToLower(filename), ".xls")
+	rows, err := openParticipantRows(file, filename)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if !isCSV && !isExcel {
-		return fmt.Errorf("unsupported file format: %s. Only CSV and Excel files are supported", filename)
+	// Skip the header row
+	if _, err := rows.Next(); err != nil {
+		if err == io.EOF {
+			return nil, ErrInvalidFileFormat
+		}
+		return nil, fmt.Errorf("%w: failed to read the header row: %v", ErrInvalidFileFormat, err)
 	}
 
-	var rows [][]string
+	// Participants must be in a category of the competition when it defines categories
+	categories, err := s.categoryRepo.ListCategories(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
 
-	if isCSV {
-		// Handle CSV file
-		rows, err = s.readCSVFile(file)
-		if err != nil {
-			return fmt.Errorf("failed to read CSV file: %w", err)
-		}
-	} else {
-		// Handle Excel file
-		rows, err = s.readExcelFile(file)
-		if err != nil {
-			return fmt.Errorf("failed to read Excel file: %w", err)
+	// The licences are verified in the register of the federation when the competition asks for it
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dossards already taken are known beforehand so that a dry run reports the duplicates too
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[int32]bool, len(participants))
+	for _, participant := range participants {
+		taken[participant.GetDossardNumber()] = true
+	}
+
+	report := aggregate.NewParticipantImportReport(dryRun)
+
+	// next reads and parses the next row of the file, io.EOF after the last one
+	fileLine := int32(1)
+	next := func() (importedRow, error) {
+		for {
+			row, err := rows.Next()
+			if err == io.EOF {
+				return importedRow{}, err
+			}
+			fileLine++
+			if err != nil {
+				return importedRow{}, fmt.Errorf("%w: failed to read line %d: %v", ErrInvalidFileFormat, fileLine, err)
+			}
+			if len(row) == 0 {
+				continue
+			}
+
+			participant, err := parseParticipantRow(competitionID, categories, row)
+			return importedRow{line: fileLine, participant: participant, err: err}, nil
 		}
 	}
 
-	if len(rows) < 2 { // At least header row and one data row required
-		return ErrInvalidFileFormat
+	// Compare the readable rows with the participants and with each other before anything is written
+	if checkDuplicates {
+		held := []importedRow{}
+		readable := []importedRow{}
+		for {
+			row, err := next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			held = append(held, row)
+			if row.err == nil {
+				readable = append(readable, row)
+			}
+		}
+
+		report.SetDuplicates(findImportDuplicates(participants, readable))
+		if !dryRun && len(report.GetDuplicates()) > 0 {
+			report.Hold()
+			dryRun = true
+		}
+
+		// The rows are then processed from memory rather than read again
+		next = func() (importedRow, error) {
+			if len(held) == 0 {
+				return importedRow{}, io.EOF
+			}
+			row := held[0]
+			held = held[1:]
+			return row, nil
+		}
 	}
 
 	// Process participants
-	for i, row := range rows {
-		// Skip header row
-		if i == 0 {
-			continue
+	read := 0
+	pending := make([]importedRow, 0, participantImportBatchSize)
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
 		}
+		read++
 
-		// File should have at least 5 columns: dossard number, category, last name, first name, gender
-		if len(row) < 5 {
-			return fmt.Errorf("invalid format on row %d: expected at least 5 columns (dossard number, category, last name, first name, gender, club)", i+1)
+		line, participant := row.line, row.participant
+		if row.err != nil {
+			report.AddFailed(aggregate.NewParticipantImportRow(line, participant.GetDossardNumber(), row.err.Error()))
+			continue
 		}
 
-		// Parse dossard number (first column)
-		dossardStr := strings.TrimSpace(row[0])
-		dossard, err := strconv.ParseInt(dossardStr, 10, 32)
+		// Derive the category from the birth date when the row has none, and flag the category disagreeing with it
+		warning, err := assignCategory(competition, categories, participant)
 		if err != nil {
-			return fmt.Errorf("invalid dossard number on row %d: %w", i+1, err)
-		}
-
-		// Get category from file (second column)
-		categoryFromFile := strings.TrimSpace(row[1])
-		// Get last name (third column)
-		lastName := strings.TrimSpace(row[2])
-		// Get first name (fourth column)
-		firstName := strings.TrimSpace(row[3])
-		// Get gender (fifth column)
-		gender := strings.TrimSpace(strings.ToUpper(row[4]))
-		// Get club (sixth column, optional)
-		var club string
-		if len(row) > 5 {
-			club = strings.TrimSpace(row[5])
-		}
-
-		// Validate gender
-		if gender != "H" && gender != "F" {
-			return fmt.Errorf("invalid gender on row %d: expected 'H' or 'F', got '%s'", i+1, gender)
-		}
-
-		// Create participant
-		participant := aggregate.NewParticipant()
-		participant.SetCompetitionID(competitionID)
-		participant.SetDossardNumber(int32(dossard))
-		participant.SetFirstName(firstName)
-		participant.SetLastName(lastName)
-		participant.SetCategory(categoryFromFile)
-		participant.SetGender(gender)
-		participant.SetClub(club)
-
-		// Add participant to database
-		err = s.participantRepo.CreateParticipant(ctx, participant)
-		if err != nil {
-			// Check for duplicate participant error, continue with other participants if possible
-			if isParticipantAlreadyExistsError(err) {
-				// Log the error or handle it as needed
-				continue
+			report.AddFailed(aggregate.NewParticipantImportRow(line, participant.GetDossardNumber(), err.Error()))
+			continue
+		}
+
+		if taken[participant.GetDossardNumber()] {
+			report.AddSkipped(aggregate.NewParticipantImportRow(line, participant.GetDossardNumber(), "dossard already taken"))
+			continue
+		}
+
+		if err := s.verifyLicence(ctx, settings, participant); err != nil {
+			report.AddFailed(aggregate.NewParticipantImportRow(line, participant.GetDossardNumber(), err.Error()))
+			continue
+		}
+
+		// The rows over the maximum number of participants are waitlisted, or refused without waitlist
+		if err := placeParticipant(competition, participant, registered); err != nil {
+			report.AddFailed(aggregate.NewParticipantImportRow(line, participant.GetDossardNumber(), err.Error()))
+			continue
+		}
+
+		taken[participant.GetDossardNumber()] = true
+		if !participant.IsWaitlisted() {
+			registered++
+		}
+
+		// The participants are created by batches of multi-row inserts
+		pending = append(pending, importedRow{line: line, participant: participant, warning: warning})
+		if len(pending) == participantImportBatchSize {
+			if err := s.createImportedParticipants(ctx, report, pending, dryRun); err != nil {
+				return nil, err
 			}
-			return fmt.Errorf("failed to create participant (row %d): %w", i+1, err)
+			pending = pending[:0]
+		}
+	}
+
+	// At least one data row is required
+	if read == 0 {
+		return nil, ErrInvalidFileFormat
+	}
+
+	if err := s.createImportedParticipants(ctx, report, pending, dryRun); err != nil {
+		return nil, err
+	}
+
+	if !dryRun && len(report.GetSkipped()) > 0 {
+		log.Printf("Skipped %d participants of competition %d whose dossard is already taken: %v",
+			len(report.GetSkipped()), competitionID, report.GetSkippedDossards())
+	}
+
+	return report, nil
+}
+
+// createImportedParticipants creates the valid rows of an import in one batch and reports them, the rows whose dossard
+// was taken since the dossards were listed being skipped. Nothing is created on a dry run.
+func (s *CompetitionService) createImportedParticipants(ctx context.Context, report *aggregate.ParticipantImportReport, rows []importedRow, dryRun bool) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	taken := make(map[int32]bool)
+	if !dryRun {
+		participants := make([]*aggregate.Participant, 0, len(rows))
+		for _, row := range rows {
+			participants = append(participants, row.participant)
+		}
+
+		dossards, err := s.participantRepo.CreateParticipantsBatch(ctx, participants)
+		if err != nil {
+			return fmt.Errorf("failed to create participants (rows %d to %d): %w", rows[0].line, rows[len(rows)-1].line, err)
+		}
+		for _, dossard := range dossards {
+			taken[dossard] = true
+		}
+	}
+
+	for _, row := range rows {
+		dossard := row.participant.GetDossardNumber()
+		if taken[dossard] {
+			report.AddSkipped(aggregate.NewParticipantImportRow(row.line, dossard, "dossard already taken"))
+			continue
+		}
+
+		report.AddInserted(row.participant.IsWaitlisted())
+		if row.warning != "" {
+			report.AddFlagged(aggregate.NewParticipantImportRow(row.line, dossard, row.warning))
 		}
 	}
 
 	return nil
 }
 
-// readCSVFile reads data from a CSV file
-func (s *CompetitionService) readCSVFile(file io.Reader) ([][]string, error) {
-	reader := csv.NewReader(file)
-	reader.FieldsPerRecord = -1 // Allow variable number of fields
+// parseParticipantRow reads a row of a participants import file. On error, the participant returned holds the
+// dossard when it could be read.
+func parseParticipantRow(competitionID int32, categories []*aggregate.Category, row []string) (*aggregate.Participant, error) {
+	participant := aggregate.NewParticipant()
+	participant.SetCompetitionID(competitionID)
 
-	records, err := reader.ReadAll()
+	// File should have at least 5 columns: dossard number, category, last name, first name, gender
+	if len(row) < 5 {
+		return participant, errors.New("expected at least 5 columns (dossard number, category, last name, first name, gender, club)")
+	}
+
+	// Parse dossard number (first column)
+	dossardStr := strings.TrimSpace(row[0])
+	dossard, err := strconv.ParseInt(dossardStr, 10, 32)
 	if err != nil {
-		return nil, err
+		return participant, fmt.Errorf("invalid dossard number: %w", err)
+	}
+	if dossard <= 0 {
+		return participant, ErrInvalidDossardNumber
 	}
+	participant.SetDossardNumber(int32(dossard))
 
-	return records, nil
+	// Get category from file (second column), left empty to be derived from the birth date
+	var categoryFromFile string
+	if strings.TrimSpace(row[1]) != "" {
+		categoryFromFile, err = resolveCategory(categories, row[1])
+		if err != nil {
+			return participant, fmt.Errorf("invalid category: %w", err)
+		}
+	}
+	// Get last name (third column)
+	lastName := strings.TrimSpace(row[2])
+	// Get first name (fourth column)
+	firstName := strings.TrimSpace(row[3])
+	// Get gender (fifth column)
+	gender := strings.TrimSpace(strings.ToUpper(row[4]))
+	// Get club (sixth column, optional)
+	var club string
+	if len(row) > 5 {
+		club = strings.TrimSpace(row[5])
+	}
+	// Get data processing and photo rights consents (seventh and eighth columns, optional)
+	var consentDataProcessing, consentPhotoRights bool
+	if len(row) > 6 {
+		consentDataProcessing, err = parseConsent(row[6])
+		if err != nil {
+			return participant, fmt.Errorf("invalid data processing consent: %w", err)
+		}
+	}
+	if len(row) > 7 {
+		consentPhotoRights, err = parseConsent(row[7])
+		if err != nil {
+			return participant, fmt.Errorf("invalid photo rights consent: %w", err)
+		}
+	}
+
+	// Get club email (ninth column, optional)
+	var clubEmail string
+	if len(row) > 8 && strings.TrimSpace(row[8]) != "" {
+		address, err := mail.ParseAddress(strings.TrimSpace(row[8]))
+		if err != nil {
+			return participant, fmt.Errorf("invalid club email: %w", err)
+		}
+		clubEmail = address.Address
+	}
+
+	// Get licence number (tenth column, optional)
+	var licence string
+	if len(row) > 9 {
+		licence = strings.TrimSpace(row[9])
+	}
+	if len(licence) > maxLicenceLength {
+		return participant, fmt.Errorf("invalid licence number: at most %d characters", maxLicenceLength)
+	}
+
+	// Get birth date (eleventh column, optional)
+	var birthDate time.Time
+	if len(row) > 10 {
+		birthDate, err = parseBirthDate(row[10])
+		if err != nil {
+			return participant, err
+		}
+	}
+
+	// Validate gender
+	if gender != "H" && gender != "F" {
+		return participant, fmt.Errorf("invalid gender: expected 'H' or 'F', got '%s'", gender)
+	}
+
+	participant.SetFirstName(firstName)
+	participant.SetLastName(lastName)
+	participant.SetCategory(categoryFromFile)
+	participant.SetGender(gender)
+	participant.SetClub(club)
+	participant.SetClubEmail(clubEmail)
+	participant.SetLicence(licence)
+	participant.SetBirthDate(birthDate)
+	participant.SetConsentDataProcessing(consentDataProcessing)
+	participant.SetConsentPhotoRights(consentPhotoRights)
+
+	return participant, nil
+}
+
+// parseConsent parses a consent cell of an import file, an empty cell means no consent
+func parseConsent(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "non", "no", "false", "n":
+		return false, nil
+	case "1", "oui", "yes", "true", "o", "y", "x":
+		return true, nil
+	default:
+		return false, fmt.Errorf("expected 'oui' or 'non', got '%s'", value)
+	}
 }
 
 // readExcelFile reads data from an Excel file
@@ -235,22 +642,129 @@ func (s *CompetitionService) readExcelFile(file io.Reader) ([][]string, error) {
 	return rows, nil
 }
 
-func (s *CompetitionService) ListCompetitions(ctx context.Context) ([]*aggregate.Competition, error) {
-	competitions, err := s.competitionRepo.ListCompetitions(ctx)
+// ListCompetitions lists a page of the competitions matching the filter and counts all of them
+func (s *CompetitionService) ListCompetitions(ctx context.Context, filter *aggregate.CompetitionFilter, pageNumber, pageSize int32) ([]*aggregate.Competition, int32, error) {
+	if !slices.Contains(aggregate.CompetitionSorts, filter.GetSort()) {
+		return nil, 0, ErrInvalidCompetitionSort
+	}
+
+	dateFrom, dateTo, ok := filter.GetDateRange()
+	if !ok || (!dateFrom.IsZero() && !dateTo.IsZero() && dateFrom.After(dateTo)) {
+		return nil, 0, ErrInvalidCompetitionDateRange
+	}
+
+	return s.competitionRepo.SearchCompetitions(ctx, filter, pageNumber, pageSize)
+}
+
+// MarkCompetitionArchived archives a competition so that it is no longer listed by default, its records are kept.
+// Archiving it again keeps the first archive time.
+func (s *CompetitionService) MarkCompetitionArchived(ctx context.Context, competitionID int32) (*aggregate.Competition, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
 	if err != nil {
 		return nil, err
 	}
+	if competition.IsArchived() {
+		return competition, nil
+	}
+
+	competition.SetArchivedAt(time.Now())
+	if err := s.competitionRepo.UpdateCompetition(ctx, competition); err != nil {
+		return nil, err
+	}
+
+	return competition, nil
+}
+
+// SetCompetitionStatus moves the competition to the given status of its lifecycle.
+// Setting the current status again is a no-op, other moves must follow the allowed transitions.
+func (s *CompetitionService) SetCompetitionStatus(ctx context.Context, competitionID int32, status string) (*aggregate.Competition, error) {
+	if !isOneOf(status, aggregate.CompetitionStatuses) {
+		return nil, ErrInvalidCompetitionStatus
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if competition.GetStatus() == status {
+		return competition, nil
+	}
+	if !competition.CanTransitionTo(status) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	competition.SetStatus(status)
+	if err := s.competitionRepo.UpdateCompetition(ctx, competition); err != nil {
+		return nil, err
+	}
+
+	// The final results are published once the competition is closed
+	if status == aggregate.CompetitionStatusClosed {
+		s.publishClosedCompetition(competitionID)
+	}
+
+	return competition, nil
+}
+
+// DeleteCompetition deletes a competition with all its records.
+// Participants are not tied to the competition by a foreign key and are deleted first,
+// their runs and ranking follow them.
+func (s *CompetitionService) DeleteCompetition(ctx context.Context, competitionID int32) error {
+	_, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	for _, participant := range participants {
+		err = s.participantRepo.DeleteParticipant(ctx, competitionID, participant.GetDossardNumber())
+		if err != nil {
+			return fmt.Errorf("failed to delete participant %d: %w", participant.GetDossardNumber(), err)
+		}
+	}
 
-	return competitions, nil
+	return s.competitionRepo.DeleteCompetition(ctx, competitionID)
 }
 
-// CreateParticipant creates a single participant for a competition
+// CreateParticipant creates a single participant for a competition, waitlisted when the competition is full
 func (s *CompetitionService) CreateParticipant(ctx context.Context, participant *aggregate.Participant) error {
 	// Check if competition exists
-	_, err := s.competitionRepo.GetCompetition(ctx, participant.GetCompetitionID())
+	competition, err := s.competitionRepo.GetCompetition(ctx, participant.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkCategory(ctx, participant.GetCompetitionID(), participant.GetCategory(), participant.SetCategory); err != nil {
+		return err
+	}
+
+	// A club chosen among the clubs of the competition gives its name
+	if participant.GetClubID() != 0 {
+		club, err := s.clubRepo.GetClub(ctx, participant.GetCompetitionID(), participant.GetClubID())
+		if err != nil {
+			return err
+		}
+		participant.SetClub(club.GetName())
+	}
+
+	settings, err := s.competitionSettings(ctx, participant.GetCompetitionID())
 	if err != nil {
 		return err
 	}
+	if err := s.verifyLicence(ctx, settings, participant); err != nil {
+		return err
+	}
+
+	registered, err := s.openRegistrations(ctx, competition)
+	if err != nil {
+		return err
+	}
+	if err := placeParticipant(competition, participant, registered); err != nil {
+		return err
+	}
 
 	// Create participant
 	return s.participantRepo.CreateParticipant(ctx, participant)
@@ -267,16 +781,99 @@ func (s *CompetitionService) GetParticipant(ctx context.Context, competitionID i
 	return participant, nil
 }
 
-// ListParticipantsByCategory retrieves all participants for a competition by category
-func (s *CompetitionService) ListParticipantsByCategory(ctx context.Context, competitionID int32, category string) ([]*aggregate.Participant, error) {
+// RenumberParticipant changes the dossard number of a participant, keeping its runs and ranking.
+// With swap, the participant holding the new dossard number takes the old one, for two bibs handed to each other's participant.
+func (s *CompetitionService) RenumberParticipant(ctx context.Context, competitionID int32, dossardNumber int32, newDossardNumber int32, swap bool) (*aggregate.Participant, error) {
+	if newDossardNumber <= 0 {
+		return nil, ErrInvalidDossardNumber
+	}
+
+	if newDossardNumber == dossardNumber {
+		return s.participantRepo.GetParticipant(ctx, competitionID, dossardNumber)
+	}
+
+	var err error
+	if swap {
+		err = s.participantRepo.SwapParticipantDossards(ctx, competitionID, dossardNumber, newDossardNumber)
+	} else {
+		err = s.participantRepo.RenumberParticipant(ctx, competitionID, dossardNumber, newDossardNumber)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.GetParticipant(ctx, competitionID, newDossardNumber)
+}
+
+// DeleteParticipant deletes a participant with its runs, liveranking rows and pending runs in one transaction and
+// returns how many of them were deleted. On a dry run nothing is deleted and the records that would be are counted.
+// The participants of a closed competition are kept, its results being frozen.
+func (s *CompetitionService) DeleteParticipant(ctx context.Context, competitionID int32, dossardNumber int32, dryRun bool) (*aggregate.ParticipantRecords, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if competition.IsClosed() {
+		return nil, ErrCompetitionClosed
+	}
+
+	if dryRun {
+		return s.participantRepo.CountParticipantRecords(ctx, competitionID, dossardNumber)
+	}
+	return s.participantRepo.DeleteParticipantWithRecords(ctx, competitionID, dossardNumber)
+}
+
+// CountParticipantsToClear counts the participants of a competition and their records, and returns the token
+// confirming they can be cleared. The token only matches while the counts are the same.
+func (s *CompetitionService) CountParticipantsToClear(ctx context.Context, competitionID int32) (*aggregate.CompetitionParticipantRecords, string, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+	if competition.IsClosed() {
+		return nil, "", ErrCompetitionClosed
+	}
+
+	counted, err := s.participantRepo.CountCompetitionParticipantRecords(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return counted, clearParticipantsToken(competitionID, counted), nil
+}
+
+// ClearParticipants deletes every participant of a competition with their runs, liveranking rows and pending runs,
+// so that a botched import can be redone. The token returned by CountParticipantsToClear must be given and the
+// counts must not have changed since. The participants of a closed competition are kept, its results being frozen.
+func (s *CompetitionService) ClearParticipants(ctx context.Context, competitionID int32, confirmationToken string) (*aggregate.CompetitionParticipantRecords, error) {
+	_, token, err := s.CountParticipantsToClear(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if confirmationToken != token {
+		return nil, ErrInvalidClearToken
+	}
+
+	return s.participantRepo.ClearParticipants(ctx, competitionID)
+}
+
+// clearParticipantsToken derives the confirmation token of the clearing from the counts it removes
+func clearParticipantsToken(competitionID int32, counted *aggregate.CompetitionParticipantRecords) string {
+	records := counted.GetRecords()
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%d:%d:%d", competitionID, counted.GetParticipants(),
+		records.GetRuns(), records.GetLiverankings(), records.GetPendingRuns())))
+	return hex.EncodeToString(sum[:8])
+}
+
+// SearchParticipants lists a page of the participants of a competition matching the filter and counts all of them
+func (s *CompetitionService) SearchParticipants(ctx context.Context, competitionID int32, filter *aggregate.ParticipantFilter, pageNumber, pageSize int32) ([]*aggregate.Participant, int32, error) {
 	// Verify the competition exists
 	_, err := s.competitionRepo.GetCompetition(ctx, competitionID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Get participants from repository
-	return s.participantRepo.ListParticipantsByCategory(ctx, competitionID, category)
+	return s.participantRepo.SearchParticipants(ctx, competitionID, filter, pageNumber, pageSize)
 }
 
 // ListZones lists all zones for a competition
@@ -308,7 +905,20 @@ func (s *CompetitionService) AddScale(ctx context.Context, competitionID int32,
 		return err
 	}
 
-	return s.scaleRepo.CreateScale(ctx, scale)
+	if err := s.checkCategory(ctx, competitionID, scale.GetCategory(), scale.SetCategory); err != nil {
+		return err
+	}
+
+	if err := s.checkZonesPerCategory(ctx, competitionID, scale.GetCategory(), scale.GetZone()); err != nil {
+		return err
+	}
+
+	if err := s.scaleRepo.CreateScale(ctx, scale); err != nil {
+		return err
+	}
+
+	// The scale references its zone by name, which is described in the zones
+	return s.zoneRepo.EnsureZone(ctx, competitionID, scale.GetZone())
 }
 
 func (s *CompetitionService) UpdateScale(ctx context.Context, competitionID int32, scale *aggregate.Scale) error {
@@ -331,7 +941,7 @@ func (s *CompetitionService) DeleteScale(ctx context.Context, competitionID int3
 	return s.scaleRepo.DeleteScale(ctx, competitionID, category, zone)
 }
 
-func (s *CompetitionService) GetLiveranking(ctx context.Context, competitionID int32, category, gender string, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error) {
+func (s *CompetitionService) GetLiveranking(ctx context.Context, competitionID int32, category, gender string, checkedInOnly bool, pageNumber, pageSize int32) ([]*aggregate.Liveranking, int32, error) {
 	// check if competition exists
 	_, err := s.competitionRepo.GetCompetition(ctx, competitionID)
 	if err != nil {
@@ -342,10 +952,16 @@ func (s *CompetitionService) GetLiveranking(ctx context.Context, competitionID i
 		return nil, 0, ErrCategoryAndGender
 	}
 
-	return s.liverankingRepo.ListLiverankingByCategoryAndGender(ctx, competitionID, category, gender, pageNumber, pageSize)
+	return s.liverankingRepo.ListLiverankingByCategoryAndGender(ctx, competitionID, category, gender, checkedInOnly, pageNumber, pageSize)
 }
 
-func (s *CompetitionService) ExportCompetitionResults(ctx context.Context, competitionID int32) ([]byte, string, error) {
+// ExportCompetitionResults exports the results to an Excel file.
+// When a consent is given, only the participants who gave it are exported.
+func (s *CompetitionService) ExportCompetitionResults(ctx context.Context, competitionID int32, consent string) ([]byte, string, error) {
+	if consent != "" && !isValidConsent(consent) {
+		return nil, "", ErrInvalidConsent
+	}
+
 	// Get competition details for filename
 	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
 	if err != nil {
@@ -373,11 +989,32 @@ func (s *CompetitionService) ExportCompetitionResults(ctx context.Context, compe
 		return nil, "", err
 	}
 
+	if consent != "" {
+		participants = filterParticipantsByConsent(participants, consent)
+	}
+
 	// Group participants by category and gender
 	participantGroups := s.groupParticipantsByCategoryGender(participants)
 
+	// The runs expected in each zone and their scoring come from the settings of the competition
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fill the template of the organizer when there is one, the default layout otherwise
+	template, err := s.exportTemplateRepo.GetExportTemplate(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+
 	// Create Excel file
-	excelData, err := s.generateExcelFile(ctx, competitionID, participantGroups, runs, scales)
+	var excelData []byte
+	if template != nil {
+		excelData, err = s.generateTemplatedExcelFile(ctx, competition, settings, template, participantGroups, runs, scales)
+	} else {
+		excelData, err = s.generateExcelFile(ctx, competitionID, settings, participantGroups, runs, scales)
+	}
 	if err != nil {
 		return nil, "", err
 	}
@@ -385,6 +1022,27 @@ func (s *CompetitionService) ExportCompetitionResults(ctx context.Context, compe
 	return excelData, filename, nil
 }
 
+// isValidConsent returns whether the consent is one participants can give
+func isValidConsent(consent string) bool {
+	for _, known := range aggregate.Consents {
+		if consent == known {
+			return true
+		}
+	}
+	return false
+}
+
+// filterParticipantsByConsent keeps the participants who gave the consent
+func filterParticipantsByConsent(participants []*aggregate.Participant, consent string) []*aggregate.Participant {
+	var filtered []*aggregate.Participant
+	for _, participant := range participants {
+		if participant.HasConsent(consent) {
+			filtered = append(filtered, participant)
+		}
+	}
+	return filtered
+}
+
 // Helper method to get all participants for a competition
 func (s *CompetitionService) getAllParticipants(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error) {
 	// Get all categories first
@@ -412,7 +1070,8 @@ func (s *CompetitionService) getAllParticipants(ctx context.Context, competition
 	return allParticipants, nil
 }
 
-// Helper method to get all runs for a competition
+// Helper method to get the runs of a competition counting in the ranking, the voided ones and those waiting for
+// their approval left out
 func (s *CompetitionService) getAllRuns(ctx context.Context, competitionID int32) (map[string][]*aggregate.Run, error) {
 	allRuns, err := s.runRepo.ListRuns(ctx, competitionID)
 	if err != nil {
@@ -422,6 +1081,9 @@ func (s *CompetitionService) getAllRuns(ctx context.Context, competitionID int32
 	// Group runs by participant (competitionID_dossard)
 	runsByParticipant := make(map[string][]*aggregate.Run)
 	for _, run := range allRuns {
+		if !run.Counts() {
+			continue
+		}
 		key := fmt.Sprintf("%d_%d", run.GetCompetitionID(), run.GetDossard())
 		runsByParticipant[key] = append(runsByParticipant[key], run)
 	}
@@ -464,6 +1126,7 @@ func (s *CompetitionService) groupParticipantsByCategoryGender(participants []*a
 // Helper method to generate Excel file
 func (s *CompetitionService) generateExcelFile(ctx context.Context,
 	competitionID int32,
+	settings *aggregate.CompetitionSettings,
 	participantGroups map[string][]*aggregate.Participant,
 	runs map[string][]*aggregate.Run,
 	scales map[string]*aggregate.Scale,
@@ -497,7 +1160,7 @@ func (s *CompetitionService) generateExcelFile(ctx context.Context,
 		}
 
 		// Generate sheet content
-		err = s.generateSheetContent(f, sheetName, participants, zones, runs, scales, competitionID)
+		err = s.generateSheetContent(f, sheetName, participants, zones, settings, runs, scales, competitionID)
 		if err != nil {
 			continue
 		}
@@ -539,49 +1202,35 @@ type ParticipantResult struct {
 	ZoneResults  []ZoneResult
 	TotalPoints  int32
 	TotalPenalty int32
-	TotalTime    int32
+	TotalTimeMs  int32
 	HasError     bool
+	RunComments  []string // comments of the referees on the runs, prefixed by their zone
 }
 
 // ZoneResult represents the result for a specific zone
 type ZoneResult struct {
 	Points  int32
 	Penalty int32
-	Time    int32
+	TimeMs  int32
 	IsError bool
 }
 
 // Helper method to generate content for a sheet
-func (s *CompetitionService) generateSheetContent(f *excelize.File, sheetName string, participants []*aggregate.Participant, zones []string, runs map[string][]*aggregate.Run, scales map[string]*aggregate.Scale, competitionID int32) error {
+func (s *CompetitionService) generateSheetContent(f *excelize.File, sheetName string, participants []*aggregate.Participant, zones []string, settings *aggregate.CompetitionSettings, runs map[string][]*aggregate.Run, scales map[string]*aggregate.Scale, competitionID int32) error {
 	// Create headers based on zone count
 	headers := []string{"Position", "Dossard", "Nom", "Prénom", "Club"}
 
-	// Determine expected runs per zone
-	expectedRunsPerZone := 1
-	if len(zones) == 2 {
-		expectedRunsPerZone = 2
-	}
-
-	// Add zone headers
-	if len(zones) == 2 {
-		// 2 zones, 2 runs each: Zone1, Zone2, Zone1, Zone2
-		for i := 0; i < 2; i++ {
-			for _, zone := range zones {
-				headers = append(headers, fmt.Sprintf("%s Points", zone))
-				headers = append(headers, fmt.Sprintf("%s Penalités", zone))
-				headers = append(headers, fmt.Sprintf("%s Temps", zone))
-			}
-		}
-	} else {
-		// 4 zones, 1 run each
-		for _, zone := range zones {
+	// Add zone headers, the runs of a zone follow each other as in the results
+	expectedRunsPerZone := settings.ExpectedRunsPerZone(len(zones))
+	for _, zone := range zones {
+		for i := 0; i < expectedRunsPerZone; i++ {
 			headers = append(headers, fmt.Sprintf("%s Points", zone))
 			headers = append(headers, fmt.Sprintf("%s Penalités", zone))
 			headers = append(headers, fmt.Sprintf("%s Temps", zone))
 		}
 	}
 
-	headers = append(headers, "Total Points", "Total Penalités", "Total Temps", "Points Gagnés")
+	headers = append(headers, "Total Points", "Total Penalités", "Total Temps", "Points Gagnés", "Motif", "Commentaires")
 
 	// Write headers
 	for i, header := range headers {
@@ -589,10 +1238,98 @@ func (s *CompetitionService) generateSheetContent(f *excelize.File, sheetName st
 		f.SetCellValue(sheetName, cell, header)
 	}
 
+	results := s.computeSheetResults(participants, zones, settings, runs, scales, competitionID)
+
+	// Write data rows
+	for i, result := range results {
+		row := i + 2 // Start from row 2 (after headers)
+		col := 0
+
+		// Position, the participants who did not finish or were disqualified show their status instead
+		if status := result.statusLabel(); status != "" {
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), status)
+		} else {
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), i+1)
+		}
+		col++
+
+		// Participant info
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetDossardNumber())
+		col++
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetLastName())
+		col++
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetFirstName())
+		col++
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetClub())
+		col++
+
+		// Zone results
+		for _, zoneResult := range result.ZoneResults {
+			if zoneResult.IsError {
+				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+				col++
+				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+				col++
+				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+				col++
+			} else {
+				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), zoneResult.Points)
+				col++
+				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), zoneResult.Penalty)
+				col++
+				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), chronoSeconds(zoneResult.TimeMs))
+				col++
+			}
+		}
+
+		// Totals
+		if !result.IsRanked() {
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+			col++
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+			col++
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+			col++
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.unrankedLabel())
+		} else {
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.TotalPoints)
+			col++
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.TotalPenalty)
+			col++
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), chronoSeconds(result.TotalTimeMs))
+			col++
+			// Points earned based on ranking
+			pointsEarned := utils.GetPointsEarned(int32(i + 1))
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), pointsEarned)
+		}
+		col++
+
+		// Why the participant was disqualified or did not finish
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetStatusReason())
+		col++
+
+		// Gate disputes or hardware issues noted by the referees
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), strings.Join(result.RunComments, "\n"))
+	}
+
+	return nil
+}
+
+// computeSheetResults calculates the results of the participants of a sheet, ranked by total points,
+// penalties and time. Participants without the expected number of runs in a zone are ranked after the others,
+// followed by those who did not finish then the disqualified ones. Those who did not start are left out.
+// With the best run scoring, only the best run of each zone counts in the totals.
+func (s *CompetitionService) computeSheetResults(participants []*aggregate.Participant, zones []string, settings *aggregate.CompetitionSettings, runs map[string][]*aggregate.Run, scales map[string]*aggregate.Scale, competitionID int32) []ParticipantResult {
+	expectedRunsPerZone := settings.ExpectedRunsPerZone(len(zones))
+
 	// Calculate results for each participant
 	var results []ParticipantResult
 
 	for _, participant := range participants {
+		if participant.GetStatus() == aggregate.ParticipantStatusDNS {
+			continue
+		}
+
 		participantKey := fmt.Sprintf("%d_%d", competitionID, participant.GetDossardNumber())
 		participantRuns := runs[participantKey]
 
@@ -605,6 +1342,9 @@ func (s *CompetitionService) generateSheetContent(f *excelize.File, sheetName st
 		runsByZone := make(map[string][]*aggregate.Run)
 		for _, run := range participantRuns {
 			runsByZone[run.GetZone()] = append(runsByZone[run.GetZone()], run)
+			if run.GetComment() != "" {
+				result.RunComments = append(result.RunComments, fmt.Sprintf("%s: %s", run.GetZone(), run.GetComment()))
+			}
 		}
 
 		// Calculate results for each zone
@@ -624,135 +1364,124 @@ func (s *CompetitionService) generateSheetContent(f *excelize.File, sheetName st
 			}
 
 			// Process each run for this zone
-			for _, run := range zoneRuns {
-				points := s.calculateRunPoints(run, scales, participant.GetCategory(), zone)
-				result.ZoneResults[zoneIndex] = ZoneResult{
-					Points:  points,
+			var bestRun ZoneResult
+			for i, run := range zoneRuns {
+				zoneResult := ZoneResult{
+					Points:  s.calculateRunPoints(run, scales, participant.GetCategory(), zone),
 					Penalty: run.GetPenality(),
-					Time:    run.GetChronoSec(),
+					TimeMs:  run.GetChronoMs(),
 				}
+				result.ZoneResults[zoneIndex] = zoneResult
+				zoneIndex++
 
+				if settings.GetScoring() == aggregate.ScoringBestRun {
+					if i == 0 || isBetterRun(zoneResult, bestRun) {
+						bestRun = zoneResult
+					}
+					continue
+				}
 				if !result.HasError {
-					result.TotalPoints += points
-					result.TotalPenalty += run.GetPenality()
-					result.TotalTime += run.GetChronoSec()
+					result.TotalPoints += zoneResult.Points
+					result.TotalPenalty += zoneResult.Penalty
+					result.TotalTimeMs += zoneResult.TimeMs
 				}
-				zoneIndex++
+			}
+
+			if settings.GetScoring() == aggregate.ScoringBestRun && !result.HasError {
+				result.TotalPoints += bestRun.Points
+				result.TotalPenalty += bestRun.Penalty
+				result.TotalTimeMs += bestRun.TimeMs
 			}
 		}
 
 		results = append(results, result)
 	}
 
-	// Sort results by ranking (Total Points DESC, Total Penalty ASC, Total Time ASC)
+	// Sort results by ranking, the participants who could not be scored then those with a status last
 	sort.Slice(results, func(i, j int) bool {
-		if results[i].HasError && !results[j].HasError {
-			return false
-		}
-		if !results[i].HasError && results[j].HasError {
-			return true
+		if results[i].rankGroup() != results[j].rankGroup() {
+			return results[i].rankGroup() < results[j].rankGroup()
 		}
-		if results[i].TotalPoints != results[j].TotalPoints {
-			return results[i].TotalPoints > results[j].TotalPoints
-		}
-		if results[i].TotalPenalty != results[j].TotalPenalty {
-			return results[i].TotalPenalty < results[j].TotalPenalty
-		}
-		return results[i].TotalTime < results[j].TotalTime
+		return results[i].rankingScore().RanksBefore(results[j].rankingScore())
 	})
 
-	// Write data rows
-	for i, result := range results {
-		row := i + 2 // Start from row 2 (after headers)
-		col := 0
+	return results
+}
 
-		// Position
-		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), i+1)
-		col++
+// IsRanked returns whether the participant is given a rank and points, having all their runs and neither
+// abandoned nor been disqualified
+func (r ParticipantResult) IsRanked() bool {
+	return !r.HasError && r.Participant.GetStatus() == aggregate.ParticipantStatusRegistered
+}
 
-		// Participant info
-		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetDossardNumber())
-		col++
-		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetLastName())
-		col++
-		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetFirstName())
-		col++
-		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.Participant.GetClub())
-		col++
+// rankGroup returns the group the participant is ranked in: the scored participants, then those missing runs,
+// those who did not finish and the disqualified ones
+func (r ParticipantResult) rankGroup() int {
+	switch {
+	case r.Participant.GetStatus() == aggregate.ParticipantStatusDSQ:
+		return 3
+	case r.Participant.GetStatus() == aggregate.ParticipantStatusDNF:
+		return 2
+	case r.HasError:
+		return 1
+	default:
+		return 0
+	}
+}
 
-		// Zone results
-		for _, zoneResult := range result.ZoneResults {
-			if zoneResult.IsError {
-				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-				col++
-				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-				col++
-				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-				col++
-			} else {
-				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), zoneResult.Points)
-				col++
-				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), zoneResult.Penalty)
-				col++
-				f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), zoneResult.Time)
-				col++
-			}
-		}
+// statusLabel returns DNF or DSQ for the participants who did not finish or were disqualified, empty otherwise
+func (r ParticipantResult) statusLabel() string {
+	switch r.Participant.GetStatus() {
+	case aggregate.ParticipantStatusDNF, aggregate.ParticipantStatusDSQ:
+		return strings.ToUpper(r.Participant.GetStatus())
+	}
+	return ""
+}
 
-		// Totals
-		if result.HasError {
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-			col++
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-			col++
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-			col++
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), "ERROR")
-		} else {
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.TotalPoints)
-			col++
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.TotalPenalty)
-			col++
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), result.TotalTime)
-			col++
-			// Points earned based on ranking
-			pointsEarned := utils.GetPointsEarned(int32(i + 1))
-			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", string(rune('A'+col)), row), pointsEarned)
-		}
+// unrankedLabel returns what is shown instead of the totals of a participant who is not ranked: their status,
+// or ERROR when they miss runs
+func (r ParticipantResult) unrankedLabel() string {
+	if status := r.statusLabel(); status != "" {
+		return status
 	}
+	return "ERROR"
+}
 
-	return nil
+// rankingScore returns the totals of the participant the results are ranked on, in the same order as the live ranking
+func (r ParticipantResult) rankingScore() aggregate.RankingScore {
+	return aggregate.RankingScore{
+		Points:  r.TotalPoints,
+		Penalty: r.TotalPenalty,
+		Time:    r.TotalTimeMs,
+		Dossard: r.Participant.GetDossardNumber(),
+	}
+}
+
+// isBetterRun returns whether a run ranks before another, with more points, then less penalties, then a shorter time
+func isBetterRun(run, other ZoneResult) bool {
+	return aggregate.RankingScore{Points: run.Points, Penalty: run.Penalty, Time: run.TimeMs}.
+		RanksBefore(aggregate.RankingScore{Points: other.Points, Penalty: other.Penalty, Time: other.TimeMs})
+}
+
+// chronoSeconds returns a chrono in milliseconds as seconds, with the milliseconds as decimals, for the exports
+func chronoSeconds(chronoMs int32) float64 {
+	return float64(chronoMs) / 1000
 }
 
 // Helper method to calculate points for a run
 func (s *CompetitionService) calculateRunPoints(run *aggregate.Run, scales map[string]*aggregate.Scale, category, zone string) int32 {
+	// The runs not finished or disqualified score no points
+	if !run.IsScored() {
+		return 0
+	}
+
 	scaleKey := fmt.Sprintf("%s_%s", category, zone)
 	scale, exists := scales[scaleKey]
 	if !exists {
 		return 0
 	}
 
-	points := int32(0)
-	if run.GetDoor1() {
-		points += scale.GetPointsDoor1()
-	}
-	if run.GetDoor2() {
-		points += scale.GetPointsDoor2()
-	}
-	if run.GetDoor3() {
-		points += scale.GetPointsDoor3()
-	}
-	if run.GetDoor4() {
-		points += scale.GetPointsDoor4()
-	}
-	if run.GetDoor5() {
-		points += scale.GetPointsDoor5()
-	}
-	if run.GetDoor6() {
-		points += scale.GetPointsDoor6()
-	}
-
-	return points
+	return scale.CalculatePoints(run.GetDoors())
 }
 
 func (s *CompetitionService) GetCompetition(ctx context.Context, competitionID int32) (*aggregate.Competition, error) {
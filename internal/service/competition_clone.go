@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// CloneCompetition creates a new competition with the details, chrono preferences, scales, zone bounds
+// and export template of an existing one, so organizers can reuse the zones and categories of a past edition.
+// Participants, runs and rankings are not copied, the new competition starts as a draft.
+// The name and date override the copied ones when given, a YYYY-MM-DD date being read in the time zone of the
+// copied competition. Nothing is left behind when the copy fails.
+func (s *CompetitionService) CloneCompetition(ctx context.Context, competitionID int32, name, date string) (*aggregate.Competition, error) {
+	source, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := aggregate.NewCompetition()
+	clone.SetName(source.GetName() + " (copy)")
+	if name = strings.TrimSpace(name); name != "" {
+		clone.SetName(name)
+	}
+	clone.SetDescription(source.GetDescription())
+	clone.SetDate(source.GetDate())
+	clone.SetTimezone(source.GetTimezone())
+	if date != "" {
+		parsed, ok := aggregate.ParseCompetitionDate(date, source.GetTimezone())
+		if !ok {
+			return nil, ErrInvalidCompetitionDate
+		}
+		clone.SetDate(parsed)
+	}
+	clone.SetLocation(source.GetLocation())
+	clone.SetOrganizer(source.GetOrganizer())
+	clone.SetContact(source.GetContact())
+	clone.SetChronoFormat(source.GetChronoFormat())
+	clone.SetChronoDirection(source.GetChronoDirection())
+	clone.SetOrganizationID(source.GetOrganizationID())
+	clone.SetMaxParticipants(source.GetMaxParticipants())
+	clone.SetWaitlist(source.HasWaitlist())
+	// The registrations close as long before the copy as they closed before the copied competition
+	if !source.GetRegistrationDeadline().IsZero() {
+		clone.SetRegistrationDeadline(clone.GetDate().Add(source.GetRegistrationDeadline().Sub(source.GetDate())))
+	}
+
+	cloneID, err := s.competitionRepo.CreateCompetition(ctx, clone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cloneSettings(ctx, competitionID, cloneID); err != nil {
+		if err := s.DeleteCompetition(ctx, cloneID); err != nil {
+			log.Printf("Failed to remove partially cloned competition %d: %v", cloneID, err)
+		}
+		return nil, err
+	}
+
+	log.Printf("Cloned competition %d into competition %d", competitionID, cloneID)
+	return clone, nil
+}
+
+// cloneSettings copies the categories, scales, zones, zone bounds, settings and export template of a competition to another
+func (s *CompetitionService) cloneSettings(ctx context.Context, sourceID, cloneID int32) error {
+	categories, err := s.categoryRepo.ListCategories(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		category.SetCompetitionID(cloneID)
+		if err := s.categoryRepo.CreateCategory(ctx, category); err != nil {
+			return fmt.Errorf("failed to copy category %s: %w", category.GetName(), err)
+		}
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		scale, err := s.scaleRepo.GetScale(ctx, sourceID, zone.GetCategory(), zone.GetZone())
+		if err != nil {
+			return err
+		}
+		scale.SetCompetitionID(cloneID)
+		if err := s.scaleRepo.CreateScale(ctx, scale); err != nil {
+			return fmt.Errorf("failed to copy scale %s/%s: %w", scale.GetCategory(), scale.GetZone(), err)
+		}
+	}
+
+	zoneDescriptions, err := s.zoneRepo.ListZones(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zoneDescriptions {
+		zone.SetCompetitionID(cloneID)
+		zone.SetOpen(true)
+		if err := s.zoneRepo.CreateZone(ctx, zone); err != nil {
+			return fmt.Errorf("failed to copy zone %s: %w", zone.GetZone(), err)
+		}
+	}
+
+	boundsList, err := s.zoneBoundsRepo.ListZoneBounds(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	for _, bounds := range boundsList {
+		bounds.SetCompetitionID(cloneID)
+		if err := s.zoneBoundsRepo.SetZoneBounds(ctx, bounds); err != nil {
+			return fmt.Errorf("failed to copy the bounds of zone %s: %w", bounds.GetZone(), err)
+		}
+	}
+
+	settings, err := s.settingsRepo.GetCompetitionSettings(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if settings != nil {
+		settings.SetCompetitionID(cloneID)
+		if err := s.settingsRepo.SetCompetitionSettings(ctx, settings); err != nil {
+			return fmt.Errorf("failed to copy the competition settings: %w", err)
+		}
+	}
+
+	template, err := s.exportTemplateRepo.GetExportTemplate(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if template != nil {
+		template.SetCompetitionID(cloneID)
+		if err := s.exportTemplateRepo.SetExportTemplate(ctx, template); err != nil {
+			return fmt.Errorf("failed to copy the export template: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrInvalidContactRole is returned when a contact is given an unknown role
+	ErrInvalidContactRole = errors.New("invalid contact role: expected results or logistics")
+)
+
+// AddContact adds an organizer contact to a competition
+func (s *CompetitionService) AddContact(ctx context.Context, contact *aggregate.CompetitionContact) error {
+	if !isValidContactRole(contact.GetRole()) {
+		return ErrInvalidContactRole
+	}
+
+	// Check if competition exists
+	_, err := s.competitionRepo.GetCompetition(ctx, contact.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+
+	return s.contactRepo.CreateContact(ctx, contact)
+}
+
+// ListContacts lists the organizer contacts of a competition
+func (s *CompetitionService) ListContacts(ctx context.Context, competitionID int32) ([]*aggregate.CompetitionContact, error) {
+	return s.contactRepo.ListContacts(ctx, competitionID)
+}
+
+// DeleteContact removes an organizer contact from a competition
+func (s *CompetitionService) DeleteContact(ctx context.Context, competitionID, contactID int32) error {
+	return s.contactRepo.DeleteContact(ctx, competitionID, contactID)
+}
+
+// GetContactEmails returns the addresses notifications of the role must be sent to.
+// Competitions created before contacts existed only have the single contact string,
+// which is used as a fallback when it holds an email address.
+func (s *CompetitionService) GetContactEmails(ctx context.Context, competitionID int32, role string) ([]string, error) {
+	contacts, err := s.contactRepo.ListContacts(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := []string{}
+	for _, contact := range contacts {
+		if role == "" || contact.GetRole() == role {
+			emails = append(emails, contact.GetEmail())
+		}
+	}
+
+	if len(contacts) > 0 {
+		return emails, nil
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if address, err := mail.ParseAddress(strings.TrimSpace(competition.GetContact())); err == nil {
+		emails = append(emails, address.Address)
+	}
+
+	return emails, nil
+}
+
+func isValidContactRole(role string) bool {
+	for _, r := range aggregate.ContactRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+const (
+	// maxRunsPerZone is the highest number of runs a participant can be expected to make in a zone
+	maxRunsPerZone = 10
+	// maxZonesPerCategory is the highest number of zones a category can be limited to
+	maxZonesPerCategory = 20
+)
+
+var (
+	// ErrInvalidRunsPerZone is returned when the runs expected in each zone are out of range
+	ErrInvalidRunsPerZone = errors.New("runs per zone must be between 0 and 10")
+	// ErrInvalidZonesPerCategory is returned when the zones of each category are out of range
+	ErrInvalidZonesPerCategory = errors.New("zones per category must be between 0 and 20")
+	// ErrInvalidScoring is returned when the scoring is not one of the known ones
+	ErrInvalidScoring = errors.New("invalid scoring, expected all_runs or best_run")
+	// ErrInvalidRunDSQRule is returned when the rule of the disqualified runs is not one of the known ones
+	ErrInvalidRunDSQRule = errors.New("invalid run DSQ rule, expected zero_points or disqualify")
+	// ErrTooManyZones is returned when adding a zone to a category which already has the zones of the settings
+	ErrTooManyZones = errors.New("the category already has the number of zones of the competition settings")
+)
+
+// GetCompetitionSettings returns the settings of a competition, the default ones when they were never set
+func (s *CompetitionService) GetCompetitionSettings(ctx context.Context, competitionID int32) (*aggregate.CompetitionSettings, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.competitionSettings(ctx, competitionID)
+}
+
+// UpdateCompetitionSettings replaces the settings of a competition
+func (s *CompetitionService) UpdateCompetitionSettings(ctx context.Context, settings *aggregate.CompetitionSettings) error {
+	if settings.GetRunsPerZone() < 0 || settings.GetRunsPerZone() > maxRunsPerZone {
+		return ErrInvalidRunsPerZone
+	}
+	if settings.GetZonesPerCategory() < 0 || settings.GetZonesPerCategory() > maxZonesPerCategory {
+		return ErrInvalidZonesPerCategory
+	}
+	if settings.GetScoring() == "" {
+		settings.SetScoring(aggregate.ScoringAllRuns)
+	}
+	if !slices.Contains(aggregate.ScoringModes, settings.GetScoring()) {
+		return ErrInvalidScoring
+	}
+	if settings.GetRunDSQRule() == "" {
+		settings.SetRunDSQRule(aggregate.RunDSQZeroPoints)
+	}
+	if !slices.Contains(aggregate.RunDSQRules, settings.GetRunDSQRule()) {
+		return ErrInvalidRunDSQRule
+	}
+
+	if _, err := s.competitionRepo.GetCompetition(ctx, settings.GetCompetitionID()); err != nil {
+		return err
+	}
+
+	return s.settingsRepo.SetCompetitionSettings(ctx, settings)
+}
+
+// competitionSettings returns the stored settings of a competition, the default ones when there are none
+func (s *CompetitionService) competitionSettings(ctx context.Context, competitionID int32) (*aggregate.CompetitionSettings, error) {
+	settings, err := s.settingsRepo.GetCompetitionSettings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = aggregate.NewCompetitionSettings()
+		settings.SetCompetitionID(competitionID)
+	}
+	return settings, nil
+}
+
+// checkZonesPerCategory returns ErrTooManyZones when adding the zone would exceed the zones of its category
+func (s *CompetitionService) checkZonesPerCategory(ctx context.Context, competitionID int32, category, zone string) error {
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	if settings.GetZonesPerCategory() == 0 {
+		return nil
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	count := int32(0)
+	for _, zoneInfo := range zones {
+		if zoneInfo.GetCategory() != category {
+			continue
+		}
+		if zoneInfo.GetZone() == zone {
+			return nil
+		}
+		count++
+	}
+	if count >= settings.GetZonesPerCategory() {
+		return ErrTooManyZones
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+const (
+	// degradedModeFlushTimeout bounds a flush of the deferred liverankings
+	degradedModeFlushTimeout = time.Minute
+	// defaultDegradedModeInterval is used when the configured interval is not positive
+	defaultDegradedModeInterval = 30 * time.Second
+)
+
+// liverankingKey identifies the liveranking entry of a participant
+type liverankingKey struct {
+	competitionID int32
+	dossard       int32
+}
+
+// DegradedMode is the runtime switch relaxing the non-essential work of the write path when the
+// venue connection is saturated: notification emails are skipped and the liverankings of the
+// participants whose runs changed are recalculated in batches every interval instead of on every run.
+// A nil *DegradedMode is valid and is never enabled.
+type DegradedMode struct {
+	liverankingRepo     repository.LiverankingRepository
+	liverankingVersions *LiverankingVersions
+	interval            time.Duration
+
+	mutex     sync.Mutex
+	enabled   bool
+	enabledAt time.Time
+	enabledBy int32
+	pending   map[liverankingKey]bool
+	stopChan  chan struct{}
+}
+
+// NewDegradedMode creates the switch, disabled, and starts the batch recalculation of the liverankings
+func NewDegradedMode(liverankingRepo repository.LiverankingRepository, liverankingVersions *LiverankingVersions, interval time.Duration) *DegradedMode {
+	if interval <= 0 {
+		interval = defaultDegradedModeInterval
+	}
+
+	d := &DegradedMode{
+		liverankingRepo:     liverankingRepo,
+		liverankingVersions: liverankingVersions,
+		interval:            interval,
+		pending:             make(map[liverankingKey]bool),
+		stopChan:            make(chan struct{}),
+	}
+
+	go d.run()
+
+	return d
+}
+
+// IsEnabled returns whether the degraded mode is enabled
+func (d *DegradedMode) IsEnabled() bool {
+	if d == nil {
+		return false
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.enabled
+}
+
+// Enable enables the degraded mode on behalf of the user, enabling it again keeps its start time
+func (d *DegradedMode) Enable(userID int32) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.enabled {
+		return
+	}
+	d.enabled = true
+	d.enabledAt = time.Now()
+	d.enabledBy = userID
+}
+
+// Disable disables the degraded mode and recalculates the deferred liverankings right away
+func (d *DegradedMode) Disable(ctx context.Context) error {
+	d.mutex.Lock()
+	d.enabled = false
+	d.enabledAt = time.Time{}
+	d.enabledBy = 0
+	d.mutex.Unlock()
+
+	return d.flush(ctx)
+}
+
+// Status returns whether the degraded mode is enabled, since when and by whom, and the number of
+// liverankings waiting for the next batch
+func (d *DegradedMode) Status() (enabled bool, enabledAt time.Time, enabledBy int32, pending int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.enabled, d.enabledAt, d.enabledBy, len(d.pending)
+}
+
+// Interval returns the interval between two batches of liveranking recalculations
+func (d *DegradedMode) Interval() time.Duration {
+	return d.interval
+}
+
+// DeferLiveranking defers the liveranking recalculation of the participant to the next batch when
+// the degraded mode is enabled, it returns false when the caller must update the liveranking itself
+func (d *DegradedMode) DeferLiveranking(competitionID, dossard int32) bool {
+	if d == nil {
+		return false
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.enabled {
+		return false
+	}
+	d.pending[liverankingKey{competitionID: competitionID, dossard: dossard}] = true
+	return true
+}
+
+// Stop stops the batch recalculation of the liverankings
+func (d *DegradedMode) Stop() {
+	close(d.stopChan)
+}
+
+// run recalculates the deferred liverankings every interval, the batches keep running while the
+// mode is disabled so that runs recorded while it was being switched off are not left behind
+func (d *DegradedMode) run() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), degradedModeFlushTimeout)
+			if err := d.flush(ctx); err != nil {
+				log.Printf("Failed to recalculate the deferred liverankings: %v", err)
+			}
+			cancel()
+		case <-d.stopChan:
+			return
+		}
+	}
+}
+
+// flush recalculates the deferred liverankings, the ones that fail are kept for the next batch
+func (d *DegradedMode) flush(ctx context.Context) error {
+	d.mutex.Lock()
+	pending := d.pending
+	d.pending = make(map[liverankingKey]bool)
+	d.mutex.Unlock()
+
+	var firstErr error
+	changed := make(map[int32]bool)
+	for key := range pending {
+		err := d.liverankingRepo.RecalculateLiveranking(ctx, key.competitionID, key.dossard)
+		if err == nil {
+			changed[key.competitionID] = true
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		d.mutex.Lock()
+		d.pending[key] = true
+		d.mutex.Unlock()
+	}
+
+	for competitionID := range changed {
+		d.liverankingVersions.Increment(competitionID)
+	}
+
+	return firstErr
+}
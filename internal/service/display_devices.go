@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	// displayTokenType is the type of the long-lived tokens display devices exchange for access tokens
+	displayTokenType = "display"
+	// defaultDisplayTokenLifetime is the longest registration of a display device when it is not configured
+	defaultDisplayTokenLifetime = 7 * 24 * time.Hour
+	// maxDisplayDeviceNameLength is the size of the name column of the display devices
+	maxDisplayDeviceNameLength = 100
+)
+
+var (
+	// ErrInvalidDisplayDeviceName is returned when registering a display device without a name or with a too long one
+	ErrInvalidDisplayDeviceName = errors.New("the display device name must be between 1 and 100 characters")
+	// ErrInvalidDisplayLifetime is returned when registering a display device for longer than the configured lifetime
+	ErrInvalidDisplayLifetime = errors.New("the display device lifetime must be positive and not exceed the configured lifetime")
+	// ErrInvalidDisplayToken is returned when a display token is invalid, or its device revoked or expired
+	ErrInvalidDisplayToken = errors.New("invalid display token")
+)
+
+// RegisterDisplayDevice registers a scoreboard display of the competition and returns it with its display token.
+// The display token is only returned once: the device exchanges it for access tokens restricted to reading the
+// rankings of the competition, and keeps refreshing them with it until the device expires or is revoked.
+// Without lifetime, the device is registered for the configured display token lifetime.
+func (s *UserService) RegisterDisplayDevice(ctx context.Context, competitionID int32, name string, lifetime time.Duration, createdBy int32) (*aggregate.DisplayDevice, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxDisplayDeviceNameLength {
+		return nil, "", ErrInvalidDisplayDeviceName
+	}
+
+	if lifetime == 0 {
+		lifetime = s.displayTokenLifetime()
+	}
+	if lifetime < 0 || lifetime > s.displayTokenLifetime() {
+		return nil, "", ErrInvalidDisplayLifetime
+	}
+
+	// The device cannot display a competition which does not exist
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	device := aggregate.NewDisplayDevice()
+	device.SetCompetitionID(competitionID)
+	device.SetName(name)
+	device.SetCreatedBy(createdBy)
+	device.SetCreatedAt(now)
+	device.SetExpiresAt(now.Add(lifetime))
+
+	if err := s.displayRepo.CreateDisplayDevice(ctx, device); err != nil {
+		return nil, "", err
+	}
+
+	displayTokenClaims := jwt.MapClaims{
+		"did":  device.GetID(),
+		"cid":  competitionID,
+		"iss":  "golene-evasion.com",
+		"type": displayTokenType,
+		"jti":  uuid.NewString(),
+		"iat":  now.Unix(),
+		"exp":  device.GetExpiresAt().Unix(),
+	}
+
+	displayToken, err := s.keySet.Sign(displayTokenClaims)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return device, displayToken, nil
+}
+
+// ListDisplayDevices lists the display devices of the competition, revoked and expired ones included
+func (s *UserService) ListDisplayDevices(ctx context.Context, competitionID int32) ([]*aggregate.DisplayDevice, error) {
+	return s.displayRepo.ListDisplayDevices(ctx, competitionID)
+}
+
+// RevokeDisplayDevice revokes a display device of the competition. Its display token can no longer be exchanged,
+// the access token the device already has stays valid until it expires.
+func (s *UserService) RevokeDisplayDevice(ctx context.Context, competitionID, deviceID int32) error {
+	return s.displayRepo.RevokeDisplayDevice(ctx, competitionID, deviceID)
+}
+
+// LoginWithDisplayToken exchanges the display token of a device for an access token restricted to reading the
+// rankings of its competition. The display token is returned as the refresh token, so that the access token is
+// refreshed transparently like the ones of users.
+func (s *UserService) LoginWithDisplayToken(ctx context.Context, displayToken string) (*aggregate.JwtToken, error) {
+	token, err := jwt.Parse(displayToken, s.keySet.KeyFunc)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidDisplayToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidDisplayToken
+	}
+
+	if tokenType, _ := claims["type"].(string); tokenType != displayTokenType {
+		return nil, ErrInvalidDisplayToken
+	}
+
+	return s.issueDisplayTokens(ctx, displayToken, claims)
+}
+
+// issueDisplayTokens issues an access token to the device of a valid display token, after checking that the
+// device is still registered. The access token does not outlive the registration of the device.
+func (s *UserService) issueDisplayTokens(ctx context.Context, displayToken string, claims jwt.MapClaims) (*aggregate.JwtToken, error) {
+	deviceID, ok := claims["did"].(float64)
+	if !ok {
+		return nil, ErrInvalidDisplayToken
+	}
+	competitionID, ok := claims["cid"].(float64)
+	if !ok {
+		return nil, ErrInvalidDisplayToken
+	}
+
+	device, err := s.displayRepo.GetDisplayDevice(ctx, int32(deviceID))
+	if err != nil || !device.IsActive() || device.GetCompetitionID() != int32(competitionID) {
+		return nil, ErrInvalidDisplayToken
+	}
+
+	if err := s.displayRepo.TouchDisplayDevice(ctx, device.GetCompetitionID(), device.GetID()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.accessTokenLifetime())
+	if expiresAt.After(device.GetExpiresAt()) {
+		expiresAt = device.GetExpiresAt()
+	}
+
+	roles := []string{aggregate.DisplayRole(device.GetCompetitionID())}
+	accessTokenClaims := jwt.MapClaims{
+		"did":   device.GetID(),
+		"roles": roles,
+		"scope": aggregate.DisplayScope,
+		"iss":   "golene-evasion.com",
+		"type":  "access",
+		"jti":   uuid.NewString(),
+		"iat":   now.Unix(),
+		"exp":   expiresAt.Unix(),
+	}
+
+	accessTokenString, err := s.keySet.Sign(accessTokenClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtToken := aggregate.NewJwtToken()
+	jwtToken.SetAccessToken(accessTokenString)
+	jwtToken.SetRefreshToken(displayToken)
+	jwtToken.SetRoles(roles)
+
+	return jwtToken, nil
+}
+
+// displayTokenLifetime returns the longest registration of a display device
+func (s *UserService) displayTokenLifetime() time.Duration {
+	if s.cfg != nil && s.cfg.Jwt.DisplayTokenLifetime > 0 {
+		return s.cfg.Jwt.DisplayTokenLifetime
+	}
+	return defaultDisplayTokenLifetime
+}
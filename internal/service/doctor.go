@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/config"
+	"github.com/golang-jwt/jwt"
+)
+
+// CheckEmailServer connects and authenticates to the SMTP server of the configuration without sending any email
+func CheckEmailServer(ctx context.Context, cfg *config.Config) error {
+	if cfg.Email.Host == "" {
+		return ErrMissingEmailConfig
+	}
+
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", cfg.Email.Host, cfg.Email.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the email server: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, cfg.Email.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet the email server: %w", err)
+	}
+	defer client.Close()
+
+	// Same steps as smtp.SendMail, which sends the emails
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: cfg.Email.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS with the email server: %w", err)
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok {
+		auth := smtp.PlainAuth("", cfg.Email.Username, cfg.Email.Password, cfg.Email.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate to the email server: %w", err)
+		}
+	}
+
+	return client.Quit()
+}
+
+// CheckKeys signs a short-lived token with the active key and verifies it as the tokens of the users are
+func (ks *KeySet) CheckKeys() error {
+	token, err := ks.Sign(jwt.StandardClaims{
+		Subject:   "doctor",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign a token: %w", err)
+	}
+
+	parsed, err := jwt.Parse(token, ks.KeyFunc)
+	if err != nil {
+		return fmt.Errorf("failed to verify a signed token: %w", err)
+	}
+	if !parsed.Valid {
+		return errors.New("signed token is not valid")
+	}
+
+	return nil
+}
+
+// CheckStorage verifies that the files read by the service can be loaded and that the archives can be written
+func CheckStorage(cfg *config.Config) error {
+	if _, err := NewPasswordPolicy(cfg); err != nil {
+		return err
+	}
+
+	// Archives are written to the working directory and large workbooks are buffered in the temporary directory
+	for _, dir := range []string{".", os.TempDir()} {
+		file, err := os.CreateTemp(dir, "orkys-doctor-*")
+		if err != nil {
+			return fmt.Errorf("failed to write to %s: %w", dir, err)
+		}
+		file.Close()
+		os.Remove(file.Name())
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"sync"
+
+	"github.com/NiskuT/cross-api/internal/config"
+)
+
+const (
+	// emailQueueSize bounds the number of emails waiting to be sent
+	emailQueueSize = 500
+	// base64LineLength is the length of the lines of the base64 encoded attachments
+	base64LineLength = 76
+)
+
+var (
+	// ErrEmailQueueFull is returned when the emails do not fit in the queue
+	ErrEmailQueueFull = errors.New("too many emails are waiting to be sent, try again later")
+)
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Email is an HTML email, with its attachments
+type Email struct {
+	To          string
+	Subject     string
+	Body        string
+	Attachments []EmailAttachment
+}
+
+// EmailQueue sends emails in the background, one at a time, so that the requests sending many
+// emails answer without waiting for the mail server. Emails failing to be sent are logged and counted.
+type EmailQueue struct {
+	cfg     *config.Config
+	metrics *Metrics
+
+	mutex    sync.Mutex
+	emails   chan *Email
+	stopChan chan struct{}
+}
+
+// NewEmailQueue creates the queue and starts sending the emails added to it
+func NewEmailQueue(cfg *config.Config, metrics *Metrics) *EmailQueue {
+	q := &EmailQueue{
+		cfg:      cfg,
+		metrics:  metrics,
+		emails:   make(chan *Email, emailQueueSize),
+		stopChan: make(chan struct{}),
+	}
+
+	go q.run()
+
+	return q
+}
+
+// Enqueue adds the emails to the queue, none of them is added when they do not all fit
+func (q *EmailQueue) Enqueue(emails ...*Email) error {
+	if q.cfg.Email.Host == "" {
+		return ErrMissingEmailConfig
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.emails)+len(emails) > cap(q.emails) {
+		return ErrEmailQueueFull
+	}
+	for _, email := range emails {
+		q.emails <- email
+	}
+
+	return nil
+}
+
+// Stop stops sending the emails, the ones still queued are dropped
+func (q *EmailQueue) Stop() {
+	close(q.stopChan)
+}
+
+// run sends the queued emails until the queue is stopped
+func (q *EmailQueue) run() {
+	for {
+		select {
+		case email := <-q.emails:
+			if err := sendSMTPEmail(q.cfg, email); err != nil {
+				q.metrics.EmailFailed()
+				log.Printf("Failed to send the queued email %q to %s: %v", email.Subject, email.To, err)
+			}
+		case <-q.stopChan:
+			return
+		}
+	}
+}
+
+// sendSMTPEmail sends the email through the configured mail server
+func sendSMTPEmail(cfg *config.Config, email *Email) error {
+	if cfg.Email.Host == "" {
+		return ErrMissingEmailConfig
+	}
+
+	msg, err := composeEmail(cfg.Email.From, email)
+	if err != nil {
+		return err
+	}
+
+	// Set up authentication information
+	auth := smtp.PlainAuth("", cfg.Email.Username, cfg.Email.Password, cfg.Email.Host)
+
+	// Connect to the server, authenticate, set the sender and recipient, and send the email
+	return smtp.SendMail(
+		fmt.Sprintf("%s:%d", cfg.Email.Host, cfg.Email.Port),
+		auth,
+		cfg.Email.From,
+		[]string{email.To},
+		msg,
+	)
+}
+
+// composeEmail renders the email as a MIME message, a multipart one when it has attachments
+func composeEmail(from string, email *Email) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n"+
+		"To: %s\r\n"+
+		"Subject: %s\r\n"+
+		"MIME-Version: 1.0\r\n", from, email.To, mime.QEncoding.Encode("UTF-8", email.Subject))
+
+	if len(email.Attachments) == 0 {
+		fmt.Fprintf(&msg, "Content-Type: text/html; charset=UTF-8\r\n"+
+			"\r\n"+
+			"%s\r\n", email.Body)
+		return msg.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&msg)
+	fmt.Fprintf(&msg, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/html; charset=UTF-8"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write([]byte(email.Body)); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range email.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Encoded lines must not exceed 76 characters
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > 0 {
+			line := encoded[:min(base64LineLength, len(encoded))]
+			encoded = encoded[len(line):]
+			if _, err := part.Write([]byte(line + "\r\n")); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return msg.Bytes(), nil
+}
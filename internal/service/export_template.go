@@ -0,0 +1,358 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/utils"
+	"github.com/xuri/excelize/v2"
+)
+
+// maxExportTemplateSize bounds the size of an uploaded export template
+const maxExportTemplateSize = 5 << 20
+
+var (
+	ErrExportTemplateNotFound = errors.New("the competition has no export template")
+	ErrExportTemplateTooLarge = fmt.Errorf("export template too large: at most %d MB", maxExportTemplateSize>>20)
+	ErrInvalidExportTemplate  = errors.New("invalid export template: expected an XLSX workbook whose first sheet has a row of result placeholders such as {{rank}}")
+)
+
+// resultPlaceholder matches a cell of the results row of a template, the row is repeated for every participant.
+// The runs are numbered in the order of the default layout.
+var resultPlaceholder = regexp.MustCompile(`^\{\{(rank|dossard|last_name|first_name|club|status|status_reason|run_comments|total_points|total_penalty|total_time|points_earned|run(\d+)_(points|penalty|time))\}\}$`)
+
+// sheetPlaceholder matches the placeholders replaced in any text cell of a template
+var sheetPlaceholder = regexp.MustCompile(`\{\{(competition|date|location|organizer|category|gender|run(\d+)_zone)\}\}`)
+
+// SetExportTemplate stores the XLSX template the results export of the competition fills instead of the default layout
+func (s *CompetitionService) SetExportTemplate(ctx context.Context, competitionID int32, r io.Reader, filename string) (*aggregate.ExportTemplate, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(strings.ToLower(filename), ".xlsx") {
+		return nil, ErrInvalidExportTemplate
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, maxExportTemplateSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxExportTemplateSize {
+		return nil, ErrExportTemplateTooLarge
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, ErrInvalidExportTemplate
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(f.GetSheetName(0), excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, ErrInvalidExportTemplate
+	}
+	if findResultsRow(rows) == 0 {
+		return nil, ErrInvalidExportTemplate
+	}
+
+	template := aggregate.NewExportTemplate()
+	template.SetCompetitionID(competitionID)
+	template.SetFilename(filename)
+	template.SetContent(content)
+	template.SetUploadedAt(time.Now())
+	if err := s.exportTemplateRepo.SetExportTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetExportTemplate retrieves the export template of the competition
+func (s *CompetitionService) GetExportTemplate(ctx context.Context, competitionID int32) (*aggregate.ExportTemplate, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	template, err := s.exportTemplateRepo.GetExportTemplate(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrExportTemplateNotFound
+	}
+
+	return template, nil
+}
+
+// DeleteExportTemplate deletes the export template of the competition, the results are exported with the default layout again
+func (s *CompetitionService) DeleteExportTemplate(ctx context.Context, competitionID int32) error {
+	if _, err := s.GetExportTemplate(ctx, competitionID); err != nil {
+		return err
+	}
+
+	return s.exportTemplateRepo.DeleteExportTemplate(ctx, competitionID)
+}
+
+// findResultsRow returns the number of the first row holding a result placeholder, zero if there is none
+func findResultsRow(rows [][]string) int {
+	for i, row := range rows {
+		for _, value := range row {
+			if resultPlaceholder.MatchString(value) {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// generateTemplatedExcelFile fills the export template with a sheet per category and gender.
+// The first sheet of the template is copied for every group, its text placeholders are replaced
+// and its results row is repeated for every participant, keeping the styles of the template.
+func (s *CompetitionService) generateTemplatedExcelFile(ctx context.Context,
+	competition *aggregate.Competition,
+	settings *aggregate.CompetitionSettings,
+	template *aggregate.ExportTemplate,
+	participantGroups map[string][]*aggregate.Participant,
+	runs map[string][]*aggregate.Run,
+	scales map[string]*aggregate.Scale,
+) ([]byte, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(template.GetContent()))
+	if err != nil {
+		return nil, ErrInvalidExportTemplate
+	}
+	defer f.Close()
+
+	templateSheet := f.GetSheetName(0)
+	rows, err := f.GetRows(templateSheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	resultsRow := findResultsRow(rows)
+	if resultsRow == 0 {
+		return nil, ErrInvalidExportTemplate
+	}
+
+	// Copied sheets lose their pictures, e.g. the organizer logo, they are added back to every copy
+	pictures := make(map[string][]excelize.Picture)
+	pictureCells, err := f.GetPictureCells(templateSheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, cell := range pictureCells {
+		if pictures[cell], err = f.GetPictures(templateSheet, cell); err != nil {
+			return nil, err
+		}
+	}
+
+	groupKeys := make([]string, 0, len(participantGroups))
+	for groupKey := range participantGroups {
+		if len(strings.Split(groupKey, "_")) == 2 {
+			groupKeys = append(groupKeys, groupKey)
+		}
+	}
+	sort.Strings(groupKeys)
+
+	// Without participants the template is returned with its placeholders filled and no results
+	if len(groupKeys) == 0 {
+		if err := fillTemplateSheet(f, templateSheet, rows, resultsRow, competition, "", "", nil, nil, 1); err != nil {
+			return nil, err
+		}
+		buffer, err := f.WriteToBuffer()
+		if err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	}
+
+	// The template sheet becomes the sheet of the first group, the others are copied from it before it is filled
+	sheetNames := make([]string, len(groupKeys))
+	for i, groupKey := range groupKeys {
+		sheetNames[i] = strings.Replace(groupKey, "_", "-", 1)
+	}
+	if err := f.SetSheetName(templateSheet, sheetNames[0]); err != nil {
+		return nil, err
+	}
+	for _, sheetName := range sheetNames[1:] {
+		index, err := f.NewSheet(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.CopySheet(0, index); err != nil {
+			return nil, err
+		}
+		for cell, cellPictures := range pictures {
+			for i := range cellPictures {
+				if err := f.AddPictureFromBytes(sheetName, cell, &cellPictures[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for i, groupKey := range groupKeys {
+		parts := strings.Split(groupKey, "_")
+		category, gender := parts[0], parts[1]
+
+		zones, err := s.getZonesForCategory(ctx, competition.GetID(), category)
+		if err != nil {
+			return nil, err
+		}
+		results := s.computeSheetResults(participantGroups[groupKey], zones, settings, runs, scales, competition.GetID())
+
+		err = fillTemplateSheet(f, sheetNames[i], rows, resultsRow, competition, category, gender, zones, results, settings.ExpectedRunsPerZone(len(zones)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f.SetActiveSheet(0)
+
+	buffer, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// fillTemplateSheet replaces the text placeholders of a sheet copied from the template and repeats its results row
+func fillTemplateSheet(f *excelize.File, sheetName string, rows [][]string, resultsRow int,
+	competition *aggregate.Competition, category, gender string,
+	zones []string, results []ParticipantResult, expectedRunsPerZone int) error {
+	replacer := func(placeholder string) string {
+		match := sheetPlaceholder.FindStringSubmatch(placeholder)
+		switch match[1] {
+		case "competition":
+			return competition.GetName()
+		case "date":
+			return competition.GetDay()
+		case "location":
+			return competition.GetLocation()
+		case "organizer":
+			return competition.GetOrganizer()
+		case "category":
+			return category
+		case "gender":
+			return gender
+		}
+		// runN_zone
+		run, _ := strconv.Atoi(match[2])
+		if run < 1 || run > len(zones)*expectedRunsPerZone {
+			return ""
+		}
+		return zones[(run-1)/expectedRunsPerZone]
+	}
+
+	resultColumns := make(map[int]string)
+	for i, row := range rows {
+		for j, value := range row {
+			if i+1 == resultsRow {
+				if resultPlaceholder.MatchString(value) {
+					resultColumns[j+1] = value
+				}
+				continue
+			}
+			if !sheetPlaceholder.MatchString(value) {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheetName, cell, sheetPlaceholder.ReplaceAllStringFunc(value, replacer)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return f.RemoveRow(sheetName, resultsRow)
+	}
+	for i := 1; i < len(results); i++ {
+		if err := f.DuplicateRow(sheetName, resultsRow); err != nil {
+			return err
+		}
+	}
+
+	for i, result := range results {
+		for column, placeholder := range resultColumns {
+			cell, err := excelize.CoordinatesToCellName(column, resultsRow+i)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheetName, cell, resultValue(placeholder, i+1, result)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resultValue returns the value of a result placeholder for the participant ranked at the given position
+func resultValue(placeholder string, rank int, result ParticipantResult) interface{} {
+	match := resultPlaceholder.FindStringSubmatch(placeholder)
+	switch match[1] {
+	case "rank":
+		if status := result.statusLabel(); status != "" {
+			return status
+		}
+		return rank
+	case "dossard":
+		return result.Participant.GetDossardNumber()
+	case "last_name":
+		return result.Participant.GetLastName()
+	case "first_name":
+		return result.Participant.GetFirstName()
+	case "club":
+		return result.Participant.GetClub()
+	case "status":
+		return result.statusLabel()
+	case "status_reason":
+		return result.Participant.GetStatusReason()
+	case "run_comments":
+		return strings.Join(result.RunComments, "\n")
+	}
+
+	if !result.IsRanked() && match[2] == "" {
+		return result.unrankedLabel()
+	}
+	switch match[1] {
+	case "total_points":
+		return result.TotalPoints
+	case "total_penalty":
+		return result.TotalPenalty
+	case "total_time":
+		return chronoSeconds(result.TotalTimeMs)
+	case "points_earned":
+		return utils.GetPointsEarned(int32(rank))
+	}
+
+	// runN_points, runN_penalty or runN_time
+	run, _ := strconv.Atoi(match[2])
+	if run < 1 || run > len(result.ZoneResults) {
+		return ""
+	}
+	zoneResult := result.ZoneResults[run-1]
+	if zoneResult.IsError {
+		return result.unrankedLabel()
+	}
+	switch match[3] {
+	case "points":
+		return zoneResult.Points
+	case "penalty":
+		return zoneResult.Penalty
+	default:
+		return chronoSeconds(zoneResult.TimeMs)
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// findImportDuplicates compares the rows of an import with the participants of the competition and the previous rows
+// of the file, and returns a warning for each row that likely duplicates one of them: the same name once accents,
+// case and separators are ignored under another dossard, or the same dossard under another name.
+func findImportDuplicates(participants []*aggregate.Participant, rows []importedRow) []*aggregate.ParticipantImportRow {
+	byDossard := make(map[int32]*aggregate.Participant, len(participants))
+	for _, participant := range participants {
+		byDossard[participant.GetDossardNumber()] = participant
+	}
+
+	warnings := []*aggregate.ParticipantImportRow{}
+	for i, row := range rows {
+		participant := row.participant
+		dossard := participant.GetDossardNumber()
+		var reasons []string
+
+		// Same dossard, another name
+		if registered, ok := byDossard[dossard]; ok && !sameParticipantName(participant, registered) {
+			reasons = append(reasons, fmt.Sprintf("dossard %d is already registered to %s %s",
+				dossard, registered.GetFirstName(), registered.GetLastName()))
+		}
+		for _, previous := range rows[:i] {
+			if previous.participant.GetDossardNumber() == dossard && !sameParticipantName(participant, previous.participant) {
+				reasons = append(reasons, fmt.Sprintf("dossard %d is also given to %s %s on row %d",
+					dossard, previous.participant.GetFirstName(), previous.participant.GetLastName(), previous.line))
+			}
+		}
+
+		// Same name, another dossard
+		for _, registered := range participants {
+			if registered.GetDossardNumber() != dossard && registered.GetGender() == participant.GetGender() &&
+				sameParticipantName(participant, registered) {
+				reasons = append(reasons, fmt.Sprintf("%s %s is already registered with dossard %d",
+					registered.GetFirstName(), registered.GetLastName(), registered.GetDossardNumber()))
+			}
+		}
+		for _, previous := range rows[:i] {
+			if previous.participant.GetDossardNumber() != dossard && previous.participant.GetGender() == participant.GetGender() &&
+				sameParticipantName(participant, previous.participant) {
+				reasons = append(reasons, fmt.Sprintf("%s %s is also on row %d with dossard %d",
+					previous.participant.GetFirstName(), previous.participant.GetLastName(), previous.line,
+					previous.participant.GetDossardNumber()))
+			}
+		}
+
+		for _, reason := range reasons {
+			warnings = append(warnings, aggregate.NewParticipantImportRow(row.line, dossard, "probable duplicate: "+reason))
+		}
+	}
+
+	return warnings
+}
+
+// sameParticipantName checks if two participants have the same name, first and last names possibly swapped,
+// as ListParticipantDuplicates compares them
+func sameParticipantName(a, b *aggregate.Participant) bool {
+	name := normalizeName(a.GetFirstName()) + " " + normalizeName(a.GetLastName())
+	firstName, lastName := normalizeName(b.GetFirstName()), normalizeName(b.GetLastName())
+
+	distance := min(levenshtein(name, firstName+" "+lastName), levenshtein(name, lastName+" "+firstName))
+	return distance <= maxNameTypos(name)
+}
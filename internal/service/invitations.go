@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// invitationLifetime is the default validity of an invitation link
+	invitationLifetime = 15 * time.Minute
+	// emailInvitationLifetime is the validity of the invitation links sent by email, which may be read later
+	emailInvitationLifetime = 72 * time.Hour
+	// maxInvitationLifetime bounds how long an invitation link can be extended
+	maxInvitationLifetime = 7 * 24 * time.Hour
+	// maxInvitationBatchSize bounds how many single-use invitation links are generated in one call
+	maxInvitationBatchSize = 100
+)
+
+var (
+	// ErrInvalidInvitationLifetime is returned when an invitation is extended beyond the allowed duration
+	ErrInvalidInvitationLifetime = errors.New("invitation lifetime must be between 1 minute and 7 days")
+	// ErrInvalidInvitationCount is returned when too few or too many invitation links are requested at once
+	ErrInvalidInvitationCount = errors.New("invitation count must be between 1 and 100")
+)
+
+// AlreadyMemberError is returned when a user accepts an invitation to a competition they are already a member of
+type AlreadyMemberError struct {
+	CompetitionID int32
+	Role          string // role the user already has in the competition, referee or admin
+}
+
+func (e *AlreadyMemberError) Error() string {
+	return fmt.Sprintf("user is already %s of competition %d", e.Role, e.CompetitionID)
+}
+
+// GenerateRefereeInvitationToken records a referee invitation for the competition and returns its token
+func (s *UserService) GenerateRefereeInvitationToken(ctx context.Context, competitionID int32) (string, *aggregate.Invitation, error) {
+	return s.generateInvitationToken(ctx, competitionID, aggregate.InvitationRoleReferee, invitationLifetime)
+}
+
+// GenerateRefereeInvitationTokens records count single-use referee invitations for the competition, each valid
+// for the given duration (default: 72 hours) and optionally labelled with the zone the referee is expected at,
+// and returns their tokens in the same order
+func (s *UserService) GenerateRefereeInvitationTokens(ctx context.Context, competitionID int32, count int32, zone string, lifetime time.Duration) ([]string, []*aggregate.Invitation, error) {
+	if count < 1 || count > maxInvitationBatchSize {
+		return nil, nil, ErrInvalidInvitationCount
+	}
+	if lifetime == 0 {
+		lifetime = emailInvitationLifetime
+	}
+	if lifetime < time.Minute || lifetime > maxInvitationLifetime {
+		return nil, nil, ErrInvalidInvitationLifetime
+	}
+
+	tokens := make([]string, 0, count)
+	invitations := make([]*aggregate.Invitation, 0, count)
+	for i := int32(0); i < count; i++ {
+		token, invitation, err := s.generateInvitation(ctx, competitionID, aggregate.InvitationRoleReferee, lifetime, func(invitation *aggregate.Invitation) {
+			invitation.SetMaxAcceptances(1)
+			invitation.SetZone(zone)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		tokens = append(tokens, token)
+		invitations = append(invitations, invitation)
+	}
+
+	return tokens, invitations, nil
+}
+
+// BuildRefereeInvitationSheet returns a printable XLSX sheet listing the links of the referee invitations and its filename
+func (s *UserService) BuildRefereeInvitationSheet(competition *aggregate.Competition, tokens []string, invitations []*aggregate.Invitation) ([]byte, string, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Invitations"
+	f.SetSheetName("Sheet1", sheetName)
+
+	f.SetCellValue(sheetName, "A1", competition.GetName())
+	f.SetCellValue(sheetName, "A2", competition.GetDay())
+	for col, header := range []string{"N°", "Zone", "Lien", "Expire le"} {
+		f.SetCellValue(sheetName, fmt.Sprintf("%c4", 'A'+col), header)
+	}
+
+	for i, invitation := range invitations {
+		row := i + 5
+		link := fmt.Sprintf("%s/referee/invitation?token=%s", s.clientURL(), url.QueryEscape(tokens[i]))
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), i+1)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), invitation.GetZone())
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), link)
+		f.SetCellHyperLink(sheetName, fmt.Sprintf("C%d", row), link, "External")
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), invitation.GetExpiresAt().Format("02/01/2006 15:04"))
+	}
+	f.SetColWidth(sheetName, "B", "B", 20)
+	f.SetColWidth(sheetName, "C", "C", 80)
+	f.SetColWidth(sheetName, "D", "D", 18)
+
+	buffer, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := strings.ReplaceAll(competition.GetName(), " ", "_") + "_referee_invitations.xlsx"
+	return buffer.Bytes(), filename, nil
+}
+
+// GenerateAdminInvitationToken records a co-admin invitation for the competition and returns its token
+func (s *UserService) GenerateAdminInvitationToken(ctx context.Context, competitionID int32) (string, *aggregate.Invitation, error) {
+	return s.generateInvitationToken(ctx, competitionID, aggregate.InvitationRoleAdmin, invitationLifetime)
+}
+
+// InviteAdmin emails a co-admin invitation link of the competition
+func (s *UserService) InviteAdmin(ctx context.Context, email string, competition *aggregate.Competition) (*aggregate.Invitation, error) {
+	token, invitation, err := s.generateInvitationToken(ctx, competition.GetID(), aggregate.InvitationRoleAdmin, emailInvitationLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	link := fmt.Sprintf("%s/admin/invitation?token=%s", s.clientURL(), url.QueryEscape(token))
+
+	subject := "Golene Evasion - Invitation d'Organisateur"
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>Invitation - Golene Evasion</h2>
+			<p>Bonjour,</p>
+			<p>Vous avez été invité(e) à organiser la compétition : %s.</p>
+			<p>Pour accepter l'invitation, ouvrez <a href="%s">ce lien</a> avant le %s.</p>
+			<p>Cordialement,<br>L'équipe Golene Evasion</p>
+		</body>
+		</html>
+	`, competition.GetName(), link, invitation.GetExpiresAt().Format("02/01/2006 15:04"))
+
+	err = s.sendEmail(email, subject, body)
+	if err != nil {
+		// Note: Even if email sending fails, the invitation can still be shared by hand
+		return invitation, fmt.Errorf("invitation created but email sending failed: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// generateInvitationToken records an invitation granting the role on the competition and returns its token
+func (s *UserService) generateInvitationToken(ctx context.Context, competitionID int32, role string, lifetime time.Duration) (string, *aggregate.Invitation, error) {
+	return s.generateInvitation(ctx, competitionID, role, lifetime, nil)
+}
+
+// generateInvitation records an invitation granting the role on the competition, once set up by the optional
+// function, and returns its token
+func (s *UserService) generateInvitation(ctx context.Context, competitionID int32, role string, lifetime time.Duration, setup func(*aggregate.Invitation)) (string, *aggregate.Invitation, error) {
+	now := time.Now()
+	invitation := aggregate.NewInvitation()
+	invitation.SetID(uuid.NewString())
+	invitation.SetCompetitionID(competitionID)
+	invitation.SetRole(role)
+	invitation.SetCreatedAt(now)
+	invitation.SetExpiresAt(now.Add(lifetime))
+	if user, ok := ctx.Value("user").(entity.UserToken); ok {
+		invitation.SetCreatedBy(user.Id)
+	}
+	if setup != nil {
+		setup(invitation)
+	}
+
+	if err := s.invitationRepo.CreateInvitation(ctx, invitation); err != nil {
+		return "", nil, fmt.Errorf("failed to record invitation: %w", err)
+	}
+
+	token, err := s.signInvitationToken(invitation)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, invitation, nil
+}
+
+// ListInvitations lists the pending invitations of a competition
+func (s *UserService) ListInvitations(ctx context.Context, competitionID int32) ([]*aggregate.Invitation, error) {
+	return s.invitationRepo.ListPendingInvitations(ctx, competitionID)
+}
+
+// RevokeInvitation revokes a pending invitation, its token can no longer be accepted
+func (s *UserService) RevokeInvitation(ctx context.Context, competitionID int32, invitationID string) error {
+	return s.invitationRepo.RevokeInvitation(ctx, competitionID, invitationID)
+}
+
+// ExtendInvitation makes a pending invitation valid for the given duration from now and returns a new token,
+// the previous tokens of the invitation keep their original expiry
+func (s *UserService) ExtendInvitation(ctx context.Context, competitionID int32, invitationID string, lifetime time.Duration) (string, *aggregate.Invitation, error) {
+	if lifetime == 0 {
+		lifetime = invitationLifetime
+	}
+	if lifetime < time.Minute || lifetime > maxInvitationLifetime {
+		return "", nil, ErrInvalidInvitationLifetime
+	}
+
+	err := s.invitationRepo.ExtendInvitation(ctx, competitionID, invitationID, time.Now().Add(lifetime))
+	if err != nil {
+		return "", nil, err
+	}
+
+	invitation, err := s.invitationRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := s.signInvitationToken(invitation)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, invitation, nil
+}
+
+// signInvitationToken creates the JWT token of an invitation
+func (s *UserService) signInvitationToken(invitation *aggregate.Invitation) (string, error) {
+	invitationClaims := jwt.MapClaims{
+		"jti":            invitation.GetID(),
+		"competition_id": invitation.GetCompetitionID(),
+		"type":           invitation.GetRole() + "_invitation",
+		"iss":            "golene-evasion.com",
+		"exp":            invitation.GetExpiresAt().Unix(),
+	}
+
+	tokenString, err := s.keySet.Sign(invitationClaims)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// Helper function to verify and extract competition ID and invitation ID from an invitation token granting the role.
+// Tokens issued before invitations were recorded have no invitation ID and are accepted until they expire.
+func (s *UserService) verifyInvitationToken(ctx context.Context, token, role string) (int32, string, error) {
+	// Parse the invitation token
+	parsedToken, err := jwt.Parse(token, s.keySet.KeyFunc)
+
+	if err != nil || !parsedToken.Valid {
+		return 0, "", ErrInvalidToken
+	}
+
+	// Extract claims
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, "", ErrInvalidToken
+	}
+
+	// Verify token type
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != role+"_invitation" {
+		return 0, "", ErrInvalidToken
+	}
+
+	// Verify issuer
+	if !claims.VerifyIssuer("golene-evasion.com", true) {
+		return 0, "", ErrInvalidToken
+	}
+
+	// Extract competition ID
+	var competitionID int32
+	if id, ok := claims["competition_id"].(float64); ok {
+		competitionID = int32(id)
+	} else {
+		return 0, "", ErrInvalidToken
+	}
+
+	// Check the invitation was not revoked
+	invitationID, _ := claims["jti"].(string)
+	if invitationID != "" {
+		invitation, err := s.invitationRepo.GetInvitation(ctx, invitationID)
+		if err != nil || !invitation.IsPending() || invitation.GetCompetitionID() != competitionID || invitation.GetRole() != role {
+			return 0, "", ErrInvalidToken
+		}
+	}
+
+	return competitionID, invitationID, nil
+}
+
+// checkNotAlreadyMember returns an *AlreadyMemberError when the user already has the access granted by the invitation,
+// admins of the competition being considered referees too
+func checkNotAlreadyMember(user *aggregate.User, role string, competitionID int32) error {
+	satisfyingRoles := []string{aggregate.InvitationRoleAdmin}
+	if role == aggregate.InvitationRoleReferee {
+		satisfyingRoles = append(satisfyingRoles, aggregate.InvitationRoleReferee)
+	}
+
+	userRoles := make(map[string]bool)
+	for _, userRole := range user.GetRoleList() {
+		userRoles[userRole] = true
+	}
+
+	for _, satisfyingRole := range satisfyingRoles {
+		if userRoles[fmt.Sprintf("%s:%d", satisfyingRole, competitionID)] {
+			return &AlreadyMemberError{CompetitionID: competitionID, Role: satisfyingRole}
+		}
+	}
+
+	return nil
+}
+
+// countInvitationAcceptance records that a user accepted the invitation, failures are only logged
+func (s *UserService) countInvitationAcceptance(ctx context.Context, competitionID int32, invitationID string) {
+	if invitationID == "" {
+		return
+	}
+
+	if err := s.invitationRepo.AddInvitationAcceptance(ctx, competitionID, invitationID); err != nil {
+		log.Println("Error counting invitation acceptance:", err)
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/NiskuT/cross-api/internal/config"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	// ErrUnsupportedSigningMethod is returned when the configured signing method is not supported
+	ErrUnsupportedSigningMethod = errors.New("unsupported JWT signing method")
+	// ErrActiveKeyNotFound is returned when the active key ID does not match a configured private key
+	ErrActiveKeyNotFound = errors.New("active JWT key not found or has no private key")
+	// ErrUnknownKeyID is returned when a token references a key ID that is not configured
+	ErrUnknownKeyID = errors.New("unknown JWT key ID")
+)
+
+// signingKey is a single asymmetric key of the key set
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey interface{}
+	publicKey  interface{}
+}
+
+// KeySet signs and verifies JWT tokens.
+// With HS256 every token is signed with the shared secret. With RS256 or ES256 tokens
+// are signed with the active key and carry its ID in the "kid" header, while every
+// configured key is accepted for verification so keys can be rotated without
+// invalidating the sessions signed with the previous one. The tokens signed with the
+// shared secret are then refused, unless they are accepted during the switch from HS256.
+type KeySet struct {
+	method           jwt.SigningMethod
+	secretKey        []byte
+	acceptHMACTokens bool
+	activeKey        *signingKey
+	keys             map[string]*signingKey
+}
+
+// NewKeySet builds the key set from the JWT configuration
+func NewKeySet(cfg *config.Config) (*KeySet, error) {
+	ks := &KeySet{
+		secretKey:        []byte(cfg.Jwt.SecretKey),
+		acceptHMACTokens: cfg.Jwt.AcceptHMACTokens,
+		keys:             make(map[string]*signingKey),
+	}
+
+	switch cfg.Jwt.SigningMethod {
+	case "", jwt.SigningMethodHS256.Alg():
+		ks.method = jwt.SigningMethodHS256
+		return ks, nil
+	case jwt.SigningMethodRS256.Alg():
+		ks.method = jwt.SigningMethodRS256
+	case jwt.SigningMethodES256.Alg():
+		ks.method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningMethod, cfg.Jwt.SigningMethod)
+	}
+
+	for kid, path := range cfg.Jwt.Keys {
+		pemData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT key %s: %w", kid, err)
+		}
+
+		key, err := parseSigningKey(ks.method, pemData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT key %s: %w", kid, err)
+		}
+		key.id = kid
+		ks.keys[kid] = key
+	}
+
+	activeKey, ok := ks.keys[cfg.Jwt.ActiveKeyID]
+	if !ok || activeKey.privateKey == nil {
+		return nil, ErrActiveKeyNotFound
+	}
+	ks.activeKey = activeKey
+
+	return ks, nil
+}
+
+// parseSigningKey parses a PEM encoded private key, or a public key for retired keys that are only used for verification
+func parseSigningKey(method jwt.SigningMethod, pemData []byte) (*signingKey, error) {
+	key := &signingKey{method: method}
+
+	if method == jwt.SigningMethodRS256 {
+		if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemData); err == nil {
+			key.privateKey = privateKey
+			key.publicKey = &privateKey.PublicKey
+			return key, nil
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemData)
+		if err != nil {
+			return nil, err
+		}
+		key.publicKey = publicKey
+		return key, nil
+	}
+
+	if privateKey, err := jwt.ParseECPrivateKeyFromPEM(pemData); err == nil {
+		key.privateKey = privateKey
+		key.publicKey = &privateKey.PublicKey
+		return key, nil
+	}
+	publicKey, err := jwt.ParseECPublicKeyFromPEM(pemData)
+	if err != nil {
+		return nil, err
+	}
+	key.publicKey = publicKey
+	return key, nil
+}
+
+// Sign signs the claims with the active key
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.method, claims)
+
+	if ks.activeKey == nil {
+		return token.SignedString(ks.secretKey)
+	}
+
+	token.Header["kid"] = ks.activeKey.id
+	return token.SignedString(ks.activeKey.privateKey)
+}
+
+// KeyFunc returns the key used to verify a token, it is meant to be given to jwt.Parse
+func (ks *KeySet) KeyFunc(token *jwt.Token) (interface{}, error) {
+	kid, hasKid := token.Header["kid"].(string)
+
+	// Tokens without key ID are signed with the shared secret, which is retired by the asymmetric signing
+	// unless the sessions signed before the switch are still accepted
+	if !hasKid {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(ks.secretKey) == 0 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		if ks.method != jwt.SigningMethodHS256 && !ks.acceptHMACTokens {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return ks.secretKey, nil
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.publicKey, nil
+}
+
+// JWKS returns the public keys of the key set in the JSON Web Key Set format
+func (ks *KeySet) JWKS() models.JWKSResponse {
+	response := models.JWKSResponse{
+		Keys: make([]models.JWK, 0, len(ks.keys)),
+	}
+
+	kids := make([]string, 0, len(ks.keys))
+	for kid := range ks.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	for _, kid := range kids {
+		key := ks.keys[kid]
+		jwk := models.JWK{
+			KeyID:     key.id,
+			Algorithm: key.method.Alg(),
+			Use:       "sig",
+		}
+
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = publicKey.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+		default:
+			continue
+		}
+
+		response.Keys = append(response.Keys, jwk)
+	}
+
+	return response
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrUnknownLicence is returned when the register of the federation does not know the licence of a participant
+	ErrUnknownLicence = errors.New("licence number unknown to the federation register")
+	// ErrLicenceRegistryUnavailable is returned when the register of the federation cannot be queried
+	ErrLicenceRegistryUnavailable = errors.New("the federation register could not verify the licence")
+)
+
+// verifyLicence checks the licence of a participant in the register of the federation when the settings of the
+// competition verify them. The participants without licence are not checked.
+func (s *CompetitionService) verifyLicence(ctx context.Context, settings *aggregate.CompetitionSettings, participant *aggregate.Participant) error {
+	if !settings.VerifiesLicences() || participant.GetLicence() == "" || s.licenceRegistry == nil {
+		return nil
+	}
+
+	valid, err := s.licenceRegistry.CheckLicence(ctx, participant.GetLicence(), participant.GetLastName(), participant.GetFirstName())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLicenceRegistryUnavailable, err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: %s", ErrUnknownLicence, participant.GetLicence())
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LiverankingVersions is the shared counter of the liveranking versions of the competitions, increased whenever
+// a liveranking changes so that clients can wait for the next change instead of polling the liveranking.
+// Versions are kept in memory by each instance of the API and start from the startup time, so that a version
+// seen before a restart is never taken for a current one. A nil *LiverankingVersions is valid and never changes.
+type LiverankingVersions struct {
+	mutex    sync.Mutex
+	start    int64
+	versions map[int32]*liverankingVersion
+}
+
+// liverankingVersion is the liveranking version of a competition
+type liverankingVersion struct {
+	version int64
+	changed chan struct{} // closed, then replaced, when the version increases
+}
+
+// NewLiverankingVersions creates the counter of the liveranking versions
+func NewLiverankingVersions() *LiverankingVersions {
+	return &LiverankingVersions{
+		start:    time.Now().UnixMilli(),
+		versions: make(map[int32]*liverankingVersion),
+	}
+}
+
+// Current returns the liveranking version of the competition
+func (v *LiverankingVersions) Current(competitionID int32) int64 {
+	if v == nil {
+		return 0
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.get(competitionID).version
+}
+
+// Increment increases the liveranking version of the competition and wakes the clients waiting for it to change
+func (v *LiverankingVersions) Increment(competitionID int32) {
+	if v == nil {
+		return
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	current := v.get(competitionID)
+	current.version++
+	close(current.changed)
+	current.changed = make(chan struct{})
+}
+
+// Wait blocks until the liveranking version of the competition differs from version or the context is done,
+// and returns the current version
+func (v *LiverankingVersions) Wait(ctx context.Context, competitionID int32, version int64) int64 {
+	if v == nil {
+		if version == 0 {
+			<-ctx.Done()
+		}
+		return 0
+	}
+
+	for {
+		v.mutex.Lock()
+		current := v.get(competitionID)
+		latest, changed := current.version, current.changed
+		v.mutex.Unlock()
+
+		if latest != version {
+			return latest
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return v.Current(competitionID)
+		}
+	}
+}
+
+// get returns the liveranking version of the competition, the mutex must be held
+func (v *LiverankingVersions) get(competitionID int32) *liverankingVersion {
+	current, found := v.versions[competitionID]
+	if !found {
+		current = &liverankingVersion{version: v.start, changed: make(chan struct{})}
+		v.versions[competitionID] = current
+	}
+	return current
+}
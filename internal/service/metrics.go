@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// invitationStatsWindow is how far back the invitations are counted in the metrics
+const invitationStatsWindow = 30 * 24 * time.Hour
+
+// Metrics collects the business health metrics exposed in the Prometheus text format.
+// Counters live in memory and restart from zero with the process, which rate() and
+// increase() handle. Gauges are read from the database on every scrape.
+// A nil *Metrics is valid and records nothing.
+type Metrics struct {
+	mutex          sync.Mutex
+	runsRecorded   map[int32]int64
+	emailFailures  int64
+	invitationRepo repository.InvitationRepository
+}
+
+// NewMetrics creates the metrics, the invitation gauges are read from the repository
+func NewMetrics(invitationRepo repository.InvitationRepository) *Metrics {
+	return &Metrics{
+		runsRecorded:   make(map[int32]int64),
+		invitationRepo: invitationRepo,
+	}
+}
+
+// RunRecorded counts a run recorded in the competition
+func (m *Metrics) RunRecorded(competitionID int32) {
+	if m == nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.runsRecorded[competitionID]++
+}
+
+// EmailFailed counts an email that could not be sent
+func (m *Metrics) EmailFailed() {
+	if m == nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.emailFailures++
+}
+
+// Write writes the metrics in the Prometheus text exposition format
+func (m *Metrics) Write(ctx context.Context, w io.Writer) error {
+	m.mutex.Lock()
+	competitionIDs := make([]int32, 0, len(m.runsRecorded))
+	for competitionID := range m.runsRecorded {
+		competitionIDs = append(competitionIDs, competitionID)
+	}
+	sort.Slice(competitionIDs, func(i, j int) bool { return competitionIDs[i] < competitionIDs[j] })
+
+	runsRecorded := make([]int64, len(competitionIDs))
+	for i, competitionID := range competitionIDs {
+		runsRecorded[i] = m.runsRecorded[competitionID]
+	}
+	emailFailures := m.emailFailures
+	m.mutex.Unlock()
+
+	fmt.Fprintln(w, "# HELP cross_runs_recorded_total Runs recorded since the API started.")
+	fmt.Fprintln(w, "# TYPE cross_runs_recorded_total counter")
+	for i, competitionID := range competitionIDs {
+		fmt.Fprintf(w, "cross_runs_recorded_total{competition_id=\"%d\"} %d\n", competitionID, runsRecorded[i])
+	}
+
+	fmt.Fprintln(w, "# HELP cross_email_failures_total Emails that could not be sent since the API started.")
+	fmt.Fprintln(w, "# TYPE cross_email_failures_total counter")
+	fmt.Fprintf(w, "cross_email_failures_total %d\n", emailFailures)
+
+	stats, err := m.invitationRepo.ListInvitationStats(ctx, time.Now().Add(-invitationStatsWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	fmt.Fprintln(w, "# HELP cross_invitations Invitations created over the last 30 days.")
+	fmt.Fprintln(w, "# TYPE cross_invitations gauge")
+	for _, competitionStats := range stats {
+		fmt.Fprintf(w, "cross_invitations{competition_id=\"%d\"} %d\n", competitionStats.GetCompetitionID(), competitionStats.GetInvitations())
+	}
+
+	fmt.Fprintln(w, "# HELP cross_invitations_accepted Invitations created over the last 30 days and accepted at least once.")
+	fmt.Fprintln(w, "# TYPE cross_invitations_accepted gauge")
+	for _, competitionStats := range stats {
+		fmt.Fprintf(w, "cross_invitations_accepted{competition_id=\"%d\"} %d\n", competitionStats.GetCompetitionID(), competitionStats.GetAccepted())
+	}
+
+	return nil
+}
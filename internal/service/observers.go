@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrNotCompetitionObserver is returned when revoking the observer role of a user who does not have it
+	ErrNotCompetitionObserver = errors.New("user is not an observer of the competition")
+)
+
+// GrantObserverRole gives an existing user read access to the rankings, runs and participants of a competition
+func (s *UserService) GrantObserverRole(ctx context.Context, email string, competitionID int32) (*aggregate.User, error) {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	role := aggregate.ObserverRole(competitionID)
+	user.AddRole(role)
+
+	err = s.userRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.recordRoleGranted(ctx, user, role)
+
+	return user, nil
+}
+
+// RevokeObserverRole removes the observer role of a competition from a user.
+// Tokens already issued keep the role until they are refreshed.
+func (s *UserService) RevokeObserverRole(ctx context.Context, competitionID, userID int32) error {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.RemoveRole(aggregate.ObserverRole(competitionID)) {
+		return ErrNotCompetitionObserver
+	}
+
+	return s.userRepo.UpdateUser(ctx, user)
+}
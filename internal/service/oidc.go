@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/config"
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrUnknownOIDCProvider is returned when the provider is not configured
+	ErrUnknownOIDCProvider = errors.New("unknown OpenID Connect provider")
+	// ErrInvalidOIDCToken is returned when the ID token of the provider cannot be trusted
+	ErrInvalidOIDCToken = errors.New("invalid OpenID Connect ID token")
+	// ErrOIDCEmailNotVerified is returned when the provider does not vouch for the email of an existing account
+	ErrOIDCEmailNotVerified = errors.New("the email is not verified by the identity provider")
+)
+
+// OIDCIdentity is the identity of a user authenticated by an OpenID Connect provider
+type OIDCIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+}
+
+// oidcDiscovery holds the endpoints published by a provider in its discovery document
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcProvider caches the discovery document and the signing keys of a provider
+type oidcProvider struct {
+	cfg       config.OIDCProviderConfig
+	mutex     sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]interface{}
+}
+
+// OIDCClient authenticates users with the authorization code flow of the configured
+// OpenID Connect providers, e.g. the SSO of a federation.
+type OIDCClient struct {
+	providers       map[string]*oidcProvider
+	redirectBaseURL string
+	httpClient      *http.Client
+}
+
+// NewOIDCClient creates a client for the providers of the configuration
+func NewOIDCClient(cfg *config.Config) *OIDCClient {
+	client := &OIDCClient{
+		providers:       make(map[string]*oidcProvider),
+		redirectBaseURL: cfg.OIDC.RedirectBaseURL,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, providerCfg := range cfg.OIDC.Providers {
+		client.providers[providerCfg.Name] = &oidcProvider{cfg: providerCfg}
+	}
+
+	return client
+}
+
+// Providers returns the names of the configured providers
+func (o *OIDCClient) Providers() []string {
+	names := make([]string, 0, len(o.providers))
+	for name := range o.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AuthorizationURL returns the URL of the provider the user is redirected to for logging in
+func (o *OIDCClient) AuthorizationURL(ctx context.Context, name, state, nonce string) (string, error) {
+	provider, ok := o.providers[name]
+	if !ok {
+		return "", ErrUnknownOIDCProvider
+	}
+
+	discovery, err := o.discover(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", provider.cfg.ClientID)
+	params.Set("redirect_uri", o.redirectURI(name))
+	params.Set("scope", "openid email profile")
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange trades the authorization code for an ID token and returns the identity it asserts
+func (o *OIDCClient) Exchange(ctx context.Context, name, code, nonce string) (*OIDCIdentity, error) {
+	provider, ok := o.providers[name]
+	if !ok {
+		return nil, ErrUnknownOIDCProvider
+	}
+
+	discovery, err := o.discover(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.redirectURI(name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(provider.cfg.ClientID), url.QueryEscape(provider.cfg.ClientSecret))
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := o.doJSON(req, &tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to exchange the authorization code: %w", err)
+	}
+
+	token, err := jwt.Parse(tokenResponse.IDToken, func(token *jwt.Token) (interface{}, error) {
+		return o.verificationKey(ctx, provider, token)
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidOIDCToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok ||
+		!claims.VerifyIssuer(discovery.Issuer, true) ||
+		!claims.VerifyAudience(provider.cfg.ClientID, true) ||
+		!claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, ErrInvalidOIDCToken
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, ErrInvalidOIDCToken
+	}
+
+	identity := &OIDCIdentity{Provider: name}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.EmailVerified, _ = claims["email_verified"].(bool)
+	identity.FirstName, _ = claims["given_name"].(string)
+	identity.LastName, _ = claims["family_name"].(string)
+
+	if identity.Subject == "" || identity.Email == "" {
+		return nil, ErrInvalidOIDCToken
+	}
+
+	return identity, nil
+}
+
+// redirectURI returns the callback URL registered with the provider
+func (o *OIDCClient) redirectURI(name string) string {
+	return o.redirectBaseURL + "/auth/oidc/" + url.PathEscape(name) + "/callback"
+}
+
+// discover fetches the discovery document of the provider once
+func (o *OIDCClient) discover(ctx context.Context, provider *oidcProvider) (*oidcDiscovery, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.discovery != nil {
+		return provider.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovery oidcDiscovery
+	if err := o.doJSON(req, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to discover the %s provider: %w", provider.cfg.Name, err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != provider.cfg.Issuer {
+		return nil, fmt.Errorf("the %s provider announces the issuer %s", provider.cfg.Name, discovery.Issuer)
+	}
+
+	provider.discovery = &discovery
+	return provider.discovery, nil
+}
+
+// verificationKey returns the key of the provider that signed the token, the keys are
+// fetched again when the key ID is unknown so that the provider can rotate them
+func (o *OIDCClient) verificationKey(ctx context.Context, provider *oidcProvider, token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	provider.mutex.Lock()
+	key, ok := provider.keys[kid]
+	provider.mutex.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	discovery, err := o.discover(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks models.JWKSResponse
+	if err := o.doJSON(req, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch the keys of the %s provider: %w", provider.cfg.Name, err)
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if publicKey, err := parseJWK(jwk); err == nil {
+			keys[jwk.KeyID] = publicKey
+		}
+	}
+
+	provider.mutex.Lock()
+	provider.keys = keys
+	provider.mutex.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	return key, nil
+}
+
+// doJSON sends the request and decodes the JSON response
+func (o *OIDCClient) doJSON(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// parseJWK decodes an RSA or P-256 public key of a JSON Web Key Set
+func parseJWK(jwk models.JWK) (interface{}, error) {
+	decode := func(value string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Curve)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.KeyType)
+	}
+}
+
+// OIDCProviders returns the names of the OpenID Connect providers users can log in with
+func (s *UserService) OIDCProviders() []string {
+	if s.oidcClient == nil {
+		return []string{}
+	}
+	return s.oidcClient.Providers()
+}
+
+// OIDCAuthorizationURL returns the URL of the provider the user is redirected to for logging in
+func (s *UserService) OIDCAuthorizationURL(ctx context.Context, provider, state, nonce string) (string, error) {
+	if s.oidcClient == nil {
+		return "", ErrUnknownOIDCProvider
+	}
+	return s.oidcClient.AuthorizationURL(ctx, provider, state, nonce)
+}
+
+// LoginWithOIDC authenticates a user with the authorization code returned by an OpenID Connect provider.
+// The identity is linked to the account with the same email when the provider verified it,
+// otherwise a user without any role is created on the first login.
+func (s *UserService) LoginWithOIDC(ctx context.Context, provider, code, nonce string) (*aggregate.JwtToken, error) {
+	if s.oidcClient == nil {
+		return nil, ErrUnknownOIDCProvider
+	}
+
+	identity, err := s.oidcClient.Exchange(ctx, provider, code, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	details := "oidc:" + provider
+
+	user, err := s.userRepo.GetUserByIdentity(ctx, provider, identity.Subject)
+	if err != nil {
+		user, err = s.provisionOIDCUser(ctx, identity)
+		if err != nil {
+			s.recordAuthEvent(ctx, aggregate.AuthEventLoginFailed, 0, identity.Email, 0, details)
+			return nil, err
+		}
+	}
+
+	tokens, err := s.generateTokens(ctx, user, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAuthEvent(ctx, aggregate.AuthEventLogin, user.GetID(), user.GetEmail(), 0, details)
+	return tokens, nil
+}
+
+// provisionOIDCUser links the identity to the account with the same email, or creates the account
+func (s *UserService) provisionOIDCUser(ctx context.Context, identity *OIDCIdentity) (*aggregate.User, error) {
+	user, err := s.userRepo.GetUserByEmail(ctx, identity.Email)
+	if err == nil && user != nil {
+		// Only the provider vouching for the email proves the person owns the existing account
+		if !identity.EmailVerified {
+			return nil, ErrOIDCEmailNotVerified
+		}
+	} else {
+		user = aggregate.NewUser()
+		user.SetEmail(identity.Email)
+		user.SetFirstName(identity.FirstName)
+		user.SetLastName(identity.LastName)
+
+		// The account has no usable password until the user resets it
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(s.passwordPolicy.Generate(32)), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		user.SetPasswordHash(string(hashedPassword))
+
+		if err := s.userRepo.CreateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	if err := s.userRepo.LinkIdentity(ctx, identity.Provider, identity.Subject, user.GetID()); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrNotOrganizationAdmin is returned when revoking the organization admin role of a user who does not have it
+	ErrNotOrganizationAdmin = errors.New("user is not an admin of the organization")
+)
+
+// GrantOrganizationAdminRole gives an existing user admin access to every competition of an organization,
+// including the competitions added to it later
+func (s *UserService) GrantOrganizationAdminRole(ctx context.Context, email string, organizationID int32) (*aggregate.User, error) {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	role := aggregate.OrganizationAdminRole(organizationID)
+	user.AddRole(role)
+
+	err = s.userRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.recordRoleGranted(ctx, user, role)
+
+	return user, nil
+}
+
+// RevokeOrganizationAdminRole removes the organization admin role from a user.
+// Tokens already issued keep the role until they are refreshed.
+func (s *UserService) RevokeOrganizationAdminRole(ctx context.Context, organizationID, userID int32) error {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.RemoveRole(aggregate.OrganizationAdminRole(organizationID)) {
+		return ErrNotOrganizationAdmin
+	}
+
+	return s.userRepo.UpdateUser(ctx, user)
+}
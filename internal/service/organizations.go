@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrEmptyOrganizationName is returned when creating an organization without a name
+	ErrEmptyOrganizationName = errors.New("organization name cannot be empty")
+)
+
+// CreateOrganization creates an organization without competitions and returns its ID
+func (s *CompetitionService) CreateOrganization(ctx context.Context, organization *aggregate.Organization) (int32, error) {
+	organization.SetName(strings.TrimSpace(organization.GetName()))
+	if organization.GetName() == "" {
+		return 0, ErrEmptyOrganizationName
+	}
+	organization.SetCreatedAt(time.Now())
+
+	return s.organizationRepo.CreateOrganization(ctx, organization)
+}
+
+// GetOrganization returns an organization with its competitions
+func (s *CompetitionService) GetOrganization(ctx context.Context, organizationID int32) (*aggregate.Organization, error) {
+	return s.organizationRepo.GetOrganization(ctx, organizationID)
+}
+
+// ListOrganizations lists every organization with its competitions
+func (s *CompetitionService) ListOrganizations(ctx context.Context) ([]*aggregate.Organization, error) {
+	return s.organizationRepo.ListOrganizations(ctx)
+}
+
+// DeleteOrganization deletes an organization, its competitions are kept without organization
+func (s *CompetitionService) DeleteOrganization(ctx context.Context, organizationID int32) error {
+	return s.organizationRepo.DeleteOrganization(ctx, organizationID)
+}
+
+// SetCompetitionOrganization moves a competition to an organization, 0 detaching it, and returns the competition
+func (s *CompetitionService) SetCompetitionOrganization(ctx context.Context, competitionID, organizationID int32) (*aggregate.Competition, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationID != 0 {
+		if _, err := s.organizationRepo.GetOrganization(ctx, organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.organizationRepo.SetCompetitionOrganization(ctx, competitionID, organizationID); err != nil {
+		return nil, err
+	}
+
+	competition.SetOrganizationID(organizationID)
+	return competition, nil
+}
+
+// ListOrganizationCompetitionIDs lists the competitions of the organizations, none without organizations configured
+func (s *CompetitionService) ListOrganizationCompetitionIDs(ctx context.Context, organizationIDs []int32) ([]int32, error) {
+	if s.organizationRepo == nil {
+		return []int32{}, nil
+	}
+	return s.organizationRepo.ListOrganizationCompetitionIDs(ctx, organizationIDs)
+}
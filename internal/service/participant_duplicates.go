@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// nameFolder lowercases the accented letters of names and turns their separators into spaces
+var nameFolder = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "á", "a", "ã", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i", "ì", "i",
+	"ñ", "n",
+	"ô", "o", "ö", "o", "ó", "o", "ò", "o", "õ", "o",
+	"û", "u", "ü", "u", "ú", "u", "ù", "u",
+	"ÿ", "y", "ý", "y",
+	"œ", "oe", "æ", "ae",
+	"-", " ", "'", " ", "’", " ", ".", " ",
+)
+
+// ListParticipantDuplicates lists the pairs of participants of a competition that are likely the same person.
+// Participants of the same gender match when their names are the same once accents, case and separators are
+// ignored, first and last names possibly swapped, or differ by a typo: one letter for short names, two otherwise.
+func (s *CompetitionService) ListParticipantDuplicates(ctx context.Context, competitionID int32) ([]*aggregate.ParticipantDuplicate, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].GetDossardNumber() < participants[j].GetDossardNumber()
+	})
+
+	names := make([]string, len(participants))
+	swappedNames := make([]string, len(participants))
+	for i, participant := range participants {
+		firstName, lastName := normalizeName(participant.GetFirstName()), normalizeName(participant.GetLastName())
+		names[i] = firstName + " " + lastName
+		swappedNames[i] = lastName + " " + firstName
+	}
+
+	duplicates := []*aggregate.ParticipantDuplicate{}
+	for i := range participants {
+		for j := i + 1; j < len(participants); j++ {
+			if participants[i].GetGender() != participants[j].GetGender() {
+				continue
+			}
+
+			distance := min(levenshtein(names[i], names[j]), levenshtein(names[i], swappedNames[j]))
+			if distance > maxNameTypos(names[i]) {
+				continue
+			}
+
+			duplicate := aggregate.NewParticipantDuplicate()
+			duplicate.AddParticipant(participants[i])
+			duplicate.AddParticipant(participants[j])
+			duplicate.SetNameDistance(int32(distance))
+			duplicate.SetSameClub(normalizeName(participants[i].GetClub()) == normalizeName(participants[j].GetClub()))
+			duplicates = append(duplicates, duplicate)
+		}
+	}
+
+	// The most likely duplicates first
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if duplicates[i].GetNameDistance() != duplicates[j].GetNameDistance() {
+			return duplicates[i].GetNameDistance() < duplicates[j].GetNameDistance()
+		}
+		return duplicates[i].IsSameClub() && !duplicates[j].IsSameClub()
+	})
+
+	return duplicates, nil
+}
+
+// normalizeName lowercases a name without its accents, separators and extra spaces
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(nameFolder.Replace(strings.ToLower(name))), " ")
+}
+
+// maxNameTypos returns how many letters two names can differ by and still be considered the same
+func maxNameTypos(name string) int {
+	if len([]rune(name)) <= 10 {
+		return 1
+	}
+	return 2
+}
+
+// levenshtein returns the number of letters to insert, delete or replace to turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
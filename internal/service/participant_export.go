@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// ParticipantExportCSV exports the participants as a CSV file
+	ParticipantExportCSV = "csv"
+	// ParticipantExportExcel exports the participants as an Excel file
+	ParticipantExportExcel = "xlsx"
+)
+
+var (
+	// ErrInvalidParticipantExportFormat is returned when the export format is neither CSV nor Excel
+	ErrInvalidParticipantExportFormat = errors.New("invalid export format: expected csv or xlsx")
+)
+
+// participantExportHeader names the columns of the participants export, in the order AddParticipants reads them
+var participantExportHeader = []string{
+	"dossard", "category", "last_name", "first_name", "gender", "club",
+	"consent_data_processing", "consent_photo_rights", "club_email", "licence", "birth_date",
+}
+
+// ExportParticipants exports the participants of the competition, ordered by dossard, with the columns of the
+// participants import so that the start list can be edited and imported again, and returns the file with its filename.
+// The consents are written as oui or non. The CSV file is comma separated like the import expects and starts with a
+// byte order mark so that spreadsheets read the accents.
+func (s *CompetitionService) ExportParticipants(ctx context.Context, competitionID int32, format string) ([]byte, string, error) {
+	if format != ParticipantExportCSV && format != ParticipantExportExcel {
+		return nil, "", ErrInvalidParticipantExportFormat
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows := make([][]string, 0, len(participants)+1)
+	rows = append(rows, participantExportHeader)
+	for _, participant := range participants {
+		rows = append(rows, participantExportRow(participant))
+	}
+
+	var data []byte
+	if format == ParticipantExportExcel {
+		data, err = writeParticipantsExcel(rows)
+	} else {
+		data, err = writeParticipantsCSV(rows)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := strings.ReplaceAll(competition.GetName(), " ", "_") + "_participants." + format
+	return data, filename, nil
+}
+
+// participantExportRow returns the cells of a participant in the order of participantExportHeader
+func participantExportRow(participant *aggregate.Participant) []string {
+	return []string{
+		strconv.Itoa(int(participant.GetDossardNumber())),
+		participant.GetCategory(),
+		participant.GetLastName(),
+		participant.GetFirstName(),
+		participant.GetGender(),
+		participant.GetClub(),
+		formatConsent(participant.GetConsentDataProcessing()),
+		formatConsent(participant.GetConsentPhotoRights()),
+		participant.GetClubEmail(),
+		participant.GetLicence(),
+		participant.GetBirthDay(),
+	}
+}
+
+// formatConsent writes a consent the way parseConsent reads it
+func formatConsent(consent bool) string {
+	if consent {
+		return "oui"
+	}
+	return "non"
+}
+
+// writeParticipantsCSV writes the rows of the participants export as a CSV file
+func writeParticipantsCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\xef\xbb\xbf")
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeParticipantsExcel writes the rows of the participants export as an Excel file, the dossards as numbers
+func writeParticipantsExcel(rows [][]string) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Participants"
+	f.SetSheetName("Sheet1", sheetName)
+
+	for i, row := range rows {
+		for j, value := range row {
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return nil, err
+			}
+			if dossard, err := strconv.Atoi(value); i > 0 && j == 0 && err == nil {
+				f.SetCellValue(sheetName, cell, dossard)
+				continue
+			}
+			f.SetCellValue(sheetName, cell, value)
+		}
+	}
+	f.SetColWidth(sheetName, "B", "F", 20)
+	f.SetColWidth(sheetName, "I", "I", 30)
+
+	buffer, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
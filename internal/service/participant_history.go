@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/utils"
+)
+
+var (
+	// ErrEmptyParticipantHistoryQuery is returned when looking for the history of a person without licence nor name
+	ErrEmptyParticipantHistoryQuery = errors.New("a licence number or a name is required")
+)
+
+// GetParticipantHistory lists the results of a person across the competitions, the latest first, for the organizers
+// to seed their start orders on past performances. The person is recognised by their licence number or their full
+// name. As for the series standings, only the competitions where they agreed to the processing of their data count,
+// and draft competitions are left out. The results are ranked as the results export ranks them.
+func (s *CompetitionService) GetParticipantHistory(ctx context.Context, licence, name string) ([]*aggregate.ParticipantHistoryEntry, error) {
+	licence = strings.TrimSpace(licence)
+	name = strings.Join(strings.Fields(name), " ")
+	if licence == "" && name == "" {
+		return nil, ErrEmptyParticipantHistoryQuery
+	}
+
+	entries, err := s.participantRepo.ListParticipantEntries(ctx, licence, name)
+	if err != nil {
+		return nil, err
+	}
+
+	history := []*aggregate.ParticipantHistoryEntry{}
+	competitions := make(map[int32]*aggregate.Competition)
+	results := make(map[int32]map[string][]ParticipantResult)
+	for _, entry := range entries {
+		if !entry.GetConsentDataProcessing() {
+			continue
+		}
+
+		competitionID := entry.GetCompetitionID()
+		competition, ok := competitions[competitionID]
+		if !ok {
+			competition, err = s.competitionRepo.GetCompetition(ctx, competitionID)
+			if err != nil {
+				return nil, err
+			}
+			competitions[competitionID] = competition
+		}
+		if competition.GetStatus() == aggregate.CompetitionStatusDraft {
+			continue
+		}
+
+		competitionResults, ok := results[competitionID]
+		if !ok {
+			competitionResults, err = s.competitionResults(ctx, competitionID)
+			if err != nil {
+				return nil, err
+			}
+			results[competitionID] = competitionResults
+		}
+
+		historyEntry := &aggregate.ParticipantHistoryEntry{
+			CompetitionID:   competitionID,
+			CompetitionName: competition.GetName(),
+			CompetitionDate: competition.GetDate(),
+			CompetitionDay:  competition.GetDay(),
+			DossardNumber:   entry.GetDossardNumber(),
+			FirstName:       entry.GetFirstName(),
+			LastName:        entry.GetLastName(),
+			Club:            entry.GetClub(),
+			Category:        entry.GetCategory(),
+			Gender:          entry.GetGender(),
+			Status:          entry.GetStatus(),
+		}
+
+		groupResults := competitionResults[fmt.Sprintf("%s_%s", entry.GetCategory(), entry.GetGender())]
+		for i, result := range groupResults {
+			if result.IsRanked() {
+				historyEntry.Ranked++
+			}
+			if result.Participant.GetDossardNumber() != entry.GetDossardNumber() {
+				continue
+			}
+			historyEntry.TotalPoints = result.TotalPoints
+			historyEntry.TotalPenalty = result.TotalPenalty
+			historyEntry.TotalTime = result.TotalTimeMs / 1000
+			historyEntry.Complete = !result.HasError
+			if result.IsRanked() {
+				historyEntry.Rank = int32(i + 1)
+				historyEntry.PointsEarned = utils.GetPointsEarned(historyEntry.Rank)
+			}
+		}
+
+		history = append(history, historyEntry)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].CompetitionDate.After(history[j].CompetitionDate)
+	})
+
+	return history, nil
+}
+
+// competitionResults ranks the participants of each category and gender of a competition as the results export
+// does, by category and gender
+func (s *CompetitionService) competitionResults(ctx context.Context, competitionID int32) (map[string][]ParticipantResult, error) {
+	participants, err := s.getAllParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.getAllRuns(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	scales, err := s.getAllScales(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]ParticipantResult)
+	for groupKey, groupParticipants := range s.groupParticipantsByCategoryGender(participants) {
+		parts := strings.Split(groupKey, "_")
+		if len(parts) != 2 {
+			continue
+		}
+
+		zones, err := s.getZonesForCategory(ctx, competitionID, parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		results[groupKey] = s.computeSheetResults(groupParticipants, zones, settings, runs, scales, competitionID)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// participantRowReader reads the rows of a participants import file one at a time, so that a large file is never
+// held in memory as a whole
+type participantRowReader interface {
+	// Next returns the next row of the file, io.EOF after the last one
+	Next() ([]string, error)
+	Close() error
+}
+
+// openParticipantRows opens a CSV or Excel participants import file, told apart by the extension of its name
+func openParticipantRows(file io.Reader, filename string) (participantRowReader, error) {
+	lowerFilename := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lowerFilename, ".csv"):
+		return newCSVParticipantRows(file), nil
+	case strings.HasSuffix(lowerFilename, ".xlsx") || strings.HasSuffix(lowerFilename, ".xls"):
+		rows, err := newExcelParticipantRows(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read Excel file: %v", ErrInvalidFileFormat, err)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported file %s, only CSV and Excel files are supported", ErrInvalidFileFormat, filename)
+	}
+}
+
+// csvParticipantRows reads the records of a CSV file as they are parsed
+type csvParticipantRows struct {
+	reader *csv.Reader
+}
+
+func newCSVParticipantRows(file io.Reader) *csvParticipantRows {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	reader.ReuseRecord = true   // Each row is parsed before the next one is read
+
+	return &csvParticipantRows{reader: reader}
+}
+
+func (r *csvParticipantRows) Next() ([]string, error) {
+	return r.reader.Read()
+}
+
+func (r *csvParticipantRows) Close() error {
+	return nil
+}
+
+// excelParticipantRows reads the rows of the first sheet of an Excel file with the streaming reader of excelize,
+// which keeps large sheets in a temporary file rather than in memory
+type excelParticipantRows struct {
+	file *excelize.File
+	rows *excelize.Rows
+}
+
+func newExcelParticipantRows(file io.Reader) (*excelParticipantRows, error) {
+	xlsx, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := xlsx.Rows(xlsx.GetSheetName(0))
+	if err != nil {
+		xlsx.Close()
+		return nil, err
+	}
+
+	return &excelParticipantRows{file: xlsx, rows: rows}, nil
+}
+
+func (r *excelParticipantRows) Next() ([]string, error) {
+	if !r.rows.Next() {
+		if err := r.rows.Error(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	return r.rows.Columns()
+}
+
+func (r *excelParticipantRows) Close() error {
+	if err := r.rows.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+
+	return r.file.Close()
+}
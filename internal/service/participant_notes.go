@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrUnknownParticipantFlag is returned when a participant is given a flag that is not one of the participant flags
+	ErrUnknownParticipantFlag = fmt.Errorf("unknown participant flag, expected one of %s", strings.Join(aggregate.ParticipantFlags, ", "))
+)
+
+// SetParticipantNotes replaces the notes and the flags of a participant, shown to the referees when they fetch the
+// participant before scoring a run. The flags given twice are kept once.
+func (s *CompetitionService) SetParticipantNotes(ctx context.Context, competitionID, dossard int32, notes string, flags []string) (*aggregate.Participant, error) {
+	given := make(map[string]bool, len(flags))
+	kept := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		flag = strings.ToLower(strings.TrimSpace(flag))
+		if !aggregate.IsParticipantFlag(flag) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownParticipantFlag, flag)
+		}
+		if given[flag] {
+			continue
+		}
+		given[flag] = true
+		kept = append(kept, flag)
+	}
+
+	if err := s.participantRepo.SetParticipantNotes(ctx, competitionID, dossard, strings.TrimSpace(notes), kept); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// maxParticipantPhotoSize bounds the size of an uploaded participant photo
+const maxParticipantPhotoSize = 5 << 20
+
+// participantPhotoExtensions maps the accepted photo formats to the extension of their objects
+var participantPhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+var (
+	// ErrParticipantPhotosDisabled is returned when uploading a participant photo without an object storage bucket configured
+	ErrParticipantPhotosDisabled = errors.New("participant photos are not configured")
+	// ErrParticipantPhotoTooLarge is returned when the uploaded participant photo is over maxParticipantPhotoSize
+	ErrParticipantPhotoTooLarge = fmt.Errorf("participant photo too large: at most %d MB", maxParticipantPhotoSize>>20)
+	// ErrInvalidParticipantPhoto is returned when the uploaded participant photo is not an image of an accepted format
+	ErrInvalidParticipantPhoto = errors.New("invalid participant photo: expected a JPEG, PNG or WebP image")
+	// ErrPhotoRightsNotGiven is returned when uploading the photo of a participant who did not consent to photo rights
+	ErrPhotoRightsNotGiven = errors.New("the participant did not consent to photo rights")
+	// ErrParticipantPhotoNotFound is returned when reading the photo of a participant who has none or withdrew their consent
+	ErrParticipantPhotoNotFound = errors.New("participant photo not found")
+)
+
+// SetParticipantPhoto stores the photo of a participant in the object storage bucket of the configuration, for the
+// referees to confirm their identity before scoring a run, and returns the participant. The participant must have
+// consented to photo rights. The format is told from the content of the photo. Each photo is stored under a new random
+// name outside the published results, so that it is only served by the API and a replaced photo is never served from a cache.
+func (s *CompetitionService) SetParticipantPhoto(ctx context.Context, competitionID, dossard int32, r io.Reader) (*aggregate.Participant, error) {
+	if s.objectStorage == nil {
+		return nil, ErrParticipantPhotosDisabled
+	}
+
+	participant, err := s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+	if err != nil {
+		return nil, err
+	}
+	if !participant.HasConsent(aggregate.ConsentPhotoRights) {
+		return nil, ErrPhotoRightsNotGiven
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, maxParticipantPhotoSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxParticipantPhotoSize {
+		return nil, ErrParticipantPhotoTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	extension, ok := participantPhotoExtensions[contentType]
+	if !ok {
+		return nil, ErrInvalidParticipantPhoto
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("competition-%d/%d-%s%s", competitionID, dossard, hex.EncodeToString(token), extension)
+	if s.cfg != nil && s.cfg.Publication.PhotoPrefix != "" {
+		key = s.cfg.Publication.PhotoPrefix + "/" + key
+	}
+
+	if err := s.objectStorage.PutObject(ctx, key, contentType, content); err != nil {
+		return nil, err
+	}
+
+	if err := s.participantRepo.SetParticipantPhoto(ctx, competitionID, dossard, key); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+}
+
+// GetParticipantPhoto returns the photo of a participant with its content type. The photo of a participant who
+// withdrew their consent to photo rights is no longer served.
+func (s *CompetitionService) GetParticipantPhoto(ctx context.Context, competitionID, dossard int32) ([]byte, string, error) {
+	if s.objectStorage == nil {
+		return nil, "", ErrParticipantPhotosDisabled
+	}
+
+	participant, err := s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+	if err != nil {
+		return nil, "", err
+	}
+	if participant.GetPhotoKey() == "" || !participant.HasConsent(aggregate.ConsentPhotoRights) {
+		return nil, "", ErrParticipantPhotoNotFound
+	}
+
+	content, contentType, err := s.objectStorage.GetObject(ctx, participant.GetPhotoKey())
+	if err != nil {
+		return nil, "", err
+	}
+	if content == nil {
+		return nil, "", ErrParticipantPhotoNotFound
+	}
+
+	return content, contentType, nil
+}
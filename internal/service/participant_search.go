@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+const (
+	// defaultParticipantSearchLimit is the number of candidates returned when no limit is given
+	defaultParticipantSearchLimit = 10
+	// maxParticipantSearchLimit bounds the number of candidates of a name search
+	maxParticipantSearchLimit = 50
+)
+
+var (
+	// ErrEmptyParticipantSearch is returned when a name search has no term
+	ErrEmptyParticipantSearch = errors.New("the search needs at least one letter of the first or last name")
+)
+
+// SearchParticipantsByName lists the participants of a competition whose first or last name starts with every term
+// of the query, so that a referee knowing only the name of a rider finds the dossard. The limit defaults to 10 and is
+// capped to 50 candidates.
+func (s *CompetitionService) SearchParticipantsByName(ctx context.Context, competitionID int32, query string, limit int32) ([]*aggregate.Participant, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyParticipantSearch
+	}
+
+	if limit <= 0 {
+		limit = defaultParticipantSearchLimit
+	}
+	if limit > maxParticipantSearchLimit {
+		limit = maxParticipantSearchLimit
+	}
+
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.SearchParticipantsByName(ctx, competitionID, query, limit)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrUnknownParticipantStatus is returned when a participant is given a status that is not one of the participant statuses
+	ErrUnknownParticipantStatus = fmt.Errorf("unknown participant status, expected one of %s", strings.Join(aggregate.ParticipantStatuses, ", "))
+	// ErrMissingDisqualificationReason is returned when a participant is disqualified without a reason
+	ErrMissingDisqualificationReason = errors.New("a disqualification requires a reason")
+)
+
+// SetParticipantStatus sets the status of a participant. The participants who did not start are left out of the
+// liveranking and the exports, those who did not finish then the disqualified ones are ranked last with the reason.
+// Going back to registered clears the reason.
+func (s *CompetitionService) SetParticipantStatus(ctx context.Context, competitionID, dossard int32, status, reason string) (*aggregate.Participant, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if !aggregate.IsParticipantStatus(status) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownParticipantStatus, status)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if status == aggregate.ParticipantStatusDSQ && reason == "" {
+		return nil, ErrMissingDisqualificationReason
+	}
+	if status == aggregate.ParticipantStatusRegistered {
+		reason = ""
+	}
+
+	if err := s.participantRepo.SetParticipantStatus(ctx, competitionID, dossard, status, reason); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+}
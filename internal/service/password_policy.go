@@ -0,0 +1,215 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/NiskuT/cross-api/internal/config"
+)
+
+// Password policy violations, returned in PasswordPolicyError
+const (
+	PasswordTooShort         = "too_short"
+	PasswordMissingLowercase = "missing_lowercase"
+	PasswordMissingUppercase = "missing_uppercase"
+	PasswordMissingDigit     = "missing_digit"
+	PasswordMissingSymbol    = "missing_symbol"
+	PasswordDenied           = "denied"
+	PasswordPwned            = "pwned"
+)
+
+const (
+	pwnedPasswordsURL = "https://api.pwnedpasswords.com/range/"
+
+	lowercaseChars = "abcdefghijklmnopqrstuvwxyz"
+	uppercaseChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars     = "0123456789"
+	symbolChars    = "!@#$%^&*()-_=+"
+)
+
+// PasswordPolicyError lists the rules of the policy a password breaks
+type PasswordPolicyError struct {
+	Violations []string
+	MinLength  int
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("password does not meet the policy: %s", strings.Join(e.Violations, ", "))
+}
+
+// PasswordPolicy checks user chosen passwords and generates compliant random ones
+type PasswordPolicy struct {
+	cfg        config.PasswordPolicyConfig
+	denyList   map[string]bool
+	httpClient *http.Client
+}
+
+// NewPasswordPolicy builds the password policy from the configuration, loading the deny list if any
+func NewPasswordPolicy(cfg *config.Config) (*PasswordPolicy, error) {
+	p := &PasswordPolicy{
+		cfg:        cfg.Password,
+		denyList:   make(map[string]bool),
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+	}
+
+	if cfg.Password.DenyListPath == "" {
+		return p, nil
+	}
+
+	file, err := os.Open(cfg.Password.DenyListPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open password deny list: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if password := strings.TrimSpace(scanner.Text()); password != "" {
+			p.denyList[strings.ToLower(password)] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read password deny list: %w", err)
+	}
+
+	return p, nil
+}
+
+// Validate returns a *PasswordPolicyError when the password breaks the policy
+func (p *PasswordPolicy) Validate(ctx context.Context, password string) error {
+	var violations []string
+
+	if len([]rune(password)) < p.cfg.MinLength {
+		violations = append(violations, PasswordTooShort)
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.cfg.RequireLowercase && !hasLower {
+		violations = append(violations, PasswordMissingLowercase)
+	}
+	if p.cfg.RequireUppercase && !hasUpper {
+		violations = append(violations, PasswordMissingUppercase)
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordMissingDigit)
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordMissingSymbol)
+	}
+
+	if p.denyList[strings.ToLower(password)] {
+		violations = append(violations, PasswordDenied)
+	}
+
+	if p.cfg.CheckPwned && p.isPwned(ctx, password) {
+		violations = append(violations, PasswordPwned)
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations, MinLength: p.cfg.MinLength}
+	}
+
+	return nil
+}
+
+// isPwned checks the password against the Have I Been Pwned range API.
+// Only the first five characters of the SHA-1 hash leave the server. When the API
+// cannot be reached the password is accepted so an outage does not block users.
+func (p *PasswordPolicy) isPwned(ctx context.Context, password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pwnedPasswordsURL+prefix, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		log.Println("Error checking password against Have I Been Pwned:", err)
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Println("Unexpected status from Have I Been Pwned:", resp.Status)
+		return false
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		hashSuffix, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// Padding entries have a count of zero
+		if found && hashSuffix == suffix && count != "0" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Generate returns a random password satisfying the character classes and minimum length of the policy
+func (p *PasswordPolicy) Generate(length int) string {
+	if length < p.cfg.MinLength {
+		length = p.cfg.MinLength
+	}
+
+	// Start with one character of every class so required classes are always present
+	password := []byte{
+		randomChar(lowercaseChars),
+		randomChar(uppercaseChars),
+		randomChar(digitChars),
+		randomChar(symbolChars),
+	}
+
+	const allChars = lowercaseChars + uppercaseChars + digitChars + symbolChars
+	for len(password) < length {
+		password = append(password, randomChar(allChars))
+	}
+
+	// Shuffle so the class of each position is not predictable
+	for i := len(password) - 1; i > 0; i-- {
+		j := randomInt(i + 1)
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password)
+}
+
+func randomChar(chars string) byte {
+	return chars[randomInt(len(chars))]
+}
+
+func randomInt(max int) int {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		panic(err)
+	}
+	return int(n.Int64())
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrCompetitionNotPublished is returned when spectators read a competition still in draft
+	ErrCompetitionNotPublished = errors.New("competition is not published")
+)
+
+// defaultPublicCompetitionCacheTTL is how long the public competition information is cached when not configured
+const defaultPublicCompetitionCacheTTL = time.Minute
+
+// publicCompetition is the public information of a competition read at a given time
+type publicCompetition struct {
+	competition *aggregate.Competition
+	zones       []aggregate.ZoneInfo
+	readAt      time.Time
+}
+
+// GetPublicCompetition returns the competition with its zones for spectators, and when they were read.
+// The information is cached as spectators may load it many times, draft competitions are not published.
+func (s *CompetitionService) GetPublicCompetition(ctx context.Context, competitionID int32) (*aggregate.Competition, []aggregate.ZoneInfo, time.Time, error) {
+	s.publicMutex.Lock()
+	cached, ok := s.publicCompetitions[competitionID]
+	s.publicMutex.Unlock()
+	if ok && time.Since(cached.readAt) < s.publicCompetitionCacheTTL() {
+		return cached.competition, cached.zones, cached.readAt, nil
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	if competition.GetStatus() == aggregate.CompetitionStatusDraft {
+		return nil, nil, time.Time{}, ErrCompetitionNotPublished
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	cached = &publicCompetition{
+		competition: competition,
+		zones:       zones,
+		readAt:      time.Now(),
+	}
+
+	s.publicMutex.Lock()
+	if s.publicCompetitions == nil {
+		s.publicCompetitions = make(map[int32]*publicCompetition)
+	}
+	s.publicCompetitions[competitionID] = cached
+	s.publicMutex.Unlock()
+
+	return cached.competition, cached.zones, cached.readAt, nil
+}
+
+// publicCompetitionCacheTTL returns how long the public competition information is cached
+func (s *CompetitionService) publicCompetitionCacheTTL() time.Duration {
+	if s.cfg != nil && s.cfg.Public.CompetitionCacheTTL > 0 {
+		return s.cfg.Public.CompetitionCacheTTL
+	}
+	return defaultPublicCompetitionCacheTTL
+}
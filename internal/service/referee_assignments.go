@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// AssignRefereeToZone assigns a referee of the competition to one of its zones, a referee can be assigned to several zones
+func (s *UserService) AssignRefereeToZone(ctx context.Context, competitionID, userID int32, zone string, assignedBy int32) (*aggregate.RefereeAssignment, error) {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompetitionReferee(user, competitionID) {
+		return nil, ErrNotCompetitionReferee
+	}
+
+	zone = strings.TrimSpace(zone)
+	if err := s.checkCompetitionZone(ctx, competitionID, zone); err != nil {
+		return nil, err
+	}
+
+	assignment := aggregate.NewRefereeAssignment()
+	assignment.SetCompetitionID(competitionID)
+	assignment.SetUserID(userID)
+	assignment.SetZone(zone)
+	assignment.SetAssignedBy(assignedBy)
+	assignment.SetAssignedAt(time.Now())
+	if err := s.assignmentRepo.AssignReferee(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	assignment.SetUser(user)
+	return assignment, nil
+}
+
+// UnassignRefereeFromZone removes the assignment of a referee to a zone of the competition
+func (s *UserService) UnassignRefereeFromZone(ctx context.Context, competitionID, userID int32, zone string) error {
+	return s.assignmentRepo.UnassignReferee(ctx, competitionID, userID, zone)
+}
+
+// ListRefereeAssignments lists the assignments of the referees of the competition by zone, with the referees
+func (s *UserService) ListRefereeAssignments(ctx context.Context, competitionID int32) ([]*aggregate.RefereeAssignment, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.ListRefereeAssignments(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[int32]*aggregate.User)
+	for _, assignment := range assignments {
+		user, found := users[assignment.GetUserID()]
+		if !found {
+			user, err = s.userRepo.GetUser(ctx, assignment.GetUserID())
+			if err != nil {
+				log.Printf("Failed to get referee %d of competition %d: %v", assignment.GetUserID(), competitionID, err)
+				continue
+			}
+			users[assignment.GetUserID()] = user
+		}
+		assignment.SetUser(user)
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// GetRefereeOnboarding returns the onboarding checklist of a referee of the competition. A referee opening
+// their checklist accepts the role, which matters for the referees added by email rather than by an invitation link.
+func (s *UserService) GetRefereeOnboarding(ctx context.Context, competitionID, userID int32) (*aggregate.RefereeOnboarding, error) {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompetitionReferee(user, competitionID) {
+		return nil, ErrNotCompetitionReferee
+	}
+
+	onboarding, err := s.onboardingRepo.GetRefereeOnboarding(ctx, competitionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding == nil {
+		onboarding = aggregate.NewRefereeOnboarding()
+		onboarding.SetCompetitionID(competitionID)
+		onboarding.SetUserID(userID)
+	}
+
+	if onboarding.GetInvitationAcceptedAt().IsZero() {
+		onboarding.SetInvitationAcceptedAt(time.Now())
+		if err := s.onboardingRepo.SetRefereeOnboarding(ctx, onboarding); err != nil {
+			return nil, err
+		}
+	}
+
+	onboarding.SetUser(user)
+	return onboarding, nil
+}
+
+// UpdateRefereeOnboarding records whether the referee read the rules and the zone they confirmed to referee,
+// an empty zone clearing the confirmation. The zone must be one of the competition.
+func (s *UserService) UpdateRefereeOnboarding(ctx context.Context, competitionID, userID int32, rulesRead bool, zone string) (*aggregate.RefereeOnboarding, error) {
+	onboarding, err := s.GetRefereeOnboarding(ctx, competitionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !rulesRead {
+		onboarding.SetRulesReadAt(time.Time{})
+	} else if onboarding.GetRulesReadAt().IsZero() {
+		onboarding.SetRulesReadAt(now)
+	}
+
+	zone = strings.TrimSpace(zone)
+	if zone != onboarding.GetConfirmedZone() {
+		if zone != "" {
+			if err := s.checkCompetitionZone(ctx, competitionID, zone); err != nil {
+				return nil, err
+			}
+		}
+		onboarding.SetConfirmedZone(zone, now)
+	}
+
+	if err := s.onboardingRepo.SetRefereeOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return onboarding, nil
+}
+
+// SubmitRefereeTestRun checks a run the referee submits from the device they will score with, without recording it,
+// and completes the test run step of their onboarding. Test runs can be submitted before the competition starts.
+func (s *UserService) SubmitRefereeTestRun(ctx context.Context, competitionID, userID int32, run *aggregate.Run) (*aggregate.RefereeOnboarding, error) {
+	if run.GetZone() == "" || run.GetPenality() < 0 || run.GetChronoSec() < 0 {
+		return nil, ErrInvalidRunData
+	}
+
+	onboarding, err := s.GetRefereeOnboarding(ctx, competitionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCompetitionZone(ctx, competitionID, run.GetZone()); err != nil {
+		return nil, err
+	}
+
+	onboarding.SetTestRunAt(time.Now())
+	if err := s.onboardingRepo.SetRefereeOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return onboarding, nil
+}
+
+// ListRefereeOnboardings lists the onboarding checklist of every referee of the competition by name, including the
+// referees who did not open it yet, and returns the number of referee invitation links still pending
+func (s *UserService) ListRefereeOnboardings(ctx context.Context, competitionID int32) ([]*aggregate.RefereeOnboarding, int32, error) {
+	roles, err := s.userRepo.ListCompetitionRoles(ctx, competitionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	started, err := s.onboardingRepo.ListRefereeOnboardings(ctx, competitionID)
+	if err != nil {
+		return nil, 0, err
+	}
+	byUser := make(map[int32]*aggregate.RefereeOnboarding, len(started))
+	for _, onboarding := range started {
+		byUser[onboarding.GetUserID()] = onboarding
+	}
+
+	onboardings := []*aggregate.RefereeOnboarding{}
+	for _, role := range roles {
+		if role.GetName() != aggregate.InvitationRoleReferee {
+			continue
+		}
+
+		user, err := s.userRepo.GetUser(ctx, role.GetUserID())
+		if err != nil {
+			log.Printf("Failed to get referee %d of competition %d: %v", role.GetUserID(), competitionID, err)
+			continue
+		}
+
+		onboarding, found := byUser[role.GetUserID()]
+		if !found {
+			onboarding = aggregate.NewRefereeOnboarding()
+			onboarding.SetCompetitionID(competitionID)
+			onboarding.SetUserID(role.GetUserID())
+		}
+		onboarding.SetUser(user)
+		onboardings = append(onboardings, onboarding)
+	}
+
+	sort.SliceStable(onboardings, func(i, j int) bool {
+		a, b := onboardings[i].GetUser(), onboardings[j].GetUser()
+		if !strings.EqualFold(a.GetLastName(), b.GetLastName()) {
+			return strings.ToLower(a.GetLastName()) < strings.ToLower(b.GetLastName())
+		}
+		return strings.ToLower(a.GetFirstName()) < strings.ToLower(b.GetFirstName())
+	})
+
+	invitations, err := s.invitationRepo.ListPendingInvitations(ctx, competitionID)
+	if err != nil {
+		return nil, 0, err
+	}
+	pendingInvitations := int32(0)
+	for _, invitation := range invitations {
+		if invitation.GetRole() == aggregate.InvitationRoleReferee {
+			pendingInvitations++
+		}
+	}
+
+	return onboardings, pendingInvitations, nil
+}
+
+// markRefereeInvitationAccepted completes the invitation step of the onboarding of a referee who accepted
+// an invitation link, failures are only logged
+func (s *UserService) markRefereeInvitationAccepted(ctx context.Context, competitionID, userID int32, role string) {
+	if role != aggregate.InvitationRoleReferee {
+		return
+	}
+
+	if _, err := s.GetRefereeOnboarding(ctx, competitionID, userID); err != nil {
+		log.Println("Error recording referee invitation acceptance:", err)
+	}
+}
+
+// checkCompetitionZone returns ErrUnknownZone when the competition has no such zone
+func (s *UserService) checkCompetitionZone(ctx context.Context, competitionID int32, zone string) error {
+	zones, err := s.zoneRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	for _, competitionZone := range zones {
+		if competitionZone.GetZone() == zone {
+			return nil
+		}
+	}
+	return ErrUnknownZone
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	// refereePinLength is the number of digits of a referee PIN, short enough to be typed on a tablet at the zone
+	refereePinLength = 6
+	// refereePinAttempts bounds the PINs drawn when the first ones collide with PINs of other referees
+	refereePinAttempts = 5
+	// defaultRefereePinTokenLifetime is the lifetime of the tokens issued for a PIN when it is not configured
+	defaultRefereePinTokenLifetime = 12 * time.Hour
+)
+
+var (
+	// ErrNotCompetitionReferee is returned when a PIN is requested for a user who does not referee the competition
+	ErrNotCompetitionReferee = errors.New("user is not a referee of the competition")
+	// ErrInvalidRefereePin is returned when a PIN does not match a referee of the competition
+	ErrInvalidRefereePin = errors.New("invalid PIN")
+)
+
+// GenerateRefereePin generates the PIN a referee logs in to the competition with on shared tablets and returns it,
+// only its hash is stored. It replaces the previous PIN of the referee.
+func (s *UserService) GenerateRefereePin(ctx context.Context, competitionID, userID, createdBy int32) (string, error) {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !isCompetitionReferee(user, competitionID) {
+		return "", ErrNotCompetitionReferee
+	}
+
+	var lastErr error
+	for i := 0; i < refereePinAttempts; i++ {
+		pin, err := randomDigits(refereePinLength)
+		if err != nil {
+			return "", err
+		}
+
+		refereePin := aggregate.NewRefereePin()
+		refereePin.SetCompetitionID(competitionID)
+		refereePin.SetUserID(userID)
+		refereePin.SetPinHash(hashRefereePin(competitionID, pin))
+		refereePin.SetCreatedBy(createdBy)
+		refereePin.SetCreatedAt(time.Now())
+
+		// Another referee of the competition may already have the PIN, a new one is drawn then
+		lastErr = s.refereePinRepo.SetRefereePin(ctx, refereePin)
+		if lastErr == nil {
+			return pin, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to store the referee PIN: %w", lastErr)
+}
+
+// RevokeRefereePin removes the PIN of a referee, the tokens already issued for it stay valid until they expire
+func (s *UserService) RevokeRefereePin(ctx context.Context, competitionID, userID int32) error {
+	return s.refereePinRepo.DeleteRefereePin(ctx, competitionID, userID)
+}
+
+// LoginWithRefereePin exchanges a PIN of the competition for a token of its referee restricted to recording runs.
+// The token has no refresh token nor session, the PIN is entered again once it expires.
+func (s *UserService) LoginWithRefereePin(ctx context.Context, competitionID int32, pin string) (*aggregate.JwtToken, error) {
+	refereePin, err := s.refereePinRepo.GetRefereePinByHash(ctx, competitionID, hashRefereePin(competitionID, pin))
+	if err != nil {
+		s.recordAuthEvent(ctx, aggregate.AuthEventLoginFailed, 0, "", competitionID, "wrong referee PIN")
+		return nil, ErrInvalidRefereePin
+	}
+
+	// The PIN is worthless once the user no longer referees the competition
+	user, err := s.userRepo.GetUser(ctx, refereePin.GetUserID())
+	if err != nil || !isCompetitionReferee(user, competitionID) {
+		s.recordAuthEvent(ctx, aggregate.AuthEventLoginFailed, refereePin.GetUserID(), "", competitionID, "referee PIN of a former referee")
+		return nil, ErrInvalidRefereePin
+	}
+
+	roles := []string{aggregate.RefereePinRole(competitionID)}
+	now := time.Now()
+	accessTokenClaims := jwt.MapClaims{
+		"sub":   user.GetID(),
+		"email": user.GetEmail(),
+		"roles": roles,
+		"scope": aggregate.RefereePinScope,
+		"iss":   "golene-evasion.com",
+		"type":  "access",
+		"jti":   uuid.NewString(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(s.refereePinTokenLifetime()).Unix(),
+	}
+
+	accessTokenString, err := s.keySet.Sign(accessTokenClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtToken := aggregate.NewJwtToken()
+	jwtToken.SetAccessToken(accessTokenString)
+	jwtToken.SetRoles(roles)
+
+	s.recordAuthEvent(ctx, aggregate.AuthEventRefereePinLogin, user.GetID(), user.GetEmail(), competitionID, "")
+	return jwtToken, nil
+}
+
+// refereePinTokenLifetime returns how long the tokens issued for a referee PIN are valid
+func (s *UserService) refereePinTokenLifetime() time.Duration {
+	if s.cfg != nil && s.cfg.Jwt.RefereePinTokenLifetime > 0 {
+		return s.cfg.Jwt.RefereePinTokenLifetime
+	}
+	return defaultRefereePinTokenLifetime
+}
+
+// isCompetitionReferee returns whether the user referees the competition
+func isCompetitionReferee(user *aggregate.User, competitionID int32) bool {
+	refereeRole := fmt.Sprintf("referee:%d", competitionID)
+	for _, role := range user.GetRoleList() {
+		if role == refereeRole {
+			return true
+		}
+	}
+	return false
+}
+
+// hashRefereePin returns the hex encoded SHA-256 hash of a PIN of the competition.
+// PINs are short, brute forcing them is prevented by the rate limit of the PIN login rather than by the hash.
+func hashRefereePin(competitionID int32, pin string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", competitionID, pin)))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomDigits returns a random string of decimal digits, leading zeros included
+func randomDigits(length int) (string, error) {
+	digits := make([]byte, length)
+	for i := range digits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + n.Int64())
+	}
+	return string(digits), nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrRegistrationClosed is returned when adding a participant after the registration deadline
+	ErrRegistrationClosed = errors.New("the registrations of the competition are closed")
+	// ErrCompetitionFull is returned when adding a participant to a full competition without waitlist
+	ErrCompetitionFull = errors.New("the competition has reached its maximum number of participants")
+	// ErrInvalidMaxParticipants is returned when the maximum number of participants is negative
+	ErrInvalidMaxParticipants = errors.New("the maximum number of participants cannot be negative")
+	// ErrParticipantNotWaitlisted is returned when promoting a participant who is not waitlisted
+	ErrParticipantNotWaitlisted = errors.New("the participant is not waitlisted")
+	// ErrInvalidPromotionCount is returned when promoting less than one participant of the waitlist
+	ErrInvalidPromotionCount = errors.New("the number of participants to promote must be positive")
+)
+
+// SetCompetitionRegistration sets the maximum number of participants, 0 for no limit, the registration deadline,
+// zero for none, and whether the entries over the limit are waitlisted. The participants already registered are kept.
+func (s *CompetitionService) SetCompetitionRegistration(ctx context.Context, competitionID, maxParticipants int32, deadline time.Time, waitlist bool) (*aggregate.Competition, error) {
+	if maxParticipants < 0 {
+		return nil, ErrInvalidMaxParticipants
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	competition.SetMaxParticipants(maxParticipants)
+	competition.SetRegistrationDeadline(deadline)
+	competition.SetWaitlist(waitlist)
+	if err := s.competitionRepo.UpdateCompetition(ctx, competition); err != nil {
+		return nil, err
+	}
+
+	return competition, nil
+}
+
+// CountRegistrations counts the registered and the waitlisted participants of a competition
+func (s *CompetitionService) CountRegistrations(ctx context.Context, competitionID int32) (int32, int32, error) {
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var registered, waitlisted int32
+	for _, participant := range participants {
+		if participant.IsWaitlisted() {
+			waitlisted++
+		} else {
+			registered++
+		}
+	}
+	return registered, waitlisted, nil
+}
+
+// PromoteWaitlistedParticipant gives a place to a waitlisted participant, within the maximum number of participants.
+// The participant is given the first free dossard after the registered participants.
+func (s *CompetitionService) PromoteWaitlistedParticipant(ctx context.Context, competitionID, dossardNumber int32) (*aggregate.Participant, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.GetParticipant(ctx, competitionID, dossardNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !participant.IsWaitlisted() {
+		return nil, ErrParticipantNotWaitlisted
+	}
+
+	if competition.GetMaxParticipants() > 0 {
+		registered, err := s.participantRepo.CountRegisteredParticipants(ctx, competitionID)
+		if err != nil {
+			return nil, err
+		}
+		if registered >= competition.GetMaxParticipants() {
+			return nil, ErrCompetitionFull
+		}
+	}
+
+	newDossardNumber, err := s.participantRepo.PromoteWaitlistedParticipant(ctx, competitionID, dossardNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.GetParticipant(ctx, competitionID, newDossardNumber)
+}
+
+// ListWaitlist lists the waitlisted participants of a competition in the order they entered the waitlist
+func (s *CompetitionService) ListWaitlist(ctx context.Context, competitionID int32) ([]*aggregate.Participant, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.ListWaitlistedParticipants(ctx, competitionID)
+}
+
+// PromoteWaitlist gives a place to up to count participants at the head of the waitlist, within the maximum number
+// of participants, and returns them with their new dossards. It returns ErrCompetitionFull when no place is left.
+func (s *CompetitionService) PromoteWaitlist(ctx context.Context, competitionID, count int32) ([]*aggregate.Participant, error) {
+	if count <= 0 {
+		return nil, ErrInvalidPromotionCount
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if competition.GetMaxParticipants() > 0 {
+		registered, err := s.participantRepo.CountRegisteredParticipants(ctx, competitionID)
+		if err != nil {
+			return nil, err
+		}
+		if registered >= competition.GetMaxParticipants() {
+			return nil, ErrCompetitionFull
+		}
+		if places := competition.GetMaxParticipants() - registered; count > places {
+			count = places
+		}
+	}
+
+	waitlist, err := s.participantRepo.ListWaitlistedParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if int(count) < len(waitlist) {
+		waitlist = waitlist[:count]
+	}
+
+	promoted := make([]*aggregate.Participant, 0, len(waitlist))
+	for _, participant := range waitlist {
+		newDossardNumber, err := s.participantRepo.PromoteWaitlistedParticipant(ctx, competitionID, participant.GetDossardNumber())
+		if err != nil {
+			return nil, err
+		}
+
+		participant, err = s.participantRepo.GetParticipant(ctx, competitionID, newDossardNumber)
+		if err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, participant)
+	}
+
+	return promoted, nil
+}
+
+// openRegistrations returns ErrRegistrationClosed once the registration deadline has passed, and otherwise the
+// number of participants registered, the waitlisted ones left out
+func (s *CompetitionService) openRegistrations(ctx context.Context, competition *aggregate.Competition) (int32, error) {
+	if competition.IsRegistrationClosed(time.Now()) {
+		return 0, ErrRegistrationClosed
+	}
+	if competition.GetMaxParticipants() == 0 {
+		return 0, nil
+	}
+
+	return s.participantRepo.CountRegisteredParticipants(ctx, competition.GetID())
+}
+
+// placeParticipant waitlists the participant when the competition is full and has a waitlist,
+// it returns ErrCompetitionFull when it has none
+func placeParticipant(competition *aggregate.Competition, participant *aggregate.Participant, registered int32) error {
+	if competition.GetMaxParticipants() == 0 || registered < competition.GetMaxParticipants() {
+		participant.SetWaitlisted(false)
+		return nil
+	}
+	if !competition.HasWaitlist() {
+		return ErrCompetitionFull
+	}
+
+	participant.SetWaitlisted(true)
+	return nil
+}
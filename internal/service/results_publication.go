@@ -0,0 +1,227 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/models"
+	"github.com/NiskuT/cross-api/internal/utils"
+)
+
+// publicationTimeout bounds the publication started in the background when a competition is closed
+const publicationTimeout = 5 * time.Minute
+
+// AnonymousParticipantName replaces the name of the participants who did not consent to the processing of their data
+// wherever they are shown publicly
+const AnonymousParticipantName = "Anonyme"
+
+var (
+	// ErrPublicationDisabled is returned when publishing results without an object storage bucket configured
+	ErrPublicationDisabled = errors.New("results publication is not configured")
+)
+
+// resultsPageTemplate renders the published results as a standalone page, without any asset to host along
+var resultsPageTemplate = template.Must(template.New("results").Parse(`<!DOCTYPE html>
+<html lang="fr">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}} - Résultats</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 60em; padding: 0 1em; }
+table { border-collapse: collapse; margin-bottom: 2em; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: .4em; text-align: left; }
+td.number { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p>{{.Date}}{{if .Location}} - {{.Location}}{{end}}{{if .Organizer}} - {{.Organizer}}{{end}}</p>
+{{range .Groups}}
+<h2>{{.Category}} {{.Gender}}</h2>
+<table>
+<thead><tr><th>Position</th><th>Dossard</th><th>Nom</th><th>Prénom</th><th>Club</th><th>Points</th><th>Pénalités</th><th>Temps</th><th>Points gagnés</th></tr></thead>
+<tbody>
+{{range .Results}}<tr><td>{{if .Complete}}{{.Rank}}{{else}}-{{end}}</td><td class="number">{{.Dossard}}</td><td>{{.LastName}}</td><td>{{.FirstName}}</td><td>{{.Club}}</td><td class="number">{{.TotalPoints}}</td><td class="number">{{.TotalPenalty}}</td><td class="number">{{.TotalTime}}</td><td class="number">{{if .Complete}}{{.PointsEarned}}{{end}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+<p>Publié le {{.PublishedAt.Format "02/01/2006 15:04"}} UTC</p>
+</body>
+</html>
+`))
+
+// PublishResults renders the results of a competition to static HTML and JSON and uploads them to the
+// object storage bucket of the configuration, so they stay available even once the API is taken down.
+// It returns the published results with the URLs of the page and of the JSON document.
+func (s *CompetitionService) PublishResults(ctx context.Context, competitionID int32) (*models.ResultsPublicationResponse, error) {
+	if s.objectStorage == nil {
+		return nil, ErrPublicationDisabled
+	}
+
+	results, err := s.buildPublishedResults(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonContent, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var htmlContent bytes.Buffer
+	if err := resultsPageTemplate.Execute(&htmlContent, results); err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("competition-%d/", competitionID)
+	if s.cfg != nil && s.cfg.Publication.Prefix != "" {
+		prefix = s.cfg.Publication.Prefix + "/" + prefix
+	}
+
+	if err := s.objectStorage.PutObject(ctx, prefix+"results.json", "application/json", jsonContent); err != nil {
+		return nil, err
+	}
+	if err := s.objectStorage.PutObject(ctx, prefix+"index.html", "text/html; charset=utf-8", htmlContent.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return &models.ResultsPublicationResponse{
+		CompetitionID: competitionID,
+		HTMLURL:       s.objectStorage.ObjectURL(prefix + "index.html"),
+		JSONURL:       s.objectStorage.ObjectURL(prefix + "results.json"),
+		PublishedAt:   results.PublishedAt,
+	}, nil
+}
+
+// publishClosedCompetition publishes the results of a competition which was just closed, in the background
+func (s *CompetitionService) publishClosedCompetition(competitionID int32) {
+	if s.objectStorage == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), publicationTimeout)
+		defer cancel()
+
+		publication, err := s.PublishResults(ctx, competitionID)
+		if err != nil {
+			log.Printf("Failed to publish the results of competition %d: %v", competitionID, err)
+			return
+		}
+		log.Printf("Published the results of competition %d to %s", competitionID, publication.HTMLURL)
+	}()
+}
+
+// buildPublishedResults ranks the participants of each category and gender as the results export does
+func (s *CompetitionService) buildPublishedResults(ctx context.Context, competitionID int32) (*models.PublishedResults, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.getAllParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.getAllRuns(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	scales, err := s.getAllScales(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	participantGroups := s.groupParticipantsByCategoryGender(participants)
+	groupKeys := make([]string, 0, len(participantGroups))
+	for groupKey := range participantGroups {
+		if len(strings.Split(groupKey, "_")) == 2 {
+			groupKeys = append(groupKeys, groupKey)
+		}
+	}
+	sort.Strings(groupKeys)
+
+	published := &models.PublishedResults{
+		CompetitionID: competition.GetID(),
+		Name:          competition.GetName(),
+		Date:          competition.GetDay(),
+		Location:      competition.GetLocation(),
+		Organizer:     competition.GetOrganizer(),
+		PublishedAt:   time.Now().UTC(),
+		Groups:        make([]models.PublishedResultGroup, 0, len(groupKeys)),
+	}
+
+	for _, groupKey := range groupKeys {
+		parts := strings.Split(groupKey, "_")
+		category, gender := parts[0], parts[1]
+
+		zones, err := s.getZonesForCategory(ctx, competitionID, category)
+		if err != nil {
+			return nil, err
+		}
+
+		group := models.PublishedResultGroup{
+			Category: category,
+			Gender:   gender,
+			Zones:    zones,
+			Results:  make([]models.PublishedResult, 0, len(participantGroups[groupKey])),
+		}
+		for i, result := range s.computeSheetResults(participantGroups[groupKey], zones, settings, runs, scales, competitionID) {
+			group.Results = append(group.Results, toPublishedResult(result, int32(i+1)))
+		}
+		published.Groups = append(published.Groups, group)
+	}
+
+	return published, nil
+}
+
+// toPublishedResult builds the published result of a participant, hiding who they are without their consent
+func toPublishedResult(result ParticipantResult, rank int32) models.PublishedResult {
+	published := models.PublishedResult{
+		Dossard:      result.Participant.GetDossardNumber(),
+		LastName:     AnonymousParticipantName,
+		TotalPoints:  result.TotalPoints,
+		TotalPenalty: result.TotalPenalty,
+		TotalTime:    result.TotalTimeMs / 1000,
+		TotalTimeMs:  result.TotalTimeMs,
+		Complete:     !result.HasError,
+		Status:       result.Participant.GetStatus(),
+		StatusReason: result.Participant.GetStatusReason(),
+	}
+
+	if result.Participant.GetConsentDataProcessing() {
+		published.LastName = result.Participant.GetLastName()
+		published.FirstName = result.Participant.GetFirstName()
+		published.Club = result.Participant.GetClub()
+	}
+
+	// Participants missing runs, then those who did not finish or were disqualified, are listed after the ranked
+	// ones without rank nor points
+	if result.IsRanked() {
+		published.Rank = rank
+		published.PointsEarned = utils.GetPointsEarned(rank)
+	} else if !published.Complete {
+		published.TotalPoints = 0
+		published.TotalPenalty = 0
+		published.TotalTime = 0
+		published.TotalTimeMs = 0
+	}
+
+	return published
+}
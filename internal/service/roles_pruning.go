@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// PruneExpiredRoles removes the referee and observer roles of competitions that took place more than
+// the configured retention ago, and reports users whose roles still make large tokens.
+// Admin roles are kept so organizers can still access the results of past competitions.
+func (s *UserService) PruneExpiredRoles(ctx context.Context) (*aggregate.RolesPruneReport, error) {
+	report := aggregate.NewRolesPruneReport()
+
+	competitions, err := s.competitionRepo.ListCompetitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list competitions: %w", err)
+	}
+
+	cutoff := time.Now().AddDate(0, -s.cfg.Roles.RetentionMonths, 0)
+	expiredRoles := make(map[string]bool)
+	for _, competition := range competitions {
+		if !competition.GetDate().Before(cutoff) {
+			continue
+		}
+		report.AddExpiredCompetitionID(competition.GetID())
+		expiredRoles[fmt.Sprintf("referee:%d", competition.GetID())] = true
+		expiredRoles[aggregate.ObserverRole(competition.GetID())] = true
+	}
+
+	users, err := s.userRepo.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	for _, user := range users {
+		var removed int32
+		for _, role := range strings.Split(user.GetRoles(), ",") {
+			role = strings.TrimSpace(role)
+			if expiredRoles[role] && user.RemoveRole(role) {
+				removed++
+			}
+		}
+
+		if removed > 0 {
+			if err := s.userRepo.UpdateUser(ctx, user); err != nil {
+				return nil, fmt.Errorf("failed to prune roles of user %d: %w", user.GetID(), err)
+			}
+			report.AddPrunedUser(removed)
+		}
+
+		if len(user.GetRoles()) >= s.cfg.Roles.WarningLength {
+			log.Printf("User %d (%s) roles use %d characters of the tokens", user.GetID(), user.GetEmail(), len(user.GetRoles()))
+			report.AddNearLimitUser(user)
+		}
+	}
+
+	return report, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/NiskuT/cross-api/internal/config"
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
@@ -11,19 +14,61 @@ import (
 	"github.com/NiskuT/cross-api/internal/domain/service"
 )
 
+// maxRunChronoMs is the longest chrono of a run in milliseconds, longer ones are typing errors
+const maxRunChronoMs = 60 * 60 * 1000
+
 // Define error constants
 var (
-	ErrInvalidRunData = errors.New("invalid run data")
-	ErrScaleNotFound  = errors.New("scale not found for this zone and category")
+	ErrInvalidRunData   = errors.New("invalid run data")
+	ErrScaleNotFound    = errors.New("scale not found for this zone and category")
+	ErrTooManyDoors     = errors.New("the run has more doors than the scale of its zone")
+	ErrUnknownRunStatus = errors.New("unknown run status, expected ok, dnf or dsq")
+	ErrInvalidChrono    = errors.New("invalid chrono, expected between 0 and 1 hour")
+
+	ErrCompetitionNotRunning = errors.New("the competition is not running, runs can only be recorded once it started")
+	ErrCompetitionClosed     = errors.New("the competition is closed, its runs and liveranking are frozen")
+	ErrZoneClosed            = errors.New("the zone is closed, it no longer accepts runs")
+	ErrZoneNotAssigned       = errors.New("the referee is not assigned to this zone")
+
+	ErrNotRunReferee           = errors.New("only the referee who recorded the run can void or correct it")
+	ErrRunAlreadyVoided        = errors.New("the run is already voided")
+	ErrUndoWindowExpired       = errors.New("the run can no longer be voided by its referee, ask an admin")
+	ErrCorrectionWindowExpired = errors.New("the run can no longer be corrected by its referee, ask an admin")
+
+	ErrRunNotPendingApproval = errors.New("the run is not waiting for its approval")
+
+	ErrIdempotencyKeyReused = errors.New("the idempotency key was already used for a run of another participant or zone")
+
+	ErrInvalidRunTimeRange = errors.New("invalid run time range: expected RFC3339 times, from before to")
 )
 
+// RunsPerZoneExceededError is returned when a participant already has the runs expected in a zone
+type RunsPerZoneExceededError struct {
+	Zone       string
+	MaxRuns    int32
+	RunNumbers []int32 // runs of the participant in the zone, neither voided nor deleted
+}
+
+func (e *RunsPerZoneExceededError) Error() string {
+	return fmt.Sprintf("the participant already has the %d runs expected in zone %s", e.MaxRuns, e.Zone)
+}
+
 // RunService implements the RunService interface
 type RunService struct {
-	runRepo         repository.RunRepository
-	participantRepo repository.ParticipantRepository
-	liverankingRepo repository.LiverankingRepository
-	scaleRepo       repository.ScaleRepository
-	cfg             *config.Config
+	runRepo             repository.RunRepository
+	competitionRepo     repository.CompetitionRepository
+	participantRepo     repository.ParticipantRepository
+	liverankingRepo     repository.LiverankingRepository
+	scaleRepo           repository.ScaleRepository
+	zoneBoundsRepo      repository.ZoneBoundsRepository
+	zoneRepo            repository.ZoneRepository
+	settingsRepo        repository.CompetitionSettingsRepository
+	assignmentRepo      repository.RefereeAssignmentRepository
+	pendingRunRepo      repository.PendingRunRepository
+	metrics             *Metrics
+	degradedMode        *DegradedMode
+	liverankingVersions *LiverankingVersions
+	cfg                 *config.Config
 }
 
 // RunServiceConfiguration is a function that configures a RunService
@@ -50,6 +95,14 @@ func RunConfWithRunRepo(repo repository.RunRepository) RunServiceConfiguration {
 	}
 }
 
+// RunConfWithCompetitionRepo configures the RunService with a CompetitionRepository
+func RunConfWithCompetitionRepo(repo repository.CompetitionRepository) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.competitionRepo = repo
+		return nil
+	}
+}
+
 // RunConfWithParticipantRepo configures the RunService with a ParticipantRepository
 func RunConfWithParticipantRepo(repo repository.ParticipantRepository) RunServiceConfiguration {
 	return func(r *RunService) error {
@@ -74,6 +127,70 @@ func RunConfWithScaleRepo(repo repository.ScaleRepository) RunServiceConfigurati
 	}
 }
 
+// RunConfWithZoneBoundsRepo configures the RunService with a ZoneBoundsRepository
+func RunConfWithZoneBoundsRepo(repo repository.ZoneBoundsRepository) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.zoneBoundsRepo = repo
+		return nil
+	}
+}
+
+// RunConfWithZoneRepo configures the RunService with a ZoneRepository
+func RunConfWithZoneRepo(repo repository.ZoneRepository) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.zoneRepo = repo
+		return nil
+	}
+}
+
+// RunConfWithSettingsRepo configures the RunService with a CompetitionSettingsRepository
+func RunConfWithSettingsRepo(repo repository.CompetitionSettingsRepository) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.settingsRepo = repo
+		return nil
+	}
+}
+
+// RunConfWithRefereeAssignmentRepo configures the RunService with a RefereeAssignmentRepository
+func RunConfWithRefereeAssignmentRepo(repo repository.RefereeAssignmentRepository) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.assignmentRepo = repo
+		return nil
+	}
+}
+
+// RunConfWithPendingRunRepo configures the RunService with a PendingRunRepository
+func RunConfWithPendingRunRepo(repo repository.PendingRunRepository) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.pendingRunRepo = repo
+		return nil
+	}
+}
+
+// RunConfWithMetrics configures the RunService with the metrics counting the recorded runs
+func RunConfWithMetrics(metrics *Metrics) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.metrics = metrics
+		return nil
+	}
+}
+
+// RunConfWithDegradedMode configures the RunService with the switch deferring the liveranking updates
+func RunConfWithDegradedMode(degradedMode *DegradedMode) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.degradedMode = degradedMode
+		return nil
+	}
+}
+
+// RunConfWithLiverankingVersions configures the RunService with the counter of the liveranking versions
+func RunConfWithLiverankingVersions(versions *LiverankingVersions) RunServiceConfiguration {
+	return func(r *RunService) error {
+		r.liverankingVersions = versions
+		return nil
+	}
+}
+
 // RunConfWithConfig configures the RunService with a Config
 func RunConfWithConfig(cfg *config.Config) RunServiceConfiguration {
 	return func(r *RunService) error {
@@ -82,11 +199,26 @@ func RunConfWithConfig(cfg *config.Config) RunServiceConfiguration {
 	}
 }
 
-// CreateRun creates a new run and updates the liveranking
+// CreateRun creates a new run with its receipt code and updates the liveranking, the competition must be running and the zone open
 func (s *RunService) CreateRun(ctx context.Context, run *aggregate.Run) error {
 	if run.GetCompetitionID() <= 0 || run.GetDossard() <= 0 || run.GetZone() == "" {
 		return ErrInvalidRunData
 	}
+	if !aggregate.IsRunStatus(run.GetStatus()) {
+		return ErrUnknownRunStatus
+	}
+	if err := checkRunChrono(run); err != nil {
+		return err
+	}
+
+	// Referees cannot score before the official start nor after the end
+	competition, err := s.competitionRepo.GetCompetition(ctx, run.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+	if !competition.IsRunning() {
+		return ErrCompetitionNotRunning
+	}
 
 	// Get the participant to retrieve the category
 	participant, err := s.participantRepo.GetParticipant(ctx, run.GetCompetitionID(), run.GetDossard())
@@ -95,36 +227,61 @@ func (s *RunService) CreateRun(ctx context.Context, run *aggregate.Run) error {
 	}
 
 	// Get the scale for the category and zone
-	scale, err := s.scaleRepo.GetScale(ctx, run.GetCompetitionID(), participant.GetCategory(), run.GetZone())
+	scale, err := s.getRunScale(ctx, run.GetCompetitionID(), participant.GetCategory(), run.GetZone())
+	if err != nil {
+		return err
+	}
+	if err := checkRunDoors(run, scale); err != nil {
+		return err
+	}
+
+	// Late or erroneous runs are rejected once the judging of the zone ended
+	if err := s.checkZoneOpen(ctx, run.GetCompetitionID(), run.GetZone()); err != nil {
+		return err
+	}
+
+	// A participant cannot run a zone more often than the settings expect, e.g. a third run in a 2-run zone
+	maxRuns, err := s.expectedRunsPerZone(ctx, run.GetCompetitionID(), participant.GetCategory())
 	if err != nil {
-		return fmt.Errorf("failed to get scale: %w", err)
+		return err
 	}
 
-	// Create the run
-	err = s.runRepo.CreateRun(ctx, run)
+	// The receipt code is returned to the referee, who writes it on the paper backup sheet
+	receiptCode, err := newReceiptCode()
 	if err != nil {
-		return fmt.Errorf("failed to create run: %w", err)
+		return fmt.Errorf("failed to draw the receipt code: %w", err)
 	}
+	run.SetReceiptCode(receiptCode)
 
-	// Calculate points based on doors passed and scale
-	totalPoints := int32(0)
-	if run.GetDoor1() {
-		totalPoints += scale.GetPointsDoor1()
+	// Create the run, the runs of the zone are counted in the same transaction. Voided runs are not counted, the
+	// runs waiting for their approval are
+	runNumbers, err := s.runRepo.CreateRun(ctx, run, maxRuns)
+	if err != nil {
+		return fmt.Errorf("failed to create run: %w", err)
 	}
-	if run.GetDoor2() {
-		totalPoints += scale.GetPointsDoor2()
+	if runNumbers != nil {
+		return &RunsPerZoneExceededError{Zone: run.GetZone(), MaxRuns: maxRuns, RunNumbers: runNumbers}
 	}
-	if run.GetDoor3() {
-		totalPoints += scale.GetPointsDoor3()
+	s.metrics.RunRecorded(run.GetCompetitionID())
+
+	if err := s.applyRunDSQRule(ctx, run, participant); err != nil {
+		return err
 	}
-	if run.GetDoor4() {
-		totalPoints += scale.GetPointsDoor4()
+
+	// The run only counts in the liveranking once approved
+	if run.IsPendingApproval() {
+		return nil
 	}
-	if run.GetDoor5() {
-		totalPoints += scale.GetPointsDoor5()
+
+	// In degraded mode the liveranking is recalculated with the next batch
+	if s.degradedMode.DeferLiveranking(run.GetCompetitionID(), run.GetDossard()) {
+		return nil
 	}
-	if run.GetDoor6() {
-		totalPoints += scale.GetPointsDoor6()
+
+	// Calculate points based on doors passed and scale, the runs not finished or disqualified score none
+	var totalPoints int32
+	if run.IsScored() {
+		totalPoints = scale.CalculatePoints(run.GetDoors())
 	}
 
 	// Create or update liveranking entry
@@ -137,17 +294,47 @@ func (s *RunService) CreateRun(ctx context.Context, run *aggregate.Run) error {
 	liveranking.SetGender(participant.GetGender())
 	liveranking.SetTotalPoints(totalPoints)
 	liveranking.SetPenality(run.GetPenality())
-	liveranking.SetChronoSec(run.GetChronoSec())
+	liveranking.SetChronoMs(run.GetChronoMs())
 
 	// Update the liveranking
 	err = s.liverankingRepo.UpsertLiveranking(ctx, liveranking)
 	if err != nil {
 		return fmt.Errorf("failed to update liveranking: %w", err)
 	}
+	s.liverankingVersions.Increment(run.GetCompetitionID())
 
 	return nil
 }
 
+// FindReplayedRun returns the run already recorded with the idempotency key of the run, nil if the run has no key
+// or none was recorded with it. ErrIdempotencyKeyReused is returned when the key was used for another participant or zone.
+func (s *RunService) FindReplayedRun(ctx context.Context, run *aggregate.Run) (*aggregate.Run, error) {
+	if run.GetIdempotencyKey() == "" {
+		return nil, nil
+	}
+
+	recorded, err := s.runRepo.GetRunByIdempotencyKey(ctx, run.GetCompetitionID(), run.GetIdempotencyKey())
+	if err != nil || recorded == nil {
+		return nil, err
+	}
+
+	if recorded.GetDossard() != run.GetDossard() || recorded.GetZone() != run.GetZone() {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return recorded, nil
+}
+
+// WaitLiverankingChange blocks until the liveranking version of the competition differs from version or the
+// context is done, and returns the current version
+func (s *RunService) WaitLiverankingChange(ctx context.Context, competitionID int32, version int64) int64 {
+	return s.liverankingVersions.Wait(ctx, competitionID, version)
+}
+
+// LiverankingVersion returns the current liveranking version of the competition
+func (s *RunService) LiverankingVersion(competitionID int32) int64 {
+	return s.liverankingVersions.Current(competitionID)
+}
+
 // GetRun retrieves a run by its identifiers
 func (s *RunService) GetRun(ctx context.Context, competitionID, runNumber, dossard int32) (*aggregate.Run, error) {
 	return s.runRepo.GetRun(ctx, competitionID, runNumber, dossard)
@@ -158,6 +345,21 @@ func (s *RunService) ListRuns(ctx context.Context, competitionID int32) ([]*aggr
 	return s.runRepo.ListRuns(ctx, competitionID)
 }
 
+// SearchRuns lists a page of the runs of a competition matching the filter, most recent first, and counts all of them
+func (s *RunService) SearchRuns(ctx context.Context, competitionID int32, filter *aggregate.RunFilter, pageNumber, pageSize int32) ([]*aggregate.Run, int32, error) {
+	if !filter.GetFrom().IsZero() && !filter.GetTo().IsZero() && filter.GetFrom().After(filter.GetTo()) {
+		return nil, 0, ErrInvalidRunTimeRange
+	}
+
+	// Verify the competition exists
+	_, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.runRepo.SearchRuns(ctx, competitionID, filter, pageNumber, pageSize)
+}
+
 // ListRunsByDossard lists all runs for a participant in a competition
 func (s *RunService) ListRunsByDossard(ctx context.Context, competitionID int32, dossard int32) ([]*aggregate.Run, error) {
 	return s.runRepo.ListRunsByDossard(ctx, competitionID, dossard)
@@ -168,34 +370,400 @@ func (s *RunService) ListRunsByDossardWithDetails(ctx context.Context, competiti
 	return s.runRepo.ListRunsByDossardWithDetails(ctx, competitionID, dossard)
 }
 
-// UpdateRun updates an existing run and recalculates liveranking
-func (s *RunService) UpdateRun(ctx context.Context, run *aggregate.Run) error {
-	err := s.runRepo.UpdateRun(ctx, run)
+// UpdateRun updates an existing run and recalculates liveranking, unless the competition is closed.
+// The change of its values is recorded in the revisions of the run with the editor.
+func (s *RunService) UpdateRun(ctx context.Context, run *aggregate.Run, editorID int32) error {
+	if err := s.checkNotClosed(ctx, run.GetCompetitionID()); err != nil {
+		return err
+	}
+
+	if !aggregate.IsRunStatus(run.GetStatus()) {
+		return ErrUnknownRunStatus
+	}
+	if err := checkRunChrono(run); err != nil {
+		return err
+	}
+
+	// The zone may have been changed, it must still be scored for the category of the participant
+	participant, err := s.participantRepo.GetParticipant(ctx, run.GetCompetitionID(), run.GetDossard())
 	if err != nil {
+		return fmt.Errorf("participant not found: %w", err)
+	}
+	scale, err := s.getRunScale(ctx, run.GetCompetitionID(), participant.GetCategory(), run.GetZone())
+	if err != nil {
+		return err
+	}
+	if err := checkRunDoors(run, scale); err != nil {
+		return err
+	}
+
+	err = s.runRepo.UpdateRun(ctx, run, editorID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyRunDSQRule(ctx, run, participant); err != nil {
 		return err
 	}
 
-	// Recalculate liveranking for this participant
+	// Recalculate liveranking for this participant, with the next batch in degraded mode
+	if s.degradedMode.DeferLiveranking(run.GetCompetitionID(), run.GetDossard()) {
+		return nil
+	}
 	err = s.liverankingRepo.RecalculateLiveranking(ctx, run.GetCompetitionID(), run.GetDossard())
 	if err != nil {
 		return fmt.Errorf("failed to recalculate liveranking: %w", err)
 	}
+	s.liverankingVersions.Increment(run.GetCompetitionID())
 
 	return nil
 }
 
-// DeleteRun deletes a run and recalculates liveranking
+// ListRunRevisions lists the changes of the values of a run, oldest first
+func (s *RunService) ListRunRevisions(ctx context.Context, competitionID, dossard, runNumber int32) ([]*aggregate.RunRevision, error) {
+	// Verify the run exists
+	if _, err := s.runRepo.GetRun(ctx, competitionID, runNumber, dossard); err != nil {
+		return nil, err
+	}
+
+	return s.runRepo.ListRunRevisions(ctx, competitionID, dossard, runNumber)
+}
+
+// DeleteRun deletes a run and recalculates liveranking, unless the competition is closed
 func (s *RunService) DeleteRun(ctx context.Context, competitionID, runNumber, dossard int32) error {
+	if err := s.checkNotClosed(ctx, competitionID); err != nil {
+		return err
+	}
+
 	err := s.runRepo.DeleteRun(ctx, competitionID, runNumber, dossard)
 	if err != nil {
 		return err
 	}
 
-	// Recalculate liveranking for this participant
+	// Recalculate liveranking for this participant, with the next batch in degraded mode
+	if s.degradedMode.DeferLiveranking(competitionID, dossard) {
+		return nil
+	}
 	err = s.liverankingRepo.RecalculateLiveranking(ctx, competitionID, dossard)
 	if err != nil {
 		return fmt.Errorf("failed to recalculate liveranking: %w", err)
 	}
+	s.liverankingVersions.Increment(competitionID)
 
 	return nil
 }
+
+// VoidOwnRun lets the referee who recorded a run void it within the undo window, e.g. after a typo.
+// The run is kept but no longer counts, and the liveranking of the participant is recalculated.
+func (s *RunService) VoidOwnRun(ctx context.Context, competitionID, runNumber, dossard, refereeID int32) (*aggregate.Run, error) {
+	run, err := s.runRepo.GetRun(ctx, competitionID, runNumber, dossard)
+	if err != nil {
+		return nil, err
+	}
+	if run.GetRefereeId() != refereeID {
+		return nil, ErrNotRunReferee
+	}
+	if run.IsVoided() {
+		return nil, ErrRunAlreadyVoided
+	}
+	if time.Since(run.GetCreatedAt()) > s.cfg.Runs.UndoWindow {
+		return nil, ErrUndoWindowExpired
+	}
+	if err := s.checkNotClosed(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.runRepo.VoidRun(ctx, competitionID, runNumber, dossard); err != nil {
+		return nil, err
+	}
+	run.SetVoidedAt(time.Now())
+	s.liverankingVersions.Increment(competitionID)
+
+	return run, nil
+}
+
+// UpdateOwnRun lets the referee who recorded a run edit it within the correction window, e.g. after a wrong door.
+// The change is kept in the revisions of the run like those of the admins, and the liveranking recalculated.
+func (s *RunService) UpdateOwnRun(ctx context.Context, run *aggregate.Run, refereeID int32) error {
+	recorded, err := s.getOwnRunToCorrect(ctx, run.GetCompetitionID(), run.GetRunNumber(), run.GetDossard(), refereeID)
+	if err != nil {
+		return err
+	}
+
+	// The run stays recorded by its referee, who may still be restricted to their zones
+	run.SetRefereeId(recorded.GetRefereeId())
+	if err := s.CheckRefereeZone(ctx, run); err != nil {
+		return err
+	}
+
+	return s.UpdateRun(ctx, run, refereeID)
+}
+
+// DeleteOwnRun lets the referee who recorded a run delete it within the correction window, e.g. after scoring
+// the wrong dossard, and recalculates the liveranking of the participant
+func (s *RunService) DeleteOwnRun(ctx context.Context, competitionID, runNumber, dossard, refereeID int32) error {
+	if _, err := s.getOwnRunToCorrect(ctx, competitionID, runNumber, dossard, refereeID); err != nil {
+		return err
+	}
+
+	return s.DeleteRun(ctx, competitionID, runNumber, dossard)
+}
+
+// getOwnRunToCorrect returns the run a referee corrects. ErrNotRunReferee is returned when another referee
+// recorded it and ErrCorrectionWindowExpired once the correction window after its recording is over.
+func (s *RunService) getOwnRunToCorrect(ctx context.Context, competitionID, runNumber, dossard, refereeID int32) (*aggregate.Run, error) {
+	run, err := s.runRepo.GetRun(ctx, competitionID, runNumber, dossard)
+	if err != nil {
+		return nil, err
+	}
+	if run.GetRefereeId() != refereeID {
+		return nil, ErrNotRunReferee
+	}
+	if run.IsVoided() {
+		return nil, ErrRunAlreadyVoided
+	}
+	if time.Since(run.GetCreatedAt()) > s.cfg.Runs.CorrectionWindow {
+		return nil, ErrCorrectionWindowExpired
+	}
+	return run, nil
+}
+
+// RequiresRunApproval returns whether the competition settings keep the runs of the referees out of the
+// liveranking until an admin approves them
+func (s *RunService) RequiresRunApproval(ctx context.Context, competitionID int32) (bool, error) {
+	settings, err := s.settingsRepo.GetCompetitionSettings(ctx, competitionID)
+	if err != nil {
+		return false, err
+	}
+	return settings != nil && settings.RequiresRunApproval(), nil
+}
+
+// ApproveRun approves a run recorded by a referee so that it counts in the ranking, the liveranking of the
+// participant is recalculated and the run DSQ rule of the settings applied
+func (s *RunService) ApproveRun(ctx context.Context, competitionID, runNumber, dossard int32) (*aggregate.Run, error) {
+	run, err := s.runRepo.GetRun(ctx, competitionID, runNumber, dossard)
+	if err != nil {
+		return nil, err
+	}
+	if run.IsVoided() {
+		return nil, ErrRunAlreadyVoided
+	}
+	if !run.IsPendingApproval() {
+		return nil, ErrRunNotPendingApproval
+	}
+	if err := s.checkNotClosed(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.runRepo.ApproveRun(ctx, competitionID, runNumber, dossard); err != nil {
+		return nil, err
+	}
+	run.SetPendingApproval(false)
+	run.SetUpdatedAt(time.Now())
+	s.liverankingVersions.Increment(competitionID)
+
+	participant, err := s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+	if err != nil {
+		return nil, fmt.Errorf("participant not found: %w", err)
+	}
+	if err := s.applyRunDSQRule(ctx, run, participant); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// getRunScale returns the scale of the zone for the category, so that runs are only recorded in the zones
+// of their own competition. ErrUnknownZone is returned when the competition has no such zone and
+// ErrScaleNotFound when the zone is not scored for the category.
+func (s *RunService) getRunScale(ctx context.Context, competitionID int32, category, zone string) (*aggregate.Scale, error) {
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneFound := false
+	for _, zoneInfo := range zones {
+		if zoneInfo.GetZone() != zone {
+			continue
+		}
+		zoneFound = true
+		if zoneInfo.GetCategory() == category {
+			return s.scaleRepo.GetScale(ctx, competitionID, category, zone)
+		}
+	}
+
+	if !zoneFound {
+		return nil, ErrUnknownZone
+	}
+	return nil, ErrScaleNotFound
+}
+
+// applyRunDSQRule disqualifies the participant of a disqualified run when the competition settings say so, once
+// the run is approved. The participants already disqualified keep the reason they were given.
+func (s *RunService) applyRunDSQRule(ctx context.Context, run *aggregate.Run, participant *aggregate.Participant) error {
+	if run.GetStatus() != aggregate.RunStatusDSQ || run.IsPendingApproval() || participant.GetStatus() == aggregate.ParticipantStatusDSQ {
+		return nil
+	}
+
+	settings, err := s.settingsRepo.GetCompetitionSettings(ctx, run.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+	if settings == nil || settings.GetRunDSQRule() != aggregate.RunDSQDisqualifies {
+		return nil
+	}
+
+	reason := fmt.Sprintf("run %d disqualified in zone %s", run.GetRunNumber(), run.GetZone())
+	err = s.participantRepo.SetParticipantStatus(ctx, run.GetCompetitionID(), run.GetDossard(), aggregate.ParticipantStatusDSQ, reason)
+	if err != nil {
+		return fmt.Errorf("failed to disqualify the participant: %w", err)
+	}
+	return nil
+}
+
+// checkRunDoors returns ErrTooManyDoors when the run has doors the scale of its zone does not have,
+// the doors it lacks count as not passed
+func checkRunDoors(run *aggregate.Run, scale *aggregate.Scale) error {
+	if len(run.GetDoors()) > scale.GetDoorCount() {
+		return fmt.Errorf("%w: %d doors for %d in zone %s", ErrTooManyDoors, len(run.GetDoors()), scale.GetDoorCount(), run.GetZone())
+	}
+	return nil
+}
+
+// checkRunChrono returns ErrInvalidChrono when the chrono of the run is negative or longer than maxRunChronoMs
+func checkRunChrono(run *aggregate.Run) error {
+	if run.GetChronoMs() < 0 || run.GetChronoMs() > maxRunChronoMs {
+		return fmt.Errorf("%w: %d ms", ErrInvalidChrono, run.GetChronoMs())
+	}
+	return nil
+}
+
+// CheckRefereeZone returns ErrZoneNotAssigned when the competition restricts the referees to their zones and
+// the referee of the run is not assigned to its zone
+func (s *RunService) CheckRefereeZone(ctx context.Context, run *aggregate.Run) error {
+	settings, err := s.settingsRepo.GetCompetitionSettings(ctx, run.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+	if settings == nil || !settings.RestrictsRefereeZones() {
+		return nil
+	}
+
+	zones, err := s.assignmentRepo.ListRefereeZones(ctx, run.GetCompetitionID(), run.GetRefereeId())
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(zones, run.GetZone()) {
+		return ErrZoneNotAssigned
+	}
+	return nil
+}
+
+// expectedRunsPerZone returns the runs the settings of the competition expect from each participant of the
+// category in each of its zones
+func (s *RunService) expectedRunsPerZone(ctx context.Context, competitionID int32, category string) (int32, error) {
+	settings, err := s.settingsRepo.GetCompetitionSettings(ctx, competitionID)
+	if err != nil {
+		return 0, err
+	}
+	if settings == nil {
+		settings = aggregate.NewCompetitionSettings()
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return 0, err
+	}
+	zoneCount := 0
+	for _, zone := range zones {
+		if zone.GetCategory() == category {
+			zoneCount++
+		}
+	}
+	return int32(settings.ExpectedRunsPerZone(zoneCount)), nil
+}
+
+// checkZoneOpen returns ErrZoneClosed when the zone of the competition is closed to new runs
+func (s *RunService) checkZoneOpen(ctx context.Context, competitionID int32, zone string) error {
+	zones, err := s.zoneRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	for _, competitionZone := range zones {
+		if competitionZone.GetZone() == zone && !competitionZone.IsOpen() {
+			return ErrZoneClosed
+		}
+	}
+	return nil
+}
+
+// checkNotClosed returns ErrCompetitionClosed when the competition is closed, freezing its runs and liveranking
+func (s *RunService) checkNotClosed(ctx context.Context, competitionID int32) error {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	if competition.IsClosed() {
+		return ErrCompetitionClosed
+	}
+	return nil
+}
+
+// GetZoneThroughput computes, for every zone of the competition, the number of runs recorded
+// over the window, the resulting runs per hour and the average interval between two runs
+func (s *RunService) GetZoneThroughput(ctx context.Context, competitionID int32, window time.Duration) ([]*aggregate.ZoneThroughput, error) {
+	if window <= 0 {
+		return nil, ErrInvalidRunData
+	}
+
+	runs, err := s.runRepo.ListRunsSince(ctx, competitionID, time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs are ordered by creation time, so each zone's timestamps are sorted too
+	timestampsByZone := make(map[string][]time.Time)
+	for _, run := range runs {
+		timestampsByZone[run.GetZone()] = append(timestampsByZone[run.GetZone()], run.GetCreatedAt())
+	}
+
+	// Report zones without any run as well, they are the slowest ones
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		if _, exists := timestampsByZone[zone.GetZone()]; !exists {
+			timestampsByZone[zone.GetZone()] = nil
+		}
+	}
+
+	zoneNames := make([]string, 0, len(timestampsByZone))
+	for zone := range timestampsByZone {
+		zoneNames = append(zoneNames, zone)
+	}
+	sort.Strings(zoneNames)
+
+	throughputs := make([]*aggregate.ZoneThroughput, 0, len(zoneNames))
+	for _, zone := range zoneNames {
+		timestamps := timestampsByZone[zone]
+
+		throughput := aggregate.NewZoneThroughput()
+		throughput.SetZone(zone)
+		throughput.SetRunCount(int32(len(timestamps)))
+		throughput.SetRunsPerHour(float64(len(timestamps)) / window.Hours())
+
+		if len(timestamps) > 0 {
+			throughput.SetLastRunAt(timestamps[len(timestamps)-1])
+		}
+		if len(timestamps) > 1 {
+			elapsed := timestamps[len(timestamps)-1].Sub(timestamps[0])
+			throughput.SetAverageIntervalSec(elapsed.Seconds() / float64(len(timestamps)-1))
+		}
+
+		throughputs = append(throughputs, throughput)
+	}
+
+	return throughputs, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// ListRunConflicts lists the participants scored more often in a zone than the settings expect for their category,
+// extra runs usually come from two referees scoring the same passage.
+func (s *RunService) ListRunConflicts(ctx context.Context, competitionID int32) ([]*aggregate.RunConflict, error) {
+	runs, err := s.runRepo.ListConflictingRuns(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return []*aggregate.RunConflict{}, nil
+	}
+
+	participants, err := s.participantRepo.ListParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[int32]string, len(participants))
+	for _, participant := range participants {
+		categories[participant.GetDossardNumber()] = participant.GetCategory()
+	}
+	maxRunsByCategory := make(map[string]int32)
+
+	// Runs are ordered by dossard and zone, so the runs of a conflict are consecutive
+	conflicts := []*aggregate.RunConflict{}
+	var current *aggregate.RunConflict
+	for _, run := range runs {
+		if current == nil || current.GetDossard() != run.GetDossard() || current.GetZone() != run.GetZone() {
+			current = aggregate.NewRunConflict()
+			current.SetDossard(run.GetDossard())
+			current.SetZone(run.GetZone())
+			conflicts = append(conflicts, current)
+		}
+		current.AddRun(run)
+	}
+
+	// The zones expecting several runs are only in conflict beyond them
+	exceeding := []*aggregate.RunConflict{}
+	for _, conflict := range conflicts {
+		category := categories[conflict.GetDossard()]
+		maxRuns, ok := maxRunsByCategory[category]
+		if !ok {
+			if maxRuns, err = s.expectedRunsPerZone(ctx, competitionID, category); err != nil {
+				return nil, err
+			}
+			maxRunsByCategory[category] = maxRuns
+		}
+		if int32(len(conflict.GetRuns())) > maxRuns {
+			exceeding = append(exceeding, conflict)
+		}
+	}
+
+	return exceeding, nil
+}
+
+// ResolveRunConflict keeps the given run as the authoritative score of the participant in its zone, the runs of
+// the zone beyond the ones expected for the category of the participant are voided and the liveranking is updated
+// accordingly, unless the competition is closed
+func (s *RunService) ResolveRunConflict(ctx context.Context, competitionID, dossard, keptRunNumber int32) (int32, error) {
+	if competitionID <= 0 || dossard <= 0 || keptRunNumber <= 0 {
+		return 0, ErrInvalidRunData
+	}
+	if err := s.checkNotClosed(ctx, competitionID); err != nil {
+		return 0, err
+	}
+
+	participant, err := s.participantRepo.GetParticipant(ctx, competitionID, dossard)
+	if err != nil {
+		return 0, err
+	}
+	maxRuns, err := s.expectedRunsPerZone(ctx, competitionID, participant.GetCategory())
+	if err != nil {
+		return 0, err
+	}
+
+	voided, err := s.runRepo.ResolveRunConflict(ctx, competitionID, dossard, keptRunNumber, maxRuns)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve run conflict: %w", err)
+	}
+	s.liverankingVersions.Increment(competitionID)
+
+	return voided, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+const (
+	// runReceiptCodeLength is the number of characters of a run receipt code, short enough to be read out
+	// and written on the paper backup sheet. With its alphabet, about 8.5e11 codes can be drawn.
+	runReceiptCodeLength = 8
+	// runReceiptCodeAlphabet leaves out the characters mistaken for one another when handwritten: 0, O, 1, I and L
+	runReceiptCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+)
+
+// ErrInvalidReceiptCode is returned when looking up a run with a code which cannot be a receipt code
+var ErrInvalidReceiptCode = errors.New("invalid receipt code, expected 8 letters and digits")
+
+// GetRunByReceiptCode returns the run with the receipt code, read from a paper backup sheet during a dispute.
+// The code is case insensitive and can be split with dashes or spaces.
+func (s *RunService) GetRunByReceiptCode(ctx context.Context, receiptCode string) (*aggregate.Run, error) {
+	receiptCode = normalizeReceiptCode(receiptCode)
+	if len(receiptCode) != runReceiptCodeLength || strings.Trim(receiptCode, runReceiptCodeAlphabet) != "" {
+		return nil, ErrInvalidReceiptCode
+	}
+
+	return s.runRepo.GetRunByReceiptCode(ctx, receiptCode)
+}
+
+// normalizeReceiptCode upper cases a receipt code and removes the dashes and spaces it was written with
+func normalizeReceiptCode(receiptCode string) string {
+	receiptCode = strings.ToUpper(receiptCode)
+	return strings.NewReplacer("-", "", " ", "").Replace(receiptCode)
+}
+
+// newReceiptCode draws a random run receipt code
+func newReceiptCode() (string, error) {
+	code := make([]byte, runReceiptCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(runReceiptCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = runReceiptCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
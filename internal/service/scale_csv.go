@@ -0,0 +1,299 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// maxScaleImportRows bounds the scales of a single import
+const maxScaleImportRows = 1000
+
+var (
+	// ErrEmptyScaleImport is returned when the import file has no scale row
+	ErrEmptyScaleImport = errors.New("the file contains no scale, expected rows of category, zone and the points of each door")
+	// ErrTooManyScaleImportRows is returned when the import file exceeds maxScaleImportRows
+	ErrTooManyScaleImportRows = fmt.Errorf("the file contains more than %d scales", maxScaleImportRows)
+	// ErrScaleImportColumns is returned when the header of the import file lacks one of the columns of the export
+	ErrScaleImportColumns = fmt.Errorf("the header of the file must name the category, zone and points_door1 to at most points_door%d columns", aggregate.MaxScaleDoors)
+	// ErrInvalidScaleImport is returned when a row of the import file is invalid, nothing is imported then
+	ErrInvalidScaleImport = errors.New("invalid scale")
+)
+
+// scaleImportColumns lists the accepted header names of the columns of a scales import, once normalized
+var scaleImportColumns = map[string][]string{
+	"category": {"category", "categorie"},
+	"zone":     {"zone"},
+}
+
+// scaleImportDoorColumn matches the normalized header names of the door columns of a scales import, such as
+// points_door1, door1 or porte1
+var scaleImportDoorColumn = regexp.MustCompile(`^(?:pointsdoor|door|porte)(\d+)$`)
+
+// ExportScales exports the scales of the competition as a CSV file, one row per category and zone, and returns
+// it with its filename. There is a points column per door of the zone with the most doors, the cells of the doors
+// the other zones lack are empty. The file is separated by semicolons and starts with a byte order mark so that spreadsheets
+// in French locales open it as is.
+func (s *CompetitionService) ExportScales(ctx context.Context, competitionID int32) ([]byte, string, error) {
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.SliceStable(zones, func(i, j int) bool {
+		if zones[i].GetCategory() != zones[j].GetCategory() {
+			return zones[i].GetCategory() < zones[j].GetCategory()
+		}
+		return zones[i].GetZone() < zones[j].GetZone()
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("\xef\xbb\xbf")
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+
+	scales := make([]*aggregate.Scale, 0, len(zones))
+	doors := 1
+	for _, zone := range zones {
+		scale, err := s.scaleRepo.GetScale(ctx, competitionID, zone.GetCategory(), zone.GetZone())
+		if err != nil {
+			return nil, "", err
+		}
+		scales = append(scales, scale)
+		doors = max(doors, scale.GetDoorCount())
+	}
+
+	header := []string{"category", "zone"}
+	for door := 1; door <= doors; door++ {
+		header = append(header, fmt.Sprintf("points_door%d", door))
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, "", err
+	}
+	for _, scale := range scales {
+		record := make([]string, len(header))
+		record[0], record[1] = scale.GetCategory(), scale.GetZone()
+		for i, points := range scale.GetDoorPoints() {
+			record[2+i] = strconv.Itoa(int(points))
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, "", err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, "", err
+	}
+
+	filename := strings.ReplaceAll(competition.GetName(), " ", "_") + "_scales.csv"
+	return buf.Bytes(), filename, nil
+}
+
+// ImportScales imports the scales of a CSV or Excel file with the columns of the export, found by their header, the
+// format being told by the extension of the filename like the participants imports. A zone has
+// as many doors as the points filled in from the first door column. The scales of the categories and zones the
+// competition already has are updated, the others are created. Every row is
+// checked before any scale is written, so that an invalid row leaves the scales of the competition unchanged.
+// It returns the number of scales created and updated.
+func (s *CompetitionService) ImportScales(ctx context.Context, competitionID int32, file io.Reader, filename string) (int32, int32, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return 0, 0, err
+	}
+
+	var rows [][]string
+	var err error
+	lowerFilename := strings.ToLower(filename)
+	if strings.HasSuffix(lowerFilename, ".xlsx") || strings.HasSuffix(lowerFilename, ".xls") {
+		rows, err = s.readExcelFile(file)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: failed to read Excel file: %v", ErrInvalidScaleImport, err)
+		}
+	} else {
+		rows, err = readImportRows(file)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %v", ErrInvalidScaleImport, err)
+		}
+	}
+	if len(rows) == 0 {
+		return 0, 0, ErrEmptyScaleImport
+	}
+	columns, doors, err := scaleImportHeader(rows[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	rows = rows[1:]
+	if len(rows) == 0 {
+		return 0, 0, ErrEmptyScaleImport
+	}
+	if len(rows) > maxScaleImportRows {
+		return 0, 0, ErrTooManyScaleImportRows
+	}
+
+	categories, err := s.categoryRepo.ListCategories(ctx, competitionID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Line numbers of the errors are those of the file, the header being the first line
+	scales := make([]*aggregate.Scale, 0, len(rows))
+	seen := make(map[string]int)
+	for i, row := range rows {
+		line := i + 2
+		scale, err := parseScaleRow(row, columns, doors, categories)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w on line %d: %v", ErrInvalidScaleImport, line, err)
+		}
+		scale.SetCompetitionID(competitionID)
+
+		key := scale.GetCategory() + "_" + scale.GetZone()
+		if previous, found := seen[key]; found {
+			return 0, 0, fmt.Errorf("%w on line %d: zone %s of category %s already on line %d", ErrInvalidScaleImport, line, scale.GetZone(), scale.GetCategory(), previous)
+		}
+		seen[key] = line
+		scales = append(scales, scale)
+	}
+
+	zones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return 0, 0, err
+	}
+	existing := make(map[string]bool)
+	zonesPerCategory := make(map[string]int32)
+	for _, zone := range zones {
+		existing[zone.GetCategory()+"_"+zone.GetZone()] = true
+		zonesPerCategory[zone.GetCategory()]++
+	}
+
+	// The zones created must fit in the zones per category of the settings
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, scale := range scales {
+		if existing[scale.GetCategory()+"_"+scale.GetZone()] {
+			continue
+		}
+		zonesPerCategory[scale.GetCategory()]++
+		if settings.GetZonesPerCategory() > 0 && zonesPerCategory[scale.GetCategory()] > settings.GetZonesPerCategory() {
+			return 0, 0, fmt.Errorf("%w: category %s", ErrTooManyZones, scale.GetCategory())
+		}
+	}
+
+	created, updated := int32(0), int32(0)
+	for _, scale := range scales {
+		if existing[scale.GetCategory()+"_"+scale.GetZone()] {
+			if err := s.scaleRepo.UpdateScale(ctx, scale); err != nil {
+				return created, updated, fmt.Errorf("failed to update the scale of zone %s of category %s: %w", scale.GetZone(), scale.GetCategory(), err)
+			}
+			updated++
+			continue
+		}
+
+		if err := s.scaleRepo.CreateScale(ctx, scale); err != nil {
+			return created, updated, fmt.Errorf("failed to create the scale of zone %s of category %s: %w", scale.GetZone(), scale.GetCategory(), err)
+		}
+		if err := s.zoneRepo.EnsureZone(ctx, competitionID, scale.GetZone()); err != nil {
+			return created, updated, fmt.Errorf("failed to create zone %s: %w", scale.GetZone(), err)
+		}
+		created++
+	}
+
+	return created, updated, nil
+}
+
+// scaleImportHeader returns the index of the columns of a scales import named in the header and the number of
+// door columns, which must follow each other from points_door1
+func scaleImportHeader(header []string) (map[string]int, int, error) {
+	columns := make(map[string]int)
+	doors := 0
+	for index, name := range header {
+		normalized := normalizeImportColumn(name)
+		if match := scaleImportDoorColumn.FindStringSubmatch(normalized); match != nil {
+			door, err := strconv.Atoi(match[1])
+			if err != nil || door < 1 || door > aggregate.MaxScaleDoors {
+				return nil, 0, ErrScaleImportColumns
+			}
+			column := fmt.Sprintf("points_door%d", door)
+			if _, found := columns[column]; !found {
+				columns[column] = index
+				doors = max(doors, door)
+			}
+			continue
+		}
+		for column, aliases := range scaleImportColumns {
+			if _, found := columns[column]; found || !isOneOf(normalized, aliases) {
+				continue
+			}
+			columns[column] = index
+		}
+	}
+
+	// Every door column up to the last one is required
+	if doors == 0 || len(columns) != len(scaleImportColumns)+doors {
+		return nil, 0, ErrScaleImportColumns
+	}
+	return columns, doors, nil
+}
+
+// parseScaleRow parses a row of a scales import, the category being one of the competition when it defines categories.
+// The zone has a door per points cell filled in before the first empty one, the cells after it must be empty.
+func parseScaleRow(row []string, columns map[string]int, doors int, categories []*aggregate.Category) (*aggregate.Scale, error) {
+	cell := func(column string) string {
+		if columns[column] >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[columns[column]])
+	}
+
+	scale := aggregate.NewScale()
+
+	category, err := resolveCategory(categories, cell("category"))
+	if err != nil {
+		return nil, err
+	}
+	if category == "" {
+		return nil, errors.New("the category is required")
+	}
+	scale.SetCategory(category)
+
+	if cell("zone") == "" {
+		return nil, errors.New("the zone is required")
+	}
+	scale.SetZone(cell("zone"))
+
+	var doorPoints []int32
+	for door := 1; door <= doors; door++ {
+		column := fmt.Sprintf("points_door%d", door)
+		if cell(column) == "" {
+			continue
+		}
+		if len(doorPoints) != door-1 {
+			return nil, fmt.Errorf("%s is filled in but points_door%d is empty, the doors of a zone follow each other", column, len(doorPoints)+1)
+		}
+		points, err := strconv.ParseInt(cell(column), 10, 32)
+		if err != nil || points < 0 {
+			return nil, fmt.Errorf("invalid %s %q, expected a number of points, zero or more", column, cell(column))
+		}
+		doorPoints = append(doorPoints, int32(points))
+	}
+	if len(doorPoints) == 0 {
+		return nil, errors.New("the points of the first door are required")
+	}
+	scale.SetDoorPoints(doorPoints)
+
+	return scale, nil
+}
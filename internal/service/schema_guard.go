@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/repository"
+)
+
+// defaultSchemaCheckInterval is used when the configured interval is not positive
+const defaultSchemaCheckInterval = 30 * time.Second
+
+// SchemaStatus describes the schema of the database compared to the schema of the instance
+type SchemaStatus struct {
+	ExpectedVersion int32
+	DatabaseVersion int32
+	MigratedAt      time.Time
+	CheckedAt       time.Time
+	ReadOnly        bool
+	Missing         []string
+}
+
+// SchemaGuard keeps an instance from writing to a database migrated by a newer version of the service, whose
+// event data it could corrupt during a rolling upgrade. The schema version of the database is read again at most
+// every interval and the instance is read-only while the database is ahead of it.
+// A nil *SchemaGuard is valid and never read-only.
+type SchemaGuard struct {
+	schemaRepo      repository.SchemaRepository
+	expectedVersion int32
+	interval        time.Duration
+
+	mutex           sync.Mutex
+	databaseVersion int32
+	migratedAt      time.Time
+	checkedAt       time.Time
+}
+
+// NewSchemaGuard creates the guard of an instance expecting the schema version
+func NewSchemaGuard(schemaRepo repository.SchemaRepository, expectedVersion int32, interval time.Duration) *SchemaGuard {
+	if interval <= 0 {
+		interval = defaultSchemaCheckInterval
+	}
+
+	return &SchemaGuard{
+		schemaRepo:      schemaRepo,
+		expectedVersion: expectedVersion,
+		interval:        interval,
+	}
+}
+
+// IsReadOnly returns whether the database was migrated by a newer version of the service.
+// The version read last is kept when it cannot be read again.
+func (g *SchemaGuard) IsReadOnly(ctx context.Context) bool {
+	if g == nil {
+		return false
+	}
+
+	g.mutex.Lock()
+	stale := time.Since(g.checkedAt) >= g.interval
+	if stale {
+		// The other requests keep the version read last while this one reads it again
+		g.checkedAt = time.Now()
+	}
+	g.mutex.Unlock()
+
+	if stale {
+		g.refresh(ctx)
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.databaseVersion > g.expectedVersion
+}
+
+// Status reads the schema version of the database again and returns the status of the schema
+func (g *SchemaGuard) Status(ctx context.Context) (*SchemaStatus, error) {
+	version, migratedAt, err := g.schemaRepo.GetSchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	g.record(version, migratedAt)
+
+	missing, err := g.schemaRepo.ListMissingSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return &SchemaStatus{
+		ExpectedVersion: g.expectedVersion,
+		DatabaseVersion: g.databaseVersion,
+		MigratedAt:      g.migratedAt,
+		CheckedAt:       g.checkedAt,
+		ReadOnly:        g.databaseVersion > g.expectedVersion,
+		Missing:         missing,
+	}, nil
+}
+
+// refresh reads the schema version of the database again
+func (g *SchemaGuard) refresh(ctx context.Context) {
+	version, migratedAt, err := g.schemaRepo.GetSchemaVersion(ctx)
+	if err != nil {
+		log.Printf("Failed to read the schema version: %v", err)
+		return
+	}
+	g.record(version, migratedAt)
+}
+
+// record keeps the schema version read from the database
+func (g *SchemaGuard) record(version int32, migratedAt time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if version > g.expectedVersion && g.databaseVersion <= g.expectedVersion {
+		log.Printf("Database migrated to schema version %d by a newer version of the service, this instance expects version %d and is now read-only", version, g.expectedVersion)
+	}
+	g.databaseVersion = version
+	g.migratedAt = migratedAt
+	g.checkedAt = time.Now()
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+const (
+	// minSeason and maxSeason bound the seasons the standings can be computed for
+	minSeason = 1900
+	maxSeason = 2100
+)
+
+// ErrInvalidSeason is returned when the standings of an implausible season are requested
+var ErrInvalidSeason = errors.New("invalid season: expected a year")
+
+// seasonStandings are the standings of a season computed at a given time
+type seasonStandings struct {
+	clubs      []*aggregate.SeasonStanding
+	athletes   []*aggregate.SeasonStanding
+	computedAt time.Time
+}
+
+// GetClubStandings ranks the clubs by the points their athletes earned over the season
+func (s *StatsService) GetClubStandings(ctx context.Context, season int) ([]*aggregate.SeasonStanding, time.Time, error) {
+	standings, err := s.getSeasonStandings(ctx, season)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return standings.clubs, standings.computedAt, nil
+}
+
+// GetAthleteStandings ranks the athletes by the points they earned over the season.
+// Only the points earned in competitions where the athlete agreed to the processing of their data are published.
+func (s *StatsService) GetAthleteStandings(ctx context.Context, season int) ([]*aggregate.SeasonStanding, time.Time, error) {
+	standings, err := s.getSeasonStandings(ctx, season)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return standings.athletes, standings.computedAt, nil
+}
+
+// getSeasonStandings returns the cached standings of the season, computing them when they are missing or stale
+func (s *StatsService) getSeasonStandings(ctx context.Context, season int) (*seasonStandings, error) {
+	if season < minSeason || season > maxSeason {
+		return nil, ErrInvalidSeason
+	}
+
+	s.mutex.Lock()
+	cached, ok := s.standings[season]
+	s.mutex.Unlock()
+	if ok && time.Since(cached.computedAt) < s.standingsCacheTTL {
+		return cached, nil
+	}
+
+	pointsEarned, err := s.statsRepo.ListPointsEarned(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	// The competitions around the season are listed, keep the ones starting in its year
+	seasonPoints := make([]*aggregate.PointsEarned, 0, len(pointsEarned))
+	for _, earned := range pointsEarned {
+		if earned.GetCompetitionDate().Year() == season {
+			seasonPoints = append(seasonPoints, earned)
+		}
+	}
+
+	standings := &seasonStandings{
+		clubs:      clubStandings(seasonPoints),
+		athletes:   athleteStandings(seasonPoints),
+		computedAt: time.Now(),
+	}
+
+	s.mutex.Lock()
+	s.standings[season] = standings
+	s.mutex.Unlock()
+
+	return standings, nil
+}
+
+// clubStandings sums the points of the athletes of every club, athletes without a club are left out
+func clubStandings(pointsEarned []*aggregate.PointsEarned) []*aggregate.SeasonStanding {
+	standingsByClub := make(map[string]*aggregate.SeasonStanding)
+	competitionsByClub := make(map[string]map[int32]bool)
+	for _, earned := range pointsEarned {
+		club := strings.TrimSpace(earned.GetClub())
+		if club == "" {
+			continue
+		}
+
+		key := strings.ToLower(club)
+		standing, ok := standingsByClub[key]
+		if !ok {
+			standing = aggregate.NewSeasonStanding()
+			standing.SetName(club)
+			standingsByClub[key] = standing
+			competitionsByClub[key] = make(map[int32]bool)
+		}
+		standing.SetPoints(standing.GetPoints() + earned.GetPoints())
+		competitionsByClub[key][earned.GetCompetitionID()] = true
+	}
+
+	standings := make([]*aggregate.SeasonStanding, 0, len(standingsByClub))
+	for key, standing := range standingsByClub {
+		standing.SetCompetitions(int32(len(competitionsByClub[key])))
+		standings = append(standings, standing)
+	}
+	return rankStandings(standings)
+}
+
+// athleteStandings sums the points of every athlete, recognised by their name across competitions.
+// The points are ordered by competition date, so the club kept is the latest one.
+func athleteStandings(pointsEarned []*aggregate.PointsEarned) []*aggregate.SeasonStanding {
+	standingsByAthlete := make(map[string]*aggregate.SeasonStanding)
+	for _, earned := range pointsEarned {
+		if !earned.GetConsentDataProcessing() {
+			continue
+		}
+
+		name := strings.TrimSpace(earned.GetFirstName()) + " " + strings.TrimSpace(earned.GetLastName())
+		key := strings.ToLower(name)
+		standing, ok := standingsByAthlete[key]
+		if !ok {
+			standing = aggregate.NewSeasonStanding()
+			standing.SetName(name)
+			standingsByAthlete[key] = standing
+		}
+		standing.SetClub(strings.TrimSpace(earned.GetClub()))
+		standing.SetPoints(standing.GetPoints() + earned.GetPoints())
+		standing.SetCompetitions(standing.GetCompetitions() + 1)
+	}
+
+	standings := make([]*aggregate.SeasonStanding, 0, len(standingsByAthlete))
+	for _, standing := range standingsByAthlete {
+		standings = append(standings, standing)
+	}
+	return rankStandings(standings)
+}
+
+// rankStandings sorts the standings by points then name and ranks them, tied standings share the same rank
+func rankStandings(standings []*aggregate.SeasonStanding) []*aggregate.SeasonStanding {
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].GetPoints() != standings[j].GetPoints() {
+			return standings[i].GetPoints() > standings[j].GetPoints()
+		}
+		return standings[i].GetName() < standings[j].GetName()
+	})
+
+	for i, standing := range standings {
+		if i > 0 && standing.GetPoints() == standings[i-1].GetPoints() {
+			standing.SetRank(standings[i-1].GetRank())
+		} else {
+			standing.SetRank(int32(i + 1))
+		}
+	}
+	return standings
+}
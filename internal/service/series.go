@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/utils"
+)
+
+var (
+	// ErrEmptySeriesName is returned when creating or renaming a series without a name
+	ErrEmptySeriesName = errors.New("series name cannot be empty")
+)
+
+// seriesStandings are the standings of a series computed at a given time
+type seriesStandings struct {
+	standings  []*aggregate.SeriesStanding
+	computedAt time.Time
+}
+
+// seriesPoints are the points a participant earned in a competition of a series
+type seriesPoints struct {
+	participant *aggregate.Participant
+	result      aggregate.SeriesResult
+}
+
+// CreateSeries creates a series without competitions and returns its ID
+func (s *CompetitionService) CreateSeries(ctx context.Context, series *aggregate.Series) (int32, error) {
+	series.SetName(strings.TrimSpace(series.GetName()))
+	if series.GetName() == "" {
+		return 0, ErrEmptySeriesName
+	}
+	series.SetCreatedAt(time.Now())
+
+	return s.seriesRepo.CreateSeries(ctx, series)
+}
+
+// GetSeries returns a series with its competitions, ordered by date
+func (s *CompetitionService) GetSeries(ctx context.Context, seriesID int32) (*aggregate.Series, error) {
+	return s.seriesRepo.GetSeries(ctx, seriesID)
+}
+
+// ListSeries lists every series with its competitions
+func (s *CompetitionService) ListSeries(ctx context.Context) ([]*aggregate.Series, error) {
+	return s.seriesRepo.ListSeries(ctx)
+}
+
+// RenameSeries renames a series and returns it
+func (s *CompetitionService) RenameSeries(ctx context.Context, seriesID int32, name string) (*aggregate.Series, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptySeriesName
+	}
+
+	series, err := s.seriesRepo.GetSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	series.SetName(name)
+	if err := s.seriesRepo.UpdateSeries(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// DeleteSeries deletes a series, its competitions are left untouched
+func (s *CompetitionService) DeleteSeries(ctx context.Context, seriesID int32) error {
+	if err := s.seriesRepo.DeleteSeries(ctx, seriesID); err != nil {
+		return err
+	}
+
+	s.forgetSeriesStandings(seriesID)
+	return nil
+}
+
+// AddCompetitionToSeries adds a competition to a series and returns the series
+func (s *CompetitionService) AddCompetitionToSeries(ctx context.Context, seriesID, competitionID int32) (*aggregate.Series, error) {
+	if _, err := s.seriesRepo.GetSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.seriesRepo.AddCompetitionToSeries(ctx, seriesID, competitionID); err != nil {
+		return nil, err
+	}
+
+	s.forgetSeriesStandings(seriesID)
+	return s.seriesRepo.GetSeries(ctx, seriesID)
+}
+
+// RemoveCompetitionFromSeries removes a competition from a series and returns the series
+func (s *CompetitionService) RemoveCompetitionFromSeries(ctx context.Context, seriesID, competitionID int32) (*aggregate.Series, error) {
+	if err := s.seriesRepo.RemoveCompetitionFromSeries(ctx, seriesID, competitionID); err != nil {
+		return nil, err
+	}
+
+	s.forgetSeriesStandings(seriesID)
+	return s.seriesRepo.GetSeries(ctx, seriesID)
+}
+
+// GetSeriesStandings ranks the participants by the points they earned over the competitions of the series, as the
+// results export awards them. Participants are recognised across competitions by their licence number, or by their
+// name and club when they have none. Only the competitions where they agreed to the processing of their data count,
+// and draft competitions are left out. A category or a gender only counts the points earned in it.
+// The standings are cached as the public competition information is.
+func (s *CompetitionService) GetSeriesStandings(ctx context.Context, seriesID int32, category, gender string) ([]*aggregate.SeriesStanding, time.Time, error) {
+	s.publicMutex.Lock()
+	cached, ok := s.seriesStandings[seriesID]
+	s.publicMutex.Unlock()
+
+	if !ok || time.Since(cached.computedAt) >= s.publicCompetitionCacheTTL() {
+		series, err := s.seriesRepo.GetSeries(ctx, seriesID)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		standings, err := s.computeSeriesStandings(ctx, series)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		cached = &seriesStandings{standings: standings, computedAt: time.Now()}
+
+		s.publicMutex.Lock()
+		if s.seriesStandings == nil {
+			s.seriesStandings = make(map[int32]*seriesStandings)
+		}
+		s.seriesStandings[seriesID] = cached
+		s.publicMutex.Unlock()
+	}
+
+	if category == "" && gender == "" {
+		return cached.standings, cached.computedAt, nil
+	}
+
+	filtered := make([]*aggregate.SeriesStanding, 0, len(cached.standings))
+	for _, standing := range cached.standings {
+		var kept *aggregate.SeriesStanding
+		for _, result := range standing.GetResults() {
+			if (category != "" && !strings.EqualFold(result.Category, category)) ||
+				(gender != "" && !strings.EqualFold(result.Gender, gender)) {
+				continue
+			}
+			if kept == nil {
+				kept = aggregate.NewSeriesStanding()
+				kept.SetFirstName(standing.GetFirstName())
+				kept.SetLastName(standing.GetLastName())
+				kept.SetClub(standing.GetClub())
+			}
+			kept.AddResult(result)
+		}
+		if kept != nil {
+			filtered = append(filtered, kept)
+		}
+	}
+
+	return rankSeriesStandings(filtered), cached.computedAt, nil
+}
+
+// forgetSeriesStandings drops the cached standings of a series whose competitions changed
+func (s *CompetitionService) forgetSeriesStandings(seriesID int32) {
+	s.publicMutex.Lock()
+	delete(s.seriesStandings, seriesID)
+	s.publicMutex.Unlock()
+}
+
+// computeSeriesStandings sums the points earned by every participant over the competitions of the series
+func (s *CompetitionService) computeSeriesStandings(ctx context.Context, series *aggregate.Series) ([]*aggregate.SeriesStanding, error) {
+	var points []seriesPoints
+	for _, competitionID := range series.GetCompetitionIDs() {
+		competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+		if err != nil {
+			return nil, err
+		}
+		if competition.GetStatus() == aggregate.CompetitionStatusDraft {
+			continue
+		}
+
+		competitionPoints, err := s.competitionPointsEarned(ctx, competitionID)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, competitionPoints...)
+	}
+
+	// Participants without a licence number join the one found under the same name and club in another competition
+	licenceByName := make(map[string]string)
+	for _, earned := range points {
+		if licence := seriesLicenceKey(earned.participant); licence != "" {
+			licenceByName[seriesNameKey(earned.participant)] = licence
+		}
+	}
+
+	standingsByParticipant := make(map[string]*aggregate.SeriesStanding)
+	for _, earned := range points {
+		key := seriesLicenceKey(earned.participant)
+		if key == "" {
+			key = licenceByName[seriesNameKey(earned.participant)]
+		}
+		if key == "" {
+			key = "name:" + seriesNameKey(earned.participant)
+		}
+
+		standing, ok := standingsByParticipant[key]
+		if !ok {
+			standing = aggregate.NewSeriesStanding()
+			standingsByParticipant[key] = standing
+		}
+		// The competitions are ordered by date, so the name and club kept are the latest ones
+		standing.SetFirstName(strings.TrimSpace(earned.participant.GetFirstName()))
+		standing.SetLastName(strings.TrimSpace(earned.participant.GetLastName()))
+		standing.SetClub(strings.TrimSpace(earned.participant.GetClub()))
+		standing.AddResult(earned.result)
+	}
+
+	standings := make([]*aggregate.SeriesStanding, 0, len(standingsByParticipant))
+	for _, standing := range standingsByParticipant {
+		standings = append(standings, standing)
+	}
+	return rankSeriesStandings(standings), nil
+}
+
+// competitionPointsEarned ranks the participants of each category and gender of a competition as the results export
+// does and returns the points they earned. Participants missing runs or without data processing consent are left out.
+func (s *CompetitionService) competitionPointsEarned(ctx context.Context, competitionID int32) ([]seriesPoints, error) {
+	participants, err := s.getAllParticipants(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.getAllRuns(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	scales, err := s.getAllScales(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.competitionSettings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []seriesPoints{}
+	for groupKey, groupParticipants := range s.groupParticipantsByCategoryGender(participants) {
+		parts := strings.Split(groupKey, "_")
+		if len(parts) != 2 {
+			continue
+		}
+		category, gender := parts[0], parts[1]
+
+		zones, err := s.getZonesForCategory(ctx, competitionID, category)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, result := range s.computeSheetResults(groupParticipants, zones, settings, runs, scales, competitionID) {
+			if !result.IsRanked() || !result.Participant.GetConsentDataProcessing() {
+				continue
+			}
+			rank := int32(i + 1)
+			points = append(points, seriesPoints{
+				participant: result.Participant,
+				result: aggregate.SeriesResult{
+					CompetitionID: competitionID,
+					Category:      category,
+					Gender:        gender,
+					Rank:          rank,
+					PointsEarned:  utils.GetPointsEarned(rank),
+				},
+			})
+		}
+	}
+
+	return points, nil
+}
+
+// seriesLicenceKey returns the key recognising a participant by their licence number, empty without one
+func seriesLicenceKey(participant *aggregate.Participant) string {
+	licence := strings.ToUpper(strings.TrimSpace(participant.GetLicence()))
+	if licence == "" {
+		return ""
+	}
+	return "licence:" + licence
+}
+
+// seriesNameKey returns the key recognising a participant by their name and club
+func seriesNameKey(participant *aggregate.Participant) string {
+	return strings.ToLower(strings.TrimSpace(participant.GetFirstName()) + " " +
+		strings.TrimSpace(participant.GetLastName()) + "|" + strings.TrimSpace(participant.GetClub()))
+}
+
+// rankSeriesStandings sorts the standings by points then name and ranks them, tied standings share the same rank
+func rankSeriesStandings(standings []*aggregate.SeriesStanding) []*aggregate.SeriesStanding {
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].GetPoints() != standings[j].GetPoints() {
+			return standings[i].GetPoints() > standings[j].GetPoints()
+		}
+		if standings[i].GetLastName() != standings[j].GetLastName() {
+			return standings[i].GetLastName() < standings[j].GetLastName()
+		}
+		return standings[i].GetFirstName() < standings[j].GetFirstName()
+	})
+
+	for i, standing := range standings {
+		if i > 0 && standing.GetPoints() == standings[i-1].GetPoints() {
+			standing.SetRank(standings[i-1].GetRank())
+		} else {
+			standing.SetRank(int32(i + 1))
+		}
+	}
+	return standings
+}
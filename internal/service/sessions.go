@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/entity"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// maxUserAgentLength is the size of the sessions.user_agent column
+const maxUserAgentLength = 255
+
+// openSession records a new refresh session for the device making the request
+func (s *UserService) openSession(ctx context.Context, userID int32) (*aggregate.Session, error) {
+	client, _ := ctx.Value(entity.SessionClientKey).(entity.SessionClient)
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	now := time.Now()
+	session := aggregate.NewSession()
+	session.SetID(uuid.NewString())
+	session.SetUserID(userID)
+	session.SetUserAgent(userAgent)
+	session.SetIP(client.IP)
+	session.SetCreatedAt(now)
+	session.SetLastUsedAt(now)
+	session.SetExpiresAt(now.Add(s.refreshTokenLifetime()))
+
+	if err := s.sessionRepo.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// ListSessions lists the active sessions of the user
+func (s *UserService) ListSessions(ctx context.Context, userID int32) ([]*aggregate.Session, error) {
+	return s.sessionRepo.ListActiveSessions(ctx, userID)
+}
+
+// RevokeSession revokes a session of the user, its refresh token can no longer be used
+func (s *UserService) RevokeSession(ctx context.Context, userID int32, sessionID string) error {
+	return s.sessionRepo.RevokeSession(ctx, userID, sessionID)
+}
+
+// RevokeRefreshToken revokes the session of a refresh token, it is used on logout.
+// Invalid tokens and tokens issued before sessions existed are ignored.
+func (s *UserService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	token, err := jwt.Parse(refreshToken, s.keySet.KeyFunc)
+	if err != nil || !token.Valid {
+		return nil
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+
+	userID, ok := claims["sub"].(float64)
+	sessionID, hasSession := claims["sid"].(string)
+	if !ok || !hasSession || sessionID == "" {
+		return nil
+	}
+
+	return s.sessionRepo.RevokeSession(ctx, int32(userID), sessionID)
+}
+
+// RevokeAccessToken denies an access token until it expires, it is used on logout.
+// Invalid tokens and tokens issued before the denylist existed are ignored.
+func (s *UserService) RevokeAccessToken(ctx context.Context, accessToken string) error {
+	if s.tokenDenylist == nil {
+		return nil
+	}
+
+	token, err := jwt.Parse(accessToken, s.keySet.KeyFunc)
+	if err != nil || !token.Valid {
+		return nil
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	expiresAt, ok := claims["exp"].(float64)
+	if tokenID == "" || !ok {
+		return nil
+	}
+
+	return s.tokenDenylist.DenyToken(ctx, tokenID, time.Unix(int64(expiresAt), 0))
+}
+
+// LogoutAll revokes every session of the user and denies the access tokens issued so far,
+// so that every device is logged out within a request instead of when its access token expires
+func (s *UserService) LogoutAll(ctx context.Context, userID int32) (int32, error) {
+	revoked, err := s.sessionRepo.RevokeAllSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.tokenDenylist != nil {
+		// Tokens carry their issue time in whole seconds, only those issued in an earlier second are denied so that
+		// a login right after the logout is not rejected
+		now := time.Now().Truncate(time.Second)
+		err = s.tokenDenylist.DenyUserTokens(ctx, userID, now, now.Add(s.accessTokenLifetime()))
+		if err != nil {
+			return revoked, err
+		}
+	}
+
+	s.recordAuthEvent(ctx, aggregate.AuthEventLogoutAll, userID, "", 0, "")
+	return revoked, nil
+}
+
+// IsAccessTokenRevoked returns whether the access token was denied by a logout.
+// The tokens are accepted when the denylist cannot be reached, the failure is logged.
+func (s *UserService) IsAccessTokenRevoked(ctx context.Context, token entity.UserToken) bool {
+	if s.tokenDenylist == nil {
+		return false
+	}
+
+	denied, err := s.tokenDenylist.IsDenied(ctx, token.Id, token.TokenID, token.IssuedAt)
+	if err != nil {
+		log.Printf("Failed to check the token denylist for user %d: %v", token.Id, err)
+		return false
+	}
+
+	return denied
+}
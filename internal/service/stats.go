@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/repository"
+	"github.com/NiskuT/cross-api/internal/domain/service"
+)
+
+// StatsService implements the StatsService interface
+type StatsService struct {
+	statsRepo         repository.StatsRepository
+	standingsCacheTTL time.Duration
+
+	mutex     sync.Mutex
+	standings map[int]*seasonStandings
+}
+
+// StatsServiceConfiguration is a function that configures a StatsService
+type StatsServiceConfiguration func(s *StatsService) error
+
+// NewStatsService creates a new StatsService
+func NewStatsService(cfgs ...StatsServiceConfiguration) service.StatsService {
+	impl := &StatsService{
+		standings: make(map[int]*seasonStandings),
+	}
+
+	for _, cfg := range cfgs {
+		if err := cfg(impl); err != nil {
+			panic(err)
+		}
+	}
+
+	return impl
+}
+
+// StatsConfWithStatsRepo configures the StatsService with a StatsRepository
+func StatsConfWithStatsRepo(repo repository.StatsRepository) StatsServiceConfiguration {
+	return func(s *StatsService) error {
+		s.statsRepo = repo
+		return nil
+	}
+}
+
+// StatsConfWithStandingsCacheTTL configures how long the standings of a season are cached
+func StatsConfWithStandingsCacheTTL(ttl time.Duration) StatsServiceConfiguration {
+	return func(s *StatsService) error {
+		s.standingsCacheTTL = ttl
+		return nil
+	}
+}
+
+// GetSystemStats counts the records of the whole platform
+func (s *StatsService) GetSystemStats(ctx context.Context) (*aggregate.SystemStats, error) {
+	return s.statsRepo.GetSystemStats(ctx)
+}
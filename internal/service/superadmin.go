@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+const (
+	// superAdminRole gives access to every competition and to the administration endpoints
+	superAdminRole = "superadmin"
+	// legacySuperAdminRole was given to super admins before superAdminRole existed
+	legacySuperAdminRole = "admin:*"
+)
+
+var (
+	// ErrCannotImpersonateSuperAdmin is returned when a super admin tries to log in as another super admin
+	ErrCannotImpersonateSuperAdmin = errors.New("super admins cannot be impersonated")
+)
+
+// isSuperAdmin returns whether the user has one of the super admin roles
+func isSuperAdmin(user *aggregate.User) bool {
+	for _, role := range user.GetRoleList() {
+		if role == superAdminRole || role == legacySuperAdminRole {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantSuperAdmin gives a user the super admin role
+func (s *UserService) GrantSuperAdmin(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	user.AddRole(superAdminRole)
+
+	err = s.userRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return err
+	}
+	s.recordRoleGranted(ctx, user, superAdminRole)
+
+	return nil
+}
+
+// Impersonate opens a session of the user on behalf of a super admin, e.g. to reproduce what an organizer sees.
+// The session is listed with the other sessions of the user, who can revoke it, and the impersonation is
+// recorded in the security events of the user.
+func (s *UserService) Impersonate(ctx context.Context, superAdminEmail string, userID int32) (*aggregate.JwtToken, error) {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isSuperAdmin(user) {
+		return nil, ErrCannotImpersonateSuperAdmin
+	}
+
+	token, err := s.generateTokens(ctx, user, "")
+	if err != nil {
+		return nil, err
+	}
+	s.recordAuthEvent(ctx, aggregate.AuthEventImpersonated, user.GetID(), user.GetEmail(), 0, fmt.Sprintf("by:%s", superAdminEmail))
+
+	return token, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+const (
+	// MinTeamMembers is the number of participants a team needs at least
+	MinTeamMembers = 3
+	// MaxTeamMembers is the number of participants a team has at most
+	MaxTeamMembers = 4
+	// DefaultTeamCountedMembers is the number of best member scores added up for a team by default
+	DefaultTeamCountedMembers = 3
+)
+
+var (
+	// ErrEmptyTeamName is returned when a team is given an empty name
+	ErrEmptyTeamName = errors.New("team name cannot be empty")
+	// ErrInvalidTeamSize is returned when a team does not have between MinTeamMembers and MaxTeamMembers members
+	ErrInvalidTeamSize = fmt.Errorf("a team has between %d and %d members", MinTeamMembers, MaxTeamMembers)
+	// ErrDuplicateTeamMember is returned when a participant is given twice as a member of a team
+	ErrDuplicateTeamMember = errors.New("a participant is given twice as a member of the team")
+	// ErrWaitlistedTeamMember is returned when a waitlisted participant is given as a member of a team
+	ErrWaitlistedTeamMember = errors.New("a waitlisted participant cannot be a member of a team")
+	// ErrInvalidTeamCountedMembers is returned when the number of member scores added up for a team is out of range
+	ErrInvalidTeamCountedMembers = fmt.Errorf("the number of counted members must be between 1 and %d", MaxTeamMembers)
+)
+
+// AddTeam adds a team of participants to a competition
+func (s *CompetitionService) AddTeam(ctx context.Context, team *aggregate.Team) error {
+	if err := s.validateTeam(ctx, team); err != nil {
+		return err
+	}
+
+	return s.teamRepo.CreateTeam(ctx, team)
+}
+
+// ListTeams lists the teams of a competition by name
+func (s *CompetitionService) ListTeams(ctx context.Context, competitionID int32) ([]*aggregate.Team, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.teamRepo.ListTeams(ctx, competitionID)
+}
+
+// UpdateTeam renames a team and replaces its members
+func (s *CompetitionService) UpdateTeam(ctx context.Context, team *aggregate.Team) error {
+	if err := s.validateTeam(ctx, team); err != nil {
+		return err
+	}
+
+	return s.teamRepo.UpdateTeam(ctx, team)
+}
+
+// DeleteTeam removes a team from a competition, its members stay registered
+func (s *CompetitionService) DeleteTeam(ctx context.Context, competitionID, teamID int32) error {
+	return s.teamRepo.DeleteTeam(ctx, competitionID, teamID)
+}
+
+// GetTeamRanking ranks the teams of a competition on the sum of the best liveranking scores of their members,
+// counting the given number of members, DefaultTeamCountedMembers when 0. The teams with the same points share
+// their rank.
+func (s *CompetitionService) GetTeamRanking(ctx context.Context, competitionID int32, countedMembers int32) ([]*aggregate.TeamRanking, error) {
+	if countedMembers == 0 {
+		countedMembers = DefaultTeamCountedMembers
+	}
+	if countedMembers < 0 || countedMembers > MaxTeamMembers {
+		return nil, ErrInvalidTeamCountedMembers
+	}
+
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	rankings, err := s.teamRepo.ListTeamRankings(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ranking := range rankings {
+		members := ranking.GetMembers()
+		sort.SliceStable(members, func(i, j int) bool {
+			if members[i].GetTotalPoints() != members[j].GetTotalPoints() {
+				return members[i].GetTotalPoints() > members[j].GetTotalPoints()
+			}
+			return members[i].GetNumberOfRuns() > members[j].GetNumberOfRuns()
+		})
+
+		var totalPoints int32
+		for i, member := range members {
+			if int32(i) >= countedMembers || member.GetNumberOfRuns() == 0 {
+				break
+			}
+			member.SetCounted(true)
+			totalPoints += member.GetTotalPoints()
+		}
+		ranking.SetTotalPoints(totalPoints)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return rankings[i].GetTotalPoints() > rankings[j].GetTotalPoints()
+	})
+	for i, ranking := range rankings {
+		if i > 0 && ranking.GetTotalPoints() == rankings[i-1].GetTotalPoints() {
+			ranking.SetRank(rankings[i-1].GetRank())
+			continue
+		}
+		ranking.SetRank(int32(i + 1))
+	}
+
+	return rankings, nil
+}
+
+// validateTeam trims the name of the team and checks its members are registered participants of the competition
+func (s *CompetitionService) validateTeam(ctx context.Context, team *aggregate.Team) error {
+	team.SetName(strings.TrimSpace(team.GetName()))
+	if team.GetName() == "" {
+		return ErrEmptyTeamName
+	}
+
+	dossards := team.GetDossards()
+	if len(dossards) < MinTeamMembers || len(dossards) > MaxTeamMembers {
+		return ErrInvalidTeamSize
+	}
+
+	// Check if competition exists
+	if _, err := s.competitionRepo.GetCompetition(ctx, team.GetCompetitionID()); err != nil {
+		return err
+	}
+
+	given := make(map[int32]bool, len(dossards))
+	for _, dossard := range dossards {
+		if given[dossard] {
+			return ErrDuplicateTeamMember
+		}
+		given[dossard] = true
+
+		participant, err := s.participantRepo.GetParticipant(ctx, team.GetCompetitionID(), dossard)
+		if err != nil {
+			return err
+		}
+		if participant.IsWaitlisted() {
+			return ErrWaitlistedTeamMember
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrInvalidChronoFormat is returned when a competition is given an unknown chrono format
+	ErrInvalidChronoFormat = errors.New("invalid chrono format: expected mm:ss, seconds or milliseconds")
+	// ErrInvalidChronoDirection is returned when a competition is given an unknown chrono direction
+	ErrInvalidChronoDirection = errors.New("invalid chrono direction: expected up or down")
+)
+
+// UpdateTimeDisplay sets how the chronos of a competition are displayed and entered by the clients
+func (s *CompetitionService) UpdateTimeDisplay(ctx context.Context, competitionID int32, chronoFormat, chronoDirection string) (*aggregate.Competition, error) {
+	if !isOneOf(chronoFormat, aggregate.ChronoFormats) {
+		return nil, ErrInvalidChronoFormat
+	}
+	if !isOneOf(chronoDirection, aggregate.ChronoDirections) {
+		return nil, ErrInvalidChronoDirection
+	}
+
+	competition, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	competition.SetChronoFormat(chronoFormat)
+	competition.SetChronoDirection(chronoDirection)
+
+	if err := s.competitionRepo.UpdateCompetition(ctx, competition); err != nil {
+		return nil, err
+	}
+
+	return competition, nil
+}
+
+// isOneOf checks if the value is one of the allowed values
+func isOneOf(value string, allowed []string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
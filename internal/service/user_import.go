@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+// maxUserImportRows bounds the accounts created by a single import, each of them sends an email
+const maxUserImportRows = 500
+
+var (
+	// ErrEmptyUserImport is returned when the import file has no user row
+	ErrEmptyUserImport = errors.New("the file contains no user, expected rows of first name, last name, email")
+	// ErrTooManyUserImportRows is returned when the import file exceeds maxUserImportRows
+	ErrTooManyUserImportRows = fmt.Errorf("the file contains more than %d users", maxUserImportRows)
+)
+
+// ImportUsers creates the accounts of a CSV file of first name, last name and email, and emails them their credentials.
+// With a competition, the accounts are given its referee role and existing accounts are added to it, otherwise
+// existing accounts are skipped. Every row is processed and its outcome reported, a failing row does not stop the import.
+func (s *UserService) ImportUsers(ctx context.Context, file io.Reader, competition *aggregate.Competition) (*aggregate.UserImportReport, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+
+	// The header row is optional, it is recognized by its email column not being an email.
+	// Line numbers of the report are those of the file.
+	firstLine := int32(1)
+	if len(rows) > 0 && len(rows[0]) >= 3 && !strings.Contains(rows[0][2], "@") {
+		rows = rows[1:]
+		firstLine = 2
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyUserImport
+	}
+	if len(rows) > maxUserImportRows {
+		return nil, ErrTooManyUserImportRows
+	}
+
+	report := aggregate.NewUserImportReport()
+	for i, row := range rows {
+		line := firstLine + int32(i)
+		status, message := s.importUser(ctx, row, competition)
+
+		email := ""
+		if len(row) >= 3 {
+			email = strings.TrimSpace(row[2])
+		}
+		report.AddRow(line, email, status, message)
+	}
+
+	return report, nil
+}
+
+// importUser imports a single row and returns its status and an explanation when something went wrong
+func (s *UserService) importUser(ctx context.Context, row []string, competition *aggregate.Competition) (string, string) {
+	if len(row) < 3 {
+		return aggregate.UserImportFailed, "expected 3 columns: first name, last name, email"
+	}
+
+	firstName := strings.TrimSpace(row[0])
+	lastName := strings.TrimSpace(row[1])
+	email := strings.TrimSpace(row[2])
+	if firstName == "" || lastName == "" {
+		return aggregate.UserImportFailed, "first name and last name are required"
+	}
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return aggregate.UserImportFailed, "invalid email"
+	}
+
+	// Existing accounts are only given the competition role
+	if existingUser, err := s.userRepo.GetUserByEmail(ctx, email); err == nil && existingUser != nil {
+		if competition == nil {
+			return aggregate.UserImportSkipped, "an account already exists with this email"
+		}
+		if err := s.AddUserToCompetition(ctx, email, competition); err != nil {
+			return aggregate.UserImportRoleAdded, err.Error()
+		}
+		return aggregate.UserImportRoleAdded, ""
+	}
+
+	role := ""
+	if competition != nil {
+		role = fmt.Sprintf("referee:%d", competition.GetID())
+	}
+
+	password, err := s.createInvitedUser(ctx, firstName, lastName, email, role)
+	if err != nil {
+		log.Printf("Failed to import user %s: %v", email, err)
+		return aggregate.UserImportFailed, "failed to create the account"
+	}
+
+	var subject, body string
+	if competition != nil {
+		subject, body = refereeWelcomeEmail(firstName, lastName, email, password, competition)
+	} else {
+		subject, body = welcomeEmail(firstName, lastName, email, password)
+	}
+	if err := s.sendEmail(email, subject, body); err != nil {
+		return aggregate.UserImportCreated, "account created but email sending failed"
+	}
+
+	return aggregate.UserImportCreated, ""
+}
+
+// welcomeEmail returns the subject and body of the email sent with the credentials of an account created without a role
+func welcomeEmail(firstName, lastName, email, password string) (string, string) {
+	subject := "Bienvenue à Golene Evasion - Création de votre compte"
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>Bienvenue à Golene Evasion !</h2>
+			<p>Cher/Chère %s %s,</p>
+			<p>Un compte a été créé pour vous sur notre plateforme, votre club pourra vous inviter comme arbitre à ses compétitions.</p>
+			<p>Voici vos identifiants de connexion :</p>
+			<ul>
+				<li><strong>Email :</strong> %s</li>
+				<li><strong>Mot de passe :</strong> %s</li>
+			</ul>
+			<p>Veuillez vous connecter à notre plateforme <a href="https://cross.golene-evasion.com">ici</a> et changer votre mot de passe après votre première connexion.</p>
+			<p>Cordialement,<br>L'équipe Golene Evasion</p>
+		</body>
+		</html>
+	`, firstName, lastName, email, password)
+
+	return subject, body
+}
@@ -5,15 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
-	"net/smtp"
 	"strings"
 	"time"
 
 	"github.com/NiskuT/cross-api/internal/config"
 	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+	"github.com/NiskuT/cross-api/internal/domain/entity"
 	"github.com/NiskuT/cross-api/internal/domain/repository"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,13 +26,31 @@ var (
 	ErrEmailSendingFailed = errors.New("failed to send email")
 	// ErrMissingEmailConfig is returned when email configuration is missing
 	ErrMissingEmailConfig = errors.New("email configuration is missing")
-	// ErrMaximumRolesReached is returned when the user has reached the maximum number of roles
-	ErrMaximumRolesReached = errors.New("maximum number of roles reached, contact support")
+)
+
+const (
+	defaultAccessTokenLifetime  = time.Hour
+	defaultRefreshTokenLifetime = 7 * 24 * time.Hour
 )
 
 type UserService struct {
-	userRepo repository.UserRepository
-	cfg      *config.Config
+	userRepo        repository.UserRepository
+	competitionRepo repository.CompetitionRepository
+	sessionRepo     repository.SessionRepository
+	invitationRepo  repository.InvitationRepository
+	authEventRepo   repository.AuthEventRepository
+	refereePinRepo  repository.RefereePinRepository
+	onboardingRepo  repository.RefereeOnboardingRepository
+	assignmentRepo  repository.RefereeAssignmentRepository
+	zoneRepo        repository.ZoneRepository
+	displayRepo     repository.DisplayDeviceRepository
+	oidcClient      *OIDCClient
+	metrics         *Metrics
+	passwordPolicy  *PasswordPolicy
+	keySet          *KeySet
+	tokenDenylist   repository.TokenDenylistRepository
+	degradedMode    *DegradedMode
+	cfg             *config.Config
 }
 
 type UserServiceConfiguration func(u *UserService) error
@@ -56,6 +74,111 @@ func UserConfWithUserRepo(repo repository.UserRepository) UserServiceConfigurati
 	}
 }
 
+func UserConfWithCompetitionRepo(repo repository.CompetitionRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.competitionRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithRefereePinRepo(repo repository.RefereePinRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.refereePinRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithRefereeOnboardingRepo(repo repository.RefereeOnboardingRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.onboardingRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithRefereeAssignmentRepo(repo repository.RefereeAssignmentRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.assignmentRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithZoneRepo(repo repository.ZoneRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.zoneRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithDisplayDeviceRepo(repo repository.DisplayDeviceRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.displayRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithTokenDenylist(denylist repository.TokenDenylistRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.tokenDenylist = denylist
+		return nil
+	}
+}
+
+func UserConfWithSessionRepo(repo repository.SessionRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.sessionRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithInvitationRepo(repo repository.InvitationRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.invitationRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithAuthEventRepo(repo repository.AuthEventRepository) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.authEventRepo = repo
+		return nil
+	}
+}
+
+func UserConfWithOIDCClient(oidcClient *OIDCClient) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.oidcClient = oidcClient
+		return nil
+	}
+}
+
+func UserConfWithMetrics(metrics *Metrics) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.metrics = metrics
+		return nil
+	}
+}
+
+func UserConfWithDegradedMode(degradedMode *DegradedMode) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.degradedMode = degradedMode
+		return nil
+	}
+}
+
+func UserConfWithPasswordPolicy(passwordPolicy *PasswordPolicy) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.passwordPolicy = passwordPolicy
+		return nil
+	}
+}
+
+func UserConfWithKeySet(keySet *KeySet) UserServiceConfiguration {
+	return func(u *UserService) error {
+		u.keySet = keySet
+		return nil
+	}
+}
+
 func UserConfWithConfig(cfg *config.Config) UserServiceConfiguration {
 	return func(u *UserService) error {
 		u.cfg = cfg
@@ -69,29 +192,31 @@ func (s *UserService) Login(ctx context.Context, email, password string) (*aggre
 	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
 		log.Println("Error getting user by email:", err)
+		s.recordAuthEvent(ctx, aggregate.AuthEventLoginFailed, 0, email, 0, "unknown email")
 		return nil, ErrInvalidCredentials
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.GetPasswordHash()), []byte(password)); err != nil {
 		log.Println("Error comparing password:", err)
+		s.recordAuthEvent(ctx, aggregate.AuthEventLoginFailed, user.GetID(), email, 0, "wrong password")
 		return nil, ErrInvalidCredentials
 	}
 
 	// Generate token
-	return s.generateTokens(user)
+	tokens, err := s.generateTokens(ctx, user, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAuthEvent(ctx, aggregate.AuthEventLogin, user.GetID(), email, 0, "")
+	return tokens, nil
 }
 
 // RefreshToken validates a refresh token and returns a new JWT token
 func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*aggregate.JwtToken, error) {
 	// Parse the token
-	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(s.cfg.Jwt.SecretKey), nil
-	})
+	token, err := jwt.Parse(refreshToken, s.keySet.KeyFunc)
 
 	if err != nil || !token.Valid {
 		return nil, ErrInvalidToken
@@ -103,8 +228,12 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*a
 		return nil, ErrInvalidToken
 	}
 
-	// Verify token type
-	if tokenType, ok := claims["type"].(string); !ok || tokenType != "refresh" {
+	// Verify token type, display devices refresh their tokens with their display token
+	tokenType, _ := claims["type"].(string)
+	if tokenType == displayTokenType {
+		return s.issueDisplayTokens(ctx, refreshToken, claims)
+	}
+	if tokenType != "refresh" {
 		return nil, ErrInvalidToken
 	}
 
@@ -122,26 +251,60 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*a
 		return nil, ErrInvalidToken
 	}
 
+	// Refresh tokens issued before sessions existed carry no session ID, they open a new session
+	sessionID, _ := claims["sid"].(string)
+	if sessionID != "" {
+		session, err := s.sessionRepo.GetSession(ctx, sessionID)
+		if err != nil || !session.IsActive() || session.GetUserID() != userID {
+			return nil, ErrInvalidToken
+		}
+
+		client, _ := ctx.Value(entity.SessionClientKey).(entity.SessionClient)
+		err = s.sessionRepo.TouchSession(ctx, sessionID, client.IP, time.Now().Add(s.refreshTokenLifetime()))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate new tokens
-	return s.generateTokens(user)
+	return s.generateTokens(ctx, user, sessionID)
 }
 
-// Helper function to generate JWT tokens
-func (s *UserService) generateTokens(user *aggregate.User) (*aggregate.JwtToken, error) {
-	roles := strings.Split(user.GetRoles(), ",")
+// Helper function to generate JWT tokens.
+// The tokens belong to the given session, or to the session of the authenticated request when
+// it is the same user, otherwise a new session is opened for the device making the request.
+func (s *UserService) generateTokens(ctx context.Context, user *aggregate.User, sessionID string) (*aggregate.JwtToken, error) {
+	roles := user.GetRoleList()
+
+	if sessionID == "" {
+		if current, ok := ctx.Value("user").(entity.UserToken); ok && current.Id == user.GetID() {
+			sessionID = current.SessionID
+		}
+	}
+
+	if sessionID == "" {
+		session, err := s.openSession(ctx, user.GetID())
+		if err != nil {
+			return nil, err
+		}
+		sessionID = session.GetID()
+	}
 
-	// Create access token
+	// Create access token, its ID and issue time let it be denied before it expires
+	now := time.Now()
 	accessTokenClaims := jwt.MapClaims{
 		"sub":   user.GetID(),
 		"email": user.GetEmail(),
 		"roles": roles,
 		"iss":   "golene-evasion.com",
 		"type":  "access",
-		"exp":   time.Now().Add(time.Hour).Unix(),
+		"sid":   sessionID,
+		"jti":   uuid.NewString(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(s.accessTokenLifetime()).Unix(),
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(s.cfg.Jwt.SecretKey))
+	accessTokenString, err := s.keySet.Sign(accessTokenClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -151,11 +314,11 @@ func (s *UserService) generateTokens(user *aggregate.User) (*aggregate.JwtToken,
 		"sub":  user.GetID(),
 		"iss":  "golene-evasion.com",
 		"type": "refresh",
-		"exp":  time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days
+		"sid":  sessionID,
+		"exp":  now.Add(s.refreshTokenLifetime()).Unix(),
 	}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims)
-	refreshTokenString, err := refreshToken.SignedString([]byte(s.cfg.Jwt.SecretKey))
+	refreshTokenString, err := s.keySet.Sign(refreshTokenClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -169,51 +332,55 @@ func (s *UserService) generateTokens(user *aggregate.User) (*aggregate.JwtToken,
 	return jwtToken, nil
 }
 
-// Helper function to generate a random password
-func generateRandomPassword(length int) string {
-	rand.Seed(time.Now().UnixNano())
-	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()-_=+"
-	result := make([]byte, length)
-	for i := range result {
-		result[i] = chars[rand.Intn(len(chars))]
+// Helper function to send an email
+// sendNotificationEmail sends an email the user can do without, it is skipped in degraded mode.
+// Emails carrying credentials or links must be sent with sendEmail.
+func (s *UserService) sendNotificationEmail(to, subject, body string) error {
+	if s.degradedMode.IsEnabled() {
+		log.Printf("Degraded mode: skipped the email %q to %s", subject, to)
+		return nil
 	}
-	return string(result)
+
+	return s.sendEmail(to, subject, body)
 }
 
-// Helper function to send an email
 func (s *UserService) sendEmail(to, subject, body string) error {
-	if s.cfg.Email.Host == "" {
-		return ErrMissingEmailConfig
-	}
-
-	// Set up authentication information
-	auth := smtp.PlainAuth("", s.cfg.Email.Username, s.cfg.Email.Password, s.cfg.Email.Host)
-
-	// Compose the email
-	msg := []byte(fmt.Sprintf("From: %s\r\n"+
-		"To: %s\r\n"+
-		"Subject: %s\r\n"+
-		"MIME-Version: 1.0\r\n"+
-		"Content-Type: text/html; charset=UTF-8\r\n"+
-		"\r\n"+
-		"%s\r\n", s.cfg.Email.From, to, subject, body))
-
-	// Connect to the server, authenticate, set the sender and recipient, and send the email
-	err := smtp.SendMail(
-		fmt.Sprintf("%s:%d", s.cfg.Email.Host, s.cfg.Email.Port),
-		auth,
-		s.cfg.Email.From,
-		[]string{to},
-		msg,
-	)
-
+	err := sendSMTPEmail(s.cfg, &Email{To: to, Subject: subject, Body: body})
+	if errors.Is(err, ErrMissingEmailConfig) {
+		return err
+	}
 	if err != nil {
+		s.metrics.EmailFailed()
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
 	return nil
 }
 
+// accessTokenLifetime returns how long the access tokens are valid
+func (s *UserService) accessTokenLifetime() time.Duration {
+	if s.cfg != nil && s.cfg.Jwt.AccessTokenLifetime > 0 {
+		return s.cfg.Jwt.AccessTokenLifetime
+	}
+	return defaultAccessTokenLifetime
+}
+
+// refreshTokenLifetime returns how long the refresh tokens and the sessions are valid
+func (s *UserService) refreshTokenLifetime() time.Duration {
+	if s.cfg != nil && s.cfg.Jwt.RefreshTokenLifetime > 0 {
+		return s.cfg.Jwt.RefreshTokenLifetime
+	}
+	return defaultRefreshTokenLifetime
+}
+
+// clientURL returns the URL of the web client the emails link to
+func (s *UserService) clientURL() string {
+	if s.cfg != nil && s.cfg.ClientURI != "" {
+		return strings.TrimSuffix(s.cfg.ClientURI, "/")
+	}
+	return "https://cross.golene-evasion.com"
+}
+
 // AddUserToCompetition adds the referee role for a specific competition to a user
 func (s *UserService) AddUserToCompetition(ctx context.Context, email string, competition *aggregate.Competition) error {
 	// Get the user
@@ -226,15 +393,13 @@ func (s *UserService) AddUserToCompetition(ctx context.Context, email string, co
 	newRole := fmt.Sprintf("referee:%d", competition.GetID())
 
 	user.AddRole(newRole)
-	if len(user.GetRoles()) >= 500 {
-		return ErrMaximumRolesReached
-	}
 
 	// Save the changes
 	err = s.userRepo.UpdateUser(ctx, user)
 	if err != nil {
 		return err
 	}
+	s.recordRoleGranted(ctx, user, newRole)
 
 	// Send notification email to existing user
 	subject := "Golene Evasion - Nouvelle Invitation d'Arbitre"
@@ -251,7 +416,7 @@ func (s *UserService) AddUserToCompetition(ctx context.Context, email string, co
 		</html>
 	`, user.GetFirstName(), user.GetLastName(), competition.GetName())
 
-	err = s.sendEmail(email, subject, body)
+	err = s.sendNotificationEmail(email, subject, body)
 	if err != nil {
 		// Note: Even if email sending fails, the role has been added successfully
 		return fmt.Errorf("referee role added but email notification failed: %w", err)
@@ -261,6 +426,17 @@ func (s *UserService) AddUserToCompetition(ctx context.Context, email string, co
 }
 
 func (s *UserService) SetUserAsAdmin(ctx context.Context, email string, competitionID int32) (*aggregate.JwtToken, error) {
+	user, err := s.GrantAdminRole(ctx, email, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate new tokens
+	return s.generateTokens(ctx, user, "")
+}
+
+// GrantAdminRole gives a user the admin role of a competition, without issuing new tokens
+func (s *UserService) GrantAdminRole(ctx context.Context, email string, competitionID int32) (*aggregate.User, error) {
 	// Get the user
 	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
@@ -276,9 +452,9 @@ func (s *UserService) SetUserAsAdmin(ctx context.Context, email string, competit
 	if err != nil {
 		return nil, err
 	}
+	s.recordRoleGranted(ctx, user, newRole)
 
-	// Generate new tokens
-	return s.generateTokens(user)
+	return user, nil
 }
 
 // InviteUser creates a new user with a referee role for a specific competition and sends an invitation email
@@ -290,33 +466,58 @@ func (s *UserService) InviteUser(ctx context.Context, firstName, lastName, email
 		return s.AddUserToCompetition(ctx, email, competition)
 	}
 
+	// Create the user with the referee role of the specified competition
+	password, err := s.createInvitedUser(ctx, firstName, lastName, email, fmt.Sprintf("referee:%d", competition.GetID()))
+	if err != nil {
+		return err
+	}
+
+	// Prepare and send the invitation email
+	subject, body := refereeWelcomeEmail(firstName, lastName, email, password, competition)
+	err = s.sendEmail(email, subject, body)
+	if err != nil {
+		// Note: Even if email sending fails, the user has been created
+		return fmt.Errorf("user created but email sending failed: %w", err)
+	}
+
+	return nil
+}
+
+// createInvitedUser creates an account with a generated password and the role, if any, and returns the password
+func (s *UserService) createInvitedUser(ctx context.Context, firstName, lastName, email, role string) (string, error) {
 	// Generate a random password
-	password := generateRandomPassword(12)
+	password := s.passwordPolicy.Generate(12)
 
 	// Create a new user
 	user := aggregate.NewUser()
 	user.SetEmail(email)
 	user.SetFirstName(firstName)
 	user.SetLastName(lastName)
-
-	// Set referee role for the specified competition
-	role := fmt.Sprintf("referee:%d", competition.GetID())
-	user.SetRoles(role)
+	if role != "" {
+		user.SetRoles(role)
+	}
 
 	// Hash the password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
 	user.SetPasswordHash(string(hashedPassword))
 
 	// Save the user to the database
 	err = s.userRepo.CreateUser(ctx, user)
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return "", fmt.Errorf("failed to create user: %w", err)
+	}
+	if role != "" {
+		s.recordRoleGranted(ctx, user, role)
 	}
 
-	// Prepare and send the invitation email
+	return password, nil
+}
+
+// refereeWelcomeEmail returns the subject and body of the email sent with the credentials of a new referee
+func refereeWelcomeEmail(firstName, lastName, email, password string, competition *aggregate.Competition) (string, string) {
 	subject := "Bienvenue à Golene Evasion - Invitation d'Arbitre"
 	body := fmt.Sprintf(`
 		<html>
@@ -335,13 +536,7 @@ func (s *UserService) InviteUser(ctx context.Context, firstName, lastName, email
 		</html>
 	`, firstName, lastName, competition.GetName(), email, password)
 
-	err = s.sendEmail(email, subject, body)
-	if err != nil {
-		// Note: Even if email sending fails, the user has been created
-		return fmt.Errorf("user created but email sending failed: %w", err)
-	}
-
-	return nil
+	return subject, body
 }
 
 // ChangePassword allows a user to change their password by verifying their current password
@@ -357,6 +552,11 @@ func (s *UserService) ChangePassword(ctx context.Context, userID int32, currentP
 		return ErrInvalidCredentials
 	}
 
+	// Check the new password against the policy
+	if err := s.passwordPolicy.Validate(ctx, newPassword); err != nil {
+		return err
+	}
+
 	// Hash the new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -372,6 +572,7 @@ func (s *UserService) ChangePassword(ctx context.Context, userID int32, currentP
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 
+	s.recordAuthEvent(ctx, aggregate.AuthEventPasswordChanged, user.GetID(), user.GetEmail(), 0, "")
 	return nil
 }
 
@@ -386,7 +587,7 @@ func (s *UserService) ForgotPassword(ctx context.Context, email string) error {
 	}
 
 	// Generate a new random password
-	newPassword := generateRandomPassword(12)
+	newPassword := s.passwordPolicy.Generate(12)
 
 	// Hash the new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
@@ -402,6 +603,7 @@ func (s *UserService) ForgotPassword(ctx context.Context, email string) error {
 	if err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
+	s.recordAuthEvent(ctx, aggregate.AuthEventPasswordReset, user.GetID(), user.GetEmail(), 0, "")
 
 	// Send the new password by email
 	subject := "Golene Evasion - Nouveau mot de passe"
@@ -426,71 +628,31 @@ func (s *UserService) ForgotPassword(ctx context.Context, email string) error {
 	return nil
 }
 
-// GenerateRefereeInvitationToken creates a special JWT token for referee invitations
-func (s *UserService) GenerateRefereeInvitationToken(ctx context.Context, competitionID int32) (string, int64, error) {
-	expiresAt := time.Now().Add(time.Minute * 15).Unix()
-	invitationClaims := jwt.MapClaims{
-		"competition_id": competitionID,
-		"type":           "referee_invitation",
-		"iss":            "golene-evasion.com",
-		"exp":            expiresAt,
-	}
-
-	invitationToken := jwt.NewWithClaims(jwt.SigningMethodHS256, invitationClaims)
-	tokenString, err := invitationToken.SignedString([]byte(s.cfg.Jwt.SecretKey))
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to generate invitation token: %w", err)
-	}
-
-	return tokenString, expiresAt, nil
+// AcceptRefereeInvitation processes a referee invitation token and adds the user to the competition
+func (s *UserService) AcceptRefereeInvitation(ctx context.Context, token string, userEmail string) (*aggregate.JwtToken, error) {
+	return s.acceptInvitation(ctx, token, aggregate.InvitationRoleReferee, userEmail)
 }
 
-// Helper function to verify and extract competition ID from referee invitation token
-func (s *UserService) verifyRefereeInvitationToken(token string) (int32, error) {
-	// Parse the invitation token
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(s.cfg.Jwt.SecretKey), nil
-	})
-
-	if err != nil || !parsedToken.Valid {
-		return 0, ErrInvalidToken
-	}
-
-	// Extract claims
-	claims, ok := parsedToken.Claims.(jwt.MapClaims)
-	if !ok {
-		return 0, ErrInvalidToken
-	}
-
-	// Verify token type
-	if tokenType, ok := claims["type"].(string); !ok || tokenType != "referee_invitation" {
-		return 0, ErrInvalidToken
-	}
-
-	// Verify issuer
-	if !claims.VerifyIssuer("golene-evasion.com", true) {
-		return 0, ErrInvalidToken
-	}
+// AcceptRefereeInvitationUnauthenticated processes a referee invitation for unauthenticated users
+func (s *UserService) AcceptRefereeInvitationUnauthenticated(ctx context.Context, token, firstName, lastName, email, password string) (*aggregate.JwtToken, error) {
+	return s.acceptInvitationUnauthenticated(ctx, token, aggregate.InvitationRoleReferee, firstName, lastName, email, password)
+}
 
-	// Extract competition ID
-	var competitionID int32
-	if id, ok := claims["competition_id"].(float64); ok {
-		competitionID = int32(id)
-	} else {
-		return 0, ErrInvalidToken
-	}
+// AcceptAdminInvitation processes a co-admin invitation token and makes the user admin of the competition
+func (s *UserService) AcceptAdminInvitation(ctx context.Context, token string, userEmail string) (*aggregate.JwtToken, error) {
+	return s.acceptInvitation(ctx, token, aggregate.InvitationRoleAdmin, userEmail)
+}
 
-	return competitionID, nil
+// AcceptAdminInvitationUnauthenticated processes a co-admin invitation token for users who are not logged in,
+// creating the account if needed
+func (s *UserService) AcceptAdminInvitationUnauthenticated(ctx context.Context, token, firstName, lastName, email, password string) (*aggregate.JwtToken, error) {
+	return s.acceptInvitationUnauthenticated(ctx, token, aggregate.InvitationRoleAdmin, firstName, lastName, email, password)
 }
 
-// AcceptRefereeInvitation processes a referee invitation token and adds the user to the competition
-func (s *UserService) AcceptRefereeInvitation(ctx context.Context, token string, userEmail string) (*aggregate.JwtToken, error) {
+// acceptInvitation processes an invitation token and grants its role on the competition to the user
+func (s *UserService) acceptInvitation(ctx context.Context, token, role, userEmail string) (*aggregate.JwtToken, error) {
 	// Verify token and extract competition ID
-	competitionID, err := s.verifyRefereeInvitationToken(token)
+	competitionID, invitationID, err := s.verifyInvitationToken(ctx, token, role)
 	if err != nil {
 		return nil, err
 	}
@@ -500,29 +662,32 @@ func (s *UserService) AcceptRefereeInvitation(ctx context.Context, token string,
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	if err := checkNotAlreadyMember(user, role, competitionID); err != nil {
+		return nil, err
+	}
 
-	// Add referee role for the competition
-	newRole := fmt.Sprintf("referee:%d", competitionID)
+	// Add the invited role for the competition
+	newRole := fmt.Sprintf("%s:%d", role, competitionID)
 	user.AddRole(newRole)
 
-	if len(user.GetRoles()) >= 500 {
-		return nil, ErrMaximumRolesReached
-	}
-
 	// Save the changes
 	err = s.userRepo.UpdateUser(ctx, user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add referee role: %w", err)
+		return nil, fmt.Errorf("failed to add %s role: %w", role, err)
 	}
+	s.recordRoleGranted(ctx, user, newRole)
+	s.countInvitationAcceptance(ctx, competitionID, invitationID)
+	s.markRefereeInvitationAccepted(ctx, competitionID, user.GetID(), role)
 
 	// Generate new tokens for the user
-	return s.generateTokens(user)
+	return s.generateTokens(ctx, user, "")
 }
 
-// AcceptRefereeInvitationUnauthenticated processes a referee invitation for unauthenticated users
-func (s *UserService) AcceptRefereeInvitationUnauthenticated(ctx context.Context, token, firstName, lastName, email, password string) (*aggregate.JwtToken, error) {
+// acceptInvitationUnauthenticated processes an invitation token for a user who is not logged in,
+// logging in the existing account or creating it, and grants the role of the invitation
+func (s *UserService) acceptInvitationUnauthenticated(ctx context.Context, token, role, firstName, lastName, email, password string) (*aggregate.JwtToken, error) {
 	// Verify token and extract competition ID
-	competitionID, err := s.verifyRefereeInvitationToken(token)
+	competitionID, invitationID, err := s.verifyInvitationToken(ctx, token, role)
 	if err != nil {
 		return nil, err
 	}
@@ -534,34 +699,40 @@ func (s *UserService) AcceptRefereeInvitationUnauthenticated(ctx context.Context
 		if err := bcrypt.CompareHashAndPassword([]byte(existingUser.GetPasswordHash()), []byte(password)); err != nil {
 			return nil, ErrInvalidCredentials
 		}
+		if err := checkNotAlreadyMember(existingUser, role, competitionID); err != nil {
+			return nil, err
+		}
 
-		// Add referee role for the competition
-		newRole := fmt.Sprintf("referee:%d", competitionID)
+		// Add the invited role for the competition
+		newRole := fmt.Sprintf("%s:%d", role, competitionID)
 		existingUser.AddRole(newRole)
 
-		if len(existingUser.GetRoles()) >= 500 {
-			return nil, ErrMaximumRolesReached
-		}
-
 		// Save the changes
 		err = s.userRepo.UpdateUser(ctx, existingUser)
 		if err != nil {
-			return nil, fmt.Errorf("failed to add referee role: %w", err)
+			return nil, fmt.Errorf("failed to add %s role: %w", role, err)
 		}
+		s.recordRoleGranted(ctx, existingUser, newRole)
+		s.countInvitationAcceptance(ctx, competitionID, invitationID)
+		s.markRefereeInvitationAccepted(ctx, competitionID, existingUser.GetID(), role)
 
 		// Generate tokens for existing user
-		return s.generateTokens(existingUser)
+		return s.generateTokens(ctx, existingUser, "")
+	}
+
+	// User doesn't exist, check the chosen password against the policy before creating the user
+	if err := s.passwordPolicy.Validate(ctx, password); err != nil {
+		return nil, err
 	}
 
-	// User doesn't exist, create new user
 	user := aggregate.NewUser()
 	user.SetEmail(email)
 	user.SetFirstName(firstName)
 	user.SetLastName(lastName)
 
-	// Set referee role for the specified competition
-	role := fmt.Sprintf("referee:%d", competitionID)
-	user.SetRoles(role)
+	// Set the invited role for the specified competition
+	newRole := fmt.Sprintf("%s:%d", role, competitionID)
+	user.SetRoles(newRole)
 
 	// Hash the password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -575,7 +746,24 @@ func (s *UserService) AcceptRefereeInvitationUnauthenticated(ctx context.Context
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	s.recordRoleGranted(ctx, user, newRole)
+	s.countInvitationAcceptance(ctx, competitionID, invitationID)
+	s.markRefereeInvitationAccepted(ctx, competitionID, user.GetID(), role)
 
 	// Generate tokens for new user
-	return s.generateTokens(user)
+	return s.generateTokens(ctx, user, "")
+}
+
+// SearchUsers lists the users whose name or email contains the query
+func (s *UserService) SearchUsers(ctx context.Context, query string, pageNumber, pageSize int32) ([]*aggregate.User, int32, error) {
+	return s.userRepo.SearchUsers(ctx, strings.TrimSpace(query), pageNumber, pageSize)
+}
+
+// ListUserRoles lists the roles of a user
+func (s *UserService) ListUserRoles(ctx context.Context, userID int32) ([]*aggregate.UserRole, error) {
+	if _, err := s.userRepo.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.ListUserRoles(ctx, userID)
 }
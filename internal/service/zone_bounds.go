@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrInvalidZoneBounds is returned when a maximum of the bounds of a zone is negative
+	ErrInvalidZoneBounds = errors.New("zone bounds cannot be negative")
+	// ErrUnknownZone is returned when bounds are defined for a zone without any scale
+	ErrUnknownZone = errors.New("zone not found in the competition")
+)
+
+// SetZoneBounds sets the plausible values of the runs of a zone, zero maximums are not checked
+func (s *CompetitionService) SetZoneBounds(ctx context.Context, bounds *aggregate.ZoneBounds) error {
+	if bounds.GetMaxChronoSec() < 0 || bounds.GetMaxPenality() < 0 {
+		return ErrInvalidZoneBounds
+	}
+
+	zones, err := s.ListZones(ctx, bounds.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+
+	zone := strings.TrimSpace(bounds.GetZone())
+	for _, zoneInfo := range zones {
+		if zoneInfo.GetZone() == zone {
+			bounds.SetZone(zone)
+			return s.zoneBoundsRepo.SetZoneBounds(ctx, bounds)
+		}
+	}
+
+	return ErrUnknownZone
+}
+
+// ListZoneBounds lists the bounds of the zones of a competition, zones without bounds are left out
+func (s *CompetitionService) ListZoneBounds(ctx context.Context, competitionID int32) ([]*aggregate.ZoneBounds, error) {
+	if _, err := s.competitionRepo.GetCompetition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+
+	return s.zoneBoundsRepo.ListZoneBounds(ctx, competitionID)
+}
+
+// CheckRunBounds returns the values of the run outside the bounds of its zone, none when the zone has no bounds
+func (s *RunService) CheckRunBounds(ctx context.Context, run *aggregate.Run) ([]aggregate.RunWarning, error) {
+	boundsList, err := s.zoneBoundsRepo.ListZoneBounds(ctx, run.GetCompetitionID())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bounds := range boundsList {
+		if bounds.GetZone() == run.GetZone() {
+			return bounds.CheckRun(run), nil
+		}
+	}
+
+	return []aggregate.RunWarning{}, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/NiskuT/cross-api/internal/domain/aggregate"
+)
+
+var (
+	// ErrEmptyZoneName is returned when a zone is described without its name
+	ErrEmptyZoneName = errors.New("the zone name is required")
+	// ErrInvalidZoneCoordinates is returned when the GPS coordinates of a zone are out of range
+	ErrInvalidZoneCoordinates = errors.New("invalid coordinates: the latitude must be between -90 and 90 and the longitude between -180 and 180")
+	// ErrInvalidZonePhotoURL is returned when the photo of a zone is not an absolute http or https URL
+	ErrInvalidZonePhotoURL = errors.New("invalid photo URL: expected an absolute http or https URL")
+	// ErrZoneInUse is returned when deleting a zone the scales of the competition still reference
+	ErrZoneInUse = errors.New("the zone is used by scales of the competition, delete them first")
+)
+
+// CreateZone describes a new zone of a competition, it can be described before its scales are added
+func (s *CompetitionService) CreateZone(ctx context.Context, zone *aggregate.Zone) error {
+	if err := validateZone(zone); err != nil {
+		return err
+	}
+
+	// Check if competition exists
+	_, err := s.competitionRepo.GetCompetition(ctx, zone.GetCompetitionID())
+	if err != nil {
+		return err
+	}
+
+	return s.zoneRepo.CreateZone(ctx, zone)
+}
+
+// GetZone returns the description of a zone of a competition
+func (s *CompetitionService) GetZone(ctx context.Context, competitionID int32, zone string) (*aggregate.Zone, error) {
+	return s.zoneRepo.GetZone(ctx, competitionID, zone)
+}
+
+// ListZoneDetails lists the descriptions of the zones of a competition, including those without scales yet
+func (s *CompetitionService) ListZoneDetails(ctx context.Context, competitionID int32) ([]*aggregate.Zone, error) {
+	// Verify the competition exists
+	_, err := s.competitionRepo.GetCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.zoneRepo.ListZones(ctx, competitionID)
+}
+
+// UpdateZone updates the description of a zone, its name cannot change as the scales reference it by name
+func (s *CompetitionService) UpdateZone(ctx context.Context, zone *aggregate.Zone) error {
+	if err := validateZone(zone); err != nil {
+		return err
+	}
+
+	return s.zoneRepo.UpdateZone(ctx, zone)
+}
+
+// SetZoneOpen opens or closes a zone of the competition to new runs, e.g. once its judging ends, and returns the zone
+func (s *CompetitionService) SetZoneOpen(ctx context.Context, competitionID int32, zone string, open bool) (*aggregate.Zone, error) {
+	if err := s.zoneRepo.SetZoneOpen(ctx, competitionID, zone, open); err != nil {
+		return nil, err
+	}
+
+	return s.zoneRepo.GetZone(ctx, competitionID, zone)
+}
+
+// DeleteZone deletes the description of a zone no scale of the competition references anymore
+func (s *CompetitionService) DeleteZone(ctx context.Context, competitionID int32, zone string) error {
+	scaledZones, err := s.scaleRepo.ListZones(ctx, competitionID)
+	if err != nil {
+		return err
+	}
+	for _, scaledZone := range scaledZones {
+		if scaledZone.GetZone() == zone {
+			return ErrZoneInUse
+		}
+	}
+
+	return s.zoneRepo.DeleteZone(ctx, competitionID, zone)
+}
+
+// validateZone trims the texts of the zone and checks its coordinates and photo URL
+func validateZone(zone *aggregate.Zone) error {
+	zone.SetZone(strings.TrimSpace(zone.GetZone()))
+	if zone.GetZone() == "" {
+		return ErrEmptyZoneName
+	}
+	zone.SetDescription(strings.TrimSpace(zone.GetDescription()))
+	zone.SetLocation(strings.TrimSpace(zone.GetLocation()))
+
+	if latitude, longitude, ok := zone.GetCoordinates(); ok {
+		if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+			return ErrInvalidZoneCoordinates
+		}
+	}
+
+	zone.SetPhotoURL(strings.TrimSpace(zone.GetPhotoURL()))
+	if zone.GetPhotoURL() != "" {
+		photoURL, err := url.Parse(zone.GetPhotoURL())
+		if err != nil || (photoURL.Scheme != "http" && photoURL.Scheme != "https") || photoURL.Host == "" {
+			return ErrInvalidZonePhotoURL
+		}
+	}
+
+	return nil
+}